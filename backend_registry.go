@@ -0,0 +1,77 @@
+package beads
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/configfile"
+)
+
+// BackendFactory opens the storage of a workspace whose metadata.json selects
+// the backend the factory was registered under. beadsDir is the workspace's
+// .beads directory; the factory reads whatever connection settings it needs
+// from there.
+type BackendFactory func(ctx context.Context, beadsDir string) (Storage, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[string]BackendFactory)
+)
+
+// RegisterBackend makes a storage implementation available to OpenBestAvailable
+// and OpenFromConfig for workspaces whose metadata.json has "backend": name.
+// It lets programs embedding beads supply their own storage without patching
+// backend selection. Call it from an init function.
+//
+// Like database/sql.Register, it panics if factory is nil, name is empty or
+// "dolt" (the built-in backend), or name is already registered. Registering a
+// removed backend name (postgres, mysql, sqlite) replaces its fail-closed error
+// with the factory.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	if factory == nil {
+		panic("beads: RegisterBackend factory is nil")
+	}
+	if name == "" || name == configfile.BackendDolt {
+		panic(fmt.Sprintf("beads: RegisterBackend cannot replace built-in backend %q", name))
+	}
+	if _, dup := backends[name]; dup {
+		panic(fmt.Sprintf("beads: RegisterBackend called twice for backend %q", name))
+	}
+	backends[name] = factory
+}
+
+// RegisteredBackends returns the names of the backends added with
+// RegisterBackend, sorted.
+func RegisteredBackends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// openRegisteredBackend opens beadsDir with the registered factory for the
+// configured backend. ok is false when no factory is registered for it.
+func openRegisteredBackend(ctx context.Context, beadsDir string, cfg *configfile.Config) (store Storage, ok bool, err error) {
+	if cfg == nil {
+		return nil, false, nil
+	}
+	backendsMu.RLock()
+	factory, ok := backends[cfg.Backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+	store, err = factory(ctx, beadsDir)
+	if err != nil {
+		return nil, true, fmt.Errorf("opening %q backend: %w", cfg.Backend, err)
+	}
+	return store, true, nil
+}
@@ -0,0 +1,78 @@
+package beads_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads"
+)
+
+func TestRegisterBackendOpensWithFactory(t *testing.T) {
+	const name = "test-registry-open"
+	errFactory := errors.New("factory called")
+	var gotDir string
+	beads.RegisterBackend(name, func(_ context.Context, beadsDir string) (beads.Storage, error) {
+		gotDir = beadsDir
+		return nil, errFactory
+	})
+	if !slices.Contains(beads.RegisteredBackends(), name) {
+		t.Fatalf("RegisteredBackends() = %v, want it to include %q", beads.RegisteredBackends(), name)
+	}
+
+	beadsDir := writeBackendMetadata(t, name)
+	for _, open := range []struct {
+		name string
+		fn   func(context.Context, string) (beads.Storage, error)
+	}{
+		{"OpenBestAvailable", beads.OpenBestAvailable},
+		{"OpenFromConfig", beads.OpenFromConfig},
+	} {
+		gotDir = ""
+		_, err := open.fn(context.Background(), beadsDir)
+		if !errors.Is(err, errFactory) {
+			t.Fatalf("%s error = %v, want factory error", open.name, err)
+		}
+		if gotDir != beadsDir {
+			t.Fatalf("%s passed %q to factory, want %q", open.name, gotDir, beadsDir)
+		}
+	}
+}
+
+func TestOpenBestAvailableRejectsUnregisteredBackend(t *testing.T) {
+	beadsDir := writeBackendMetadata(t, "test-registry-missing")
+	store, err := beads.OpenBestAvailable(context.Background(), beadsDir)
+	if store != nil {
+		_ = store.Close()
+		t.Fatal("unregistered backend returned a store")
+	}
+	if err == nil || !strings.Contains(err.Error(), "test-registry-missing") {
+		t.Fatalf("unregistered backend error = %v, want it to name the backend", err)
+	}
+}
+
+func TestRegisterBackendPanics(t *testing.T) {
+	factory := func(context.Context, string) (beads.Storage, error) { return nil, nil }
+	beads.RegisterBackend("test-registry-dup", factory)
+	for _, tc := range []struct {
+		name    string
+		backend string
+		factory beads.BackendFactory
+	}{
+		{"empty name", "", factory},
+		{"built-in dolt", "dolt", factory},
+		{"nil factory", "test-registry-nil", nil},
+		{"duplicate", "test-registry-dup", factory},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("RegisterBackend(%q) did not panic", tc.backend)
+				}
+			}()
+			beads.RegisterBackend(tc.backend, tc.factory)
+		})
+	}
+}
@@ -248,18 +248,32 @@ type (
 	StatusEntry = storage.StatusEntry
 )
 
+// MemoryDB is the database path that makes Open return an ephemeral store
+// (see OpenEphemeral).
+const MemoryDB = ":memory:"
+
 // Open opens a Dolt-backed beads database at the given path.
 // This always opens in embedded mode. Use OpenFromConfig to respect
-// server mode settings from metadata.json.
+// server mode settings from metadata.json. Passing MemoryDB opens an
+// ephemeral store with the default "bd" prefix.
 func Open(ctx context.Context, dbPath string) (Storage, error) {
+	if dbPath == MemoryDB {
+		return OpenEphemeral(ctx, "")
+	}
 	return dolt.New(ctx, &dolt.Config{Path: dbPath, CreateIfMissing: true})
 }
 
 // OpenFromConfig opens the Dolt implementation using configuration from
 // metadata.json. Unlike Open, this respects Dolt server mode settings and database
-// name configuration.
+// name configuration. A backend added with RegisterBackend is opened with its
+// factory instead.
 // beadsDir is the path to the .beads directory.
 func OpenFromConfig(ctx context.Context, beadsDir string) (Storage, error) {
+	if cfg, err := configfile.Load(beadsDir); err == nil {
+		if store, ok, err := openRegisteredBackend(ctx, beadsDir, cfg); ok {
+			return store, err
+		}
+	}
 	return dolt.NewFromConfigWithOptions(ctx, beadsDir, &dolt.Config{CreateIfMissing: true})
 }
 
@@ -29,6 +29,9 @@ func OpenBestAvailable(ctx context.Context, beadsDir string) (Storage, error) {
 	if cfg == nil {
 		cfg = configfile.DefaultConfig()
 	}
+	if store, ok, err := openRegisteredBackend(ctx, beadsDir, cfg); ok {
+		return store, err
+	}
 	if !configfile.IsSupportedBackend(cfg.Backend) {
 		return nil, configuredBackendUnavailable(cfg.Backend)
 	}
@@ -51,3 +54,16 @@ func OpenBestAvailable(ctx context.Context, beadsDir string) (Storage, error) {
 	}
 	return store, nil
 }
+
+// OpenEphemeral opens a throwaway database for tests, CI analysis jobs, and
+// agent sandboxes that need a transient issue graph. It is an embedded Dolt
+// store in a private temporary directory, initialized with the given issue
+// prefix ("bd" when empty); the directory is deleted when the store is
+// closed. Nothing is read from or written to any workspace.
+func OpenEphemeral(ctx context.Context, prefix string) (Storage, error) {
+	store, err := embeddeddolt.OpenEphemeral(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
@@ -23,6 +23,9 @@ func OpenBestAvailable(ctx context.Context, beadsDir string) (Storage, error) {
 	if cfg == nil {
 		cfg = configfile.DefaultConfig()
 	}
+	if store, ok, err := openRegisteredBackend(ctx, beadsDir, cfg); ok {
+		return store, err
+	}
 	if !configfile.IsSupportedBackend(cfg.Backend) {
 		return nil, configuredBackendUnavailable(cfg.Backend)
 	}
@@ -36,3 +39,9 @@ func OpenBestAvailable(ctx context.Context, beadsDir string) (Storage, error) {
 	}
 	return nil, fmt.Errorf("embedded Dolt requires CGO; use server mode (bd init --server)")
 }
+
+// OpenEphemeral opens a throwaway database. Ephemeral databases use embedded
+// Dolt, so non-CGO builds return an error.
+func OpenEphemeral(_ context.Context, _ string) (Storage, error) {
+	return nil, fmt.Errorf("ephemeral databases use embedded Dolt, which requires CGO")
+}
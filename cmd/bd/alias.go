@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:     "alias",
+	GroupID: "issues",
+	Short:   "Manage alternate IDs of issues",
+	Long: `Manage alternate IDs of issues.
+
+An alias is another name for an issue: a key in an external tracker
+(PROJ-123, gh-42) or an ID the issue had before it was renamed. Every command
+that takes an issue ID (show, update, close, dep add, ...) accepts an alias
+wherever it accepts the ID. An exact alias wins over a partial-ID match.
+
+bd rename and bd rename-prefix record the old ID as an alias automatically,
+so references in commit messages and other trackers keep resolving.
+
+Examples:
+  bd alias add bd-123 PROJ-456 gh-78   # Add aliases
+  bd alias list bd-123                 # List the aliases of an issue
+  bd show PROJ-456                     # Resolves to bd-123
+  bd alias remove PROJ-456             # Drop an alias`,
+}
+
+var aliasAddCmd = &cobra.Command{
+	Use:           "add <id> <alias>...",
+	Short:         "Add aliases to an issue",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("alias add")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("alias is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		result, aliasStore, err := resolveAliasIssue(ctx, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer result.Close()
+
+		actor := getActorWithGit()
+		added := make([]*types.IssueAlias, 0, len(args)-1)
+		for _, name := range args[1:] {
+			alias := &types.IssueAlias{Alias: name, IssueID: result.ResolvedID, CreatedBy: actor}
+			if err := aliasStore.AddIssueAlias(ctx, alias); err != nil {
+				return HandleErrorRespectJSON("adding alias %s: %v", name, err)
+			}
+			added = append(added, alias)
+		}
+		if err := commitPendingIfEmbedded(ctx, result.Store, actor, doltAutoCommitParams{
+			Command:  "alias add",
+			IssueIDs: []string{result.ResolvedID},
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(result.ResolvedID)
+
+		if jsonOutput {
+			return outputJSON(added)
+		}
+		fmt.Printf("%s Added %s to %s\n", ui.RenderPass("✓"), strings.Join(args[1:], ", "),
+			formatFeedbackID(result.ResolvedID, result.Issue.Title))
+		return nil
+	},
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:           "remove <alias>...",
+	Aliases:       []string{"rm"},
+	Short:         "Remove aliases",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("alias remove")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("alias is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		aliasStore, ok := storage.UnwrapStore(store).(storage.AliasStore)
+		if !ok {
+			return HandleErrorRespectJSON("storage backend does not support aliases")
+		}
+		issueIDs := make([]string, 0, len(args))
+		for _, name := range args {
+			issueID, err := aliasStore.ResolveIssueAlias(ctx, name)
+			if err != nil {
+				return HandleErrorRespectJSON("%s is not an alias", name)
+			}
+			if err := aliasStore.RemoveIssueAlias(ctx, name); err != nil {
+				return HandleErrorRespectJSON("removing alias %s: %v", name, err)
+			}
+			issueIDs = append(issueIDs, issueID)
+		}
+		if err := commitPendingIfEmbedded(ctx, store, getActor(), doltAutoCommitParams{
+			Command:  "alias remove",
+			IssueIDs: issueIDs,
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"removed": args})
+		}
+		for i, name := range args {
+			fmt.Printf("%s Removed alias %s of %s\n", ui.RenderPass("✓"), name, issueIDs[i])
+		}
+		return nil
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:           "list <id>",
+	Short:         "List the aliases of an issue",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("alias is not supported in proxied-server mode")
+		}
+		result, aliasStore, err := resolveAliasIssue(rootCtx, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer result.Close()
+		byIssue, err := aliasStore.GetIssueAliases(rootCtx, []string{result.ResolvedID})
+		if err != nil {
+			return HandleErrorRespectJSON("listing aliases: %v", err)
+		}
+		list := byIssue[result.ResolvedID]
+
+		if jsonOutput {
+			if list == nil {
+				list = []*types.IssueAlias{}
+			}
+			return outputJSON(list)
+		}
+		if len(list) == 0 {
+			fmt.Printf("No aliases for %s\n", result.ResolvedID)
+			return nil
+		}
+		for _, a := range list {
+			meta := a.CreatedAt.Local().Format("2006-01-02")
+			if a.CreatedBy != "" {
+				meta += " " + a.CreatedBy
+			}
+			fmt.Printf("  %s  %s\n", a.Alias, ui.RenderMuted(meta))
+		}
+		return nil
+	},
+}
+
+// resolveAliasIssue resolves id, writable, and returns the store holding it
+// as an AliasStore. The caller must Close the result.
+func resolveAliasIssue(ctx context.Context, id string) (*RoutedResult, storage.AliasStore, error) {
+	result, err := resolveAndGetIssueForMutation(ctx, store, id)
+	if err != nil {
+		if result != nil {
+			result.Close()
+		}
+		return nil, nil, fmt.Errorf("resolving %s: %w", id, err)
+	}
+	if result == nil || result.Issue == nil {
+		if result != nil {
+			result.Close()
+		}
+		return nil, nil, fmt.Errorf("issue %s not found", id)
+	}
+	aliasStore, ok := storage.UnwrapStore(result.Store).(storage.AliasStore)
+	if !ok {
+		result.Close()
+		return nil, nil, fmt.Errorf("storage backend does not support aliases")
+	}
+	return result, aliasStore, nil
+}
+
+// getIssueAliases returns the aliases of issueID, or nil when the store does
+// not support them. Best effort, like the other show sections.
+func getIssueAliases(ctx context.Context, st storage.DoltStorage, issueID string) []string {
+	aliasStore, ok := storage.UnwrapStore(st).(storage.AliasStore)
+	if !ok {
+		return nil
+	}
+	byIssue, err := aliasStore.GetIssueAliases(ctx, []string{issueID})
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(byIssue[issueID]))
+	for _, a := range byIssue[issueID] {
+		names = append(names, a.Alias)
+	}
+	return names
+}
+
+func init() {
+	aliasAddCmd.ValidArgsFunction = issueIDCompletion
+	aliasListCmd.ValidArgsFunction = issueIDCompletion
+	aliasCmd.AddCommand(aliasAddCmd)
+	aliasCmd.AddCommand(aliasRemoveCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
@@ -0,0 +1,114 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// bdAliasFail runs "bd alias" expecting failure.
+func bdAliasFail(t *testing.T, bd, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(bd, append([]string{"alias"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = bdEnv(dir)
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected bd alias %s to fail, but succeeded:\n%s", strings.Join(args, " "), out)
+	}
+	return string(out)
+}
+
+func TestEmbeddedAlias(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "al")
+	target := bdCreateSilent(t, bd, dir, "Aliased issue")
+	other := bdCreateSilent(t, bd, dir, "Other issue")
+
+	showDetails := func(t *testing.T, id string) types.IssueDetails {
+		t.Helper()
+		var details types.IssueDetails
+		if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, id)), &details); err != nil {
+			t.Fatal(err)
+		}
+		return details
+	}
+
+	bdCommand(t, bd, dir, "alias", "add", target, "PROJ-12", "gh-7")
+
+	t.Run("lookup_paths_resolve_aliases", func(t *testing.T) {
+		details := showDetails(t, "PROJ-12")
+		if details.ID != target {
+			t.Fatalf("show PROJ-12 = %s, want %s", details.ID, target)
+		}
+		if want := []string{"PROJ-12", "gh-7"}; !reflect.DeepEqual(details.Aliases, want) {
+			t.Errorf("aliases = %v, want %v", details.Aliases, want)
+		}
+
+		bdCommand(t, bd, dir, "update", "gh-7", "--priority", "0")
+		if got := bdShow(t, bd, dir, target); got.Priority != 0 {
+			t.Errorf("priority after update via alias = %d, want 0", got.Priority)
+		}
+
+		bdDepAdd(t, bd, dir, other, "PROJ-12")
+		deps := showDetails(t, other).Dependencies
+		if len(deps) != 1 || deps[0].ID != target {
+			t.Errorf("dependencies of %s = %+v, want %s", other, deps, target)
+		}
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		if out := bdAliasFail(t, bd, dir, "add", other, "PROJ-12"); !strings.Contains(out, "already refers to "+target) {
+			t.Errorf("duplicate alias output = %s", out)
+		}
+		if out := bdAliasFail(t, bd, dir, "add", target, other); !strings.Contains(out, "already an issue ID") {
+			t.Errorf("issue ID alias output = %s", out)
+		}
+		// Re-adding an alias to its own issue is a no-op.
+		bdCommand(t, bd, dir, "alias", "add", target, "PROJ-12")
+	})
+
+	t.Run("rename_records_old_id", func(t *testing.T) {
+		bdCommand(t, bd, dir, "rename", target, "al-renamed")
+		for _, id := range []string{target, "PROJ-12"} {
+			if got := showDetails(t, id).ID; got != "al-renamed" {
+				t.Errorf("show %s = %s, want al-renamed", id, got)
+			}
+		}
+		out := bdCommand(t, bd, dir, "alias", "list", "al-renamed", "--json")
+		var list []*types.IssueAlias
+		if err := json.Unmarshal([]byte(out), &list); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if len(list) != 3 {
+			t.Errorf("aliases after rename = %s", out)
+		}
+
+		var lookup remapLookupResult
+		out = bdCommand(t, bd, dir, "remap", "lookup", "gh-7", "--json")
+		if err := json.Unmarshal([]byte(out), &lookup); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if !lookup.Alias || lookup.CurrentID != "al-renamed" {
+			t.Errorf("remap lookup gh-7 = %+v", lookup)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		bdCommand(t, bd, dir, "alias", "remove", "gh-7")
+		bdShowFail(t, bd, dir, "gh-7")
+		bdAliasFail(t, bd, dir, "remove", "gh-7")
+	})
+}
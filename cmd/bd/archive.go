@@ -0,0 +1,581 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// archiveFileName is the cold-storage file under .beads/ that `bd archive`
+// moves old closed issues into and `bd unarchive` restores them from.
+const archiveFileName = "archive.jsonl"
+
+// archiveRecord is one archived issue: the export record (labels,
+// dependencies, comments, attachments) plus the issue's event history and
+// when it was archived. It stays readable by parseJSONLFile, which ignores
+// the extra keys.
+type archiveRecord struct {
+	RecordType string `json:"_type"`
+	*types.Issue
+	Events     []*types.Event `json:"events,omitempty"`
+	ArchivedAt time.Time      `json:"archived_at"`
+}
+
+var archiveCmd = &cobra.Command{
+	Use:     "archive",
+	GroupID: "maint",
+	Short:   "Move old closed beads into .beads/archive.jsonl",
+	Long: `Move closed beads out of the database into cold storage.
+
+Long-lived repos accumulate years of closed work that every query still has to
+scan. Archiving moves closed beads older than --closed-before into
+.beads/archive.jsonl, together with their labels, dependencies, comments,
+attachments, and events, and deletes them from the database. Archived beads no
+longer appear in list, ready, search, or exports; find them with
+` + "`bd search --archived`" + ` and bring one back with ` + "`bd unarchive <id>`" + `.
+
+Skips: pinned beads, ephemeral beads, and closed beads that a bead staying in
+the database still depends on (archiving them would orphan the edge).
+
+Commit .beads/archive.jsonl alongside the rest of .beads/ to share it; Dolt
+history also keeps the archived rows, but 'bd trash' does not offer them.
+
+EXAMPLES:
+  bd archive --closed-before 2024-01-01 --dry-run   # Preview
+  bd archive --closed-before 2024-01-01             # Archive
+  bd archive --closed-before -180d                  # Closed more than 180 days ago`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runArchive,
+}
+
+var unarchiveCmd = &cobra.Command{
+	Use:     "unarchive <id> [<id>...]",
+	GroupID: "maint",
+	Short:   "Restore archived beads from .beads/archive.jsonl",
+	Long: `Restore beads moved to cold storage by 'bd archive'.
+
+Each bead comes back with its labels, comments, attachments, and the
+dependencies it owns; it is removed from .beads/archive.jsonl. Dependencies on
+beads that are still archived are skipped and reported. The event history kept
+in the archive is restored with its original timestamps, so 'bd history' and
+'bd show --full' read as before the bead was archived.
+
+EXAMPLES:
+  bd unarchive bd-42
+  bd unarchive bd-42 bd-43 --json`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runUnarchive,
+}
+
+func init() {
+	archiveCmd.Flags().String("closed-before", "", "Archive beads closed before this date (YYYY-MM-DD, RFC3339, or relative like -90d)")
+	archiveCmd.Flags().Bool("dry-run", false, "Show what would be archived without changing anything")
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(unarchiveCmd)
+}
+
+func runArchive(cmd *cobra.Command, _ []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("archive is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("archive")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	closedBefore, _ := cmd.Flags().GetString("closed-before")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if closedBefore == "" {
+		return HandleErrorWithHint("bd archive requires --closed-before",
+			"Pass a date (e.g. --closed-before 2024-01-01) or a relative age (--closed-before -180d).")
+	}
+	cutoff, err := parseTimeFlag(closedBefore)
+	if err != nil {
+		return HandleErrorRespectJSON("parsing --closed-before: %v", err)
+	}
+	if !dryRun {
+		CheckReadonly("archive")
+	}
+
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return HandleErrorRespectJSON("no .beads directory found")
+	}
+	if store == nil {
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+	}
+	ctx := rootCtx
+
+	statusClosed := types.StatusClosed
+	persistentOnly := false
+	candidates, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		Status:       &statusClosed,
+		Ephemeral:    &persistentOnly,
+		ClosedBefore: &cutoff,
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("listing issues: %v", err)
+	}
+	candidates, safetyStats := filterClosedDeletionCandidates(candidates, &cutoff)
+
+	// Drop candidates that a bead staying behind depends on, repeating until
+	// stable: dropping one candidate can strand the candidates it depends on.
+	inSet := make(map[string]bool, len(candidates))
+	for _, issue := range candidates {
+		inSet[issue.ID] = true
+	}
+	dependents := make(map[string][]*types.Issue, len(candidates))
+	for _, issue := range candidates {
+		deps, err := store.GetDependents(ctx, issue.ID)
+		if err != nil {
+			return HandleErrorRespectJSON("checking dependents of %s: %v", issue.ID, err)
+		}
+		dependents[issue.ID] = deps
+	}
+	var dependedOn []string
+	for changed := true; changed; {
+		changed = false
+		for _, issue := range candidates {
+			if !inSet[issue.ID] {
+				continue
+			}
+			for _, dep := range dependents[issue.ID] {
+				if !inSet[dep.ID] {
+					inSet[issue.ID] = false
+					dependedOn = append(dependedOn, issue.ID)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	kept := candidates[:0]
+	for _, issue := range candidates {
+		if inSet[issue.ID] {
+			kept = append(kept, issue)
+		}
+	}
+	candidates = kept
+	sort.Strings(dependedOn)
+
+	archivePath := filepath.Join(beadsDir, archiveFileName)
+	ids := make([]string, len(candidates))
+	for i, issue := range candidates {
+		ids[i] = issue.ID
+	}
+
+	if dryRun || len(candidates) == 0 {
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"dry_run":           dryRun,
+				"archive_count":     len(ids),
+				"ids":               ids,
+				"pinned_skipped":    safetyStats.PinnedSkipped,
+				"dependent_skipped": dependedOn,
+				"archive_path":      archivePath,
+			})
+		}
+		if len(candidates) == 0 {
+			fmt.Printf("No closed beads to archive (closed before %s)\n", cutoff.Format("2006-01-02"))
+		} else {
+			fmt.Printf("Would archive %d closed bead(s) to %s\n", len(ids), archivePath)
+			for _, issue := range candidates {
+				fmt.Printf("  %s %s\n", ui.IDStyle.Render(issue.ID), issue.Title)
+			}
+		}
+		printArchiveSkips(safetyStats.PinnedSkipped, dependedOn)
+		if dryRun {
+			fmt.Printf("\n(Dry-run mode — no changes made)\n")
+		}
+		return nil
+	}
+
+	labelsMap, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return HandleErrorRespectJSON("loading labels: %v", err)
+	}
+	depsMap, err := store.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return HandleErrorRespectJSON("loading dependencies: %v", err)
+	}
+	commentsMap, err := store.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return HandleErrorRespectJSON("loading comments: %v", err)
+	}
+	attachmentsMap := getAttachmentsForIssues(ctx, store, ids)
+
+	existing, err := readArchive(archivePath)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	now := time.Now().UTC()
+	records := make([]*archiveRecord, 0, len(existing)+len(candidates))
+	for _, rec := range existing {
+		if !inSet[rec.ID] {
+			records = append(records, rec)
+		}
+	}
+	for _, issue := range candidates {
+		events, err := store.GetEvents(ctx, issue.ID, 0)
+		if err != nil {
+			return HandleErrorRespectJSON("loading events of %s: %v", issue.ID, err)
+		}
+		issue.Labels = labelsMap[issue.ID]
+		issue.Dependencies = depsMap[issue.ID]
+		issue.Comments = commentsMap[issue.ID]
+		issue.Attachments = attachmentsMap[issue.ID]
+		sanitizeZeroTime(issue)
+		records = append(records, &archiveRecord{RecordType: "issue", Issue: issue, Events: events, ArchivedAt: now})
+	}
+
+	// Write the archive before deleting so a failure never loses a bead; if
+	// the delete fails, put the previous archive back.
+	if err := writeArchive(archivePath, records); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	result, err := store.DeleteIssues(ctx, ids, false, false, false)
+	if err != nil {
+		if restoreErr := writeArchive(archivePath, existing); restoreErr != nil {
+			WarnError("restoring %s: %v", archivePath, restoreErr)
+		}
+		return HandleErrorRespectJSON("archive failed: %v", err)
+	}
+
+	commandDidWrite.Store(true)
+	if result.DeletedCount > 0 {
+		commandMayEmptyJSONLExport.Store(true)
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"archived_count":    result.DeletedCount,
+			"ids":               ids,
+			"pinned_skipped":    safetyStats.PinnedSkipped,
+			"dependent_skipped": dependedOn,
+			"archive_path":      archivePath,
+		})
+	}
+	fmt.Printf("%s Archived %d closed bead(s) to %s\n", ui.RenderPass("✓"), result.DeletedCount, archivePath)
+	printArchiveSkips(safetyStats.PinnedSkipped, dependedOn)
+	return nil
+}
+
+// printArchiveSkips reports the closed beads bd archive left in the database.
+func printArchiveSkips(pinned int, dependedOn []string) {
+	if pinned > 0 {
+		fmt.Printf("  Pinned (skipped):       %d\n", pinned)
+	}
+	if len(dependedOn) > 0 {
+		fmt.Printf("  %s %d (%s)\n", ui.MutedStyle.Render("Depended on (skipped):"), len(dependedOn), strings.Join(dependedOn, ", "))
+	}
+}
+
+func runUnarchive(_ *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("unarchive is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("unarchive")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	CheckReadonly("unarchive")
+
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return HandleErrorRespectJSON("no .beads directory found")
+	}
+	if store == nil {
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+	}
+	ctx := rootCtx
+
+	archivePath := filepath.Join(beadsDir, archiveFileName)
+	records, err := readArchive(archivePath)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	wanted := make(map[string]bool, len(args))
+	for _, id := range args {
+		wanted[id] = true
+	}
+	var restore []*types.Issue
+	var events []*types.Event
+	var remaining []*archiveRecord
+	for _, rec := range records {
+		if wanted[rec.ID] {
+			restore = append(restore, rec.Issue)
+			events = append(events, rec.Events...)
+			delete(wanted, rec.ID)
+		} else {
+			remaining = append(remaining, rec)
+		}
+	}
+	if len(wanted) > 0 {
+		var missing []string
+		for id := range wanted {
+			missing = append(missing, id)
+		}
+		sort.Strings(missing)
+		return HandleErrorRespectJSON("not in %s: %s", archivePath, strings.Join(missing, ", "))
+	}
+	for _, issue := range restore {
+		if existing, err := store.GetIssue(ctx, issue.ID); err == nil && existing != nil {
+			return HandleErrorRespectJSON("%s already exists in the database; remove it from %s by hand if the archived copy is stale", issue.ID, archivePath)
+		}
+	}
+
+	importer, ok := storage.UnwrapStore(store).(storage.EventImporter)
+	if !ok && len(events) > 0 {
+		return HandleErrorRespectJSON("storage backend cannot restore event history")
+	}
+
+	result, err := importIssuesCore(ctx, "", store, restore, ImportOptions{
+		SkipPrefixValidation: true,
+		ConflictSkip:         true,
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("unarchive failed: %v", err)
+	}
+	commandDidWrite.Store(true)
+	if len(events) > 0 {
+		// The archive is only rewritten once the history is back, so a
+		// failure here leaves it in the archive file.
+		if err := importer.ImportEvents(ctx, events); err != nil {
+			return HandleErrorRespectJSON("restoring event history: %v (the beads were restored; %s still holds their history)", err, archivePath)
+		}
+	}
+	if err := writeArchive(archivePath, remaining); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"unarchived_count":     result.Created,
+			"ids":                  result.ImportedIDs,
+			"restored_events":      len(events),
+			"skipped_dependencies": result.SkippedDependencies,
+		})
+	}
+	fmt.Printf("%s Unarchived %d bead(s) with %d event(s) of history\n", ui.RenderPass("✓"), result.Created, len(events))
+	for _, skipped := range result.SkippedDependencies {
+		WarnError("dependency not restored: %s", skipped)
+	}
+	return nil
+}
+
+// readArchive loads every record from the archive file. A missing file is an
+// empty archive.
+func readArchive(path string) ([]*archiveRecord, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the .beads directory
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	// Allow up to 64MB per line for large descriptions
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	var records []*archiveRecord
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if hasConflictMarkerPrefix(string(line)) {
+			return nil, jsonlConflictMarkerError(path, lineNo)
+		}
+		rec := &archiveRecord{}
+		if err := json.Unmarshal(line, rec); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		if rec.Issue == nil || rec.ID == "" {
+			return nil, fmt.Errorf("%s:%d: record has no issue id", path, lineNo)
+		}
+		rec.SetDefaults()
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// writeArchive atomically replaces the archive file with records, sorted by
+// ID so the file diffs cleanly in git.
+func writeArchive(path string, records []*archiveRecord) error {
+	sort.SliceStable(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	var buf bytes.Buffer
+	for _, rec := range records {
+		data, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("marshaling archived issue %s: %w", rec.ID, err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if err := atomicfile.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// runArchivedSearch is `bd search --archived`: the query, --type, --assignee,
+// and --label filters applied to .beads/archive.jsonl instead of the database.
+func runArchivedSearch(cmd *cobra.Command, args []string) error {
+	queryFlag, _ := cmd.Flags().GetString("query")
+	query := strings.Join(args, " ")
+	if query == "" {
+		query = queryFlag
+	}
+	if query == "" {
+		return HandleError("search query is required")
+	}
+	status, _ := cmd.Flags().GetString("status")
+	assignee, _ := cmd.Flags().GetString("assignee")
+	issueType, _ := cmd.Flags().GetString("type")
+	labels, _ := cmd.Flags().GetStringSlice("label")
+	labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+	limit, _ := cmd.Flags().GetInt("limit")
+	longFormat, _ := cmd.Flags().GetBool("long")
+	sortBy, _ := cmd.Flags().GetString("sort")
+	reverse, _ := cmd.Flags().GetBool("reverse")
+
+	text, err := parseSearchText(query)
+	if err != nil {
+		return HandleError("%v", err)
+	}
+	if err := text.mergeFlags(&status, &issueType, &assignee, &labels); err != nil {
+		return HandleError("%v", err)
+	}
+
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return HandleError("no .beads directory found")
+	}
+	records, err := readArchive(filepath.Join(beadsDir, archiveFileName))
+	if err != nil {
+		return HandleError("%v", err)
+	}
+
+	var issues []*types.Issue
+	for _, rec := range records {
+		issue := rec.Issue
+		if text.IDLookup && !strings.HasPrefix(issue.ID, strings.TrimSpace(query)) {
+			continue
+		}
+		if !archivedIssueMatchesTerms(issue, text.Terms) {
+			continue
+		}
+		if issueType != "" && string(issue.IssueType) != issueType {
+			continue
+		}
+		if assignee != "" && issue.Assignee != assignee {
+			continue
+		}
+		if !hasAllLabels(issue.Labels, labels) || (len(labelsAny) > 0 && !hasAnyLabel(issue.Labels, labelsAny)) {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+
+	var hits map[string]searchHit
+	if text.rankedSearch(sortBy) {
+		hits = rankSearchResults(issues, text.Terms)
+	} else {
+		sortIssues(issues, sortBy, reverse)
+	}
+	if limit > 0 && len(issues) > limit {
+		issues = issues[:limit]
+	}
+
+	if jsonOutput {
+		out := make([]*types.IssueWithCounts, len(issues))
+		for i, issue := range issues {
+			out[i] = &types.IssueWithCounts{
+				Issue:           issue,
+				DependencyCount: len(issue.Dependencies),
+				CommentCount:    len(issue.Comments),
+			}
+		}
+		return outputJSON(out)
+	}
+	outputSearchResults(issues, query, longFormat, hits)
+	return nil
+}
+
+// archivedIssueMatchesTerms reports whether every term appears in its field
+// of issue (any field, comment, or the ID for an unscoped term), matching
+// the database text search case-insensitively.
+func archivedIssueMatchesTerms(issue *types.Issue, terms []types.TextTerm) bool {
+	for _, term := range terms {
+		needle := strings.ToLower(term.Text)
+		contains := func(s string) bool { return strings.Contains(strings.ToLower(s), needle) }
+		found := term.Field == types.TextFieldAny && contains(issue.ID)
+		for _, f := range searchFieldWeights {
+			if !found && (term.Field == types.TextFieldAny || term.Field == f.field) {
+				found = contains(f.text(issue))
+			}
+		}
+		if !found && (term.Field == types.TextFieldAny || term.Field == types.TextFieldComments) {
+			for _, c := range issue.Comments {
+				if contains(c.Text) {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAllLabels(have, want []string) bool {
+	for _, w := range want {
+		if !hasAnyLabel(have, []string{w}) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnyLabel(have, want []string) bool {
+	for _, h := range have {
+		for _, w := range want {
+			if h == w {
+				return true
+			}
+		}
+	}
+	return false
+}
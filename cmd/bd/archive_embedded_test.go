@@ -0,0 +1,98 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// bdArchiveCmd runs a bd subcommand that must succeed and returns its output.
+func bdArchiveCmd(t *testing.T, bd, dir string, args ...string) string {
+	t.Helper()
+	out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+	if err != nil {
+		t.Fatalf("bd %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func TestEmbeddedArchive(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+
+	t.Run("archive_search_unarchive", func(t *testing.T) {
+		dir, beadsDir, _ := bdInit(t, bd, "--prefix", "ar")
+		old := bdCreate(t, bd, dir, "Legacy migration notes", "--labels", "history")
+		bdClose(t, bd, dir, old.ID)
+		needed := createAndClose(t, bd, dir, "Closed but still depended on")
+		open := bdCreate(t, bd, dir, "Open follow-up")
+		bdDepAdd(t, bd, dir, open.ID, needed)
+
+		out := bdArchiveCmd(t, bd, dir, "archive", "--closed-before", "+1d", "--dry-run")
+		if !strings.Contains(out, old.ID) || !strings.Contains(out, "Dry-run") {
+			t.Fatalf("dry run should list %s, got:\n%s", old.ID, out)
+		}
+		if _, err := os.Stat(filepath.Join(beadsDir, archiveFileName)); !os.IsNotExist(err) {
+			t.Fatalf("dry run must not write the archive (stat err = %v)", err)
+		}
+
+		out = bdArchiveCmd(t, bd, dir, "archive", "--closed-before", "+1d")
+		if !strings.Contains(out, "Archived 1 closed bead(s)") || !strings.Contains(out, needed) {
+			t.Fatalf("expected one archived bead and %s skipped as depended on, got:\n%s", needed, out)
+		}
+
+		listing := bdList(t, bd, dir, "--status=closed", "--json")
+		if strings.Contains(listing, old.ID) {
+			t.Errorf("archived bead %s still listed:\n%s", old.ID, listing)
+		}
+		if !strings.Contains(listing, needed) {
+			t.Errorf("depended-on bead %s must stay in the database:\n%s", needed, listing)
+		}
+
+		hits := bdSearchJSON(t, bd, dir, "migration", "--archived")
+		if len(hits) != 1 || hits[0]["id"] != old.ID {
+			t.Fatalf("search --archived migration = %v, want %s", hits, old.ID)
+		}
+		if hits := bdSearchJSON(t, bd, dir, "migration", "--status", "all"); len(hits) != 0 {
+			t.Errorf("default search must exclude archived beads, got %v", hits)
+		}
+
+		// Archived beads are out of the database but not deleted: the
+		// trash neither lists nor restores them.
+		if out := bdArchiveCmd(t, bd, dir, "trash", "list", "--json"); strings.Contains(out, old.ID) {
+			t.Errorf("trash list shows archived bead %s:\n%s", old.ID, out)
+		}
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "trash", "restore", old.ID); err == nil || !strings.Contains(string(out), "bd unarchive") {
+			t.Errorf("trash restore of archived bead %s: want refusal, got err=%v\n%s", old.ID, err, out)
+		}
+
+		archived, err := readArchive(filepath.Join(beadsDir, archiveFileName))
+		if err != nil || len(archived) != 1 || len(archived[0].Events) == 0 {
+			t.Fatalf("archive = %v (err %v), want one record with its events", archived, err)
+		}
+		archivedEvent := archived[0].Events[0]
+
+		bdArchiveCmd(t, bd, dir, "unarchive", old.ID)
+		if out := bdArchiveCmd(t, bd, dir, "show", old.ID, "--full", "--json"); !strings.Contains(out, archivedEvent.ID) {
+			t.Errorf("restored history lacks archived event %s (%s):\n%s", archivedEvent.ID, archivedEvent.EventType, out)
+		}
+		restored := bdShow(t, bd, dir, old.ID)
+		if restored.Title != "Legacy migration notes" || len(restored.Labels) != 1 || restored.Labels[0] != "history" {
+			t.Errorf("restored bead = %+v, want title and history label", restored)
+		}
+		data, err := os.ReadFile(filepath.Join(beadsDir, archiveFileName))
+		if err != nil {
+			t.Fatalf("read archive: %v", err)
+		}
+		if strings.Contains(string(data), old.ID) {
+			t.Errorf("unarchived bead still in archive:\n%s", data)
+		}
+	})
+}
@@ -1,26 +1,36 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
 var assignCmd = &cobra.Command{
-	Use:     "assign <id> <name>",
+	Use:     "assign <id> [name...]",
 	GroupID: "issues",
 	Short:   "Assign an issue to someone",
 	Long: `Assign an issue to someone.
 
-Shorthand for 'bd update <id> --assignee <name>'.
+The first name becomes the assignee, as with 'bd update <id> --assignee <name>'.
+Further names, or every name with --add, become co-assignees. Co-assignees
+count in 'bd workload' and match --assignee filters the same way the
+assignee does. "me" resolves to the identity config, then git user.email.
 
 Examples:
   bd assign bd-123 alice
-  bd assign bd-123 ""      # unassign`,
-	Args:          cobra.ExactArgs(2),
+  bd assign bd-123 alice bob       # alice assignee, bob co-assignee
+  bd assign bd-123 carol --add     # add carol, keep the assignee
+  bd assign bd-123 me --team infra
+  bd assign bd-123 ""              # unassign`,
+	Args:          cobra.MinimumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -33,12 +43,24 @@ Examples:
 			}
 		}()
 
-		if usesProxiedServer() {
-			return runAssignProxiedServer(rootCtx, args)
+		addOnly, _ := cmd.Flags().GetBool("add")
+		teamSet := cmd.Flags().Changed("team")
+		team, _ := cmd.Flags().GetString("team")
+		if len(args) < 2 && !teamSet {
+			return HandleErrorRespectJSON("give at least one name, or --team")
 		}
-
 		id := args[0]
-		assignee := args[1]
+		names := make([]string, 0, len(args)-1)
+		for _, n := range args[1:] {
+			names = append(names, resolveAssignee(n))
+		}
+
+		if usesProxiedServer() {
+			if len(names) != 1 || addOnly || teamSet {
+				return HandleErrorRespectJSON("co-assignees and teams are not supported in proxied-server mode")
+			}
+			return runAssignProxiedServer(rootCtx, []string{id, names[0]})
+		}
 
 		ctx := rootCtx
 
@@ -63,11 +85,34 @@ Examples:
 			return HandleErrorRespectJSON("%s", err)
 		}
 
-		updates := map[string]interface{}{
-			"assignee": assignee,
+		primary, coAssignees := result.Issue.Assignee, names
+		if !addOnly && len(names) > 0 {
+			primary, coAssignees = names[0], names[1:]
+			if err := issueStore.UpdateIssue(ctx, result.ResolvedID, map[string]interface{}{"assignee": primary}, actor); err != nil {
+				return HandleErrorRespectJSON("updating %s: %v", id, err)
+			}
 		}
-		if err := issueStore.UpdateIssue(ctx, result.ResolvedID, updates, actor); err != nil {
-			return HandleErrorRespectJSON("updating %s: %v", id, err)
+		var extra []string
+		for _, n := range coAssignees {
+			if n != "" && n != primary {
+				extra = append(extra, n)
+			}
+		}
+		if len(extra) > 0 || teamSet {
+			assignStore, ok := storage.UnwrapStore(issueStore).(storage.AssignmentStore)
+			if !ok {
+				return HandleErrorRespectJSON("storage backend does not support co-assignees or teams")
+			}
+			if len(extra) > 0 {
+				if err := assignStore.AddIssueAssignees(ctx, result.ResolvedID, extra); err != nil {
+					return HandleErrorRespectJSON("adding co-assignees to %s: %v", id, err)
+				}
+			}
+			if teamSet {
+				if err := assignStore.SetIssueTeam(ctx, result.ResolvedID, team); err != nil {
+					return HandleErrorRespectJSON("setting team of %s: %v", id, err)
+				}
+			}
 		}
 
 		if err := commitPendingIfEmbedded(ctx, issueStore, actor, doltAutoCommitParams{
@@ -83,6 +128,7 @@ Examples:
 		title := ""
 		if updatedIssue != nil {
 			title = updatedIssue.Title
+			hydrateAssignments(ctx, issueStore, []*types.Issue{updatedIssue})
 		}
 		if jsonOutput {
 			if updatedIssue != nil {
@@ -90,18 +136,175 @@ Examples:
 					return err
 				}
 			}
-		} else {
-			if assignee == "" {
-				fmt.Printf("%s Unassigned %s\n", ui.RenderPass("✓"), formatFeedbackID(result.ResolvedID, title))
+			return nil
+		}
+		switch {
+		case !addOnly && len(names) > 0 && primary == "":
+			fmt.Printf("%s Unassigned %s\n", ui.RenderPass("✓"), formatFeedbackID(result.ResolvedID, title))
+		case len(names) > 0:
+			fmt.Printf("%s Assigned %s to %s\n", ui.RenderPass("✓"), formatFeedbackID(result.ResolvedID, title), strings.Join(nonEmpty(names), ", "))
+		}
+		if teamSet {
+			if team == "" {
+				fmt.Printf("%s Cleared team of %s\n", ui.RenderPass("✓"), formatFeedbackID(result.ResolvedID, title))
 			} else {
-				fmt.Printf("%s Assigned %s to %s\n", ui.RenderPass("✓"), formatFeedbackID(result.ResolvedID, title), assignee)
+				fmt.Printf("%s Set team of %s to %s\n", ui.RenderPass("✓"), formatFeedbackID(result.ResolvedID, title), team)
 			}
 		}
 		return nil
 	},
 }
 
+var unassignCmd = &cobra.Command{
+	Use:     "unassign <id> [name...]",
+	GroupID: "issues",
+	Short:   "Remove assignees from an issue",
+	Long: `Remove assignees from an issue.
+
+With no names, clears the assignee and every co-assignee. Naming the
+assignee clears it; naming a co-assignee removes just that person.
+--team also clears the issue's team.
+
+Examples:
+  bd unassign bd-123              # nobody is assigned
+  bd unassign bd-123 bob          # drop bob, keep the others
+  bd unassign bd-123 me --team`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("unassign is not supported in proxied-server mode")
+		}
+		CheckReadonly("unassign")
+
+		evt := metrics.NewCommandEvent("unassign")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		clearTeam, _ := cmd.Flags().GetBool("team")
+		id := args[0]
+		var names []string
+		for _, n := range args[1:] {
+			names = append(names, resolveAssignee(n))
+		}
+
+		ctx := rootCtx
+		result, err := resolveAndGetIssueForMutation(ctx, store, id)
+		if err != nil {
+			if result != nil {
+				result.Close()
+			}
+			return HandleErrorRespectJSON("resolving %s: %v", id, err)
+		}
+		if result == nil || result.Issue == nil {
+			if result != nil {
+				result.Close()
+			}
+			return HandleErrorRespectJSON("issue %s not found", id)
+		}
+		defer result.Close()
+
+		issueStore := result.Store
+		if err := validateIssueUpdatable(id, result.Issue); err != nil {
+			return HandleErrorRespectJSON("%s", err)
+		}
+
+		clearPrimary := len(names) == 0 && !clearTeam
+		for _, n := range names {
+			clearPrimary = clearPrimary || n == result.Issue.Assignee
+		}
+		if clearPrimary && result.Issue.Assignee != "" {
+			if err := issueStore.UpdateIssue(ctx, result.ResolvedID, map[string]interface{}{"assignee": ""}, actor); err != nil {
+				return HandleErrorRespectJSON("updating %s: %v", id, err)
+			}
+		}
+		// Co-assignees only exist on stores that support them; without
+		// them there is nothing more to remove unless a team was asked for.
+		assignStore, ok := storage.UnwrapStore(issueStore).(storage.AssignmentStore)
+		switch {
+		case ok && !result.Issue.Ephemeral && (len(names) > 0 || !clearTeam):
+			if err := assignStore.RemoveIssueAssignees(ctx, result.ResolvedID, names); err != nil {
+				return HandleErrorRespectJSON("removing co-assignees from %s: %v", id, err)
+			}
+		case !ok && clearTeam:
+			return HandleErrorRespectJSON("storage backend does not support teams")
+		}
+		if clearTeam {
+			if err := assignStore.SetIssueTeam(ctx, result.ResolvedID, ""); err != nil {
+				return HandleErrorRespectJSON("clearing team of %s: %v", id, err)
+			}
+		}
+
+		if err := commitPendingIfEmbedded(ctx, issueStore, actor, doltAutoCommitParams{
+			Command:  "unassign",
+			IssueIDs: []string{result.ResolvedID},
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		SetLastTouchedID(result.ResolvedID)
+
+		updatedIssue, _ := issueStore.GetIssue(ctx, result.ResolvedID)
+		if jsonOutput {
+			if updatedIssue != nil {
+				hydrateAssignments(ctx, issueStore, []*types.Issue{updatedIssue})
+				return outputJSON(updatedIssue)
+			}
+			return nil
+		}
+		fmt.Printf("%s Unassigned %s\n", ui.RenderPass("✓"), formatFeedbackID(result.ResolvedID, issueTitleOrEmpty(updatedIssue)))
+		return nil
+	},
+}
+
+// hydrateAssignments fills in Assignees and Team from the assignment side
+// tables. Best effort: stores without them leave the issues untouched.
+func hydrateAssignments(ctx context.Context, st storage.DoltStorage, issues []*types.Issue) {
+	assignStore, ok := storage.UnwrapStore(st).(storage.AssignmentStore)
+	if !ok || len(issues) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if !issue.Ephemeral {
+			ids = append(ids, issue.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	byIssue, err := assignStore.GetIssueAssignments(ctx, ids)
+	if err != nil {
+		return
+	}
+	for _, issue := range issues {
+		if a := byIssue[issue.ID]; a != nil {
+			issue.Assignees = a.Assignees
+			issue.Team = a.Team
+		}
+	}
+}
+
+func nonEmpty(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		if n != "" {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
 func init() {
+	assignCmd.Flags().Bool("add", false, "Add every name as a co-assignee and keep the current assignee")
+	assignCmd.Flags().String("team", "", "Set the owning team (\"\" clears it)")
 	assignCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(assignCmd)
+
+	unassignCmd.Flags().Bool("team", false, "Also clear the owning team")
+	unassignCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(unassignCmd)
 }
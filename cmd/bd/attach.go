@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/attachments"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var attachCmd = &cobra.Command{
+	Use:     "attach <id> <file>",
+	GroupID: "issues",
+	Short:   "Attach a file to an issue",
+	Long: `Attach a file to an issue.
+
+The file's content is stored content-addressed (by SHA256) under
+.beads/attachments/, and the issue records a reference to it by name. Commit
+.beads/attachments/ with git to share attachments; the references travel with
+the issue data (Dolt sync and JSONL export). Attaching identical content twice,
+to any issue, stores it once.
+
+Removing an attachment drops the reference only; the blob stays on disk.
+
+Examples:
+  bd attach bd-123 screenshot.png               # Attach under the file's name
+  bd attach bd-123 trace.log --name crash.log   # Attach under another name
+  bd attach list bd-123                         # List attachments
+  bd attach get bd-123 crash.log                # Extract to ./crash.log
+  bd attach get bd-123 crash.log -o -           # Write to stdout
+  bd attach remove bd-123 crash.log             # Drop the reference`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("attach")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("attach is not supported in proxied-server mode")
+		}
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			name = filepath.Base(args[1])
+		}
+
+		ctx := rootCtx
+		result, attStore, err := resolveAttachmentIssue(ctx, args[0], true)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer result.Close()
+		if err := validateIssueUpdatable(args[0], result.Issue); err != nil {
+			return HandleErrorRespectJSON("%s", err)
+		}
+
+		f, err := os.Open(args[1]) //nolint:gosec // G304: user-specified file to attach
+		if err != nil {
+			return HandleErrorRespectJSON("opening %s: %v", args[1], err)
+		}
+		defer f.Close()
+		sha, size, err := attachments.Put(beads.FindBeadsDir(), f)
+		if err != nil {
+			return HandleErrorRespectJSON("storing %s: %v", args[1], err)
+		}
+
+		attachment := &types.Attachment{
+			IssueID:   result.ResolvedID,
+			SHA256:    sha,
+			Name:      name,
+			Size:      size,
+			MediaType: mime.TypeByExtension(filepath.Ext(name)),
+			CreatedBy: getActorWithGit(),
+		}
+		if err := attStore.AddAttachment(ctx, attachment); err != nil {
+			return HandleErrorRespectJSON("attaching %s: %v", name, err)
+		}
+		if err := commitPendingIfEmbedded(ctx, result.Store, actor, doltAutoCommitParams{
+			Command:  "attach",
+			IssueIDs: []string{result.ResolvedID},
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(result.ResolvedID)
+
+		if jsonOutput {
+			return outputJSON(attachment)
+		}
+		fmt.Printf("%s Attached %s to %s (%s, %s)\n", ui.RenderPass("✓"), name,
+			formatFeedbackID(result.ResolvedID, result.Issue.Title), formatAttachmentSize(size), sha[:12])
+		return nil
+	},
+}
+
+var attachListCmd = &cobra.Command{
+	Use:           "list <id>",
+	Short:         "List the attachments of an issue",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("attach is not supported in proxied-server mode")
+		}
+		result, attStore, err := resolveAttachmentIssue(rootCtx, args[0], false)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer result.Close()
+		byIssue, err := attStore.GetAttachmentsForIssues(rootCtx, []string{result.ResolvedID})
+		if err != nil {
+			return HandleErrorRespectJSON("listing attachments: %v", err)
+		}
+		list := byIssue[result.ResolvedID]
+
+		if jsonOutput {
+			if list == nil {
+				list = []*types.Attachment{}
+			}
+			return outputJSON(list)
+		}
+		if len(list) == 0 {
+			fmt.Printf("No attachments on %s\n", result.ResolvedID)
+			return nil
+		}
+		printAttachments(list)
+		return nil
+	},
+}
+
+var attachGetCmd = &cobra.Command{
+	Use:   "get <id> <name>",
+	Short: "Extract an attachment to a file",
+	Long: `Extract an attachment to a file.
+
+Writes to ./<name> by default; use -o to choose the path, or -o - for stdout.
+An existing file is not overwritten unless --force is given.`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("attach is not supported in proxied-server mode")
+		}
+		output, _ := cmd.Flags().GetString("output")
+		force, _ := cmd.Flags().GetBool("force")
+
+		result, attStore, err := resolveAttachmentIssue(rootCtx, args[0], false)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer result.Close()
+		attachment, err := findAttachment(rootCtx, attStore, result.ResolvedID, args[1])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		blob, err := attachments.Open(beads.FindBeadsDir(), attachment.SHA256)
+		if err != nil {
+			if errors.Is(err, attachments.ErrNotFound) {
+				return HandleErrorRespectJSON("blob for %s is not in .beads/attachments (not pulled yet?): %v", args[1], err)
+			}
+			return HandleErrorRespectJSON("opening %s: %v", args[1], err)
+		}
+		defer blob.Close()
+
+		if output == "-" {
+			if _, err := io.Copy(os.Stdout, blob); err != nil {
+				return HandleErrorRespectJSON("writing %s: %v", args[1], err)
+			}
+			return nil
+		}
+		if output == "" {
+			output = filepath.Base(attachment.Name)
+		}
+		flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		if force {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		}
+		out, err := os.OpenFile(output, flags, 0o644) //nolint:gosec // G304: user-specified output path
+		if err != nil {
+			if errors.Is(err, os.ErrExist) {
+				return HandleErrorRespectJSON("%s already exists (use --force to overwrite)", output)
+			}
+			return HandleErrorRespectJSON("creating %s: %v", output, err)
+		}
+		if _, err := io.Copy(out, blob); err != nil {
+			_ = out.Close()
+			return HandleErrorRespectJSON("writing %s: %v", output, err)
+		}
+		if err := out.Close(); err != nil {
+			return HandleErrorRespectJSON("writing %s: %v", output, err)
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"attachment": attachment, "path": output})
+		}
+		fmt.Printf("%s Extracted %s to %s\n", ui.RenderPass("✓"), attachment.Name, output)
+		return nil
+	},
+}
+
+var attachRemoveCmd = &cobra.Command{
+	Use:           "remove <id> <name>",
+	Aliases:       []string{"rm"},
+	Short:         "Remove an attachment from an issue",
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("attach remove")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("attach is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		result, attStore, err := resolveAttachmentIssue(ctx, args[0], true)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer result.Close()
+		if err := attStore.RemoveAttachment(ctx, result.ResolvedID, args[1]); err != nil {
+			return HandleErrorRespectJSON("removing attachment: %v", err)
+		}
+		if err := commitPendingIfEmbedded(ctx, result.Store, actor, doltAutoCommitParams{
+			Command:  "attach remove",
+			IssueIDs: []string{result.ResolvedID},
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"issue_id": result.ResolvedID, "removed": args[1]})
+		}
+		fmt.Printf("%s Removed %s from %s\n", ui.RenderPass("✓"), args[1], result.ResolvedID)
+		return nil
+	},
+}
+
+// resolveAttachmentIssue resolves id and returns the store holding it as an
+// AttachmentStore. The caller must Close the result.
+func resolveAttachmentIssue(ctx context.Context, id string, forMutation bool) (*RoutedResult, storage.AttachmentStore, error) {
+	resolve := resolveAndGetIssueWithRouting
+	if forMutation {
+		resolve = resolveAndGetIssueForMutation
+	}
+	result, err := resolve(ctx, store, id)
+	if err != nil {
+		if result != nil {
+			result.Close()
+		}
+		return nil, nil, fmt.Errorf("resolving %s: %w", id, err)
+	}
+	if result == nil || result.Issue == nil {
+		if result != nil {
+			result.Close()
+		}
+		return nil, nil, fmt.Errorf("issue %s not found", id)
+	}
+	attStore, ok := storage.UnwrapStore(result.Store).(storage.AttachmentStore)
+	if !ok {
+		result.Close()
+		return nil, nil, fmt.Errorf("storage backend does not support attachments")
+	}
+	return result, attStore, nil
+}
+
+func findAttachment(ctx context.Context, attStore storage.AttachmentStore, issueID, name string) (*types.Attachment, error) {
+	byIssue, err := attStore.GetAttachmentsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, fmt.Errorf("listing attachments: %w", err)
+	}
+	for _, a := range byIssue[issueID] {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no attachment named %q", issueID, name)
+}
+
+// getIssueAttachments returns the attachments of issueID, or nil when the
+// store does not support them. Best effort, like the other show sections.
+func getIssueAttachments(ctx context.Context, st storage.DoltStorage, issueID string) []*types.Attachment {
+	return getAttachmentsForIssues(ctx, st, []string{issueID})[issueID]
+}
+
+// getAttachmentsForIssues bulk-loads attachments for export. It returns an
+// empty map when the store does not support attachments or the query fails.
+func getAttachmentsForIssues(ctx context.Context, st storage.DoltStorage, issueIDs []string) map[string][]*types.Attachment {
+	attStore, ok := storage.UnwrapStore(st).(storage.AttachmentStore)
+	if !ok {
+		return nil
+	}
+	byIssue, err := attStore.GetAttachmentsForIssues(ctx, issueIDs)
+	if err != nil {
+		return nil
+	}
+	return byIssue
+}
+
+// importAttachmentRefs records the attachment references carried by imported
+// issues. Blobs are not part of the JSONL; they arrive with .beads/attachments.
+func importAttachmentRefs(ctx context.Context, st storage.DoltStorage, issues []*types.Issue, skip map[string]struct{}) error {
+	attStore, ok := storage.UnwrapStore(st).(storage.AttachmentStore)
+	if !ok {
+		return nil
+	}
+	for _, issue := range issues {
+		if _, skipped := skip[issue.ID]; skipped || issue.Ephemeral {
+			continue
+		}
+		for _, a := range issue.Attachments {
+			ref := *a
+			ref.IssueID = issue.ID
+			if err := attStore.AddAttachment(ctx, &ref); err != nil {
+				return fmt.Errorf("importing attachment %s of %s: %w", a.Name, issue.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func printAttachments(list []*types.Attachment) {
+	for _, a := range list {
+		fmt.Printf("  %s  %s  %s\n", a.Name, ui.RenderMuted(formatAttachmentSize(a.Size)), ui.RenderMuted(a.SHA256[:12]))
+	}
+}
+
+func formatAttachmentSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	attachCmd.Flags().String("name", "", "Name to attach the file under (default: the file's base name)")
+	attachGetCmd.Flags().StringP("output", "o", "", "Output path, or - for stdout (default: ./<name>)")
+	attachGetCmd.Flags().Bool("force", false, "Overwrite an existing output file")
+	attachCmd.ValidArgsFunction = issueIDCompletion
+	attachListCmd.ValidArgsFunction = issueIDCompletion
+	attachGetCmd.ValidArgsFunction = issueIDCompletion
+	attachRemoveCmd.ValidArgsFunction = issueIDCompletion
+	attachCmd.AddCommand(attachListCmd, attachGetCmd, attachRemoveCmd)
+	rootCmd.AddCommand(attachCmd)
+}
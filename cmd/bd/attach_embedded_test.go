@@ -0,0 +1,96 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedAttach(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "at")
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+		if err != nil {
+			t.Fatalf("bd %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+
+	issue := bdCreate(t, bd, dir, "Has a log", "--type", "bug")
+	other := bdCreate(t, bd, dir, "Same log", "--type", "bug")
+	src := filepath.Join(t.TempDir(), "trace.log")
+	if err := os.WriteFile(src, []byte("panic: boom\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run(t, "attach", issue.ID, src)
+	run(t, "attach", other.ID, src, "--name", "crash.log")
+
+	// Identical content is stored once, content-addressed.
+	var blobs []string
+	_ = filepath.WalkDir(filepath.Join(beadsDir, "attachments"), func(p string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			blobs = append(blobs, p)
+		}
+		return nil
+	})
+	if len(blobs) != 1 {
+		t.Fatalf("want one stored blob, got %v", blobs)
+	}
+	if name := filepath.Base(blobs[0]); filepath.Base(filepath.Dir(blobs[0])) != name[:2] {
+		t.Errorf("blob %s not sharded by digest prefix", blobs[0])
+	}
+
+	t.Run("show", func(t *testing.T) {
+		got := bdShow(t, bd, dir, issue.ID)
+		if len(got.Attachments) != 1 || got.Attachments[0].Name != "trace.log" || got.Attachments[0].Size != 12 {
+			t.Fatalf("show attachments = %+v, want trace.log (12 bytes)", got.Attachments)
+		}
+		if !strings.Contains(run(t, "show", issue.ID), "ATTACHMENTS") {
+			t.Error("bd show text output lacks an ATTACHMENTS section")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		out := bdExport(t, bd, dir)
+		if !strings.Contains(out, `"attachments":[{`) || !strings.Contains(out, `"name":"crash.log"`) {
+			t.Errorf("export lacks attachment references:\n%s", out)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "out.log")
+		run(t, "attach", "get", other.ID, "crash.log", "-o", dst)
+		data, err := os.ReadFile(dst)
+		if err != nil || string(data) != "panic: boom\n" {
+			t.Fatalf("extracted %q, %v", data, err)
+		}
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "attach", "get", other.ID, "crash.log", "-o", dst); err == nil || !strings.Contains(string(out), "already exists") {
+			t.Errorf("get over an existing file: want refusal, got err=%v\n%s", err, out)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		run(t, "attach", "remove", issue.ID, "trace.log")
+		if got := bdShow(t, bd, dir, issue.ID); len(got.Attachments) != 0 {
+			t.Errorf("attachments after remove = %+v", got.Attachments)
+		}
+		// The blob stays: the other issue still references it.
+		if _, err := os.Stat(blobs[0]); err != nil {
+			t.Errorf("blob removed with the reference: %v", err)
+		}
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "attach", "remove", issue.ID, "trace.log"); err == nil {
+			t.Errorf("removing a missing attachment succeeded:\n%s", out)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// blameFieldAliases maps the flag spellings bd uses elsewhere (--acceptance,
+// --type, --estimate, ...) to the column names recorded in events.
+var blameFieldAliases = map[string]string{
+	"desc":       "description",
+	"acceptance": "acceptance_criteria",
+	"type":       "issue_type",
+	"estimate":   "estimated_minutes",
+	"due":        "due_at",
+	"defer":      "defer_until",
+	"external":   "external_ref",
+	"ephemeral":  "wisp",
+}
+
+var blameCmd = &cobra.Command{
+	Use:     "blame <id>",
+	GroupID: "views",
+	Short:   "Show who last changed a field of an issue, and when",
+	Long: `Show who last changed a field of an issue, and when, with the old and new
+value. Changes are read from the issue's audit events, so they cover every
+update since the issue was created, including uncommitted ones.
+
+If the field never changed, blame reports who created the issue.
+
+Fields are column names (title, description, design, notes,
+acceptance_criteria, status, priority, issue_type, assignee, ...); the flag
+spellings desc, acceptance, type, and estimate work too.
+
+Examples:
+  bd blame bd-123 --field description      # Last change to the description
+  bd blame bd-123 --field status --all     # Every status change, newest first`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("blame")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		field, _ := cmd.Flags().GetString("field")
+		all, _ := cmd.Flags().GetBool("all")
+		if usesProxiedServer() {
+			uw, err := openProxiedListUOW(rootCtx)
+			if err != nil {
+				return HandleError("%v", err)
+			}
+			defer uw.Close(rootCtx)
+			return runBlame(rootCtx, uw.IssueUseCase(), args[0], field, all)
+		}
+		return runBlame(rootCtx, store, args[0], field, all)
+	},
+}
+
+func runBlame(ctx context.Context, backend historyBackend, issueID, field string, all bool) error {
+	field = normalizeBlameField(field)
+	if field == "" {
+		return HandleErrorRespectJSON("--field is required")
+	}
+	events, err := collectHistoryEvents(ctx, backend, issueID, 0)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to get history events: %v", err)
+	}
+	if len(events) == 0 {
+		return HandleErrorRespectJSON("no history events found for issue %s", issueID)
+	}
+
+	var changes []issueops.FieldChange
+	for _, c := range eventsFieldChanges(events) {
+		if c.Field == field {
+			changes = append(changes, c)
+		}
+	}
+	if !all && len(changes) > 1 {
+		changes = changes[:1]
+	}
+
+	if jsonOutput {
+		if changes == nil {
+			changes = []issueops.FieldChange{}
+		}
+		return outputJSON(changes)
+	}
+	if len(changes) == 0 {
+		// Events are newest first, so the creation event is last.
+		created := events[len(events)-1]
+		if created.EventType == types.EventCreated {
+			fmt.Printf("%s of %s unchanged since the issue was created by %s on %s\n",
+				field, issueID, created.Actor, created.CreatedAt.Format("2006-01-02 15:04:05"))
+		} else {
+			fmt.Printf("No recorded changes to %s of %s\n", field, issueID)
+		}
+		return nil
+	}
+	for i, c := range changes {
+		fmt.Printf("%s %s by %s (%s)\n", ui.RenderAccent(field),
+			c.At.Format("2006-01-02 15:04:05"), c.Actor, c.EventType)
+		printFieldChangeValues(c)
+		if i < len(changes)-1 {
+			fmt.Println()
+		}
+	}
+	return nil
+}
+
+func normalizeBlameField(field string) string {
+	field = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(field, "--")))
+	field = strings.ReplaceAll(field, "-", "_")
+	if alias, ok := blameFieldAliases[field]; ok {
+		return alias
+	}
+	return field
+}
+
+// eventsFieldChanges flattens the field changes of events, newest first.
+// created_at has one-second precision, so events within the same second are
+// ordered by their UUIDv7 IDs, which sort by creation time.
+func eventsFieldChanges(events []types.Event) []issueops.FieldChange {
+	ordered := slices.Clone(events)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].CreatedAt.Equal(ordered[j].CreatedAt) {
+			return ordered[i].CreatedAt.After(ordered[j].CreatedAt)
+		}
+		return ordered[i].ID > ordered[j].ID
+	})
+	changes := []issueops.FieldChange{}
+	for i := range ordered {
+		changes = append(changes, issueops.EventFieldChanges(&ordered[i])...)
+	}
+	return changes
+}
+
+func printFieldChanges(issueID string, changes []issueops.FieldChange) {
+	if len(changes) == 0 {
+		fmt.Printf("No field changes found for issue %s\n", issueID)
+		return
+	}
+	fmt.Printf("\n%s Field changes for %s (%d)\n", ui.RenderAccent("📜"), issueID, len(changes))
+	lastEvent := ""
+	for _, c := range changes {
+		if c.EventID != lastEvent {
+			fmt.Printf("\n%s %s by %s\n", ui.RenderMuted(c.At.Format("2006-01-02 15:04:05")), c.EventType, c.Actor)
+			lastEvent = c.EventID
+		}
+		fmt.Printf("  %s:\n", c.Field)
+		printFieldChangeValues(c)
+	}
+	fmt.Println()
+}
+
+// printFieldChangeValues prints the old and new value of c, truncating long
+// text so a description rewrite stays readable.
+func printFieldChangeValues(c issueops.FieldChange) {
+	old := "(unknown)"
+	if c.OldKnown {
+		old = blameValue(c.Old)
+	}
+	fmt.Printf("    - %s\n", ui.RenderMuted(old))
+	fmt.Printf("    + %s\n", blameValue(c.New))
+}
+
+func blameValue(v string) string {
+	if v == "" {
+		return "(empty)"
+	}
+	v = strings.Join(strings.Fields(v), " ")
+	if r := []rune(v); len(r) > 120 {
+		return string(r[:119]) + "…"
+	}
+	return v
+}
+
+func init() {
+	blameCmd.Flags().String("field", "", "Field to blame (e.g. description, status, assignee)")
+	blameCmd.Flags().Bool("all", false, "Show every change to the field, newest first")
+	blameCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(blameCmd)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNormalizeBlameField(t *testing.T) {
+	for in, want := range map[string]string{
+		"description":         "description",
+		"desc":                "description",
+		"--acceptance":        "acceptance_criteria",
+		"Acceptance-Criteria": "acceptance_criteria",
+		"type":                "issue_type",
+		"":                    "",
+	} {
+		if got := normalizeBlameField(in); got != want {
+			t.Errorf("normalizeBlameField(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEventsFieldChangesOrdersSameSecondByID(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	reason := "done"
+	oldIssue := `{"status":"open"}`
+	update := `{"status":"in_progress"}`
+	events := []types.Event{
+		{ID: "0190-a", EventType: types.EventStatusChanged, CreatedAt: at, OldValue: &oldIssue, NewValue: &update},
+		{ID: "0190-b", EventType: types.EventClosed, CreatedAt: at, NewValue: &reason},
+	}
+	changes := eventsFieldChanges(events)
+	if len(changes) == 0 || changes[0].EventType != types.EventClosed {
+		t.Fatalf("newest change = %+v, want the close (later UUIDv7 ID)", changes)
+	}
+}
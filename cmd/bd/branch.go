@@ -1,25 +1,33 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
 var branchCmd = &cobra.Command{
 	Use:     "branch [name]",
 	GroupID: "sync",
-	Short:   "List or create branches",
+	Short:   "List, create, merge, or delete branches",
 	Long: `List all branches or create a new branch.
 
 This command requires the Dolt storage backend. Without arguments,
 it lists all branches. With an argument, it creates a new branch.
+Branches are merged back with Dolt's cell-level merge: two branches
+that changed different fields of the same issue merge cleanly.
 
 Examples:
-  bd branch                    # List all branches
-  bd branch feature-xyz        # Create a new branch named feature-xyz`,
+  bd branch                          # List all branches
+  bd branch feature-xyz              # Create a new branch named feature-xyz
+  bd branch merge feature-xyz        # Merge feature-xyz into the current branch
+  bd branch merge feature-xyz --report  # Preview conflicts without merging
+  bd branch delete feature-xyz       # Delete feature-xyz`,
 	Args:          cobra.MaximumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -82,6 +90,144 @@ Examples:
 	},
 }
 
+var (
+	branchMergeStrategy string
+	branchMergeReport   bool
+)
+
+var branchMergeCmd = &cobra.Command{
+	Use:   "merge <branch>",
+	Short: "Merge a branch into the current branch",
+	Long: `Merge the specified branch into the current branch using Dolt's
+cell-level merge.
+
+With --report, nothing is merged: the conflicts the merge would produce
+are listed per issue and field, with both sides' values, so they can be
+reconciled on either branch first. Without --report this is the same as
+'bd vc merge'.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("branch merge is not supported in proxied-server mode")
+		}
+		if branchMergeReport && branchMergeStrategy != "" {
+			return HandleErrorRespectJSON("--report cannot be combined with --strategy")
+		}
+		evt := metrics.NewCommandEvent("branch-merge")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if !branchMergeReport {
+			return runBranchMerge(rootCtx, args[0], branchMergeStrategy)
+		}
+		return reportBranchMerge(rootCtx, args[0])
+	},
+}
+
+var branchDeleteCmd = &cobra.Command{
+	Use:     "delete <branch>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a branch",
+	Long: `Delete a branch, including any changes on it that were never merged.
+The current branch cannot be deleted.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("branch delete is not supported in proxied-server mode")
+		}
+		CheckReadonly("branch delete")
+		ctx := rootCtx
+		branchName := args[0]
+
+		if current, err := store.CurrentBranch(ctx); err == nil && current == branchName {
+			return HandleErrorRespectJSON("cannot delete the current branch %q", branchName)
+		}
+		if err := store.DeleteBranch(ctx, branchName); err != nil {
+			return HandleErrorRespectJSON("failed to delete branch: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"deleted": branchName,
+			})
+		}
+		fmt.Printf("Deleted branch: %s\n", ui.RenderAccent(branchName))
+		return nil
+	},
+}
+
+// reportBranchMerge lists the conflicts merging branchName into the current
+// branch would produce, without merging.
+func reportBranchMerge(ctx context.Context, branchName string) error {
+	previewer, ok := storage.UnwrapStore(store).(storage.MergePreviewer)
+	if !ok {
+		return HandleErrorRespectJSON("current storage backend does not support merge previews")
+	}
+	conflicts, err := previewer.PreviewMerge(ctx, branchName)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to preview merge: %v", err)
+	}
+
+	if jsonOutput {
+		type conflictJSON struct {
+			IssueID string      `json:"issue_id,omitempty"`
+			Field   string      `json:"field"`
+			Ours    interface{} `json:"ours"`
+			Theirs  interface{} `json:"theirs"`
+		}
+		out := make([]conflictJSON, 0, len(conflicts))
+		for _, c := range conflicts {
+			out = append(out, conflictJSON{IssueID: c.IssueID, Field: c.Field, Ours: c.OursValue, Theirs: c.TheirsValue})
+		}
+		return outputJSON(map[string]interface{}{
+			"branch":    branchName,
+			"conflicts": out,
+		})
+	}
+
+	if len(conflicts) == 0 {
+		fmt.Printf("%s %s merges cleanly\n", ui.RenderPass("✓"), ui.RenderAccent(branchName))
+		return nil
+	}
+	fmt.Printf("\n%s Merging %s would conflict in %d field(s):\n\n", ui.RenderAccent("!!"), ui.RenderAccent(branchName), len(conflicts))
+	for _, c := range conflicts {
+		if c.IssueID == "" {
+			fmt.Printf("  - %s (schema)\n", c.Field)
+			continue
+		}
+		fmt.Printf("  - %s %s\n", c.IssueID, c.Field)
+		fmt.Printf("      ours:   %s\n", formatConflictValue(c.OursValue))
+		fmt.Printf("      theirs: %s\n", formatConflictValue(c.TheirsValue))
+	}
+	fmt.Printf("\nMerge anyway with: bd branch merge %s --strategy [ours|theirs]\n\n", branchName)
+	return nil
+}
+
+// formatConflictValue renders one side of a previewed conflict on one line.
+func formatConflictValue(v interface{}) string {
+	if v == nil {
+		return ui.RenderMuted("(null)")
+	}
+	s := strings.ReplaceAll(fmt.Sprint(v), "\n", " ")
+	if len(s) > 80 {
+		s = s[:77] + "..."
+	}
+	return s
+}
+
 func init() {
+	branchMergeCmd.Flags().StringVar(&branchMergeStrategy, "strategy", "", "Conflict resolution strategy: 'ours' or 'theirs'")
+	branchMergeCmd.Flags().BoolVar(&branchMergeReport, "report", false, "List the conflicts the merge would produce without merging")
+
+	branchCmd.AddCommand(branchMergeCmd)
+	branchCmd.AddCommand(branchDeleteCmd)
 	rootCmd.AddCommand(branchCmd)
 }
@@ -105,6 +105,49 @@ func TestEmbeddedBranch(t *testing.T) {
 			t.Errorf("expected at least 4 branches, got %d", len(branches))
 		}
 	})
+
+	t.Run("merge_report_and_merge", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "brmerge")
+
+		bdBranch(t, bd, dir, "agent-1")
+		out := bdBranch(t, bd, dir, "merge", "agent-1", "--report", "--json")
+		var report struct {
+			Branch    string            `json:"branch"`
+			Conflicts []json.RawMessage `json:"conflicts"`
+		}
+		if err := json.Unmarshal([]byte(out), &report); err != nil {
+			t.Fatalf("failed to parse JSON: %v\n%s", err, out)
+		}
+		if report.Branch != "agent-1" || len(report.Conflicts) != 0 {
+			t.Errorf("expected a clean report for agent-1, got: %s", out)
+		}
+
+		out = bdBranch(t, bd, dir, "merge", "agent-1")
+		if !strings.Contains(out, "merged agent-1") {
+			t.Errorf("expected merge confirmation, got: %s", out)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "brdel")
+
+		bdBranch(t, bd, dir, "scratch")
+		out := bdBranch(t, bd, dir, "delete", "scratch")
+		if !strings.Contains(out, "Deleted branch: scratch") {
+			t.Errorf("expected delete confirmation, got: %s", out)
+		}
+		if listOut := bdBranch(t, bd, dir); strings.Contains(listOut, "scratch") {
+			t.Errorf("expected 'scratch' gone from branch list, got: %s", listOut)
+		}
+
+		cmd := exec.Command(bd, "branch", "delete", "main")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		failOut, err := cmd.CombinedOutput()
+		if err == nil || !strings.Contains(string(failOut), "current branch") {
+			t.Errorf("expected deleting the current branch to fail, got err=%v: %s", err, failOut)
+		}
+	})
 }
 
 func TestEmbeddedBranchConcurrent(t *testing.T) {
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var checkCmd = &cobra.Command{
+	Use:     "check",
+	GroupID: "issues",
+	Short:   "Manage the checklist of an issue",
+	Long: `Manage the checklist of an issue.
+
+A checklist is the set of markdown task items ("- [ ] write tests",
+"- [x] write tests") in the issue's description, so it is stored, synced and
+exported with the description. Items are numbered from 1 in the order they
+appear; items inside fenced code blocks don't count. bd show and bd list
+show the checklist's progress.
+
+Promoting an item turns it into a child task of the issue (parent-child
+dependency) and removes it from the checklist, like bd split does for a
+single item.
+
+Examples:
+  bd check list bd-abc                 # Numbered items and progress
+  bd check add bd-abc "write tests"    # Append an item
+  bd check done bd-abc 2               # Tick item 2
+  bd check undo bd-abc 2               # Untick item 2
+  bd check remove bd-abc 3             # Delete item 3
+  bd check promote bd-abc 1            # Make item 1 a child issue`,
+}
+
+var checkListCmd = &cobra.Command{
+	Use:           "list <id>",
+	Aliases:       []string{"ls"},
+	Short:         "List the checklist items of an issue",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("check is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		result, err := resolveAndGetIssueWithRouting(ctx, store, args[0])
+		if err != nil {
+			if result != nil {
+				result.Close()
+			}
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		if result == nil || result.Issue == nil {
+			return HandleErrorRespectJSON("issue %s not found", args[0])
+		}
+		defer result.Close()
+
+		out := newChecklistResult(result.ResolvedID, result.Issue.Description)
+		if jsonOutput {
+			return outputJSON(out)
+		}
+		if len(out.Items) == 0 {
+			fmt.Printf("No checklist items in %s\n", result.ResolvedID)
+			return nil
+		}
+		fmt.Printf("%s %s\n", formatFeedbackID(result.ResolvedID, result.Issue.Title), ui.RenderMuted(formatChecklistCount(out.Progress)))
+		for _, item := range out.Items {
+			fmt.Println(formatChecklistItem(item))
+		}
+		return nil
+	},
+}
+
+var checkAddCmd = &cobra.Command{
+	Use:           "add <id> <text>...",
+	Short:         "Append items to an issue's checklist",
+	Long:          "Append one unchecked item per text argument to the issue's checklist, creating a \"## Checklist\" section when it has none.",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		texts := make([]string, 0, len(args)-1)
+		for _, text := range args[1:] {
+			text = strings.Join(strings.Fields(text), " ")
+			if text == "" {
+				return HandleErrorRespectJSON("checklist item text cannot be empty")
+			}
+			texts = append(texts, text)
+		}
+		return runChecklistEdit("check add", args[0], func(issue *types.Issue, _ []types.ChecklistItem) (string, string, error) {
+			return types.AddChecklistItems(issue.Description, texts...),
+				fmt.Sprintf("Added %d checklist item(s) to", len(texts)), nil
+		})
+	},
+}
+
+var checkDoneCmd = &cobra.Command{
+	Use:           "done <id> <n>...",
+	Short:         "Mark checklist items done",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChecklistMark("check done", args, true)
+	},
+}
+
+var checkUndoCmd = &cobra.Command{
+	Use:           "undo <id> <n>...",
+	Short:         "Mark checklist items not done",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChecklistMark("check undo", args, false)
+	},
+}
+
+var checkRemoveCmd = &cobra.Command{
+	Use:           "remove <id> <n>...",
+	Aliases:       []string{"rm"},
+	Short:         "Delete checklist items",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChecklistEdit("check remove", args[0], func(issue *types.Issue, items []types.ChecklistItem) (string, string, error) {
+			picked, err := pickChecklistItems(items, args[1:])
+			if err != nil {
+				return "", "", err
+			}
+			desc, err := carveSplitCandidates(issue.Description, checklistSplitCandidates(picked))
+			if err != nil {
+				return "", "", err
+			}
+			return desc, fmt.Sprintf("Removed %d checklist item(s) from", len(picked)), nil
+		})
+	},
+}
+
+var checkPromoteCmd = &cobra.Command{
+	Use:   "promote <id> <n>",
+	Short: "Turn a checklist item into a child issue",
+	Long: `Turn an unchecked checklist item into a child task of the issue.
+
+The child gets the item's text as its title and the issue's priority, and
+depends on the issue through a parent-child dependency. The item is removed
+from the checklist in the same transaction.`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runCheckPromote,
+}
+
+// checklistResult is the --json payload of the check subcommands.
+type checklistResult struct {
+	IssueID  string                   `json:"issue_id"`
+	Items    []types.ChecklistItem    `json:"items"`
+	Progress *types.ChecklistProgress `json:"progress"`
+	Created  *types.Issue             `json:"created,omitempty"` // bd check promote
+}
+
+func init() {
+	for _, c := range []*cobra.Command{checkListCmd, checkAddCmd, checkDoneCmd, checkUndoCmd, checkRemoveCmd, checkPromoteCmd} {
+		c.ValidArgsFunction = issueIDCompletion
+		checkCmd.AddCommand(c)
+	}
+	rootCmd.AddCommand(checkCmd)
+}
+
+func newChecklistResult(issueID, description string) *checklistResult {
+	items := types.ParseChecklist(description)
+	if items == nil {
+		items = []types.ChecklistItem{}
+	}
+	progress := types.ChecklistProgressOf(description)
+	if progress == nil {
+		progress = &types.ChecklistProgress{}
+	}
+	return &checklistResult{IssueID: issueID, Items: items, Progress: progress}
+}
+
+func runChecklistMark(command string, args []string, done bool) error {
+	return runChecklistEdit(command, args[0], func(issue *types.Issue, items []types.ChecklistItem) (string, string, error) {
+		picked, err := pickChecklistItems(items, args[1:])
+		if err != nil {
+			return "", "", err
+		}
+		desc := issue.Description
+		for _, item := range picked {
+			desc = types.SetChecklistItemDone(desc, item, done)
+		}
+		verb := "Checked"
+		if !done {
+			verb = "Unchecked"
+		}
+		nums := make([]string, len(picked))
+		for i, item := range picked {
+			nums[i] = strconv.Itoa(item.Index)
+		}
+		return desc, fmt.Sprintf("%s item %s of", verb, strings.Join(nums, ", ")), nil
+	})
+}
+
+// runChecklistEdit resolves id for writing, rewrites its description with
+// edit and stores the result. The write is a compare-and-swap on the version
+// that was read, so a concurrent edit of the issue is reported instead of
+// being overwritten. edit returns the new description and the verb phrase
+// of the confirmation message.
+func runChecklistEdit(command, id string, edit func(issue *types.Issue, items []types.ChecklistItem) (string, string, error)) error {
+	CheckReadonly(command)
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("check is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent(strings.ReplaceAll(command, " ", "_"))
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+	ctx := rootCtx
+
+	result, err := resolveAndGetIssueForMutation(ctx, store, id)
+	if err != nil {
+		if result != nil {
+			result.Close()
+		}
+		return HandleErrorRespectJSON("resolving %s: %v", id, err)
+	}
+	if result == nil || result.Issue == nil {
+		return HandleErrorRespectJSON("issue %s not found", id)
+	}
+	defer result.Close()
+	issue := result.Issue
+
+	desc, verb, err := edit(issue, types.ParseChecklist(issue.Description))
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	actor := getActorWithGit()
+	if desc != issue.Description {
+		opts := storage.UpdateIssueOptions{ExpectedVersion: &issue.RowVersion}
+		if err := result.Store.UpdateIssueChecked(ctx, result.ResolvedID, map[string]interface{}{"description": desc}, actor, opts); err != nil {
+			return HandleErrorRespectJSON("updating %s: %v", result.ResolvedID, err)
+		}
+		if err := commitPendingIfEmbedded(ctx, result.Store, actor, doltAutoCommitParams{
+			Command:  command,
+			IssueIDs: []string{result.ResolvedID},
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+	}
+	SetLastTouchedID(result.ResolvedID)
+
+	out := newChecklistResult(result.ResolvedID, desc)
+	if jsonOutput {
+		return outputJSON(out)
+	}
+	fmt.Printf("%s %s %s %s\n", ui.RenderPass("✓"), verb, formatFeedbackID(result.ResolvedID, issue.Title),
+		ui.RenderMuted("("+formatChecklistCount(out.Progress)+")"))
+	return nil
+}
+
+func runCheckPromote(cmd *cobra.Command, args []string) error {
+	CheckReadonly("check promote")
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("check is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("check_promote")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("failed to get storage: %v", err)
+	}
+	ctx := rootCtx
+
+	result, err := resolveAndGetFromStore(ctx, store, args[0], false)
+	if err != nil {
+		return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+	}
+	defer result.Close()
+	parent := result.Issue
+	if parent.Ephemeral {
+		return HandleErrorRespectJSON("cannot promote items of ephemeral issue %s", parent.ID)
+	}
+	picked, err := pickChecklistItems(types.ParseChecklist(parent.Description), args[1:])
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if picked[0].Done {
+		return HandleErrorRespectJSON("item %d of %s is already done", picked[0].Index, parent.ID)
+	}
+	candidates := checklistSplitCandidates(picked)
+	remaining, err := carveSplitCandidates(parent.Description, candidates)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	nextChild, err := store.GetNextChildID(ctx, parent.ID)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	actor := getActorWithGit()
+	var created []*types.Issue
+	err = transact(ctx, store, fmt.Sprintf("bd: promote checklist item %d of %s", picked[0].Index, parent.ID), func(tx storage.Transaction) error {
+		var err error
+		created, err = createSplitChildren(ctx, tx, parent, candidates, nextChild, false, actor)
+		if err != nil {
+			return err
+		}
+		return tx.UpdateIssue(ctx, parent.ID, map[string]interface{}{"description": remaining}, actor)
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("promoting item %d of %s: %v", picked[0].Index, parent.ID, err)
+	}
+	commandDidWrite.Store(true)
+	SetLastTouchedID(parent.ID)
+
+	out := newChecklistResult(parent.ID, remaining)
+	out.Created = created[0]
+	if jsonOutput {
+		return outputJSON(out)
+	}
+	fmt.Printf("%s Promoted item %d of %s to %s\n", ui.RenderPass("✓"), picked[0].Index, parent.ID,
+		formatFeedbackID(out.Created.ID, out.Created.Title))
+	return nil
+}
+
+// pickChecklistItems returns the items numbered by args, which may repeat,
+// in checklist order.
+func pickChecklistItems(items []types.ChecklistItem, args []string) ([]types.ChecklistItem, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("the issue has no checklist items")
+	}
+	selected, err := parseSplitSelection(strings.Join(args, ","), len(items))
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no checklist items selected")
+	}
+	picked := make([]types.ChecklistItem, len(selected))
+	for i, idx := range selected {
+		picked[i] = items[idx]
+	}
+	return picked, nil
+}
+
+// checklistSplitCandidates adapts checklist items for the bd split helpers.
+func checklistSplitCandidates(items []types.ChecklistItem) []splitCandidate {
+	candidates := make([]splitCandidate, len(items))
+	for i, item := range items {
+		candidates[i] = splitCandidate{Kind: "checklist", Title: item.Text, start: item.Line, end: item.Line + 1}
+	}
+	return candidates
+}
+
+func formatChecklistItem(item types.ChecklistItem) string {
+	if item.Done {
+		return fmt.Sprintf("  %2d. %s %s", item.Index, ui.RenderPass("[x]"), ui.RenderMuted(item.Text))
+	}
+	return fmt.Sprintf("  %2d. [ ] %s", item.Index, item.Text)
+}
+
+// formatChecklistCount renders progress as "2/5 done (40%)".
+func formatChecklistCount(p *types.ChecklistProgress) string {
+	return fmt.Sprintf("%d/%d done (%d%%)", p.Done, p.Total, p.Percent())
+}
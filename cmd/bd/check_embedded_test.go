@@ -0,0 +1,111 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedCheck(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "ck")
+	issue := bdCreate(t, bd, dir, "Ship feature", "--description", "Context.", "--priority", "1")
+
+	checklist := func(t *testing.T) checklistResult {
+		t.Helper()
+		out := bdCommand(t, bd, dir, "check", "list", issue.ID, "--json")
+		var r checklistResult
+		if err := json.Unmarshal([]byte(out), &r); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		return r
+	}
+
+	t.Run("add_creates_section", func(t *testing.T) {
+		bdCommand(t, bd, dir, "check", "add", issue.ID, "write tests", "update docs", "release")
+		if got := bdShow(t, bd, dir, issue.ID).Description; got != "Context.\n\n## Checklist\n- [ ] write tests\n- [ ] update docs\n- [ ] release" {
+			t.Errorf("description = %q", got)
+		}
+	})
+
+	t.Run("done_and_undo", func(t *testing.T) {
+		out := bdCommand(t, bd, dir, "check", "done", issue.ID, "1-2")
+		if !strings.Contains(out, "2/3 done (66%)") {
+			t.Errorf("done output: %s", out)
+		}
+		bdCommand(t, bd, dir, "check", "undo", issue.ID, "2")
+		r := checklist(t)
+		if !r.Items[0].Done || r.Items[1].Done || r.Progress.Done != 1 || r.Progress.Total != 3 {
+			t.Errorf("checklist = %+v", r)
+		}
+	})
+
+	t.Run("progress_in_show_and_list", func(t *testing.T) {
+		var details types.IssueDetails
+		if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, issue.ID)), &details); err != nil {
+			t.Fatal(err)
+		}
+		if details.Checklist == nil || details.Checklist.Done != 1 || details.Checklist.Total != 3 {
+			t.Errorf("show --json checklist = %+v", details.Checklist)
+		}
+		if out := bdCommand(t, bd, dir, "show", issue.ID); !strings.Contains(out, "Checklist 1/3 done (33%)") {
+			t.Errorf("show output missing progress:\n%s", out)
+		}
+		if out := bdCommand(t, bd, dir, "list"); !strings.Contains(out, "Ship feature [1/3]") {
+			t.Errorf("list output missing progress:\n%s", out)
+		}
+	})
+
+	t.Run("out_of_range", func(t *testing.T) {
+		out, err := bdRunWithFlockRetry(t, bd, dir, "check", "done", issue.ID, "7")
+		if err == nil || !strings.Contains(string(out), "outside 1-3") {
+			t.Errorf("expected range error, got err=%v:\n%s", err, out)
+		}
+	})
+
+	t.Run("promote", func(t *testing.T) {
+		out := bdCommand(t, bd, dir, "check", "promote", issue.ID, "2", "--json")
+		var r checklistResult
+		if err := json.Unmarshal([]byte(out), &r); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if r.Created == nil || r.Created.ID != issue.ID+".1" || r.Created.Title != "update docs" || r.Created.Priority != 1 {
+			t.Fatalf("created = %+v", r.Created)
+		}
+		if r.Progress.Total != 2 {
+			t.Errorf("promoted item should leave the checklist: %+v", r.Items)
+		}
+
+		var details types.IssueDetails
+		if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, r.Created.ID)), &details); err != nil {
+			t.Fatal(err)
+		}
+		if len(details.Dependencies) != 1 || details.Dependencies[0].ID != issue.ID || details.Dependencies[0].DependencyType != types.DepParentChild {
+			t.Errorf("child dependencies = %+v", details.Dependencies)
+		}
+
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "check", "promote", issue.ID, "1"); err == nil || !strings.Contains(string(out), "already done") {
+			t.Errorf("promoting a done item: err=%v:\n%s", err, out)
+		}
+	})
+
+	t.Run("remove_last_items_drops_section", func(t *testing.T) {
+		bdCommand(t, bd, dir, "check", "remove", issue.ID, "1", "2")
+		if got := bdShow(t, bd, dir, issue.ID).Description; got != "Context." {
+			t.Errorf("description = %q", got)
+		}
+		if r := checklist(t); len(r.Items) != 0 || r.Progress.Total != 0 {
+			t.Errorf("checklist = %+v", r)
+		}
+	})
+}
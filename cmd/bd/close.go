@@ -145,6 +145,10 @@ the flags appear in the command line.`,
 				}
 			}
 
+			// The store closes open duplicates together with the issue; list
+			// them first so the close can report them.
+			dups := openDuplicates(ctx, activeStore, id)
+
 			// Delegate the is_blocked guard to the engine (GH#962). CloseIssueChecked
 			// runs the guard and the close in ONE transaction, so there is no
 			// read-then-write TOCTOU window between the check and the close. --force
@@ -155,9 +159,9 @@ the flags appear in the command line.`,
 				Force:   force,
 			})
 			if err != nil {
-				if errors.Is(err, storage.ErrCloseBlocked) {
-					// The guard refused atomically; ErrCloseBlocked's message names the
-					// blockers. Preserve the actionable hint.
+				if errors.Is(err, storage.ErrCloseBlocked) || errors.Is(err, storage.ErrTransitionNotAllowed) {
+					// The guard refused atomically; the error names the blockers or
+					// the workflow rule. Preserve the actionable hint.
 					fmt.Fprintf(os.Stderr, "%v (use --force to override)\n", err)
 				} else {
 					fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
@@ -193,6 +197,9 @@ the flags appear in the command line.`,
 				if molID := autoCloseCompletedMolecule(ctx, activeStore, id, actor, session); molID != "" {
 					mutatedStores[activeStore] = append(mutatedStores[activeStore], molID)
 				}
+				if dups := closeOpenDuplicates(ctx, activeStore, id, actor, session); len(dups) > 0 {
+					mutatedStores[activeStore] = append(mutatedStores[activeStore], dups...)
+				}
 			} else {
 				mutatedStores[activeStore] = append(mutatedStores[activeStore], id)
 
@@ -208,6 +215,9 @@ the flags appear in the command line.`,
 				// Auto-close parent molecule if all steps are now complete.
 				// Runs against the same store the step was closed in.
 				autoCloseCompletedMolecule(ctx, activeStore, id, actor, session)
+
+				// Issues marked as duplicates of this one closed with it.
+				mutatedStores[activeStore] = append(mutatedStores[activeStore], reportClosedDuplicates(dups, id)...)
 			}
 
 			// First id this command settled as closed — a real close or an
@@ -622,6 +632,53 @@ func autoCloseCompletedMolecule(ctx context.Context, s storage.DoltStorage, clos
 	return moleculeID
 }
 
+// openDuplicates lists the open issues with a duplicates dependency on
+// canonicalID.
+func openDuplicates(ctx context.Context, s storage.DoltStorage, canonicalID string) []*types.IssueWithDependencyMetadata {
+	dependents, err := s.GetDependentsWithMetadata(ctx, canonicalID)
+	if err != nil {
+		return nil
+	}
+	var open []*types.IssueWithDependencyMetadata
+	for _, dep := range dependents {
+		if dep.DependencyType == types.DepDuplicates && dep.Status != types.StatusClosed {
+			open = append(open, dep)
+		}
+	}
+	return open
+}
+
+// reportClosedDuplicates prints the duplicates the store closed in the same
+// transaction as canonicalID (issueops.CloseOpenDuplicatesInTx), listed by
+// openDuplicates before the close, and returns their IDs.
+func reportClosedDuplicates(dups []*types.IssueWithDependencyMetadata, canonicalID string) []string {
+	var closed []string
+	for _, dup := range dups {
+		closed = append(closed, dup.ID)
+		if !jsonOutput {
+			debug.PrintNormal("%s Closed duplicate %s of %s\n", ui.RenderPass("✓"), formatFeedbackID(dup.ID, dup.Title), canonicalID)
+		}
+	}
+	return closed
+}
+
+// closeOpenDuplicates closes duplicates still open under an issue that was
+// already closed, such as ones left open by a bd older than the store-level
+// propagation, and returns their IDs. Like the molecule auto-close it is
+// state-derived and best effort: a duplicate that cannot be closed is
+// reported as a warning and does not fail the close.
+func closeOpenDuplicates(ctx context.Context, s storage.DoltStorage, canonicalID, actorName, session string) []string {
+	var closed []string
+	for _, dup := range openDuplicates(ctx, s, canonicalID) {
+		if err := s.CloseIssue(ctx, dup.ID, "duplicate of "+canonicalID, actorName, session); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not close duplicate %s: %v\n", dup.ID, err)
+			continue
+		}
+		closed = append(closed, reportClosedDuplicates([]*types.IssueWithDependencyMetadata{dup}, canonicalID)...)
+	}
+	return closed
+}
+
 // shouldAutoCloseCompletedRoot returns true for molecule roots that should
 // auto-close when their final step closes. Regular epics stay open and become
 // explicit close-eligible work, while ephemeral wisps, template-driven
@@ -46,6 +46,7 @@ type closeProxiedTxResult struct {
 	errors           []string
 	warnings         []string
 	autoClosedMol    *types.Issue
+	closedDups       []*types.Issue
 }
 
 func runCloseProxiedServer(cmd *cobra.Command, ctx context.Context, args []string) error {
@@ -80,12 +81,19 @@ func runCloseProxiedServer(cmd *cobra.Command, ctx context.Context, args []strin
 
 		for i, id := range args {
 			reason := reasonForCloseIndex(reasons, i)
+			dups := proxiedOpenDuplicates(ctx, uw, id)
 			outcome, ok := closeProxiedOne(ctx, uw, id, reason, in, &result.errors)
 			if ok {
 				mol := autoCloseProxiedCompletedMolecule(ctx, uw, id, actor, in.session, &result.warnings)
 				if mol != nil {
 					result.autoClosedMol = mol
 				}
+				if outcome.closed {
+					// The store closed the duplicates together with the issue.
+					result.closedDups = append(result.closedDups, dups...)
+				} else {
+					result.closedDups = append(result.closedDups, closeProxiedOpenDuplicates(ctx, uw, id, actor, in.session, &result.warnings)...)
+				}
 				result.outcomes = append(result.outcomes, outcome)
 				result.reasons = append(result.reasons, reason)
 			}
@@ -148,6 +156,9 @@ func runCloseProxiedServer(cmd *cobra.Command, ctx context.Context, args []strin
 		if res.autoClosedMol != nil {
 			fmt.Printf("%s Auto-closed completed molecule %s\n", ui.RenderPass("✓"), formatFeedbackID(res.autoClosedMol.ID, res.autoClosedMol.Title))
 		}
+		for _, dup := range res.closedDups {
+			fmt.Printf("%s Closed duplicate %s\n", ui.RenderPass("✓"), formatFeedbackID(dup.ID, dup.Title))
+		}
 		if len(res.unblocked) > 0 {
 			fmt.Printf("\nNewly unblocked:\n")
 			for _, issue := range res.unblocked {
@@ -390,6 +401,40 @@ func autoCloseProxiedCompletedMolecule(ctx context.Context, uw uow.UnitOfWork, c
 	return root
 }
 
+// proxiedOpenDuplicates is the proxied-server openDuplicates.
+func proxiedOpenDuplicates(ctx context.Context, uw uow.UnitOfWork, canonicalID string) []*types.Issue {
+	dependents, err := uw.DependencyUseCase().ListWithIssueMetadata(ctx, canonicalID, domain.DepListFilter{
+		Types:     []types.DependencyType{types.DepDuplicates},
+		Direction: domain.DepDirectionIn,
+	})
+	if err != nil {
+		return nil
+	}
+	var open []*types.Issue
+	for _, dep := range dependents {
+		if dep.DependencyType != types.DepDuplicates || dep.Status == types.StatusClosed {
+			continue
+		}
+		dup := dep.Issue
+		open = append(open, &dup)
+	}
+	return open
+}
+
+// closeProxiedOpenDuplicates is the proxied-server closeOpenDuplicates.
+func closeProxiedOpenDuplicates(ctx context.Context, uw uow.UnitOfWork, canonicalID, actorName, session string, warnings *[]string) []*types.Issue {
+	var closed []*types.Issue
+	for _, dup := range proxiedOpenDuplicates(ctx, uw, canonicalID) {
+		params := domain.CloseIssueParams{Reason: "duplicate of " + canonicalID, Session: session}
+		if _, err := uw.IssueUseCase().CloseIssue(ctx, dup.ID, params, actorName); err != nil {
+			*warnings = append(*warnings, fmt.Sprintf("could not close duplicate %s: %v", dup.ID, err))
+			continue
+		}
+		closed = append(closed, dup)
+	}
+	return closed
+}
+
 func proxiedFindParentMolecule(ctx context.Context, uw uow.UnitOfWork, issueID string) string {
 	current := issueID
 	for depth := 0; depth < 50; depth++ {
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/uimd"
 )
 
@@ -28,7 +31,11 @@ Examples:
   bd comments add bd-123 "This is a comment"
 
   # Add a comment from a file
-  bd comments add bd-123 -f notes.txt`,
+  bd comments add bd-123 -f notes.txt
+
+  # Fix or remove a comment (IDs are shown in the list)
+  bd comments edit bd-123 0190f2a1 "Corrected text"
+  bd comments delete bd-123 0190f2a1`,
 	Args:          cobra.MinimumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -92,7 +99,7 @@ Examples:
 			if localTime {
 				ts = ts.Local()
 			}
-			fmt.Printf("[%s] at %s\n", comment.Author, ts.Format("2006-01-02 15:04"))
+			fmt.Printf("[%s] at %s %s\n", comment.Author, ts.Format("2006-01-02 15:04"), ui.RenderMuted(comment.ID))
 			rendered := uimd.RenderMarkdown(comment.Text)
 			for _, line := range strings.Split(strings.TrimRight(rendered, "\n"), "\n") {
 				fmt.Printf("  %s\n", line)
@@ -214,16 +221,173 @@ Examples:
 	},
 }
 
+var commentsEditCmd = &cobra.Command{
+	Use:   "edit <issue-id> <comment-id> [text]",
+	Short: "Replace the text of a comment",
+	Long: `Replace the text of a comment, keeping its author and timestamp.
+
+Comment IDs are shown by 'bd comments <issue-id>'; any unique prefix of one
+works. The previous text remains in Dolt history.
+
+Examples:
+  bd comments edit bd-123 0190f2a1 "Corrected text"
+  bd comments edit bd-123 0190f2a1 -f notes.txt`,
+	Args:          cobra.RangeArgs(2, 3),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("comment edit")
+
+		evt := metrics.NewCommandEvent("comments-edit")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("comments edit is not supported in proxied-server mode")
+		}
+
+		commentText, _ := cmd.Flags().GetString("file")
+		if commentText != "" {
+			data, err := os.ReadFile(commentText) // #nosec G304 - user-provided file path is intentional
+			if err != nil {
+				return HandleErrorRespectJSON("reading file: %v", err)
+			}
+			commentText = string(data)
+		} else if len(args) < 3 {
+			return HandleErrorRespectJSON("comment text required (use -f to read from file)")
+		} else {
+			commentText = args[2]
+		}
+		if strings.TrimSpace(commentText) == "" {
+			return HandleErrorRespectJSON("comment text cannot be empty (use 'bd comments delete' to remove a comment)")
+		}
+
+		ctx := rootCtx
+		result, editor, err := resolveCommentEditor(ctx, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer result.Close()
+
+		comment, err := editor.EditIssueComment(ctx, result.ResolvedID, args[1], commentText)
+		if err != nil {
+			return HandleErrorRespectJSON("editing comment: %v", err)
+		}
+		if err := commitPendingIfEmbedded(ctx, result.Store, actor, doltAutoCommitParams{
+			Command:  "comments edit",
+			IssueIDs: []string{result.ResolvedID},
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(comment)
+		}
+		fmt.Printf("Comment %s on %s updated\n", comment.ID, result.ResolvedID)
+		return nil
+	},
+}
+
+var commentsDeleteCmd = &cobra.Command{
+	Use:     "delete <issue-id> <comment-id>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a comment",
+	Long: `Delete a comment from an issue.
+
+Comment IDs are shown by 'bd comments <issue-id>'; any unique prefix of one
+works. The comment remains in Dolt history.
+
+Examples:
+  bd comments delete bd-123 0190f2a1`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("comment delete")
+
+		evt := metrics.NewCommandEvent("comments-delete")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("comments delete is not supported in proxied-server mode")
+		}
+
+		ctx := rootCtx
+		result, editor, err := resolveCommentEditor(ctx, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer result.Close()
+
+		if err := editor.DeleteIssueComment(ctx, result.ResolvedID, args[1]); err != nil {
+			return HandleErrorRespectJSON("deleting comment: %v", err)
+		}
+		if err := commitPendingIfEmbedded(ctx, result.Store, actor, doltAutoCommitParams{
+			Command:  "comments delete",
+			IssueIDs: []string{result.ResolvedID},
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"issue_id": result.ResolvedID, "deleted": args[1]})
+		}
+		fmt.Printf("Comment %s deleted from %s\n", args[1], result.ResolvedID)
+		return nil
+	},
+}
+
+// resolveCommentEditor resolves issueID for mutation and returns the store
+// holding it as a CommentEditor. The caller must Close the result.
+func resolveCommentEditor(ctx context.Context, issueID string) (*RoutedResult, storage.CommentEditor, error) {
+	if err := ensureStoreActive(); err != nil {
+		return nil, nil, err
+	}
+	result, err := resolveAndGetIssueForMutation(ctx, store, issueID)
+	if err != nil {
+		if result != nil {
+			result.Close()
+		}
+		return nil, nil, fmt.Errorf("resolving %s: %w", issueID, err)
+	}
+	if result == nil || result.Issue == nil {
+		if result != nil {
+			result.Close()
+		}
+		return nil, nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	editor, ok := storage.UnwrapStore(result.Store).(storage.CommentEditor)
+	if !ok {
+		result.Close()
+		return nil, nil, fmt.Errorf("storage backend does not support editing comments")
+	}
+	return result, editor, nil
+}
+
 func init() {
 	commentsCmd.AddCommand(commentsMisplacedListCmd)
 	commentsCmd.AddCommand(commentsAddCmd)
+	commentsCmd.AddCommand(commentsEditCmd)
+	commentsCmd.AddCommand(commentsDeleteCmd)
 	commentsCmd.Flags().Bool("local-time", false, "Show timestamps in local time instead of UTC")
 	commentsAddCmd.Flags().StringP("file", "f", "", "Read comment text from file")
 	commentsAddCmd.Flags().StringP("author", "a", "", "Add author to comment")
+	commentsEditCmd.Flags().StringP("file", "f", "", "Read new comment text from file")
 
 	// Issue ID completions
 	commentsCmd.ValidArgsFunction = issueIDCompletion
 	commentsAddCmd.ValidArgsFunction = issueIDCompletion
+	commentsEditCmd.ValidArgsFunction = issueIDCompletion
+	commentsDeleteCmd.ValidArgsFunction = issueIDCompletion
 
 	rootCmd.AddCommand(commentsCmd)
 }
@@ -153,6 +153,52 @@ func TestEmbeddedCommentsCLI(t *testing.T) {
 		_ = stdout.String()
 	})
 
+	// ===== comments edit / delete =====
+
+	t.Run("comments_edit_and_delete", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Editable comments", "--type", "task")
+		cmd := exec.Command(bd, "comments", "add", issue.ID, "Typo heer", "--json")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		stdout, stderr, err := runCommandBuffers(t, cmd)
+		if err != nil {
+			t.Fatalf("bd comments add --json failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+		}
+		s := stdout.String()
+		var added map[string]interface{}
+		if err := json.Unmarshal([]byte(s[strings.Index(s, "{"):]), &added); err != nil {
+			t.Fatalf("parse comment JSON: %v\n%s", err, s)
+		}
+		id, _ := added["id"].(string)
+		bdComments(t, bd, dir, "add", issue.ID, "Keep me")
+
+		if out := bdComments(t, bd, dir, issue.ID); !strings.Contains(out, id) {
+			t.Errorf("expected comment id %s in list: %s", id, out)
+		}
+		// A unique prefix addresses the comment.
+		out := bdComments(t, bd, dir, "edit", issue.ID, id[:len(id)-4], "Typo here")
+		if !strings.Contains(out, "updated") {
+			t.Errorf("expected 'updated' in output: %s", out)
+		}
+		out = bdComments(t, bd, dir, issue.ID)
+		if !strings.Contains(out, "Typo here") || strings.Contains(out, "Typo heer") {
+			t.Errorf("edit not reflected in list: %s", out)
+		}
+
+		bdComments(t, bd, dir, "delete", issue.ID, id)
+		out = bdComments(t, bd, dir, issue.ID)
+		if strings.Contains(out, "Typo here") || !strings.Contains(out, "Keep me") {
+			t.Errorf("delete removed the wrong comments: %s", out)
+		}
+
+		cmd = exec.Command(bd, "comments", "delete", issue.ID, id)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		if _, _, err := runCommandBuffers(t, cmd); err == nil {
+			t.Error("deleting an already-deleted comment succeeded")
+		}
+	})
+
 	// ===== Round-trip =====
 
 	t.Run("comments_add_then_list_round_trip", func(t *testing.T) {
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var backfillCommitsCmd = &cobra.Command{
+	Use:     "backfill-commits [revision-range...]",
+	GroupID: "sync",
+	Short:   "Link git commits to the issues their messages mention",
+	Long: `Scan git commit messages for issue IDs and record a link between each
+commit and the issues it mentions, for 'bd show' and 'bd commits'.
+
+Revisions are passed to 'git log' (default: HEAD, i.e. the whole history of
+the current branch). Only IDs with the project's issue prefix (or one of
+allowed_prefixes) that name an existing issue are linked, and links already
+recorded are skipped, so the scan can be repeated.
+
+With --close, an issue mentioned after a closing keyword is closed by the
+commit that newly links it:
+
+  close, closes, closed, fix, fixes, fixed, resolve, resolves, resolved
+
+e.g. "Fix crash on save (fixes bd-12)". The keyword applies to the ID right
+after it. Issues with open blockers are left open with a warning.
+
+The post-commit git hook runs this for each new commit, with --close
+(see 'bd hooks config' to turn either behavior off).
+
+Examples:
+  bd backfill-commits                       # Whole history of HEAD
+  bd backfill-commits v1.2.0..HEAD          # Commits since a tag
+  bd backfill-commits --all --since 90d     # Every branch, last 90 days
+  bd backfill-commits -n 1 --close HEAD     # What the post-commit hook does`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("backfill-commits")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("backfill-commits is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("backfill-commits")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		closeIssues, _ := cmd.Flags().GetBool("close")
+		maxCount, _ := cmd.Flags().GetInt("max-count")
+		all, _ := cmd.Flags().GetBool("all")
+		since, _ := cmd.Flags().GetString("since")
+
+		gitArgs := []string{"--reverse"}
+		if maxCount > 0 {
+			gitArgs = append(gitArgs, fmt.Sprintf("--max-count=%d", maxCount))
+		}
+		if since != "" {
+			// git understands dates and "N days ago"; accept the Nd
+			// shorthand used by other bd commands too.
+			if days, err := strconv.Atoi(strings.TrimSuffix(since, "d")); err == nil && strings.HasSuffix(since, "d") {
+				since = fmt.Sprintf("%d days ago", days)
+			}
+			gitArgs = append(gitArgs, "--since="+since)
+		}
+		if all {
+			gitArgs = append(gitArgs, "--all")
+		} else if len(args) == 0 {
+			args = []string{"HEAD"}
+		}
+		commits, err := readGitCommits(append(gitArgs, args...))
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		ctx := rootCtx
+		result, err := linkCommits(ctx, store, commits, closeIssues)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if len(result.Linked) > 0 || len(result.Closed) > 0 {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			return outputJSON(result)
+		}
+		for _, id := range result.Closed {
+			fmt.Printf("%s Closed %s (%s)\n", ui.RenderPass("✓"), id, result.closedBy[id])
+		}
+		issues := make(map[string]bool)
+		for _, link := range result.Linked {
+			issues[link.IssueID] = true
+		}
+		debug.PrintNormal("Scanned %d commit(s): %d new link(s) to %d issue(s)\n", result.Scanned, len(result.Linked), len(issues))
+		return nil
+	},
+}
+
+var commitsCmd = &cobra.Command{
+	Use:     "commits <id>",
+	GroupID: "views",
+	Short:   "Show the git commits linked to an issue",
+	Long: `Show the git commits linked to an issue (see 'bd backfill-commits'),
+oldest first, each followed by its diff.
+
+Commits that are not in the local repository (e.g. linked from another
+clone, or rewritten since) are listed without a diff.
+
+Examples:
+  bd commits bd-12            # Commits with full diffs
+  bd commits bd-12 --stat     # Diffstat only
+  bd commits bd-12 --no-diff  # Just the list`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("commits is not supported in proxied-server mode")
+		}
+		stat, _ := cmd.Flags().GetBool("stat")
+		noDiff, _ := cmd.Flags().GetBool("no-diff")
+
+		ctx := rootCtx
+		result, err := resolveAndGetIssueWithRouting(ctx, store, args[0])
+		if err != nil || result == nil || result.Issue == nil {
+			if result != nil {
+				result.Close()
+			}
+			return HandleErrorRespectJSON("issue %s not found", args[0])
+		}
+		defer result.Close()
+		issue := result.Issue
+		links := getIssueCommitLinks(ctx, result.Store, issue.ID)
+
+		diffArgs := []string{"--patch", "--stat"}
+		if stat {
+			diffArgs = []string{"--stat"}
+		}
+
+		if jsonOutput {
+			type commitWithDiff struct {
+				*types.CommitLink
+				Missing bool   `json:"missing,omitempty"`
+				Diff    string `json:"diff,omitempty"`
+			}
+			out := make([]commitWithDiff, 0, len(links))
+			for _, link := range links {
+				entry := commitWithDiff{CommitLink: link, Missing: !gitHasCommit(link.CommitSHA)}
+				if !entry.Missing && !noDiff {
+					show := exec.Command("git", append([]string{"show", "--no-color", "--format="}, append(diffArgs, link.CommitSHA)...)...) // #nosec G204 -- sha validated on insert
+					diff, _ := show.Output()
+					entry.Diff = string(diff)
+				}
+				out = append(out, entry)
+			}
+			return outputJSON(out)
+		}
+
+		fmt.Printf("Commits linked to %s: %s\n", issue.ID, issue.Title)
+		if len(links) == 0 {
+			fmt.Println("\nNo linked commits. Run 'bd backfill-commits' to scan history.")
+			return nil
+		}
+		for _, link := range links {
+			fmt.Println()
+			if !gitHasCommit(link.CommitSHA) {
+				fmt.Printf("%s\n", formatCommitLinkLine(link))
+				fmt.Printf("    %s\n", ui.RenderMuted("(commit not in this repository)"))
+				continue
+			}
+			if noDiff {
+				fmt.Printf("%s\n", formatCommitLinkLine(link))
+				continue
+			}
+			show := exec.Command("git", append([]string{"--no-pager", "show"}, append(diffArgs, link.CommitSHA)...)...) // #nosec G204 -- sha validated on insert
+			show.Stdout = os.Stdout
+			show.Stderr = os.Stderr
+			if err := show.Run(); err != nil {
+				return HandleErrorRespectJSON("git show %s: %v", link.CommitSHA, err)
+			}
+		}
+		return nil
+	},
+}
+
+// gitCommit is one commit read by readGitCommits.
+type gitCommit struct {
+	SHA         string
+	Author      string
+	CommittedAt time.Time
+	Message     string
+}
+
+// Subject returns the first line of the commit message.
+func (c *gitCommit) Subject() string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+	return strings.TrimSpace(subject)
+}
+
+// readGitCommits runs git log with args in the current directory.
+func readGitCommits(args []string) ([]*gitCommit, error) {
+	// Fields are separated by US and records by RS, which do not occur in
+	// commit messages.
+	logArgs := append([]string{"log", "--no-color", "--format=%H%x1f%an%x1f%cI%x1f%B%x1e"}, args...)
+	cmd := exec.Command("git", logArgs...) // #nosec G204 -- revisions passed through to git log
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git log: %s", msg)
+		}
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	var commits []*gitCommit
+	for _, record := range strings.Split(string(out), "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		committedAt, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, &gitCommit{SHA: fields[0], Author: fields[1], CommittedAt: committedAt, Message: fields[3]})
+	}
+	return commits, nil
+}
+
+// commitMention is an issue ID found in a commit message.
+type commitMention struct {
+	ID     string
+	Closes bool
+}
+
+// commitClosingKeyword matches a closing keyword at the end of the text
+// before an issue ID.
+var commitClosingKeyword = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s*:?\s*$`)
+
+// commitIDPattern builds the pattern for issue IDs with the given prefixes:
+// prefix-<hash or number>, with optional .N child suffixes, not preceded by
+// a word character so "xbd-1" is not a mention of "bd-1".
+func commitIDPattern(prefixes []string) *regexp.Regexp {
+	quoted := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		quoted[i] = regexp.QuoteMeta(p)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(quoted))) // longest alternative first for shared stems
+	return regexp.MustCompile(`(?:^|[^\w.-])((?:` + strings.Join(quoted, "|") + `)-[a-z0-9]+(?:\.[0-9]+)*)\b`)
+}
+
+// scanCommitMessage returns the issue IDs a commit message mentions, in
+// order of first mention. An ID counts as closed if any of its mentions
+// follows a closing keyword.
+func scanCommitMessage(message string, idPattern *regexp.Regexp) []commitMention {
+	var mentions []commitMention
+	index := make(map[string]int)
+	for _, loc := range idPattern.FindAllStringSubmatchIndex(message, -1) {
+		id := message[loc[2]:loc[3]]
+		closes := commitClosingKeyword.MatchString(message[:loc[2]])
+		if i, ok := index[id]; ok {
+			mentions[i].Closes = mentions[i].Closes || closes
+			continue
+		}
+		index[id] = len(mentions)
+		mentions = append(mentions, commitMention{ID: id, Closes: closes})
+	}
+	return mentions
+}
+
+// commitLinkResult reports what linkCommits did.
+type commitLinkResult struct {
+	Scanned  int                 `json:"scanned"`
+	Linked   []*types.CommitLink `json:"linked"`
+	Closed   []string            `json:"closed"`
+	closedBy map[string]string
+}
+
+// linkCommits records links for the issues each commit mentions and, with
+// closeIssues, closes the issues a newly linked commit names after a closing
+// keyword. Re-scanning a commit neither duplicates its links nor re-closes
+// an issue that was reopened since.
+func linkCommits(ctx context.Context, s storage.DoltStorage, commits []*gitCommit, closeIssues bool) (*commitLinkResult, error) {
+	result := &commitLinkResult{Scanned: len(commits), Linked: []*types.CommitLink{}, Closed: []string{}, closedBy: map[string]string{}}
+	linkStore, ok := storage.UnwrapStore(s).(storage.CommitLinkStore)
+	if !ok {
+		return nil, fmt.Errorf("storage backend does not support commit links")
+	}
+
+	var prefixes []string
+	if p, err := s.GetConfig(ctx, "issue_prefix"); err == nil && p != "" {
+		prefixes = append(prefixes, p)
+	}
+	if allowed, err := s.GetConfig(ctx, "allowed_prefixes"); err == nil {
+		for _, p := range strings.Split(allowed, ",") {
+			if p = strings.TrimSuffix(strings.TrimSpace(p), "-"); p != "" {
+				prefixes = append(prefixes, p)
+			}
+		}
+	}
+	if len(prefixes) == 0 {
+		return nil, fmt.Errorf("no issue prefix configured")
+	}
+	idPattern := commitIDPattern(prefixes)
+
+	// Resolve each mentioned ID once; unknown IDs and wisps are not linked.
+	known := make(map[string]*types.Issue)
+	var candidates []*types.CommitLink
+	for _, commit := range commits {
+		for _, m := range scanCommitMessage(commit.Message, idPattern) {
+			issue, seen := known[m.ID]
+			if !seen {
+				issue, _ = s.GetIssue(ctx, m.ID)
+				if issue != nil && issue.Ephemeral {
+					issue = nil
+				}
+				known[m.ID] = issue
+			}
+			if issue == nil {
+				continue
+			}
+			candidates = append(candidates, &types.CommitLink{
+				IssueID:     m.ID,
+				CommitSHA:   commit.SHA,
+				Subject:     commit.Subject(),
+				Author:      commit.Author,
+				CommittedAt: commit.CommittedAt,
+				Closes:      m.Closes,
+			})
+		}
+	}
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(known))
+	for id, issue := range known {
+		if issue != nil {
+			ids = append(ids, id)
+		}
+	}
+	existing, err := linkStore.GetCommitLinksForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	linked := make(map[string]bool)
+	for id, links := range existing {
+		for _, link := range links {
+			linked[id+" "+link.CommitSHA] = true
+		}
+	}
+	for _, link := range candidates {
+		if key := link.IssueID + " " + link.CommitSHA; !linked[key] {
+			linked[key] = true
+			result.Linked = append(result.Linked, link)
+		}
+	}
+	if len(result.Linked) == 0 {
+		return result, nil
+	}
+	if _, err := linkStore.AddCommitLinks(ctx, result.Linked); err != nil {
+		return nil, err
+	}
+
+	if !closeIssues {
+		return result, nil
+	}
+	for _, link := range result.Linked {
+		issue := known[link.IssueID]
+		if !link.Closes || issue.Status == types.StatusClosed || result.closedBy[link.IssueID] != "" {
+			continue
+		}
+		short := link.CommitSHA[:min(len(link.CommitSHA), 12)]
+		reason := fmt.Sprintf("Closed by commit %s: %s", short, link.Subject)
+		_, err := s.CloseIssueChecked(ctx, link.IssueID, actor, storage.CloseIssueOptions{Reason: reason})
+		if errors.Is(err, storage.ErrCloseBlocked) {
+			fmt.Fprintf(os.Stderr, "Not closing %s from commit %s: %v\n", link.IssueID, short, err)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("closing %s: %w", link.IssueID, err)
+		}
+		audit.LogFieldChange(link.IssueID, "status", string(issue.Status), "closed", actor, reason)
+		result.Closed = append(result.Closed, link.IssueID)
+		result.closedBy[link.IssueID] = "commit " + short
+	}
+	return result, nil
+}
+
+// gitHasCommit reports whether sha names a commit in the local repository.
+func gitHasCommit(sha string) bool {
+	return exec.Command("git", "cat-file", "-e", sha+"^{commit}").Run() == nil // #nosec G204 -- sha validated on insert
+}
+
+// getIssueCommitLinks returns the commits linked to issueID, or nil when
+// the store does not support them. Best effort, like the other show sections.
+func getIssueCommitLinks(ctx context.Context, st storage.DoltStorage, issueID string) []*types.CommitLink {
+	linkStore, ok := storage.UnwrapStore(st).(storage.CommitLinkStore)
+	if !ok {
+		return nil
+	}
+	byIssue, err := linkStore.GetCommitLinksForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil
+	}
+	return byIssue[issueID]
+}
+
+func formatCommitLinkLine(link *types.CommitLink) string {
+	line := fmt.Sprintf("  %s  %s", ui.RenderAccent(link.CommitSHA[:min(len(link.CommitSHA), 10)]), link.Subject)
+	var meta []string
+	if !link.CommittedAt.IsZero() {
+		meta = append(meta, link.CommittedAt.Local().Format("2006-01-02"))
+	}
+	if link.Author != "" {
+		meta = append(meta, link.Author)
+	}
+	if link.Closes {
+		meta = append(meta, "closes")
+	}
+	if len(meta) > 0 {
+		line += "  " + ui.RenderMuted("("+strings.Join(meta, ", ")+")")
+	}
+	return line
+}
+
+func printCommitLinks(links []*types.CommitLink) {
+	for _, link := range links {
+		fmt.Println(formatCommitLinkLine(link))
+	}
+}
+
+func init() {
+	backfillCommitsCmd.Flags().Bool("close", false, "Close issues named after a closing keyword (fixes, closes, resolves)")
+	backfillCommitsCmd.Flags().IntP("max-count", "n", 0, "Scan at most this many commits (0 = no limit)")
+	backfillCommitsCmd.Flags().Bool("all", false, "Scan the commits of every branch and tag")
+	backfillCommitsCmd.Flags().String("since", "", "Only scan commits newer than this (e.g. 30d, 2026-01-01)")
+	commitsCmd.Flags().Bool("stat", false, "Show a diffstat instead of the full diff")
+	commitsCmd.Flags().Bool("no-diff", false, "List the commits without diffs")
+	commitsCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(backfillCommitsCmd)
+	rootCmd.AddCommand(commitsCmd)
+}
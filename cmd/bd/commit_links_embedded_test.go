@@ -0,0 +1,123 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitCommitFile writes a file and commits it with message, with hooks
+// disabled so the test drives linking itself. Returns the commit SHA.
+func gitCommitFile(t *testing.T, dir, name, message string) string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(message), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"add", name},
+		{"-c", "core.hooksPath=" + t.TempDir(), "commit", "-q", "-m", message},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestEmbeddedBackfillCommits(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "cl")
+	fixed := bdCreateSilent(t, bd, dir, "Crash on save")
+	mentioned := bdCreateSilent(t, bd, dir, "Refactor saving")
+
+	first := gitCommitFile(t, dir, "a.txt", "Fix crash on save\n\nFixes "+fixed+", see "+mentioned+" and cl-zzzz")
+	second := gitCommitFile(t, dir, "b.txt", "Tidy up after "+mentioned)
+
+	var result struct {
+		Scanned int `json:"scanned"`
+		Linked  []struct {
+			IssueID   string `json:"issue_id"`
+			CommitSHA string `json:"commit"`
+		} `json:"linked"`
+		Closed []string `json:"closed"`
+	}
+	if err := json.Unmarshal([]byte(bdCommand(t, bd, dir, "backfill-commits", "--close", "--json")), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Scanned < 2 || len(result.Linked) != 3 {
+		t.Fatalf("backfill result = %+v, want 3 links (unknown cl-zzzz skipped)", result)
+	}
+	if len(result.Closed) != 1 || result.Closed[0] != fixed {
+		t.Errorf("closed = %v, want [%s]", result.Closed, fixed)
+	}
+
+	var issue struct {
+		Status      string `json:"status"`
+		CloseReason string `json:"close_reason"`
+		Commits     []struct {
+			CommitSHA string `json:"commit"`
+			Subject   string `json:"subject"`
+			Closes    bool   `json:"closes"`
+		} `json:"commits"`
+	}
+	if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, fixed)), &issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue.Status != "closed" || !strings.Contains(issue.CloseReason, first[:12]) {
+		t.Errorf("%s status = %q, reason %q; want closed by %s", fixed, issue.Status, issue.CloseReason, first)
+	}
+	if len(issue.Commits) != 1 || issue.Commits[0].Subject != "Fix crash on save" || !issue.Commits[0].Closes {
+		t.Errorf("%s commits = %+v", fixed, issue.Commits)
+	}
+
+	// Re-scanning links nothing new and does not close the reopened issue.
+	bdCommand(t, bd, dir, "reopen", fixed)
+	if err := json.Unmarshal([]byte(bdCommand(t, bd, dir, "backfill-commits", "--close", "--json")), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Linked) != 0 || len(result.Closed) != 0 {
+		t.Errorf("rescan = %+v, want no changes", result)
+	}
+
+	out := bdCommand(t, bd, dir, "show", mentioned)
+	if !strings.Contains(out, "COMMITS") || !strings.Contains(out, second[:10]) || !strings.Contains(out, first[:10]) {
+		t.Errorf("show %s missing commits:\n%s", mentioned, out)
+	}
+
+	out = bdCommand(t, bd, dir, "commits", mentioned, "--stat")
+	if !strings.Contains(out, "b.txt") || !strings.Contains(out, "Tidy up after") {
+		t.Errorf("commits --stat output:\n%s", out)
+	}
+	var commits []struct {
+		CommitSHA string `json:"commit"`
+		Diff      string `json:"diff"`
+	}
+	if err := json.Unmarshal([]byte(bdCommand(t, bd, dir, "commits", mentioned, "--json")), &commits); err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("commits --json = %+v", commits)
+	}
+	// Both commits land in the same second, so either may be listed first.
+	for _, c := range commits {
+		if c.CommitSHA == second && !strings.Contains(c.Diff, "+Tidy up after") {
+			t.Errorf("diff for %s = %q", second, c.Diff)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanCommitMessage(t *testing.T) {
+	pattern := commitIDPattern([]string{"bd", "bd-ext"})
+	tests := []struct {
+		name    string
+		message string
+		want    []commitMention
+	}{
+		{
+			name:    "plain mention",
+			message: "Refactor parser (bd-a1b2)",
+			want:    []commitMention{{ID: "bd-a1b2"}},
+		},
+		{
+			name:    "closing keywords",
+			message: "Fixes bd-1, closes: bd-2 and refs bd-3\n\nResolved BD-4? no; Resolved bd-5",
+			want:    []commitMention{{ID: "bd-1", Closes: true}, {ID: "bd-2", Closes: true}, {ID: "bd-3"}, {ID: "bd-5", Closes: true}},
+		},
+		{
+			name:    "child IDs and other prefixes",
+			message: "fix bd-ext-9 and bd-abc.1.2",
+			want:    []commitMention{{ID: "bd-ext-9", Closes: true}, {ID: "bd-abc.1.2"}},
+		},
+		{
+			name:    "repeated mention closes once mentioned with keyword",
+			message: "Work on bd-7\n\nfixed bd-7",
+			want:    []commitMention{{ID: "bd-7", Closes: true}},
+		},
+		{
+			name:    "embedded in other words",
+			message: "xbd-1 prefix-bd-3 a.bd-4 (see bd-2)",
+			want:    []commitMention{{ID: "bd-2"}},
+		},
+		{
+			name:    "keyword must directly precede",
+			message: "fix typo in bd-8",
+			want:    []commitMention{{ID: "bd-8"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanCommitMessage(tt.message, pattern); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scanCommitMessage(%q) = %+v, want %+v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/beads/internal/git"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/remotecache"
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/tracker"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -40,6 +41,8 @@ Common namespaces:
   - custom.*          Custom integration settings
   - status.*          Issue status configuration
   - claim.*           Claim arbitration settings (pool-aware claiming)
+  - merge.*           JSONL export merge settings (stored in config.yaml)
+  - hooks.*           Per-repo git hook behavior toggles (see 'bd hooks config')
   - doctor.suppress.* Suppress specific bd doctor warnings (GH#1095)
 
 Auto-Export (config.yaml):
@@ -63,6 +66,17 @@ Auto-Import (config.yaml):
   Keys:
     import.path       Input filename relative to .beads/ (default: issues.jsonl)
 
+Merge Auto-Resolution (config.yaml):
+  When enabled, 'bd hooks install' registers 'bd merge-file' as the git merge
+  driver for the JSONL export so branches merge it by issue ID, and the
+  post-merge hook regenerates an export left with conflict markers from the
+  Dolt database (when a Dolt remote is configured).
+
+  Keys:
+    merge.auto_resolve  Enable merge-time JSONL resolution (default: false)
+    merge.strategy      bd merge-file field collision policy: union, ours,
+                        theirs (default: union)
+
 Custom Status States:
   You can define custom status states for multi-step pipelines using the
   status.custom config key. Statuses should be comma-separated.
@@ -223,6 +237,11 @@ var configSetCmd = &cobra.Command{
 				return HandleError("invalid status.custom value: %v", err)
 			}
 		}
+		if key == issueops.StatusTransitionsConfigKey && value != "" {
+			if _, err := types.ParseStatusTransitions(value); err != nil {
+				return HandleError("invalid %s value: %v", key, err)
+			}
+		}
 
 		if err := store.SetConfig(ctx, key, value); err != nil {
 			return HandleError("setting config: %v", err)
@@ -825,6 +844,11 @@ Examples:
 					return HandleError("invalid status.custom value: %v", err)
 				}
 			}
+			if p.key == issueops.StatusTransitionsConfigKey && p.value != "" {
+				if _, err := types.ParseStatusTransitions(p.value); err != nil {
+					return HandleError("invalid %s value: %v", p.key, err)
+				}
+			}
 		}
 
 		var yamlPairs, gitPairs, dbPairs []kvPair
@@ -940,7 +964,7 @@ var recognizedConfigPrefixes = []string{
 	"status.", "types.", "doctor.suppress.", "routing.", "sync.", "git.",
 	"directory.", "repos.", "external_projects.", "validation.",
 	"hierarchy.", "ai.", "backup.", "federation.", "metrics.", "agent.",
-	"claim.",
+	"claim.", "merge.", "hooks.",
 }
 
 // allRecognizedConfigPrefixes returns the static namespaces plus the prefix of
@@ -963,8 +987,8 @@ var recognizedConfigKeys = map[string]bool{
 	"identity": true, "no-push": true, "no-git-ops": true,
 	"create.require-description": true, "beads.role": true,
 	"auto_compact_enabled": true, "schema_version": true,
-	"output.title-length": true,
-	"prime.max-memories":  true, "prime.max-memory-chars": true,
+	"output.title-length": true, "id.counter-range": true,
+	"prime.max-memories": true, "prime.max-memory-chars": true,
 }
 
 func isRecognizedConfigKey(key string) bool {
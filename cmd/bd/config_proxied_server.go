@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/storage/uow"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -15,6 +16,11 @@ func runConfigSetProxiedServer(ctx context.Context, key, value string) error {
 			return HandleErrorRespectJSON("invalid status.custom value: %v", err)
 		}
 	}
+	if key == issueops.StatusTransitionsConfigKey && value != "" {
+		if _, err := types.ParseStatusTransitions(value); err != nil {
+			return HandleErrorRespectJSON("invalid %s value: %v", key, err)
+		}
+	}
 
 	if uowProvider == nil {
 		return HandleErrorRespectJSON("proxied-server UOW provider not initialized")
@@ -147,6 +147,7 @@ func parseCountFilter(cmd *cobra.Command) (types.IssueFilter, string, string, bo
 		filter.Priority = &priority
 	}
 	if assignee != "" {
+		assignee = resolveAssignee(assignee)
 		filter.Assignee = &assignee
 	}
 	if issueType != "" {
@@ -45,6 +45,9 @@ var createCmd = &cobra.Command{
 		}()
 
 		if usesProxiedServer() {
+			if fromTemplate, _ := cmd.Flags().GetString("from-template"); fromTemplate != "" {
+				return HandleErrorRespectJSON("create --from-template is not supported in proxied-server mode")
+			}
 			in, err := gatherCreateInput(cmd, args)
 			if err != nil {
 				return err
@@ -53,6 +56,14 @@ var createCmd = &cobra.Command{
 		}
 		file, _ := cmd.Flags().GetString("file")
 		graphFile, _ := cmd.Flags().GetString("graph")
+		fromTemplate, _ := cmd.Flags().GetString("from-template")
+
+		if fromTemplate != "" {
+			if file != "" || graphFile != "" {
+				return HandleError("cannot combine --from-template with --file or --graph")
+			}
+			return createIssuesFromTemplate(cmd, args, fromTemplate)
+		}
 
 		if file != "" {
 			if graphFile != "" {
@@ -761,6 +772,8 @@ func createDepsAcceptedTypeList() string {
 func init() {
 	createCmd.Flags().StringP("file", "f", "", "Create multiple issues from markdown file")
 	createCmd.Flags().String("graph", "", "Create a graph of issues with dependencies from JSON plan file")
+	createCmd.Flags().String("from-template", "", "Create issues from a YAML template in .beads/templates/ (name or path to .yaml)")
+	createCmd.Flags().StringArray("var", []string{}, "Template variable for --from-template (key=value, repeatable)")
 	createCmd.Flags().String("title", "", "Issue title (alternative to positional argument)")
 	createCmd.Flags().Bool("silent", false, "Output only the issue ID (for scripting)")
 	createCmd.Flags().Bool("dry-run", false, "Preview what would be created without actually creating")
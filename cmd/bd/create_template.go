@@ -0,0 +1,332 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// IssueTemplatesDir is the directory under .beads/ holding the YAML issue
+// templates used by 'bd create --from-template'.
+const IssueTemplatesDir = "templates"
+
+// issueTemplateRootKey is the graph plan key of a template's root issue.
+const issueTemplateRootKey = "root"
+
+// issueTemplate is a parameterized issue read from .beads/templates/*.yaml.
+// Text fields may contain {{variable}} placeholders, filled from --var
+// values and the defaults declared under vars.
+type issueTemplate struct {
+	Vars              map[string]issueTemplateVar `yaml:"vars"`
+	issueTemplateNode `yaml:",inline"`
+}
+
+// issueTemplateVar declares a template variable. A variable without a
+// default must be given with --var.
+type issueTemplateVar struct {
+	Description string  `yaml:"description"`
+	Default     *string `yaml:"default"`
+}
+
+// issueTemplateNode is one issue of a template: the root or a child.
+type issueTemplateNode struct {
+	Key         string              `yaml:"key"` // children only; referenced by depends_on
+	Title       string              `yaml:"title"`
+	Description string              `yaml:"description"`
+	Type        string              `yaml:"type"`
+	Priority    string              `yaml:"priority"`
+	Assignee    string              `yaml:"assignee"`
+	Estimate    *int                `yaml:"estimate"`
+	Labels      []string            `yaml:"labels"`
+	Checklist   []string            `yaml:"checklist"`
+	DependsOn   []string            `yaml:"depends_on"` // template keys or existing issue IDs
+	Children    []issueTemplateNode `yaml:"children"`
+}
+
+// loadIssueTemplate reads the named template from .beads/templates/, or
+// the file itself when name is a path to a .yaml file.
+func loadIssueTemplate(beadsDir, name string) (*issueTemplate, error) {
+	path := name
+	if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
+		if beadsDir == "" {
+			return nil, fmt.Errorf("no .beads directory found")
+		}
+		dir := filepath.Join(beadsDir, IssueTemplatesDir)
+		path = filepath.Join(dir, name+".yaml")
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			path = filepath.Join(dir, name+".yml")
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			available := listIssueTemplates(beadsDir)
+			if len(available) == 0 {
+				return nil, fmt.Errorf("template %q not found: no templates in %s", name, dir)
+			}
+			return nil, fmt.Errorf("template %q not found (available: %s)", name, strings.Join(available, ", "))
+		}
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- template path from .beads/templates or the user
+	if err != nil {
+		return nil, fmt.Errorf("reading template: %w", err)
+	}
+	return parseIssueTemplate(data)
+}
+
+// parseIssueTemplate decodes a template, rejecting unknown fields so a typo
+// does not silently drop part of it.
+func parseIssueTemplate(data []byte) (*issueTemplate, error) {
+	var tpl issueTemplate
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&tpl); err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	if tpl.Title == "" {
+		return nil, fmt.Errorf("template has no title")
+	}
+	if tpl.Key != "" || len(tpl.DependsOn) > 0 {
+		return nil, fmt.Errorf("key and depends_on are only valid on children")
+	}
+	return &tpl, nil
+}
+
+// listIssueTemplates returns the names of the templates in .beads/templates/.
+func listIssueTemplates(beadsDir string) []string {
+	entries, err := os.ReadDir(filepath.Join(beadsDir, IssueTemplatesDir))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if ext := filepath.Ext(name); !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, strings.TrimSuffix(name, ext))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// variables returns every variable the template uses or declares, sorted.
+func (tpl *issueTemplate) variables() []string {
+	seen := make(map[string]bool)
+	for name := range tpl.Vars {
+		seen[name] = true
+	}
+	var walk func(n *issueTemplateNode)
+	walk = func(n *issueTemplateNode) {
+		texts := append([]string{n.Title, n.Description, n.Type, n.Priority, n.Assignee}, n.Labels...)
+		for _, text := range append(texts, n.Checklist...) {
+			for _, v := range extractVariables(text) {
+				seen[v] = true
+			}
+		}
+		for i := range n.Children {
+			walk(&n.Children[i])
+		}
+	}
+	walk(&tpl.issueTemplateNode)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveVars merges vars with the template's defaults and returns the
+// variables still without a value. Every var given must be one the template
+// knows, so a misspelled --var is caught.
+func (tpl *issueTemplate) resolveVars(vars map[string]string) (map[string]string, []string, error) {
+	known := tpl.variables()
+	knownSet := make(map[string]bool, len(known))
+	for _, name := range known {
+		knownSet[name] = true
+	}
+	for name := range vars {
+		if !knownSet[name] {
+			return nil, nil, fmt.Errorf("unknown variable %q (template uses: %s)", name, strings.Join(known, ", "))
+		}
+	}
+	resolved := make(map[string]string, len(known))
+	var missing []string
+	for _, name := range known {
+		if v, ok := vars[name]; ok {
+			resolved[name] = v
+		} else if def := tpl.Vars[name]; def.Default != nil {
+			resolved[name] = *def.Default
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	return resolved, missing, nil
+}
+
+// graphPlan expands the template into a graph plan: the root issue, then
+// its children depth-first as parent-child descendants, with depends_on as
+// blocks edges. vars must come from resolveVars.
+func (tpl *issueTemplate) graphPlan(vars map[string]string) (*GraphApplyPlan, error) {
+	plan := &GraphApplyPlan{}
+	var add func(n *issueTemplateNode, key, parentKey string) error
+	add = func(n *issueTemplateNode, key, parentKey string) error {
+		node := GraphApplyNode{
+			Key:         key,
+			Title:       substituteVariables(n.Title, vars),
+			Description: substituteVariables(n.Description, vars),
+			Assignee:    substituteVariables(n.Assignee, vars),
+			Estimate:    n.Estimate,
+			ParentKey:   parentKey,
+		}
+		if n.Type != "" {
+			node.Type = string(types.IssueType(substituteVariables(n.Type, vars)).Normalize())
+		}
+		if n.Priority != "" {
+			p, err := validation.ValidatePriority(substituteVariables(n.Priority, vars))
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			node.Priority = &p
+		}
+		for _, label := range n.Labels {
+			if label = strings.TrimSpace(substituteVariables(label, vars)); label != "" {
+				node.Labels = append(node.Labels, label)
+			}
+		}
+		if len(n.Checklist) > 0 {
+			var sb strings.Builder
+			if node.Description != "" {
+				sb.WriteString(strings.TrimRight(node.Description, "\n"))
+				sb.WriteString("\n\n")
+			}
+			sb.WriteString("## Checklist\n")
+			for _, item := range n.Checklist {
+				fmt.Fprintf(&sb, "- [ ] %s\n", substituteVariables(item, vars))
+			}
+			node.Description = sb.String()
+		}
+		for _, target := range n.DependsOn {
+			node.Deps = append(node.Deps, GraphApplyNodeDep{Type: string(types.DepBlocks), Target: target})
+		}
+		plan.Nodes = append(plan.Nodes, node)
+
+		for i := range n.Children {
+			child := &n.Children[i]
+			childKey := child.Key
+			if childKey == "" {
+				childKey = fmt.Sprintf("%s.%d", key, i+1)
+			}
+			if err := add(child, childKey, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := add(&tpl.issueTemplateNode, issueTemplateRootKey, ""); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// createIssuesFromTemplate implements 'bd create --from-template': the
+// template's issues are created in one transaction. A title argument
+// replaces the root's title, and --assignee, --priority, --labels and
+// --parent apply to the root issue.
+func createIssuesFromTemplate(cmd *cobra.Command, args []string, name string) error {
+	varFlags, _ := cmd.Flags().GetStringArray("var")
+	vars := make(map[string]string, len(varFlags))
+	for _, v := range varFlags {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok {
+			return HandleErrorRespectJSON("invalid variable format '%s', expected 'key=value'", v)
+		}
+		vars[key] = value
+	}
+
+	tpl, err := loadIssueTemplate(beads.FindBeadsDir(), name)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	resolved, missing, err := tpl.resolveVars(vars)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if len(missing) > 0 {
+		return HandleErrorWithHintRespectJSON(
+			fmt.Sprintf("missing required variables: %s", strings.Join(missing, ", ")),
+			fmt.Sprintf("Provide them with: --var %s=<value>", missing[0]),
+		)
+	}
+	plan, err := tpl.graphPlan(resolved)
+	if err != nil {
+		return HandleErrorRespectJSON("template %s: %v", name, err)
+	}
+	plan.CommitMessage = fmt.Sprintf("bd: create %d issue(s) from template %s", len(plan.Nodes), name)
+
+	root := &plan.Nodes[0]
+	titleFlag, _ := cmd.Flags().GetString("title")
+	if len(args) > 0 {
+		root.Title = args[0]
+	} else if titleFlag != "" {
+		root.Title = titleFlag
+	}
+	if cmd.Flags().Changed("assignee") {
+		root.Assignee, _ = cmd.Flags().GetString("assignee")
+	}
+	if cmd.Flags().Changed("priority") {
+		priorityStr, _ := cmd.Flags().GetString("priority")
+		p, err := validation.ValidatePriority(priorityStr)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		root.Priority = &p
+	}
+	labels, _ := cmd.Flags().GetStringSlice("labels")
+	labelAlias, _ := cmd.Flags().GetStringSlice("label")
+	root.Labels = append(root.Labels, append(labels, labelAlias...)...)
+	root.ParentID, _ = cmd.Flags().GetString("parent")
+
+	if err := validateGraphApplyPlan(plan, loadEmbeddedCustomTypes()); err != nil {
+		return HandleErrorRespectJSON("template %s: %v", name, err)
+	}
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return emitGraphApplyDryRun(plan)
+	}
+
+	wisp, _ := cmd.Flags().GetBool("ephemeral")
+	noHistory, _ := cmd.Flags().GetBool("no-history")
+	result, err := executeGraphApply(rootCtx, plan, GraphApplyOptions{Ephemeral: wisp, NoHistory: noHistory})
+	if err != nil {
+		return HandleErrorRespectJSON("create from template %s: %v", name, err)
+	}
+	rootID := result.IDs[issueTemplateRootKey]
+	SetLastTouchedID(rootID)
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"template": name,
+			"id":       rootID,
+			"ids":      result.IDs,
+		})
+	}
+	if silent, _ := cmd.Flags().GetBool("silent"); silent {
+		fmt.Println(rootID)
+		return nil
+	}
+	fmt.Printf("%s Created issue: %s from template %s\n", ui.RenderPass("✓"), formatFeedbackID(rootID, root.Title), name)
+	depth := map[string]int{issueTemplateRootKey: 0}
+	for _, node := range plan.Nodes[1:] {
+		depth[node.Key] = depth[node.ParentKey] + 1
+		fmt.Printf("%s%s %s\n", strings.Repeat("  ", depth[node.Key]), ui.RenderMuted(result.IDs[node.Key]), node.Title)
+	}
+	return nil
+}
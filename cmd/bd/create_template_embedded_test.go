@@ -0,0 +1,87 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedCreateFromTemplate(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "tp")
+	templates := filepath.Join(beadsDir, IssueTemplatesDir)
+	if err := os.MkdirAll(templates, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(templates, "bug.yaml"), []byte(testBugTemplate), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	broken := "title: Broken\nchildren:\n  - title: Child\n    depends_on: [tp-nonexistent]\n"
+	if err := os.WriteFile(filepath.Join(templates, "broken.yaml"), []byte(broken), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("expands_template", func(t *testing.T) {
+		var result struct {
+			ID  string            `json:"id"`
+			IDs map[string]string `json:"ids"`
+		}
+		out := bdCommand(t, bd, dir, "create", "--from-template", "bug", "--json",
+			"--var", "component=parser", "--var", "summary=crash", "--assignee", "alice")
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if len(result.IDs) != 4 || result.ID != result.IDs["root"] {
+			t.Fatalf("result = %+v", result)
+		}
+
+		var root types.IssueDetails
+		if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, result.ID)), &root); err != nil {
+			t.Fatal(err)
+		}
+		if root.Title != "[parser] crash" || root.IssueType != types.TypeFeature || root.Priority != 1 || root.Assignee != "alice" {
+			t.Errorf("root = %+v", root.Issue)
+		}
+		if !strings.Contains(root.Description, "- [ ] Reproduce crash") {
+			t.Errorf("root description = %q", root.Description)
+		}
+
+		var note types.IssueDetails
+		if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, result.IDs["root.2"])), &note); err != nil {
+			t.Fatal(err)
+		}
+		deps := map[string]types.DependencyType{}
+		for _, d := range note.Dependencies {
+			deps[d.ID] = d.DependencyType
+		}
+		if deps[result.ID] != types.DepParentChild || deps[result.IDs["fix"]] != types.DepBlocks {
+			t.Errorf("release note deps = %v, want parent %s and blocker %s", deps, result.ID, result.IDs["fix"])
+		}
+	})
+
+	t.Run("missing_var", func(t *testing.T) {
+		out := bdCreateFail(t, bd, dir, "--from-template", "bug", "--var", "component=parser")
+		if !strings.Contains(out, "missing required variables: summary") {
+			t.Errorf("output = %s", out)
+		}
+	})
+
+	t.Run("atomic", func(t *testing.T) {
+		before := len(bdListJSON(t, bd, dir, "--all"))
+		bdCreateFail(t, bd, dir, "--from-template", "broken")
+		if after := len(bdListJSON(t, bd, dir, "--all")); after != before {
+			t.Errorf("failed template left %d issue(s) behind", after-before)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testBugTemplate = `vars:
+  component:
+    description: Affected component
+  severity:
+    default: "1"
+title: "[{{component}}] {{summary}}"
+type: feat
+priority: "{{severity}}"
+labels: [bug, "{{component}}"]
+description: Reported against {{component}}.
+checklist:
+  - Reproduce {{summary}}
+children:
+  - key: fix
+    title: Fix {{summary}}
+  - title: Release note
+    type: chore
+    depends_on: [fix]
+    children:
+      - title: Announce
+`
+
+func TestIssueTemplateGraphPlan(t *testing.T) {
+	tpl, err := parseIssueTemplate([]byte(testBugTemplate))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := tpl.variables(), []string{"component", "severity", "summary"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("variables = %v, want %v", got, want)
+	}
+	if _, missing, _ := tpl.resolveVars(map[string]string{"component": "parser"}); !reflect.DeepEqual(missing, []string{"summary"}) {
+		t.Errorf("missing = %v, want [summary]", missing)
+	}
+	if _, _, err := tpl.resolveVars(map[string]string{"componnet": "parser"}); err == nil || !strings.Contains(err.Error(), "componnet") {
+		t.Errorf("misspelled var error = %v", err)
+	}
+
+	vars, missing, err := tpl.resolveVars(map[string]string{"component": "parser", "summary": "crash"})
+	if err != nil || len(missing) != 0 {
+		t.Fatalf("resolveVars: %v, missing %v", err, missing)
+	}
+	plan, err := tpl.graphPlan(vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Nodes) != 4 {
+		t.Fatalf("nodes = %+v", plan.Nodes)
+	}
+
+	root := plan.Nodes[0]
+	if root.Key != issueTemplateRootKey || root.Title != "[parser] crash" || root.Type != "feature" || *root.Priority != 1 {
+		t.Errorf("root = %+v", root)
+	}
+	if !reflect.DeepEqual(root.Labels, []string{"bug", "parser"}) {
+		t.Errorf("root labels = %v", root.Labels)
+	}
+	if want := "Reported against parser.\n\n## Checklist\n- [ ] Reproduce crash\n"; root.Description != want {
+		t.Errorf("root description = %q, want %q", root.Description, want)
+	}
+
+	fix, note, announce := plan.Nodes[1], plan.Nodes[2], plan.Nodes[3]
+	if fix.Key != "fix" || fix.ParentKey != issueTemplateRootKey || fix.Title != "Fix crash" {
+		t.Errorf("fix = %+v", fix)
+	}
+	if note.Key != "root.2" || len(note.Deps) != 1 || note.Deps[0].Target != "fix" || note.Deps[0].Type != "blocks" {
+		t.Errorf("release note = %+v", note)
+	}
+	if announce.Key != "root.2.1" || announce.ParentKey != "root.2" {
+		t.Errorf("announce = %+v", announce)
+	}
+	if err := validateGraphApplyPlan(plan, nil); err != nil {
+		t.Errorf("plan does not validate: %v", err)
+	}
+}
+
+func TestLoadIssueTemplate(t *testing.T) {
+	beadsDir := t.TempDir()
+	dir := filepath.Join(beadsDir, IssueTemplatesDir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bug.yaml"), []byte(testBugTemplate), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "typo.yml"), []byte("title: x\nlabel: [a]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadIssueTemplate(beadsDir, "bug"); err != nil {
+		t.Errorf("load bug: %v", err)
+	}
+	if _, err := loadIssueTemplate(beadsDir, "typo"); err == nil || !strings.Contains(err.Error(), "label") {
+		t.Errorf("unknown field error = %v", err)
+	}
+	if _, err := loadIssueTemplate(beadsDir, "epic"); err == nil || !strings.Contains(err.Error(), "available: bug, typo") {
+		t.Errorf("missing template error = %v", err)
+	}
+}
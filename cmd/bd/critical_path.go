@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/domain"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var criticalPathCmd = &cobra.Command{
+	Use:     "critical-path",
+	GroupID: "deps",
+	Short:   "Show the longest blocking chain of open work",
+	Long: `Show the longest chain of open issues that have to be done one after
+another because each blocks the next (blocks, conditional-blocks and
+waits-for dependencies).
+
+A chain is as long as the sum of its issues' estimates (--estimate on
+create/update). Issues without an estimate count as --default-estimate
+minutes, so the chain with the most issues wins when nothing is estimated.
+With --to the chain ends at that issue: it is the sequence of blockers that
+decides when the issue can be finished. The chain is listed in the order
+the work has to happen, first blocker first.
+
+Examples:
+  bd critical-path
+  bd critical-path --to bd-42
+  bd critical-path --default-estimate 120 --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("critical-path is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("critical-path")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		to, _ := cmd.Flags().GetString("to")
+		defaultEstimate, _ := cmd.Flags().GetInt("default-estimate")
+		if defaultEstimate < 0 {
+			return HandleErrorRespectJSON("--default-estimate must not be negative")
+		}
+
+		ctx := rootCtx
+		if to != "" {
+			resolved, err := utils.ResolvePartialID(ctx, store, to)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			to = resolved
+		}
+		g, err := loadOpenBlockingGraph(ctx, store)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if to != "" && g.issues[to] == nil {
+			return HandleErrorRespectJSON("%s is not open work; nothing left blocks it", to)
+		}
+		path := g.criticalPath(to, defaultEstimate)
+
+		if jsonOutput {
+			return outputJSON(path)
+		}
+		if len(path.Issues) == 0 {
+			fmt.Println("No open work")
+			return nil
+		}
+
+		header := "Critical path"
+		if to != "" {
+			header += " to " + to
+		}
+		fmt.Printf("\n%s %s: %d issues, %d min\n\n", ui.RenderAccent("⏱"), header, len(path.Issues), path.EstimatedMinutes)
+		for i, issue := range path.Issues {
+			estimate := ui.RenderMuted("no estimate")
+			if issue.EstimatedMinutes != nil {
+				estimate = fmt.Sprintf("%d min", *issue.EstimatedMinutes)
+			}
+			fmt.Printf("  %2d. %s  %s\n", i+1, formatTreeNode(&types.TreeNode{Issue: *issue, Depth: 1}, false), estimate)
+		}
+		if path.Unestimated > 0 {
+			fmt.Printf("\n%s %d issue(s) without an estimate counted as %d min each\n", ui.RenderMuted("ℹ"), path.Unestimated, defaultEstimate)
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+var bottlenecksCmd = &cobra.Command{
+	Use:     "bottlenecks",
+	GroupID: "deps",
+	Short:   "Rank open issues by how much work they block",
+	Long: `Rank open issues by the number of open issues they block, directly or
+through other blocked issues (blocks, conditional-blocks and waits-for
+dependencies). The issues at the top are the ones to unblock first.
+
+Ties are broken by the number of directly blocked issues, then priority.
+
+Examples:
+  bd bottlenecks
+  bd bottlenecks --limit 5 --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("bottlenecks is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("bottlenecks")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		g, err := loadOpenBlockingGraph(rootCtx, store)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		rows := g.bottlenecks()
+		if limit > 0 && len(rows) > limit {
+			rows = rows[:limit]
+		}
+
+		if jsonOutput {
+			if rows == nil {
+				rows = []*bottleneckRow{}
+			}
+			return outputJSON(rows)
+		}
+		if len(rows) == 0 {
+			fmt.Println("No open issue blocks other open work")
+			return nil
+		}
+		fmt.Println(ui.RenderBold(fmt.Sprintf("%7s  %6s  %s", "BLOCKED", "DIRECT", "ISSUE")))
+		for _, r := range rows {
+			fmt.Printf("%7d  %6d  %s\n", r.TransitivelyBlocked, r.DirectlyBlocked,
+				formatTreeNode(&types.TreeNode{Issue: *g.issues[r.ID], Depth: 1}, false))
+		}
+		return nil
+	},
+}
+
+// blockingGraph is the graph of open work that bd critical-path and
+// bd bottlenecks analyse. Only hard blockers between two open issues are
+// edges; a closed blocker no longer holds anything up.
+type blockingGraph struct {
+	issues   map[string]*types.Issue
+	blockers map[string][]string // issue -> open issues it waits on
+	blocked  map[string][]string // issue -> open issues waiting on it
+}
+
+// loadOpenBlockingGraph reads the open issues with their dependencies,
+// leaving out infrastructure types, templates and ephemeral issues as
+// 'bd workload' does.
+func loadOpenBlockingGraph(ctx context.Context, s storage.DoltStorage) (*blockingGraph, error) {
+	filter := types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}}
+	infraSet := s.GetInfraTypes(ctx)
+	infraTypes := domain.DefaultInfraTypes()
+	if len(infraSet) > 0 {
+		infraTypes = infraTypes[:0]
+		for t := range infraSet {
+			infraTypes = append(infraTypes, t)
+		}
+	}
+	for _, t := range infraTypes {
+		filter.ExcludeTypes = append(filter.ExcludeTypes, types.IssueType(t))
+	}
+	notTemplate, persistent := false, false
+	filter.IsTemplate = &notTemplate
+	filter.Ephemeral = &persistent
+
+	issues, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	issueIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	deps, err := s.GetDependencyRecordsForIssues(ctx, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Dependencies = deps[issue.ID]
+	}
+	return newBlockingGraph(issues), nil
+}
+
+// newBlockingGraph builds the graph from issues with their dependencies
+// loaded. Closed issues and edges to issues outside the set are dropped.
+func newBlockingGraph(issues []*types.Issue) *blockingGraph {
+	g := &blockingGraph{
+		issues:   make(map[string]*types.Issue),
+		blockers: make(map[string][]string),
+		blocked:  make(map[string][]string),
+	}
+	for _, issue := range issues {
+		if issue.Status != types.StatusClosed {
+			g.issues[issue.ID] = issue
+		}
+	}
+	for id, issue := range g.issues {
+		for _, dep := range issue.Dependencies {
+			if !dep.Type.IsBlockingEdge() || g.issues[dep.DependsOnID] == nil || dep.DependsOnID == id {
+				continue
+			}
+			g.blockers[id] = append(g.blockers[id], dep.DependsOnID)
+			g.blocked[dep.DependsOnID] = append(g.blocked[dep.DependsOnID], id)
+		}
+	}
+	for _, adj := range []map[string][]string{g.blockers, g.blocked} {
+		for id := range adj {
+			sort.Strings(adj[id])
+		}
+	}
+	return g
+}
+
+// sortedIDs returns the graph's issue IDs in a stable order.
+func (g *blockingGraph) sortedIDs() []string {
+	ids := make([]string, 0, len(g.issues))
+	for id := range g.issues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// criticalPathResult is the output of bd critical-path.
+type criticalPathResult struct {
+	Issues           []*types.Issue `json:"issues"` // in the order the work has to happen
+	EstimatedMinutes int            `json:"estimated_minutes"`
+	Unestimated      int            `json:"unestimated"` // issues counted at the default estimate
+}
+
+// criticalPath returns the chain of blockers with the largest total
+// estimate that ends at to, or anywhere when to is empty. Unestimated issues
+// weigh defaultMinutes; equal weights prefer the chain with more issues.
+// Blocker cycles (waits-for edges are not cycle-checked on insert) are cut
+// where the walk first meets them.
+func (g *blockingGraph) criticalPath(to string, defaultMinutes int) *criticalPathResult {
+	type chain struct {
+		minutes, length int
+		next            string // first blocker along the chain, "" at its end
+	}
+	weight := func(id string) int {
+		if m := g.issues[id].EstimatedMinutes; m != nil {
+			return *m
+		}
+		return defaultMinutes
+	}
+	longer := func(a, b chain) bool {
+		if a.minutes != b.minutes {
+			return a.minutes > b.minutes
+		}
+		return a.length > b.length
+	}
+
+	memo := make(map[string]chain)
+	onStack := make(map[string]bool)
+	var longest func(id string) chain
+	longest = func(id string) chain {
+		if c, ok := memo[id]; ok {
+			return c
+		}
+		onStack[id] = true
+		best := chain{minutes: weight(id), length: 1}
+		for _, blocker := range g.blockers[id] {
+			if onStack[blocker] {
+				continue
+			}
+			sub := longest(blocker)
+			candidate := chain{minutes: weight(id) + sub.minutes, length: 1 + sub.length, next: blocker}
+			if longer(candidate, best) {
+				best = candidate
+			}
+		}
+		delete(onStack, id)
+		memo[id] = best
+		return best
+	}
+
+	start := to
+	if start == "" {
+		var best chain
+		for _, id := range g.sortedIDs() {
+			if c := longest(id); start == "" || longer(c, best) {
+				start, best = id, c
+			}
+		}
+	}
+	result := &criticalPathResult{Issues: []*types.Issue{}}
+	if start == "" {
+		return result
+	}
+	longest(start)
+
+	// Walk from the end of the chain towards its first blocker, then reverse
+	// into execution order. Memoized chains that were cut at different points
+	// of a cycle can lead back into the walk, so it stops at a repeat.
+	seen := make(map[string]bool)
+	for id := start; id != "" && !seen[id]; id = memo[id].next {
+		seen[id] = true
+		issue := g.issues[id]
+		result.Issues = append(result.Issues, issue)
+		result.EstimatedMinutes += weight(id)
+		if issue.EstimatedMinutes == nil {
+			result.Unestimated++
+		}
+	}
+	for i, j := 0, len(result.Issues)-1; i < j; i, j = i+1, j-1 {
+		result.Issues[i], result.Issues[j] = result.Issues[j], result.Issues[i]
+	}
+	return result
+}
+
+// bottleneckRow is one line of bd bottlenecks.
+type bottleneckRow struct {
+	ID                  string       `json:"id"`
+	Title               string       `json:"title"`
+	Status              types.Status `json:"status"`
+	Priority            int          `json:"priority"`
+	Assignee            string       `json:"assignee,omitempty"`
+	DirectlyBlocked     int          `json:"directly_blocked"`
+	TransitivelyBlocked int          `json:"transitively_blocked"`
+}
+
+// bottlenecks ranks the issues that block other open work by how many open
+// issues they block, directly or transitively.
+func (g *blockingGraph) bottlenecks() []*bottleneckRow {
+	var rows []*bottleneckRow
+	for _, id := range g.sortedIDs() {
+		if len(g.blocked[id]) == 0 {
+			continue
+		}
+		seen := map[string]bool{id: true}
+		queue := []string{id}
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+			for _, next := range g.blocked[cur] {
+				if !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		issue := g.issues[id]
+		rows = append(rows, &bottleneckRow{
+			ID:                  id,
+			Title:               issue.Title,
+			Status:              issue.Status,
+			Priority:            issue.Priority,
+			Assignee:            issue.Assignee,
+			DirectlyBlocked:     len(g.blocked[id]),
+			TransitivelyBlocked: len(seen) - 1,
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.TransitivelyBlocked != b.TransitivelyBlocked {
+			return a.TransitivelyBlocked > b.TransitivelyBlocked
+		}
+		if a.DirectlyBlocked != b.DirectlyBlocked {
+			return a.DirectlyBlocked > b.DirectlyBlocked
+		}
+		return a.Priority < b.Priority
+	})
+	return rows
+}
+
+func init() {
+	criticalPathCmd.Flags().String("to", "", "End the chain at this issue")
+	criticalPathCmd.Flags().Int("default-estimate", 60, "Minutes counted for issues without an estimate")
+	bottlenecksCmd.Flags().IntP("limit", "n", 10, "Maximum issues to show (0 for all)")
+	rootCmd.AddCommand(criticalPathCmd)
+	rootCmd.AddCommand(bottlenecksCmd)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func criticalPathTestGraph() *blockingGraph {
+	minutes := func(m int) *int { return &m }
+	issue := func(id string, estimate *int, deps ...*types.Dependency) *types.Issue {
+		return &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, EstimatedMinutes: estimate, Dependencies: deps}
+	}
+	blocks := func(id, on string) *types.Dependency {
+		return &types.Dependency{IssueID: id, DependsOnID: on, Type: types.DepBlocks}
+	}
+	// release waits on api and docs; api waits on schema; docs has a long
+	// estimate; schema waits on a closed spike; notes only relate to docs.
+	return newBlockingGraph([]*types.Issue{
+		issue("release", minutes(30), blocks("release", "api"), blocks("release", "docs")),
+		issue("api", minutes(120), blocks("api", "schema")),
+		issue("docs", minutes(600)),
+		issue("schema", nil, blocks("schema", "spike")),
+		{ID: "spike", Status: types.StatusClosed},
+		issue("notes", minutes(10), &types.Dependency{IssueID: "notes", DependsOnID: "docs", Type: types.DepRelatesTo}),
+	})
+}
+
+func criticalPathIDs(path *criticalPathResult) string {
+	var ids []string
+	for _, issue := range path.Issues {
+		ids = append(ids, issue.ID)
+	}
+	return strings.Join(ids, ">")
+}
+
+func TestCriticalPath(t *testing.T) {
+	g := criticalPathTestGraph()
+
+	path := g.criticalPath("", 60)
+	if got := criticalPathIDs(path); got != "docs>release" {
+		t.Errorf("critical path = %s, want docs>release", got)
+	}
+	if path.EstimatedMinutes != 630 || path.Unestimated != 0 {
+		t.Errorf("estimate = %d (%d unestimated), want 630 (0)", path.EstimatedMinutes, path.Unestimated)
+	}
+
+	// With a large default the unestimated schema makes the api chain longer.
+	path = g.criticalPath("release", 1000)
+	if got := criticalPathIDs(path); got != "schema>api>release" {
+		t.Errorf("critical path = %s, want schema>api>release", got)
+	}
+	if path.EstimatedMinutes != 1150 || path.Unestimated != 1 {
+		t.Errorf("estimate = %d (%d unestimated), want 1150 (1)", path.EstimatedMinutes, path.Unestimated)
+	}
+
+	if got := criticalPathIDs(g.criticalPath("api", 60)); got != "schema>api" {
+		t.Errorf("critical path to api = %s, want schema>api", got)
+	}
+}
+
+func TestCriticalPathSurvivesCycles(t *testing.T) {
+	waits := func(id, on string) *types.Dependency {
+		return &types.Dependency{IssueID: id, DependsOnID: on, Type: types.DepWaitsFor}
+	}
+	g := newBlockingGraph([]*types.Issue{
+		{ID: "a", Status: types.StatusOpen, Dependencies: []*types.Dependency{waits("a", "b")}},
+		{ID: "b", Status: types.StatusOpen, Dependencies: []*types.Dependency{waits("b", "c")}},
+		{ID: "c", Status: types.StatusOpen, Dependencies: []*types.Dependency{waits("c", "a")}},
+	})
+	if path := g.criticalPath("", 60); len(path.Issues) != 3 {
+		t.Errorf("critical path through a cycle = %s, want all three issues once", criticalPathIDs(path))
+	}
+}
+
+func TestBottlenecks(t *testing.T) {
+	rows := criticalPathTestGraph().bottlenecks()
+	var got []string
+	for _, r := range rows {
+		got = append(got, r.ID)
+	}
+	if strings.Join(got, ",") != "schema,api,docs" {
+		t.Fatalf("bottlenecks = %v, want [schema api docs]", got)
+	}
+	if rows[0].TransitivelyBlocked != 2 || rows[0].DirectlyBlocked != 1 {
+		t.Errorf("schema blocks %d (%d directly), want 2 (1)", rows[0].TransitivelyBlocked, rows[0].DirectlyBlocked)
+	}
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage/domain"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:     "dashboard",
+	GroupID: "views",
+	Short:   "Generate a static HTML dashboard",
+	Long: `Generate a static HTML dashboard in --out: an index.html that needs no
+server, external scripts or stylesheets, plus the underlying data as
+dashboard.json.
+
+The page has summary counts, a filterable issue list (text, status,
+assignee, priority and type), a dependency graph of open issues that block
+or contain one another, a burndown of open issues over the last --days
+days, an age histogram of open issues, and per-assignee workload.
+
+The directory can be published as-is, e.g. to GitHub Pages from CI.
+Infrastructure types, templates and ephemeral issues are left out, as in
+'bd export'.
+
+Examples:
+  bd dashboard --out site/
+  bd dashboard --out public/ --days 90 --title "Release 2.0"`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("dashboard is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("dashboard")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		outDir, _ := cmd.Flags().GetString("out")
+		days, _ := cmd.Flags().GetInt("days")
+		title, _ := cmd.Flags().GetString("title")
+		if outDir == "" {
+			return HandleErrorRespectJSON("--out is required")
+		}
+		if days < 1 {
+			return HandleErrorRespectJSON("--days must be at least 1")
+		}
+
+		ctx := rootCtx
+		filter := types.IssueFilter{}
+		infraSet := store.GetInfraTypes(ctx)
+		infraTypes := domain.DefaultInfraTypes()
+		if len(infraSet) > 0 {
+			infraTypes = infraTypes[:0]
+			for t := range infraSet {
+				infraTypes = append(infraTypes, t)
+			}
+		}
+		for _, t := range infraTypes {
+			filter.ExcludeTypes = append(filter.ExcludeTypes, types.IssueType(t))
+		}
+		notTemplate, persistent := false, false
+		filter.IsTemplate = &notTemplate
+		filter.Ephemeral = &persistent
+
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to search issues: %v", err)
+		}
+		issueIDs := make([]string, len(issues))
+		for i, issue := range issues {
+			issueIDs[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, issueIDs)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to get labels: %v", err)
+		}
+		deps, err := store.GetDependencyRecordsForIssues(ctx, issueIDs)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to get dependencies: %v", err)
+		}
+		for _, issue := range issues {
+			issue.Labels = labels[issue.ID]
+			issue.Dependencies = deps[issue.ID]
+		}
+
+		if title == "" {
+			title = "Beads dashboard"
+		}
+		data := buildDashboard(issues, time.Now(), days)
+		data.Title = title
+		files, err := writeDashboard(outDir, data, issues)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to write dashboard: %v", err)
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"out":    outDir,
+				"files":  files,
+				"issues": len(data.Issues),
+			})
+		}
+		fmt.Printf("%s Wrote dashboard for %d issues to %s\n", ui.RenderPass("✓"), len(data.Issues), filepath.Join(outDir, "index.html"))
+		return nil
+	},
+}
+
+// dashboardData is everything the dashboard page shows except the graph,
+// and is also written out as dashboard.json.
+type dashboardData struct {
+	Title       string               `json:"title"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Summary     dashboardSummary     `json:"summary"`
+	Issues      []dashboardIssue     `json:"issues"`
+	Burndown    []dashboardDay       `json:"burndown"`
+	Age         []dashboardBucket    `json:"age"`
+	Workload    []*dashboardWorkload `json:"workload"`
+}
+
+type dashboardSummary struct {
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Blocked    int `json:"blocked"`
+	Ready      int `json:"ready"`
+	Closed     int `json:"closed"`
+}
+
+type dashboardIssue struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Priority  int       `json:"priority"`
+	Type      string    `json:"type"`
+	Assignee  string    `json:"assignee,omitempty"`
+	Labels    []string  `json:"labels,omitempty"`
+	Blocked   bool      `json:"blocked,omitempty"` // has an open blocker
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// dashboardDay is one day of the burndown: issues still open at the end of
+// the day, and issues created and closed during it.
+type dashboardDay struct {
+	Date    string `json:"date"`
+	Open    int    `json:"open"`
+	Created int    `json:"created"`
+	Closed  int    `json:"closed"`
+}
+
+type dashboardBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+type dashboardWorkload struct {
+	Assignee         string `json:"assignee"`
+	InProgress       int    `json:"in_progress"`
+	Ready            int    `json:"ready"`
+	Blocked          int    `json:"blocked"`
+	Other            int    `json:"other"` // deferred, pinned, hooked, custom
+	Total            int    `json:"total"`
+	EstimatedMinutes int    `json:"estimated_minutes,omitempty"`
+}
+
+// dashboardAgeBuckets are the upper bounds of the age histogram's bars;
+// the last bar takes everything older.
+var dashboardAgeBuckets = []struct {
+	label string
+	max   time.Duration
+}{
+	{"< 1 day", 24 * time.Hour},
+	{"1-7 days", 7 * 24 * time.Hour},
+	{"1-4 weeks", 28 * 24 * time.Hour},
+	{"1-3 months", 90 * 24 * time.Hour},
+	{"3-12 months", 365 * 24 * time.Hour},
+	{"> 1 year", 0},
+}
+
+// dashboardUnassigned is the workload row for issues without an assignee.
+const dashboardUnassigned = "(unassigned)"
+
+// buildDashboard computes the dashboard from issues with their dependencies
+// loaded. The burndown covers the days-day window ending today.
+func buildDashboard(issues []*types.Issue, now time.Time, days int) *dashboardData {
+	data := &dashboardData{GeneratedAt: now.UTC()}
+
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	hasOpenBlocker := func(issue *types.Issue) bool {
+		for _, dep := range issue.Dependencies {
+			if dep.Type != types.DepBlocks {
+				continue
+			}
+			if blocker := byID[dep.DependsOnID]; blocker != nil && blocker.Status != types.StatusClosed {
+				return true
+			}
+		}
+		return false
+	}
+
+	sorted := append([]*types.Issue(nil), issues...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if (a.Status == types.StatusClosed) != (b.Status == types.StatusClosed) {
+			return b.Status == types.StatusClosed
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+
+	workload := make(map[string]*dashboardWorkload)
+	for _, issue := range sorted {
+		blocked := issue.Status != types.StatusClosed && hasOpenBlocker(issue)
+		data.Issues = append(data.Issues, dashboardIssue{
+			ID:        issue.ID,
+			Title:     issue.Title,
+			Status:    string(issue.Status),
+			Priority:  issue.Priority,
+			Type:      string(issue.IssueType),
+			Assignee:  issue.Assignee,
+			Labels:    issue.Labels,
+			Blocked:   blocked,
+			CreatedAt: issue.CreatedAt,
+			UpdatedAt: issue.UpdatedAt,
+		})
+
+		if issue.Status == types.StatusClosed {
+			data.Summary.Closed++
+			continue
+		}
+		assignee := issue.Assignee
+		if assignee == "" {
+			assignee = dashboardUnassigned
+		}
+		w := workload[assignee]
+		if w == nil {
+			w = &dashboardWorkload{Assignee: assignee}
+			workload[assignee] = w
+		}
+		w.Total++
+		if issue.EstimatedMinutes != nil {
+			w.EstimatedMinutes += *issue.EstimatedMinutes
+		}
+		switch {
+		case issue.Status == types.StatusInProgress:
+			data.Summary.InProgress++
+			w.InProgress++
+		case issue.Status == types.StatusBlocked || blocked:
+			data.Summary.Blocked++
+			w.Blocked++
+		case issue.Status == types.StatusOpen:
+			data.Summary.Ready++
+			w.Ready++
+		default:
+			w.Other++
+		}
+		if issue.Status == types.StatusOpen {
+			data.Summary.Open++
+		}
+	}
+
+	for _, w := range workload {
+		data.Workload = append(data.Workload, w)
+	}
+	sort.Slice(data.Workload, func(i, j int) bool {
+		a, b := data.Workload[i], data.Workload[j]
+		if (a.Assignee == dashboardUnassigned) != (b.Assignee == dashboardUnassigned) {
+			return b.Assignee == dashboardUnassigned
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Assignee < b.Assignee
+	})
+
+	data.Burndown = dashboardBurndown(issues, now, days)
+	data.Age = dashboardAge(issues, now)
+	return data
+}
+
+// dashboardBurndown counts, for each local day in the window, the issues
+// open at its end and those created and closed during it.
+func dashboardBurndown(issues []*types.Issue, now time.Time, days int) []dashboardDay {
+	now = now.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	burndown := make([]dashboardDay, days)
+	for i := range burndown {
+		start := today.AddDate(0, 0, i-days+1)
+		end := start.AddDate(0, 0, 1)
+		day := dashboardDay{Date: start.Format("2006-01-02")}
+		for _, issue := range issues {
+			closedAt := issue.ClosedAt
+			if issue.Status != types.StatusClosed {
+				closedAt = nil
+			}
+			if !issue.CreatedAt.Before(start) && issue.CreatedAt.Before(end) {
+				day.Created++
+			}
+			if closedAt != nil && !closedAt.Before(start) && closedAt.Before(end) {
+				day.Closed++
+			}
+			if issue.CreatedAt.Before(end) && (closedAt == nil || !closedAt.Before(end)) {
+				day.Open++
+			}
+		}
+		burndown[i] = day
+	}
+	return burndown
+}
+
+// dashboardAge buckets the issues that are not closed by time since
+// creation.
+func dashboardAge(issues []*types.Issue, now time.Time) []dashboardBucket {
+	buckets := make([]dashboardBucket, len(dashboardAgeBuckets))
+	for i, b := range dashboardAgeBuckets {
+		buckets[i].Label = b.label
+	}
+	for _, issue := range issues {
+		if issue.Status == types.StatusClosed {
+			continue
+		}
+		age := now.Sub(issue.CreatedAt)
+		i := 0
+		for i < len(dashboardAgeBuckets)-1 && age >= dashboardAgeBuckets[i].max {
+			i++
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
+
+// writeDashboard writes index.html and dashboard.json to dir, creating it
+// if needed, and returns the paths written.
+func writeDashboard(dir string, data *dashboardData, issues []*types.Issue) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	page, err := renderDashboardHTML(data, dashboardGraphSVG(issues))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files := []string{filepath.Join(dir, "index.html"), filepath.Join(dir, "dashboard.json")}
+	for i, content := range [][]byte{page, append(raw, '\n')} {
+		if err := os.WriteFile(files[i], content, 0o644); err != nil { // #nosec G306 -- published site, meant to be world-readable
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func init() {
+	dashboardCmd.Flags().StringP("out", "o", "", "Directory to write the dashboard to (required)")
+	dashboardCmd.Flags().Int("days", 30, "Days of history in the burndown chart")
+	dashboardCmd.Flags().String("title", "", "Page title (default \"Beads dashboard\")")
+	rootCmd.AddCommand(dashboardCmd)
+}
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Graph node geometry for the dashboard's dependency graph, in SVG units.
+const (
+	dashNodeW     = 180
+	dashNodeH     = 40
+	dashColGap    = 70
+	dashRowGap    = 14
+	dashGroupGap  = 36
+	dashGraphPad  = 10
+	dashTitleChar = 24
+)
+
+// dashboardGraphSVG draws the open issues that block or contain another
+// open issue, one connected component under another, each laid out in
+// columns by blocking order as in 'bd graph'. Nodes link to the issue's
+// row in the list. Returns "" when there are no such issues.
+func dashboardGraphSVG(issues []*types.Issue) template.HTML {
+	issueMap := make(map[string]*types.Issue)
+	for _, issue := range issues {
+		if isOpenStatus(issue.Status) {
+			issueMap[issue.ID] = issue
+		}
+	}
+	var deps []*types.Dependency
+	linked := make(map[string]bool)
+	for _, issue := range issues {
+		if issueMap[issue.ID] == nil {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep.Type != types.DepBlocks && dep.Type != types.DepParentChild {
+				continue
+			}
+			if issueMap[dep.DependsOnID] == nil {
+				continue
+			}
+			deps = append(deps, dep)
+			linked[dep.IssueID], linked[dep.DependsOnID] = true, true
+		}
+	}
+	if len(deps) == 0 {
+		return ""
+	}
+	var graphIssues []*types.Issue
+	for _, issue := range issues {
+		if linked[issue.ID] {
+			graphIssues = append(graphIssues, issue)
+		}
+	}
+
+	type box struct{ x, y int }
+	boxes := make(map[string]box)
+	var nodes, edges strings.Builder
+	width, y := 0, dashGraphPad
+	for _, sg := range assembleAllSubgraphs(graphIssues, issueMap, deps) {
+		layout := computeLayout(sg)
+		rows := 0
+		for layer, ids := range layout.Layers {
+			for pos, id := range ids {
+				b := box{x: dashGraphPad + layer*(dashNodeW+dashColGap), y: y + pos*(dashNodeH+dashRowGap)}
+				boxes[id] = b
+				issue := layout.Nodes[id].Issue
+				fill, font := graphStatusColors(issue.Status)
+				fmt.Fprintf(&nodes, `<a href="#issue-%s"><g class="node"><title>%s</title>`,
+					html.EscapeString(issue.ID), html.EscapeString(fmt.Sprintf("%s: %s (%s, P%d)", issue.ID, issue.Title, issue.Status, issue.Priority)))
+				fmt.Fprintf(&nodes, `<rect x="%d" y="%d" width="%d" height="%d" rx="5" fill="%s" stroke="%s"/>`,
+					b.x, b.y, dashNodeW, dashNodeH, fill, font)
+				fmt.Fprintf(&nodes, `<text x="%d" y="%d" fill="%s"><tspan class="id">%s %s</tspan> P%d</text>`,
+					b.x+8, b.y+16, font, statusPlainIcon(issue.Status), html.EscapeString(issue.ID), issue.Priority)
+				fmt.Fprintf(&nodes, `<text x="%d" y="%d" fill="%s">%s</text></g></a>`,
+					b.x+8, b.y+32, font, html.EscapeString(truncateTitle(issue.Title, dashTitleChar)))
+			}
+			rows = max(rows, len(ids))
+			width = max(width, dashGraphPad*2+(layer+1)*(dashNodeW+dashColGap)-dashColGap)
+		}
+		for _, dep := range sg.Dependencies {
+			from, to := boxes[dep.DependsOnID], boxes[dep.IssueID]
+			class := "blocks"
+			if dep.Type == types.DepParentChild {
+				class = "parent"
+			}
+			if from.x == to.x {
+				// Same column: loop out to the right and back.
+				fmt.Fprintf(&edges, `<path class="%s" d="M%d %d C%d %d %d %d %d %d"/>`, class,
+					from.x+dashNodeW, from.y+dashNodeH/2, from.x+dashNodeW+40, from.y+dashNodeH/2,
+					to.x+dashNodeW+40, to.y+dashNodeH/2, to.x+dashNodeW, to.y+dashNodeH/2)
+				continue
+			}
+			x1, y1, x2, y2 := from.x+dashNodeW, from.y+dashNodeH/2, to.x, to.y+dashNodeH/2
+			if to.x < from.x {
+				x1, x2 = from.x, to.x+dashNodeW
+			}
+			mid := (x1 + x2) / 2
+			fmt.Fprintf(&edges, `<path class="%s" d="M%d %d C%d %d %d %d %d %d"/>`, class, x1, y1, mid, y1, mid, y2, x2, y2)
+		}
+		y += rows*(dashNodeH+dashRowGap) - dashRowGap + dashGroupGap
+	}
+	height := y - dashGroupGap + dashGraphPad
+
+	return template.HTML(fmt.Sprintf(`<svg class="graph" width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`+
+		`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="7" markerHeight="7" orient="auto-start-reverse"><path d="M0 0L10 5L0 10z" fill="#888"/></marker></defs>`+
+		`<g class="edges">%s</g><g class="nodes">%s</g></svg>`,
+		width, height, width, height, edges.String(), nodes.String())) // #nosec G203 -- all text is escaped above
+}
+
+// Chart geometry, in SVG units.
+const (
+	dashChartW   = 640
+	dashChartH   = 220
+	dashChartPad = 36
+)
+
+// dashboardBurndownSVG draws open issues per day as a line over bars of
+// issues closed that day.
+func dashboardBurndownSVG(days []dashboardDay) template.HTML {
+	if len(days) == 0 {
+		return ""
+	}
+	top := 1
+	for _, d := range days {
+		top = max(top, d.Open, d.Closed)
+	}
+	plotW, plotH := dashChartW-2*dashChartPad, dashChartH-2*dashChartPad
+	step := float64(plotW) / float64(len(days))
+	yOf := func(v int) float64 { return float64(dashChartPad+plotH) - float64(v)*float64(plotH)/float64(top) }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg class="chart" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`, dashChartW, dashChartH)
+	fmt.Fprintf(&b, `<line class="axis" x1="%d" y1="%d" x2="%d" y2="%d"/>`, dashChartPad, dashChartPad+plotH, dashChartPad+plotW, dashChartPad+plotH)
+	fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" text-anchor="end">%d</text>`, dashChartPad-6, dashChartPad+4, top)
+	fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" text-anchor="end">0</text>`, dashChartPad-6, dashChartPad+plotH+4)
+	points := make([]string, len(days))
+	for i, d := range days {
+		x := float64(dashChartPad) + step*float64(i)
+		if d.Closed > 0 {
+			fmt.Fprintf(&b, `<rect class="closed" x="%.1f" y="%.1f" width="%.1f" height="%.1f"><title>%s: %d closed</title></rect>`,
+				x+step*0.15, yOf(d.Closed), step*0.7, float64(dashChartPad+plotH)-yOf(d.Closed), d.Date, d.Closed)
+		}
+		points[i] = fmt.Sprintf("%.1f,%.1f", x+step/2, yOf(d.Open))
+	}
+	fmt.Fprintf(&b, `<polyline class="open" points="%s"/>`, strings.Join(points, " "))
+	for i, d := range days {
+		fmt.Fprintf(&b, `<circle class="open" cx="%.1f" cy="%.1f" r="3"><title>%s: %d open, %d created, %d closed</title></circle>`,
+			float64(dashChartPad)+step*(float64(i)+0.5), yOf(d.Open), d.Date, d.Open, d.Created, d.Closed)
+	}
+	fmt.Fprintf(&b, `<text class="label" x="%d" y="%d">%s</text>`, dashChartPad, dashChartH-dashChartPad/2, days[0].Date)
+	fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" text-anchor="end">%s</text>`, dashChartPad+plotW, dashChartH-dashChartPad/2, days[len(days)-1].Date)
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String()) // #nosec G203 -- dates and numbers only
+}
+
+// dashboardAgeSVG draws the age histogram as horizontal bars.
+func dashboardAgeSVG(buckets []dashboardBucket) template.HTML {
+	const labelW, barH, gap = 100, 22, 8
+	top := 1
+	for _, bucket := range buckets {
+		top = max(top, bucket.Count)
+	}
+	plotW := dashChartW - labelW - 2*dashChartPad
+	height := len(buckets)*(barH+gap) + 2*gap
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg class="chart" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`, dashChartW, height)
+	for i, bucket := range buckets {
+		y := gap + i*(barH+gap)
+		w := bucket.Count * plotW / top
+		fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" text-anchor="end">%s</text>`, dashChartPad+labelW-8, y+barH/2+4, html.EscapeString(bucket.Label))
+		fmt.Fprintf(&b, `<rect class="age" x="%d" y="%d" width="%d" height="%d"/>`, dashChartPad+labelW, y, w, barH)
+		fmt.Fprintf(&b, `<text class="label" x="%d" y="%d">%d</text>`, dashChartPad+labelW+w+6, y+barH/2+4, bucket.Count)
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String()) // #nosec G203 -- labels are escaped
+}
+
+// dashboardPage is the data handed to dashboardTemplate.
+type dashboardPage struct {
+	*dashboardData
+	Graph     template.HTML
+	BurndownS template.HTML
+	AgeS      template.HTML
+	Statuses  []string
+	Assignees []string
+	Types     []string
+	MaxLoad   int
+}
+
+func renderDashboardHTML(data *dashboardData, graph template.HTML) ([]byte, error) {
+	page := dashboardPage{
+		dashboardData: data,
+		Graph:         graph,
+		BurndownS:     dashboardBurndownSVG(data.Burndown),
+		AgeS:          dashboardAgeSVG(data.Age),
+	}
+	statuses, assignees, issueTypes := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, issue := range data.Issues {
+		statuses[issue.Status] = true
+		issueTypes[issue.Type] = true
+		if issue.Assignee != "" {
+			assignees[issue.Assignee] = true
+		}
+	}
+	page.Statuses, page.Assignees, page.Types = sortedKeys(statuses), sortedKeys(assignees), sortedKeys(issueTypes)
+	for _, w := range data.Workload {
+		page.MaxLoad = max(page.MaxLoad, w.Total)
+	}
+
+	var buf bytes.Buffer
+	if err := dashboardTemplate.Execute(&buf, page); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"pct": func(n, of int) int {
+		if of == 0 {
+			return 0
+		}
+		return n * 100 / of
+	},
+	"hours": func(minutes int) string {
+		if minutes == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%.1fh", float64(minutes)/60)
+	},
+	"join": strings.Join,
+}).Parse(dashboardHTML))
+
+// dashboardHTML is the page template. It has no external resources, so the
+// generated page works offline and from any static host.
+const dashboardHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0 auto; max-width: 1200px; padding: 16px 24px; color: #1a1a1a; background: #fafafa; }
+h1 { margin-bottom: 0; }
+h2 { margin-top: 32px; border-bottom: 1px solid #ddd; padding-bottom: 4px; }
+.generated { color: #666; font-size: 13px; }
+.cards { display: flex; gap: 12px; flex-wrap: wrap; margin-top: 16px; }
+.card { background: #fff; border: 1px solid #ddd; border-radius: 8px; padding: 12px 20px; min-width: 110px; }
+.card .n { font-size: 28px; font-weight: 600; }
+.card .k { color: #666; font-size: 13px; }
+.charts { display: grid; grid-template-columns: repeat(auto-fit, minmax(420px, 1fr)); gap: 16px; }
+.chart { width: 100%; height: auto; background: #fff; border: 1px solid #ddd; border-radius: 8px; }
+.chart .axis { stroke: #999; }
+.chart .label { font-size: 11px; fill: #666; }
+.chart .closed { fill: #5cb85c; opacity: 0.7; }
+.chart polyline.open { fill: none; stroke: #4a9eff; stroke-width: 2; }
+.chart circle.open { fill: #4a9eff; }
+.chart .age { fill: #f0ad4e; }
+.graph-wrap { overflow: auto; background: #fff; border: 1px solid #ddd; border-radius: 8px; max-height: 720px; }
+.graph text { font-size: 11px; }
+.graph .id { font-weight: 600; }
+.graph path { fill: none; stroke: #888; stroke-width: 1.3; marker-end: url(#arrow); }
+.graph path.parent { stroke-dasharray: 5,3; stroke: #aaa; }
+.filters { display: flex; gap: 8px; flex-wrap: wrap; margin-bottom: 8px; }
+.filters input, .filters select { font-size: 13px; padding: 4px 6px; }
+table { border-collapse: collapse; width: 100%; background: #fff; font-size: 13px; }
+th, td { text-align: left; padding: 5px 8px; border-bottom: 1px solid #eee; vertical-align: top; }
+th { background: #f0f0f0; position: sticky; top: 0; }
+tr:target { background: #fff3cd; }
+.status { border-radius: 3px; padding: 1px 6px; font-size: 12px; white-space: nowrap; }
+.status-open { background: #e8f4fd; } .status-in_progress { background: #fff3cd; } .status-blocked { background: #f8d7da; } .status-closed { background: #d4edda; color: #555; }
+.label-chip { background: #eee; border-radius: 3px; padding: 0 4px; margin-right: 3px; font-size: 11px; }
+.muted { color: #888; }
+.bar { display: flex; height: 14px; min-width: 2px; border-radius: 3px; overflow: hidden; }
+.bar span { display: block; height: 100%; }
+.bar .ip { background: #f0ad4e; } .bar .rd { background: #4a9eff; } .bar .bl { background: #d9534f; } .bar .ot { background: #bbb; }
+td.num, th.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="generated">Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}</div>
+
+<div class="cards">
+  <div class="card"><div class="n">{{.Summary.Open}}</div><div class="k">Open</div></div>
+  <div class="card"><div class="n">{{.Summary.InProgress}}</div><div class="k">In progress</div></div>
+  <div class="card"><div class="n">{{.Summary.Ready}}</div><div class="k">Ready</div></div>
+  <div class="card"><div class="n">{{.Summary.Blocked}}</div><div class="k">Blocked</div></div>
+  <div class="card"><div class="n">{{.Summary.Closed}}</div><div class="k">Closed</div></div>
+</div>
+
+<h2>Trends</h2>
+<div class="charts">
+  <div><h3>Burndown</h3><p class="muted">Open issues at the end of each day (line) and issues closed that day (bars).</p>{{.BurndownS}}</div>
+  <div><h3>Age of open issues</h3><p class="muted">Time since creation.</p>{{.AgeS}}</div>
+</div>
+
+<h2>Workload</h2>
+{{if .Workload}}
+<table>
+<tr><th>Assignee</th><th class="num">In progress</th><th class="num">Ready</th><th class="num">Blocked</th><th class="num">Total</th><th class="num">Estimate</th><th style="width:35%"></th></tr>
+{{range .Workload}}
+<tr><td>{{.Assignee}}</td><td class="num">{{.InProgress}}</td><td class="num">{{.Ready}}</td><td class="num">{{.Blocked}}</td><td class="num">{{.Total}}</td><td class="num">{{hours .EstimatedMinutes}}</td>
+<td><div class="bar" style="width:{{pct .Total $.MaxLoad}}%"><span class="ip" style="flex:{{.InProgress}}"></span><span class="rd" style="flex:{{.Ready}}"></span><span class="bl" style="flex:{{.Blocked}}"></span><span class="ot" style="flex:{{.Other}}"></span></div></td></tr>
+{{end}}
+</table>
+{{else}}<p class="muted">No open issues.</p>{{end}}
+
+<h2>Dependency graph</h2>
+{{if .Graph}}
+<p class="muted">Open issues that block (solid) or contain (dashed) another open issue, blockers to the left. Click a node to jump to the issue.</p>
+<div class="graph-wrap">{{.Graph}}</div>
+{{else}}<p class="muted">No dependencies between open issues.</p>{{end}}
+
+<h2>Issues</h2>
+<div class="filters">
+  <input id="f-text" type="search" placeholder="Filter by ID, title or label">
+  <select id="f-status"><option value="!closed">Not closed</option><option value="">Any status</option>{{range .Statuses}}<option>{{.}}</option>{{end}}</select>
+  <select id="f-assignee"><option value="">Any assignee</option><option value="-">Unassigned</option>{{range .Assignees}}<option>{{.}}</option>{{end}}</select>
+  <select id="f-priority"><option value="">Any priority</option><option>0</option><option>1</option><option>2</option><option>3</option><option>4</option></select>
+  <select id="f-type"><option value="">Any type</option>{{range .Types}}<option>{{.}}</option>{{end}}</select>
+  <span id="f-count" class="muted"></span>
+</div>
+<table id="issues">
+<tr><th>ID</th><th>Title</th><th>Status</th><th>P</th><th>Type</th><th>Assignee</th><th>Labels</th><th>Updated</th></tr>
+{{range .Issues}}
+<tr id="issue-{{.ID}}" data-status="{{.Status}}" data-assignee="{{.Assignee}}" data-priority="{{.Priority}}" data-type="{{.Type}}" data-text="{{.ID}} {{.Title}} {{join .Labels " "}}">
+<td>{{.ID}}</td><td>{{.Title}}</td><td><span class="status status-{{.Status}}">{{.Status}}</span>{{if .Blocked}} <span class="status status-blocked">blocked by deps</span>{{end}}</td>
+<td>P{{.Priority}}</td><td>{{.Type}}</td><td>{{.Assignee}}</td><td>{{range .Labels}}<span class="label-chip">{{.}}</span>{{end}}</td><td class="muted">{{.UpdatedAt.Format "2006-01-02"}}</td></tr>
+{{end}}
+</table>
+
+<script>
+(function () {
+  var ids = ["f-text", "f-status", "f-assignee", "f-priority", "f-type"];
+  var rows = Array.prototype.slice.call(document.querySelectorAll("#issues tr[data-status]"));
+  function val(id) { return document.getElementById(id).value; }
+  function apply() {
+    var text = val("f-text").toLowerCase(), status = val("f-status"), assignee = val("f-assignee"),
+        priority = val("f-priority"), type = val("f-type"), shown = 0;
+    rows.forEach(function (r) {
+      var d = r.dataset, ok = true;
+      if (status === "!closed") ok = d.status !== "closed"; else if (status) ok = d.status === status;
+      if (ok && assignee) ok = assignee === "-" ? d.assignee === "" : d.assignee === assignee;
+      if (ok && priority) ok = d.priority === priority;
+      if (ok && type) ok = d.type === type;
+      if (ok && text) ok = d.text.toLowerCase().indexOf(text) >= 0;
+      r.style.display = ok ? "" : "none";
+      if (ok) shown++;
+    });
+    document.getElementById("f-count").textContent = shown + " of " + rows.length + " issues";
+  }
+  ids.forEach(function (id) { document.getElementById(id).addEventListener("input", apply); });
+  apply();
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func dashboardTestIssues(now time.Time) []*types.Issue {
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	closedAt := daysAgo(2)
+	estimate := 90
+	return []*types.Issue{
+		{ID: "dx-1", Title: "Ship <it>", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "alice",
+			CreatedAt: daysAgo(3), EstimatedMinutes: &estimate,
+			Dependencies: []*types.Dependency{{IssueID: "dx-1", DependsOnID: "dx-2", Type: types.DepBlocks}}},
+		{ID: "dx-2", Title: "Build it", Status: types.StatusInProgress, Priority: 0, IssueType: types.TypeTask, Assignee: "alice",
+			CreatedAt: daysAgo(3)},
+		{ID: "dx-3", Title: "Old bug", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeBug,
+			CreatedAt: daysAgo(10), ClosedAt: &closedAt},
+		{ID: "dx-4", Title: "Someday", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeFeature,
+			CreatedAt: daysAgo(100), Labels: []string{"ideas"}},
+		{ID: "dx-5", Title: "Parked", Status: types.StatusDeferred, Priority: 2, IssueType: types.TypeTask, Assignee: "bob",
+			CreatedAt: now.Add(-time.Hour)},
+	}
+}
+
+func TestBuildDashboard(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	data := buildDashboard(dashboardTestIssues(now), now, 7)
+
+	want := dashboardSummary{Open: 2, InProgress: 1, Blocked: 1, Ready: 1, Closed: 1}
+	if data.Summary != want {
+		t.Errorf("summary = %+v, want %+v", data.Summary, want)
+	}
+	if data.Issues[0].ID != "dx-2" || data.Issues[len(data.Issues)-1].ID != "dx-3" {
+		t.Errorf("issue order = %v, want priority order with closed last", data.Issues)
+	}
+	if !data.Issues[1].Blocked {
+		t.Errorf("dx-1 should be marked blocked: %+v", data.Issues[1])
+	}
+
+	if len(data.Workload) != 3 {
+		t.Fatalf("workload = %+v", data.Workload)
+	}
+	alice, bob, unassigned := data.Workload[0], data.Workload[1], data.Workload[2]
+	if alice.Assignee != "alice" || alice.InProgress != 1 || alice.Blocked != 1 || alice.Total != 2 || alice.EstimatedMinutes != 90 {
+		t.Errorf("alice = %+v", alice)
+	}
+	if bob.Other != 1 || unassigned.Assignee != dashboardUnassigned || unassigned.Ready != 1 {
+		t.Errorf("bob = %+v, unassigned = %+v", bob, unassigned)
+	}
+
+	if len(data.Burndown) != 7 || data.Burndown[6].Date != "2026-03-10" {
+		t.Fatalf("burndown = %+v", data.Burndown)
+	}
+	// dx-3 closes two days ago; dx-1 and dx-2 are created three days ago.
+	if d := data.Burndown[3]; d.Created != 2 || d.Open != 4 {
+		t.Errorf("three days ago = %+v, want 2 created, 4 open", d)
+	}
+	if d := data.Burndown[4]; d.Closed != 1 || d.Open != 3 {
+		t.Errorf("two days ago = %+v, want 1 closed, 3 open", d)
+	}
+	if d := data.Burndown[6]; d.Created != 1 || d.Open != 4 {
+		t.Errorf("today = %+v, want 1 created, 4 open", d)
+	}
+
+	counts := map[string]int{}
+	for _, b := range data.Age {
+		counts[b.Label] = b.Count
+	}
+	if counts["< 1 day"] != 1 || counts["1-7 days"] != 2 || counts["3-12 months"] != 1 {
+		t.Errorf("age = %+v", data.Age)
+	}
+}
+
+func TestWriteDashboard(t *testing.T) {
+	now := time.Now()
+	issues := dashboardTestIssues(now)
+	data := buildDashboard(issues, now, 30)
+	data.Title = "Team <board>"
+	dir := filepath.Join(t.TempDir(), "site")
+
+	files, err := writeDashboard(dir, data, issues)
+	if err != nil {
+		t.Fatalf("writeDashboard: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("files = %v", files)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(page)
+	for _, want := range []string{
+		"<title>Team &lt;board&gt;</title>",
+		`<tr id="issue-dx-1"`,
+		`<a href="#issue-dx-2">`,         // graph node for the blocker
+		`class="blocks"`,                 // and its edge
+		"Ship &lt;it&gt;",                // titles are escaped
+		`<span class="label-chip">ideas`, // labels are listed
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("index.html missing %q", want)
+		}
+	}
+	if strings.Contains(html, "<script src") || strings.Contains(html, "<link") {
+		t.Error("index.html should not load external resources")
+	}
+	// Only issues connected to another open issue are drawn.
+	if strings.Contains(html, `href="#issue-dx-4"`) {
+		t.Error("unconnected issue dx-4 should not be in the graph")
+	}
+
+	var decoded dashboardData
+	raw, err := os.ReadFile(filepath.Join(dir, "dashboard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Issues) != 5 || len(decoded.Burndown) != 30 {
+		t.Errorf("dashboard.json has %d issues, %d days", len(decoded.Issues), len(decoded.Burndown))
+	}
+}
@@ -25,7 +25,9 @@ This command will:
 2. Update text references to "[deleted:ID]" in directly connected issues
 3. Permanently delete the issues from the database
 
-This is a destructive operation that cannot be undone. Use with caution.
+Deleted issues that were already in a Dolt commit stay in history and can be
+brought back with 'bd trash restore' (without their dependency links); see
+'bd trash'. Use with caution.
 
 BATCH DELETION:
 Delete multiple issues at once:
@@ -5,8 +5,10 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -98,6 +100,77 @@ func isDisallowedHierarchicalDependency(fromID, toID string, depType types.Depen
 	return depType != types.DepParentChild || toID != immediateParent
 }
 
+// resolveDepTypeAlias maps the relation names dep add accepts to a stored
+// dependency type. Underscores may stand for hyphens (relates_to, caused_by),
+// child-of is parent-child, duplicate-of is duplicates, and parent-of is
+// parent-child with the edge reversed: "A parent-of B" stores "B parent-child
+// A". Other names, including custom types, are returned unchanged.
+func resolveDepTypeAlias(name string) (dt types.DependencyType, reverse bool) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "-")
+	switch normalized {
+	case "child-of":
+		return types.DepParentChild, false
+	case "parent-of":
+		return types.DepParentChild, true
+	case "duplicate-of":
+		return types.DepDuplicates, false
+	}
+	if dt := types.DependencyType(normalized); dt.IsWellKnown() {
+		return dt, false
+	}
+	return types.DependencyType(name), false
+}
+
+// depCyclePath renders the cycle that adding fromID -> toID would close, as
+// "from → to → … → from". It walks the edges the storage cycle check follows
+// (blocks, conditional-blocks, parent-child) from toID back to fromID and
+// returns "" when no such path is found.
+func depCyclePath(fromID, toID string, neighbors depPathNeighborFunc) string {
+	scheduling := func(id string) ([]*types.IssueWithDependencyMetadata, error) {
+		deps, err := neighbors(id)
+		if err != nil {
+			return nil, err
+		}
+		var out []*types.IssueWithDependencyMetadata
+		for _, dep := range deps {
+			switch dep.DependencyType {
+			case types.DepBlocks, types.DepConditionalBlocks, types.DepParentChild:
+				out = append(out, dep)
+			}
+		}
+		return out, nil
+	}
+	paths, err := findBlockingPaths(&types.Issue{ID: toID}, fromID, math.MaxInt32, false, scheduling)
+	if err != nil || len(paths) == 0 {
+		return ""
+	}
+	ids := []string{fromID}
+	for _, node := range paths[0] {
+		ids = append(ids, node.ID)
+	}
+	return strings.Join(ids, " → ")
+}
+
+// depCycleRejection is the error text for a dep add rejected because it would
+// close the given cycle path.
+func depCycleRejection(cycle string) string {
+	msg := "adding dependency would create a cycle"
+	if cycle != "" {
+		msg += ": " + cycle
+	}
+	return msg + "\nUse --force to store it as a non-blocking relates-to link instead, or 'bd dep cycles' to review existing cycles"
+}
+
+// printForcedRelatesTo tells the user that a cycle-closing dependency was
+// stored as relates-to because of --force.
+func printForcedRelatesTo(cycle string, requested types.DependencyType) {
+	if cycle == "" {
+		cycle = "unknown path"
+	}
+	fmt.Fprintf(os.Stderr, "%s %s dependency would create a cycle (%s); stored as relates-to instead\n",
+		ui.RenderWarn("⚠"), requested, cycle)
+}
+
 // warnIfCyclesExist checks for dependency cycles and prints a warning if found.
 func warnIfCyclesExist(s storage.DoltStorage) {
 	if s == nil {
@@ -260,6 +333,18 @@ For bulk wiring, pass newline-delimited JSON with --file. Each line must be an
 object with "from" and "to" fields, and may include "type". The aliases
 "issue_id" and "depends_on_id" are also accepted. Use --file - to read stdin.
 
+Only blocking types (blocks, parent-child, conditional-blocks, waits-for)
+affect ready work. The others record a relation: related/relates-to,
+caused-by, discovered-from, duplicates, supersedes, and so on. Underscores
+may stand for hyphens (relates_to, caused_by), child-of means parent-child,
+and parent-of stores parent-child with the two issues swapped. Closing an
+issue also closes the open issues that duplicate it.
+
+A blocking dependency that would close a cycle (through blocks,
+conditional-blocks or parent-child edges) is rejected, and the error shows
+the full cycle path. Pass --force to keep the link as a non-blocking
+relates-to edge instead. Use 'bd dep cycles' to find cycles already stored.
+
 External references are stored as-is and resolved at query time using
 the external_projects config. They block the issue until the capability
 is "shipped" in the target project.
@@ -270,6 +355,9 @@ Examples:
   bd dep add bd-42 --depends-on bd-41                 # Alias (same effect)
   bd dep add gt-xyz external:beads:mol-run-assignee   # Cross-project dependency
   bd dep add bd-42 bd-41 --no-cycle-check             # Skip cycle check (bulk wiring)
+  bd dep add bd-41 bd-42 --force                      # Cycle? Store as relates-to instead
+  bd dep add bd-42 bd-41 --type relates_to            # Non-blocking relation
+  bd dep add bd-40 bd-42 --type parent_of             # bd-42 becomes a child of bd-40
   bd dep add --file deps.jsonl                        # Bulk JSONL: {"from":"bd-42","to":"bd-41"}`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		file, _ := cmd.Flags().GetString("file")
@@ -378,7 +466,14 @@ Examples:
 			}
 		}
 
-		dt := types.DependencyType(depType)
+		dt, reverse := resolveDepTypeAlias(depType)
+		if reverse {
+			if isExternalRef {
+				return HandleErrorRespectJSON("%s needs a local issue on both sides", depType)
+			}
+			fromID, toID = toID, fromID
+		}
+		depType = string(dt)
 		if isDisallowedHierarchicalDependency(fromID, toID, dt) {
 			return HandleErrorRespectJSON("cannot add dependency: %s is already a child of %s. Children inherit dependency on parent completion via hierarchy. Adding an explicit dependency would create a deadlock", fromID, toID)
 		}
@@ -393,8 +488,25 @@ Examples:
 			Type:        dt,
 		}
 
+		// A rejected cycle is reported with its full path. With --force the
+		// edge is kept as a relates-to link, which does not affect ready work.
+		var forcedCycle string
 		if err := fromStore.AddDependencyWithOptions(ctx, dep, actor, storage.DependencyAddOptions{EmitEvent: true}); err != nil {
-			return HandleErrorRespectJSON("%v", err)
+			if !errors.Is(err, domain.ErrDependencyCycle) {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			forcedCycle = depCyclePath(fromID, toID, func(id string) ([]*types.IssueWithDependencyMetadata, error) {
+				return fromStore.GetDependenciesWithMetadata(ctx, id)
+			})
+			if force, _ := cmd.Flags().GetBool("force"); !force {
+				return HandleErrorRespectJSON("%s", depCycleRejection(forcedCycle))
+			}
+			printForcedRelatesTo(forcedCycle, dt)
+			dep.Type = types.DepRelatesTo
+			depType = string(dep.Type)
+			if err := fromStore.AddDependencyWithOptions(ctx, dep, actor, storage.DependencyAddOptions{EmitEvent: true}); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
 		}
 
 		noCycleCheck, _ := cmd.Flags().GetBool("no-cycle-check")
@@ -410,12 +522,17 @@ Examples:
 		}
 
 		if jsonOutput {
-			return outputJSON(map[string]interface{}{
+			result := map[string]interface{}{
 				"status":        "added",
 				"issue_id":      fromID,
 				"depends_on_id": toID,
 				"type":          depType,
-			})
+			}
+			if dep.Type != dt {
+				result["requested_type"] = string(dt)
+				result["cycle"] = forcedCycle
+			}
+			return outputJSON(result)
 		}
 
 		fmt.Printf("%s Added dependency: %s depends on %s (%s)\n",
@@ -608,7 +725,10 @@ func readBulkDepEdges(file string, defaultType string) ([]bulkDepEdge, error) {
 		if to == "" {
 			errs = append(errs, fmt.Sprintf("line %d: missing to", lineNo))
 		}
-		dt := types.DependencyType(depType)
+		dt, reverse := resolveDepTypeAlias(depType)
+		if reverse {
+			from, to = to, from
+		}
 		if !dt.IsValid() {
 			errs = append(errs, fmt.Sprintf("line %d: invalid dependency type %q: must be non-empty and at most 50 characters", lineNo, depType))
 		}
@@ -1268,10 +1388,18 @@ type treeRenderer struct {
 	direction string
 	// Whether the root node has open children (i.e., is blocked)
 	rootBlocked bool
+	// Where rendered lines go
+	out io.Writer
 }
 
 // renderTree renders the tree with proper box-drawing connectors
 func renderTree(tree []*types.TreeNode, maxDepth int, direction string) {
+	renderTreeTo(os.Stdout, tree, maxDepth, direction)
+}
+
+// renderTreeTo renders the tree like renderTree, writing to w (bd ui draws
+// it into a pane).
+func renderTreeTo(w io.Writer, tree []*types.TreeNode, maxDepth int, direction string) {
 	if len(tree) == 0 {
 		return
 	}
@@ -1281,6 +1409,7 @@ func renderTree(tree []*types.TreeNode, maxDepth int, direction string) {
 		activeConnectors: make([]bool, maxDepth+1),
 		maxDepth:         maxDepth,
 		direction:        direction,
+		out:              w,
 	}
 
 	// Build a map of parent -> children for proper sibling tracking
@@ -1302,7 +1431,8 @@ func renderTree(tree []*types.TreeNode, maxDepth int, direction string) {
 	// Check if root has open blocking dependencies (GH#3565).
 	// Only genuine blockers (blocks, conditional-blocks, waits-for) count;
 	// parent-child, related, discovered-from, etc. do not block.
-	if root != nil {
+	// In an up tree the root's children are its dependents, not blockers.
+	if root != nil && direction != "up" {
 		hasOpenBlockers := false
 		for _, child := range children[root.ID] {
 			if (child.Status == types.StatusOpen || child.Status == types.StatusInProgress) &&
@@ -1347,7 +1477,7 @@ func (r *treeRenderer) renderNode(node *types.TreeNode, children map[string][]*t
 
 	// Check if we've seen this node before (diamond dependency)
 	if r.seen[node.ID] {
-		fmt.Printf("%s%s (shown above)\n", prefix.String(), ui.RenderMuted(node.ID))
+		fmt.Fprintf(r.out, "%s%s (shown above)\n", prefix.String(), ui.RenderMuted(node.ID))
 		return
 	}
 	r.seen[node.ID] = true
@@ -1360,7 +1490,7 @@ func (r *treeRenderer) renderNode(node *types.TreeNode, children map[string][]*t
 		line += ui.RenderWarn(" …")
 	}
 
-	fmt.Printf("%s%s\n", prefix.String(), line)
+	fmt.Fprintf(r.out, "%s%s\n", prefix.String(), line)
 
 	// Render children
 	nodeChildren := children[node.ID]
@@ -1573,10 +1703,11 @@ func init() {
 	depCmd.Flags().StringP("blocks", "b", "", "Issue ID that this issue blocks (shorthand for: bd dep add <blocked> <blocker>)")
 	depCmd.Flags().Bool("no-cycle-check", false, "Skip per-edge cycle checks for speed (bulk wiring); bulk --file adds still run one final whole-graph check before commit")
 
-	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|tracks|related|parent-child|discovered-from|until|caused-by|validates|relates-to|supersedes)")
+	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|tracks|related|parent-child|child-of|parent-of|discovered-from|until|caused-by|validates|relates-to|duplicates|supersedes)")
 	depAddCmd.Flags().String("blocked-by", "", "Issue ID that blocks the first issue (alternative to positional arg)")
 	depAddCmd.Flags().String("depends-on", "", "Issue ID that the first issue depends on (alias for --blocked-by)")
 	depAddCmd.Flags().String("file", "", "Read dependency edges from JSONL file, or '-' for stdin")
+	depAddCmd.Flags().Bool("force", false, "Store a dependency that would create a cycle as a non-blocking relates-to link instead of rejecting it")
 	depAddCmd.Flags().Bool("no-cycle-check", false, "Skip per-edge cycle checks for speed (bulk wiring); bulk --file adds still run one final whole-graph check before commit")
 
 	depTreeCmd.Flags().Bool("show-all-paths", false, "Show all paths to nodes (no deduplication for diamond dependencies)")
@@ -1590,6 +1721,12 @@ func init() {
 	// Note: --type flag intentionally omitted from depTreeCmd — TreeNode lacks
 	// dependency type info so filtering is not possible. Use 'bd dep list --type' instead.
 
+	depPathCmd.Flags().Bool("all", false, "Show every blocking path instead of only the shortest")
+	depPathCmd.Flags().IntP("max-depth", "d", 50, "Maximum path length in edges (safety limit)")
+
+	depInferCmd.Flags().Bool("apply", false, "Add the proposed dependencies instead of only listing them")
+	depInferCmd.Flags().Float64("min-confidence", 0.5, "Only propose dependencies with at least this confidence (0-1)")
+
 	depListCmd.Flags().String("direction", "down", "Direction: 'down' (dependencies), 'up' (dependents)")
 	depListCmd.Flags().StringP("type", "t", "", "Filter by dependency type (e.g., tracks, blocks, parent-child)")
 
@@ -1598,11 +1735,15 @@ func init() {
 	depRemoveCmd.ValidArgsFunction = issueIDCompletion
 	depListCmd.ValidArgsFunction = issueIDCompletion
 	depTreeCmd.ValidArgsFunction = issueIDCompletion
+	depPathCmd.ValidArgsFunction = issueIDCompletion
+	depInferCmd.ValidArgsFunction = issueIDCompletion
 
 	depCmd.AddCommand(depAddCmd)
 	depCmd.AddCommand(depRemoveCmd)
 	depCmd.AddCommand(depListCmd)
 	depCmd.AddCommand(depTreeCmd)
+	depCmd.AddCommand(depPathCmd)
+	depCmd.AddCommand(depInferCmd)
 	depCmd.AddCommand(depCyclesCmd)
 	rootCmd.AddCommand(depCmd)
 }
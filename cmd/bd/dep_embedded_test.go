@@ -157,6 +157,56 @@ func TestEmbeddedDep(t *testing.T) {
 		// Should succeed without error
 	})
 
+	t.Run("add_type_aliases", func(t *testing.T) {
+		r1 := bdCreate(t, bd, dir, "Alias related 1", "--type", "task")
+		r2 := bdCreate(t, bd, dir, "Alias related 2", "--type", "task")
+		bdDep(t, bd, dir, "add", r1.ID, r2.ID, "--type", "relates_to")
+		if out := bdDep(t, bd, dir, "list", r1.ID, "--type", "relates-to"); !strings.Contains(out, r2.ID) {
+			t.Errorf("expected relates-to edge: %s", out)
+		}
+		if out := bdCommand(t, bd, dir, "ready", "--json"); !strings.Contains(out, r1.ID) {
+			t.Errorf("relates-to should not block %s:\n%s", r1.ID, out)
+		}
+
+		parent := bdCreate(t, bd, dir, "Alias parent", "--type", "task")
+		kid := bdCreate(t, bd, dir, "Alias kid", "--type", "task")
+		done := bdCreate(t, bd, dir, "Alias done kid", "--type", "task")
+		bdDep(t, bd, dir, "add", parent.ID, kid.ID, "--type", "parent_of")
+		bdDep(t, bd, dir, "add", done.ID, parent.ID, "--type", "child-of")
+		if out := bdDep(t, bd, dir, "list", parent.ID, "--direction", "up", "--type", "parent-child"); !strings.Contains(out, kid.ID) || !strings.Contains(out, done.ID) {
+			t.Errorf("expected both children of %s: %s", parent.ID, out)
+		}
+		bdCommand(t, bd, dir, "close", done.ID)
+		if out := bdCommand(t, bd, dir, "show", parent.ID); !strings.Contains(out, "1/2 complete (50%)") {
+			t.Errorf("expected child progress on a non-epic parent:\n%s", out)
+		}
+	})
+
+	t.Run("close_propagates_to_duplicates", func(t *testing.T) {
+		canonical := bdCreate(t, bd, dir, "Canonical", "--type", "bug")
+		dup := bdCreate(t, bd, dir, "Duplicate report", "--type", "bug")
+		bdDep(t, bd, dir, "add", dup.ID, canonical.ID, "--type", "duplicate-of")
+		if out := bdCommand(t, bd, dir, "show", canonical.ID); !strings.Contains(out, "DUPLICATES") || !strings.Contains(out, dup.ID) {
+			t.Errorf("expected a DUPLICATES section:\n%s", out)
+		}
+		out := bdCommand(t, bd, dir, "close", canonical.ID)
+		if !strings.Contains(out, "Closed duplicate") {
+			t.Errorf("expected duplicate closure in output:\n%s", out)
+		}
+		if got := bdShow(t, bd, dir, dup.ID); got.Status != "closed" {
+			t.Errorf("duplicate status = %s, want closed", got.Status)
+		}
+
+		// Closing through bd update propagates the same way.
+		canonical2 := bdCreate(t, bd, dir, "Canonical via update", "--type", "bug")
+		dup2 := bdCreate(t, bd, dir, "Duplicate via update", "--type", "bug")
+		bdDep(t, bd, dir, "add", dup2.ID, canonical2.ID, "--type", "duplicate-of")
+		bdCommand(t, bd, dir, "update", canonical2.ID, "--status", "closed")
+		if got := bdShow(t, bd, dir, dup2.ID); got.Status != "closed" || got.CloseReason != "duplicate of "+canonical2.ID {
+			t.Errorf("duplicate = %s (%q), want closed as duplicate of %s", got.Status, got.CloseReason, canonical2.ID)
+		}
+	})
+
 	t.Run("add_blocked_by_flag", func(t *testing.T) {
 		x := bdCreate(t, bd, dir, "Blocked by test", "--type", "task")
 		y := bdCreate(t, bd, dir, "Blocker test", "--type", "task")
@@ -188,6 +238,30 @@ func TestEmbeddedDep(t *testing.T) {
 		}
 	})
 
+	t.Run("add_cycle_path_and_force", func(t *testing.T) {
+		a := bdCreate(t, bd, dir, "Cycle path A", "--type", "task")
+		b := bdCreate(t, bd, dir, "Cycle path B", "--type", "task")
+		c := bdCreate(t, bd, dir, "Cycle path C", "--type", "task")
+		bdDep(t, bd, dir, "add", a.ID, b.ID)
+		bdDep(t, bd, dir, "add", b.ID, c.ID)
+
+		out := bdDepFail(t, bd, dir, "add", c.ID, a.ID)
+		if want := c.ID + " → " + a.ID + " → " + b.ID + " → " + c.ID; !strings.Contains(out, want) {
+			t.Errorf("expected cycle path %q in error: %s", want, out)
+		}
+		if !strings.Contains(out, "--force") {
+			t.Errorf("expected --force hint in error: %s", out)
+		}
+
+		res := bdDepJSON(t, bd, dir, "add", c.ID, a.ID, "--force")
+		if res["type"] != "relates-to" || res["requested_type"] != "blocks" {
+			t.Errorf("expected blocks downgraded to relates-to, got %v", res)
+		}
+		if out := bdDep(t, bd, dir, "list", c.ID, "--type", "relates-to"); !strings.Contains(out, a.ID) {
+			t.Errorf("expected relates-to edge %s -> %s: %s", c.ID, a.ID, out)
+		}
+	})
+
 	t.Run("add_child_parent_antipattern", func(t *testing.T) {
 		p := bdCreate(t, bd, dir, "AP Parent", "--type", "epic")
 		// Create child with hierarchical ID
@@ -405,6 +479,117 @@ func TestEmbeddedDep(t *testing.T) {
 		}
 	})
 
+	// ===== dep path =====
+
+	t.Run("path_shortest_and_all", func(t *testing.T) {
+		top := bdCreate(t, bd, dir, "Path top", "--type", "task")
+		mid := bdCreate(t, bd, dir, "Path middle", "--type", "task")
+		bottom := bdCreate(t, bd, dir, "Path bottom", "--type", "task")
+		loose := bdCreate(t, bd, dir, "Path loose", "--type", "task")
+		bdDep(t, bd, dir, "add", top.ID, mid.ID)
+		bdDep(t, bd, dir, "add", mid.ID, bottom.ID)
+		bdDep(t, bd, dir, "add", top.ID, bottom.ID)
+		bdDep(t, bd, dir, "add", top.ID, loose.ID, "--type", "relates-to")
+
+		out := bdDep(t, bd, dir, "path", top.ID, bottom.ID)
+		if !strings.Contains(out, "(1 hops)") || strings.Contains(out, mid.ID) {
+			t.Errorf("expected the direct edge as shortest path: %s", out)
+		}
+
+		fullArgs := []string{"dep", "path", top.ID, bottom.ID, "--all", "--json"}
+		cmd := exec.Command(bd, fullArgs...)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		stdout, stderr, err := runCommandBuffers(t, cmd)
+		if err != nil {
+			t.Fatalf("dep path --json failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+		}
+		var paths [][]struct {
+			ID             string `json:"id"`
+			EdgeFromParent string `json:"edge_from_parent"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &paths); err != nil {
+			t.Fatalf("parse dep path JSON: %v\n%s", err, stdout.String())
+		}
+		if len(paths) != 2 || len(paths[0]) != 2 || len(paths[1]) != 3 || paths[1][1].ID != mid.ID {
+			t.Errorf("expected direct and via-middle paths, got %+v", paths)
+		}
+
+		if out := bdDep(t, bd, dir, "path", top.ID, loose.ID); !strings.Contains(out, "does not depend on") {
+			t.Errorf("relates-to should not form a blocking path: %s", out)
+		}
+	})
+
+	// ===== dep infer =====
+
+	t.Run("infer_proposes_and_applies", func(t *testing.T) {
+		schema := bdCreate(t, bd, dir, "Infer schema", "--type", "task")
+		spike := bdCreate(t, bd, dir, "Infer spike", "--type", "task")
+		api := bdCreate(t, bd, dir, "Infer API", "--type", "task",
+			"--description", "Blocked by "+schema.ID+". No longer waiting on "+spike.ID+".")
+
+		out := bdDep(t, bd, dir, "infer", api.ID)
+		if !strings.Contains(out, schema.ID) || strings.Contains(out, spike.ID) || !strings.Contains(out, "1 proposal(s)") {
+			t.Fatalf("expected one proposal: %s", out)
+		}
+		bdDep(t, bd, dir, "infer", api.ID, "--apply")
+		if out := bdDep(t, bd, dir, "list", api.ID, "--json"); !strings.Contains(out, schema.ID) {
+			t.Errorf("inferred dependency was not added: %s", out)
+		}
+		if out := bdDep(t, bd, dir, "infer", api.ID); !strings.Contains(out, "No unlinked dependencies") {
+			t.Errorf("an added dependency should not be proposed again: %s", out)
+		}
+	})
+
+	// ===== impact =====
+
+	t.Run("impact_transitive_dependents", func(t *testing.T) {
+		root := bdCreate(t, bd, dir, "Impact root", "--type", "task")
+		direct := bdCreate(t, bd, dir, "Impact direct", "--type", "task")
+		shared := bdCreate(t, bd, dir, "Impact shared", "--type", "task")
+		other := bdCreate(t, bd, dir, "Impact other blocker", "--type", "task")
+		indirect := bdCreate(t, bd, dir, "Impact indirect", "--type", "task")
+		child := bdCreate(t, bd, dir, "Impact child", "--type", "task")
+		bdDep(t, bd, dir, "add", direct.ID, root.ID)
+		bdDep(t, bd, dir, "add", shared.ID, root.ID)
+		bdDep(t, bd, dir, "add", shared.ID, other.ID)
+		bdDep(t, bd, dir, "add", indirect.ID, direct.ID)
+		bdDep(t, bd, dir, "add", child.ID, root.ID, "--type", "parent-child")
+
+		cmd := exec.Command(bd, "impact", root.ID, "--json")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		stdout, stderr, err := runCommandBuffers(t, cmd)
+		if err != nil {
+			t.Fatalf("impact --json failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+		}
+		var entries []impactEntry
+		if err := json.Unmarshal(stdout.Bytes(), &entries); err != nil {
+			t.Fatalf("parse impact JSON: %v\n%s", err, stdout.String())
+		}
+		got := make(map[string]impactEntry)
+		for _, e := range entries {
+			got[e.ID] = e
+		}
+		if len(entries) != 4 {
+			t.Fatalf("expected 4 dependents, got %+v", entries)
+		}
+		if got[direct.ID].Effect != impactUnblocked || got[shared.ID].Effect != "" || got[child.ID].Effect != impactOrphaned {
+			t.Errorf("unexpected effects: %+v", entries)
+		}
+		if e := got[indirect.ID]; e.Depth != 2 || e.ParentID != direct.ID {
+			t.Errorf("indirect dependent = %+v, want depth 2 via %s", e, direct.ID)
+		}
+
+		out := bdCommand(t, bd, dir, "impact", root.ID)
+		if !strings.Contains(out, "4 open issue(s)") || !strings.Contains(out, "makes ready: "+direct.ID) {
+			t.Errorf("unexpected impact output: %s", out)
+		}
+		if out := bdCommand(t, bd, dir, "impact", indirect.ID); !strings.Contains(out, "No open work depends on") {
+			t.Errorf("expected no dependents of %s: %s", indirect.ID, out)
+		}
+	})
+
 	// ===== dep cycles =====
 
 	t.Run("cycles_detect", func(t *testing.T) {
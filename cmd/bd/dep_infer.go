@@ -0,0 +1,391 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var depInferCmd = &cobra.Command{
+	Use:   "infer [id...]",
+	Short: "Propose dependencies from issue IDs mentioned in issue text",
+	Long: `Find dependencies that issue text describes but the graph does not
+have, such as a description saying "blocked by bd-42" without a blocks edge.
+
+The description, notes and acceptance criteria of open issues (or of the
+given issues) are scanned for references to existing issue IDs that follow
+a relation phrase in the same sentence:
+
+  blocked by, depends on, waiting on/for    blocks
+  requires, needs, after                    blocks
+  blocks, blocker for, prerequisite for     blocks (the other issue waits)
+  duplicate of, duplicates                  duplicates
+  child of, subtask of, part of, parent of  parent-child
+  caused by                                 caused-by
+  related to, see also, see                 related
+
+Each proposal carries a confidence from 0 to 1: explicit phrases such as
+"blocked by" or "duplicate of" score 0.9, "requires" 0.7, and loose ones
+such as "after" or "see" 0.3-0.4. A negated phrase ("no longer blocked by
+bd-42") proposes nothing, and pairs of issues that are already linked in
+either direction are skipped. Only proposals at or above
+--min-confidence are listed.
+
+By default bd dep infer only reports. --apply adds the listed proposals in
+one transaction with the same checks as 'bd dep add --file', so a proposal
+that would create a cycle rolls the whole batch back; raise
+--min-confidence or link the issues by hand in that case.
+
+Examples:
+  bd dep infer                          # Review proposals
+  bd dep infer bd-42 --min-confidence 0 # Everything found in one issue
+  bd dep infer --apply                  # Add the proposals
+  bd dep infer --json`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("dep-infer")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("dep infer is not supported in proxied-server mode")
+		}
+
+		apply, _ := cmd.Flags().GetBool("apply")
+		minConfidence, _ := cmd.Flags().GetFloat64("min-confidence")
+		if minConfidence < 0 || minConfidence > 1 {
+			return HandleErrorRespectJSON("--min-confidence must be between 0 and 1")
+		}
+		if apply {
+			CheckReadonly("dep infer")
+		}
+
+		ctx := rootCtx
+		notTemplate, persistent := false, false
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IsTemplate: &notTemplate, Ephemeral: &persistent})
+		if err != nil {
+			return HandleErrorRespectJSON("failed to search issues: %v", err)
+		}
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		deps, err := store.GetDependencyRecordsForIssues(ctx, ids)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to get dependencies: %v", err)
+		}
+		for _, issue := range issues {
+			issue.Dependencies = deps[issue.ID]
+		}
+
+		var only map[string]bool
+		if len(args) > 0 {
+			only = make(map[string]bool, len(args))
+			for _, arg := range args {
+				id, err := utils.ResolvePartialID(ctx, store, arg)
+				if err != nil {
+					return HandleErrorRespectJSON("%v", err)
+				}
+				only[id] = true
+			}
+		}
+
+		report := &depInferReport{Proposals: []*inferredDep{}}
+		for _, p := range inferDependencies(issues, only) {
+			if p.Confidence >= minConfidence {
+				report.Proposals = append(report.Proposals, p)
+			}
+		}
+
+		if apply && len(report.Proposals) > 0 {
+			edges := make([]bulkDepEdge, len(report.Proposals))
+			for i, p := range report.Proposals {
+				edges[i] = bulkDepEdge{Line: i + 1, IssueID: p.IssueID, DependsOnID: p.DependsOnID, Type: p.Type}
+			}
+			commitMsg := fmt.Sprintf("dependency: infer %d edges", len(edges))
+			if err := transact(ctx, store, commitMsg, func(tx storage.Transaction) error {
+				return addBulkDependenciesInTx(ctx, tx, edges, false, actor)
+			}); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			report.Applied = true
+			warnIfCyclesExist(store)
+		}
+
+		if jsonOutput {
+			return outputJSON(report)
+		}
+		printDepInferReport(report)
+		return nil
+	},
+}
+
+// depInferReport is the `bd dep infer --json` payload.
+type depInferReport struct {
+	Applied   bool           `json:"applied"`
+	Proposals []*inferredDep `json:"proposals"`
+}
+
+// inferredDep is a dependency that issue text suggests: IssueID depends on
+// DependsOnID with Type, found in Field of Source.
+type inferredDep struct {
+	IssueID     string               `json:"issue_id"`
+	DependsOnID string               `json:"depends_on_id"`
+	Type        types.DependencyType `json:"type"`
+	Confidence  float64              `json:"confidence"`
+	Source      string               `json:"source"` // issue whose text mentions the other
+	Field       string               `json:"field"`
+	Evidence    string               `json:"evidence"`
+}
+
+// depInferRule maps a relation phrase to the dependency it implies for the
+// issue an ID reference follows.
+type depInferRule struct {
+	phrase     string
+	depType    types.DependencyType
+	reverse    bool // the referenced issue depends on the issue being scanned
+	confidence float64
+}
+
+var depInferRules = []depInferRule{
+	{"blocked by", types.DepBlocks, false, 0.9},
+	{"blocked on", types.DepBlocks, false, 0.9},
+	{"depends on", types.DepBlocks, false, 0.9},
+	{"dependent on", types.DepBlocks, false, 0.9},
+	{"waiting on", types.DepBlocks, false, 0.9},
+	{"waiting for", types.DepBlocks, false, 0.9},
+	{"waits on", types.DepBlocks, false, 0.9},
+	{"waits for", types.DepBlocks, false, 0.9},
+	{"requires", types.DepBlocks, false, 0.7},
+	{"needs", types.DepBlocks, false, 0.7},
+	{"after", types.DepBlocks, false, 0.4},
+	{"blocks", types.DepBlocks, true, 0.9},
+	{"blocking", types.DepBlocks, true, 0.8},
+	{"blocker for", types.DepBlocks, true, 0.9},
+	{"blocker of", types.DepBlocks, true, 0.9},
+	{"prerequisite for", types.DepBlocks, true, 0.8},
+	{"required by", types.DepBlocks, true, 0.7},
+	{"needed by", types.DepBlocks, true, 0.7},
+	{"duplicate of", types.DepDuplicates, false, 0.9},
+	{"dupe of", types.DepDuplicates, false, 0.9},
+	{"duplicates", types.DepDuplicates, false, 0.8},
+	{"child of", types.DepParentChild, false, 0.8},
+	{"subtask of", types.DepParentChild, false, 0.8},
+	{"part of", types.DepParentChild, false, 0.6},
+	{"parent of", types.DepParentChild, true, 0.8},
+	{"caused by", types.DepCausedBy, false, 0.8},
+	{"related to", types.DepRelated, false, 0.4},
+	{"relates to", types.DepRelated, false, 0.4},
+	{"see also", types.DepRelated, false, 0.4},
+	{"see", types.DepRelated, false, 0.3},
+}
+
+// maxInferGapWords is how many words may separate a relation phrase from
+// the ID it applies to ("blocked by the schema work in bd-42"); other IDs
+// in a list do not count.
+const maxInferGapWords = 4
+
+var (
+	depInferPhraseRe = func() *regexp.Regexp {
+		phrases := make([]string, len(depInferRules))
+		for i, r := range depInferRules {
+			phrases[i] = strings.ReplaceAll(regexp.QuoteMeta(r.phrase), " ", `[\s_-]+`)
+		}
+		// Longer phrases first so "blocker for" wins over a shorter prefix.
+		sort.SliceStable(phrases, func(i, j int) bool { return len(phrases[i]) > len(phrases[j]) })
+		return regexp.MustCompile(`(?i)\b(?:` + strings.Join(phrases, "|") + `)\b`)
+	}()
+	depInferIDRe       = regexp.MustCompile(`[A-Za-z0-9][A-Za-z0-9_-]*-[A-Za-z0-9]+(?:\.[0-9]+)*`)
+	depInferClauseRe   = regexp.MustCompile(`[.;!?](?:\s|$)|\n`)
+	depInferNegationRe = regexp.MustCompile(`(?i)(?:\bnot|\bno longer|n't|\bnever)\s*$`)
+	depInferSpaceRe    = regexp.MustCompile(`[\s_-]+`)
+)
+
+// depInferRuleFor returns the rule of a matched phrase.
+func depInferRuleFor(phrase string) depInferRule {
+	normalized := strings.ToLower(depInferSpaceRe.ReplaceAllString(phrase, " "))
+	for _, r := range depInferRules {
+		if r.phrase == normalized {
+			return r
+		}
+	}
+	return depInferRule{}
+}
+
+// inferDependencies scans the text of the non-closed issues (only those in
+// only, when set) for ID references after relation phrases. issues is every
+// known issue with its dependencies loaded: references to other IDs are
+// ignored, as are pairs already linked in either direction and edges that
+// would make a closed issue depend on something. A pair is proposed once,
+// with the most confident type. Proposals are sorted by confidence, then ID.
+func inferDependencies(issues []*types.Issue, only map[string]bool) []*inferredDep {
+	byID := make(map[string]*types.Issue, len(issues))
+	linked := make(map[[2]string]bool)
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+		for _, dep := range issue.Dependencies {
+			linked[[2]string{issue.ID, dep.DependsOnID}] = true
+			linked[[2]string{dep.DependsOnID, issue.ID}] = true
+		}
+	}
+
+	best := make(map[[2]string]*inferredDep)
+	var order [][2]string
+	for _, issue := range issues {
+		if issue.Status == types.StatusClosed || (only != nil && !only[issue.ID]) {
+			continue
+		}
+		fields := []struct{ name, text string }{
+			{"description", issue.Description},
+			{"notes", issue.Notes},
+			{"acceptance_criteria", issue.AcceptanceCriteria},
+		}
+		for _, field := range fields {
+			for _, m := range scanDepReferences(field.text, byID) {
+				if m.ref == issue.ID {
+					continue
+				}
+				p := &inferredDep{
+					IssueID:     issue.ID,
+					DependsOnID: m.ref,
+					Type:        m.rule.depType,
+					Confidence:  m.rule.confidence,
+					Source:      issue.ID,
+					Field:       field.name,
+					Evidence:    m.evidence,
+				}
+				if m.rule.reverse {
+					p.IssueID, p.DependsOnID = m.ref, issue.ID
+				}
+				pair := [2]string{p.IssueID, p.DependsOnID}
+				if linked[pair] || byID[p.IssueID].Status == types.StatusClosed {
+					continue
+				}
+				// One proposal per pair of issues, whichever issue mentions
+				// the other.
+				key := pair
+				if key[0] > key[1] {
+					key[0], key[1] = key[1], key[0]
+				}
+				if prev, ok := best[key]; !ok {
+					order = append(order, key)
+					best[key] = p
+				} else if p.Confidence > prev.Confidence {
+					best[key] = p
+				}
+			}
+		}
+	}
+
+	proposals := make([]*inferredDep, 0, len(order))
+	for _, key := range order {
+		proposals = append(proposals, best[key])
+	}
+	sort.SliceStable(proposals, func(i, j int) bool {
+		a, b := proposals[i], proposals[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if a.IssueID != b.IssueID {
+			return a.IssueID < b.IssueID
+		}
+		return a.DependsOnID < b.DependsOnID
+	})
+	return proposals
+}
+
+// depReference is an ID reference that follows a relation phrase.
+type depReference struct {
+	ref      string
+	rule     depInferRule
+	evidence string
+}
+
+// scanDepReferences finds the references to known IDs in text that follow
+// a relation phrase in the same sentence, each attributed to the nearest
+// phrase before it.
+func scanDepReferences(text string, known map[string]*types.Issue) []depReference {
+	var refs []depReference
+	start := 0
+	bounds := append(depInferClauseRe.FindAllStringIndex(text, -1), []int{len(text), len(text)})
+	for _, b := range bounds {
+		clause := text[start:b[0]]
+		start = b[1]
+
+		phrases := depInferPhraseRe.FindAllStringIndex(clause, -1)
+		if len(phrases) == 0 {
+			continue
+		}
+		ids := depInferIDRe.FindAllStringIndex(clause, -1)
+		for _, idLoc := range ids {
+			ref := clause[idLoc[0]:idLoc[1]]
+			if known[ref] == nil {
+				continue
+			}
+			// The nearest phrase that ends before the reference.
+			p := -1
+			for i, ph := range phrases {
+				if ph[1] <= idLoc[0] {
+					p = i
+				}
+			}
+			if p < 0 {
+				continue
+			}
+			ph := phrases[p]
+			if depInferNegationRe.MatchString(clause[:ph[0]]) {
+				continue
+			}
+			gap := clause[ph[1]:idLoc[0]]
+			for _, other := range ids {
+				if other[0] >= ph[1] && other[1] <= idLoc[0] {
+					gap = strings.Replace(gap, clause[other[0]:other[1]], " ", 1)
+				}
+			}
+			words := 0
+			for _, w := range strings.Fields(gap) {
+				if w != "," && w != "and" && w != "or" && w != "&" {
+					words++
+				}
+			}
+			if words > maxInferGapWords {
+				continue
+			}
+			evidence := strings.Join(strings.Fields(clause[ph[0]:idLoc[1]]), " ")
+			if r := []rune(evidence); len(r) > 80 {
+				evidence = string(r[:77]) + "..."
+			}
+			refs = append(refs, depReference{ref: ref, rule: depInferRuleFor(clause[ph[0]:ph[1]]), evidence: evidence})
+		}
+	}
+	return refs
+}
+
+func printDepInferReport(report *depInferReport) {
+	if len(report.Proposals) == 0 {
+		fmt.Printf("%s No unlinked dependencies found in issue text\n", ui.RenderPass("✓"))
+		return
+	}
+	for i, p := range report.Proposals {
+		fmt.Printf("%3d. %s %s %s  %s\n", i+1, p.IssueID, ui.RenderMuted("→"), p.DependsOnID,
+			ui.RenderMuted(fmt.Sprintf("[%s] %.1f", p.Type, p.Confidence)))
+		fmt.Printf("     %s %s\n", ui.RenderMuted(p.Source+" "+p.Field+":"), fmt.Sprintf("%q", p.Evidence))
+	}
+	fmt.Println()
+	if report.Applied {
+		fmt.Printf("%s Added %d dependencies\n", ui.RenderPass("✓"), len(report.Proposals))
+		return
+	}
+	fmt.Printf("%d proposal(s). Run 'bd dep infer --apply' to add them.\n", len(report.Proposals))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func depInferTestIssues() []*types.Issue {
+	issue := func(id, description string) *types.Issue {
+		return &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Description: description}
+	}
+	linked := issue("bd-5", "Blocked by bd-1.")
+	linked.Dependencies = []*types.Dependency{{IssueID: "bd-5", DependsOnID: "bd-1", Type: types.DepBlocks}}
+	closed := issue("bd-9", "Depends on bd-1.")
+	closed.Status = types.StatusClosed
+	return []*types.Issue{
+		issue("bd-1", "Schema work."),
+		issue("bd-2", "Blocked by bd-1 and bd-3; see also bd-4. Not blocked by bd-6 any more."),
+		issue("bd-3", "This blocks bd-4, which is waiting for the schema rewrite that we planned in bd-1.1."),
+		issue("bd-4", "Duplicate of bd-7. Mentions bd-1 without a relation; needs bd-404."),
+		linked,
+		issue("bd-6", "Blocker for bd-9."),
+		issue("bd-7", "Part of bd-1.1\nrelated to bd-4"),
+		closed,
+		issue("bd-1.1", ""),
+	}
+}
+
+func TestInferDependencies(t *testing.T) {
+	var got []string
+	for _, p := range inferDependencies(depInferTestIssues(), nil) {
+		got = append(got, fmt.Sprintf("%s>%s:%s:%.1f", p.IssueID, p.DependsOnID, p.Type, p.Confidence))
+	}
+	want := []string{
+		"bd-2>bd-1:blocks:0.9",
+		"bd-2>bd-3:blocks:0.9",
+		"bd-4>bd-3:blocks:0.9",     // "bd-3 blocks bd-4", found in bd-3
+		"bd-4>bd-7:duplicates:0.9", // the duplicate-of edge wins over bd-7's "related to"
+		"bd-7>bd-1.1:parent-child:0.6",
+		"bd-2>bd-4:related:0.4",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("inferDependencies =\n  %s\nwant\n  %s", strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+func TestInferDependenciesOnly(t *testing.T) {
+	proposals := inferDependencies(depInferTestIssues(), map[string]bool{"bd-3": true})
+	if len(proposals) != 1 || proposals[0].Source != "bd-3" || proposals[0].Evidence != "blocks bd-4" {
+		t.Fatalf("proposals for bd-3 = %+v", proposals)
+	}
+}
+
+func TestScanDepReferencesGap(t *testing.T) {
+	known := map[string]*types.Issue{"bd-1": {ID: "bd-1"}}
+	if refs := scanDepReferences("Blocked by the schema work in bd-1", known); len(refs) != 1 {
+		t.Errorf("four words between phrase and ID should match, got %+v", refs)
+	}
+	if refs := scanDepReferences("Blocked by some of the remaining schema work in bd-1", known); len(refs) != 0 {
+		t.Errorf("a distant reference should not match, got %+v", refs)
+	}
+	if refs := scanDepReferences("No longer blocked-by bd-1", known); len(refs) != 0 {
+		t.Errorf("a negated phrase should not match, got %+v", refs)
+	}
+}
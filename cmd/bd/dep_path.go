@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// maxDepPaths caps how many paths bd dep path --all collects, since the number
+// of simple paths in a dense graph grows exponentially with depth.
+const maxDepPaths = 100
+
+var depPathCmd = &cobra.Command{
+	Use:   "path <from-id> <to-id>",
+	Short: "Show the blocking path(s) between two issues",
+	Long: `Show how one issue depends on another through blocking dependencies.
+
+A path starts at <from-id> and follows the issues it depends on (blocks,
+parent-child, conditional-blocks and waits-for edges) until it reaches
+<to-id>. Relations that do not affect ready work, such as relates-to or
+discovered-from, are not followed. By default the shortest path is shown;
+--all lists every path of at most --max-depth edges, shortest first.
+
+With --json each path is an array of tree nodes (the same shape as
+bd dep tree --json): depth is the position along the path, parent_id the
+previous issue and edge_from_parent the dependency type of that hop.
+
+Examples:
+  bd dep path bd-10 bd-95                # Why is bd-10 waiting on bd-95?
+  bd dep path bd-10 bd-95 --all          # Every blocking path
+  bd dep path bd-10 bd-95 --json         # Paths for scripts`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("dep-path")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		all, _ := cmd.Flags().GetBool("all")
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		if maxDepth < 1 {
+			return HandleErrorRespectJSON("--max-depth must be >= 1")
+		}
+
+		if usesProxiedServer() {
+			return runDepPathProxiedServer(cmd, rootCtx, args, maxDepth, all)
+		}
+
+		ctx := rootCtx
+
+		fromID, pathStore, pathCleanup, err := resolveIDWithRouting(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer pathCleanup()
+
+		toID, _, toCleanup, err := resolveIDWithRouting(ctx, store, args[1])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		toCleanup()
+
+		from, err := pathStore.GetIssue(ctx, fromID)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		paths, err := findBlockingPaths(from, toID, maxDepth, all, func(id string) ([]*types.IssueWithDependencyMetadata, error) {
+			return pathStore.GetDependenciesWithMetadata(ctx, id)
+		})
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		return outputDepPaths(paths, fromID, toID)
+	},
+}
+
+// depPathNeighborFunc lists the issues an issue depends on, with the type of
+// each dependency.
+type depPathNeighborFunc func(id string) ([]*types.IssueWithDependencyMetadata, error)
+
+// findBlockingPaths returns the blocking dependency paths from the from issue
+// to toID. Each path is a chain of tree nodes that starts at from (depth 0)
+// and ends at toID. Only edges that affect ready work are followed. Unless all
+// is set, the single shortest path is returned; otherwise every simple path of
+// at most maxDepth edges, shortest first and capped at maxDepPaths.
+func findBlockingPaths(from *types.Issue, toID string, maxDepth int, all bool, neighbors depPathNeighborFunc) ([][]*types.TreeNode, error) {
+	root := &types.TreeNode{Issue: *from}
+	if from.ID == toID {
+		return [][]*types.TreeNode{{root}}, nil
+	}
+
+	// Each issue's dependencies are loaded at most once, however many paths
+	// pass through it.
+	cache := make(map[string][]*types.IssueWithDependencyMetadata)
+	next := func(id string) ([]*types.IssueWithDependencyMetadata, error) {
+		if deps, ok := cache[id]; ok {
+			return deps, nil
+		}
+		deps, err := neighbors(id)
+		if err != nil {
+			return nil, err
+		}
+		var blocking []*types.IssueWithDependencyMetadata
+		for _, dep := range deps {
+			if dep.DependencyType.AffectsReadyWork() && !IsExternalRef(dep.ID) {
+				blocking = append(blocking, dep)
+			}
+		}
+		cache[id] = blocking
+		return blocking, nil
+	}
+	step := func(prev *types.TreeNode, dep *types.IssueWithDependencyMetadata) *types.TreeNode {
+		return &types.TreeNode{
+			Issue:          dep.Issue,
+			Depth:          prev.Depth + 1,
+			ParentID:       prev.ID,
+			EdgeFromParent: dep.DependencyType,
+		}
+	}
+
+	if !all {
+		// Breadth-first search: the first time toID is reached is along a
+		// shortest path.
+		prev := map[string]*types.TreeNode{from.ID: root}
+		frontier := []*types.TreeNode{root}
+		for len(frontier) > 0 {
+			var nextFrontier []*types.TreeNode
+			for _, node := range frontier {
+				if node.Depth >= maxDepth {
+					continue
+				}
+				deps, err := next(node.ID)
+				if err != nil {
+					return nil, err
+				}
+				for _, dep := range deps {
+					if _, seen := prev[dep.ID]; seen {
+						continue
+					}
+					child := step(node, dep)
+					prev[dep.ID] = child
+					if dep.ID == toID {
+						path := make([]*types.TreeNode, child.Depth+1)
+						for n := child; ; n = prev[n.ParentID] {
+							path[n.Depth] = n
+							if n.Depth == 0 {
+								break
+							}
+						}
+						return [][]*types.TreeNode{path}, nil
+					}
+					nextFrontier = append(nextFrontier, child)
+				}
+			}
+			frontier = nextFrontier
+		}
+		return nil, nil
+	}
+
+	var paths [][]*types.TreeNode
+	onPath := map[string]bool{from.ID: true}
+	var walk func(path []*types.TreeNode) error
+	walk = func(path []*types.TreeNode) error {
+		last := path[len(path)-1]
+		if last.Depth >= maxDepth || len(paths) >= maxDepPaths {
+			return nil
+		}
+		deps, err := next(last.ID)
+		if err != nil {
+			return err
+		}
+		for _, dep := range deps {
+			if onPath[dep.ID] || len(paths) >= maxDepPaths {
+				continue
+			}
+			extended := append(append([]*types.TreeNode(nil), path...), step(last, dep))
+			if dep.ID == toID {
+				paths = append(paths, extended)
+				continue
+			}
+			onPath[dep.ID] = true
+			if err := walk(extended); err != nil {
+				return err
+			}
+			delete(onPath, dep.ID)
+		}
+		return nil
+	}
+	if err := walk([]*types.TreeNode{root}); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(paths, func(i, j int) bool { return len(paths[i]) < len(paths[j]) })
+	return paths, nil
+}
+
+// outputDepPaths prints the paths found by bd dep path, as JSON or as one
+// arrow-separated chain per path.
+func outputDepPaths(paths [][]*types.TreeNode, fromID, toID string) error {
+	if jsonOutput {
+		if paths == nil {
+			paths = [][]*types.TreeNode{}
+		}
+		return outputJSON(paths)
+	}
+
+	if len(paths) == 0 {
+		fmt.Printf("\n%s does not depend on %s through blocking dependencies\n", fromID, toID)
+		return nil
+	}
+
+	if len(paths) == 1 {
+		fmt.Printf("\n%s Blocking path from %s to %s (%d hops):\n\n", ui.RenderAccent("🔗"), fromID, toID, len(paths[0])-1)
+	} else {
+		fmt.Printf("\n%s %d blocking paths from %s to %s:\n\n", ui.RenderAccent("🔗"), len(paths), fromID, toID)
+	}
+	for i, path := range paths {
+		if len(paths) > 1 {
+			fmt.Printf("%d. (%d hops)\n", i+1, len(path)-1)
+		}
+		for _, node := range path {
+			if node.Depth == 0 {
+				fmt.Printf("  %s\n", formatTreeNode(node, depPathBlocksRoot(path)))
+				continue
+			}
+			fmt.Printf("  %s %s\n", ui.RenderMuted("→"), formatTreeNode(node, false))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// depPathBlocksRoot reports whether the first hop of a path is an open hard
+// blocker, in which case the root is shown as [BLOCKED] rather than [READY].
+func depPathBlocksRoot(path []*types.TreeNode) bool {
+	if len(path) < 2 {
+		return false
+	}
+	first := path[1]
+	return first.EdgeFromParent.IsBlockingEdge() && first.Status != types.StatusClosed
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -155,7 +156,14 @@ func runDepAddProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 		toID = dependsOnArg
 	}
 
-	dt := types.DependencyType(depType)
+	dt, reverse := resolveDepTypeAlias(depType)
+	if reverse {
+		if strings.HasPrefix(toID, "external:") {
+			return HandleErrorRespectJSON("%s needs a local issue on both sides", depType)
+		}
+		fromID, toID = toID, fromID
+	}
+	depType = string(dt)
 	if isDisallowedHierarchicalDependency(fromID, toID, dt) {
 		return HandleErrorRespectJSON("cannot add dependency: %s is already a child of %s. Children inherit dependency on parent completion via hierarchy. Adding an explicit dependency would create a deadlock", fromID, toID)
 	}
@@ -170,25 +178,39 @@ func runDepAddProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 
 	noCycleCheck, _ := cmd.Flags().GetBool("no-cycle-check")
 
-	res, err := uow.RunTxResult(ctx, uowProvider, func(ctx context.Context, uw uow.UnitOfWork) (depAddResult, string, error) {
-		dep := &types.Dependency{IssueID: fromID, DependsOnID: toID, Type: dt}
-		if _, err := uw.DependencyUseCase().AddDependencies(ctx, []*types.Dependency{dep}, actor, domain.BulkAddDepsOpts{}); err != nil {
-			return depAddResult{}, "", err
-		}
+	addEdge := func(edgeType types.DependencyType) (depAddResult, error) {
+		return uow.RunTxResult(ctx, uowProvider, func(ctx context.Context, uw uow.UnitOfWork) (depAddResult, string, error) {
+			dep := &types.Dependency{IssueID: fromID, DependsOnID: toID, Type: edgeType}
+			if _, err := uw.DependencyUseCase().AddDependencies(ctx, []*types.Dependency{dep}, actor, domain.BulkAddDepsOpts{}); err != nil {
+				return depAddResult{}, "", err
+			}
 
-		var cycles [][]*types.Issue
-		var cycleErr error
-		if !noCycleCheck {
-			cycles, cycleErr = uw.DependencyUseCase().DetectCycles(ctx)
-		}
+			var cycles [][]*types.Issue
+			var cycleErr error
+			if !noCycleCheck {
+				cycles, cycleErr = uw.DependencyUseCase().DetectCycles(ctx)
+			}
 
-		return depAddResult{
-			fromTitle: proxiedLookupTitle(ctx, uw, fromID),
-			toTitle:   proxiedLookupTitle(ctx, uw, toID),
-			cycles:    cycles,
-			cycleErr:  cycleErr,
-		}, fmt.Sprintf("bd: dep add %s %s", fromID, toID), nil
-	})
+			return depAddResult{
+				fromTitle: proxiedLookupTitle(ctx, uw, fromID),
+				toTitle:   proxiedLookupTitle(ctx, uw, toID),
+				cycles:    cycles,
+				cycleErr:  cycleErr,
+			}, fmt.Sprintf("bd: dep add %s %s", fromID, toID), nil
+		})
+	}
+
+	var forcedCycle string
+	res, err := addEdge(dt)
+	if err != nil && errors.Is(err, domain.ErrDependencyCycle) {
+		forcedCycle = proxiedDepCyclePath(ctx, fromID, toID)
+		if force, _ := cmd.Flags().GetBool("force"); !force {
+			return HandleErrorRespectJSON("%s", depCycleRejection(forcedCycle))
+		}
+		printForcedRelatesTo(forcedCycle, dt)
+		depType = string(types.DepRelatesTo)
+		res, err = addEdge(types.DepRelatesTo)
+	}
 	if err != nil {
 		return HandleErrorRespectJSON("%v", err)
 	}
@@ -197,12 +219,17 @@ func runDepAddProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 	printCycleWarnings(res.cycles)
 
 	if jsonOutput {
-		_ = outputJSON(map[string]interface{}{
+		result := map[string]interface{}{
 			"status":        "added",
 			"issue_id":      fromID,
 			"depends_on_id": toID,
 			"type":          depType,
-		})
+		}
+		if depType != string(dt) {
+			result["requested_type"] = string(dt)
+			result["cycle"] = forcedCycle
+		}
+		_ = outputJSON(result)
 		return nil
 	}
 
@@ -214,6 +241,20 @@ func runDepAddProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 	return nil
 }
 
+// proxiedDepCyclePath is the proxied-server depCyclePath, read through a
+// fresh unit of work after the rejected add rolled back.
+func proxiedDepCyclePath(ctx context.Context, fromID, toID string) string {
+	uw, err := uowProvider.NewUOW(ctx)
+	if err != nil {
+		return ""
+	}
+	defer uw.Close(ctx)
+	depUC := uw.DependencyUseCase()
+	return depCyclePath(fromID, toID, func(id string) ([]*types.IssueWithDependencyMetadata, error) {
+		return depUC.ListWithIssueMetadata(ctx, id, domain.DepListFilter{Direction: domain.DepDirectionOut})
+	})
+}
+
 func runDepAddBulkProxied(cmd *cobra.Command, ctx context.Context, file, defaultType string) error {
 	edges, err := readBulkDepEdges(file, defaultType)
 	if err != nil {
@@ -605,3 +646,29 @@ func runDepCyclesProxiedServer(_ *cobra.Command, ctx context.Context) error {
 	}
 	return nil
 }
+
+func runDepPathProxiedServer(_ *cobra.Command, ctx context.Context, args []string, maxDepth int, all bool) error {
+	if uowProvider == nil {
+		return HandleErrorRespectJSON("proxied-server UOW provider not initialized")
+	}
+	uw, err := uowProvider.NewUOW(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("open unit of work: %v", err)
+	}
+	defer uw.Close(ctx)
+
+	fromID, toID := args[0], args[1]
+	from, err := uw.IssueUseCase().GetIssue(ctx, fromID)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	depUC := uw.DependencyUseCase()
+	paths, err := findBlockingPaths(from, toID, maxDepth, all, func(id string) ([]*types.IssueWithDependencyMetadata, error) {
+		return depUC.ListWithIssueMetadata(ctx, id, domain.DepListFilter{Direction: domain.DepDirectionOut})
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	return outputDepPaths(paths, fromID, toID)
+}
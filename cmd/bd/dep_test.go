@@ -1456,6 +1456,119 @@ func TestIsChildOf(t *testing.T) {
 	}
 }
 
+func TestResolveDepTypeAlias(t *testing.T) {
+	tests := []struct {
+		name        string
+		want        types.DependencyType
+		wantReverse bool
+	}{
+		{"blocks", types.DepBlocks, false},
+		{"relates_to", types.DepRelatesTo, false},
+		{"caused_by", types.DepCausedBy, false},
+		{"Duplicates", types.DepDuplicates, false},
+		{"duplicate-of", types.DepDuplicates, false},
+		{"child_of", types.DepParentChild, false},
+		{"parent-of", types.DepParentChild, true},
+		{"my_custom", types.DependencyType("my_custom"), false},
+	}
+	for _, tt := range tests {
+		got, reverse := resolveDepTypeAlias(tt.name)
+		if got != tt.want || reverse != tt.wantReverse {
+			t.Errorf("resolveDepTypeAlias(%q) = (%q, %v), want (%q, %v)", tt.name, got, reverse, tt.want, tt.wantReverse)
+		}
+	}
+}
+
+func TestFindBlockingPaths(t *testing.T) {
+	// a -> b -> d, a -> c -> d, a -> d (relates-to only), d -> a (cycle back)
+	edges := map[string][]*types.IssueWithDependencyMetadata{
+		"a": {
+			{Issue: types.Issue{ID: "b"}, DependencyType: types.DepBlocks},
+			{Issue: types.Issue{ID: "c"}, DependencyType: types.DepParentChild},
+			{Issue: types.Issue{ID: "d"}, DependencyType: types.DepRelatesTo},
+		},
+		"b": {{Issue: types.Issue{ID: "d"}, DependencyType: types.DepBlocks}},
+		"c": {
+			{Issue: types.Issue{ID: "e"}, DependencyType: types.DepWaitsFor},
+			{Issue: types.Issue{ID: "external:proj:cap"}, DependencyType: types.DepBlocks},
+		},
+		"e": {{Issue: types.Issue{ID: "d"}, DependencyType: types.DepBlocks}},
+		"d": {{Issue: types.Issue{ID: "a"}, DependencyType: types.DepBlocks}},
+	}
+	calls := 0
+	neighbors := func(id string) ([]*types.IssueWithDependencyMetadata, error) {
+		calls++
+		return edges[id], nil
+	}
+	from := &types.Issue{ID: "a"}
+
+	ids := func(path []*types.TreeNode) string {
+		var parts []string
+		for _, n := range path {
+			parts = append(parts, n.ID)
+		}
+		return strings.Join(parts, ">")
+	}
+
+	paths, err := findBlockingPaths(from, "d", 50, false, neighbors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || ids(paths[0]) != "a>b>d" {
+		t.Fatalf("shortest path = %v, want a>b>d", paths)
+	}
+	if last := paths[0][2]; last.Depth != 2 || last.ParentID != "b" || last.EdgeFromParent != types.DepBlocks {
+		t.Errorf("last node = %+v", last)
+	}
+
+	calls = 0
+	paths, err = findBlockingPaths(from, "d", 50, true, neighbors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range paths {
+		got = append(got, ids(p))
+	}
+	if strings.Join(got, " ") != "a>b>d a>c>e>d" {
+		t.Errorf("all paths = %v, want [a>b>d a>c>e>d]", got)
+	}
+	if calls > len(edges) {
+		t.Errorf("neighbors called %d times, want at most once per issue", calls)
+	}
+
+	if paths, _ := findBlockingPaths(from, "d", 1, true, neighbors); len(paths) != 0 {
+		t.Errorf("--max-depth 1 should find no path, got %d", len(paths))
+	}
+	if paths, _ := findBlockingPaths(from, "a", 50, false, neighbors); len(paths) != 1 || len(paths[0]) != 1 {
+		t.Errorf("path to self = %v, want the single root node", paths)
+	}
+}
+
+func TestDepCyclePath(t *testing.T) {
+	// Adding a -> b: b reaches a through c, but only over scheduling edges;
+	// the waits-for shortcut b -> a is outside the cycle check.
+	edges := map[string][]*types.IssueWithDependencyMetadata{
+		"b": {
+			{Issue: types.Issue{ID: "a"}, DependencyType: types.DepWaitsFor},
+			{Issue: types.Issue{ID: "c"}, DependencyType: types.DepParentChild},
+		},
+		"c": {{Issue: types.Issue{ID: "a"}, DependencyType: types.DepConditionalBlocks}},
+	}
+	neighbors := func(id string) ([]*types.IssueWithDependencyMetadata, error) {
+		return edges[id], nil
+	}
+	if got, want := depCyclePath("a", "b", neighbors), "a → b → c → a"; got != want {
+		t.Errorf("depCyclePath = %q, want %q", got, want)
+	}
+	if got := depCyclePath("a", "x", neighbors); got != "" {
+		t.Errorf("depCyclePath without a cycle = %q, want empty", got)
+	}
+	if msg := depCycleRejection(""); !strings.Contains(msg, "would create a cycle") || !strings.Contains(msg, "--force") {
+		t.Errorf("depCycleRejection = %q", msg)
+	}
+}
+
 // TestDepRoutedTargetOpensReadOnly is the regression guard for the dep/link
 // target-resolution invariant: a cross-rig dependency target is resolved by ID
 // only, so resolveIDWithRouting must open the routed foreign store read-only,
@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/ui"
@@ -24,11 +25,14 @@ const (
 )
 
 type doctorCheck struct {
+	ID       string `json:"id,omitempty"` // stable snake_case ID (see doctorCheckID)
 	Name     string `json:"name"`
-	Status   string `json:"status"` // statusOK, statusWarning, or statusError
+	Status   string `json:"status"`             // statusOK, statusWarning, or statusError
+	Severity string `json:"severity,omitempty"` // blocking, degraded, advisory, or none
 	Message  string `json:"message"`
 	Detail   string `json:"detail,omitempty"` // Additional detail like storage type
 	Fix      string `json:"fix,omitempty"`
+	Fixable  bool   `json:"fixable"`            // whether 'bd doctor --fix' repairs it
 	Category string `json:"category,omitempty"` // category for grouping in output
 }
 
@@ -47,8 +51,12 @@ var (
 	doctorYes                       bool
 	doctorInteractive               bool   // per-fix confirmation mode
 	doctorDryRun                    bool   // preview fixes without applying
+	doctorPlan                      bool   // two-phase fix: consolidated plan, one confirmation
 	doctorOutput                    string // export diagnostics to file
 	doctorFixChildParent            bool   // opt-in fix for child→parent deps
+	doctorPrefer                    string // SQL/CLI remote conflict winner: "sql" or "cli"
+	doctorFailOn                    string // exit-code threshold: "warning" or "error"
+	doctorCheckScripts              bool   // run executable scripts in .beads/checks.d
 	doctorVerbose                   bool   // show detailed output during fixes
 	perfMode                        bool
 	checkHealthMode                 bool
@@ -60,6 +68,9 @@ var (
 	doctorServer                    bool   // run server mode health checks
 	doctorMigration                 string // migration validation mode: "pre" or "post"
 	doctorAgent                     bool   // agent-facing diagnostic mode (ZFC-compliant)
+	doctorWatch                     bool   // re-run checks continuously, printing transitions
+	doctorWatchInterval             time.Duration
+	doctorWatchChecks               []string // check slugs to watch (default: all)
 )
 
 // ConfigKeyHintsDoctor is the config key for suppressing doctor hints
@@ -110,7 +121,17 @@ Specific Check Mode (--check):
     issues, orphaned issues). Advisory only - warns, never blocks.
   - pollution: Detect and optionally clean test issues from database
   - validate: Run focused data-integrity checks (duplicates, orphaned
-    deps, test pollution, git conflicts). Use with --fix to auto-repair.
+    deps, test pollution, git conflicts, JSONL conflict markers). Use
+    with --fix to auto-repair.
+  - <check-id>: Run the full diagnostics and report only that check, e.g.
+    --check=git_hooks or --check=dependency_cycles.
+
+JSON Output (--json):
+  Each check carries a stable snake_case "id" (derived from its name and
+  kept when a check is renamed), "status", "severity" (blocking, degraded,
+  advisory, none), "category", and "fixable" (whether --fix repairs it).
+  Match checks by id rather than by display name. The report includes
+  schema_version, also in files written with --output.
 
 Deep Validation Mode (--deep):
   Validate full graph integrity. May be slow on large databases.
@@ -150,6 +171,51 @@ Agent Mode (--agent):
   ZFC-compliant: Go observes and reports, the agent decides and acts.
   Combine with --json for structured agent-facing output.
 
+Watch Mode (--watch):
+  Re-run the checks every --watch-interval (default 30s), and sooner when
+  files in .beads/ or the git hooks directory change, printing only checks
+  whose status changes between OK, warning, and error. Useful for agents and
+  long sessions to notice a Dolt server exiting or hooks being replaced.
+  Limit the checks with --watch-checks (slugs, as for doctor.suppress).
+  With --json, each transition is printed as one JSON object.
+
+Fix Plan (--plan):
+  With --fix --plan, doctor first collects every proposed fix into one
+  execution plan (automatic fixes in apply order, then manual follow-ups),
+  asks once (or not at all with --yes), and applies the automatic fixes
+  with a per-fix result. Each fix is checkpointed: if one fails, the .beads/
+  files and Dolt commits it changed are rolled back and the fixes after it
+  are not run. --plan --dry-run prints the plan only.
+
+Remote Conflicts (--prefer):
+  When a Dolt remote has one URL in SQL and another in a CLI directory
+  ('dolt remote -v'), --fix asks which to keep, or keeps the side named by
+  --prefer=sql|cli without asking. The losing side is rewritten and the
+  choice is recorded as dolt.remote_prefer, which later fixes reuse.
+
+Exit Codes (--fail-on):
+  By default doctor exits 1 when a check fails. With --fail-on it exits by
+  severity, for CI pipelines and pre-push hooks:
+    0  healthy (warnings too, with --fail-on=error)
+    1  warnings (--fail-on=warning)
+    2  errors
+    3  --fix applied fixes but the verification run still fails
+  Suppressed warnings (doctor.suppress) do not count.
+
+Custom Checks (.beads/checks.d):
+  Repo-specific invariants run as extra checks. Each *.yaml file lists rules
+  whose match selects violating issues (status, priority, type, labels,
+  no_assignee, no_labels, no_parent, empty_description):
+    checks:
+      - name: P0 issues have an assignee
+        severity: error            # default: warning
+        match: {priority: 0, status: [open, in_progress], no_assignee: true}
+        fix: Assign with 'bd update <id> --assignee <name>'
+  Executable files in checks.d run only with --check-scripts, from the repo
+  root with BEADS_DIR set. They report by exit code (0 ok, 1 warning, other
+  error; first output line is the message) or by printing a JSON object
+  with status, message, detail, and fix.
+
 Suppressing Warnings:
   Suppress specific warnings by setting doctor.suppress.<check-slug> config:
     bd config set doctor.suppress.pending-migrations true
@@ -167,11 +233,16 @@ Examples:
   bd doctor --fix        # Automatically fix issues (with confirmation)
   bd doctor --fix --yes  # Automatically fix issues (no confirmation)
   bd doctor --fix -i     # Confirm each fix individually
+  bd doctor --fix --plan # Review the whole fix plan, confirm once, roll back a failed fix
   bd doctor --fix --fix-child-parent  # Also fix child→parent deps (opt-in)
+  bd doctor --fix --prefer=sql  # Resolve remote URL conflicts keeping SQL
   bd doctor --fix --force # Force repair even when database can't be opened
   bd doctor --fix --source=jsonl # Rebuild database from a JSONL export
+  bd doctor --fail-on=warning  # CI gate: exit 1 on warnings, 2 on errors
   bd doctor --dry-run    # Preview what --fix would do without making changes
   bd doctor --perf       # Performance diagnostics
+  bd doctor --watch      # Print check status transitions until Ctrl+C
+  bd doctor --watch --watch-checks dolt-connection,git-hooks --watch-interval 10s
   bd doctor --output diagnostics.json  # Export diagnostics to file
   bd doctor --check=artifacts           # Show classic artifacts (JSONL, SQLite, cruft dirs)
   bd doctor --check=artifacts --clean  # Delete safe-to-delete artifacts (with confirmation)
@@ -217,6 +288,20 @@ Examples:
 			return nil
 		}
 
+		if err := validateDoctorFailOn(doctorFailOn); err != nil {
+			return HandleError("%v", err)
+		}
+		if doctorPrefer != "" && doctorPrefer != fix.RemotePreferSQL && doctorPrefer != fix.RemotePreferCLI {
+			return HandleError("invalid --prefer %q (must be 'sql' or 'cli')", doctorPrefer)
+		}
+
+		if doctorPlan && !doctorFix && !doctorDryRun {
+			return HandleError("--plan requires --fix (or --dry-run to only print the plan)")
+		}
+		if doctorPlan && doctorInteractive {
+			return HandleError("--plan and --interactive are mutually exclusive")
+		}
+
 		if doctorFix && isOrchestratorRoot(absPath) {
 			return HandleErrorWithHint(
 				"refusing to run 'bd doctor --fix' at orchestrator workspace root",
@@ -261,7 +346,7 @@ Examples:
 				}
 				return runValidateCheck(absPath)
 			default:
-				return HandleErrorWithHint(fmt.Sprintf("unknown check %q", doctorCheckFlag), "Available checks: artifacts, conventions, pollution, validate")
+				return runSingleDoctorCheck(absPath, doctorCheckFlag)
 			}
 		}
 
@@ -288,16 +373,30 @@ Examples:
 			return runMigrationValidation(absPath, doctorMigration)
 		}
 
+		if doctorWatch {
+			return runDoctorWatch(absPath, doctorWatchInterval, doctorWatchChecks)
+		}
+
 		result := runDiagnostics(absPath)
 
+		fixesApplied := 0
 		if doctorDryRun {
-			previewFixes(result)
+			if doctorPlan {
+				printDoctorFixPlan(result)
+			} else {
+				previewFixes(result)
+			}
 		} else if doctorFix {
-			applyFixes(result)
+			if doctorPlan {
+				fixesApplied = applyFixesPlanned(result)
+			} else {
+				fixesApplied = applyFixes(result)
+			}
 			fmt.Println("\nVerifying fixes...")
 			result = runDiagnostics(absPath)
 		}
 
+		annotateDoctorChecks(result.Checks)
 		if doctorOutput != "" || jsonOutput {
 			result.Timestamp = time.Now().UTC().Format(time.RFC3339)
 			result.Platform = doctor.CollectPlatformInfo(absPath)
@@ -327,6 +426,12 @@ Examples:
 			printDiagnostics(result)
 		}
 
+		if doctorFailOn != "" {
+			if code := doctorExitCode(result.Checks, doctorFailOn, fixesApplied); code != doctorExitHealthy {
+				return &exitError{Code: code}
+			}
+			return nil
+		}
 		if !result.OverallOK {
 			return SilentExit()
 		}
@@ -339,17 +444,24 @@ func init() {
 	doctorCmd.Flags().BoolVarP(&doctorYes, "yes", "y", false, "Skip confirmation prompt (for non-interactive use)")
 	doctorCmd.Flags().BoolVarP(&doctorInteractive, "interactive", "i", false, "Confirm each fix individually")
 	doctorCmd.Flags().BoolVar(&doctorDryRun, "dry-run", false, "Preview fixes without making changes")
+	doctorCmd.Flags().BoolVar(&doctorPlan, "plan", false, "With --fix, show the consolidated fix plan, confirm once, and roll back a failing fix")
 	doctorCmd.Flags().BoolVar(&doctorFixChildParent, "fix-child-parent", false, "Remove child→parent dependencies (opt-in)")
+	doctorCmd.Flags().BoolVar(&doctorCheckScripts, "check-scripts", false, "Also run executable custom check scripts in .beads/checks.d")
+	doctorCmd.Flags().StringVar(&doctorFailOn, "fail-on", "", "Exit non-zero by severity for CI: 'warning' or 'error' (1 warnings, 2 errors, 3 fix did not verify)")
+	doctorCmd.Flags().StringVar(&doctorPrefer, "prefer", "", "Resolve SQL/CLI Dolt remote URL conflicts: 'sql' or 'cli'")
 	doctorCmd.Flags().BoolVarP(&doctorVerbose, "verbose", "v", false, "Show all checks (default shows only warnings/errors)")
 	doctorCmd.Flags().BoolVar(&doctorOrchestrator, "orchestrator", false, "Running in orchestrator multi-workspace mode (routes.jsonl is expected, higher duplicate tolerance)")
 	doctorCmd.Flags().IntVar(&orchestratorDuplicatesThreshold, "orchestrator-duplicates-threshold", 1000, "Duplicate tolerance threshold for orchestrator mode (wisps are ephemeral)")
 	doctorCmd.Flags().BoolVar(&doctorServer, "server", false, "Run Dolt server mode health checks (connectivity, version, schema)")
 	doctorCmd.Flags().StringVar(&doctorMigration, "migration", "", "Run legacy Dolt-server migration diagnostics: 'pre' or 'post'")
 	doctorCmd.Flags().BoolVar(&doctorAgent, "agent", false, "Agent-facing diagnostic mode: rich context for AI agents (ZFC-compliant)")
+	doctorCmd.Flags().BoolVar(&doctorWatch, "watch", false, "Re-run checks continuously and print status transitions")
+	doctorCmd.Flags().DurationVar(&doctorWatchInterval, "watch-interval", 30*time.Second, "Interval between --watch runs")
+	doctorCmd.Flags().StringSliceVar(&doctorWatchChecks, "watch-checks", nil, "Check slugs to watch (comma-separated; default all)")
 }
 
 func shouldSkipDoctorNetworkChecks() bool {
-	return jsonOutput || !ui.IsTerminal()
+	return jsonOutput || doctorWatch || !ui.IsTerminal()
 }
 
 // validateDoctorWorkspaceBackend keeps doctor diagnostics read-only when metadata
@@ -444,6 +556,11 @@ func runDiagnostics(path string) doctorResult {
 	legacyCheck := convertWithCategory(doctor.CheckStaleLegacyHooks(), doctor.CategoryGit)
 	result.Checks = append(result.Checks, legacyCheck)
 
+	// Check for bd hooks bypassed via core.hooksPath, a missing execute bit,
+	// or commits made with --no-verify
+	hookBypassCheck := convertWithCategory(doctor.CheckGitHookBypass(path), doctor.CategoryGit)
+	result.Checks = append(result.Checks, hookBypassCheck)
+
 	// Check git hooks Dolt compatibility (hooks without Dolt check cause errors)
 	doltHooksCheck := convertWithCategory(doctor.CheckGitHooksDoltCompatibility(path), doctor.CategoryGit)
 	result.Checks = append(result.Checks, doltHooksCheck)
@@ -672,6 +789,10 @@ func runDiagnostics(path string) doctorResult {
 	doltModeCheck := convertWithCategory(doctor.CheckDoltServerModeMismatch(path), doctor.CategoryFederation)
 	result.Checks = append(result.Checks, doltModeCheck)
 
+	// Check 8i: Dolt binary/server versions against known incompatibilities
+	doltCompatCheck := convertDoctorCheck(doctor.CheckDoltCompatibility(path))
+	result.Checks = append(result.Checks, doltCompatCheck)
+
 	// Check 9: Permissions
 	permCheck := convertWithCategory(doctor.CheckPermissionsWithStore(path, sharedStore), doctor.CategoryCore)
 	result.Checks = append(result.Checks, permCheck)
@@ -686,6 +807,19 @@ func runDiagnostics(path string) doctorResult {
 		result.OverallOK = false
 	}
 
+	// Check 10a: Dependency graph integrity — dangling targets, self
+	// dependencies, and cycles among blocking edges
+	depGraphCheck := convertWithCategory(doctor.CheckDependencyGraphWithStore(sharedStore), doctor.CategoryMetadata)
+	result.Checks = append(result.Checks, depGraphCheck)
+	if depGraphCheck.Status == statusError {
+		result.OverallOK = false
+	}
+
+	// Check 10a1: Wisp side-table rows whose wisp expired or was deleted
+	wispOrphanCheck := convertWithCategory(doctor.CheckOrphanedWispsWithStore(sharedStore), doctor.CategoryMaintenance)
+	result.Checks = append(result.Checks, wispOrphanCheck)
+	// Don't fail overall check for orphaned wisp rows, just warn
+
 	// Check 10b: Rekey-backfill leftovers — randomly-keyed or targetless
 	// dependency rows that survive the #4259 migration backfill (bd-6dnrw.17).
 	depKeyCheck := convertWithCategory(doctor.CheckDependencyKeysWithStore(sharedStore), doctor.CategoryMetadata)
@@ -850,6 +984,18 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, untrackedCheck)
 	// Don't fail overall check for untracked files, just warn
 
+	// Check 20a: Conflict markers left in .beads/*.jsonl by a git merge
+	jsonlConflictsCheck := convertWithCategory(doctor.CheckJSONLConflicts(path), doctor.CategoryData)
+	result.Checks = append(result.Checks, jsonlConflictsCheck)
+	if jsonlConflictsCheck.Status == statusError {
+		result.OverallOK = false
+	}
+
+	// Check 20b: JSONL export out of step with the database
+	exportFreshnessCheck := convertWithCategory(doctor.CheckExportFreshness(path, sharedStore), doctor.CategoryData)
+	result.Checks = append(result.Checks, exportFreshnessCheck)
+	// Don't fail overall check for a stale export, just warn
+
 	// Check 21: Orphaned dependencies (from bd repair-deps, bd validate)
 	orphanedDepsCheck := convertDoctorCheck(doctor.CheckOrphanedDependencies(path))
 	result.Checks = append(result.Checks, orphanedDepsCheck)
@@ -870,6 +1016,13 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, pollutionCheck)
 	// Don't fail overall check for test pollution, just warn
 
+	// Check 25: Clock skew (future or out-of-order issue timestamps). Appended
+	// before the stale-issue check so its fix runs first and stale detection
+	// sees corrected dates.
+	timestampCheck := convertDoctorCheck(doctor.CheckTimestampSanity(path))
+	result.Checks = append(result.Checks, timestampCheck)
+	// Don't fail overall check for skewed timestamps, just warn
+
 	// Check 26: Stale closed issues (maintenance)
 	staleClosedCheck := convertDoctorCheck(doctor.CheckStaleClosedIssues(path))
 	result.Checks = append(result.Checks, staleClosedCheck)
@@ -901,6 +1054,11 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, sizeCheck)
 	// Don't fail overall check for size warning, just inform
 
+	// Check 29a: Performance profile (query latency, indexes, events size)
+	perfProfileCheck := convertDoctorCheck(doctor.CheckPerformanceProfile(path))
+	result.Checks = append(result.Checks, perfProfileCheck)
+	// Don't fail overall check for slow queries, just warn
+
 	// Check 30: Pending migrations (summarizes all available migrations)
 	migrationsCheck := convertDoctorCheck(doctor.CheckPendingMigrations(path))
 	result.Checks = append(result.Checks, migrationsCheck)
@@ -932,6 +1090,15 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, btrfsNoCowCheck)
 	// Don't fail overall check for btrfs NoCOW, just warn
 
+	// Repo-specific checks from .beads/checks.d (YAML rules; scripts opt-in)
+	for _, dc := range doctor.RunCustomChecks(path, sharedStore, doctorCheckScripts) {
+		customCheck := convertDoctorCheck(dc)
+		result.Checks = append(result.Checks, customCheck)
+		if customCheck.Status == statusError {
+			result.OverallOK = false
+		}
+	}
+
 	// GH#1095: Filter out suppressed checks (doctor.suppress.<slug> = true)
 	suppressed := doctor.GetSuppressedChecksWithStore(sharedStore)
 	if len(suppressed) > 0 {
@@ -1070,7 +1237,7 @@ func exportDiagnostics(result doctorResult, outputPath string) error {
 
 	encoder := json.NewEncoder(f)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(result); err != nil {
+	if err := encoder.Encode(wrapWithSchemaVersion(result)); err != nil {
 		return fmt.Errorf("failed to write JSON: %w", err)
 	}
 
@@ -0,0 +1,306 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CustomChecksDir is the directory under .beads/ holding repo-specific doctor
+// checks: declarative YAML rules and executable scripts.
+const CustomChecksDir = "checks.d"
+
+// customScriptTimeout bounds each check script so a hung script cannot stall
+// bd doctor.
+const customScriptTimeout = 30 * time.Second
+
+// customRuleFile is a YAML file of declarative rules in checks.d.
+type customRuleFile struct {
+	Checks []customRule `yaml:"checks"`
+}
+
+// customRule flags the issues matching Match as violations. Match fields
+// combine with AND; an issue that matches every set field violates the rule.
+type customRule struct {
+	Name     string          `yaml:"name"`
+	Severity string          `yaml:"severity"` // warning (default) or error
+	Fix      string          `yaml:"fix"`
+	Match    customRuleMatch `yaml:"match"`
+}
+
+type customRuleMatch struct {
+	Status           customStringList `yaml:"status"`
+	Priority         *int             `yaml:"priority"`
+	Type             string           `yaml:"type"`
+	Labels           []string         `yaml:"labels"`
+	NoAssignee       bool             `yaml:"no_assignee"`
+	NoLabels         bool             `yaml:"no_labels"`
+	NoParent         bool             `yaml:"no_parent"`
+	EmptyDescription bool             `yaml:"empty_description"`
+}
+
+// customStringList accepts either a single string or a list in YAML.
+type customStringList []string
+
+func (l *customStringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = []string{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// filter translates the match into an issue search filter.
+func (m customRuleMatch) filter() types.IssueFilter {
+	var f types.IssueFilter
+	for _, s := range m.Status {
+		f.Statuses = append(f.Statuses, types.Status(s))
+	}
+	f.Priority = m.Priority
+	if m.Type != "" {
+		t := types.IssueType(m.Type)
+		f.IssueType = &t
+	}
+	f.Labels = m.Labels
+	f.NoAssignee = m.NoAssignee
+	f.NoLabels = m.NoLabels
+	f.NoParent = m.NoParent
+	f.EmptyDescription = m.EmptyDescription
+	return f
+}
+
+// RunCustomChecks runs the checks in .beads/checks.d: every *.yaml/*.yml file
+// of declarative rules, evaluated against the database, and, when runScripts
+// is set, every executable file. Scripts are opt-in because checks.d is
+// committed with the repository; running them on a plain 'bd doctor' would
+// execute code from any clone.
+func RunCustomChecks(path string, ss *SharedStore, runScripts bool) []DoctorCheck {
+	beadsDir := beadsDirFromSharedStore(path, ss)
+	dir := filepath.Join(beadsDir, CustomChecksDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var checks []DoctorCheck
+	var skippedScripts []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		file := filepath.Join(dir, e.Name())
+		switch ext := filepath.Ext(e.Name()); {
+		case ext == ".yaml" || ext == ".yml":
+			checks = append(checks, runCustomRuleFile(file, ss)...)
+		case isExecutableFile(file):
+			if !runScripts {
+				skippedScripts = append(skippedScripts, e.Name())
+				continue
+			}
+			checks = append(checks, runCustomScript(file, filepath.Dir(beadsDir), beadsDir))
+		}
+	}
+
+	if len(skippedScripts) > 0 {
+		checks = append(checks, DoctorCheck{
+			Name:     "Custom Check Scripts",
+			Status:   StatusOK,
+			Message:  fmt.Sprintf("%d script(s) in .beads/%s not run", len(skippedScripts), CustomChecksDir),
+			Detail:   strings.Join(skippedScripts, ", "),
+			Fix:      "Review the scripts, then run 'bd doctor --check-scripts'",
+			Category: CategoryCustom,
+		})
+	}
+	return checks
+}
+
+// runCustomRuleFile evaluates one YAML rule file, returning a check per rule.
+func runCustomRuleFile(file string, ss *SharedStore) []DoctorCheck {
+	base := filepath.Base(file)
+	// #nosec G304 -- file is inside the .beads/checks.d directory
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return []DoctorCheck{customCheckError(base, "Cannot read rule file", err.Error())}
+	}
+	var rules customRuleFile
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return []DoctorCheck{customCheckError(base, "Invalid rule file", err.Error())}
+	}
+
+	store := ss.Store()
+	var checks []DoctorCheck
+	for i, rule := range rules.Checks {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("%s #%d", base, i+1)
+		}
+		if store == nil {
+			checks = append(checks, DoctorCheck{
+				Name:     "Custom: " + name,
+				Status:   StatusOK,
+				Message:  "N/A (database unavailable)",
+				Category: CategoryCustom,
+			})
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), customScriptTimeout)
+		issues, err := store.SearchIssues(ctx, "", rule.Match.filter())
+		cancel()
+		if err != nil {
+			checks = append(checks, customCheckError(name, "Rule query failed", err.Error()))
+			continue
+		}
+		checks = append(checks, customRuleResult(name, rule, issues))
+	}
+	return checks
+}
+
+// customRuleResult turns the issues matching a rule into its check.
+func customRuleResult(name string, rule customRule, violations []*types.Issue) DoctorCheck {
+	check := DoctorCheck{Name: "Custom: " + name, Category: CategoryCustom}
+	if len(violations) == 0 {
+		check.Status = StatusOK
+		check.Message = "No violations"
+		return check
+	}
+	check.Status = StatusWarning
+	if rule.Severity == StatusError {
+		check.Status = StatusError
+	}
+	check.Message = fmt.Sprintf("%d issue(s) violate this rule", len(violations))
+	ids := make([]string, 0, len(violations))
+	for _, issue := range violations {
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+	if len(ids) > 10 {
+		ids = append(ids[:10], fmt.Sprintf("... and %d more", len(violations)-10))
+	}
+	check.Detail = strings.Join(ids, ", ")
+	check.Fix = rule.Fix
+	return check
+}
+
+// customScriptResult is the optional JSON a check script may print.
+type customScriptResult struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+	Fix     string `json:"fix"`
+}
+
+// runCustomScript runs one check script from the repository root with
+// BEADS_DIR set. A script reports by printing a JSON object (status, message,
+// detail, fix) or by exit code: 0 ok, 1 warning, anything else error, with
+// the first output line as the message and the rest as detail.
+func runCustomScript(file, repoRoot, beadsDir string) DoctorCheck {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	ctx, cancel := context.WithTimeout(context.Background(), customScriptTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, file) // #nosec G204 -- opted in via --check-scripts
+	cmd.Dir = repoRoot
+	cmd.Env = append(os.Environ(), "BEADS_DIR="+beadsDir, "BD_DOCTOR_CHECK=1")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return customCheckError(name, fmt.Sprintf("Timed out after %s", customScriptTimeout), "")
+	}
+
+	exitCode := 0
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return customCheckError(name, "Failed to run script", err.Error())
+		}
+		exitCode = exitErr.ExitCode()
+	}
+	return parseCustomScriptOutput(name, stdout.String(), stderr.String(), exitCode)
+}
+
+// parseCustomScriptOutput builds a check from a script's output and exit code.
+func parseCustomScriptOutput(name, stdout, stderr string, exitCode int) DoctorCheck {
+	check := DoctorCheck{Name: "Custom: " + name, Category: CategoryCustom}
+	out := strings.TrimSpace(stdout)
+
+	var result customScriptResult
+	if strings.HasPrefix(out, "{") && json.Unmarshal([]byte(out), &result) == nil {
+		switch result.Status {
+		case StatusOK, StatusWarning, StatusError:
+			check.Status = result.Status
+		default:
+			check.Status = StatusError
+			check.Detail = fmt.Sprintf("script reported unknown status %q", result.Status)
+		}
+		check.Message = result.Message
+		if result.Detail != "" {
+			check.Detail = result.Detail
+		}
+		check.Fix = result.Fix
+		return check
+	}
+
+	switch exitCode {
+	case 0:
+		check.Status = StatusOK
+	case 1:
+		check.Status = StatusWarning
+	default:
+		check.Status = StatusError
+	}
+	if out == "" {
+		out = strings.TrimSpace(stderr)
+	}
+	message, detail, _ := strings.Cut(out, "\n")
+	check.Message = message
+	check.Detail = strings.TrimSpace(detail)
+	if check.Message == "" {
+		check.Message = fmt.Sprintf("exit code %d", exitCode)
+	}
+	return check
+}
+
+func customCheckError(name, message, detail string) DoctorCheck {
+	return DoctorCheck{
+		Name:     "Custom: " + name,
+		Status:   StatusError,
+		Message:  message,
+		Detail:   detail,
+		Category: CategoryCustom,
+	}
+}
+
+// isExecutableFile reports whether file is a regular file with an execute
+// bit. On Windows, where there is none, .exe, .bat, .cmd, and .ps1 count.
+func isExecutableFile(file string) bool {
+	info, err := os.Stat(file)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".exe", ".bat", ".cmd", ".ps1":
+			return true
+		}
+		return false
+	}
+	return info.Mode()&0o111 != 0
+}
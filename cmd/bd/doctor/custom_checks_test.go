@@ -0,0 +1,120 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCustomRuleMatchFilter(t *testing.T) {
+	var rules customRuleFile
+	src := `
+checks:
+  - name: P0 has assignee
+    severity: error
+    match:
+      priority: 0
+      status: [open, in_progress]
+      no_assignee: true
+  - name: tasks in epics
+    match:
+      type: task
+      status: open
+      no_parent: true
+`
+	if err := yaml.Unmarshal([]byte(src), &rules); err != nil {
+		t.Fatal(err)
+	}
+	if len(rules.Checks) != 2 {
+		t.Fatalf("parsed %d rules, want 2", len(rules.Checks))
+	}
+
+	f := rules.Checks[0].Match.filter()
+	if f.Priority == nil || *f.Priority != 0 || !f.NoAssignee || len(f.Statuses) != 2 {
+		t.Errorf("rule 1 filter = %+v", f)
+	}
+	f = rules.Checks[1].Match.filter()
+	if f.IssueType == nil || *f.IssueType != types.IssueType("task") || !f.NoParent ||
+		len(f.Statuses) != 1 || f.Statuses[0] != types.Status("open") || f.Priority != nil {
+		t.Errorf("rule 2 filter = %+v", f)
+	}
+}
+
+func TestCustomRuleResult(t *testing.T) {
+	rule := customRule{Severity: "error", Fix: "assign it"}
+	if c := customRuleResult("r", rule, nil); c.Status != StatusOK {
+		t.Errorf("no violations: status %s", c.Status)
+	}
+	var issues []*types.Issue
+	for _, id := range []string{"bd-c", "bd-a", "bd-b"} {
+		issues = append(issues, &types.Issue{ID: id})
+	}
+	c := customRuleResult("r", rule, issues)
+	if c.Status != StatusError || c.Detail != "bd-a, bd-b, bd-c" || c.Fix != "assign it" || c.Name != "Custom: r" {
+		t.Errorf("violations: %+v", c)
+	}
+	if c := customRuleResult("r", customRule{}, issues); c.Status != StatusWarning {
+		t.Errorf("default severity: status %s, want warning", c.Status)
+	}
+}
+
+func TestParseCustomScriptOutput(t *testing.T) {
+	tests := []struct {
+		name, stdout, stderr string
+		exit                 int
+		wantStatus, wantMsg  string
+	}{
+		{"ok", "all good\n", "", 0, StatusOK, "all good"},
+		{"warning with detail", "2 stale\nbd-1\nbd-2\n", "", 1, StatusWarning, "2 stale"},
+		{"error from stderr", "", "boom\n", 3, StatusError, "boom"},
+		{"silent failure", "", "", 2, StatusError, "exit code 2"},
+		{"json overrides exit code", `{"status":"warning","message":"from json","fix":"do it"}`, "", 0, StatusWarning, "from json"},
+		{"json unknown status", `{"status":"meh","message":"x"}`, "", 0, StatusError, "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := parseCustomScriptOutput("s", tt.stdout, tt.stderr, tt.exit)
+			if c.Status != tt.wantStatus || c.Message != tt.wantMsg {
+				t.Errorf("got %s %q, want %s %q", c.Status, c.Message, tt.wantStatus, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRunCustomChecksScripts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+	repo := t.TempDir()
+	checksDir := filepath.Join(repo, ".beads", CustomChecksDir)
+	if err := os.MkdirAll(checksDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\necho \"beads at $BEADS_DIR\"\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(checksDir, "stale.sh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(checksDir, "README.md"), []byte("not a check"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := RunCustomChecks(repo, nil, false)
+	if len(checks) != 1 || checks[0].Name != "Custom Check Scripts" || checks[0].Detail != "stale.sh" {
+		t.Fatalf("without --check-scripts: %+v", checks)
+	}
+
+	checks = RunCustomChecks(repo, nil, true)
+	if len(checks) != 1 {
+		t.Fatalf("with --check-scripts: %+v", checks)
+	}
+	c := checks[0]
+	if c.Name != "Custom: stale" || c.Status != StatusWarning || !strings.HasSuffix(c.Message, filepath.Join(".beads")) {
+		t.Errorf("script check = %+v", c)
+	}
+}
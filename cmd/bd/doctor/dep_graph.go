@@ -0,0 +1,82 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+)
+
+// CheckDependencyGraphWithStore scans the dependency graph for edges that
+// point at issues that do not exist, issues that depend on themselves, and
+// cycles among blocking edges. Blocking cycles are an error: every issue in
+// one can never become ready.
+func CheckDependencyGraphWithStore(ss *SharedStore) DoctorCheck {
+	store := ss.Store()
+	if store == nil {
+		return DoctorCheck{
+			Name:    "Dependency Graph",
+			Status:  StatusOK,
+			Message: "N/A (no database)",
+		}
+	}
+
+	report, err := fix.ScanDependencyGraph(context.Background(), store.UnderlyingDB())
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Dependency Graph",
+			Status:  StatusWarning,
+			Message: "Unable to scan dependency graph",
+			Detail:  err.Error(),
+		}
+	}
+	if report.Empty() {
+		return DoctorCheck{
+			Name:    "Dependency Graph",
+			Status:  StatusOK,
+			Message: "No dangling, self, or cyclic blocking dependencies",
+		}
+	}
+
+	var parts, details []string
+	if n := len(report.Dangling); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d dangling", n))
+		details = append(details, "dangling: "+joinDepGraphEdges(report.Dangling))
+	}
+	if n := len(report.SelfLoops); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d self-dependenc(ies)", n))
+		details = append(details, "self: "+joinDepGraphEdges(report.SelfLoops))
+	}
+	if n := len(report.Cycles); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d blocking cycle(s)", n))
+		for _, cycle := range report.Cycles {
+			details = append(details, "cycle: "+strings.Join(cycle, ", "))
+		}
+	}
+	detail := strings.Join(details, "; ")
+	if len(detail) > 300 {
+		detail = detail[:300] + "..."
+	}
+
+	check := DoctorCheck{
+		Name:    "Dependency Graph",
+		Status:  StatusWarning,
+		Message: "Dependency graph has " + strings.Join(parts, ", "),
+		Detail:  detail,
+		Fix:     "Run 'bd doctor --fix' to remove dangling and self dependencies",
+	}
+	if len(report.Cycles) > 0 {
+		check.Status = StatusError
+		check.Fix += "; break cycles with 'bd dep remove' (see 'bd dep cycles')"
+	}
+	return check
+}
+
+func joinDepGraphEdges(edges []fix.DepGraphEdge) string {
+	parts := make([]string, len(edges))
+	for i, e := range edges {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,219 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/doltserver"
+)
+
+// doltCompatRule is one known incompatibility between bd and a range of Dolt
+// releases. Since is inclusive and Before exclusive; an empty bound is open.
+type doltCompatRule struct {
+	Since    string
+	Before   string
+	Binary   bool // applies to the local dolt binary
+	Server   bool // applies to the running sql-server
+	Remote   bool // only matters when the server is not on this machine
+	Problem  string
+	Guidance string
+}
+
+// doltCompatRules is the compatibility table checked by CheckDoltCompatibility.
+// Add a row when a Dolt release is found to break (or stop supporting) part of
+// how bd runs or talks to the server.
+var doltCompatRules = []doltCompatRule{
+	{
+		Before:   doltserver.MinDoltVersionForArchiveLevelConfig,
+		Binary:   true,
+		Problem:  "the managed server cannot disable archive compression, so background GC may write zstd archives (gastownhall/beads#4986)",
+		Guidance: "Upgrade dolt to " + doltserver.MinDoltVersionForArchiveLevelConfig + " or later: https://docs.dolthub.com/introduction/installation",
+	},
+	{
+		Since:    "1.44.0",
+		Server:   true,
+		Remote:   true,
+		Problem:  "the default root user exists only as root@localhost, so clients connecting over TCP (e.g. through a Docker port mapping) authenticate as root@% and are denied",
+		Guidance: "On the server run CREATE USER 'root'@'%' and GRANT ALL ON *.* TO 'root'@'%', configure a dedicated user for bd, or pin the server image below 1.44",
+	},
+}
+
+// parseDoltVersion extracts the version from `dolt version` output ("dolt
+// version 1.52.3", possibly followed by more lines) or a bare dolt_version()
+// result, as numeric segments.
+func parseDoltVersion(s string) ([]int, bool) {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, false
+	}
+	token := strings.TrimPrefix(fields[len(fields)-1], "v")
+	var segs []int
+	for _, part := range strings.Split(token, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		segs = append(segs, n)
+	}
+	return segs, true
+}
+
+// compareDoltVersions compares two parsed versions, treating missing trailing
+// segments as zero.
+func compareDoltVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// matches reports whether version falls inside the rule's range.
+func (r doltCompatRule) matches(version []int) bool {
+	if r.Since != "" {
+		since, _ := parseDoltVersion(r.Since)
+		if compareDoltVersions(version, since) < 0 {
+			return false
+		}
+	}
+	if r.Before != "" {
+		before, _ := parseDoltVersion(r.Before)
+		if compareDoltVersions(version, before) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluateDoltCompat checks the binary and server versions (either may be
+// empty when unavailable) against doltCompatRules. remote is true when the
+// server runs on another host, where a version mismatch with the local binary
+// is expected.
+func evaluateDoltCompat(binaryVersion, serverVersion string, remote bool) DoctorCheck {
+	check := DoctorCheck{Name: "Dolt Compatibility", Category: CategoryDolt}
+	binary, hasBinary := parseDoltVersion(binaryVersion)
+	server, hasServer := parseDoltVersion(serverVersion)
+	if !hasBinary && !hasServer {
+		check.Status = StatusOK
+		check.Message = "N/A (dolt version unavailable)"
+		return check
+	}
+
+	var versions []string
+	if hasBinary {
+		versions = append(versions, "binary "+formatDoltVersion(binary))
+	}
+	if hasServer {
+		versions = append(versions, "server "+formatDoltVersion(server))
+	}
+
+	var problems, fixes []string
+	for _, rule := range doltCompatRules {
+		if rule.Remote && !remote {
+			continue
+		}
+		var hit []string
+		if rule.Binary && hasBinary && rule.matches(binary) {
+			hit = append(hit, "dolt binary "+formatDoltVersion(binary))
+		}
+		if rule.Server && hasServer && rule.matches(server) {
+			hit = append(hit, "dolt server "+formatDoltVersion(server))
+		}
+		if len(hit) == 0 {
+			continue
+		}
+		problems = append(problems, strings.Join(hit, " and ")+": "+rule.Problem)
+		fixes = append(fixes, rule.Guidance)
+	}
+
+	// A managed local server is started from the local binary; a different
+	// minor version means it predates an upgrade (or downgrade) of dolt.
+	if hasBinary && hasServer && !remote && compareDoltVersions(binary[:min(2, len(binary))], server[:min(2, len(server))]) != 0 {
+		problems = append(problems, fmt.Sprintf("local server runs dolt %s but the installed binary is %s", formatDoltVersion(server), formatDoltVersion(binary)))
+		fixes = append(fixes, "Restart the server so it uses the installed binary: 'bd dolt stop' (the next bd command restarts it)")
+	}
+
+	if len(problems) == 0 {
+		check.Status = StatusOK
+		check.Message = strings.Join(versions, ", ")
+		return check
+	}
+	check.Status = StatusWarning
+	check.Message = fmt.Sprintf("%d known Dolt compatibility issue(s) (%s)", len(problems), strings.Join(versions, ", "))
+	check.Detail = strings.Join(problems, "\n")
+	check.Fix = strings.Join(fixes, "\n")
+	return check
+}
+
+func formatDoltVersion(v []int) string {
+	parts := make([]string, len(v))
+	for i, n := range v {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+// CheckDoltCompatibility reports the installed dolt binary and running server
+// versions and warns about releases with known incompatibilities.
+func CheckDoltCompatibility(path string) DoctorCheck {
+	backend, beadsDir := getBackendAndBeadsDir(path)
+	if backend != configfile.BackendDolt {
+		return DoctorCheck{
+			Name:     "Dolt Compatibility",
+			Status:   StatusOK,
+			Message:  "N/A (non-Dolt backend)",
+			Category: CategoryDolt,
+		}
+	}
+
+	var binaryVersion string
+	if doltBin, err := exec.LookPath("dolt"); err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		out, err := exec.CommandContext(ctx, doltBin, "version").Output() //nolint:gosec // G204: doltBin is a PATH lookup result
+		cancel()
+		if err == nil {
+			binaryVersion = string(out)
+		}
+	}
+
+	var serverVersion string
+	remote := false
+	if _, err := os.Stat(beadsDir); err == nil {
+		if db, cfg, err := openDoltDB(beadsDir); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			_ = db.QueryRowContext(ctx, "SELECT dolt_version()").Scan(&serverVersion)
+			cancel()
+			_ = db.Close()
+			remote = !isLoopbackHost(cfg.GetDoltServerHost())
+		}
+	}
+
+	return evaluateDoltCompat(binaryVersion, serverVersion, remote)
+}
+
+// isLoopbackHost reports whether host refers to this machine.
+func isLoopbackHost(host string) bool {
+	switch strings.ToLower(strings.TrimSpace(host)) {
+	case "", "localhost", "127.0.0.1", "::1", "0.0.0.0":
+		return true
+	}
+	return false
+}
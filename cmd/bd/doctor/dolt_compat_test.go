@@ -0,0 +1,61 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDoltVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"dolt version 1.52.3\n", "1.52.3", true},
+		{"dolt version 1.43.0\ndatabase storage format: NEW_FORMAT\n", "1.43.0", true},
+		{"1.81.10", "1.81.10", true},
+		{"v1.44", "1.44", true},
+		{"", "", false},
+		{"dolt version unknown", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := parseDoltVersion(tt.in)
+		if ok != tt.ok || (ok && formatDoltVersion(got) != tt.want) {
+			t.Errorf("parseDoltVersion(%q) = %v, %v; want %s, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestEvaluateDoltCompat(t *testing.T) {
+	tests := []struct {
+		name       string
+		binary     string
+		server     string
+		remote     bool
+		wantStatus string
+		wantDetail string
+	}{
+		{"nothing known", "", "", false, StatusOK, ""},
+		{"current binary only", "dolt version 1.60.0", "", false, StatusOK, ""},
+		{"old binary", "dolt version 1.50.2", "", false, StatusWarning, "archive compression"},
+		{"matching local server", "dolt version 1.60.0", "1.60.1", false, StatusOK, ""},
+		{"stale local server", "dolt version 1.60.0", "1.58.4", false, StatusWarning, "local server runs dolt 1.58.4"},
+		{"remote server version differs", "dolt version 1.60.0", "1.43.0", true, StatusOK, ""},
+		{"remote server with root@% auth", "dolt version 1.60.0", "1.44.2", true, StatusWarning, "root@localhost"},
+		{"local server ignores remote-only rule", "dolt version 1.60.0", "1.60.0", false, StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := evaluateDoltCompat(tt.binary, tt.server, tt.remote)
+			if check.Status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s (message %q, detail %q)", check.Status, tt.wantStatus, check.Message, check.Detail)
+			}
+			if tt.wantDetail != "" && !strings.Contains(check.Detail, tt.wantDetail) {
+				t.Errorf("detail %q does not mention %q", check.Detail, tt.wantDetail)
+			}
+			if check.Status == StatusWarning && check.Fix == "" {
+				t.Error("warning has no fix guidance")
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package doctor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// Export freshness states reported by ClassifyExportFreshness.
+const (
+	ExportFresh       = "fresh"
+	ExportStaleExport = "stale_export" // database changed since the last export
+	ExportStaleImport = "stale_import" // export file changed since bd wrote it
+	ExportDiverged    = "diverged"     // both changed
+	ExportUnknown     = "unknown"      // nothing to compare against
+)
+
+// exportStateFile mirrors the state bd export / auto-export records in
+// .beads/export-state.json; only the fields the check compares are decoded.
+type exportStateFile struct {
+	LastDoltCommit string `json:"last_dolt_commit"`
+	JSONLHash      string `json:"jsonl_hash"`
+}
+
+// exportFilePath returns the configured export file in beadsDir.
+func exportFilePath(beadsDir string) string {
+	exportPath := config.GetString("export.path")
+	if exportPath == "" {
+		exportPath = "issues.jsonl"
+	}
+	return filepath.Join(beadsDir, exportPath)
+}
+
+// ClassifyExportFreshness compares the export file in beadsDir and the
+// database state hash storeHash against what was recorded at the last export.
+// The returned detail explains an unknown result.
+func ClassifyExportFreshness(beadsDir, storeHash string) (state, detail string) {
+	// #nosec G304 -- fixed file name inside the .beads directory
+	data, err := os.ReadFile(filepath.Join(beadsDir, "export-state.json"))
+	if err != nil {
+		return ExportUnknown, "no export has been recorded"
+	}
+	var recorded exportStateFile
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return ExportUnknown, "export-state.json is unreadable"
+	}
+	if recorded.JSONLHash == "" {
+		return ExportUnknown, "last export predates export hashes"
+	}
+
+	// #nosec G304 -- export path from config, inside the .beads directory
+	exported, err := os.ReadFile(exportFilePath(beadsDir))
+	if err != nil {
+		return ExportUnknown, "no export file"
+	}
+	sum := sha256.Sum256(exported)
+	fileChanged := hex.EncodeToString(sum[:]) != recorded.JSONLHash
+	dbChanged := storeHash != "" && storeHash != recorded.LastDoltCommit
+
+	switch {
+	case fileChanged && dbChanged:
+		return ExportDiverged, ""
+	case fileChanged:
+		return ExportStaleImport, ""
+	case dbChanged:
+		return ExportStaleExport, ""
+	default:
+		return ExportFresh, ""
+	}
+}
+
+// CheckExportFreshness reports whether the JSONL export and the database have
+// drifted apart since the last export: the database was modified without
+// re-exporting (stale export), or the file was replaced, e.g. by a git pull,
+// without importing it (stale import).
+func CheckExportFreshness(path string, ss *SharedStore) DoctorCheck {
+	beadsDir := beadsDirFromSharedStore(path, ss)
+	if _, err := os.Stat(beadsDir); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:    "Export Freshness",
+			Status:  StatusOK,
+			Message: "N/A (no .beads directory)",
+		}
+	}
+
+	exportName := filepath.Base(exportFilePath(beadsDir))
+	state, detail := ClassifyExportFreshness(beadsDir, sharedStoreStateHash(ss))
+	switch state {
+	case ExportStaleExport:
+		return DoctorCheck{
+			Name:    "Export Freshness",
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Database changed since %s was last exported", exportName),
+			Fix:     "Run 'bd doctor --fix' to re-export (or 'bd export -o .beads/" + exportName + "')",
+		}
+	case ExportStaleImport:
+		return DoctorCheck{
+			Name:    "Export Freshness",
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%s changed since it was last exported and has not been imported", exportName),
+			Detail:  "The file was likely updated by git (pull, merge, checkout)",
+			Fix:     "Run 'bd doctor --fix' to import it (or 'bd import')",
+		}
+	case ExportDiverged:
+		return DoctorCheck{
+			Name:    "Export Freshness",
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Both the database and %s changed since the last export", exportName),
+			Detail:  "Importing may overwrite local changes and exporting would discard the file's changes",
+			Fix:     "Review with 'git diff .beads/" + exportName + "', then run 'bd import' followed by 'bd export -o .beads/" + exportName + "'",
+		}
+	case ExportUnknown:
+		return DoctorCheck{
+			Name:    "Export Freshness",
+			Status:  StatusOK,
+			Message: "N/A (" + detail + ")",
+		}
+	default:
+		return DoctorCheck{
+			Name:    "Export Freshness",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%s matches the database", exportName),
+		}
+	}
+}
+
+// ExportFreshnessState classifies the workspace at path with its own store,
+// for callers outside a doctor run (e.g. 'bd doctor --fix').
+func ExportFreshnessState(path string) string {
+	ss := NewSharedStore(path)
+	defer ss.Close()
+	state, _ := ClassifyExportFreshness(beadsDirFromSharedStore(path, ss), sharedStoreStateHash(ss))
+	return state
+}
+
+// sharedStoreStateHash returns the database state hash, or "" when the store
+// is unavailable (in which case only the export file is compared).
+func sharedStoreStateHash(ss *SharedStore) string {
+	store := ss.Store()
+	if store == nil {
+		return ""
+	}
+	hash, err := store.GetStateHash(context.Background())
+	if err != nil {
+		return ""
+	}
+	return hash
+}
@@ -0,0 +1,84 @@
+package doctor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeExportFreshnessFixture(t *testing.T, beadsDir, exported, recordedFile, recordedDB string) {
+	t.Helper()
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(exported), 0600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(recordedFile))
+	data, err := json.Marshal(map[string]string{
+		"last_dolt_commit": recordedDB,
+		"jsonl_hash":       hex.EncodeToString(sum[:]),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "export-state.json"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClassifyExportFreshness(t *testing.T) {
+	const v1 = `{"id":"bd-1","title":"a"}` + "\n"
+	const v2 = `{"id":"bd-1","title":"b"}` + "\n"
+
+	tests := []struct {
+		name       string
+		exported   string
+		storeHash  string
+		wantStatus string
+	}{
+		{"fresh", v1, "h1", ExportFresh},
+		{"database changed", v1, "h2", ExportStaleExport},
+		{"file changed", v2, "h1", ExportStaleImport},
+		{"both changed", v2, "h2", ExportDiverged},
+		{"store unavailable", v1, "", ExportFresh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beadsDir := filepath.Join(t.TempDir(), ".beads")
+			writeExportFreshnessFixture(t, beadsDir, tt.exported, v1, "h1")
+			if got, _ := ClassifyExportFreshness(beadsDir, tt.storeHash); got != tt.wantStatus {
+				t.Errorf("ClassifyExportFreshness() = %q, want %q", got, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("no recorded export", func(t *testing.T) {
+		beadsDir := t.TempDir()
+		if got, _ := ClassifyExportFreshness(beadsDir, "h1"); got != ExportUnknown {
+			t.Errorf("ClassifyExportFreshness() = %q, want %q", got, ExportUnknown)
+		}
+	})
+}
+
+func TestCheckExportFreshness(t *testing.T) {
+	t.Run("no beads dir", func(t *testing.T) {
+		result := CheckExportFreshness(t.TempDir(), nil)
+		if result.Status != StatusOK {
+			t.Errorf("expected OK for missing .beads dir, got %s: %s", result.Status, result.Message)
+		}
+	})
+
+	t.Run("stale import warns", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		beadsDir := filepath.Join(tmpDir, ".beads")
+		writeExportFreshnessFixture(t, beadsDir, `{"id":"bd-2"}`+"\n", `{"id":"bd-1"}`+"\n", "h1")
+		result := CheckExportFreshness(tmpDir, nil)
+		if result.Status != StatusWarning {
+			t.Errorf("expected warning for changed export file, got %s: %s", result.Status, result.Message)
+		}
+	})
+}
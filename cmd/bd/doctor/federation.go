@@ -162,7 +162,7 @@ func CheckLegacyCLIRemotes(path string) DoctorCheck {
 		Status:   StatusWarning,
 		Message:  fmt.Sprintf("%d legacy CLI remote(s) not visible through SQL", len(missing)),
 		Detail:   fmt.Sprintf("Inspected CLI directories:\n%s\nRemotes: %s\nbd dolt remote list, push, and pull use SQL remotes as the source of truth.", strings.Join(inspected, "\n"), strings.Join(missing, ", ")),
-		Fix:      "Re-register each remote with 'bd dolt remote add <name> <url>' so it is stored in SQL.",
+		Fix:      "Run 'bd doctor --fix' to register CLI-only remotes in SQL; remotes with different URLs need --prefer=sql|cli (or an interactive choice).",
 		Category: CategoryFederation,
 	}
 }
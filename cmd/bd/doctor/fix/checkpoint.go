@@ -0,0 +1,156 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// checkpointMaxFileSize caps the files a Checkpoint copies into memory.
+// Larger files (database dumps, big JSONL exports) are not restored.
+const checkpointMaxFileSize = 16 << 20
+
+// Checkpoint records the state a doctor fix may change so a failed fix can be
+// undone: the top-level files in .beads/ (metadata.json, config.yaml, JSONL,
+// .gitignore, ...), the repository's .gitignore, and the Dolt HEAD commit.
+//
+// Restore puts snapshotted files back and resets the database to the recorded
+// HEAD. It does not delete files a fix created (backups, new directories), and
+// it cannot undo a fix that reinitializes the database.
+type Checkpoint struct {
+	files    map[string]checkpointFile
+	beadsDir string
+	doltHead string // empty when the database could not be checkpointed
+	dbNote   string // why the database is not covered, when it is not
+}
+
+type checkpointFile struct {
+	data []byte
+	mode os.FileMode
+}
+
+// NewCheckpoint snapshots the workspace at path.
+func NewCheckpoint(path string) (*Checkpoint, error) {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return nil, err
+	}
+	cp := &Checkpoint{files: make(map[string]checkpointFile), beadsDir: beadsDir}
+
+	entries, err := os.ReadDir(beadsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", beadsDir, err)
+	}
+	localDir, err := localWorkspaceBeadsDir(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{filepath.Join(filepath.Dir(localDir), ".gitignore")}
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			files = append(files, filepath.Join(beadsDir, e.Name()))
+		}
+	}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > checkpointMaxFileSize {
+			continue
+		}
+		// #nosec G304 -- files inside the workspace being repaired
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", file, err)
+		}
+		cp.files[file] = checkpointFile{data: data, mode: info.Mode().Perm()}
+	}
+
+	cp.doltHead, cp.dbNote = checkpointDoltHead(beadsDir)
+	return cp, nil
+}
+
+// checkpointDoltHead returns the Dolt HEAD commit, or a note explaining why
+// the database cannot be rolled back. A dirty working set is not covered:
+// resetting to HEAD would also discard those uncommitted changes.
+func checkpointDoltHead(beadsDir string) (head, note string) {
+	db, err := openDoltDB(beadsDir)
+	if err != nil {
+		return "", "database unavailable"
+	}
+	defer db.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var dirty int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM dolt_status").Scan(&dirty); err != nil {
+		return "", fmt.Sprintf("cannot read dolt_status: %v", err)
+	}
+	if dirty > 0 {
+		return "", "database has uncommitted changes"
+	}
+	if err := db.QueryRowContext(ctx, "SELECT DOLT_HASHOF('HEAD')").Scan(&head); err != nil {
+		return "", fmt.Sprintf("cannot read HEAD: %v", err)
+	}
+	return head, ""
+}
+
+// CoversDatabase reports whether Restore can roll the database back, and if
+// not, why.
+func (c *Checkpoint) CoversDatabase() (bool, string) {
+	return c.doltHead != "", c.dbNote
+}
+
+// Restore rolls the workspace back to the checkpoint. It returns one line per
+// restored item and the first error; it keeps going after an error so as much
+// as possible is restored.
+func (c *Checkpoint) Restore() ([]string, error) {
+	var restored []string
+	var firstErr error
+	files := make([]string, 0, len(c.files))
+	for file := range c.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		snap := c.files[file]
+		// #nosec G304 -- files inside the workspace being repaired
+		current, err := os.ReadFile(file)
+		if err == nil && string(current) == string(snap.data) {
+			continue
+		}
+		if err := os.WriteFile(file, snap.data, snap.mode); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to restore %s: %w", file, err)
+			}
+			continue
+		}
+		restored = append(restored, "restored "+file)
+	}
+
+	if c.doltHead != "" {
+		if err := c.resetDolt(); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+		} else {
+			restored = append(restored, "reset database to "+c.doltHead)
+		}
+	}
+	return restored, firstErr
+}
+
+func (c *Checkpoint) resetDolt() error {
+	db, err := openDoltDB(c.beadsDir)
+	if err != nil {
+		return fmt.Errorf("failed to reset database: %w", err)
+	}
+	defer db.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := db.ExecContext(ctx, "CALL DOLT_RESET('--hard', ?)", c.doltHead); err != nil {
+		return fmt.Errorf("failed to reset database to %s: %w", c.doltHead, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointRestoresFiles(t *testing.T) {
+	dir := setupTestWorkspace(t)
+	beadsDir := filepath.Join(dir, ".beads")
+	configPath := filepath.Join(beadsDir, "config.yaml")
+	gitignorePath := filepath.Join(dir, ".gitignore")
+	if err := os.WriteFile(configPath, []byte("prefix: bd\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(gitignorePath, []byte("node_modules/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err := NewCheckpoint(dir)
+	if err != nil {
+		t.Fatalf("NewCheckpoint: %v", err)
+	}
+	if covered, note := cp.CoversDatabase(); covered || note == "" {
+		t.Errorf("CoversDatabase() = %v, %q; want false with a reason (no database)", covered, note)
+	}
+
+	if err := os.WriteFile(configPath, []byte("prefix: broken\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(gitignorePath, []byte("node_modules/\n.beads/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := cp.Restore()
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if len(restored) != 2 {
+		t.Errorf("restored %v, want 2 files", restored)
+	}
+	for file, want := range map[string]string{configPath: "prefix: bd\n", gitignorePath: "node_modules/\n"} {
+		got, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(file), got, want)
+		}
+	}
+
+	// Unchanged files are not rewritten.
+	if restored, err := cp.Restore(); err != nil || len(restored) != 0 {
+		t.Errorf("second Restore = %v, %v; want nothing to restore", restored, err)
+	}
+}
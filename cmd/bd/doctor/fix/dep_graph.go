@@ -0,0 +1,234 @@
+package fix
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DepGraphEdge is one row of the dependencies or wisp_dependencies table.
+type DepGraphEdge struct {
+	Table       string
+	IssueID     string
+	DependsOnID string
+	Type        string
+}
+
+func (e DepGraphEdge) String() string {
+	return e.IssueID + "→" + e.DependsOnID
+}
+
+// DepGraphReport summarizes dependency-graph integrity problems: edges whose
+// target does not exist, edges from an issue to itself, and cycles among
+// blocking edges (blocks, conditional-blocks, waits-for). Each cycle lists
+// the issue IDs of one strongly connected component, sorted.
+type DepGraphReport struct {
+	Dangling  []DepGraphEdge
+	SelfLoops []DepGraphEdge
+	Cycles    [][]string
+}
+
+// Empty reports whether the graph has no integrity problems.
+func (r DepGraphReport) Empty() bool {
+	return len(r.Dangling) == 0 && len(r.SelfLoops) == 0 && len(r.Cycles) == 0
+}
+
+// ScanDependencyGraph loads both edge tables and the set of existing issue
+// and wisp IDs, and analyzes the graph. external: targets are cross-rig
+// references and are never dangling (#1593).
+func ScanDependencyGraph(ctx context.Context, db *sql.DB) (DepGraphReport, error) {
+	exists := make(map[string]bool)
+	for _, table := range []string{"issues", "wisps"} {
+		//nolint:gosec // G201: table is a hardcoded constant, never user input.
+		rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT id FROM %s", table))
+		if err != nil {
+			return DepGraphReport{}, fmt.Errorf("%s: %w", table, err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return DepGraphReport{}, fmt.Errorf("%s: %w", table, err)
+			}
+			exists[id] = true
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return DepGraphReport{}, fmt.Errorf("%s: %w", table, err)
+		}
+	}
+
+	//nolint:gosec // G202: fixDependencyUnionSQL returns a fixed internal SELECT fragment.
+	rows, err := db.QueryContext(ctx, `
+		SELECT d.dep_table, d.issue_id, d.depends_on_id, d.type
+		FROM (`+fixDependencyUnionSQL()+`) d
+		WHERE d.depends_on_id IS NOT NULL`)
+	if err != nil {
+		return DepGraphReport{}, fmt.Errorf("dependencies: %w", err)
+	}
+	defer rows.Close()
+	var edges []DepGraphEdge
+	for rows.Next() {
+		var e DepGraphEdge
+		if err := rows.Scan(&e.Table, &e.IssueID, &e.DependsOnID, &e.Type); err != nil {
+			return DepGraphReport{}, fmt.Errorf("dependencies: %w", err)
+		}
+		edges = append(edges, e)
+	}
+	if err := rows.Err(); err != nil {
+		return DepGraphReport{}, fmt.Errorf("dependencies: %w", err)
+	}
+	return analyzeDependencyGraph(edges, exists), nil
+}
+
+// analyzeDependencyGraph classifies edges and finds blocking cycles with
+// Tarjan's strongly connected components algorithm. Split from
+// ScanDependencyGraph so the analysis is testable without a database.
+func analyzeDependencyGraph(edges []DepGraphEdge, exists map[string]bool) DepGraphReport {
+	var report DepGraphReport
+	adj := make(map[string][]string)
+	for _, e := range edges {
+		switch {
+		case e.IssueID == e.DependsOnID:
+			report.SelfLoops = append(report.SelfLoops, e)
+		case !strings.HasPrefix(e.DependsOnID, "external:") && !exists[e.DependsOnID]:
+			report.Dangling = append(report.Dangling, e)
+		case types.DependencyType(e.Type).IsBlockingEdge():
+			adj[e.IssueID] = append(adj[e.IssueID], e.DependsOnID)
+		}
+	}
+
+	nodes := make([]string, 0, len(adj))
+	for id := range adj {
+		nodes = append(nodes, id)
+	}
+	sort.Strings(nodes)
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	next := 0
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		index[v] = next
+		lowlink[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range adj[v] {
+			if _, seen := index[w]; !seen {
+				strongConnect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], index[w])
+			}
+		}
+		if lowlink[v] != index[v] {
+			return
+		}
+		var component []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		if len(component) > 1 {
+			sort.Strings(component)
+			report.Cycles = append(report.Cycles, component)
+		}
+	}
+	for _, v := range nodes {
+		if _, seen := index[v]; !seen {
+			strongConnect(v)
+		}
+	}
+	sort.Slice(report.Cycles, func(i, j int) bool { return report.Cycles[i][0] < report.Cycles[j][0] })
+	return report
+}
+
+// DependencyGraph removes dangling and self-referencing dependency edges and
+// reports blocking cycles, which need a human to decide which edge is wrong.
+// If verbose is true, prints each removed edge; otherwise shows only a summary.
+func DependencyGraph(path string, verbose bool) error {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDoltDB(beadsDir)
+	if err != nil {
+		fmt.Printf("  Dependency graph fix skipped (%v)\n", err)
+		return nil
+	}
+	defer db.Close()
+
+	return repairDependencyGraph(context.Background(), db, verbose)
+}
+
+// repairDependencyGraph scans and repairs the graph on an open connection.
+func repairDependencyGraph(ctx context.Context, db *sql.DB, verbose bool) error {
+	report, err := ScanDependencyGraph(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to scan dependency graph: %w", err)
+	}
+
+	prune := append(append([]DepGraphEdge{}, report.Dangling...), report.SelfLoops...)
+	if len(prune) > 0 {
+		// Uses explicit transaction so writes persist when @@autocommit is OFF
+		// (e.g. Dolt server started with --no-auto-commit).
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		showIndividual := verbose || len(prune) < 20
+		removed := 0
+		repairedTables := make(map[string]bool)
+		for _, e := range prune {
+			if e.Table != "dependencies" && e.Table != "wisp_dependencies" {
+				fmt.Printf("  Warning: skipped dependency from unexpected table %s\n", e.Table)
+				continue
+			}
+			//nolint:gosec // G202: table is one of the two hardcoded edge tables checked above.
+			if _, err := tx.Exec("DELETE FROM "+e.Table+" WHERE issue_id = ? AND "+fixDependencyTargetExpr+" = ?", e.IssueID, e.DependsOnID); err != nil {
+				fmt.Printf("  Warning: failed to remove %s: %v\n", e, err)
+				continue
+			}
+			removed++
+			repairedTables[e.Table] = true
+			if showIndividual {
+				fmt.Printf("  Removed dependency %s\n", e)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit dependency graph repairs: %w", err)
+		}
+		// Best effort: commit advisory; repair already applied.
+		for table := range repairedTables {
+			_, _ = db.Exec("CALL DOLT_ADD(?)", table)
+		}
+		if len(repairedTables) > 0 {
+			_, _ = db.Exec("CALL DOLT_COMMIT('-m', 'doctor: remove dangling and self dependencies')")
+		}
+		fmt.Printf("  Removed %d dangling or self-referencing dependency edge(s)\n", removed)
+	}
+
+	if len(report.Cycles) > 0 {
+		fmt.Printf("  %d blocking cycle(s) need manual review (break one edge each with 'bd dep remove'):\n", len(report.Cycles))
+		for _, cycle := range report.Cycles {
+			fmt.Printf("    %s\n", strings.Join(cycle, ", "))
+		}
+	}
+	if report.Empty() {
+		fmt.Println("  No dependency graph problems to fix")
+	}
+	return nil
+}
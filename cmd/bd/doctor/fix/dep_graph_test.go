@@ -0,0 +1,37 @@
+package fix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeDependencyGraph(t *testing.T) {
+	exists := map[string]bool{"a": true, "b": true, "c": true, "d": true, "e": true}
+	edges := []DepGraphEdge{
+		// a → b → c → a is a blocking cycle.
+		{Table: "dependencies", IssueID: "a", DependsOnID: "b", Type: "blocks"},
+		{Table: "dependencies", IssueID: "b", DependsOnID: "c", Type: "waits-for"},
+		{Table: "dependencies", IssueID: "c", DependsOnID: "a", Type: "conditional-blocks"},
+		// d ↔ e only through non-blocking edges is not a cycle.
+		{Table: "dependencies", IssueID: "d", DependsOnID: "e", Type: "blocks"},
+		{Table: "dependencies", IssueID: "e", DependsOnID: "d", Type: "related"},
+		{Table: "dependencies", IssueID: "d", DependsOnID: "d", Type: "blocks"},
+		{Table: "wisp_dependencies", IssueID: "e", DependsOnID: "gone", Type: "blocks"},
+		{Table: "dependencies", IssueID: "e", DependsOnID: "external:other:x", Type: "blocks"},
+	}
+
+	report := analyzeDependencyGraph(edges, exists)
+	if want := [][]string{{"a", "b", "c"}}; !reflect.DeepEqual(report.Cycles, want) {
+		t.Errorf("Cycles = %v, want %v", report.Cycles, want)
+	}
+	if len(report.SelfLoops) != 1 || report.SelfLoops[0].String() != "d→d" {
+		t.Errorf("SelfLoops = %v, want [d→d]", report.SelfLoops)
+	}
+	if len(report.Dangling) != 1 || report.Dangling[0].String() != "e→gone" {
+		t.Errorf("Dangling = %v, want [e→gone] (external refs are never dangling)", report.Dangling)
+	}
+
+	if !analyzeDependencyGraph(edges[3:5], exists).Empty() {
+		t.Error("a graph with only a non-blocking back edge should be clean")
+	}
+}
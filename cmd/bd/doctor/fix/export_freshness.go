@@ -0,0 +1,51 @@
+package fix
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// ExportFreshness brings the JSONL export and the database back in step for
+// the state reported by doctor.CheckExportFreshness: a stale export is
+// regenerated from the database, and a stale import is imported and then
+// re-exported so the recorded export state matches again. A diverged export is
+// left for manual review, since either direction would drop changes.
+func ExportFreshness(path, state string) error {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return err
+	}
+	exportName := config.GetString("export.path")
+	if exportName == "" {
+		exportName = "issues.jsonl"
+	}
+	fullPath := filepath.Join(beadsDir, exportName)
+
+	var steps [][]string
+	switch state {
+	case "stale_export":
+		steps = [][]string{{"export", "-o", fullPath}}
+	case "stale_import":
+		steps = [][]string{{"import", fullPath}, {"export", "-o", fullPath}}
+	case "diverged":
+		return fmt.Errorf("both the database and %s changed; review the differences, then run 'bd import' and 'bd export -o %s'", exportName, fullPath)
+	default:
+		return nil
+	}
+
+	bdBinary, err := getBdBinary()
+	if err != nil {
+		return err
+	}
+	for _, args := range steps {
+		cmd := newBdCmd(bdBinary, args...)
+		cmd.Dir = filepath.Dir(beadsDir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("bd %s failed: %w\n%s", args[0], err, out)
+		}
+	}
+	fmt.Printf("  Synced %s with the database\n", exportName)
+	return nil
+}
@@ -139,6 +139,10 @@ func DetectActiveHookManager(path string) string {
 		}
 	}
 
+	if manager := HookManagerForHooksDir(path, hooksDir); manager != "" {
+		return manager
+	}
+
 	// Check common hooks for manager signatures
 	for _, hookName := range []string{"pre-commit", "pre-push", "post-merge"} {
 		hookPath := filepath.Join(hooksDir, hookName)
@@ -146,16 +150,38 @@ func DetectActiveHookManager(path string) string {
 		if err != nil {
 			continue
 		}
-		contentStr := string(content)
+		if manager := HookManagerForContent(string(content)); manager != "" {
+			return manager
+		}
+	}
 
-		// Check each manager pattern (deterministic order)
-		for _, mp := range hookManagerPatterns {
-			if mp.pattern.MatchString(contentStr) {
-				return mp.name
-			}
+	return ""
+}
+
+// HookManagerForContent returns the hook manager whose generated script
+// content matches, or "" for hooks not written by a known manager.
+func HookManagerForContent(content string) string {
+	// Check each manager pattern (deterministic order)
+	for _, mp := range hookManagerPatterns {
+		if mp.pattern.MatchString(content) {
+			return mp.name
 		}
 	}
+	return ""
+}
 
+// HookManagerForHooksDir reports the manager that owns hooksDir outright.
+// husky (v9+) points core.hooksPath at .husky/_ and regenerates every file in
+// it, so nothing written there by bd survives the next husky install.
+func HookManagerForHooksDir(repoRoot, hooksDir string) string {
+	rel, err := filepath.Rel(repoRoot, hooksDir)
+	if err != nil {
+		return ""
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".husky" || strings.HasPrefix(rel, ".husky/") {
+		return "husky"
+	}
 	return ""
 }
 
@@ -636,3 +662,38 @@ func GitHooks(path string) error {
 
 	return nil
 }
+
+// GitHookPermissions restores the execute bit on installed hooks in the
+// active hooks directory (core.hooksPath when set). Git silently skips
+// hooks that are not executable.
+func GitHookPermissions(path string) error {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = path
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("not a git repository")
+	}
+	hooksDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(path, hooksDir)
+	}
+
+	fixed := 0
+	for _, name := range []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "post-commit", "prepare-commit-msg"} {
+		hookPath := filepath.Join(hooksDir, name)
+		info, err := os.Stat(hookPath)
+		if err != nil || info.IsDir() || info.Mode()&0o111 != 0 {
+			continue
+		}
+		// #nosec G302 -- git hooks must be executable
+		if err := os.Chmod(hookPath, info.Mode()|0o755); err != nil {
+			return fmt.Errorf("chmod %s: %w", hookPath, err)
+		}
+		fmt.Printf("  Made %s executable\n", hookPath)
+		fixed++
+	}
+	if fixed == 0 {
+		fmt.Println("  No non-executable hooks found")
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -1328,3 +1329,30 @@ func TestDetectExternalHookManagers_MultiplePresentInOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestGitHookPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no execute bit on Windows")
+	}
+	dir := newGitRepo(t)
+	runGit(t, dir, "config", "core.hooksPath", ".beads/hooks")
+	hooksDir := filepath.Join(dir, ".beads", "hooks")
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	hook := filepath.Join(hooksDir, "pre-commit")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nbd hooks run pre-commit \"$@\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GitHookPermissions(dir); err != nil {
+		t.Fatalf("GitHookPermissions() error = %v", err)
+	}
+	info, err := os.Stat(hook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&0o111 == 0 {
+		t.Errorf("pre-commit mode = %v, want executable", info.Mode())
+	}
+}
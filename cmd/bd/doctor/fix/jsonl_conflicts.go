@@ -0,0 +1,274 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// FindJSONLConflicts returns the .beads/*.jsonl files (relative to beadsDir)
+// that contain git conflict marker lines. Used by CheckJSONLConflicts in the
+// doctor package.
+func FindJSONLConflicts(beadsDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(beadsDir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	var conflicted []string
+	for _, fpath := range matches {
+		has, err := jsonlHasConflictMarkers(fpath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", filepath.Base(fpath), err)
+		}
+		if has {
+			conflicted = append(conflicted, filepath.Base(fpath))
+		}
+	}
+	sort.Strings(conflicted)
+	return conflicted, nil
+}
+
+// jsonlHasConflictMarkers streams the file so large exports are not loaded
+// into memory.
+func jsonlHasConflictMarkers(path string) (bool, error) {
+	f, err := os.Open(path) // #nosec G304 - path constructed from beadsDir
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		for _, marker := range []string{"<<<<<<<", "=======", ">>>>>>>", "|||||||"} {
+			if strings.HasPrefix(line, marker) {
+				return true, nil
+			}
+		}
+	}
+	return false, scanner.Err()
+}
+
+// JSONLConflicts repairs .beads/*.jsonl files that contain conflict markers.
+//
+// A file git still lists as unmerged is re-merged mechanically: the base, ours
+// and theirs versions are read back from the index and fed to 'bd merge-file',
+// which merges by issue ID. A conflicted export file that is no longer
+// unmerged (the markers were committed) is regenerated from the database,
+// which is the source of truth, in the schema version and shard mode it was
+// written with. Any other file is left for manual resolution.
+func JSONLConflicts(path string) error {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return err
+	}
+	conflicted, err := FindJSONLConflicts(beadsDir)
+	if err != nil {
+		return err
+	}
+	if len(conflicted) == 0 {
+		return nil
+	}
+
+	bdBinary, err := getBdBinary()
+	if err != nil {
+		return err
+	}
+
+	exportName := config.GetString("export.path")
+	if exportName == "" {
+		exportName = "issues.jsonl"
+	}
+
+	var manual []string
+	regenerated := make(map[string]bool)
+	for _, name := range conflicted {
+		fullPath := filepath.Join(beadsDir, name)
+		stages := unmergedStages(beadsDir, name)
+		switch {
+		case stages[2] != "" && stages[3] != "":
+			if err := remergeJSONL(bdBinary, beadsDir, fullPath, stages); err != nil {
+				return fmt.Errorf("failed to merge %s: %w", name, err)
+			}
+			fmt.Printf("  Merged %s with bd merge-file (run 'git add .beads/%s' to mark it resolved)\n", name, name)
+		default:
+			args, ok := exportRegenArgs(beadsDir, name, exportName)
+			if !ok {
+				manual = append(manual, name)
+				continue
+			}
+			// One sharded export rewrites every shard, so conflicted
+			// siblings share a single run.
+			key := strings.Join(args, " ")
+			if !regenerated[key] {
+				cmd := newBdCmd(bdBinary, args...)
+				cmd.Dir = filepath.Dir(beadsDir)
+				if out, err := cmd.CombinedOutput(); err != nil {
+					return fmt.Errorf("failed to regenerate %s: %w\n%s", name, err, out)
+				}
+				regenerated[key] = true
+			}
+			fmt.Printf("  Regenerated %s from the database\n", name)
+		}
+	}
+
+	if len(manual) > 0 {
+		return fmt.Errorf("no mechanical resolution for %s; resolve the markers by hand", strings.Join(manual, ", "))
+	}
+	return nil
+}
+
+// jsonlExportHeader is the part of a v2 export header record that decides
+// how the file was written.
+type jsonlExportHeader struct {
+	Schema        string `json:"_schema"`
+	SchemaVersion int    `json:"schema_version"`
+	Shard         string `json:"shard"`
+}
+
+// readJSONLExportHeader returns the v2 header of an export file. The header
+// is normally the first line, but a conflict over it puts a marker first, so
+// the first record carrying _schema is taken.
+func readJSONLExportHeader(path string) (jsonlExportHeader, bool) {
+	f, err := os.Open(path) // #nosec G304 - path constructed from beadsDir
+	if err != nil {
+		return jsonlExportHeader{}, false
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.Contains(line, []byte(`"_schema"`)) {
+			continue
+		}
+		var header jsonlExportHeader
+		if json.Unmarshal(line, &header) == nil && header.Schema != "" {
+			return header, true
+		}
+	}
+	return jsonlExportHeader{}, false
+}
+
+// exportShardMode maps a shard name back to the bd export --shard mode that
+// produces it. The meta shard (labels and memories) exists in both modes, so
+// its siblings next to the export file decide.
+func exportShardMode(beadsDir, exportBase, shard string) string {
+	switch shard {
+	case "":
+		return ""
+	case "open", "closed":
+		return "status"
+	case "meta":
+		ext := filepath.Ext(exportBase)
+		siblings, _ := filepath.Glob(filepath.Join(beadsDir, strings.TrimSuffix(exportBase, ext)+"-*"+ext))
+		for _, path := range siblings {
+			if header, ok := readJSONLExportHeader(path); ok && header.Shard != "meta" {
+				if mode := exportShardMode(beadsDir, exportBase, header.Shard); mode != "" {
+					return mode
+				}
+			}
+		}
+		return ""
+	default:
+		return "id"
+	}
+}
+
+// exportRegenArgs returns the bd arguments that regenerate a conflicted
+// export file from the database without changing its format: the configured
+// export file keeps its schema version, and a shard file next to it is
+// rewritten, with its siblings, in the same --shard mode. ok is false for
+// files bd export did not write.
+func exportRegenArgs(beadsDir, name, exportName string) (args []string, ok bool) {
+	exportBase := filepath.Base(exportName)
+	exportPath := filepath.Join(beadsDir, exportBase)
+	header, _ := readJSONLExportHeader(filepath.Join(beadsDir, name))
+
+	if name == exportBase {
+		args = []string{"export", "-o", exportPath}
+		if header.SchemaVersion > 1 {
+			args = append(args, "--schema-version", strconv.Itoa(header.SchemaVersion))
+		}
+		return args, true
+	}
+
+	ext := filepath.Ext(exportBase)
+	if !strings.HasPrefix(name, strings.TrimSuffix(exportBase, ext)+"-") || filepath.Ext(name) != ext {
+		return nil, false
+	}
+	mode := exportShardMode(beadsDir, exportBase, header.Shard)
+	if mode == "" {
+		return nil, false
+	}
+	return []string{"export", "--shard", mode, "-o", exportPath}, true
+}
+
+// unmergedStages returns the index blob IDs for stages 1-3 (base, ours,
+// theirs) of a file git still lists as unmerged. Missing stages are "".
+func unmergedStages(beadsDir, name string) [4]string {
+	var stages [4]string
+	cmd := exec.Command("git", "ls-files", "-u", "--", name)
+	cmd.Dir = beadsDir
+	out, err := cmd.Output()
+	if err != nil {
+		// Not a git repository (or git unavailable): nothing is unmerged.
+		return stages
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		// <mode> <object> <stage>\t<file>
+		fields := strings.Fields(strings.SplitN(line, "\t", 2)[0])
+		if len(fields) != 3 {
+			continue
+		}
+		switch fields[2] {
+		case "1", "2", "3":
+			stages[fields[2][0]-'0'] = fields[1]
+		}
+	}
+	return stages
+}
+
+// remergeJSONL writes the index stages to temporary files and runs
+// 'bd merge-file' over them, writing the result to fullPath.
+func remergeJSONL(bdBinary, beadsDir, fullPath string, stages [4]string) error {
+	tmpDir, err := os.MkdirTemp("", "bd-jsonl-merge-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	var paths [4]string
+	for i, name := range map[int]string{1: "base.jsonl", 2: "ours.jsonl", 3: "theirs.jsonl"} {
+		paths[i] = filepath.Join(tmpDir, name)
+		var content []byte
+		if stages[i] != "" {
+			cmd := exec.Command("git", "cat-file", "blob", stages[i]) // #nosec G204 -- object ID from git ls-files
+			cmd.Dir = beadsDir
+			if content, err = cmd.Output(); err != nil {
+				return fmt.Errorf("git cat-file %s: %w", stages[i], err)
+			}
+		}
+		if err := os.WriteFile(paths[i], content, 0600); err != nil {
+			return err
+		}
+	}
+
+	cmd := newBdCmd(bdBinary, "merge-file", paths[1], paths[2], paths[3], fullPath)
+	cmd.Dir = filepath.Dir(beadsDir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w\n%s", err, out)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package fix
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnmergedStages(t *testing.T) {
+	repo := newGitRepo(t)
+	beadsDir := filepath.Join(repo, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		_ = cmd.Run() // the conflicting merge exits non-zero by design
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(jsonlPath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"id":"bd-1","title":"base"}` + "\n")
+	git("add", ".")
+	git("commit", "-qm", "base")
+	git("checkout", "-qb", "other")
+	write(`{"id":"bd-1","title":"theirs"}` + "\n")
+	git("commit", "-qam", "theirs")
+	git("checkout", "-q", "-")
+	write(`{"id":"bd-1","title":"ours"}` + "\n")
+	git("commit", "-qam", "ours")
+	git("merge", "-q", "other")
+
+	conflicted, err := FindJSONLConflicts(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicted) != 1 || conflicted[0] != "issues.jsonl" {
+		t.Fatalf("FindJSONLConflicts = %v, want [issues.jsonl]", conflicted)
+	}
+
+	stages := unmergedStages(beadsDir, "issues.jsonl")
+	for i := 1; i <= 3; i++ {
+		if stages[i] == "" {
+			t.Errorf("stage %d missing: %v", i, stages)
+		}
+	}
+	if got := unmergedStages(beadsDir, "routes.jsonl"); got != [4]string{} {
+		t.Errorf("unmergedStages(routes.jsonl) = %v, want none", got)
+	}
+}
+
+func TestExportRegenArgs(t *testing.T) {
+	beadsDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(beadsDir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	header := func(shard string) string {
+		return `{"_schema":"beads-jsonl/2","schema_version":2,"shard":"` + shard + `","sort":"id"}` + "\n"
+	}
+	exportPath := filepath.Join(beadsDir, "issues.jsonl")
+
+	write("issues.jsonl", "<<<<<<< HEAD\n"+`{"_schema":"beads-jsonl/2","schema_version":2,"sort":"id"}`+"\n=======\n")
+	write("issues-open.jsonl", header("open"))
+	write("issues-meta.jsonl", header("meta"))
+	write("issues-a.jsonl", header("a"))
+	write("routes.jsonl", `{"prefix":"bd-"}`+"\n")
+
+	for name, want := range map[string]string{
+		"issues.jsonl":      "export -o " + exportPath + " --schema-version 2",
+		"issues-open.jsonl": "export --shard status -o " + exportPath,
+		"routes.jsonl":      "",
+	} {
+		args, ok := exportRegenArgs(beadsDir, name, "issues.jsonl")
+		if got := strings.Join(args, " "); got != want || ok != (want != "") {
+			t.Errorf("exportRegenArgs(%s) = %q, %v; want %q", name, got, ok, want)
+		}
+	}
+
+	// The meta shard takes the mode of its siblings: with only id shards
+	// next to it, id.
+	if err := os.Remove(filepath.Join(beadsDir, "issues-open.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+	args, _ := exportRegenArgs(beadsDir, "issues-meta.jsonl", "issues.jsonl")
+	if got, want := strings.Join(args, " "), "export --shard id -o "+exportPath; got != want {
+		t.Errorf("exportRegenArgs(issues-meta.jsonl) = %q, want %q", got, want)
+	}
+
+	// A headerless v1 export is regenerated as v1.
+	write("issues.jsonl", `{"id":"bd-1"}`+"\n")
+	args, _ = exportRegenArgs(beadsDir, "issues.jsonl", "issues.jsonl")
+	if got, want := strings.Join(args, " "), "export -o "+exportPath; got != want {
+		t.Errorf("exportRegenArgs(v1 issues.jsonl) = %q, want %q", got, want)
+	}
+}
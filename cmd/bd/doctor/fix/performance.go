@@ -0,0 +1,110 @@
+package fix
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
+)
+
+// PerformanceIndex is an index the hot bd queries rely on, with the DDL that
+// recreates it when a migration or manual schema edit has dropped it.
+type PerformanceIndex struct {
+	Table  string
+	Name   string
+	Create string
+	Use    string // the query it serves, for doctor output
+}
+
+// PerformanceIndexes are the indexes checked by the Performance Profile check.
+var PerformanceIndexes = []PerformanceIndex{
+	{"issues", "idx_issues_is_blocked", "CREATE INDEX idx_issues_is_blocked ON issues(is_blocked, status)", "bd ready"},
+	{"issues", "idx_issues_status_updated_at", "CREATE INDEX idx_issues_status_updated_at ON issues(status, updated_at)", "bd list by status"},
+	{"dependencies", "idx_dependencies_issue", "CREATE INDEX idx_dependencies_issue ON dependencies(issue_id)", "dependency traversal"},
+	{"dependencies", "idx_dep_issue_target", "CREATE INDEX idx_dep_issue_target ON dependencies(depends_on_issue_id)", "reverse dependency lookups"},
+	{"events", "idx_events_issue", "CREATE INDEX idx_events_issue ON events(issue_id)", "bd show history"},
+}
+
+// MissingPerformanceIndexes returns the PerformanceIndexes absent from the
+// current database.
+func MissingPerformanceIndexes(ctx context.Context, db *sql.DB) ([]PerformanceIndex, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT DISTINCT TABLE_NAME, INDEX_NAME FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE()`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	defer rows.Close()
+	present := make(map[string]bool)
+	for rows.Next() {
+		var table, name string
+		if err := rows.Scan(&table, &name); err != nil {
+			return nil, err
+		}
+		present[table+"."+name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var missing []PerformanceIndex
+	for _, idx := range PerformanceIndexes {
+		if !present[idx.Table+"."+idx.Name] {
+			missing = append(missing, idx)
+		}
+	}
+	return missing, nil
+}
+
+// PerformanceMaintenance recreates missing PerformanceIndexes and runs dolt
+// gc to compact the chunk store. It returns one line per change made, for the
+// fix summary.
+func PerformanceMaintenance(path string) ([]string, error) {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return nil, err
+	}
+	db, err := openDoltDB(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	missing, err := MissingPerformanceIndexes(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	for _, idx := range missing {
+		if _, err := db.ExecContext(ctx, idx.Create); err != nil {
+			return changes, fmt.Errorf("failed to create %s: %w", idx.Name, err)
+		}
+		changes = append(changes, fmt.Sprintf("created index %s on %s", idx.Name, idx.Table))
+	}
+	if len(missing) > 0 {
+		// Best-effort: the indexes are already usable in the working set.
+		_, _ = db.ExecContext(ctx, "CALL DOLT_ADD('-A')")
+		_, _ = db.ExecContext(ctx, "CALL DOLT_COMMIT('-m', 'bd doctor: recreate missing performance indexes')")
+	}
+
+	// DOLT_GC cannot run inside a transaction; use a dedicated connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return changes, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+	if err := versioncontrolops.DoltGC(ctx, conn); err != nil {
+		return changes, err
+	}
+	changes = append(changes, "ran dolt gc")
+
+	for _, c := range changes {
+		fmt.Printf("  %s\n", c)
+	}
+	return changes, nil
+}
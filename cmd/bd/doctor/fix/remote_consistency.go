@@ -0,0 +1,153 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/storage/doltutil"
+)
+
+// RemotePreferConfigKey records which side won the last SQL/CLI remote URL
+// conflict, so later non-interactive fixes resolve the same way.
+const RemotePreferConfigKey = "dolt.remote_prefer"
+
+// Remote conflict resolutions.
+const (
+	RemotePreferSQL = "sql" // keep the SQL remote, rewrite the CLI remote
+	RemotePreferCLI = "cli" // keep the CLI remote, rewrite the SQL remote
+)
+
+// RemoteConflict is a remote whose URL differs between SQL (dolt_remotes) and
+// a CLI directory (`dolt remote -v`).
+type RemoteConflict struct {
+	Name   string
+	SQLURL string
+	CLIURL string
+	CLIDir string
+}
+
+// RemoteChooser decides a conflict, returning RemotePreferSQL,
+// RemotePreferCLI, or "" to leave it unresolved.
+type RemoteChooser func(RemoteConflict) (string, error)
+
+// RemoteConsistency makes SQL and CLI remotes agree. CLI-only remotes are
+// registered in SQL. Remotes whose URLs differ are resolved by prefer ("sql"
+// or "cli"); when prefer is empty, by the preference recorded at the last
+// resolution, and failing that by choose (nil leaves the conflict alone). The
+// winning preference is recorded under RemotePreferConfigKey. It returns one
+// line per change made, for the fix summary.
+func RemoteConsistency(path, prefer string, choose RemoteChooser) ([]string, error) {
+	if prefer != "" && prefer != RemotePreferSQL && prefer != RemotePreferCLI {
+		return nil, fmt.Errorf("invalid --prefer %q (want %s or %s)", prefer, RemotePreferSQL, RemotePreferCLI)
+	}
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	store, err := dolt.NewFromConfig(ctx, beadsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	recorded, _ := store.GetConfig(ctx, RemotePreferConfigKey)
+	if prefer == "" && (recorded == RemotePreferSQL || recorded == RemotePreferCLI) {
+		prefer = recorded
+		fmt.Printf("  Using recorded remote preference %q (%s)\n", prefer, RemotePreferConfigKey)
+	}
+
+	sqlRemotes, err := store.ListRemotes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SQL remotes: %w", err)
+	}
+	sqlByName := make(map[string]string, len(sqlRemotes))
+	for _, r := range sqlRemotes {
+		sqlByName[r.Name] = r.URL
+	}
+
+	var changes []string
+	decided := ""
+	seen := make(map[string]bool)
+	for _, dir := range []string{store.CLIDir(), store.Path()} {
+		if dir == "" {
+			continue
+		}
+		dir = filepath.Clean(dir)
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		if _, err := os.Stat(filepath.Join(dir, ".dolt")); err != nil {
+			continue
+		}
+		cliRemotes, err := doltutil.ListCLIRemotes(dir)
+		if err != nil {
+			fmt.Printf("  Warning: skipped %s: %v\n", dir, err)
+			continue
+		}
+
+		for _, r := range cliRemotes {
+			sqlURL, inSQL := sqlByName[r.Name]
+			if inSQL && doltutil.RemoteURLsMatch(sqlURL, r.URL) {
+				continue
+			}
+			if !inSQL {
+				if err := store.AddRemote(ctx, r.Name, r.URL); err != nil {
+					return changes, err
+				}
+				sqlByName[r.Name] = r.URL
+				changes = append(changes, fmt.Sprintf("registered CLI remote %s=%s in SQL", r.Name, r.URL))
+				continue
+			}
+
+			conflict := RemoteConflict{Name: r.Name, SQLURL: sqlURL, CLIURL: r.URL, CLIDir: dir}
+			side := prefer
+			if side == "" && choose != nil {
+				if side, err = choose(conflict); err != nil {
+					return changes, err
+				}
+			}
+			switch side {
+			case RemotePreferSQL:
+				if err := doltutil.EnsureCLIRemote(dir, r.Name, sqlURL); err != nil {
+					return changes, err
+				}
+				changes = append(changes, fmt.Sprintf("rewrote CLI remote %s in %s: %s → %s (kept SQL)", r.Name, dir, r.URL, sqlURL))
+			case RemotePreferCLI:
+				if err := store.RemoveRemote(ctx, r.Name); err != nil {
+					return changes, err
+				}
+				if err := store.AddRemote(ctx, r.Name, r.URL); err != nil {
+					return changes, err
+				}
+				sqlByName[r.Name] = r.URL
+				changes = append(changes, fmt.Sprintf("rewrote SQL remote %s: %s → %s (kept CLI)", r.Name, sqlURL, r.URL))
+			default:
+				fmt.Printf("  Skipped conflicting remote %s (SQL %s, CLI %s); rerun with --prefer=sql or --prefer=cli\n", r.Name, sqlURL, r.URL)
+				continue
+			}
+			decided = side
+		}
+	}
+
+	if decided != "" && decided != recorded {
+		if err := store.SetConfig(ctx, RemotePreferConfigKey, decided); err != nil {
+			fmt.Printf("  Warning: failed to record remote preference: %v\n", err)
+		} else {
+			changes = append(changes, fmt.Sprintf("recorded %s=%s", RemotePreferConfigKey, decided))
+		}
+	}
+
+	for _, c := range changes {
+		fmt.Printf("  %s\n", c)
+	}
+	if len(changes) == 0 {
+		fmt.Println("  No remote changes made")
+	}
+	return changes, nil
+}
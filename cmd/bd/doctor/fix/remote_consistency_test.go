@@ -0,0 +1,16 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoteConsistencyRejectsUnknownPreference(t *testing.T) {
+	changes, err := RemoteConsistency(t.TempDir(), "both", nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid --prefer") {
+		t.Fatalf("RemoteConsistency(prefer=both) error = %v, want invalid --prefer", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes = %v, want none", changes)
+	}
+}
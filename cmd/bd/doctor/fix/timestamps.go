@@ -0,0 +1,205 @@
+package fix
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TimestampFutureTolerance is how far past now a timestamp may be before it
+// counts as written by a machine with a fast clock.
+const TimestampFutureTolerance = 5 * time.Minute
+
+// timestampOrderTolerance absorbs the separate time.Now calls a single write
+// makes for created/updated/closed, so only real inversions are flagged.
+const timestampOrderTolerance = time.Minute
+
+// IssueTimestamps are an issue's lifecycle timestamps.
+type IssueTimestamps struct {
+	ID        string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ClosedAt  *time.Time
+}
+
+// TimestampAnomaly is an issue whose timestamps are in the future or out of
+// order, with the clamped values the fix writes.
+type TimestampAnomaly struct {
+	IssueTimestamps
+	Problems []string
+	Fixed    IssueTimestamps
+}
+
+// timestampAnomalyQuery selects candidate rows; FindTimestampAnomalies applies
+// the tolerances. The future bound is passed in so the client clock, not the
+// server's, decides what is "now".
+const timestampAnomalyQuery = `
+	SELECT id, created_at, updated_at, closed_at FROM issues
+	WHERE created_at > ? OR updated_at > ? OR closed_at > ?
+	   OR updated_at < created_at
+	   OR (closed_at IS NOT NULL AND (closed_at < created_at OR closed_at > updated_at))`
+
+// LoadTimestampAnomalies returns the issues with future or inverted
+// timestamps relative to now.
+func LoadTimestampAnomalies(ctx context.Context, db *sql.DB, now time.Time) ([]TimestampAnomaly, error) {
+	bound := now.Add(TimestampFutureTolerance)
+	rows, err := db.QueryContext(ctx, timestampAnomalyQuery, bound, bound, bound)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issue timestamps: %w", err)
+	}
+	defer rows.Close()
+	var candidates []IssueTimestamps
+	for rows.Next() {
+		var ts IssueTimestamps
+		var closed sql.NullTime
+		if err := rows.Scan(&ts.ID, &ts.CreatedAt, &ts.UpdatedAt, &closed); err != nil {
+			return nil, err
+		}
+		if closed.Valid {
+			ts.ClosedAt = &closed.Time
+		}
+		candidates = append(candidates, ts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return FindTimestampAnomalies(candidates, now), nil
+}
+
+// FindTimestampAnomalies checks each issue's timestamps against now and each
+// other, returning the issues that need correcting.
+func FindTimestampAnomalies(issues []IssueTimestamps, now time.Time) []TimestampAnomaly {
+	var anomalies []TimestampAnomaly
+	for _, ts := range issues {
+		var problems []string
+		future := now.Add(TimestampFutureTolerance)
+		if ts.CreatedAt.After(future) {
+			problems = append(problems, "created_at in the future")
+		}
+		if ts.UpdatedAt.After(future) {
+			problems = append(problems, "updated_at in the future")
+		}
+		if ts.ClosedAt != nil && ts.ClosedAt.After(future) {
+			problems = append(problems, "closed_at in the future")
+		}
+		if ts.CreatedAt.Sub(ts.UpdatedAt) > timestampOrderTolerance {
+			problems = append(problems, "updated_at before created_at")
+		}
+		if ts.ClosedAt != nil {
+			if ts.CreatedAt.Sub(*ts.ClosedAt) > timestampOrderTolerance {
+				problems = append(problems, "closed_at before created_at")
+			}
+			if ts.ClosedAt.Sub(ts.UpdatedAt) > timestampOrderTolerance {
+				problems = append(problems, "closed_at after updated_at")
+			}
+		}
+		if len(problems) > 0 {
+			anomalies = append(anomalies, TimestampAnomaly{IssueTimestamps: ts, Problems: problems, Fixed: clampTimestamps(ts, now)})
+		}
+	}
+	return anomalies
+}
+
+// clampTimestamps pulls future values back to now, then restores the order
+// created <= closed <= updated: creation moves back to the earliest recorded
+// time, and closing counts as an update.
+func clampTimestamps(ts IssueTimestamps, now time.Time) IssueTimestamps {
+	out := IssueTimestamps{ID: ts.ID, CreatedAt: minTime(ts.CreatedAt, now), UpdatedAt: minTime(ts.UpdatedAt, now)}
+	if ts.ClosedAt != nil {
+		closed := minTime(*ts.ClosedAt, now)
+		out.ClosedAt = &closed
+		out.CreatedAt = minTime(out.CreatedAt, closed)
+		if closed.After(out.UpdatedAt) {
+			out.UpdatedAt = closed
+		}
+	}
+	out.CreatedAt = minTime(out.CreatedAt, out.UpdatedAt)
+	return out
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// describe renders timestamps for the event log.
+func (ts IssueTimestamps) describe() string {
+	parts := []string{
+		"created_at=" + ts.CreatedAt.UTC().Format(time.RFC3339),
+		"updated_at=" + ts.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if ts.ClosedAt != nil {
+		parts = append(parts, "closed_at="+ts.ClosedAt.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(parts, " ")
+}
+
+// TimestampSanity clamps future and inverted issue timestamps and records each
+// correction as an 'updated' event whose old and new values hold the
+// timestamps before and after, so the original values are not lost.
+func TimestampSanity(path string) error {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return err
+	}
+	db, err := openDoltDB(beadsDir)
+	if err != nil {
+		fmt.Printf("  Timestamp fix skipped (%v)\n", err)
+		return nil
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	anomalies, err := LoadTimestampAnomalies(ctx, db, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if len(anomalies) == 0 {
+		fmt.Println("  Issue timestamps already consistent — nothing to fix")
+		return nil
+	}
+
+	actor := detectActor()
+	// Explicit transaction so writes persist when @@autocommit is OFF.
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	for _, a := range anomalies {
+		// updated_at is set explicitly so ON UPDATE CURRENT_TIMESTAMP does not
+		// overwrite the corrected value.
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE issues SET created_at = ?, updated_at = ?, closed_at = ? WHERE id = ?`,
+			a.Fixed.CreatedAt, a.Fixed.UpdatedAt, a.Fixed.ClosedAt, a.ID); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to correct timestamps of %s: %w", a.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (id, issue_id, event_type, actor, old_value, new_value, comment)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			issueops.NewEventID(), a.ID, types.EventUpdated, actor, a.IssueTimestamps.describe(), a.Fixed.describe(),
+			"bd doctor: corrected clock-skewed timestamps ("+strings.Join(a.Problems, ", ")+")"); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to record timestamp correction for %s: %w", a.ID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit timestamp corrections: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "CALL DOLT_ADD(?, ?)", "issues", "events"); err != nil {
+		return fmt.Errorf("failed to stage timestamp corrections: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, "CALL DOLT_COMMIT('-m', 'doctor: correct clock-skewed issue timestamps')"); err != nil && !issueops.IsNothingToCommitError(err) {
+		return fmt.Errorf("failed to commit timestamp corrections to Dolt: %w", err)
+	}
+	fmt.Printf("  Corrected timestamps on %d issue(s)\n", len(anomalies))
+	return nil
+}
@@ -0,0 +1,78 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindTimestampAnomalies(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return now.Add(d) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name         string
+		ts           IssueTimestamps
+		wantProblems []string
+		want         IssueTimestamps
+	}{
+		{
+			name: "sane",
+			ts:   IssueTimestamps{ID: "bd-1", CreatedAt: at(-48 * time.Hour), UpdatedAt: at(-time.Hour), ClosedAt: ptr(at(-time.Hour))},
+		},
+		{
+			name: "within future tolerance",
+			ts:   IssueTimestamps{ID: "bd-2", CreatedAt: at(time.Minute), UpdatedAt: at(time.Minute)},
+		},
+		{
+			name:         "created in the future",
+			ts:           IssueTimestamps{ID: "bd-3", CreatedAt: at(72 * time.Hour), UpdatedAt: at(72 * time.Hour)},
+			wantProblems: []string{"created_at in the future", "updated_at in the future"},
+			want:         IssueTimestamps{ID: "bd-3", CreatedAt: now, UpdatedAt: now},
+		},
+		{
+			name:         "updated before created",
+			ts:           IssueTimestamps{ID: "bd-4", CreatedAt: at(-time.Hour), UpdatedAt: at(-5 * time.Hour)},
+			wantProblems: []string{"updated_at before created_at"},
+			want:         IssueTimestamps{ID: "bd-4", CreatedAt: at(-5 * time.Hour), UpdatedAt: at(-5 * time.Hour)},
+		},
+		{
+			name:         "closed in the future and after update",
+			ts:           IssueTimestamps{ID: "bd-5", CreatedAt: at(-time.Hour), UpdatedAt: at(-time.Hour), ClosedAt: ptr(at(24 * time.Hour))},
+			wantProblems: []string{"closed_at in the future", "closed_at after updated_at"},
+			want:         IssueTimestamps{ID: "bd-5", CreatedAt: at(-time.Hour), UpdatedAt: now, ClosedAt: ptr(now)},
+		},
+		{
+			name:         "closed before created",
+			ts:           IssueTimestamps{ID: "bd-6", CreatedAt: at(-time.Hour), UpdatedAt: at(-time.Hour), ClosedAt: ptr(at(-3 * time.Hour))},
+			wantProblems: []string{"closed_at before created_at"},
+			want:         IssueTimestamps{ID: "bd-6", CreatedAt: at(-3 * time.Hour), UpdatedAt: at(-time.Hour), ClosedAt: ptr(at(-3 * time.Hour))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anomalies := FindTimestampAnomalies([]IssueTimestamps{tt.ts}, now)
+			if len(tt.wantProblems) == 0 {
+				if len(anomalies) != 0 {
+					t.Fatalf("unexpected anomaly: %v", anomalies[0].Problems)
+				}
+				return
+			}
+			if len(anomalies) != 1 {
+				t.Fatalf("got %d anomalies, want 1", len(anomalies))
+			}
+			a := anomalies[0]
+			if strings.Join(a.Problems, "; ") != strings.Join(tt.wantProblems, "; ") {
+				t.Errorf("problems = %v, want %v", a.Problems, tt.wantProblems)
+			}
+			if a.Fixed.describe() != tt.want.describe() {
+				t.Errorf("fixed = %s, want %s", a.Fixed.describe(), tt.want.describe())
+			}
+			// The corrected values must themselves be clean.
+			if again := FindTimestampAnomalies([]IssueTimestamps{a.Fixed}, now); len(again) != 0 {
+				t.Errorf("corrected timestamps still anomalous: %v", again[0].Problems)
+			}
+		})
+	}
+}
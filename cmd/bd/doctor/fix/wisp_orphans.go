@@ -0,0 +1,111 @@
+package fix
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// wispAuxTables are the per-wisp side tables keyed by issue_id. Rows outlive
+// their wisp when it expires or is deleted on a schema without the cascading
+// foreign key (wisp_labels, wisp_events, and wisp_comments never had one).
+var wispAuxTables = []string{"wisp_dependencies", "wisp_labels", "wisp_events", "wisp_comments"}
+
+// WispOrphanCount is the number of orphaned rows in one wisp side table.
+type WispOrphanCount struct {
+	Table string
+	Rows  int
+}
+
+// wispOrphanWhere selects rows whose wisp no longer exists.
+const wispOrphanWhere = `NOT EXISTS (SELECT 1 FROM wisps w WHERE w.id = t.issue_id)`
+
+// CountOrphanedWisps counts rows in each wisp side table whose issue_id has no
+// matching wisp. Tables missing from the schema are skipped; only tables with
+// orphans appear in the result.
+func CountOrphanedWisps(ctx context.Context, db *sql.DB) ([]WispOrphanCount, error) {
+	if ok, err := depKeyColumnExists(ctx, db, "wisps", "id"); err != nil || !ok {
+		return nil, err
+	}
+	var out []WispOrphanCount
+	for _, table := range wispAuxTables {
+		hasIssueID, err := depKeyColumnExists(ctx, db, table, "issue_id")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", table, err)
+		}
+		if !hasIssueID {
+			continue
+		}
+		var n int
+		//nolint:gosec // G201: table is a hardcoded constant, never user input.
+		if err := db.QueryRowContext(ctx, fmt.Sprintf(
+			`SELECT COUNT(*) FROM %s t WHERE %s`, table, wispOrphanWhere)).Scan(&n); err != nil {
+			return nil, fmt.Errorf("%s: %w", table, err)
+		}
+		if n > 0 {
+			out = append(out, WispOrphanCount{Table: table, Rows: n})
+		}
+	}
+	return out, nil
+}
+
+// OrphanedWisps deletes wisp side-table rows whose wisp no longer exists, in a
+// single transaction, and prints how many rows were removed from each table.
+// The wisp tables are dolt_ignore'd, so there is nothing to Dolt-commit.
+func OrphanedWisps(path string) error {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDoltDB(beadsDir)
+	if err != nil {
+		fmt.Printf("  Orphaned wisp fix skipped (%v)\n", err)
+		return nil
+	}
+	defer db.Close()
+
+	return removeOrphanedWisps(context.Background(), db)
+}
+
+// removeOrphanedWisps does the work of OrphanedWisps on an open connection.
+func removeOrphanedWisps(ctx context.Context, db *sql.DB) error {
+	counts, err := CountOrphanedWisps(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to count orphaned wisp rows: %w", err)
+	}
+	if len(counts) == 0 {
+		fmt.Println("  No orphaned wisp rows to remove")
+		return nil
+	}
+
+	// Uses explicit transaction so writes persist when @@autocommit is OFF
+	// (e.g. Dolt server started with --no-auto-commit), and so a failure
+	// part-way leaves every table untouched.
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	removed := make([]WispOrphanCount, 0, len(counts))
+	for _, c := range counts {
+		//nolint:gosec // G201: table is a hardcoded constant, never user input.
+		res, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE t FROM %s t WHERE %s`, c.Table, wispOrphanWhere))
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to remove orphaned rows from %s: %w", c.Table, err)
+		}
+		n, _ := res.RowsAffected()
+		removed = append(removed, WispOrphanCount{Table: c.Table, Rows: int(n)})
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit orphaned wisp removals: %w", err)
+	}
+
+	total := 0
+	for _, r := range removed {
+		total += r.Rows
+		fmt.Printf("  Removed %d orphaned row(s) from %s\n", r.Rows, r.Table)
+	}
+	fmt.Printf("  Fixed %d orphaned wisp row(s)\n", total)
+	return nil
+}
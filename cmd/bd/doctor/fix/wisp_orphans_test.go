@@ -0,0 +1,101 @@
+//go:build cgo
+
+package fix
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/testutil"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TestOrphanedWisps_RemovesRowsOfMissingWisps leaves side-table rows behind
+// for a wisp that no longer exists and checks that only those rows are
+// counted and removed.
+func TestOrphanedWisps_RemovesRowsOfMissingWisps(t *testing.T) {
+	testutil.RequireDoltBinary(t)
+
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("create .beads: %v", err)
+	}
+	cfg := &configfile.Config{
+		Database: "dolt",
+		Backend:  configfile.BackendDolt,
+	}
+	if err := cfg.Save(beadsDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	// Unique database name: the test Dolt container may outlive a single run.
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatalf("rand: %v", err)
+	}
+	dbName := "fixwisporphans_" + hex.EncodeToString(buf)
+
+	ctx := context.Background()
+	store, err := dolt.New(ctx, &dolt.Config{
+		Path:            filepath.Join(beadsDir, "dolt"),
+		Database:        dbName,
+		CreateIfMissing: true,
+		MaxOpenConns:    1,
+	})
+	if err != nil {
+		t.Skipf("skipping: Dolt server not available: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "tst"); err != nil {
+		t.Fatalf("SetConfig(issue_prefix): %v", err)
+	}
+
+	wisp := &types.Issue{ID: "tst-wisp-live", Title: "live wisp", Priority: 2, Status: types.StatusOpen, IssueType: types.TypeTask, NoHistory: true}
+	if err := store.CreateIssue(ctx, wisp, "test"); err != nil {
+		t.Fatalf("CreateIssue(wisp): %v", err)
+	}
+
+	db := store.UnderlyingDB()
+	for _, stmt := range []string{
+		`INSERT INTO wisp_labels (issue_id, label) VALUES ('tst-wisp-live', 'keep')`,
+		`INSERT INTO wisp_labels (issue_id, label) VALUES ('tst-wisp-gone', 'stale')`,
+		`INSERT INTO wisp_comments (id, issue_id, author, text) VALUES (UUID(), 'tst-wisp-gone', 'test', 'left behind')`,
+		`INSERT INTO wisp_events (id, issue_id, event_type) VALUES (UUID(), 'tst-wisp-gone', 'created')`,
+		`INSERT INTO wisp_events (id, issue_id, event_type) VALUES (UUID(), 'tst-wisp-gone', 'closed')`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	counts, err := CountOrphanedWisps(ctx, db)
+	if err != nil {
+		t.Fatalf("CountOrphanedWisps: %v", err)
+	}
+	got := make(map[string]int)
+	for _, c := range counts {
+		got[c.Table] = c.Rows
+	}
+	if got["wisp_labels"] != 1 || got["wisp_comments"] != 1 || got["wisp_events"] != 2 || got["wisp_dependencies"] != 0 {
+		t.Fatalf("orphan counts = %v, want labels:1 comments:1 events:2", got)
+	}
+
+	if err := removeOrphanedWisps(ctx, db); err != nil {
+		t.Fatalf("removeOrphanedWisps: %v", err)
+	}
+	if counts, err := CountOrphanedWisps(ctx, db); err != nil || len(counts) != 0 {
+		t.Fatalf("after fix: counts = %v, err = %v; want none", counts, err)
+	}
+	var kept int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM wisp_labels WHERE issue_id = 'tst-wisp-live'`).Scan(&kept); err != nil || kept != 1 {
+		t.Errorf("live wisp label count = %d (err %v), want 1", kept, err)
+	}
+}
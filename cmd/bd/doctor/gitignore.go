@@ -62,6 +62,9 @@ dolt-server.lock
 dolt-server.port
 dolt-server.activity
 
+# Git hook timing log (written by bd hooks run)
+hooks.log
+
 # Debug-mode pprof artifacts (written when dolt.debug: true in config.yaml)
 dolt-pprof/
 
@@ -71,6 +74,9 @@ dolt-pprof/
 # Backup data (auto-exported JSONL, local-only)
 backup/
 
+# Webhook notification outbox, dead-letter log and scan state
+notify/
+
 # Per-project environment file (Dolt connection config, GH#2520)
 .env
 
@@ -120,6 +126,7 @@ var requiredPatterns = []string{
 	"dolt-server.lock",
 	"dolt-server.port",
 	"dolt-server.activity",
+	"hooks.log",
 	"daemon.*",
 	"*.lock",
 	"*.corrupt.backup/",
@@ -127,6 +134,7 @@ var requiredPatterns = []string{
 	"proxied_server_client_info.json",
 	".local_version",
 	"backup/",
+	"notify/",
 }
 
 // CheckGitignore checks if .beads/.gitignore is up to date.
@@ -0,0 +1,189 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/git"
+)
+
+// bdManagedHooks are the hooks 'bd hooks install' writes.
+var bdManagedHooks = []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "post-commit", "prepare-commit-msg"}
+
+// hookExportSlack is how long after the last recorded export a commit
+// touching .beads/ may land before it counts as having skipped the
+// pre-commit export. The hook exports before the commit message editor
+// opens, so this allows for slow commit messages.
+const hookExportSlack = 30 * time.Minute
+
+// CheckGitHookBypass looks for ways bd's git hooks are silently not running:
+// core.hooksPath pointing at a directory without bd hooks, bd hooks that
+// are not executable, and recent commits touching .beads/ made after the last
+// export even though the pre-commit hook exports (a sign of --no-verify).
+func CheckGitHookBypass(path string) DoctorCheck {
+	hooksDir, err := git.GetGitHooksDir()
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Git Hook Bypass",
+			Status:  StatusOK,
+			Message: "N/A (not a git repository)",
+		}
+	}
+
+	var problems, fixes []string
+
+	if hooksPath := gitConfigValue(path, "core.hooksPath"); hooksPath != "" && !dirHasBdHook(hooksDir) {
+		problems = append(problems, fmt.Sprintf("core.hooksPath is %s, which contains no bd hooks", hooksPath))
+		if commonDir, err := git.GetGitCommonDir(); err == nil && dirHasBdHook(filepath.Join(commonDir, "hooks")) {
+			problems[len(problems)-1] += " (the bd hooks in .git/hooks never run)"
+		}
+		fixes = append(fixes, "Run 'bd hooks install' to install bd hooks in "+hooksDir+", or 'git config --unset core.hooksPath' if the override is unintended")
+	}
+
+	if notExec := nonExecutableBdHooks(hooksDir); len(notExec) > 0 {
+		problems = append(problems, fmt.Sprintf("bd hook(s) not executable, so git skips them: %s", strings.Join(notExec, ", ")))
+		fixes = append(fixes, "Run 'bd doctor --fix' (or chmod +x the hooks in "+hooksDir+")")
+	}
+
+	if preCommitExportEnabled() {
+		if exportedAt, ok := lastExportTime(ResolveBeadsDirForRepo(path)); ok {
+			commits := commitsSinceExport(path, exportedAt.Add(hookExportSlack), gitConfigValue(path, "user.email"))
+			if len(commits) > 0 {
+				problems = append(problems, fmt.Sprintf("%d recent commit(s) touching .beads/ were made after the last export, suggesting the pre-commit hook was skipped (--no-verify): %s",
+					len(commits), strings.Join(commits, "; ")))
+				fixes = append(fixes, "Run 'bd export' and commit the result; avoid 'git commit --no-verify' for commits that touch .beads/")
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return DoctorCheck{
+			Name:    "Git Hook Bypass",
+			Status:  StatusOK,
+			Message: "bd hooks run on commit",
+		}
+	}
+	return DoctorCheck{
+		Name:    "Git Hook Bypass",
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d way(s) bd hooks are being bypassed", len(problems)),
+		Detail:  strings.Join(problems, "\n"),
+		Fix:     strings.Join(fixes, "\n"),
+	}
+}
+
+// gitConfigValue returns a git config value for the repository at path, or
+// "" when unset.
+func gitConfigValue(path, key string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), gitCmdTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "config", "--get", key) // #nosec G204 -- fixed git subcommand, key is a constant
+	cmd.Dir = path
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// dirHasBdHook reports whether any bd-managed hook in hooksDir is a bd hook.
+func dirHasBdHook(hooksDir string) bool {
+	for _, name := range bdManagedHooks {
+		// #nosec G304 -- fixed hook names inside the git hooks directory
+		if content, err := os.ReadFile(filepath.Join(hooksDir, name)); err == nil && isBdHookContent(string(content)) {
+			return true
+		}
+	}
+	return false
+}
+
+// nonExecutableBdHooks lists the bd hooks in hooksDir that lack an execute
+// bit. Git ignores such hooks without any message. Windows has no execute
+// bit, so nothing is reported there.
+func nonExecutableBdHooks(hooksDir string) []string {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	var names []string
+	for _, name := range bdManagedHooks {
+		hookPath := filepath.Join(hooksDir, name)
+		info, err := os.Stat(hookPath)
+		if err != nil || info.IsDir() || info.Mode()&0o111 != 0 {
+			continue
+		}
+		// #nosec G304 -- fixed hook names inside the git hooks directory
+		if content, err := os.ReadFile(hookPath); err == nil && isBdHookContent(string(content)) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// preCommitExportEnabled reports whether the pre-commit hook exports JSONL:
+// export.auto is on and hooks.pre-commit.export is not turned off.
+func preCommitExportEnabled() bool {
+	if !config.GetBool("export.auto") {
+		return false
+	}
+	return config.GetString("hooks.pre-commit.export") == "" || config.GetBool("hooks.pre-commit.export")
+}
+
+// lastExportTime reads when bd last exported, from .beads/export-state.json.
+func lastExportTime(beadsDir string) (time.Time, bool) {
+	// #nosec G304 -- fixed file name inside the .beads directory
+	data, err := os.ReadFile(filepath.Join(beadsDir, "export-state.json"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var state struct {
+		Timestamp time.Time `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil || state.Timestamp.IsZero() {
+		return time.Time{}, false
+	}
+	return state.Timestamp, true
+}
+
+// commitsSinceExport returns "<short-sha> <subject>" for recent commits by
+// committer email that touch .beads/ and were committed after since. Commits
+// by others are skipped: their exports happened on their machines.
+func commitsSinceExport(path string, since time.Time, email string) []string {
+	if email == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gitCmdTimeout)
+	defer cancel()
+	// #nosec G204 -- fixed git subcommand; email comes from git config
+	cmd := exec.CommandContext(ctx, "git", "log", "-n", "20", "-F", "--format=%h %ct %s", "--committer="+email, "--", ".beads")
+	cmd.Dir = path
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var commits []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 2 {
+			continue
+		}
+		ct, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || !time.Unix(ct, 0).After(since) {
+			continue
+		}
+		entry := fields[0]
+		if len(fields) == 3 {
+			entry += " " + fields[2]
+		}
+		commits = append(commits, entry)
+	}
+	return commits
+}
@@ -0,0 +1,90 @@
+package doctor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNonExecutableBdHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no execute bit on Windows")
+	}
+	hooksDir := t.TempDir()
+	write := func(name, content string, mode os.FileMode) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(hooksDir, name), []byte(content), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("pre-commit", "#!/bin/sh\n# bd-shim\nbd hooks run pre-commit \"$@\"\n", 0644)
+	write("post-merge", "#!/bin/sh\nbd hooks run post-merge \"$@\"\n", 0755)
+	write("pre-push", "#!/bin/sh\necho not ours\n", 0644)
+
+	got := nonExecutableBdHooks(hooksDir)
+	if len(got) != 1 || got[0] != "pre-commit" {
+		t.Errorf("nonExecutableBdHooks() = %v, want [pre-commit]", got)
+	}
+	if !dirHasBdHook(hooksDir) {
+		t.Error("dirHasBdHook() = false, want true")
+	}
+	if dirHasBdHook(t.TempDir()) {
+		t.Error("dirHasBdHook(empty) = true, want false")
+	}
+}
+
+func TestLastExportTime(t *testing.T) {
+	beadsDir := t.TempDir()
+	if _, ok := lastExportTime(beadsDir); ok {
+		t.Error("lastExportTime() without export-state.json reported ok")
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "export-state.json"),
+		[]byte(`{"last_dolt_commit":"abc","timestamp":"2026-03-01T10:00:00Z","issues":3}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := lastExportTime(beadsDir)
+	if !ok || !got.Equal(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("lastExportTime() = %v, %v", got, ok)
+	}
+}
+
+func TestCommitsSinceExport(t *testing.T) {
+	dir := setupGitRepo(t)
+	git := func(env []string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(file, msg, email string, when time.Time) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(msg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git(nil, "add", file)
+		date := when.Format(time.RFC3339)
+		git([]string{"GIT_COMMITTER_DATE=" + date, "GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_EMAIL=" + email},
+			"-c", "user.name=Test", "-c", "user.email="+email, "commit", "-q", "--no-verify", "-m", msg)
+	}
+
+	exported := time.Now().Add(-2 * time.Hour)
+	commit(".beads/config.yaml", "before export", "me@example.com", exported.Add(-time.Hour))
+	commit(".beads/config.yaml", "skipped hook", "me@example.com", exported.Add(time.Hour))
+	commit("README.md", "no beads change", "me@example.com", exported.Add(time.Hour))
+	commit(".beads/issues.jsonl", "from a teammate", "them@example.com", exported.Add(time.Hour))
+
+	got := commitsSinceExport(dir, exported.Add(hookExportSlack), "me@example.com")
+	if len(got) != 1 || !strings.HasSuffix(got[0], " skipped hook") {
+		t.Errorf("commitsSinceExport() = %v, want only the skipped-hook commit", got)
+	}
+	if got := commitsSinceExport(dir, exported, ""); got != nil {
+		t.Errorf("commitsSinceExport() without email = %v, want nil", got)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
 )
 
 const (
@@ -16,13 +18,21 @@ const (
 
 	hookMarkerBeginTag = "BEGIN BEADS INTEGRATION"
 	hookMarkerEndTag   = "END BEADS INTEGRATION"
+
+	// windowsHookShimMarker tags the <hook>.cmd companions bd writes on Windows.
+	windowsHookShimMarker = "bd-windows-shim"
 )
 
+// windowsHookExtensions are the companion script types Windows tooling runs
+// in place of an extensionless hook.
+var windowsHookExtensions = []string{".cmd", ".bat", ".ps1"}
+
 var managedHookNames = []string{
 	"pre-commit",
 	"post-merge",
 	"pre-push",
 	"post-checkout",
+	"post-commit",
 	"prepare-commit-msg",
 }
 
@@ -35,6 +45,10 @@ type HookMigrationHookPlan struct {
 	LegacyBDHook     bool   `json:"legacy_bd_hook"`
 	HasOldSidecar    bool   `json:"has_old_sidecar"`
 	HasBackupSidecar bool   `json:"has_backup_sidecar"`
+	WindowsScript    bool   `json:"windows_script,omitempty"`
+	WindowsCompanion string `json:"windows_companion,omitempty"`
+	WindowsBDShim    bool   `json:"windows_bd_shim,omitempty"`
+	ManagedBy        string `json:"managed_by,omitempty"`
 	State            string `json:"state"`
 	NeedsMigration   bool   `json:"needs_migration"`
 	SuggestedAction  string `json:"suggested_action,omitempty"`
@@ -47,6 +61,7 @@ type HookMigrationPlan struct {
 	RepoRoot            string                  `json:"repo_root,omitempty"`
 	HooksDir            string                  `json:"hooks_dir,omitempty"`
 	IsGitRepo           bool                    `json:"is_git_repo"`
+	HookManager         string                  `json:"hook_manager,omitempty"`
 	Hooks               []HookMigrationHookPlan `json:"hooks"`
 	TotalHooks          int                     `json:"total_hooks"`
 	NeedsMigrationCount int                     `json:"needs_migration_count"`
@@ -79,9 +94,10 @@ func PlanHookMigration(path string) (HookMigrationPlan, error) {
 	plan.IsGitRepo = true
 	plan.RepoRoot = repoRoot
 	plan.HooksDir = hooksDir
+	plan.HookManager = fix.HookManagerForHooksDir(repoRoot, hooksDir)
 
 	for _, hookName := range managedHookNames {
-		hook := inspectHookMigration(hooksDir, hookName)
+		hook := inspectHookMigration(hooksDir, hookName, plan.HookManager)
 		if hook.NeedsMigration {
 			plan.NeedsMigrationCount++
 		}
@@ -94,7 +110,9 @@ func PlanHookMigration(path string) (HookMigrationPlan, error) {
 	return plan, nil
 }
 
-func inspectHookMigration(hooksDir, hookName string) HookMigrationHookPlan {
+// inspectHookMigration classifies one hook. dirManager names the hook manager
+// that owns the whole hooks directory, if any.
+func inspectHookMigration(hooksDir, hookName, dirManager string) HookMigrationHookPlan {
 	hookPath := filepath.Join(hooksDir, hookName)
 	plan := HookMigrationHookPlan{
 		Name:             hookName,
@@ -103,6 +121,15 @@ func inspectHookMigration(hooksDir, hookName string) HookMigrationHookPlan {
 		HasBackupSidecar: fileExists(hookPath + ".backup"),
 		MarkerState:      hookMarkerStateNone,
 	}
+	for _, ext := range windowsHookExtensions {
+		// #nosec G304 -- path is derived from git hooks dir + known hook names
+		if companion, err := os.ReadFile(hookPath + ext); err == nil {
+			plan.WindowsCompanion = hookPath + ext
+			plan.WindowsBDShim = strings.Contains(string(companion), windowsHookShimMarker) ||
+				strings.Contains(string(companion), "bd hooks run")
+			break
+		}
+	}
 
 	content, err := os.ReadFile(hookPath) // #nosec G304 -- path is derived from git hooks dir + known hook names
 	if err == nil {
@@ -110,6 +137,11 @@ func inspectHookMigration(hooksDir, hookName string) HookMigrationHookPlan {
 		contentStr := string(content)
 		plan.MarkerState = detectHookMarkerState(contentStr)
 		plan.LegacyBDHook = isLegacyBDHook(contentStr)
+		plan.WindowsScript = isWindowsHookScript(contentStr)
+		plan.ManagedBy = dirManager
+		if plan.ManagedBy == "" {
+			plan.ManagedBy = fix.HookManagerForContent(contentStr)
+		}
 	} else if !errors.Is(err, os.ErrNotExist) {
 		plan.ReadError = err.Error()
 		plan.State = "read_error"
@@ -133,7 +165,29 @@ func classifyHookMigration(hook *HookMigrationHookPlan) {
 	case hookMarkerStateBroken:
 		hook.State = "marker_broken"
 		hook.NeedsMigration = true
-		hook.SuggestedAction = "Repair BEGIN/END marker mismatch, then rerun hook migration."
+		hook.SuggestedAction = "Rebuild the managed section from the template (bd hooks doctor --fix-broken-markers), preserving lines outside the markers."
+		return
+	}
+
+	if hook.Exists && hook.ManagedBy != "" && !hook.LegacyBDHook {
+		// The manager regenerates this file; a bd section injected here would
+		// be lost on its next install, so integrate through its config instead.
+		hook.State = "manager_owned"
+		hook.SuggestedAction = fmt.Sprintf("Generated by %s; run 'bd hooks install --manager' to add bd to its configuration.", hook.ManagedBy)
+		return
+	}
+
+	if hook.Exists && hook.WindowsScript {
+		// Git for Windows runs hooks through its bundled sh, so a batch or
+		// PowerShell body in the extensionless hook file never runs there.
+		hook.NeedsMigration = true
+		if hook.LegacyBDHook {
+			hook.State = "legacy_windows_script"
+			hook.SuggestedAction = "Replace the Windows script with a portable sh hook and a .cmd companion."
+		} else {
+			hook.State = "windows_custom_script"
+			hook.SuggestedAction = fmt.Sprintf("Rename the Windows script to %s.cmd, then rerun hook migration.", hook.Name)
+		}
 		return
 	}
 
@@ -170,6 +224,10 @@ func classifyHookMigration(hook *HookMigrationHookPlan) {
 			hook.State = "missing_with_backup_sidecar"
 			hook.NeedsMigration = true
 			hook.SuggestedAction = "Recreate hook from .backup sidecar and inject managed section."
+		case hook.WindowsBDShim:
+			hook.State = "missing_with_windows_companion"
+			hook.NeedsMigration = true
+			hook.SuggestedAction = "Create the portable sh hook next to the Windows companion and inject managed section."
 		default:
 			hook.State = "missing_no_artifacts"
 		}
@@ -208,7 +266,30 @@ func detectHookMarkerState(content string) string {
 func isLegacyBDHook(content string) bool {
 	return strings.Contains(content, "# bd-shim") ||
 		strings.Contains(content, "bd-hooks-version:") ||
-		strings.Contains(content, "# bd (beads)")
+		strings.Contains(content, "# bd (beads)") ||
+		(isWindowsHookScript(content) && strings.Contains(content, "bd hooks run"))
+}
+
+// isWindowsHookScript reports whether a hook file holds a cmd.exe batch or
+// PowerShell script rather than a POSIX shell script.
+func isWindowsHookScript(content string) bool {
+	content = strings.TrimPrefix(content, "\ufeff")
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#!") {
+			return false
+		}
+		for _, prefix := range []string{"@echo", "@rem", "rem ", "::", "#requires", "param(", "$erroractionpreference"} {
+			if strings.HasPrefix(line, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
 }
 
 // IsUnmodifiedLegacyHook returns true if content contains only known BD-managed
@@ -153,6 +153,87 @@ func TestPlanHookMigration_MissingHookWithBackupSidecar(t *testing.T) {
 	}
 }
 
+func TestPlanHookMigration_WindowsScripts(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupGitRepoInDir(t, tmpDir)
+	forceRepoHooksPath(t, tmpDir)
+
+	_, hooksDir, err := resolveGitHooksDir(tmpDir)
+	if err != nil {
+		t.Fatalf("resolveGitHooksDir failed: %v", err)
+	}
+
+	writeHookFile(t, filepath.Join(hooksDir, "pre-commit"), "@echo off\r\nbd hooks run pre-commit %*\r\n")
+	writeHookFile(t, filepath.Join(hooksDir, "pre-push"), "\ufeff@echo off\r\ncall lint.cmd\r\n")
+	writeHookFile(t, filepath.Join(hooksDir, "post-merge.cmd"), "@echo off\r\nrem bd-windows-shim: post-merge\r\nbd hooks run post-merge %*\r\n")
+
+	plan, err := PlanHookMigration(tmpDir)
+	if err != nil {
+		t.Fatalf("PlanHookMigration returned error: %v", err)
+	}
+
+	want := map[string]string{
+		"pre-commit": "legacy_windows_script",
+		"pre-push":   "windows_custom_script",
+		"post-merge": "missing_with_windows_companion",
+	}
+	for name, state := range want {
+		hook, ok := findHookPlan(plan, name)
+		if !ok {
+			t.Fatalf("%s hook not found in plan", name)
+		}
+		if hook.State != state || !hook.NeedsMigration {
+			t.Errorf("%s: state = %q (needs migration %v), want %q", name, hook.State, hook.NeedsMigration, state)
+		}
+	}
+	if hook, _ := findHookPlan(plan, "post-merge"); !hook.WindowsBDShim || filepath.Base(hook.WindowsCompanion) != "post-merge.cmd" {
+		t.Errorf("post-merge companion not detected: %+v", hook)
+	}
+}
+
+func TestPlanHookMigration_ManagerOwnedHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupGitRepoInDir(t, tmpDir)
+
+	// husky v9 points core.hooksPath at .husky/_ and regenerates it.
+	huskyHooks := filepath.Join(tmpDir, ".husky", "_")
+	writeHookFile(t, filepath.Join(huskyHooks, "pre-commit"), "#!/usr/bin/env sh\n. \"$(dirname \"$0\")/h\"\n")
+	writeHookFile(t, filepath.Join(huskyHooks, "pre-commit.old"), "#!/bin/sh\necho old\n")
+	cmd := exec.Command("git", "config", "core.hooksPath", ".husky/_")
+	cmd.Dir = tmpDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git config core.hooksPath: %v\n%s", err, out)
+	}
+
+	plan, err := PlanHookMigration(tmpDir)
+	if err != nil {
+		t.Fatalf("PlanHookMigration returned error: %v", err)
+	}
+	if plan.HookManager != "husky" {
+		t.Fatalf("expected hook manager husky, got %q", plan.HookManager)
+	}
+	hook, _ := findHookPlan(plan, "pre-commit")
+	if hook.State != "manager_owned" || hook.NeedsMigration {
+		t.Fatalf("expected manager_owned without migration, got %q (needs migration %v)", hook.State, hook.NeedsMigration)
+	}
+}
+
+func TestIsWindowsHookScript(t *testing.T) {
+	tests := map[string]bool{
+		"@echo off\r\nbd hooks run pre-commit %*\r\n": true,
+		"\r\n:: batch comment\r\n":                    true,
+		"#Requires -Version 5\nbd hooks run pre-push": true,
+		"#!/bin/sh\n@echo not really\n":               false,
+		"echo hello\n":                                false,
+		"":                                            false,
+	}
+	for content, want := range tests {
+		if got := isWindowsHookScript(content); got != want {
+			t.Errorf("isWindowsHookScript(%q) = %v, want %v", content, got, want)
+		}
+	}
+}
+
 func TestDetectHookMarkerState_DuplicateBeginTags(t *testing.T) {
 	content := "#!/bin/sh\n# --- BEGIN BEADS INTEGRATION v0.57.0 ---\n# --- BEGIN BEADS INTEGRATION v0.57.0 ---\nbd hook pre-commit \"$@\"\n# --- END BEADS INTEGRATION v0.57.0 ---\n"
 	got := detectHookMarkerState(content)
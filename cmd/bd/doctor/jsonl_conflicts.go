@@ -0,0 +1,49 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+)
+
+// CheckJSONLConflicts detects unresolved git conflict markers in
+// .beads/*.jsonl. Unlike CheckGitConflicts it scans the files in every backend
+// mode: with export.auto the JSONL export is git-tracked and a bad merge leaves
+// markers behind that break 'bd import' and the post-merge hook.
+func CheckJSONLConflicts(path string) DoctorCheck {
+	beadsDir := ResolveBeadsDirForRepo(path)
+	if _, err := os.Stat(beadsDir); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:    "JSONL Conflicts",
+			Status:  StatusOK,
+			Message: "N/A (no .beads directory)",
+		}
+	}
+
+	conflicted, err := fix.FindJSONLConflicts(beadsDir)
+	if err != nil {
+		return DoctorCheck{
+			Name:    "JSONL Conflicts",
+			Status:  StatusWarning,
+			Message: "Unable to scan JSONL files",
+			Detail:  err.Error(),
+		}
+	}
+	if len(conflicted) == 0 {
+		return DoctorCheck{
+			Name:    "JSONL Conflicts",
+			Status:  StatusOK,
+			Message: "No conflict markers in .beads/*.jsonl",
+		}
+	}
+
+	return DoctorCheck{
+		Name:    "JSONL Conflicts",
+		Status:  StatusError,
+		Message: fmt.Sprintf("Unresolved merge conflict markers in %d JSONL file(s)", len(conflicted)),
+		Detail:  strings.Join(conflicted, ", "),
+		Fix:     "Run 'bd doctor --fix' to re-merge with 'bd merge-file' (or regenerate the export from the database)",
+	}
+}
@@ -0,0 +1,58 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckJSONLConflicts(t *testing.T) {
+	t.Run("no beads dir", func(t *testing.T) {
+		result := CheckJSONLConflicts(t.TempDir())
+		if result.Status != StatusOK {
+			t.Errorf("expected OK for missing .beads dir, got %s: %s", result.Status, result.Message)
+		}
+	})
+
+	t.Run("clean jsonl", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		beadsDir := filepath.Join(tmpDir, ".beads")
+		if err := os.MkdirAll(beadsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(`{"id":"bd-1"}`+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		result := CheckJSONLConflicts(tmpDir)
+		if result.Status != StatusOK {
+			t.Errorf("expected OK for clean JSONL, got %s: %s", result.Status, result.Message)
+		}
+	})
+
+	t.Run("conflict markers detected", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		beadsDir := filepath.Join(tmpDir, ".beads")
+		if err := os.MkdirAll(beadsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "<<<<<<< HEAD\n{\"id\":\"bd-1\",\"title\":\"a\"}\n=======\n{\"id\":\"bd-1\",\"title\":\"b\"}\n>>>>>>> other\n"
+		if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(beadsDir, "routes.jsonl"), []byte(`{"prefix":"bd-"}`+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		result := CheckJSONLConflicts(tmpDir)
+		if result.Status != StatusError {
+			t.Fatalf("expected error, got %s: %s", result.Status, result.Message)
+		}
+		if result.Detail != "issues.jsonl" {
+			t.Errorf("detail = %q, want only issues.jsonl", result.Detail)
+		}
+		if result.Fix == "" {
+			t.Error("expected a Fix hint")
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+	"github.com/steveyegge/beads/internal/configfile"
+)
+
+// eventsTableWarnRows is the events row count above which the audit trail
+// itself becomes the main cost of history queries and the chunk store.
+const eventsTableWarnRows = 250000
+
+// perfQuery is one representative query timed by CheckPerformanceProfile.
+type perfQuery struct {
+	Name      string
+	SQL       string
+	Threshold time.Duration
+}
+
+// perfQueries mirror the hot paths of bd ready, bd search, and dependency
+// traversal (bd dep tree, blocked-by computation).
+var perfQueries = []perfQuery{
+	{
+		Name:      "ready",
+		SQL:       "SELECT id FROM issues WHERE status = 'open' AND is_blocked = 0 ORDER BY priority LIMIT 100",
+		Threshold: 200 * time.Millisecond,
+	},
+	{
+		Name:      "search",
+		SQL:       "SELECT id FROM issues WHERE title LIKE '%bd-doctor-probe%' OR description LIKE '%bd-doctor-probe%' LIMIT 50",
+		Threshold: 500 * time.Millisecond,
+	},
+	{
+		Name: "dependency traversal",
+		SQL: `WITH RECURSIVE deps (issue_id, depth) AS (
+			SELECT issue_id, 0 FROM (SELECT issue_id FROM dependencies LIMIT 20) seed
+			UNION ALL
+			SELECT d.depends_on_issue_id, deps.depth + 1 FROM dependencies d
+			JOIN deps ON d.issue_id = deps.issue_id
+			WHERE d.depends_on_issue_id IS NOT NULL AND deps.depth < 10
+		) SELECT COUNT(*) FROM deps`,
+		Threshold: 500 * time.Millisecond,
+	},
+}
+
+// perfSample is the measured latency of one perfQuery.
+type perfSample struct {
+	Query   perfQuery
+	Elapsed time.Duration
+	Err     error
+}
+
+// perfProfile is everything CheckPerformanceProfile measured.
+type perfProfile struct {
+	Samples        []perfSample
+	MissingIndexes []fix.PerformanceIndex
+	EventRows      int64
+}
+
+// CheckPerformanceProfile times representative queries against the database
+// and looks for schema problems that slow them down: missing indexes and an
+// oversized events table. 'bd doctor --fix' recreates missing indexes and runs
+// dolt gc.
+func CheckPerformanceProfile(path string) DoctorCheck {
+	backend, beadsDir := getBackendAndBeadsDir(path)
+	if backend != configfile.BackendDolt {
+		return DoctorCheck{
+			Name:     "Performance Profile",
+			Status:   StatusOK,
+			Message:  "N/A (non-Dolt backend)",
+			Category: CategoryPerformance,
+		}
+	}
+	db, _, err := openDoltDB(beadsDir)
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Performance Profile",
+			Status:   StatusOK,
+			Message:  "N/A (database unavailable)",
+			Category: CategoryPerformance,
+		}
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var profile perfProfile
+	for _, q := range perfQueries {
+		start := time.Now()
+		rows, err := db.QueryContext(ctx, q.SQL)
+		if err == nil {
+			for rows.Next() {
+			}
+			err = rows.Err()
+			_ = rows.Close()
+		}
+		profile.Samples = append(profile.Samples, perfSample{Query: q, Elapsed: time.Since(start), Err: err})
+	}
+	if missing, err := fix.MissingPerformanceIndexes(ctx, db); err == nil {
+		profile.MissingIndexes = missing
+	}
+	_ = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM events").Scan(&profile.EventRows)
+
+	return assessPerformanceProfile(profile)
+}
+
+// assessPerformanceProfile turns measurements into a check.
+func assessPerformanceProfile(p perfProfile) DoctorCheck {
+	check := DoctorCheck{Name: "Performance Profile", Category: CategoryPerformance}
+
+	var timings, problems, fixes []string
+	for _, s := range p.Samples {
+		if s.Err != nil {
+			problems = append(problems, fmt.Sprintf("%s query failed: %v", s.Query.Name, s.Err))
+			continue
+		}
+		timings = append(timings, fmt.Sprintf("%s %s", s.Query.Name, s.Elapsed.Round(time.Millisecond)))
+		if s.Elapsed > s.Query.Threshold {
+			problems = append(problems, fmt.Sprintf("%s query took %s (threshold %s)",
+				s.Query.Name, s.Elapsed.Round(time.Millisecond), s.Query.Threshold))
+		}
+	}
+
+	if len(p.MissingIndexes) > 0 {
+		for _, idx := range p.MissingIndexes {
+			problems = append(problems, fmt.Sprintf("missing index %s on %s (used by %s)", idx.Name, idx.Table, idx.Use))
+		}
+		fixes = append(fixes, "Run 'bd doctor --fix' to recreate missing indexes and run dolt gc")
+	}
+
+	if p.EventRows > eventsTableWarnRows {
+		problems = append(problems, fmt.Sprintf("events table has %d rows (threshold %d)", p.EventRows, eventsTableWarnRows))
+		fixes = append(fixes, "Run 'bd gc' to decay old events and compact history")
+	}
+
+	if len(problems) > 0 && len(fixes) == 0 {
+		fixes = append(fixes, "Run 'bd doctor --fix' to run dolt gc, or 'bd doctor --perf' for a detailed profile")
+	}
+
+	if len(problems) == 0 {
+		check.Status = StatusOK
+		check.Message = strings.Join(timings, ", ")
+		return check
+	}
+	check.Status = StatusWarning
+	check.Message = fmt.Sprintf("%d performance issue(s)", len(problems))
+	check.Detail = strings.Join(problems, "\n")
+	if len(timings) > 0 {
+		check.Detail += "\nTimings: " + strings.Join(timings, ", ")
+	}
+	check.Fix = strings.Join(fixes, "\n")
+	return check
+}
@@ -0,0 +1,46 @@
+package doctor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+)
+
+func TestAssessPerformanceProfile(t *testing.T) {
+	ready := perfQueries[0]
+	fast := []perfSample{{Query: ready, Elapsed: 5 * time.Millisecond}}
+
+	tests := []struct {
+		name       string
+		profile    perfProfile
+		wantStatus string
+		wantDetail string
+		wantFix    string
+	}{
+		{"healthy", perfProfile{Samples: fast, EventRows: 100}, StatusOK, "", ""},
+		{"slow query", perfProfile{Samples: []perfSample{{Query: ready, Elapsed: time.Second}}}, StatusWarning, "ready query took 1s", "dolt gc"},
+		{"failed query", perfProfile{Samples: []perfSample{{Query: ready, Err: errors.New("boom")}}}, StatusWarning, "ready query failed: boom", ""},
+		{"missing index", perfProfile{Samples: fast, MissingIndexes: fix.PerformanceIndexes[:1]}, StatusWarning, "missing index idx_issues_is_blocked", "recreate missing indexes"},
+		{"huge events table", perfProfile{Samples: fast, EventRows: eventsTableWarnRows + 1}, StatusWarning, "events table has", "bd gc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := assessPerformanceProfile(tt.profile)
+			if check.Status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s (detail %q)", check.Status, tt.wantStatus, check.Detail)
+			}
+			if check.Category != CategoryPerformance {
+				t.Errorf("category = %q, want %q", check.Category, CategoryPerformance)
+			}
+			if tt.wantDetail != "" && !strings.Contains(check.Detail, tt.wantDetail) {
+				t.Errorf("detail %q does not mention %q", check.Detail, tt.wantDetail)
+			}
+			if tt.wantFix != "" && !strings.Contains(check.Fix, tt.wantFix) {
+				t.Errorf("fix %q does not mention %q", check.Fix, tt.wantFix)
+			}
+		})
+	}
+}
@@ -10,7 +10,7 @@ import (
 )
 
 // CheckHooksQuick does a fast check for outdated git hooks.
-// Checks all beads hooks: pre-commit, post-merge, pre-push, post-checkout.
+// Checks all beads hooks: pre-commit, post-merge, pre-push, post-checkout, post-commit.
 // cliVersion is the current CLI version to compare against.
 func CheckHooksQuick(cliVersion string) string {
 	// Get hooks directory from common git dir (hooks are shared across worktrees)
@@ -25,7 +25,7 @@ func CheckHooksQuick(cliVersion string) string {
 	}
 
 	// Check all beads-managed hooks
-	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout"}
+	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "post-commit"}
 
 	var outdatedHooks []string
 	var oldestVersion string
@@ -0,0 +1,93 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+	"github.com/steveyegge/beads/internal/configfile"
+)
+
+// CheckTimestampSanity flags issues whose timestamps were written by a machine
+// with a wrong clock: created, updated, or closed in the future, or out of
+// order (updated or closed before created, closed after the last update).
+// Such issues sort wrongly and defeat stale-issue detection.
+func CheckTimestampSanity(path string) DoctorCheck {
+	backend, beadsDir := getBackendAndBeadsDir(path)
+	if backend != configfile.BackendDolt {
+		return DoctorCheck{
+			Name:     "Timestamp Sanity",
+			Status:   StatusOK,
+			Message:  "N/A (non-Dolt backend)",
+			Category: CategoryData,
+		}
+	}
+	db, _, err := openDoltDB(beadsDir)
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Timestamp Sanity",
+			Status:   StatusOK,
+			Message:  "N/A (database unavailable)",
+			Category: CategoryData,
+		}
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	anomalies, err := fix.LoadTimestampAnomalies(ctx, db, time.Now().UTC())
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Timestamp Sanity",
+			Status:   StatusWarning,
+			Message:  "Unable to check issue timestamps",
+			Detail:   err.Error(),
+			Category: CategoryData,
+		}
+	}
+	return timestampSanityResult(anomalies)
+}
+
+// timestampSanityResult turns the anomalies found into a check.
+func timestampSanityResult(anomalies []fix.TimestampAnomaly) DoctorCheck {
+	if len(anomalies) == 0 {
+		return DoctorCheck{
+			Name:     "Timestamp Sanity",
+			Status:   StatusOK,
+			Message:  "No future or out-of-order issue timestamps",
+			Category: CategoryData,
+		}
+	}
+
+	future := 0
+	var lines []string
+	for i, a := range anomalies {
+		for _, p := range a.Problems {
+			if strings.HasSuffix(p, "in the future") {
+				future++
+				break
+			}
+		}
+		if i < 10 {
+			lines = append(lines, fmt.Sprintf("%s: %s", a.ID, strings.Join(a.Problems, ", ")))
+		}
+	}
+	if len(anomalies) > 10 {
+		lines = append(lines, fmt.Sprintf("... and %d more", len(anomalies)-10))
+	}
+
+	detail := strings.Join(lines, "\n")
+	if future > 0 {
+		detail += fmt.Sprintf("\n%d issue(s) have future timestamps; check the clock on the machines that wrote them", future)
+	}
+	return DoctorCheck{
+		Name:     "Timestamp Sanity",
+		Status:   StatusWarning,
+		Message:  fmt.Sprintf("%d issue(s) with future or out-of-order timestamps", len(anomalies)),
+		Detail:   detail,
+		Fix:      "Run 'bd doctor --fix' to clamp the timestamps (each correction is recorded in the issue's history)",
+		Category: CategoryData,
+	}
+}
@@ -0,0 +1,30 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+)
+
+func TestTimestampSanityResult(t *testing.T) {
+	if check := timestampSanityResult(nil); check.Status != StatusOK {
+		t.Errorf("no anomalies: status = %s, want ok", check.Status)
+	}
+
+	check := timestampSanityResult([]fix.TimestampAnomaly{
+		{IssueTimestamps: fix.IssueTimestamps{ID: "bd-1"}, Problems: []string{"created_at in the future"}},
+		{IssueTimestamps: fix.IssueTimestamps{ID: "bd-2"}, Problems: []string{"updated_at before created_at"}},
+	})
+	if check.Status != StatusWarning {
+		t.Fatalf("status = %s, want warning", check.Status)
+	}
+	for _, want := range []string{"bd-1: created_at in the future", "bd-2: updated_at before created_at", "1 issue(s) have future timestamps"} {
+		if !strings.Contains(check.Detail, want) {
+			t.Errorf("detail %q does not mention %q", check.Detail, want)
+		}
+	}
+	if !strings.Contains(check.Fix, "bd doctor --fix") {
+		t.Errorf("fix = %q", check.Fix)
+	}
+}
@@ -30,6 +30,7 @@ var trackedRuntimePatterns = []string{
 	"dolt-server.log",
 	"dolt-server.lock",
 	"dolt-server.port",
+	"hooks.log",
 
 	// Socket files
 	"bd.sock",
@@ -62,6 +63,7 @@ var trackedRuntimeDirPrefixes = []string{
 	"dolt/",
 	"backup/",
 	"export-state/",
+	"notify/",
 }
 
 // sensitiveFileNames are filenames that indicate a security concern if
@@ -19,6 +19,7 @@ const (
 	CategoryPerformance = "Performance"
 	CategoryFederation  = "Federation"
 	CategoryDolt        = "Dolt Storage"
+	CategoryCustom      = "Custom Checks"
 )
 
 // CategoryOrder defines the display order for categories
@@ -33,6 +34,7 @@ var CategoryOrder = []string{
 	CategoryFederation,
 	CategoryMetadata,
 	CategoryMaintenance,
+	CategoryCustom,
 }
 
 // DoctorCheck represents a single diagnostic check result
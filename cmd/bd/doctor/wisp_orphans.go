@@ -0,0 +1,54 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+)
+
+// CheckOrphanedWispsWithStore counts rows in the wisp side tables
+// (wisp_dependencies, wisp_labels, wisp_events, wisp_comments) left behind by
+// wisps that expired or were deleted.
+func CheckOrphanedWispsWithStore(ss *SharedStore) DoctorCheck {
+	store := ss.Store()
+	if store == nil {
+		return DoctorCheck{
+			Name:    "Orphaned Wisps",
+			Status:  StatusOK,
+			Message: "N/A (no database)",
+		}
+	}
+
+	counts, err := fix.CountOrphanedWisps(context.Background(), store.UnderlyingDB())
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Orphaned Wisps",
+			Status:  StatusWarning,
+			Message: "Unable to scan wisp tables",
+			Detail:  err.Error(),
+		}
+	}
+	if len(counts) == 0 {
+		return DoctorCheck{
+			Name:    "Orphaned Wisps",
+			Status:  StatusOK,
+			Message: "No orphaned wisp rows",
+		}
+	}
+
+	total := 0
+	parts := make([]string, 0, len(counts))
+	for _, c := range counts {
+		total += c.Rows
+		parts = append(parts, fmt.Sprintf("%s: %d", c.Table, c.Rows))
+	}
+	return DoctorCheck{
+		Name:    "Orphaned Wisps",
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d row(s) reference wisps that no longer exist", total),
+		Detail:  strings.Join(parts, ", "),
+		Fix:     "Run 'bd doctor --fix' to delete them",
+	}
+}
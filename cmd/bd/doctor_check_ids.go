@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// doctorCheckIDOverrides pins the stable ID of checks whose display name no
+// longer derives it. Check IDs are a scripting contract (bd doctor --json,
+// bd doctor --check <id>): when renaming a check, add "New Name": "old_id"
+// here so existing scripts keep matching.
+var doctorCheckIDOverrides = map[string]string{}
+
+// doctorAutoFixChecks are the checks 'bd doctor --fix' repairs itself. Keep in
+// sync with the switch in applyFixList; checks handled there with only a
+// manual hint (Duplicate Issues, Git Conflicts, ...) are not listed.
+var doctorAutoFixChecks = map[string]bool{
+	"Metadata Config":           true,
+	"Gitignore":                 true,
+	"Project Gitignore":         true,
+	"Redirect Tracking":         true,
+	"Last-Touched Tracking":     true,
+	"Tracked Runtime Files":     true,
+	"Git Hooks":                 true,
+	"Git Hook Bypass":           true,
+	"Permissions":               true,
+	"Database":                  true,
+	"Database Integrity":        true,
+	"Schema Compatibility":      true,
+	"Repo Fingerprint":          true,
+	"Database Config":           true,
+	"Cross-Table Duplicates":    true,
+	"Orphaned Dependencies":     true,
+	"Dependency Graph":          true,
+	"Dolt Remote Migration":     true,
+	"Orphaned Wisps":            true,
+	"Dependency Keys":           true,
+	"Blocked State":             true,
+	"Child-Parent Dependencies": true,
+	"JSONL Conflicts":           true,
+	"Export Freshness":          true,
+	"Stale Closed Issues":       true,
+	"Timestamp Sanity":          true,
+	"Performance Profile":       true,
+	"Legacy MQ Files":           true,
+	"Patrol Pollution":          true,
+	"Lock Files":                true,
+	"Circuit Breaker":           true,
+	"Fresh Clone":               true,
+	"Pending Migrations":        true,
+	"Config Values":             true,
+	"Classic Artifacts":         true,
+	"Btrfs NoCOW (dolt)":        true,
+	"Project Identity":          true,
+	"Dolt Schema":               true,
+	"Dolt Format":               true,
+	"Corrupt Manifest":          true,
+}
+
+// doctorCheckID returns the stable snake_case ID for a check display name:
+// "Git Hooks" → "git_hooks", "Btrfs NoCOW (dolt)" → "btrfs_nocow_dolt".
+// Applied to an ID or a slug (git-hooks) it returns the ID, so callers can
+// normalize user input with it.
+func doctorCheckID(name string) string {
+	if id, ok := doctorCheckIDOverrides[name]; ok {
+		return id
+	}
+	var b strings.Builder
+	pendingSep := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingSep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			pendingSep = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingSep = true
+	}
+	return b.String()
+}
+
+// doctorCheckSeverity maps a check status onto the severity scale used by
+// --agent output: blocking, degraded, or advisory; passing checks are none.
+func doctorCheckSeverity(status string) string {
+	switch status {
+	case statusError:
+		return "blocking"
+	case statusWarning:
+		return "degraded"
+	case statusOK:
+		return "none"
+	default:
+		return "advisory"
+	}
+}
+
+// annotateDoctorChecks fills in the machine-readable fields of every check:
+// stable ID, severity, and whether --fix can repair it.
+func annotateDoctorChecks(checks []doctorCheck) {
+	for i := range checks {
+		c := &checks[i]
+		c.ID = doctorCheckID(c.Name)
+		c.Severity = doctorCheckSeverity(c.Status)
+		c.Fixable = c.Status != statusOK && doctorAutoFixChecks[c.Name]
+	}
+}
+
+// selectDoctorCheck narrows result to the checks with the given ID and
+// recomputes OverallOK for them. It reports false when no check matches.
+func selectDoctorCheck(result *doctorResult, id string) bool {
+	id = doctorCheckID(id)
+	var selected []doctorCheck
+	for _, c := range result.Checks {
+		if doctorCheckID(c.Name) == id {
+			selected = append(selected, c)
+		}
+	}
+	if len(selected) == 0 {
+		return false
+	}
+	result.Checks = selected
+	result.OverallOK = true
+	for _, c := range selected {
+		if c.Status == statusError {
+			result.OverallOK = false
+		}
+	}
+	return true
+}
+
+// runSingleDoctorCheck runs the diagnostics and reports only the check with
+// the given ID (bd doctor --check <id>).
+func runSingleDoctorCheck(path, id string) error {
+	if isEmbeddedMode() {
+		printEmbeddedUnsupported("doctor --check=" + id)
+		return nil
+	}
+	result := runDiagnostics(path)
+	if !selectDoctorCheck(&result, id) {
+		ids := make([]string, 0, len(result.Checks))
+		for _, c := range result.Checks {
+			ids = append(ids, doctorCheckID(c.Name))
+		}
+		return HandleErrorWithHint(fmt.Sprintf("unknown check %q", id),
+			"Available checks: artifacts, conventions, pollution, validate, or a check ID: "+strings.Join(ids, ", "))
+	}
+	annotateDoctorChecks(result.Checks)
+
+	if jsonOutput {
+		if err := outputJSON(result); err != nil {
+			return err
+		}
+	} else {
+		for _, c := range result.Checks {
+			fmt.Printf("%s %s (%s): %s\n", doctorWatchIcon(c.Status), c.Name, c.ID, c.Message)
+			if c.Detail != "" {
+				fmt.Printf("  %s\n", c.Detail)
+			}
+			if c.Fix != "" && c.Status != statusOK {
+				fmt.Printf("  Fix: %s\n", c.Fix)
+			}
+		}
+	}
+	if !result.OverallOK {
+		return SilentExit()
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDoctorCheckID(t *testing.T) {
+	// These IDs are a scripting contract; changing one breaks
+	// `bd doctor --check <id>` and JSON consumers.
+	cases := map[string]string{
+		"Git Hooks":                 "git_hooks",
+		"Btrfs NoCOW (dolt)":        "btrfs_nocow_dolt",
+		"Last-Touched Tracking":     "last_touched_tracking",
+		"bd prime Output":           "bd_prime_output",
+		"conventions.lint":          "conventions_lint",
+		"Child-Parent Dependencies": "child_parent_dependencies",
+		"git-hooks":                 "git_hooks",
+		"git_hooks":                 "git_hooks",
+	}
+	for name, want := range cases {
+		if got := doctorCheckID(name); got != want {
+			t.Errorf("doctorCheckID(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestAnnotateDoctorChecks(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "Git Hooks", Status: statusWarning, Fix: "bd hooks install"},
+		{Name: "Duplicate Issues", Status: statusWarning, Fix: "bd duplicates"},
+		{Name: "Lock Files", Status: statusOK},
+		{Name: "Dependency Cycles", Status: statusError},
+	}
+	annotateDoctorChecks(checks)
+
+	if c := checks[0]; c.ID != "git_hooks" || c.Severity != "degraded" || !c.Fixable {
+		t.Errorf("Git Hooks annotated as %+v", c)
+	}
+	if checks[1].Fixable {
+		t.Error("Duplicate Issues has no automatic fix and must not be fixable")
+	}
+	if c := checks[2]; c.Severity != "none" || c.Fixable {
+		t.Errorf("passing check annotated as %+v", c)
+	}
+	if checks[3].Severity != "blocking" {
+		t.Errorf("error check severity = %q, want blocking", checks[3].Severity)
+	}
+
+	data, err := json.Marshal(checks[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{`"id":"git_hooks"`, `"severity":"degraded"`, `"fixable":true`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("JSON %s missing %s", data, key)
+		}
+	}
+}
+
+func TestSelectDoctorCheck(t *testing.T) {
+	result := doctorResult{
+		OverallOK: false,
+		Checks: []doctorCheck{
+			{Name: "Git Hooks", Status: statusOK},
+			{Name: "Dependency Cycles", Status: statusError},
+		},
+	}
+	if selectDoctorCheck(&result, "no_such_check") {
+		t.Fatal("unknown ID should not match")
+	}
+	if !selectDoctorCheck(&result, "git-hooks") {
+		t.Fatal("slug form should select git_hooks")
+	}
+	if len(result.Checks) != 1 || !result.OverallOK {
+		t.Errorf("selection should keep one passing check, got %+v", result)
+	}
+}
+
+// TestDoctorAutoFixChecksHaveFixCase keeps doctorAutoFixChecks in step with
+// the dispatch in applyFixList.
+func TestDoctorAutoFixChecksHaveFixCase(t *testing.T) {
+	src, err := os.ReadFile("doctor_fix.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range doctorAutoFixChecks {
+		if !strings.Contains(string(src), `case "`+name+`":`) {
+			t.Errorf("%q is marked fixable but applyFixList has no case for it", name)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import "fmt"
+
+// Exit codes of 'bd doctor --fail-on', for CI pipelines and pre-push hooks.
+const (
+	doctorExitHealthy         = 0
+	doctorExitWarnings        = 1
+	doctorExitErrors          = 2
+	doctorExitFixVerifyFailed = 3
+)
+
+// --fail-on thresholds.
+const (
+	doctorFailOnWarning = "warning"
+	doctorFailOnError   = "error"
+)
+
+// validateDoctorFailOn rejects --fail-on values other than "", warning, error.
+func validateDoctorFailOn(failOn string) error {
+	switch failOn {
+	case "", doctorFailOnWarning, doctorFailOnError:
+		return nil
+	}
+	return fmt.Errorf("invalid --fail-on %q (must be 'warning' or 'error')", failOn)
+}
+
+// doctorExitCode maps the final check results onto the --fail-on exit codes:
+// 2 when any check errors, 1 when any warns and failOn is warning, else 0.
+// When fixes were applied and the verification run still fails, the result
+// is 3 so CI can tell "fix did not stick" from "never fixed".
+func doctorExitCode(checks []doctorCheck, failOn string, fixesApplied int) int {
+	code := doctorExitHealthy
+	for _, c := range checks {
+		switch c.Status {
+		case statusError:
+			code = doctorExitErrors
+		case statusWarning:
+			if failOn == doctorFailOnWarning && code < doctorExitWarnings {
+				code = doctorExitWarnings
+			}
+		}
+	}
+	if code != doctorExitHealthy && fixesApplied > 0 {
+		return doctorExitFixVerifyFailed
+	}
+	return code
+}
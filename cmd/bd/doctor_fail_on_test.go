@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestDoctorExitCode(t *testing.T) {
+	ok := doctorCheck{Name: "A", Status: statusOK}
+	warn := doctorCheck{Name: "B", Status: statusWarning}
+	fail := doctorCheck{Name: "C", Status: statusError}
+
+	tests := []struct {
+		name    string
+		checks  []doctorCheck
+		failOn  string
+		applied int
+		want    int
+	}{
+		{"healthy", []doctorCheck{ok}, doctorFailOnWarning, 0, doctorExitHealthy},
+		{"warning gated", []doctorCheck{ok, warn}, doctorFailOnWarning, 0, doctorExitWarnings},
+		{"warning tolerated", []doctorCheck{ok, warn}, doctorFailOnError, 0, doctorExitHealthy},
+		{"error beats warning", []doctorCheck{warn, fail, warn}, doctorFailOnWarning, 0, doctorExitErrors},
+		{"error with fail-on error", []doctorCheck{fail}, doctorFailOnError, 0, doctorExitErrors},
+		{"fix did not verify", []doctorCheck{fail}, doctorFailOnError, 2, doctorExitFixVerifyFailed},
+		{"fix verified", []doctorCheck{ok, warn}, doctorFailOnError, 2, doctorExitHealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := doctorExitCode(tt.checks, tt.failOn, tt.applied); got != tt.want {
+				t.Errorf("doctorExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDoctorFailOn(t *testing.T) {
+	for _, v := range []string{"", "warning", "error"} {
+		if err := validateDoctorFailOn(v); err != nil {
+			t.Errorf("validateDoctorFailOn(%q) = %v", v, err)
+		}
+	}
+	if err := validateDoctorFailOn("warn"); err == nil {
+		t.Error("validateDoctorFailOn(\"warn\") = nil, want error")
+	}
+}
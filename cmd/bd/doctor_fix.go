@@ -53,7 +53,9 @@ func previewFixes(result doctorResult) {
 	fmt.Println("Run 'bd doctor --fix' to apply these fixes")
 }
 
-func applyFixes(result doctorResult) {
+// applyFixes offers the fixable issues for repair and returns how many fixes
+// were applied successfully.
+func applyFixes(result doctorResult) int {
 	// Collect all fixable issues
 	var fixableIssues []doctorCheck
 	for _, check := range result.Checks {
@@ -64,7 +66,7 @@ func applyFixes(result doctorResult) {
 
 	if len(fixableIssues) == 0 {
 		fmt.Println("\nNo fixable issues found.")
-		return
+		return 0
 	}
 
 	// Show what will be fixed
@@ -75,49 +77,59 @@ func applyFixes(result doctorResult) {
 
 	// Interactive mode - confirm each fix individually
 	if doctorInteractive {
-		applyFixesInteractive(result.Path, fixableIssues)
-		return
+		return applyFixesInteractive(result.Path, fixableIssues)
 	}
 
-	// Ask for confirmation (skip if --yes flag is set or stdin is non-interactive)
-	if !doctorYes {
-		// Detect non-interactive stdin (e.g., piped input in CI/automation)
-		isInteractive := term.IsTerminal(int(os.Stdin.Fd()))
-		if !isInteractive {
-			// In non-interactive mode without --yes, skip with helpful message
-			fmt.Fprintf(os.Stderr, "\n%s Running in non-interactive mode\n", ui.RenderWarn("⚠"))
-			fmt.Fprintf(os.Stderr, "  To auto-fix issues without prompting, use: %s\n\n", ui.RenderAccent("bd doctor --fix --yes"))
-			return
-		}
-
-		fmt.Printf("\nThis will attempt to fix %d issue(s). Continue? (Y/n): ", len(fixableIssues))
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-			return
-		}
-
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "" && response != "y" && response != "yes" {
-			fmt.Println("Fix canceled.")
-			return
-		}
+	if !confirmDoctorFixes(len(fixableIssues)) {
+		return 0
 	}
 
 	// Apply fixes
 	fmt.Println("\nApplying fixes...")
-	applyFixList(result.Path, fixableIssues)
+	return applyFixList(result.Path, fixableIssues)
 }
 
-// applyFixesInteractive prompts for each fix individually
-func applyFixesInteractive(path string, issues []doctorCheck) {
+// confirmDoctorFixes asks once before applying n fixes. It returns true
+// without asking under --yes, and false without a terminal to ask on.
+func confirmDoctorFixes(n int) bool {
+	if doctorYes {
+		return true
+	}
+	// Detect non-interactive stdin (e.g., piped input in CI/automation)
+	isInteractive := term.IsTerminal(int(os.Stdin.Fd()))
+	if !isInteractive {
+		// In non-interactive mode without --yes, skip with helpful message
+		fmt.Fprintf(os.Stderr, "\n%s Running in non-interactive mode\n", ui.RenderWarn("⚠"))
+		fmt.Fprintf(os.Stderr, "  To auto-fix issues without prompting, use: %s\n\n", ui.RenderAccent("bd doctor --fix --yes"))
+		return false
+	}
+
+	fmt.Printf("\nThis will attempt to fix %d issue(s). Continue? (Y/n): ", n)
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "" && response != "y" && response != "yes" {
+		fmt.Println("Fix canceled.")
+		return false
+	}
+
+	return true
+}
+
+// applyFixesInteractive prompts for each fix individually and returns how many
+// fixes were applied successfully.
+func applyFixesInteractive(path string, issues []doctorCheck) int {
 	// Detect non-interactive stdin before attempting to prompt
 	isInteractive := term.IsTerminal(int(os.Stdin.Fd()))
 	if !isInteractive {
 		fmt.Fprintf(os.Stderr, "\n%s Interactive mode requires a terminal\n", ui.RenderWarn("⚠"))
 		fmt.Fprintf(os.Stderr, "  Use 'bd doctor --fix --yes' for non-interactive mode\n\n")
-		return
+		return 0
 	}
 
 	reader := bufio.NewReader(os.Stdin)
@@ -159,9 +171,9 @@ func applyFixesInteractive(path string, issues []doctorCheck) {
 			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
 			if len(approvedFixes) > 0 {
 				fmt.Printf("\nApplying %d previously approved fix(es) before exit...\n", len(approvedFixes))
-				applyFixList(path, approvedFixes)
+				return applyFixList(path, approvedFixes)
 			}
-			return
+			return 0
 		}
 
 		response = strings.TrimSpace(strings.ToLower(response))
@@ -179,11 +191,10 @@ func applyFixesInteractive(path string, issues []doctorCheck) {
 			fmt.Println("  → Quit")
 			if len(approvedFixes) > 0 {
 				fmt.Printf("\nApplying %d approved fix(es)...\n", len(approvedFixes))
-				applyFixList(path, approvedFixes)
-			} else {
-				fmt.Println("\nNo fixes applied.")
+				return applyFixList(path, approvedFixes)
 			}
-			return
+			fmt.Println("\nNo fixes applied.")
+			return 0
 		default:
 			// Treat unknown input as skip
 			fmt.Println("  → Skipped (unrecognized input)")
@@ -194,10 +205,10 @@ func applyFixesInteractive(path string, issues []doctorCheck) {
 	// Apply all approved fixes
 	if len(approvedFixes) > 0 {
 		fmt.Printf("\nApplying %d approved fix(es)...\n", len(approvedFixes))
-		applyFixList(path, approvedFixes)
-	} else {
-		fmt.Println("\nNo fixes approved.")
+		return applyFixList(path, approvedFixes)
 	}
+	fmt.Println("\nNo fixes approved.")
+	return 0
 }
 
 // orderDoctorFixes sorts doctor fixes in place into a dependency-aware apply
@@ -229,11 +240,12 @@ func orderDoctorFixes(fixes []doctorCheck) {
 		priority[name] = i
 	}
 	// "Blocked State" recomputes is_blocked from the dependency graph, so it must
-	// run after every graph-mutating fix (Dependency Keys, Orphaned/Child-Parent
-	// Dependencies, Cross-Table Duplicates). Those are all unlisted and share the
-	// default priority below, and their relative order would otherwise be decided
-	// by check-append order alone. Pin Blocked State to an explicit terminal
-	// priority so it is provably last regardless of append order (bd-6dnrw.37).
+	// run after every graph-mutating fix (Dependency Keys, Dependency Graph,
+	// Orphaned/Child-Parent Dependencies, Cross-Table Duplicates). Those are all
+	// unlisted and share the default priority below, and their relative order
+	// would otherwise be decided by check-append order alone. Pin Blocked State
+	// to an explicit terminal priority so it is provably last regardless of
+	// append order (bd-6dnrw.37).
 	const defaultPriority = 1000
 	priority["Blocked State"] = defaultPriority + 1
 	slices.SortStableFunc(fixes, func(a, b doctorCheck) int {
@@ -255,161 +267,21 @@ func orderDoctorFixes(fixes []doctorCheck) {
 	})
 }
 
-// applyFixList applies a list of fixes and reports results
-func applyFixList(path string, fixes []doctorCheck) {
+// applyFixList applies a list of fixes, reports results, and returns how many
+// fixes succeeded.
+func applyFixList(path string, fixes []doctorCheck) int {
 	orderDoctorFixes(fixes)
 
 	fixedCount := 0
 	errorCount := 0
+	var changes []string
 
 	for _, check := range fixes {
 		fmt.Printf("\nFixing %s...\n", check.Name)
 
-		var err error
-		switch check.Name {
-		case "Metadata Config":
-			err = fix.FixMissingMetadataJSON(path)
-		case "Gitignore":
-			err = doctor.FixGitignore(path)
-		case "Project Gitignore":
-			// Stealth / no-git-ops repos must not get a tracked .gitignore; route the patterns into
-			// .git/info/exclude instead (matches bd init --stealth) and strip any beads section a
-			// previous run leaked into the tracked .gitignore so stealth leaves no trace.
-			if isStealthRepo(path) {
-				if err = addProjectPatternsToGitExclude(path, doctor.ProjectGitignorePatterns, false); err == nil {
-					_, err = removeBeadsProjectGitignoreSection(path)
-				}
-			} else {
-				err = doctor.FixProjectGitignore(path)
-			}
-		case "Redirect Tracking":
-			err = doctor.FixRedirectTracking(path)
-		case "Last-Touched Tracking":
-			err = doctor.FixLastTouchedTracking(path)
-		case "Tracked Runtime Files":
-			err = doctor.FixTrackedRuntimeFiles(path)
-		case "Git Hooks":
-			err = fix.GitHooks(path)
-		case "Sync Divergence":
-			fmt.Printf("  ⚠ Sync divergence fix removed (Dolt-native sync)\n")
-			continue
-		case "Permissions":
-			err = fix.Permissions(path)
-		case "Database":
-			err = fix.DatabaseVersionWithBdVersion(path, Version)
-			// Also repair any other missing metadata fields (bd_version, repo_id, clone_id)
-			if mErr := fix.FixMissingMetadata(path, Version); mErr != nil && err == nil {
-				err = mErr
-			}
-		case "Database Integrity":
-			// Corruption detected - backup and reinitialize
-			err = fix.DatabaseIntegrity(path)
-		case "Schema Compatibility":
-			err = fix.SchemaCompatibility(path)
-		case "Repo Fingerprint":
-			err = fix.RepoFingerprint(path, doctorYes)
-			// Also repair any other missing metadata fields (bd_version, repo_id, clone_id)
-			if mErr := fix.FixMissingMetadata(path, Version); mErr != nil && err == nil {
-				err = mErr
-			}
-		case "Database Config":
-			err = fix.DatabaseConfig(path)
-		case "JSONL Config":
-			fmt.Printf("  ⚠ JSONL config migration removed (Dolt-native sync)\n")
-			continue
-		case "Untracked Files":
-			fmt.Printf("  ⚠ Untracked JSONL fix removed (Dolt-native storage)\n")
-			continue
-		case "Cross-Table Duplicates":
-			err = fix.CrossTableDuplicates(path, doctorVerbose)
-		case "Orphaned Dependencies":
-			err = fix.OrphanedDependencies(path, doctorVerbose)
-		case "Dependency Keys":
-			err = fix.DependencyKeys(path, doctorVerbose)
-		case "Blocked State":
-			// bd-6dnrw.37: full is_blocked recompute. Pinned to a terminal
-			// priority in the sort above so it runs after every graph-mutating
-			// fix, recomputing from the corrected graph.
-			err = fix.RecomputeBlocked(path)
-		case "Child-Parent Dependencies":
-			// Requires explicit opt-in flag (destructive, may remove intentional deps)
-			if !doctorFixChildParent {
-				fmt.Printf("  ⚠ Child→parent deps require explicit opt-in: bd doctor --fix --fix-child-parent\n")
-				continue
-			}
-			err = fix.ChildParentDependencies(path, doctorVerbose)
-		case "Duplicate Issues":
-			// No auto-fix: duplicates require user review
-			fmt.Printf("  ⚠ Run 'bd duplicates' to review and merge duplicates\n")
-			continue
-		case "Test Pollution":
-			// No auto-fix: test cleanup requires user review
-			fmt.Printf("  ⚠ Run 'bd doctor --check=pollution' to review and clean test issues\n")
-			continue
-		case "Git Conflicts":
-			// No auto-fix: git conflicts require manual resolution
-			fmt.Printf("  ⚠ Resolve conflicts manually\n")
-			continue
-		case "Stale Closed Issues":
-			// consolidate cleanup into doctor --fix
-			err = fix.StaleClosedIssues(path)
-		case "Compaction Candidates":
-			// No auto-fix: compaction requires agent review
-			fmt.Printf("  ⚠ Run 'bd compact --analyze' to review candidates\n")
-			continue
-		case "Large Database":
-			// No auto-fix: pruning deletes data, must be user-controlled
-			fmt.Printf("  ⚠ Run 'bd cleanup --older-than 90' to prune old closed issues\n")
-			continue
-		case "Legacy MQ Files":
-			err = doctor.FixStaleMQFiles(path)
-		case "Patrol Pollution":
-			err = fix.PatrolPollution(path)
-		case "Lock Files":
-			err = fix.StaleLockFiles(path)
-		case "Circuit Breaker":
-			dolt.CleanStaleCircuitBreakerFiles()
-			fmt.Printf("  %s Cleared stale circuit breaker files\n", ui.RenderPass("✓"))
-		case "Fresh Clone":
-			err = fix.FreshCloneImport(path, Version)
-		case "Pending Migrations":
-			err = fixPendingMigrations(path)
-		case "Config Values":
-			err = fix.ConfigValues(path)
-		case "Classic Artifacts":
-			err = fix.ClassicArtifacts(path)
-		case "Btrfs NoCOW (dolt)":
-			// Applies FS_NOCOW_FL to .beads/ and any existing dolt data
-			// subdirs. Prints the returned message (which includes the
-			// "relocate existing files" warning) so the user sees why the
-			// fix is incomplete on its own.
-			var msg string
-			msg, err = doctor.FixBtrfsNoCOW(path)
-			if err == nil && msg != "" {
-				fmt.Print(msg)
-				if !strings.HasSuffix(msg, "\n") {
-					fmt.Println()
-				}
-			}
-		case "Project Identity":
-			err = fix.FixProjectIdentity(path)
-		case "Dolt Schema":
-			// GH#2160: Pre-#2142 migrations may have wrong database configured.
-			// Probe the server and backfill dolt_database in metadata.json.
-			err = fix.FixMissingDoltDatabase(path)
-		case "Dolt Format":
-			err = fix.DoltFormat(path)
-		case "Corrupt Manifest":
-			// GH#3290 / bd-6dnrw.6: destructive backup+reinit, gated here so it
-			// only ever runs on explicit doctor --fix confirmation.
-			var backups []string
-			backups, err = doltserver.RecoverCorruptManifest(doctor.ResolveBeadsDirForRepo(path))
-			for _, b := range backups {
-				fmt.Printf("  Backed up corrupt dolt database to %s and reinitialized\n", b)
-			}
-		default:
-			fmt.Printf("  ⚠ No automatic fix available for %s\n", check.Name)
-			fmt.Printf("  Manual fix: %s\n", check.Fix)
+		fixChanges, handled, err := applyDoctorFix(path, check)
+		changes = append(changes, fixChanges...)
+		if !handled {
 			continue
 		}
 
@@ -425,9 +297,188 @@ func applyFixList(path string, fixes []doctorCheck) {
 
 	// Summary
 	fmt.Printf("\nFix summary: %d fixed, %d errors\n", fixedCount, errorCount)
+	for _, c := range changes {
+		fmt.Printf("  - %s\n", c)
+	}
 	if errorCount > 0 {
 		fmt.Println("\nSome fixes failed. Please review the errors above and apply manual fixes as needed.")
 	}
+	return fixedCount
+}
+
+// applyDoctorFix applies the fix for one check. handled is false for checks
+// with no automatic fix, after their manual hint is printed. changes lists
+// notable changes the fix made, for the summary.
+func applyDoctorFix(path string, check doctorCheck) (changes []string, handled bool, err error) {
+	switch check.Name {
+	case "Metadata Config":
+		err = fix.FixMissingMetadataJSON(path)
+	case "Gitignore":
+		err = doctor.FixGitignore(path)
+	case "Project Gitignore":
+		// Stealth / no-git-ops repos must not get a tracked .gitignore; route the patterns into
+		// .git/info/exclude instead (matches bd init --stealth) and strip any beads section a
+		// previous run leaked into the tracked .gitignore so stealth leaves no trace.
+		if isStealthRepo(path) {
+			if err = addProjectPatternsToGitExclude(path, doctor.ProjectGitignorePatterns, false); err == nil {
+				_, err = removeBeadsProjectGitignoreSection(path)
+			}
+		} else {
+			err = doctor.FixProjectGitignore(path)
+		}
+	case "Redirect Tracking":
+		err = doctor.FixRedirectTracking(path)
+	case "Last-Touched Tracking":
+		err = doctor.FixLastTouchedTracking(path)
+	case "Tracked Runtime Files":
+		err = doctor.FixTrackedRuntimeFiles(path)
+	case "Git Hooks":
+		err = fix.GitHooks(path)
+	case "Git Hook Bypass":
+		// Only the execute bit is repairable; core.hooksPath and
+		// --no-verify commits need the user's decision.
+		err = fix.GitHookPermissions(path)
+	case "Sync Divergence":
+		fmt.Printf("  ⚠ Sync divergence fix removed (Dolt-native sync)\n")
+		return nil, false, nil
+	case "Permissions":
+		err = fix.Permissions(path)
+	case "Database":
+		err = fix.DatabaseVersionWithBdVersion(path, Version)
+		// Also repair any other missing metadata fields (bd_version, repo_id, clone_id)
+		if mErr := fix.FixMissingMetadata(path, Version); mErr != nil && err == nil {
+			err = mErr
+		}
+	case "Database Integrity":
+		// Corruption detected - backup and reinitialize
+		err = fix.DatabaseIntegrity(path)
+	case "Schema Compatibility":
+		err = fix.SchemaCompatibility(path)
+	case "Repo Fingerprint":
+		err = fix.RepoFingerprint(path, doctorYes)
+		// Also repair any other missing metadata fields (bd_version, repo_id, clone_id)
+		if mErr := fix.FixMissingMetadata(path, Version); mErr != nil && err == nil {
+			err = mErr
+		}
+	case "Database Config":
+		err = fix.DatabaseConfig(path)
+	case "JSONL Config":
+		fmt.Printf("  ⚠ JSONL config migration removed (Dolt-native sync)\n")
+		return nil, false, nil
+	case "Untracked Files":
+		fmt.Printf("  ⚠ Untracked JSONL fix removed (Dolt-native storage)\n")
+		return nil, false, nil
+	case "Cross-Table Duplicates":
+		err = fix.CrossTableDuplicates(path, doctorVerbose)
+	case "Orphaned Dependencies":
+		err = fix.OrphanedDependencies(path, doctorVerbose)
+	case "Dependency Graph":
+		err = fix.DependencyGraph(path, doctorVerbose)
+	case "Orphaned Wisps":
+		err = fix.OrphanedWisps(path)
+	case "Dolt Remote Migration":
+		var remoteChanges []string
+		remoteChanges, err = fix.RemoteConsistency(path, doctorPrefer, promptRemoteConflict)
+		changes = append(changes, remoteChanges...)
+	case "Dependency Keys":
+		err = fix.DependencyKeys(path, doctorVerbose)
+	case "Blocked State":
+		// bd-6dnrw.37: full is_blocked recompute. Pinned to a terminal
+		// priority in the sort above so it runs after every graph-mutating
+		// fix, recomputing from the corrected graph.
+		err = fix.RecomputeBlocked(path)
+	case "Child-Parent Dependencies":
+		// Requires explicit opt-in flag (destructive, may remove intentional deps)
+		if !doctorFixChildParent {
+			fmt.Printf("  ⚠ Child→parent deps require explicit opt-in: bd doctor --fix --fix-child-parent\n")
+			return nil, false, nil
+		}
+		err = fix.ChildParentDependencies(path, doctorVerbose)
+	case "Duplicate Issues":
+		// No auto-fix: duplicates require user review
+		fmt.Printf("  ⚠ Run 'bd duplicates' to review and merge duplicates\n")
+		return nil, false, nil
+	case "Test Pollution":
+		// No auto-fix: test cleanup requires user review
+		fmt.Printf("  ⚠ Run 'bd doctor --check=pollution' to review and clean test issues\n")
+		return nil, false, nil
+	case "Git Conflicts":
+		// No auto-fix: git conflicts require manual resolution
+		fmt.Printf("  ⚠ Resolve conflicts manually\n")
+		return nil, false, nil
+	case "JSONL Conflicts":
+		err = fix.JSONLConflicts(path)
+	case "Export Freshness":
+		err = fix.ExportFreshness(path, doctor.ExportFreshnessState(path))
+	case "Timestamp Sanity":
+		err = fix.TimestampSanity(path)
+	case "Stale Closed Issues":
+		// consolidate cleanup into doctor --fix
+		err = fix.StaleClosedIssues(path)
+	case "Compaction Candidates":
+		// No auto-fix: compaction requires agent review
+		fmt.Printf("  ⚠ Run 'bd compact --analyze' to review candidates\n")
+		return nil, false, nil
+	case "Large Database":
+		// No auto-fix: pruning deletes data, must be user-controlled
+		fmt.Printf("  ⚠ Run 'bd cleanup --older-than 90' to prune old closed issues\n")
+		return nil, false, nil
+	case "Performance Profile":
+		var perfChanges []string
+		perfChanges, err = fix.PerformanceMaintenance(path)
+		changes = append(changes, perfChanges...)
+	case "Legacy MQ Files":
+		err = doctor.FixStaleMQFiles(path)
+	case "Patrol Pollution":
+		err = fix.PatrolPollution(path)
+	case "Lock Files":
+		err = fix.StaleLockFiles(path)
+	case "Circuit Breaker":
+		dolt.CleanStaleCircuitBreakerFiles()
+		fmt.Printf("  %s Cleared stale circuit breaker files\n", ui.RenderPass("✓"))
+	case "Fresh Clone":
+		err = fix.FreshCloneImport(path, Version)
+	case "Pending Migrations":
+		err = fixPendingMigrations(path)
+	case "Config Values":
+		err = fix.ConfigValues(path)
+	case "Classic Artifacts":
+		err = fix.ClassicArtifacts(path)
+	case "Btrfs NoCOW (dolt)":
+		// Applies FS_NOCOW_FL to .beads/ and any existing dolt data
+		// subdirs. Prints the returned message (which includes the
+		// "relocate existing files" warning) so the user sees why the
+		// fix is incomplete on its own.
+		var msg string
+		msg, err = doctor.FixBtrfsNoCOW(path)
+		if err == nil && msg != "" {
+			fmt.Print(msg)
+			if !strings.HasSuffix(msg, "\n") {
+				fmt.Println()
+			}
+		}
+	case "Project Identity":
+		err = fix.FixProjectIdentity(path)
+	case "Dolt Schema":
+		// GH#2160: Pre-#2142 migrations may have wrong database configured.
+		// Probe the server and backfill dolt_database in metadata.json.
+		err = fix.FixMissingDoltDatabase(path)
+	case "Dolt Format":
+		err = fix.DoltFormat(path)
+	case "Corrupt Manifest":
+		// GH#3290 / bd-6dnrw.6: destructive backup+reinit, gated here so it
+		// only ever runs on explicit doctor --fix confirmation.
+		var backups []string
+		backups, err = doltserver.RecoverCorruptManifest(doctor.ResolveBeadsDirForRepo(path))
+		for _, b := range backups {
+			fmt.Printf("  Backed up corrupt dolt database to %s and reinitialized\n", b)
+		}
+	default:
+		fmt.Printf("  ⚠ No automatic fix available for %s\n", check.Name)
+		fmt.Printf("  Manual fix: %s\n", check.Fix)
+		return nil, false, nil
+	}
+	return changes, true, err
 }
 
 func fixPendingMigrations(path string) error {
@@ -467,3 +518,28 @@ func fixPendingMigrations(path string) error {
 
 	return nil
 }
+
+// promptRemoteConflict asks which side of a SQL/CLI remote URL conflict to
+// keep. Without a terminal it leaves the conflict unresolved, so unattended
+// runs never guess; --prefer decides instead.
+func promptRemoteConflict(c fix.RemoteConflict) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", nil
+	}
+	fmt.Printf("  Remote %s has conflicting URLs:\n", c.Name)
+	fmt.Printf("    [s]ql: %s\n", c.SQLURL)
+	fmt.Printf("    [c]li: %s (%s)\n", c.CLIURL, c.CLIDir)
+	fmt.Print("  Keep which? [s/c/N]: ")
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("reading input: %w", err)
+	}
+	switch strings.TrimSpace(strings.ToLower(response)) {
+	case "s", "sql":
+		return fix.RemotePreferSQL, nil
+	case "c", "cli":
+		return fix.RemotePreferCLI, nil
+	default:
+		return "", nil
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// doctorFixPlanStep is one entry of a 'bd doctor --fix --plan' execution plan.
+type doctorFixPlanStep struct {
+	Check doctorCheck
+	Auto  bool // false: no automatic fix, listed for manual follow-up only
+}
+
+// Per-step outcomes of applying a plan.
+const (
+	planStepFixed          = "fixed"
+	planStepFailed         = "failed"
+	planStepRolledBack     = "failed, rolled back"
+	planStepRollbackFailed = "failed, rollback incomplete"
+	planStepNotRun         = "not run"
+)
+
+// doctorFixPlanResult is the outcome of one automatic plan step.
+type doctorFixPlanResult struct {
+	Step    doctorFixPlanStep
+	Outcome string
+	Err     error
+	Changes []string
+}
+
+// doctorFixCheckpoint undoes the changes of a failed fix.
+type doctorFixCheckpoint interface {
+	Restore() ([]string, error)
+}
+
+// buildDoctorFixPlan collects the fixable checks in apply order and marks
+// which of them --fix repairs automatically.
+func buildDoctorFixPlan(checks []doctorCheck) []doctorFixPlanStep {
+	var fixable []doctorCheck
+	for _, check := range checks {
+		if (check.Status == statusWarning || check.Status == statusError) && check.Fix != "" {
+			fixable = append(fixable, check)
+		}
+	}
+	orderDoctorFixes(fixable)
+
+	steps := make([]doctorFixPlanStep, 0, len(fixable))
+	for _, check := range fixable {
+		auto := doctorAutoFixChecks[check.Name]
+		if check.Name == "Child-Parent Dependencies" && !doctorFixChildParent {
+			auto = false
+		}
+		steps = append(steps, doctorFixPlanStep{Check: check, Auto: auto})
+	}
+	return steps
+}
+
+func countAutoPlanSteps(steps []doctorFixPlanStep) int {
+	n := 0
+	for _, s := range steps {
+		if s.Auto {
+			n++
+		}
+	}
+	return n
+}
+
+// formatDoctorFixPlan renders the consolidated plan. dbNote, when set, says
+// why database changes cannot be rolled back.
+func formatDoctorFixPlan(steps []doctorFixPlanStep, dryRun bool, dbNote string) []string {
+	lines := []string{"Doctor fix plan"}
+	if dryRun {
+		lines = append(lines, "Mode: dry-run")
+	} else {
+		lines = append(lines, "Mode: apply")
+	}
+
+	auto := countAutoPlanSteps(steps)
+	lines = append(lines, fmt.Sprintf("Automatic fixes: %d (applied in this order)", auto))
+	n := 0
+	for _, s := range steps {
+		if !s.Auto {
+			continue
+		}
+		n++
+		lines = append(lines, fmt.Sprintf("  %d. %s [%s]: %s", n, s.Check.Name, s.Check.Status, s.Check.Message))
+		lines = append(lines, fmt.Sprintf("     action: %s", s.Check.Fix))
+	}
+
+	if manual := len(steps) - auto; manual > 0 {
+		lines = append(lines, fmt.Sprintf("Manual follow-up: %d (not applied)", manual))
+		for _, s := range steps {
+			if !s.Auto {
+				lines = append(lines, fmt.Sprintf("  - %s: %s", s.Check.Name, s.Check.Fix))
+			}
+		}
+	}
+
+	if auto > 0 {
+		lines = append(lines, "Rollback: each fix is checkpointed; a failing fix is rolled back and the fixes after it are not run")
+		if dbNote != "" {
+			lines = append(lines, fmt.Sprintf("  Database changes cannot be rolled back: %s", dbNote))
+		}
+	}
+	return lines
+}
+
+// applyFixesPlanned implements 'bd doctor --fix --plan': it prints the whole
+// plan, asks once, then applies the automatic fixes in order, and returns how
+// many succeeded.
+func applyFixesPlanned(result doctorResult) int {
+	steps := buildDoctorFixPlan(result.Checks)
+	if len(steps) == 0 {
+		fmt.Println("\nNo fixable issues found.")
+		return 0
+	}
+
+	var first doctorFixCheckpoint
+	dbNote := ""
+	if countAutoPlanSteps(steps) > 0 {
+		cp, err := fix.NewCheckpoint(result.Path)
+		if err != nil {
+			dbNote = err.Error()
+		} else {
+			first = cp
+			if covered, note := cp.CoversDatabase(); !covered {
+				dbNote = note
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Join(formatDoctorFixPlan(steps, false, dbNote), "\n"))
+	if countAutoPlanSteps(steps) == 0 {
+		fmt.Println("\nNothing to apply automatically.")
+		return 0
+	}
+	if !confirmDoctorFixes(countAutoPlanSteps(steps)) {
+		return 0
+	}
+
+	results := runDoctorFixPlan(steps, applyDoctorFix, result.Path, func() (doctorFixCheckpoint, error) {
+		if first != nil {
+			cp := first
+			first = nil
+			return cp, nil
+		}
+		return fix.NewCheckpoint(result.Path)
+	})
+	return printDoctorFixPlanResults(results)
+}
+
+// printDoctorFixPlan prints the plan without applying it (--plan --dry-run).
+func printDoctorFixPlan(result doctorResult) {
+	steps := buildDoctorFixPlan(result.Checks)
+	if len(steps) == 0 {
+		fmt.Println("\n✓ No fixable issues found (dry-run)")
+		return
+	}
+	fmt.Println()
+	fmt.Println(strings.Join(formatDoctorFixPlan(steps, true, ""), "\n"))
+	fmt.Println("\nRun 'bd doctor --fix --plan' to apply this plan")
+}
+
+// runDoctorFixPlan applies the automatic steps in order. Each fix runs after
+// a checkpoint; when it fails, the checkpoint is restored, undoing whatever
+// the fix changed before failing, and the remaining steps are not run. Fixes
+// that already succeeded are kept.
+func runDoctorFixPlan(
+	steps []doctorFixPlanStep,
+	apply func(string, doctorCheck) ([]string, bool, error),
+	path string,
+	checkpoint func() (doctorFixCheckpoint, error),
+) []doctorFixPlanResult {
+	var results []doctorFixPlanResult
+	total := countAutoPlanSteps(steps)
+	stopped := false
+	for _, step := range steps {
+		if !step.Auto {
+			continue
+		}
+		res := doctorFixPlanResult{Step: step}
+		if stopped {
+			res.Outcome = planStepNotRun
+			results = append(results, res)
+			continue
+		}
+
+		fmt.Printf("\n[%d/%d] Fixing %s...\n", len(results)+1, total, step.Check.Name)
+		cp, cpErr := checkpoint()
+		if cpErr != nil {
+			fmt.Printf("  %s Checkpoint failed, this fix cannot be rolled back: %v\n", ui.RenderWarn("⚠"), cpErr)
+		}
+
+		changes, handled, err := apply(path, step.Check)
+		res.Changes = changes
+		switch {
+		case err == nil && !handled:
+			// The check turned out to need manual action; nothing changed.
+			res.Outcome = planStepNotRun
+		case err == nil:
+			res.Outcome = planStepFixed
+			fmt.Printf("  %s Fixed\n", ui.RenderPass("✓"))
+		default:
+			res.Err = err
+			stopped = true
+			fmt.Printf("  %s Error: %v\n", ui.RenderFail("✗"), err)
+			res.Outcome = planStepFailed
+			if cp != nil {
+				restored, rbErr := cp.Restore()
+				for _, r := range restored {
+					fmt.Printf("  rollback: %s\n", r)
+				}
+				if rbErr != nil {
+					res.Outcome = planStepRollbackFailed
+					fmt.Printf("  %s Rollback incomplete: %v\n", ui.RenderFail("✗"), rbErr)
+				} else {
+					res.Outcome = planStepRolledBack
+				}
+			}
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// printDoctorFixPlanResults prints the per-fix report and returns how many
+// fixes succeeded.
+func printDoctorFixPlanResults(results []doctorFixPlanResult) int {
+	fixed := 0
+	fmt.Println("\nFix plan results:")
+	for i, r := range results {
+		mark := ui.RenderPass("✓")
+		switch r.Outcome {
+		case planStepFixed:
+			fixed++
+		case planStepNotRun:
+			mark = "-"
+		default:
+			mark = ui.RenderFail("✗")
+		}
+		fmt.Printf("  %d. %s %s: %s\n", i+1, mark, r.Step.Check.Name, r.Outcome)
+		for _, c := range r.Changes {
+			fmt.Printf("       - %s\n", c)
+		}
+		if r.Err != nil {
+			fmt.Printf("       manual fix: %s\n", r.Step.Check.Fix)
+		}
+	}
+	fmt.Printf("\nFix summary: %d of %d fixed\n", fixed, len(results))
+	return fixed
+}
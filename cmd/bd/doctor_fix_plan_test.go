@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeFixCheckpoint struct {
+	restored *[]string
+	name     string
+}
+
+func (f fakeFixCheckpoint) Restore() ([]string, error) {
+	*f.restored = append(*f.restored, f.name)
+	return []string{"restored " + f.name}, nil
+}
+
+func TestBuildDoctorFixPlan(t *testing.T) {
+	steps := buildDoctorFixPlan([]doctorCheck{
+		{Name: "Blocked State", Status: statusWarning, Fix: "bd doctor --fix"},
+		{Name: "Git Hooks", Status: statusOK, Fix: "bd hooks install"},
+		{Name: "Duplicate Issues", Status: statusWarning, Fix: "bd duplicates"},
+		{Name: "Gitignore", Status: statusError, Fix: "bd doctor --fix"},
+		{Name: "Permissions", Status: statusWarning},
+	})
+
+	var got []string
+	for _, s := range steps {
+		got = append(got, s.Check.Name)
+	}
+	want := []string{"Gitignore", "Duplicate Issues", "Blocked State"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("plan = %v, want %v", got, want)
+	}
+	if !steps[0].Auto || steps[1].Auto || !steps[2].Auto {
+		t.Errorf("auto flags = %v %v %v, want true false true", steps[0].Auto, steps[1].Auto, steps[2].Auto)
+	}
+
+	plan := strings.Join(formatDoctorFixPlan(steps, false, "database unavailable"), "\n")
+	for _, s := range []string{"Automatic fixes: 2", "1. Gitignore", "2. Blocked State", "Manual follow-up: 1", "- Duplicate Issues: bd duplicates", "cannot be rolled back: database unavailable"} {
+		if !strings.Contains(plan, s) {
+			t.Errorf("plan does not contain %q:\n%s", s, plan)
+		}
+	}
+}
+
+func TestRunDoctorFixPlan_RollsBackFailedFixAndStops(t *testing.T) {
+	steps := []doctorFixPlanStep{
+		{Check: doctorCheck{Name: "Gitignore"}, Auto: true},
+		{Check: doctorCheck{Name: "Duplicate Issues"}, Auto: false},
+		{Check: doctorCheck{Name: "Permissions"}, Auto: true},
+		{Check: doctorCheck{Name: "Blocked State"}, Auto: true},
+	}
+	var applied, restored []string
+	apply := func(_ string, check doctorCheck) ([]string, bool, error) {
+		applied = append(applied, check.Name)
+		if check.Name == "Permissions" {
+			return nil, true, errors.New("chmod failed")
+		}
+		return []string{"changed " + check.Name}, true, nil
+	}
+	// Name each checkpoint after the automatic step it guards.
+	guarded := []string{"Gitignore", "Permissions", "Blocked State"}
+	taken := 0
+	checkpoint := func() (doctorFixCheckpoint, error) {
+		cp := fakeFixCheckpoint{restored: &restored, name: guarded[taken]}
+		taken++
+		return cp, nil
+	}
+	results := runDoctorFixPlan(steps, apply, ".", checkpoint)
+
+	if strings.Join(applied, ",") != "Gitignore,Permissions" {
+		t.Errorf("applied = %v, want Gitignore then Permissions only", applied)
+	}
+	if strings.Join(restored, ",") != "Permissions" {
+		t.Errorf("restored = %v, want only the failed Permissions fix", restored)
+	}
+	wantOutcomes := []string{planStepFixed, planStepRolledBack, planStepNotRun}
+	if len(results) != len(wantOutcomes) {
+		t.Fatalf("got %d results, want %d", len(results), len(wantOutcomes))
+	}
+	for i, want := range wantOutcomes {
+		if results[i].Outcome != want {
+			t.Errorf("result %d (%s) = %q, want %q", i, results[i].Step.Check.Name, results[i].Outcome, want)
+		}
+	}
+	if results[1].Err == nil {
+		t.Error("failed step has no error")
+	}
+}
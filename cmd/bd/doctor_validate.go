@@ -86,6 +86,7 @@ func collectValidateChecks(path string) []validateCheckResult {
 		{check: convertDoctorCheck(doctor.CheckOrphanedDependencies(path)), fixable: true},
 		{check: convertDoctorCheck(doctor.CheckTestPollution(path))},
 		{check: convertDoctorCheck(doctor.CheckGitConflicts(path))},
+		{check: convertDoctorCheck(doctor.CheckJSONLConflicts(path)), fixable: true},
 	}
 }
 
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// doctorWatchFilePoll is how often --watch looks for file changes in .beads/
+// and the git hooks directory. Like bd list --watch it polls rather than using
+// fsnotify: much of what doctor inspects (the Dolt server, its database) is
+// not a local file, and polling a few directory listings is cheap.
+const doctorWatchFilePoll = time.Second
+
+// doctorWatchTransition is one check changing status between two runs.
+type doctorWatchTransition struct {
+	Time    string `json:"time"`
+	Check   string `json:"check"`
+	Slug    string `json:"slug"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+// filterDoctorChecks keeps the checks named in slugs, matched by check ID so
+// an ID (git_hooks), slug (git-hooks), or display name all work. An empty
+// slugs keeps every check.
+func filterDoctorChecks(checks []doctorCheck, slugs []string) []doctorCheck {
+	if len(slugs) == 0 {
+		return checks
+	}
+	want := make(map[string]bool, len(slugs))
+	for _, s := range slugs {
+		want[doctorCheckID(s)] = true
+	}
+	var out []doctorCheck
+	for _, c := range checks {
+		if want[doctorCheckID(c.Name)] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// diffDoctorChecks compares checks against the statuses of the previous run
+// (keyed by slug) and returns the new statuses and the checks whose status
+// changed. A check that disappears from the run (e.g. skipped because .beads/
+// went missing) is reported as "missing".
+func diffDoctorChecks(prev map[string]string, checks []doctorCheck, now time.Time) (map[string]string, []doctorWatchTransition) {
+	next := make(map[string]string, len(checks))
+	var transitions []doctorWatchTransition
+	stamp := now.Format(time.RFC3339)
+	for _, c := range checks {
+		slug := doctor.CheckNameToSlug(c.Name)
+		next[slug] = c.Status
+		if from, ok := prev[slug]; ok && from != c.Status {
+			transitions = append(transitions, doctorWatchTransition{
+				Time: stamp, Check: c.Name, Slug: slug, From: from, To: c.Status, Message: c.Message, Fix: c.Fix,
+			})
+		}
+	}
+	var gone []string
+	for slug := range prev {
+		if _, ok := next[slug]; !ok {
+			gone = append(gone, slug)
+		}
+	}
+	sort.Strings(gone)
+	for _, slug := range gone {
+		transitions = append(transitions, doctorWatchTransition{
+			Time: stamp, Check: slug, Slug: slug, From: prev[slug], To: "missing", Message: "check no longer runs",
+		})
+	}
+	return next, transitions
+}
+
+// doctorWatchFingerprint summarizes the names, sizes, and modification times
+// of the entries directly inside dirs, so a change to any of them (a new lock
+// or socket file, a rewritten hook) can trigger an early re-run.
+func doctorWatchFingerprint(dirs ...string) string {
+	var b strings.Builder
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			fmt.Fprintf(&b, "%s:absent;", dir)
+			continue
+		}
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(&b, "%s/%s:%d:%d;", dir, e.Name(), info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return b.String()
+}
+
+func doctorWatchIcon(status string) string {
+	switch status {
+	case statusOK:
+		return ui.RenderPassIcon()
+	case statusWarning:
+		return ui.RenderWarnIcon()
+	default:
+		return ui.RenderFailIcon()
+	}
+}
+
+func printDoctorWatchTransitions(transitions []doctorWatchTransition) {
+	for _, t := range transitions {
+		if jsonOutput {
+			_ = outputJSON(t)
+			continue
+		}
+		ts := t.Time
+		if parsed, err := time.Parse(time.RFC3339, t.Time); err == nil {
+			ts = parsed.Format("15:04:05")
+		}
+		fmt.Printf("[%s] %s %s: %s → %s — %s\n", ts, doctorWatchIcon(t.To), t.Check, t.From, t.To, t.Message)
+		if t.Fix != "" && t.To != statusOK {
+			fmt.Printf("           Fix: %s\n", t.Fix)
+		}
+	}
+}
+
+// runDoctorWatch re-runs the doctor checks (or the subset named in slugs)
+// every interval, and sooner when files in .beads/ or the hooks directory
+// change, printing only status transitions until interrupted.
+func runDoctorWatch(path string, interval time.Duration, slugs []string) error {
+	if interval <= 0 {
+		return HandleError("--watch-interval must be positive")
+	}
+
+	beadsDir := doctor.ResolveBeadsDirForRepo(path)
+	hooksDir, _ := git.GetGitHooksDir()
+
+	checks := filterDoctorChecks(runDiagnostics(path).Checks, slugs)
+	if len(slugs) > 0 && len(checks) == 0 {
+		return HandleErrorWithHint(
+			fmt.Sprintf("no doctor checks match %s", strings.Join(slugs, ", ")),
+			"Use check slugs as shown by 'bd doctor --json' names, e.g. git-hooks, dolt-connection")
+	}
+	statuses, _ := diffDoctorChecks(nil, checks, time.Now())
+
+	if jsonOutput {
+		for _, c := range checks {
+			_ = outputJSON(doctorWatchTransition{
+				Time: time.Now().Format(time.RFC3339), Check: c.Name, Slug: doctor.CheckNameToSlug(c.Name),
+				To: c.Status, Message: c.Message, Fix: c.Fix,
+			})
+		}
+	} else {
+		counts := map[string]int{}
+		for _, c := range checks {
+			counts[c.Status]++
+		}
+		fmt.Printf("Watching %d check(s) in %s: %d ok, %d warning(s), %d error(s)\n",
+			len(checks), path, counts[statusOK], counts[statusWarning], counts[statusError])
+		for _, c := range checks {
+			if c.Status != statusOK {
+				fmt.Printf("  %s %s: %s\n", doctorWatchIcon(c.Status), c.Name, c.Message)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Re-checking every %s and on changes in .beads/ and git hooks (Press Ctrl+C to exit)\n", interval)
+	}
+
+	// Handle Ctrl+C — deferred Stop prevents signal handler leak
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	filePoll := time.NewTicker(doctorWatchFilePoll)
+	defer filePoll.Stop()
+	fingerprint := doctorWatchFingerprint(beadsDir, hooksDir)
+
+	for {
+		select {
+		case <-sigChan:
+			if !jsonOutput {
+				fmt.Fprintf(os.Stderr, "\nStopped watching.\n")
+			}
+			return nil
+		case <-filePoll.C:
+			next := doctorWatchFingerprint(beadsDir, hooksDir)
+			if next == fingerprint {
+				continue
+			}
+			fingerprint = next
+		case <-ticker.C:
+		}
+
+		var transitions []doctorWatchTransition
+		statuses, transitions = diffDoctorChecks(statuses, filterDoctorChecks(runDiagnostics(path).Checks, slugs), time.Now())
+		printDoctorWatchTransitions(transitions)
+		// Checks themselves may touch files under .beads/; don't let that
+		// retrigger an immediate re-run.
+		fingerprint = doctorWatchFingerprint(beadsDir, hooksDir)
+	}
+}
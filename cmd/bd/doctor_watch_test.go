@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiffDoctorChecks(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	first := []doctorCheck{
+		{Name: "Git Hooks", Status: statusOK},
+		{Name: "Dolt Connection", Status: statusOK},
+		{Name: "Lock Files", Status: statusWarning},
+	}
+	statuses, transitions := diffDoctorChecks(nil, first, now)
+	if len(transitions) != 0 {
+		t.Fatalf("first run should only record a baseline, got %+v", transitions)
+	}
+
+	second := []doctorCheck{
+		{Name: "Git Hooks", Status: statusOK},
+		{Name: "Dolt Connection", Status: statusError, Message: "server not reachable"},
+	}
+	statuses, transitions = diffDoctorChecks(statuses, second, now)
+	if len(transitions) != 2 {
+		t.Fatalf("expected 2 transitions, got %+v", transitions)
+	}
+	if got := transitions[0]; got.Slug != "dolt-connection" || got.From != statusOK || got.To != statusError || got.Message != "server not reachable" {
+		t.Errorf("unexpected transition %+v", got)
+	}
+	if got := transitions[1]; got.Slug != "lock-files" || got.To != "missing" {
+		t.Errorf("a check that stops running should be reported missing, got %+v", got)
+	}
+
+	if _, transitions = diffDoctorChecks(statuses, second, now); len(transitions) != 0 {
+		t.Errorf("unchanged run should print nothing, got %+v", transitions)
+	}
+}
+
+func TestFilterDoctorChecks(t *testing.T) {
+	checks := []doctorCheck{{Name: "Git Hooks"}, {Name: "Dolt Connection"}, {Name: "Lock Files"}}
+	if got := filterDoctorChecks(checks, nil); len(got) != 3 {
+		t.Errorf("no filter should keep all checks, got %d", len(got))
+	}
+	got := filterDoctorChecks(checks, []string{"git-hooks", "Dolt Connection"})
+	if len(got) != 2 || got[0].Name != "Git Hooks" || got[1].Name != "Dolt Connection" {
+		t.Errorf("filter by slug or name failed, got %+v", got)
+	}
+}
+
+func TestDoctorWatchFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	before := doctorWatchFingerprint(dir, "")
+	if err := os.WriteFile(filepath.Join(dir, "dolt-server.pid"), []byte("1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if after := doctorWatchFingerprint(dir, ""); after == before {
+		t.Error("a new file should change the fingerprint")
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/issueops"
 )
@@ -61,7 +63,8 @@ type doltAutoCommitParams struct {
 //   - Only applies when dolt auto-commit is "on" AND the active store is versioned (Dolt).
 //   - Skips SQL server modes; the server owns transaction commit lifecycle there.
 //   - In "batch" mode, commits are deferred — changes accumulate in the working set
-//     until an explicit commit point (bd dolt commit).
+//     until an explicit commit point (bd dolt commit), or until a write lands
+//     after the dolt.auto-commit-interval window since the last commit.
 //   - Uses Dolt's "commit all" behavior under the hood (DOLT_COMMIT -Am).
 //   - Treats "nothing to commit" as a no-op.
 func maybeAutoCommit(ctx context.Context, p doltAutoCommitParams) error {
@@ -87,8 +90,9 @@ func maybeAutoCommitStore(ctx context.Context, st storage.DoltStorage, p doltAut
 		return err
 	}
 	// In batch mode, skip per-command commits. Changes stay in the working set
-	// and are committed at logical boundaries (bd dolt commit).
-	if mode != doltAutoCommitOn {
+	// and are committed at logical boundaries (bd dolt commit), or once the
+	// dolt.auto-commit-interval window since the last commit has elapsed.
+	if mode != doltAutoCommitOn && mode != doltAutoCommitBatch {
 		return nil
 	}
 
@@ -100,6 +104,13 @@ func maybeAutoCommitStore(ctx context.Context, st storage.DoltStorage, p doltAut
 	}
 
 	msg := p.MessageOverride
+	if mode == doltAutoCommitBatch {
+		if !batchCommitWindowElapsed(ctx, st) {
+			return nil
+		}
+		// The batch spans earlier commands too, so don't attribute it to this one.
+		msg = formatDoltAutoCommitMessage("batch", getActor(), nil)
+	}
 	if strings.TrimSpace(msg) == "" {
 		msg = formatDoltAutoCommitMessage(p.Command, getActor(), p.IssueIDs)
 	}
@@ -113,6 +124,22 @@ func maybeAutoCommitStore(ctx context.Context, st storage.DoltStorage, p doltAut
 	return nil
 }
 
+// batchCommitWindowElapsed reports whether batch mode should commit now: the
+// dolt.auto-commit-interval config is set and the newest Dolt commit is at
+// least that old. A zero interval (the default) leaves batches to explicit
+// bd dolt commit and the shutdown flush.
+func batchCommitWindowElapsed(ctx context.Context, st storage.DoltStorage) bool {
+	interval := config.GetDuration("dolt.auto-commit-interval")
+	if interval <= 0 {
+		return false
+	}
+	commits, err := st.Log(ctx, 1)
+	if err != nil {
+		return false
+	}
+	return len(commits) == 0 || time.Since(commits[0].Date) >= interval
+}
+
 func isDoltNothingToCommit(err error) bool {
 	return issueops.IsNothingToCommitError(err)
 }
@@ -5,8 +5,11 @@ package main
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -17,6 +20,11 @@ type fakeCommitPendingStore struct {
 	pendingCalls int
 	message      string
 	err          error
+	lastCommit   time.Time
+}
+
+func (f *fakeCommitPendingStore) Log(_ context.Context, _ int) ([]storage.CommitInfo, error) {
+	return []storage.CommitInfo{{Hash: "head", Date: f.lastCommit}}, nil
 }
 
 func (f *fakeCommitPendingStore) Commit(_ context.Context, message string) error {
@@ -137,6 +145,42 @@ func TestCommitPendingIfEmbeddedHonorsBatchAndOffModes(t *testing.T) {
 	}
 }
 
+func TestCommitPendingIfEmbeddedBatchCommitsAfterInterval(t *testing.T) {
+	saveStorageMode(t)
+	initConfigForTest(t)
+	serverMode = false
+	proxiedServerMode = false
+	doltAutoCommit = string(doltAutoCommitBatch)
+	config.Set("dolt.auto-commit-interval", "5m")
+
+	fresh := &fakeCommitPendingStore{lastCommit: time.Now().Add(-time.Minute)}
+	if err := commitPendingIfEmbedded(context.Background(), fresh, "tester", doltAutoCommitParams{Command: "update"}); err != nil {
+		t.Fatalf("commitPendingIfEmbedded: %v", err)
+	}
+	if fresh.commitCalls != 0 {
+		t.Fatalf("Commit calls = %d, want 0 inside the batch window", fresh.commitCalls)
+	}
+
+	stale := &fakeCommitPendingStore{lastCommit: time.Now().Add(-10 * time.Minute)}
+	if err := commitPendingIfEmbedded(context.Background(), stale, "tester", doltAutoCommitParams{Command: "update", IssueIDs: []string{"bd-1"}}); err != nil {
+		t.Fatalf("commitPendingIfEmbedded: %v", err)
+	}
+	if stale.commitCalls != 1 {
+		t.Fatalf("Commit calls = %d, want 1 once the batch window elapsed", stale.commitCalls)
+	}
+	if !strings.HasPrefix(stale.message, "bd: batch (auto-commit) by ") {
+		t.Fatalf("message = %q, want batch auto-commit message", stale.message)
+	}
+
+	config.Set("dolt.auto-commit-interval", "0")
+	if err := commitPendingIfEmbedded(context.Background(), stale, "tester", doltAutoCommitParams{Command: "update"}); err != nil {
+		t.Fatalf("commitPendingIfEmbedded: %v", err)
+	}
+	if stale.commitCalls != 1 {
+		t.Fatalf("Commit calls = %d, want no commit with the interval disabled", stale.commitCalls)
+	}
+}
+
 func TestCommitPendingIfEmbeddedPropagatesEmbeddedError(t *testing.T) {
 	saveStorageMode(t)
 	serverMode = false
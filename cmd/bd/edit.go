@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
@@ -20,12 +22,19 @@ var editCmd = &cobra.Command{
 
 By default, edits the description. Use flags to edit other fields.
 
+With --all, the editor opens one structured buffer: a commented header with
+title, status, priority and labels, then Description and Acceptance Criteria
+sections. On save the buffer is validated (an invalid buffer reopens with the
+error at the top), the changes are shown as a diff, and they are applied in a
+single transaction that records the usual events. Delete everything to cancel.
+
 Examples:
   bd edit bd-42                    # Edit description
   bd edit bd-42 --title            # Edit title
   bd edit bd-42 --design           # Edit design notes
   bd edit bd-42 --notes            # Edit notes
-  bd edit bd-42 --acceptance       # Edit acceptance criteria`,
+  bd edit bd-42 --acceptance       # Edit acceptance criteria
+  bd edit bd-42 --all              # Edit the main fields in one buffer`,
 	Args:          cobra.ExactArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -66,23 +75,15 @@ Examples:
 			fieldToEdit = "acceptance_criteria"
 		}
 
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = os.Getenv("VISUAL")
-		}
-		if editor == "" {
-			for _, defaultEditor := range []string{"vim", "vi", "nano", "emacs"} {
-				if _, err := exec.LookPath(defaultEditor); err == nil {
-					editor = defaultEditor
-					break
-				}
-			}
-		}
+		editor := findEditor()
 		if editor == "" {
 			return HandleErrorRespectJSON("no editor found. Set $EDITOR or $VISUAL environment variable")
 		}
 
 		issue := result.Issue
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			return runStructuredEdit(ctx, issueStore, issue, editor)
+		}
 
 		var currentValue string
 		switch fieldToEdit {
@@ -116,14 +117,7 @@ Examples:
 		}
 		_ = tmpFile.Close()
 
-		editorParts := strings.Fields(editor)
-		editorArgs := append(editorParts[1:], tmpPath)
-		editorCmd := exec.Command(editorParts[0], editorArgs...) //nolint:gosec // G204: editor from trusted $EDITOR/$VISUAL env or known defaults
-		editorCmd.Stdin = os.Stdin
-		editorCmd.Stdout = os.Stdout
-		editorCmd.Stderr = os.Stderr
-
-		if err := editorCmd.Run(); err != nil {
+		if err := runEditor(editor, tmpPath); err != nil {
 			return HandleErrorRespectJSON("running editor: %v", err)
 		}
 
@@ -183,7 +177,136 @@ Examples:
 	},
 }
 
+// findEditor returns $EDITOR, then $VISUAL, then the first common editor on
+// PATH, or "" when there is none.
+func findEditor() string {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		for _, defaultEditor := range []string{"vim", "vi", "nano", "emacs"} {
+			if _, err := exec.LookPath(defaultEditor); err == nil {
+				editor = defaultEditor
+				break
+			}
+		}
+	}
+	return editor
+}
+
+// runEditor opens path in editor, attached to the terminal.
+func runEditor(editor, path string) error {
+	editorParts := strings.Fields(editor)
+	editorArgs := append(editorParts[1:], path)
+	editorCmd := exec.Command(editorParts[0], editorArgs...) //nolint:gosec // G204: editor from trusted $EDITOR/$VISUAL env or known defaults
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	return editorCmd.Run()
+}
+
+// runStructuredEdit implements bd edit --all: edit the main fields of issue
+// in one buffer, re-opening it until it validates, then apply every change
+// in one transaction.
+func runStructuredEdit(ctx context.Context, st storage.DoltStorage, issue *types.Issue, editor string) error {
+	id := issue.ID
+	labels, err := st.GetLabels(ctx, id)
+	if err != nil {
+		return HandleErrorRespectJSON("getting labels: %v", err)
+	}
+	customStatuses, err := st.GetCustomStatuses(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("getting custom statuses: %v", err)
+	}
+	original := editBufferFromIssue(issue, labels)
+	content := renderEditBuffer(id, original, customStatuses)
+
+	tmpFile, err := os.CreateTemp("", "bd-edit-all-*.md")
+	if err != nil {
+		return HandleErrorRespectJSON("creating temp file: %v", err)
+	}
+	tmpPath := tmpFile.Name()
+	_ = tmpFile.Close()
+	keepFile := false
+	defer func() {
+		if !keepFile {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	var edited *editBuffer
+	for {
+		if err := os.WriteFile(tmpPath, []byte(content), 0600); err != nil {
+			return HandleErrorRespectJSON("writing to temp file: %v", err)
+		}
+		if err := runEditor(editor, tmpPath); err != nil {
+			return HandleErrorRespectJSON("running editor: %v", err)
+		}
+		// #nosec G304 -- tmpPath was created earlier in this function
+		raw, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return HandleErrorRespectJSON("reading edited file: %v", err)
+		}
+		text := string(raw)
+		if isEmptyEditBuffer(text) {
+			fmt.Println("Edit cancelled")
+			return nil
+		}
+		edited, err = parseEditBuffer(text)
+		if err == nil {
+			err = validateEditBuffer(edited, customStatuses)
+		}
+		if err == nil {
+			break
+		}
+		if text == content {
+			// Saved again without fixing the reported problem: give up.
+			keepFile = true
+			fmt.Fprintf(os.Stderr, "Your edits are preserved in: %s\n", tmpPath)
+			return HandleErrorRespectJSON("invalid edit: %v", err)
+		}
+		content = annotateEditBuffer(text, err)
+	}
+
+	changes := diffEditBuffers(original, edited)
+	if changes.empty() {
+		fmt.Println("No changes made")
+		return nil
+	}
+	fmt.Print(formatEditBufferDiff(original, edited, changes))
+
+	err = transactHonoringAutoCommit(ctx, st, fmt.Sprintf("bd: edit %s", id), func(tx storage.Transaction) error {
+		if len(changes.Updates) > 0 {
+			if err := tx.UpdateIssue(ctx, id, changes.Updates, actor); err != nil {
+				return err
+			}
+		}
+		for _, label := range changes.RemoveLabels {
+			if err := tx.RemoveLabel(ctx, id, label, actor); err != nil {
+				return err
+			}
+		}
+		for _, label := range changes.AddLabels {
+			if err := tx.AddLabel(ctx, id, label, actor); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		keepFile = true
+		fmt.Fprintf(os.Stderr, "Your edits are preserved in: %s\n", tmpPath)
+		return HandleErrorRespectJSON("updating issue: %v", err)
+	}
+
+	fmt.Printf("%s Updated %s for issue: %s\n", ui.RenderPass("✓"),
+		strings.Join(changes.ChangedFields, ", "), formatFeedbackID(id, edited.Title))
+	return nil
+}
+
 func init() {
+	editCmd.Flags().Bool("all", false, "Edit title, status, priority, labels, description and acceptance criteria in one structured buffer")
 	editCmd.Flags().Bool("title", false, "Edit the title")
 	editCmd.Flags().Bool("description", false, "Edit the description (default)")
 	editCmd.Flags().Bool("design", false, "Edit the design notes")
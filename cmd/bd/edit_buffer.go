@@ -0,0 +1,308 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// editBuffer holds the fields bd edit --all puts in its structured buffer.
+type editBuffer struct {
+	Title              string
+	Status             string
+	Priority           int
+	Labels             []string
+	Description        string
+	AcceptanceCriteria string
+}
+
+const (
+	editBufferSeparator   = "---"
+	editBufferDescription = "## Description"
+	editBufferAcceptance  = "## Acceptance Criteria"
+	editBufferErrorPrefix = "# ERROR: "
+)
+
+func editBufferFromIssue(issue *types.Issue, labels []string) *editBuffer {
+	return &editBuffer{
+		Title:              issue.Title,
+		Status:             string(issue.Status),
+		Priority:           issue.Priority,
+		Labels:             normalizeEditLabels(labels),
+		Description:        strings.TrimSpace(issue.Description),
+		AcceptanceCriteria: strings.TrimSpace(issue.AcceptanceCriteria),
+	}
+}
+
+// renderEditBuffer serializes b as a commented header of "key: value" lines,
+// a --- separator, and markdown sections for the long-form fields.
+func renderEditBuffer(id string, b *editBuffer, customStatuses []string) string {
+	statuses := []string{"open", "in_progress", "blocked", "deferred", "closed"}
+	statuses = append(statuses, customStatuses...)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Editing %s. Change the fields below, then save and quit.\n", id)
+	sb.WriteString("# Lines starting with '#' above the --- line are ignored.\n")
+	sb.WriteString("# Delete everything to cancel.\n")
+	sb.WriteString("#\n")
+	fmt.Fprintf(&sb, "# status:   %s\n", strings.Join(statuses, ", "))
+	sb.WriteString("# priority: 0 (critical) to 4 (backlog)\n")
+	sb.WriteString("# labels:   comma-separated\n\n")
+	fmt.Fprintf(&sb, "title: %s\n", b.Title)
+	fmt.Fprintf(&sb, "status: %s\n", b.Status)
+	fmt.Fprintf(&sb, "priority: %d\n", b.Priority)
+	fmt.Fprintf(&sb, "labels: %s\n", strings.Join(b.Labels, ", "))
+	sb.WriteString(editBufferSeparator + "\n")
+	fmt.Fprintf(&sb, "%s\n\n", editBufferDescription)
+	if b.Description != "" {
+		sb.WriteString(b.Description + "\n\n")
+	}
+	fmt.Fprintf(&sb, "%s\n\n", editBufferAcceptance)
+	if b.AcceptanceCriteria != "" {
+		sb.WriteString(b.AcceptanceCriteria + "\n")
+	}
+	return sb.String()
+}
+
+// annotateEditBuffer replaces any earlier error lines at the top of text
+// with err, so the user sees why the last save was rejected.
+func annotateEditBuffer(text string, err error) string {
+	lines := strings.Split(text, "\n")
+	for len(lines) > 0 && strings.HasPrefix(lines[0], editBufferErrorPrefix) {
+		lines = lines[1:]
+	}
+	var sb strings.Builder
+	for _, line := range strings.Split(err.Error(), "\n") {
+		sb.WriteString(editBufferErrorPrefix + line + "\n")
+	}
+	return sb.String() + strings.Join(lines, "\n")
+}
+
+// isEmptyEditBuffer reports whether text has nothing but comments and
+// whitespace left, which cancels the edit.
+func isEmptyEditBuffer(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// parseEditBuffer parses text written by renderEditBuffer (and edited by the
+// user). It checks structure only; validateEditBuffer checks values.
+func parseEditBuffer(text string) (*editBuffer, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	sep := slices.Index(lines, editBufferSeparator)
+	if sep < 0 {
+		return nil, fmt.Errorf("missing %q line between the fields and the description", editBufferSeparator)
+	}
+
+	b := &editBuffer{}
+	seen := map[string]bool{}
+	for i, line := range lines[:sep] {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\", got %q", i+1, trimmed)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if seen[key] {
+			return nil, fmt.Errorf("line %d: %s is set twice", i+1, key)
+		}
+		seen[key] = true
+		switch key {
+		case "title":
+			b.Title = value
+		case "status":
+			b.Status = value
+		case "priority":
+			p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(value), "P"))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: priority must be a number from 0 to 4, got %q", i+1, value)
+			}
+			b.Priority = p
+		case "labels":
+			b.Labels = normalizeEditLabels(strings.Split(value, ","))
+		default:
+			return nil, fmt.Errorf("line %d: unknown field %q (expected title, status, priority or labels)", i+1, key)
+		}
+	}
+	for _, key := range []string{"title", "status", "priority"} {
+		if !seen[key] {
+			return nil, fmt.Errorf("missing %s field", key)
+		}
+	}
+
+	body := lines[sep+1:]
+	desc := slices.Index(body, editBufferDescription)
+	if desc < 0 {
+		return nil, fmt.Errorf("missing %q section", editBufferDescription)
+	}
+	acc := slices.Index(body[desc+1:], editBufferAcceptance)
+	if acc < 0 {
+		return nil, fmt.Errorf("missing %q section after %q", editBufferAcceptance, editBufferDescription)
+	}
+	acc += desc + 1
+	b.Description = strings.TrimSpace(strings.Join(body[desc+1:acc], "\n"))
+	b.AcceptanceCriteria = strings.TrimSpace(strings.Join(body[acc+1:], "\n"))
+	return b, nil
+}
+
+// validateEditBuffer checks field values before anything is written.
+func validateEditBuffer(b *editBuffer, customStatuses []string) error {
+	if b.Title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+	if !types.Status(b.Status).IsValidWithCustom(customStatuses) {
+		return fmt.Errorf("invalid status %q", b.Status)
+	}
+	if b.Priority < 0 || b.Priority > 4 {
+		return fmt.Errorf("priority must be from 0 to 4, got %d", b.Priority)
+	}
+	for _, label := range b.Labels {
+		if strings.ContainsAny(label, " \t") {
+			return fmt.Errorf("label %q contains whitespace", label)
+		}
+	}
+	return nil
+}
+
+// normalizeEditLabels trims and de-duplicates labels, dropping empty ones.
+func normalizeEditLabels(labels []string) []string {
+	var out []string
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); label != "" && !slices.Contains(out, label) {
+			out = append(out, label)
+		}
+	}
+	return out
+}
+
+// editBufferChanges is what applying an edited buffer writes: scalar field
+// updates for UpdateIssue plus label additions and removals.
+type editBufferChanges struct {
+	Updates       map[string]interface{}
+	AddLabels     []string
+	RemoveLabels  []string
+	ChangedFields []string // buffer field names, in buffer order
+}
+
+func (c *editBufferChanges) empty() bool {
+	return len(c.ChangedFields) == 0
+}
+
+func diffEditBuffers(old, edited *editBuffer) *editBufferChanges {
+	c := &editBufferChanges{Updates: map[string]interface{}{}}
+	if edited.Title != old.Title {
+		c.Updates["title"] = edited.Title
+		c.ChangedFields = append(c.ChangedFields, "title")
+	}
+	if edited.Status != old.Status {
+		c.Updates["status"] = edited.Status
+		c.ChangedFields = append(c.ChangedFields, "status")
+	}
+	if edited.Priority != old.Priority {
+		c.Updates["priority"] = edited.Priority
+		c.ChangedFields = append(c.ChangedFields, "priority")
+	}
+	for _, label := range edited.Labels {
+		if !slices.Contains(old.Labels, label) {
+			c.AddLabels = append(c.AddLabels, label)
+		}
+	}
+	for _, label := range old.Labels {
+		if !slices.Contains(edited.Labels, label) {
+			c.RemoveLabels = append(c.RemoveLabels, label)
+		}
+	}
+	if len(c.AddLabels) > 0 || len(c.RemoveLabels) > 0 {
+		c.ChangedFields = append(c.ChangedFields, "labels")
+	}
+	if edited.Description != old.Description {
+		c.Updates["description"] = edited.Description
+		c.ChangedFields = append(c.ChangedFields, "description")
+	}
+	if edited.AcceptanceCriteria != old.AcceptanceCriteria {
+		c.Updates["acceptance_criteria"] = edited.AcceptanceCriteria
+		c.ChangedFields = append(c.ChangedFields, "acceptance criteria")
+	}
+	return c
+}
+
+// formatEditBufferDiff renders the changes between old and edited: one line
+// per scalar field and a line diff for the long-form fields.
+func formatEditBufferDiff(old, edited *editBuffer, c *editBufferChanges) string {
+	var sb strings.Builder
+	for _, field := range c.ChangedFields {
+		switch field {
+		case "title":
+			fmt.Fprintf(&sb, "title: %q → %q\n", old.Title, edited.Title)
+		case "status":
+			fmt.Fprintf(&sb, "status: %s → %s\n", old.Status, edited.Status)
+		case "priority":
+			fmt.Fprintf(&sb, "priority: P%d → P%d\n", old.Priority, edited.Priority)
+		case "labels":
+			var parts []string
+			for _, l := range c.AddLabels {
+				parts = append(parts, "+"+l)
+			}
+			for _, l := range c.RemoveLabels {
+				parts = append(parts, "-"+l)
+			}
+			fmt.Fprintf(&sb, "labels: %s\n", strings.Join(parts, " "))
+		case "description":
+			sb.WriteString("description:\n" + formatLineDiff(old.Description, edited.Description))
+		case "acceptance criteria":
+			sb.WriteString("acceptance criteria:\n" + formatLineDiff(old.AcceptanceCriteria, edited.AcceptanceCriteria))
+		}
+	}
+	return sb.String()
+}
+
+// formatLineDiff returns the lines removed from a ("  - ") and added in b
+// ("  + "), aligned on their longest common subsequence.
+func formatLineDiff(a, b string) string {
+	var al, bl []string
+	if a != "" {
+		al = strings.Split(a, "\n")
+	}
+	if b != "" {
+		bl = strings.Split(b, "\n")
+	}
+	// lcs[i][j] is the LCS length of al[i:] and bl[j:].
+	lcs := make([][]int, len(al)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bl)+1)
+	}
+	for i := len(al) - 1; i >= 0; i-- {
+		for j := len(bl) - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(al) || j < len(bl) {
+		switch {
+		case i < len(al) && j < len(bl) && al[i] == bl[j]:
+			i, j = i+1, j+1
+		case i < len(al) && (j == len(bl) || lcs[i+1][j] >= lcs[i][j+1]):
+			sb.WriteString("  - " + al[i] + "\n")
+			i++
+		default:
+			sb.WriteString("  + " + bl[j] + "\n")
+			j++
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEditBufferRoundTrip(t *testing.T) {
+	issue := &types.Issue{
+		Title:              "Fix login: redirect loop",
+		Status:             types.StatusInProgress,
+		Priority:           1,
+		Description:        "Steps:\n\n## Repro\n1. log in",
+		AcceptanceCriteria: "- no loop",
+	}
+	want := editBufferFromIssue(issue, []string{"auth", " backend", "auth"})
+	text := renderEditBuffer("bd-1", want, []string{"in_review"})
+	if !strings.Contains(text, "in_review") {
+		t.Errorf("custom statuses should be listed in the header:\n%s", text)
+	}
+
+	got, err := parseEditBuffer(text)
+	if err != nil {
+		t.Fatalf("parseEditBuffer: %v\n%s", err, text)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip:\ngot  %+v\nwant %+v", got, want)
+	}
+	if c := diffEditBuffers(want, got); !c.empty() {
+		t.Errorf("unchanged buffer reported changes: %v", c.ChangedFields)
+	}
+}
+
+func TestParseEditBufferErrors(t *testing.T) {
+	valid := renderEditBuffer("bd-1", &editBuffer{Title: "T", Status: "open", Priority: 2}, nil)
+	tests := []struct {
+		name, text, want string
+	}{
+		{"no separator", strings.Replace(valid, "\n---\n", "\n", 1), "missing \"---\""},
+		{"unknown field", strings.Replace(valid, "title: T", "title: T\nowner: me", 1), "unknown field \"owner\""},
+		{"duplicate field", strings.Replace(valid, "title: T", "title: T\ntitle: U", 1), "title is set twice"},
+		{"missing field", strings.Replace(valid, "priority: 2\n", "", 1), "missing priority"},
+		{"bad priority", strings.Replace(valid, "priority: 2", "priority: high", 1), "priority must be a number"},
+		{"no acceptance section", strings.Replace(valid, "## Acceptance Criteria", "## Acceptance", 1), "missing \"## Acceptance Criteria\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseEditBuffer(tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	if b, err := parseEditBuffer(strings.Replace(valid, "priority: 2", "priority: P0", 1)); err != nil || b.Priority != 0 {
+		t.Errorf("P-prefixed priority: %+v, %v", b, err)
+	}
+}
+
+func TestValidateEditBuffer(t *testing.T) {
+	ok := editBuffer{Title: "T", Status: "in_review", Priority: 4, Labels: []string{"a"}}
+	if err := validateEditBuffer(&ok, []string{"in_review"}); err != nil {
+		t.Errorf("valid buffer rejected: %v", err)
+	}
+	for name, mutate := range map[string]func(*editBuffer){
+		"empty title":  func(b *editBuffer) { b.Title = "" },
+		"bad status":   func(b *editBuffer) { b.Status = "done" },
+		"priority":     func(b *editBuffer) { b.Priority = 5 },
+		"label spaces": func(b *editBuffer) { b.Labels = []string{"two words"} },
+	} {
+		b := ok
+		mutate(&b)
+		if err := validateEditBuffer(&b, []string{"in_review"}); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestDiffEditBuffers(t *testing.T) {
+	old := &editBuffer{Title: "T", Status: "open", Priority: 2, Labels: []string{"a", "b"}, Description: "one\ntwo\nthree"}
+	edited := &editBuffer{Title: "T", Status: "blocked", Priority: 2, Labels: []string{"b", "c"}, Description: "one\n2\nthree"}
+	c := diffEditBuffers(old, edited)
+
+	if want := []string{"status", "labels", "description"}; !reflect.DeepEqual(c.ChangedFields, want) {
+		t.Errorf("changed fields = %v, want %v", c.ChangedFields, want)
+	}
+	if want := map[string]interface{}{"status": "blocked", "description": "one\n2\nthree"}; !reflect.DeepEqual(c.Updates, want) {
+		t.Errorf("updates = %v, want %v", c.Updates, want)
+	}
+	if !reflect.DeepEqual(c.AddLabels, []string{"c"}) || !reflect.DeepEqual(c.RemoveLabels, []string{"a"}) {
+		t.Errorf("labels: add %v remove %v", c.AddLabels, c.RemoveLabels)
+	}
+
+	diff := formatEditBufferDiff(old, edited, c)
+	for _, want := range []string{"status: open → blocked", "labels: +c -a", "  - two\n  + 2\n"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff missing %q:\n%s", want, diff)
+		}
+	}
+	if strings.Contains(diff, "one") {
+		t.Errorf("unchanged lines should not be in the diff:\n%s", diff)
+	}
+}
+
+func TestAnnotateEditBuffer(t *testing.T) {
+	text := annotateEditBuffer("title: x\n", errors.New("first"))
+	text = annotateEditBuffer(text, errors.New("second"))
+	if text != "# ERROR: second\ntitle: x\n" {
+		t.Errorf("annotated buffer = %q", text)
+	}
+	if !isEmptyEditBuffer("# only comments\n\n  \n") || isEmptyEditBuffer(text) {
+		t.Error("isEmptyEditBuffer misclassified a buffer")
+	}
+}
@@ -0,0 +1,101 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// bdEditWithScript runs "bd edit" with $EDITOR set to a shell script whose
+// body receives the buffer path as $1.
+func bdEditWithScript(t *testing.T, bd, dir, script string, args ...string) (string, error) {
+	t.Helper()
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(editor, []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatalf("write editor script: %v", err)
+	}
+	cmd := exec.Command(bd, append([]string{"edit"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(bdEnv(dir), "EDITOR="+editor, "VISUAL=")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestEmbeddedEditAll(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "ed")
+
+	t.Run("applies_all_changes", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Edit everything", "--description", "Old body", "--labels", "backend,legacy", "--priority", "2")
+		out, err := bdEditWithScript(t, bd, dir,
+			`sed -i -e 's/^status: open$/status: in_progress/' -e 's/^priority: 2$/priority: 1/' `+
+				`-e 's/^labels: .*/labels: backend, ui/' -e 's/^Old body$/New body/' "$1"`,
+			issue.ID, "--all")
+		if err != nil {
+			t.Fatalf("bd edit --all failed: %v\n%s", err, out)
+		}
+		for _, want := range []string{"status: open → in_progress", "priority: P2 → P1", "labels: +ui -legacy", "  - Old body", "  + New body"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("diff missing %q:\n%s", want, out)
+			}
+		}
+
+		got := bdShow(t, bd, dir, issue.ID)
+		if got.Status != types.StatusInProgress || got.Priority != 1 || got.Description != "New body" || got.Title != "Edit everything" {
+			t.Errorf("issue not updated: status=%s priority=%d description=%q title=%q", got.Status, got.Priority, got.Description, got.Title)
+		}
+		slices.Sort(got.Labels)
+		if !slices.Equal(got.Labels, []string{"backend", "ui"}) {
+			t.Errorf("labels = %v, want [backend ui]", got.Labels)
+		}
+	})
+
+	t.Run("unchanged_buffer", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Leave alone")
+		out, err := bdEditWithScript(t, bd, dir, "true", issue.ID, "--all")
+		if err != nil || !strings.Contains(out, "No changes made") {
+			t.Errorf("expected no changes, got err=%v:\n%s", err, out)
+		}
+	})
+
+	t.Run("empty_buffer_cancels", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Cancel me")
+		out, err := bdEditWithScript(t, bd, dir, `: > "$1"`, issue.ID, "--all")
+		if err != nil || !strings.Contains(out, "Edit cancelled") {
+			t.Errorf("expected cancel, got err=%v:\n%s", err, out)
+		}
+		if got := bdShow(t, bd, dir, issue.ID); got.Title != "Cancel me" {
+			t.Errorf("title changed on cancel: %q", got.Title)
+		}
+	})
+
+	t.Run("invalid_buffer_reopens_then_fails", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Bad priority")
+		// The script makes the same invalid change every time it runs, so the
+		// second save is identical to the annotated buffer and bd gives up.
+		out, err := bdEditWithScript(t, bd, dir,
+			`grep -q '^# ERROR: ' "$1" && echo reopened >&2; sed -i 's/^priority: 2$/priority: 9/' "$1"`,
+			issue.ID, "--all")
+		if err == nil {
+			t.Fatalf("expected invalid edit to fail:\n%s", out)
+		}
+		if !strings.Contains(out, "reopened") || !strings.Contains(out, "priority must be from 0 to 4") {
+			t.Errorf("expected the buffer to reopen with the error, got:\n%s", out)
+		}
+		if got := bdShow(t, bd, dir, issue.ID); got.Priority != 2 {
+			t.Errorf("priority changed by a rejected edit: %d", got.Priority)
+		}
+	})
+}
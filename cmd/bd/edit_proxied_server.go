@@ -14,6 +14,9 @@ import (
 
 func runEditProxiedServer(cmd *cobra.Command, ctx context.Context, args []string) error {
 	id := args[0]
+	if all, _ := cmd.Flags().GetBool("all"); all {
+		return HandleErrorRespectJSON("edit --all is not supported in proxied-server mode")
+	}
 
 	fieldToEdit := "description"
 	switch {
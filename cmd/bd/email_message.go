@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// emailMessage is the part of an RFC 822 message that bd ingest email
+// turns into an issue or a comment.
+type emailMessage struct {
+	MessageID   string
+	InReplyTo   string
+	References  []string
+	From        string // as written, e.g. "Ada Lovelace <ada@example.com>"
+	FromAddress string // bare address, e.g. "ada@example.com"
+	Subject     string
+	Date        time.Time
+	Body        string
+	Attachments []emailAttachment
+}
+
+type emailAttachment struct {
+	Name      string
+	MediaType string
+	Data      []byte
+}
+
+// threadIDs returns the message IDs this message replies to, nearest
+// first: In-Reply-To, then References from the most recent back to the
+// thread root.
+func (m *emailMessage) threadIDs() []string {
+	var ids []string
+	seen := map[string]bool{m.MessageID: true}
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	add(m.InReplyTo)
+	for i := len(m.References) - 1; i >= 0; i-- {
+		add(m.References[i])
+	}
+	return ids
+}
+
+var emailWordDecoder = &mime.WordDecoder{}
+
+// parseEmailMessage reads one RFC 822 message. The body is the first
+// text/plain part (or text/html with the markup stripped when there is
+// none); parts with a filename, or marked as attachments, are returned as
+// attachments.
+func parseEmailMessage(r io.Reader) (*emailMessage, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing message: %w", err)
+	}
+	m := &emailMessage{
+		MessageID: firstMessageID(msg.Header.Get("Message-Id")),
+		InReplyTo: firstMessageID(msg.Header.Get("In-Reply-To")),
+	}
+	m.References = messageIDs(msg.Header.Get("References"))
+	if subject, err := emailWordDecoder.DecodeHeader(msg.Header.Get("Subject")); err == nil {
+		m.Subject = strings.Join(strings.Fields(subject), " ")
+	} else {
+		m.Subject = strings.Join(strings.Fields(msg.Header.Get("Subject")), " ")
+	}
+	if from := msg.Header.Get("From"); from != "" {
+		addr, err := (&mail.AddressParser{WordDecoder: emailWordDecoder}).Parse(from)
+		if err == nil {
+			m.FromAddress = strings.ToLower(addr.Address)
+			m.From = addr.Address
+			if addr.Name != "" {
+				m.From = fmt.Sprintf("%s <%s>", addr.Name, addr.Address)
+			}
+		} else {
+			m.From = strings.TrimSpace(from)
+		}
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		m.Date = date
+	}
+
+	var plain, htmlBody string
+	err = walkEmailPart(msg.Header, msg.Body, func(header mimeHeader, data []byte) {
+		mediaType, params, _ := mime.ParseMediaType(header.Get("Content-Type"))
+		if mediaType == "" {
+			mediaType = "text/plain"
+		}
+		disposition, dparams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+		name := dparams["filename"]
+		if name == "" {
+			name = params["name"]
+		}
+		if name != "" {
+			if decoded, err := emailWordDecoder.DecodeHeader(name); err == nil {
+				name = decoded
+			}
+		}
+		if disposition == "attachment" || name != "" {
+			if name == "" {
+				name = "attachment"
+				if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+					name += exts[0]
+				}
+			}
+			m.Attachments = append(m.Attachments, emailAttachment{Name: name, MediaType: mediaType, Data: data})
+			return
+		}
+		switch {
+		case mediaType == "text/plain" && plain == "":
+			plain = decodeEmailCharset(data, params["charset"])
+		case mediaType == "text/html" && htmlBody == "":
+			htmlBody = decodeEmailCharset(data, params["charset"])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if plain == "" && htmlBody != "" {
+		plain = stripEmailHTML(htmlBody)
+	}
+	m.Body = strings.TrimSpace(strings.ReplaceAll(plain, "\r\n", "\n"))
+	return m, nil
+}
+
+// mimeHeader is the header lookup shared by mail.Header and the
+// textproto.MIMEHeader of multipart parts.
+type mimeHeader interface {
+	Get(key string) string
+}
+
+// walkEmailPart decodes a MIME entity and calls leaf for every non-multipart
+// part with its transfer encoding removed, descending into nested
+// multiparts (mixed, alternative, related, ...).
+func walkEmailPart(header mimeHeader, body io.Reader, leaf func(mimeHeader, []byte)) error {
+	mediaType, params, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("reading %s part: %w", mediaType, err)
+			}
+			if err := walkEmailPart(part.Header, part, leaf); err != nil {
+				return err
+			}
+		}
+	}
+
+	var r io.Reader = body
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		r = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		r = quotedprintable.NewReader(body)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("decoding %s part: %w", mediaType, err)
+	}
+	leaf(header, data)
+	return nil
+}
+
+// decodeEmailCharset converts a text part to UTF-8. UTF-8, US-ASCII and
+// Latin-1 are handled; other charsets pass through unchanged.
+func decodeEmailCharset(data []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	return string(bytes.ToValidUTF8(data, []byte("�")))
+}
+
+var (
+	emailHTMLBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>|</tr>`)
+	emailHTMLTags   = regexp.MustCompile(`(?s)<[^>]*>`)
+	emailHTMLDrop   = regexp.MustCompile(`(?is)<(style|script|head)\b.*?</(style|script|head)>`)
+	emailBlankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// stripEmailHTML reduces an HTML body to readable text.
+func stripEmailHTML(s string) string {
+	s = emailHTMLDrop.ReplaceAllString(s, "")
+	s = emailHTMLBreaks.ReplaceAllString(s, "\n")
+	s = emailHTMLTags.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return emailBlankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+}
+
+var emailMessageIDPattern = regexp.MustCompile(`<([^<>\s]+)>`)
+
+// messageIDs extracts the <...> message IDs from a header value.
+func messageIDs(value string) []string {
+	var ids []string
+	for _, match := range emailMessageIDPattern.FindAllStringSubmatch(value, -1) {
+		ids = append(ids, match[1])
+	}
+	return ids
+}
+
+// firstMessageID returns the first message ID in a header value, accepting
+// an ID without angle brackets as some clients write them.
+func firstMessageID(value string) string {
+	if ids := messageIDs(value); len(ids) > 0 {
+		return ids[0]
+	}
+	if fields := strings.Fields(value); len(fields) == 1 {
+		return fields[0]
+	}
+	return ""
+}
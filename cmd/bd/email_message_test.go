@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const testMultipartEmail = "From: =?UTF-8?Q?Ad=C3=A1_Lovelace?= <Ada@Example.com>\r\n" +
+	"To: bugs@example.com\r\n" +
+	"Subject: =?UTF-8?Q?Crash_on_=E2=80=9Csave=E2=80=9D?=\r\n" +
+	"Date: Mon, 03 Mar 2025 10:00:00 +0000\r\n" +
+	"Message-ID: <root-1@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=outer\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=inner\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"The app crashes when I press =E2=80=9Csave=E2=80=9D.=\r\n" +
+	" Steps below.\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html\r\n" +
+	"\r\n" +
+	"<p>ignored</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: text/plain; name=\"trace.log\"\r\n" +
+	"Content-Disposition: attachment; filename=\"trace.log\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"cGFuaWM6IG5p\r\n" +
+	"bCBwb2ludGVy\r\n" +
+	"--outer--\r\n"
+
+func TestParseEmailMessage(t *testing.T) {
+	msg, err := parseEmailMessage(strings.NewReader(testMultipartEmail))
+	if err != nil {
+		t.Fatalf("parseEmailMessage: %v", err)
+	}
+	if msg.Subject != "Crash on “save”" {
+		t.Errorf("subject = %q", msg.Subject)
+	}
+	if msg.From != "Adá Lovelace <Ada@Example.com>" || msg.FromAddress != "ada@example.com" {
+		t.Errorf("from = %q / %q", msg.From, msg.FromAddress)
+	}
+	if msg.MessageID != "root-1@example.com" || msg.Date.IsZero() {
+		t.Errorf("message id %q date %v", msg.MessageID, msg.Date)
+	}
+	if msg.Body != "The app crashes when I press “save”. Steps below." {
+		t.Errorf("body = %q", msg.Body)
+	}
+	if len(msg.Attachments) != 1 || msg.Attachments[0].Name != "trace.log" || string(msg.Attachments[0].Data) != "panic: nil pointer" {
+		t.Errorf("attachments = %+v", msg.Attachments)
+	}
+}
+
+func TestParseEmailMessageHTMLOnlyReply(t *testing.T) {
+	raw := "From: bob@example.com\r\n" +
+		"Subject: Re: Crash\r\n" +
+		"Message-ID: <reply-2@example.com>\r\n" +
+		"In-Reply-To: <reply-1@example.com>\r\n" +
+		"References: <root-1@example.com> <reply-1@example.com>\r\n" +
+		"Content-Type: text/html; charset=iso-8859-1\r\n" +
+		"\r\n" +
+		"<html><head><style>p{}</style></head><body><p>Still broken &amp; caf\xe9</p><p>Bob</p></body></html>\r\n"
+	msg, err := parseEmailMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("parseEmailMessage: %v", err)
+	}
+	if msg.Body != "Still broken & café\nBob" {
+		t.Errorf("body = %q", msg.Body)
+	}
+	if got := strings.Join(msg.threadIDs(), " "); got != "reply-1@example.com root-1@example.com" {
+		t.Errorf("threadIDs = %q, want nearest first without duplicates", got)
+	}
+}
@@ -2,11 +2,16 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
-	"os"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 var epicCmd = &cobra.Command{
@@ -15,8 +20,12 @@ var epicCmd = &cobra.Command{
 	Short:   "Epic management commands",
 }
 var epicStatusCmd = &cobra.Command{
-	Use:           "status",
-	Short:         "Show epic completion status",
+	Use:   "status [epic-id...]",
+	Short: "Show epic completion status",
+	Long: `Show progress for open epics, or only the given ones: children closed,
+in progress, and blocked (status blocked or waiting on an open blocker), and a
+projected finish date extrapolated from how fast children have closed since the
+epic was created.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -30,21 +39,32 @@ var epicStatusCmd = &cobra.Command{
 		eligibleOnly, _ := cmd.Flags().GetBool("eligible-only")
 
 		if usesProxiedServer() {
-			return runEpicStatusProxiedServer(rootCtx, eligibleOnly)
+			return runEpicStatusProxiedServer(rootCtx, eligibleOnly, args)
 		}
 
+		epicIDs := make([]string, 0, len(args))
+		for _, arg := range args {
+			id, err := utils.ResolvePartialID(rootCtx, store, arg)
+			if err != nil {
+				return HandleErrorRespectJSON("resolving %s: %v", arg, err)
+			}
+			epicIDs = append(epicIDs, id)
+		}
 		epics, err := store.GetEpicsEligibleForClosure(rootCtx)
 		if err != nil {
 			return HandleErrorRespectJSON("getting epic status: %v", err)
 		}
-		return renderEpicStatus(epics, eligibleOnly)
+		return renderEpicStatus(epics, eligibleOnly, epicIDs)
 	},
 }
 
-func renderEpicStatus(epics []*types.EpicStatus, eligibleOnly bool) error {
+func renderEpicStatus(epics []*types.EpicStatus, eligibleOnly bool, epicIDs []string) error {
 	if eligibleOnly {
 		epics = filterEligibleEpics(epics)
 	}
+	if len(epicIDs) > 0 {
+		epics = filterEpicsByID(epics, epicIDs)
+	}
 	if jsonOutput {
 		if epics == nil {
 			epics = []*types.EpicStatus{}
@@ -72,6 +92,16 @@ func renderEpicStatus(epics []*types.EpicStatus, eligibleOnly bool) error {
 		fmt.Printf("%s %s %s\n", statusIcon, ui.RenderAccent(epic.ID), ui.RenderBold(epic.Title))
 		fmt.Printf("   Progress: %d/%d children closed (%d%%)\n",
 			epicStatus.ClosedChildren, epicStatus.TotalChildren, percentage)
+		if epicStatus.InProgressChildren > 0 || epicStatus.BlockedChildren > 0 {
+			blocked := fmt.Sprintf("%d blocked", epicStatus.BlockedChildren)
+			if epicStatus.BlockedChildren > 0 {
+				blocked = ui.RenderWarn(blocked)
+			}
+			fmt.Printf("   Open: %d in progress, %s\n", epicStatus.InProgressChildren, blocked)
+		}
+		if epicStatus.ProjectedFinish != nil {
+			fmt.Printf("   Projected finish: %s\n", epicStatus.ProjectedFinish.Local().Format("2006-01-02"))
+		}
 		if epicStatus.EligibleForClose {
 			fmt.Printf("   %s\n", ui.RenderPass("Eligible for closure"))
 		}
@@ -90,6 +120,180 @@ func filterEligibleEpics(epics []*types.EpicStatus) []*types.EpicStatus {
 	return filtered
 }
 
+// filterEpicsByID keeps the epics whose ID is in ids, in the order given.
+func filterEpicsByID(epics []*types.EpicStatus, ids []string) []*types.EpicStatus {
+	byID := make(map[string]*types.EpicStatus, len(epics))
+	for _, epic := range epics {
+		byID[epic.Epic.ID] = epic
+	}
+	filtered := []*types.EpicStatus{}
+	for _, id := range ids {
+		if epic, ok := byID[id]; ok {
+			filtered = append(filtered, epic)
+		}
+	}
+	return filtered
+}
+
+var epicCreateCmd = &cobra.Command{
+	Use:   "create [title]",
+	Short: "Create an epic",
+	Long: `Create an issue of type epic. Add children with 'bd epic add' (or
+'bd create --parent'), and follow progress with 'bd epic status'.
+
+Example:
+  bd epic create "Q3 auth overhaul" -d "Replace session tokens" -p 1`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("epic-create")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		CheckReadonly("epic create")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("epic create is not supported in proxied-server mode (use bd create --type epic)")
+		}
+
+		priorityStr, _ := cmd.Flags().GetString("priority")
+		description, _ := cmd.Flags().GetString("description")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		priority, err := validation.ValidatePriority(priorityStr)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		ctx := rootCtx
+		epic := &types.Issue{
+			Title:       strings.Join(args, " "),
+			Description: description,
+			Status:      types.StatusOpen,
+			Priority:    priority,
+			IssueType:   types.TypeEpic,
+			Labels:      labels,
+			CreatedBy:   getActorWithGit(),
+			Owner:       getOwner(),
+		}
+		if err := createIssueWithDeps(ctx, store, epic, actor, createDepEdges{}); err != nil {
+			return HandleErrorRespectJSON("creating epic: %v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(epic.ID)
+
+		if jsonOutput {
+			return outputJSON(epic)
+		}
+		fmt.Printf("%s Created epic: %s\n", ui.RenderPass("✓"), formatFeedbackID(epic.ID, epic.Title))
+		return nil
+	},
+}
+
+var epicAddCmd = &cobra.Command{
+	Use:   "add [epic-id] [issue-id...]",
+	Short: "Add issues to an epic",
+	Long: `Make each issue a child of the epic. An issue that already has another
+parent is moved; one already under this epic is left alone. All issues are
+added in one transaction.`,
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("epic-add")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		CheckReadonly("epic add")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("epic add is not supported in proxied-server mode (use bd update --parent)")
+		}
+
+		ctx := rootCtx
+		epicID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving epic %s: %v", args[0], err)
+		}
+		epic, err := store.GetIssue(ctx, epicID)
+		if err != nil {
+			return HandleErrorRespectJSON("getting epic %s: %v", epicID, err)
+		}
+		if epic.IssueType != types.TypeEpic {
+			return HandleErrorRespectJSON("%s is a %s, not an epic", epicID, epic.IssueType)
+		}
+		childIDs, err := resolveLabelIssueIDs(ctx, "add", args[1:])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		var added, moved, unchanged []string
+		commitMsg := fmt.Sprintf("bd: add %d issue(s) to epic %s", len(childIDs), epicID)
+		err = transactHonoringAutoCommit(ctx, store, commitMsg, func(tx storage.Transaction) error {
+			added, moved, unchanged = nil, nil, nil
+			for _, childID := range childIDs {
+				if childID == epicID {
+					return fmt.Errorf("cannot add epic %s to itself", epicID)
+				}
+				deps, err := tx.GetDependencyRecords(ctx, childID)
+				if err != nil {
+					return fmt.Errorf("getting dependencies of %s: %w", childID, err)
+				}
+				already := false
+				for _, dep := range deps {
+					if dep.Type != types.DepParentChild {
+						continue
+					}
+					if dep.DependsOnID == epicID {
+						already = true
+						continue
+					}
+					if err := tx.RemoveDependency(ctx, childID, dep.DependsOnID, actor); err != nil {
+						return fmt.Errorf("removing %s from parent %s: %w", childID, dep.DependsOnID, err)
+					}
+					moved = append(moved, childID)
+				}
+				if already {
+					unchanged = append(unchanged, childID)
+					continue
+				}
+				dep := &types.Dependency{IssueID: childID, DependsOnID: epicID, Type: types.DepParentChild}
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("adding %s to epic %s: %w", childID, epicID, err)
+				}
+				added = append(added, childID)
+			}
+			return nil
+		})
+		if err != nil {
+			return HandleErrorRespectJSON("epic add: %v", err)
+		}
+		if len(added) > 0 {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"epic":      epicID,
+				"added":     append([]string{}, added...),
+				"moved":     append([]string{}, moved...),
+				"unchanged": append([]string{}, unchanged...),
+			})
+		}
+		for _, id := range added {
+			fmt.Printf("%s Added %s to epic %s\n", ui.RenderPass("✓"), id, epicID)
+		}
+		for _, id := range unchanged {
+			fmt.Printf("%s already in epic %s\n", id, epicID)
+		}
+		return nil
+	},
+}
+
 var closeEligibleEpicsCmd = &cobra.Command{
 	Use:           "close-eligible",
 	Short:         "Close epics where all children are complete",
@@ -173,6 +377,14 @@ func outputCloseEligibleResult(closedIDs []string) error {
 }
 
 func init() {
+	epicCreateCmd.Flags().StringP("description", "d", "", "Epic description")
+	epicCreateCmd.Flags().StringP("priority", "p", "2", "Priority (0-4 or P0-P4)")
+	epicCreateCmd.Flags().StringSliceP("labels", "l", []string{}, "Labels")
+	epicStatusCmd.ValidArgsFunction = issueIDCompletion
+	epicAddCmd.ValidArgsFunction = issueIDCompletion
+
+	epicCmd.AddCommand(epicCreateCmd)
+	epicCmd.AddCommand(epicAddCmd)
 	epicCmd.AddCommand(epicStatusCmd)
 	epicCmd.AddCommand(closeEligibleEpicsCmd)
 	epicStatusCmd.Flags().Bool("eligible-only", false, "Show only epics eligible for closure")
@@ -180,6 +180,63 @@ func TestEmbeddedEpic(t *testing.T) {
 	})
 }
 
+// TestEmbeddedEpicCreateAdd covers bd epic create, bd epic add, and the
+// in-progress/blocked/projection fields of bd epic status.
+func TestEmbeddedEpicCreateAdd(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "ea")
+
+	created, ok := bdEpicJSON(t, bd, dir, "create", "Auth overhaul", "-p", "1").(map[string]interface{})
+	if !ok || created["issue_type"] != "epic" {
+		t.Fatalf("epic create returned %v", created)
+	}
+	epicID := created["id"].(string)
+
+	other := bdCreate(t, bd, dir, "Other epic", "--type", "epic")
+	done := bdCreate(t, bd, dir, "Done", "--type", "task")
+	doing := bdCreate(t, bd, dir, "Doing", "--type", "task")
+	stuck := bdCreate(t, bd, dir, "Stuck", "--type", "task")
+	blocker := bdCreate(t, bd, dir, "Blocker", "--type", "task")
+	bdDep(t, bd, dir, "add", doing.ID, other.ID, "--type", "parent-child")
+
+	res := bdEpicJSON(t, bd, dir, "add", epicID, done.ID, doing.ID, stuck.ID).(map[string]interface{})
+	if len(res["added"].([]interface{})) != 3 || len(res["moved"].([]interface{})) != 1 {
+		t.Fatalf("epic add = %v, want 3 added, 1 moved", res)
+	}
+	res = bdEpicJSON(t, bd, dir, "add", epicID, done.ID).(map[string]interface{})
+	if len(res["unchanged"].([]interface{})) != 1 {
+		t.Fatalf("re-adding = %v, want 1 unchanged", res)
+	}
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "epic", "add", done.ID, stuck.ID); err == nil {
+		t.Fatalf("epic add onto a task succeeded:\n%s", out)
+	}
+
+	bdUpdate(t, bd, dir, doing.ID, "--status", "in_progress")
+	bdDep(t, bd, dir, "add", stuck.ID, blocker.ID)
+	bdClose(t, bd, dir, done.ID)
+
+	arr := bdEpicJSON(t, bd, dir, "status", epicID).([]interface{})
+	if len(arr) != 1 {
+		t.Fatalf("status %s returned %d epics, want 1", epicID, len(arr))
+	}
+	st := arr[0].(map[string]interface{})
+	if st["total_children"] != float64(3) || st["closed_children"] != float64(1) ||
+		st["in_progress_children"] != float64(1) || st["blocked_children"] != float64(1) {
+		t.Errorf("status = %v, want 3 total, 1 closed, 1 in progress, 1 blocked", st)
+	}
+	if st["projected_finish"] == nil {
+		t.Errorf("status lacks projected_finish: %v", st)
+	}
+	if out := bdEpic(t, bd, dir, "status", epicID); !strings.Contains(out, "Projected finish") || !strings.Contains(out, "1 blocked") {
+		t.Errorf("status text lacks blocked count or projection:\n%s", out)
+	}
+}
+
 // TestEmbeddedEpicConcurrent exercises epic operations concurrently.
 func TestEmbeddedEpicConcurrent(t *testing.T) {
 	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
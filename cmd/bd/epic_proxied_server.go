@@ -9,7 +9,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage/uow"
 )
 
-func runEpicStatusProxiedServer(ctx context.Context, eligibleOnly bool) error {
+func runEpicStatusProxiedServer(ctx context.Context, eligibleOnly bool, epicIDs []string) error {
 	uw, err := openProxiedListUOW(ctx)
 	if err != nil {
 		return HandleError("%v", err)
@@ -20,7 +20,7 @@ func runEpicStatusProxiedServer(ctx context.Context, eligibleOnly bool) error {
 	if err != nil {
 		return HandleErrorRespectJSON("getting epic status: %v", err)
 	}
-	return renderEpicStatus(epics, eligibleOnly)
+	return renderEpicStatus(epics, eligibleOnly, epicIDs)
 }
 
 func runCloseEligibleEpicsProxiedServer(ctx context.Context, dryRun bool) error {
@@ -229,7 +229,7 @@ func TestEpicCommandInit(t *testing.T) {
 
 	var hasStatusCmd bool
 	for _, cmd := range epicCmd.Commands() {
-		if cmd.Use == "status" {
+		if cmd.Name() == "status" {
 			hasStatusCmd = true
 		}
 	}
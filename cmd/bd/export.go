@@ -6,14 +6,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/query"
 	"github.com/steveyegge/beads/internal/storage/domain"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -39,12 +42,53 @@ Memories (from 'bd remember') are excluded by default because they may
 contain sensitive agent context. Use --include-memories or --all to
 include them.
 
+With --format csv, issues are written as CSV (one row per issue, header
+row first) for spreadsheets; 'bd import --format csv' reads it back.
+Labels and blocking dependencies are comma-separated within their cells.
+Comments and memories are not included.
+
+--format org writes an Emacs org-mode outline: a headline per issue with
+its TODO state (TODO, STARTED, BLOCKED, DEFERRED, DONE), priority [#A]-[#E]
+for P0-P4, labels as tags, due/defer dates as DEADLINE/SCHEDULED, and the
+bd ID in an :ID: property; children nest under their parent. --format
+taskwarrior writes JSON for 'task import', with stable task UUIDs derived
+from bd IDs so re-importing updates tasks, blockers as depends, and the bd
+ID in a bdid attribute.
+
+--format ics writes an iCalendar feed of deadlines for calendar apps to
+subscribe to: one event per issue with a due date (all-day when due at
+midnight), milestones marked as such, with the bd ID as the event UID so
+regenerated feeds update events in place. Issues without a due date are
+left out.
+
+--filter scopes the export with a 'bd query' expression, e.g.
+--filter "status!=closed AND label=backend". Unlike 'bd query', closed
+issues are not excluded unless the expression says so. --assignee and
+--label (repeatable, all must match) are shorthands for the common cases.
+
+--schema-version 2 writes JSONL format v2, built to keep git diffs small:
+a header record ({"_schema":"beads-jsonl/2","schema_version":2,
+"issue_prefix":...}) first, issues sorted by ID with object keys in
+canonical (sorted) order, labels and dependencies sorted, and no derived
+dependency/comment counts. 'bd import' reads both versions and refuses
+files from a newer format. --shard status|id (implies v2) splits the export
+into files next to -o: issues-open.jsonl and issues-closed.jsonl, or one
+file per leading ID character; label definitions and memories go to
+issues-meta.jsonl. Import the shards together with
+'bd import issues-*.jsonl' so dependencies between them resolve.
+
 EXAMPLES:
   bd export                              # Export issues to stdout
   bd export -o issues.jsonl              # Export issues to file
   bd export --include-memories           # Export issues + memories
   bd export --all -o full.jsonl          # Include infra + templates + gates + memories
-  bd export --scrub -o clean.jsonl       # Exclude test/pollution records`,
+  bd export --scrub -o clean.jsonl       # Exclude test/pollution records
+  bd export --format csv -o issues.csv   # Spreadsheet-friendly CSV
+  bd export --format org --filter "status!=closed" -o ~/org/beads.org
+  bd export --format taskwarrior | task import -
+  bd export --format ics --assignee alice -o ~/Calendars/beads.ics
+  bd export --schema-version 2 -o .beads/issues.jsonl   # Diff-friendly v2
+  bd export --shard status -o .beads/issues.jsonl       # issues-open/-closed.jsonl`,
 	GroupID:       "sync",
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -60,6 +104,12 @@ var (
 	exportIncludeMemories bool
 	exportExcludeOwners   []string
 	exportVerbose         bool
+	exportFormat          string
+	exportSchemaVersion   int
+	exportShard           string
+	exportFilter          string
+	exportAssignee        string
+	exportLabels          []string
 )
 
 func init() {
@@ -72,6 +122,12 @@ func init() {
 	_ = exportCmd.Flags().MarkHidden("no-memories")
 	exportCmd.Flags().StringArrayVar(&exportExcludeOwners, "exclude-owner", nil, "Exclude issues created by this identity (repeatable; also reads export.exclude_owners config)")
 	exportCmd.Flags().BoolVar(&exportVerbose, "verbose", false, "Print filtered issue count when owners are excluded")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "jsonl", "Output format: jsonl, csv, org, taskwarrior, or ics")
+	exportCmd.Flags().IntVar(&exportSchemaVersion, "schema-version", 1, "JSONL format version: 1, or 2 (header record, canonical ordering)")
+	exportCmd.Flags().StringVar(&exportShard, "shard", "", "Split a v2 export into files by status or id next to -o (e.g. issues-open.jsonl)")
+	exportCmd.Flags().StringVar(&exportFilter, "filter", "", "Only export issues matching a query expression (see 'bd query')")
+	exportCmd.Flags().StringVar(&exportAssignee, "assignee", "", "Only export issues assigned to this person")
+	exportCmd.Flags().StringArrayVar(&exportLabels, "label", nil, "Only export issues with this label (repeatable; all must match)")
 	rootCmd.AddCommand(exportCmd)
 }
 
@@ -88,12 +144,44 @@ func runExport(cmd *cobra.Command, args []string) error {
 
 	ctx := rootCtx
 
+	writeFlat, isFlat := exportFlatFormats[exportFormat]
+	if exportFormat != "jsonl" && !isFlat {
+		return HandleErrorRespectJSON("unknown export format %q (want jsonl, csv, org, taskwarrior, or ics)", exportFormat)
+	}
+	var matchesFilter func(*types.Issue) bool
+	if exportFilter != "" {
+		node, err := query.Parse(exportFilter)
+		if err != nil {
+			return HandleErrorRespectJSON("parsing --filter: %v", err)
+		}
+		matchesFilter, err = query.NewEvaluator(time.Now()).WithMe(resolveMe).Predicate(node)
+		if err != nil {
+			return HandleErrorRespectJSON("invalid --filter: %v", err)
+		}
+	}
+	schemaVersion := exportSchemaVersion
+	if schemaVersion != 1 && schemaVersion != jsonlSchemaVersion {
+		return HandleErrorRespectJSON("unsupported --schema-version %d (want 1 or 2)", schemaVersion)
+	}
+	if exportShard != "" {
+		if !slices.Contains(exportShardModes, exportShard) {
+			return HandleErrorRespectJSON("unknown --shard %q (want %s)", exportShard, strings.Join(exportShardModes, " or "))
+		}
+		if exportOutput == "" {
+			return HandleErrorRespectJSON("--shard needs -o <path>; shard files are written next to it")
+		}
+		schemaVersion = jsonlSchemaVersion
+	}
+	if exportFormat != "jsonl" && (schemaVersion != 1 || exportShard != "") {
+		return HandleErrorRespectJSON("--schema-version and --shard only apply to JSONL exports")
+	}
+
 	// Determine output destination. File output uses atomic writes
 	// (temp file + rename) so concurrent exports and crashes never
 	// leave a truncated or interleaved JSONL file.
 	var w io.Writer
 	var aw *atomicfile.Writer
-	if exportOutput != "" {
+	if exportOutput != "" && exportShard == "" {
 		var err error
 		aw, err = atomicfile.Create(exportOutput, 0o644)
 		if err != nil {
@@ -115,6 +203,10 @@ func runExport(cmd *cobra.Command, args []string) error {
 		Limit:         0,
 		MaxRows:       0,
 		MaxRowsSource: "",
+		Labels:        exportLabels,
+	}
+	if exportAssignee != "" {
+		filter.Assignee = &exportAssignee
 	}
 
 	// Exclude infra types by default (agents, roles, messages).
@@ -186,6 +278,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 	labelsMap, _ := store.GetLabelsForIssues(ctx, issueIDs)
 	allDeps, _ := store.GetDependencyRecordsForIssues(ctx, issueIDs)
 	commentsMap, _ := store.GetCommentsForIssues(ctx, issueIDs)
+	attachmentsMap := getAttachmentsForIssues(ctx, store, issueIDs)
 	commentCounts, _ := store.GetCommentCounts(ctx, issueIDs)
 	depCounts, _ := store.GetDependencyCounts(ctx, issueIDs)
 
@@ -194,6 +287,28 @@ func runExport(cmd *cobra.Command, args []string) error {
 		issue.Labels = labelsMap[issue.ID]
 		issue.Dependencies = allDeps[issue.ID]
 		issue.Comments = commentsMap[issue.ID]
+		issue.Attachments = attachmentsMap[issue.ID]
+	}
+
+	if matchesFilter != nil {
+		matched := issues[:0]
+		for _, issue := range issues {
+			if matchesFilter(issue) {
+				matched = append(matched, issue)
+			}
+		}
+		issues = matched
+	}
+
+	if isFlat {
+		return finishFlatExport(w, aw, issues, writeFlat)
+	}
+	includeMemories := (exportIncludeMemories || exportAll) && !exportNoMemories
+	if exportShard != "" {
+		return finishShardedExport(ctx, exportOutput, exportShard, issues, includeMemories)
+	}
+	if schemaVersion == jsonlSchemaVersion {
+		return finishJSONLv2Export(ctx, w, aw, issues, includeMemories)
 	}
 
 	// Write JSONL: one JSON object per line
@@ -232,6 +347,10 @@ func runExport(cmd *cobra.Command, args []string) error {
 		count++
 	}
 
+	if _, err := writeLabelDefinitionRecords(w, getLabelDefinitionsForExport(ctx, store)); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
 	// Export memories only when explicitly requested (GH#3650).
 	// Memories may contain sensitive agent context and are excluded by default.
 	memoryCount := 0
@@ -276,6 +395,20 @@ func runExport(cmd *cobra.Command, args []string) error {
 		if err := aw.Close(); err != nil {
 			return HandleErrorRespectJSON("failed to finalize export file: %v", err)
 		}
+		// Writing the configured export file by hand brings it up to date just
+		// like auto-export does; record that so the auto-export throttle and
+		// 'bd doctor' see the file as current.
+		if beadsDir := beads.FindBeadsDir(); beadsDir != "" && isConfiguredExportPath(beadsDir, exportOutput) {
+			if stateHash, err := storeStateHash(ctx); err == nil {
+				saveExportAutoState(beadsDir, &exportAutoState{
+					LastDoltCommit: stateHash,
+					Timestamp:      time.Now(),
+					Issues:         count,
+					Memories:       memoryCount,
+					JSONLHash:      fileSHA256(exportOutput),
+				})
+			}
+		}
 	}
 
 	// Print summary to stderr (not stdout, to avoid mixing with JSONL)
@@ -293,6 +426,31 @@ func runExport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// exportFlatFormats are the non-JSONL export formats.
+var exportFlatFormats = map[string]func(io.Writer, []*types.Issue) error{
+	"csv":         writeIssuesCSV,
+	"org":         writeIssuesOrg,
+	"taskwarrior": writeIssuesTaskwarrior,
+	"ics":         writeIssuesICS,
+}
+
+// finishFlatExport writes issues in a non-JSONL format and finalizes the
+// output file. These formats carry issues only: memories and label
+// definitions have no place in them, and the file never counts as the
+// auto-export JSONL.
+func finishFlatExport(w io.Writer, aw *atomicfile.Writer, issues []*types.Issue, write func(io.Writer, []*types.Issue) error) error {
+	if err := write(w, issues); err != nil {
+		return HandleErrorRespectJSON("failed to write %s: %v", exportFormat, err)
+	}
+	if aw != nil {
+		if err := aw.Close(); err != nil {
+			return HandleErrorRespectJSON("failed to finalize export file: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d issues to %s\n", len(issues), exportOutput)
+	}
+	return nil
+}
+
 // exportIssueRecord wraps IssueWithCounts with a _type discriminator so that
 // every line in the JSONL export is self-describing. Memory lines already
 // carry "_type":"memory"; this gives issue lines "_type":"issue". (GH#3271)
@@ -3,6 +3,8 @@ package main
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -27,6 +29,10 @@ type exportAutoState struct {
 	Timestamp      time.Time `json:"timestamp"`
 	Issues         int       `json:"issues"`
 	Memories       int       `json:"memories"`
+	// JSONLHash is the SHA-256 of the export file as written, so a later
+	// change to the file (e.g. a git pull) can be told apart from a change
+	// to the database. Empty for states recorded by older versions.
+	JSONLHash string `json:"jsonl_hash,omitempty"`
 }
 
 const exportAutoStateFile = "export-state.json"
@@ -159,6 +165,7 @@ func maybeAutoExport(ctx context.Context, allowEmptyOverwrite bool) error {
 		Timestamp:      time.Now(),
 		Issues:         issueCount,
 		Memories:       memoryCount,
+		JSONLHash:      fileSHA256(fullPath),
 	}
 	saveExportAutoState(beadsDir, &newState)
 	return nil
@@ -395,6 +402,7 @@ func exportToFile(ctx context.Context, path string, includeMemories bool) (issue
 		labelsMap, _ := store.GetLabelsForIssues(ctx, issueIDs)
 		allDeps, _ := store.GetDependencyRecordsForIssues(ctx, issueIDs)
 		commentsMap, _ := store.GetCommentsForIssues(ctx, issueIDs)
+		attachmentsMap := getAttachmentsForIssues(ctx, store, issueIDs)
 		commentCounts, _ := store.GetCommentCounts(ctx, issueIDs)
 		depCounts, _ := store.GetDependencyCounts(ctx, issueIDs)
 
@@ -402,6 +410,7 @@ func exportToFile(ctx context.Context, path string, includeMemories bool) (issue
 			issue.Labels = labelsMap[issue.ID]
 			issue.Dependencies = allDeps[issue.ID]
 			issue.Comments = commentsMap[issue.ID]
+			issue.Attachments = attachmentsMap[issue.ID]
 		}
 
 		// Write issues
@@ -428,6 +437,10 @@ func exportToFile(ctx context.Context, path string, includeMemories bool) (issue
 		}
 	}
 
+	if _, err := writeLabelDefinitionRecords(w, getLabelDefinitionsForExport(ctx, store)); err != nil {
+		return issueCount, memoryCount, err
+	}
+
 	// Write memories
 	if includeMemories {
 		allConfig, err := store.GetAllConfig(ctx)
@@ -531,6 +544,8 @@ func classifyExistingAutoExportRecord(line []byte, infraTypes map[string]bool, i
 			stats.Memories++
 		}
 		return nil
+	case "label":
+		return nil
 	case "", "issue":
 		if record.ID == "" {
 			stats.FilteredRecords++
@@ -562,6 +577,31 @@ func loadExportAutoState(beadsDir string) *exportAutoState {
 	return &state
 }
 
+// fileSHA256 returns the hex SHA-256 of the file at path, or "" if it cannot
+// be read.
+func fileSHA256(path string) string {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// isConfiguredExportPath reports whether path is the export file that
+// auto-export maintains in beadsDir.
+func isConfiguredExportPath(beadsDir, path string) bool {
+	want, err := filepath.Abs(filepath.Join(beadsDir, exportJSONLRelPath()))
+	if err != nil {
+		return false
+	}
+	got, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return got == want
+}
+
 func saveExportAutoState(beadsDir string, state *exportAutoState) {
 	path := filepath.Join(beadsDir, exportAutoStateFile)
 	data, err := json.Marshal(state)
@@ -1155,3 +1155,19 @@ func autoExportDataLossTestEnv(home string) []string {
 	}
 	return append(env, "HOME="+home, "BEADS_DOLT_AUTO_START=0", "BEADS_NO_DAEMON=1", "BD_DISABLE_METRICS=1", "BD_DISABLE_EVENT_FLUSH=1")
 }
+
+func TestIsConfiguredExportPath(t *testing.T) {
+	initConfigForTest(t)
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+
+	if !isConfiguredExportPath(beadsDir, filepath.Join(beadsDir, "issues.jsonl")) {
+		t.Error("default export path should match")
+	}
+	if isConfiguredExportPath(beadsDir, filepath.Join(beadsDir, "other.jsonl")) {
+		t.Error("a different file should not match")
+	}
+	config.Set("export.path", "custom.jsonl")
+	if !isConfiguredExportPath(beadsDir, filepath.Join(beadsDir, "custom.jsonl")) {
+		t.Error("configured export.path should match")
+	}
+}
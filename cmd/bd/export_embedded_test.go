@@ -160,6 +160,35 @@ func TestEmbeddedExport(t *testing.T) {
 		// Export with --no-memories on empty db should not error
 		bdExport(t, bd, dir, "--no-memories")
 	})
+
+	t.Run("org_with_filter", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "exorg")
+		bdCreateSilent(t, bd, dir, "Backend work", "--labels", "backend")
+		bdCreateSilent(t, bd, dir, "Frontend work", "--labels", "frontend")
+
+		out := bdExport(t, bd, dir, "--format", "org", "--filter", "label=backend")
+		if !strings.Contains(out, "* TODO [#C] Backend work :backend:") {
+			t.Errorf("expected backend headline in org export:\n%s", out)
+		}
+		if strings.Contains(out, "Frontend work") {
+			t.Errorf("--filter did not exclude the frontend issue:\n%s", out)
+		}
+	})
+
+	t.Run("ics_by_assignee", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "exics")
+		mine := bdCreateSilent(t, bd, dir, "Alice deadline", "--assignee", "alice", "--due", "2030-06-01", "--labels", "q2")
+		bdCreateSilent(t, bd, dir, "Bob deadline", "--assignee", "bob", "--due", "2030-06-02", "--labels", "q2")
+		bdCreateSilent(t, bd, dir, "Alice undated", "--assignee", "alice")
+
+		out := bdExport(t, bd, dir, "--format", "ics", "--assignee", "alice", "--label", "q2")
+		if !strings.Contains(out, "UID:"+mine+"@beads") || !strings.Contains(out, "DTSTART;VALUE=DATE:20300601") {
+			t.Errorf("expected alice's deadline in ics export:\n%s", out)
+		}
+		if strings.Count(out, "BEGIN:VEVENT") != 1 {
+			t.Errorf("expected exactly one event:\n%s", out)
+		}
+	})
 }
 
 func TestEmbeddedExportConcurrent(t *testing.T) {
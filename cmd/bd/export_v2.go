@@ -0,0 +1,352 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// JSONL export format versions. Version 1 is the historical headerless
+// format; version 2 adds a header record and a canonical serialization.
+// Import accepts both and refuses files from a newer format.
+const (
+	jsonlSchemaPrefix  = "beads-jsonl/"
+	jsonlSchemaVersion = 2
+)
+
+// jsonlHeader is the first record of a v2 export. Importers of both
+// versions skip it by its _schema key.
+type jsonlHeader struct {
+	Schema        string `json:"_schema"`
+	SchemaVersion int    `json:"schema_version"`
+	IssuePrefix   string `json:"issue_prefix,omitempty"`
+	Sort          string `json:"sort"`
+	Shard         string `json:"shard,omitempty"`
+}
+
+// checkJSONLSchema rejects a header from a format newer than this bd
+// understands, rather than importing records whose meaning may have changed.
+func checkJSONLSchema(raw json.RawMessage) error {
+	var schema string
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return fmt.Errorf("invalid JSONL header: _schema is not a string")
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(schema, jsonlSchemaPrefix))
+	if !strings.HasPrefix(schema, jsonlSchemaPrefix) || err != nil {
+		return fmt.Errorf("unrecognized JSONL schema %q", schema)
+	}
+	if version > jsonlSchemaVersion {
+		return fmt.Errorf("JSONL schema %q is newer than this bd supports (up to %s%d); upgrade bd to import it",
+			schema, jsonlSchemaPrefix, jsonlSchemaVersion)
+	}
+	return nil
+}
+
+// exportRecordV2 is an issue line in a v2 export. It omits the dependency
+// and comment counts of v1: they are derived, and carrying them meant a new
+// edge rewrote the line of the issue on its far end too.
+type exportRecordV2 struct {
+	RecordType string `json:"_type"`
+	*types.Issue
+}
+
+// canonicalJSON re-encodes a JSON value with object keys sorted at every
+// level, including inside free-form metadata, so equal records always
+// serialize to identical bytes.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// normalizeForExport puts an issue's list fields in a stable order.
+func normalizeForExport(issue *types.Issue) {
+	sort.Strings(issue.Labels)
+	sort.SliceStable(issue.Dependencies, func(i, j int) bool {
+		a, b := issue.Dependencies[i], issue.Dependencies[j]
+		if a.DependsOnID != b.DependsOnID {
+			return a.DependsOnID < b.DependsOnID
+		}
+		return a.Type < b.Type
+	})
+	sort.SliceStable(issue.Comments, func(i, j int) bool {
+		return issue.Comments[i].CreatedAt.Before(issue.Comments[j].CreatedAt)
+	})
+}
+
+// exportShardModes are the accepted --shard values.
+var exportShardModes = []string{"status", "id"}
+
+// exportShardKey names the shard an issue belongs to: "open" or "closed"
+// for status sharding, or the first character of the ID after the prefix
+// for id sharding, which splits the hash space into up to 36 ranges.
+func exportShardKey(issue *types.Issue, mode string) string {
+	switch mode {
+	case "status":
+		if issue.Status == types.StatusClosed {
+			return "closed"
+		}
+		return "open"
+	case "id":
+		suffix := issue.ID
+		if i := strings.LastIndex(suffix, "-"); i >= 0 {
+			suffix = suffix[i+1:]
+		}
+		if suffix == "" {
+			return "other"
+		}
+		return strings.ToLower(suffix[:1])
+	}
+	return ""
+}
+
+// exportShardPath inserts the shard name before the extension:
+// issues.jsonl + "open" → issues-open.jsonl.
+func exportShardPath(base, shard string) string {
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-" + shard + ext
+}
+
+// writeJSONLv2 writes a v2 export: the header, then issues sorted by ID,
+// then the extra (non-issue) records already serialized.
+func writeJSONLv2(w io.Writer, header jsonlHeader, issues []*types.Issue, extra [][]byte) error {
+	header.Schema = jsonlSchemaPrefix + strconv.Itoa(jsonlSchemaVersion)
+	header.SchemaVersion = jsonlSchemaVersion
+	header.Sort = "id"
+	line, err := canonicalJSON(header)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	sorted := append([]*types.Issue(nil), issues...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	for _, issue := range sorted {
+		sanitizeZeroTime(issue)
+		normalizeForExport(issue)
+		line, err := canonicalJSON(&exportRecordV2{RecordType: "issue", Issue: issue})
+		if err != nil {
+			return fmt.Errorf("failed to marshal issue %s: %w", issue.ID, err)
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("failed to write issue %s: %w", issue.ID, err)
+		}
+	}
+	for _, line := range extra {
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("failed to write: %w", err)
+		}
+	}
+	return nil
+}
+
+// exportExtraRecordsV2 serializes the label definitions and, when requested,
+// memories that follow the issues, in canonical form.
+func exportExtraRecordsV2(ctx context.Context, includeMemories bool) ([][]byte, int, error) {
+	var extra [][]byte
+	defs := getLabelDefinitionsForExport(ctx, store)
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	for _, def := range defs {
+		line, err := canonicalJSON(&labelDefinitionRecord{RecordType: "label", LabelDefinition: def})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal label %s: %w", def.Name, err)
+		}
+		extra = append(extra, line)
+	}
+	memoryCount := 0
+	if includeMemories {
+		allConfig, err := store.GetAllConfig(ctx)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read config for memories: %w", err)
+		}
+		fullPrefix := kvPrefix + memoryPrefix
+		var memKeys []string
+		for k := range allConfig {
+			if strings.HasPrefix(k, fullPrefix) {
+				memKeys = append(memKeys, k)
+			}
+		}
+		sort.Strings(memKeys)
+		for _, k := range memKeys {
+			line, err := canonicalJSON(map[string]string{
+				"_type": "memory",
+				"key":   strings.TrimPrefix(k, fullPrefix),
+				"value": allConfig[k],
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			extra = append(extra, line)
+			memoryCount++
+		}
+	}
+	return extra, memoryCount, nil
+}
+
+// exportIssuePrefix returns the issue prefix recorded in v2 headers.
+func exportIssuePrefix(ctx context.Context) string {
+	if p := config.GetString("issue-prefix"); p != "" {
+		return p
+	}
+	if p, err := store.GetConfig(ctx, "issue_prefix"); err == nil {
+		return p
+	}
+	return ""
+}
+
+// finishShardedExport writes a v2 export split into one file per shard next
+// to base (issues.jsonl → issues-open.jsonl, issues-closed.jsonl). Label
+// definitions and memories go to <base>-meta.jsonl. Each file is written
+// atomically and carries its own header, and shard files from an earlier
+// export that would now be empty are removed so a re-export never leaves
+// stale issues behind.
+func finishShardedExport(ctx context.Context, base, mode string, issues []*types.Issue, includeMemories bool) error {
+	shards := make(map[string][]*types.Issue)
+	for _, issue := range issues {
+		key := exportShardKey(issue, mode)
+		shards[key] = append(shards[key], issue)
+	}
+	extra, memoryCount, err := exportExtraRecordsV2(ctx, includeMemories)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	prefix := exportIssuePrefix(ctx)
+
+	write := func(shard string, shardIssues []*types.Issue, records [][]byte) error {
+		path := exportShardPath(base, shard)
+		aw, err := atomicfile.Create(path, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		if err := writeJSONLv2(aw, jsonlHeader{IssuePrefix: prefix, Shard: shard}, shardIssues, records); err != nil {
+			_ = aw.Abort()
+			return err
+		}
+		return aw.Close()
+	}
+
+	names := make([]string, 0, len(shards))
+	for name := range shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := write(name, shards[name], nil); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+	}
+	if len(extra) > 0 {
+		if err := write("meta", nil, extra); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+	}
+	removeStaleShards(base, shards, len(extra) > 0)
+
+	fmt.Fprintf(os.Stderr, "Exported %d issues", len(issues))
+	if memoryCount > 0 {
+		fmt.Fprintf(os.Stderr, " and %d memories", memoryCount)
+	}
+	fmt.Fprintf(os.Stderr, " to %d shard file(s) matching %s\n", len(names), exportShardPath(base, "*"))
+	return nil
+}
+
+// removeStaleShards deletes v2 shard files next to base that this export
+// did not write. Only files whose first line is a v2 header naming a shard
+// are touched.
+func removeStaleShards(base string, written map[string][]*types.Issue, wroteMeta bool) {
+	matches, _ := filepath.Glob(exportShardPath(base, "*"))
+	for _, path := range matches {
+		shard, ok := readShardHeader(path)
+		if !ok {
+			continue
+		}
+		if _, current := written[shard]; current || (shard == "meta" && wroteMeta) {
+			continue
+		}
+		_ = os.Remove(path)
+	}
+}
+
+// readShardHeader returns the shard named by a file's v2 header.
+func readShardHeader(path string) (string, bool) {
+	f, err := os.Open(path) //nolint:gosec // G304: path derived from the user's -o
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return "", false
+	}
+	var header jsonlHeader
+	if err := json.Unmarshal(line, &header); err != nil || header.Schema == "" || header.Shard == "" {
+		return "", false
+	}
+	return header.Shard, true
+}
+
+// finishJSONLv2Export writes a single-file v2 export and finalizes it like
+// the v1 path, including the auto-export state when -o is the configured
+// export file.
+func finishJSONLv2Export(ctx context.Context, w io.Writer, aw *atomicfile.Writer, issues []*types.Issue, includeMemories bool) error {
+	extra, memoryCount, err := exportExtraRecordsV2(ctx, includeMemories)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if err := writeJSONLv2(w, jsonlHeader{IssuePrefix: exportIssuePrefix(ctx)}, issues, extra); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if aw == nil {
+		return nil
+	}
+	if err := aw.Close(); err != nil {
+		return HandleErrorRespectJSON("failed to finalize export file: %v", err)
+	}
+	if beadsDir := beads.FindBeadsDir(); beadsDir != "" && isConfiguredExportPath(beadsDir, exportOutput) {
+		if stateHash, err := storeStateHash(ctx); err == nil {
+			saveExportAutoState(beadsDir, &exportAutoState{
+				LastDoltCommit: stateHash,
+				Timestamp:      time.Now(),
+				Issues:         len(issues),
+				Memories:       memoryCount,
+				JSONLHash:      fileSHA256(exportOutput),
+			})
+		}
+	}
+	if memoryCount > 0 {
+		fmt.Fprintf(os.Stderr, "Exported %d issues and %d memories to %s\n", len(issues), memoryCount, exportOutput)
+	} else {
+		fmt.Fprintf(os.Stderr, "Exported %d issues to %s\n", len(issues), exportOutput)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCanonicalJSONSortsKeys(t *testing.T) {
+	got, err := canonicalJSON(map[string]interface{}{
+		"b": 1,
+		"a": map[string]interface{}{"z": "<x>", "y": 2.50},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":{"y":2.5,"z":"<x>"},"b":1}`; string(got) != want {
+		t.Errorf("canonicalJSON = %s, want %s", got, want)
+	}
+}
+
+func TestCheckJSONLSchema(t *testing.T) {
+	for _, schema := range []string{`"beads-jsonl/1"`, `"beads-jsonl/2"`} {
+		if err := checkJSONLSchema(json.RawMessage(schema)); err != nil {
+			t.Errorf("%s: unexpected error %v", schema, err)
+		}
+	}
+	for _, schema := range []string{`"beads-jsonl/3"`, `"other/1"`, `"beads-jsonl/x"`, `2`} {
+		if err := checkJSONLSchema(json.RawMessage(schema)); err == nil {
+			t.Errorf("%s: expected an error", schema)
+		}
+	}
+}
+
+func TestExportShardKeyAndPath(t *testing.T) {
+	tests := []struct {
+		issue *types.Issue
+		mode  string
+		want  string
+	}{
+		{&types.Issue{ID: "bd-a1b", Status: types.StatusOpen}, "status", "open"},
+		{&types.Issue{ID: "bd-a1b", Status: types.StatusInProgress}, "status", "open"},
+		{&types.Issue{ID: "bd-a1b", Status: types.StatusClosed}, "status", "closed"},
+		{&types.Issue{ID: "bd-A1b"}, "id", "a"},
+		{&types.Issue{ID: "my-proj-9zz"}, "id", "9"},
+	}
+	for _, tt := range tests {
+		if got := exportShardKey(tt.issue, tt.mode); got != tt.want {
+			t.Errorf("exportShardKey(%s, %s) = %q, want %q", tt.issue.ID, tt.mode, got, tt.want)
+		}
+	}
+	if got := exportShardPath("/x/issues.jsonl", "open"); got != "/x/issues-open.jsonl" {
+		t.Errorf("exportShardPath = %q", got)
+	}
+}
+
+func TestWriteJSONLv2(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "bd-b", Title: "Second", Status: types.StatusOpen, IssueType: types.TypeTask, Labels: []string{"z", "a"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "bd-a", Title: "First", Status: types.StatusOpen, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+	}
+	var buf bytes.Buffer
+	if err := writeJSONLv2(&buf, jsonlHeader{IssuePrefix: "bd"}, issues, [][]byte{[]byte(`{"_type":"memory"}`)}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.String())
+	}
+	if want := `{"_schema":"beads-jsonl/2","issue_prefix":"bd","schema_version":2,"sort":"id"}`; lines[0] != want {
+		t.Errorf("header = %s, want %s", lines[0], want)
+	}
+	if !strings.Contains(lines[1], `"id":"bd-a"`) || !strings.Contains(lines[2], `"id":"bd-b"`) {
+		t.Errorf("issues not sorted by ID:\n%s", buf.String())
+	}
+	if !strings.Contains(lines[2], `"labels":["a","z"]`) || strings.Contains(lines[2], "dependency_count") {
+		t.Errorf("issue line not normalized: %s", lines[2])
+	}
+	if !strings.HasPrefix(lines[1], `{"_type":"issue",`) || lines[3] != `{"_type":"memory"}` {
+		t.Errorf("unexpected record layout:\n%s", buf.String())
+	}
+
+	// Identical input must produce identical bytes.
+	var again bytes.Buffer
+	if err := writeJSONLv2(&again, jsonlHeader{IssuePrefix: "bd"}, issues, [][]byte{[]byte(`{"_type":"memory"}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Error("writeJSONLv2 output is not deterministic")
+	}
+}
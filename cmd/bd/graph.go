@@ -38,16 +38,18 @@ var (
 	graphDOT     bool
 	graphHTML    bool
 	graphOpen    bool
+	graphFormat  string
 )
 
 var graphCmd = &cobra.Command{
-	Use:     "graph [issue-id]",
+	Use:     "graph [issue-id...]",
 	GroupID: "deps",
 	Short:   "Display issue dependency graph",
 	Long: `Display a visualization of an issue's dependency graph.
 
 For epics, shows all children and their dependencies.
 For regular issues, shows the issue and its direct dependencies.
+With several issue IDs, shows the union of their graphs.
 
 With --all, shows all open issues grouped by connected component.
 With --open, filters to only open/actionable issues (compact layer format).
@@ -56,7 +58,8 @@ Display formats:
   (default)        DAG with columns and box-drawing edges (terminal-native)
   --box            ASCII boxes showing layers, more detailed
   --compact        Tree format, one line per issue, more scannable
-  --dot            Graphviz DOT format (pipe to dot -Tsvg > graph.svg)
+  --format dot     Graphviz DOT (pipe to dot -Tsvg > graph.svg); --dot is an alias
+  --format mermaid Mermaid flowchart, for a mermaid code fence in Markdown
   --html           Self-contained interactive HTML with D3.js visualization
   --open           Open issues only, compact layers (LLM-friendly)
 
@@ -67,11 +70,19 @@ The graph shows execution order:
 
 Status icons: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred
 
+DOT and Mermaid output color nodes by status and draw blockers pointing at
+the issues they block, parents at their children (dashed/dotted). They are
+always a single diagram: with --all, the components are combined, and with
+--open, closed and deferred issues are dropped instead of switching to the
+compact layer format.
+
 Examples:
   bd graph issue-id              # Terminal DAG visualization (default)
   bd graph --box issue-id        # ASCII boxes with layer grouping
   bd graph --dot issue-id | dot -Tsvg > graph.svg  # SVG via Graphviz
   bd graph --dot issue-id | dot -Tpng > graph.png  # PNG via Graphviz
+  bd graph --format mermaid --open epic-id > docs/epic.mmd  # Mermaid for docs
+  bd graph --format dot id-1 id-2        # Union of two issues' graphs
   bd graph --html issue-id > graph.html  # Interactive browser view
   bd graph --all --html > all.html       # All issues, interactive
   bd graph --open issue-id       # Open issues only, layered by blocking order
@@ -83,7 +94,7 @@ after the BFS traversal completes — the whole subgraph is always walked
 first, then rejected if it's over cap. --all checks each status
 (open/in_progress/blocked) independently, so up to 3x the cap can be loaded
 in total before any individual status trips it.`,
-	Args:          cobra.RangeArgs(0, 1),
+	Args:          cobra.ArbitraryArgs,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -100,6 +111,9 @@ in total before any individual status trips it.`,
 		if !graphAll && len(args) == 0 {
 			return HandleErrorWithHintRespectJSON("issue ID required", "Use --all for all open issues")
 		}
+		if err := resolveGraphFormat(); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
 
 		if usesProxiedServer() {
 			if err := rejectMaxRowsUnderProxiedServer(cmd); err != nil {
@@ -128,15 +142,19 @@ in total before any individual status trips it.`,
 			return renderGraphAllSubgraphs(subgraphs)
 		}
 
-		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
-		if err != nil {
-			return HandleErrorRespectJSON("issue '%s' not found", args[0])
-		}
-
-		subgraph, err := loadGraphSubgraph(ctx, store, issueID)
-		if err != nil {
-			return HandleErrorRespectJSON("loading graph: %v", err)
+		var subgraphs []*TemplateSubgraph
+		for _, arg := range args {
+			issueID, err := utils.ResolvePartialID(ctx, store, arg)
+			if err != nil {
+				return HandleErrorRespectJSON("issue '%s' not found", arg)
+			}
+			sg, err := loadGraphSubgraph(ctx, store, issueID)
+			if err != nil {
+				return HandleErrorRespectJSON("loading graph: %v", err)
+			}
+			subgraphs = append(subgraphs, sg)
 		}
+		subgraph := mergeSubgraphs(subgraphs)
 
 		// Apply the defensive row cap (be-x42v) on the connected-component
 		// node count. loadGraphSubgraph is a BFS over GetDependents/
@@ -173,6 +191,12 @@ func renderGraphAllSubgraphs(subgraphs []*TemplateSubgraph) error {
 		subgraphs = filtered
 	}
 
+	if graphFormat != "" && !jsonOutput {
+		merged := mergeSubgraphs(subgraphs)
+		renderGraphDiagram(computeLayout(merged), merged)
+		return nil
+	}
+
 	if len(subgraphs) == 0 {
 		fmt.Println("No open issues found")
 		return nil
@@ -183,7 +207,7 @@ func renderGraphAllSubgraphs(subgraphs []*TemplateSubgraph) error {
 	}
 
 	if graphHTML && !graphOpen {
-		merged := mergeSubgraphsForHTML(subgraphs)
+		merged := mergeSubgraphs(subgraphs)
 		layout := computeLayout(merged)
 		renderGraphHTML(layout, merged)
 		return nil
@@ -202,16 +226,14 @@ func renderGraphAllSubgraphs(subgraphs []*TemplateSubgraph) error {
 
 	for i, subgraph := range subgraphs {
 		layout := computeLayout(subgraph)
-		if graphDOT {
-			renderGraphDOT(layout, subgraph)
-		} else if graphCompact {
+		if graphCompact {
 			renderGraphCompact(layout, subgraph)
 		} else if graphBox {
 			renderGraph(layout, subgraph)
 		} else {
 			renderGraphVisual(layout, subgraph)
 		}
-		if !graphDOT && i < len(subgraphs)-1 {
+		if i < len(subgraphs)-1 {
 			fmt.Println(strings.Repeat("─", 60))
 		}
 	}
@@ -222,6 +244,11 @@ func renderGraphSingleSubgraph(subgraph *TemplateSubgraph) error {
 	if graphOpen {
 		subgraph = filterSubgraphOpen(subgraph)
 		if subgraph == nil || len(subgraph.Issues) == 0 {
+			if graphFormat != "" && !jsonOutput {
+				empty := mergeSubgraphs(nil)
+				renderGraphDiagram(computeLayout(empty), empty)
+				return nil
+			}
 			fmt.Println("No open issues in subgraph")
 			return nil
 		}
@@ -237,14 +264,17 @@ func renderGraphSingleSubgraph(subgraph *TemplateSubgraph) error {
 		})
 	}
 
+	if graphFormat != "" {
+		renderGraphDiagram(layout, subgraph)
+		return nil
+	}
+
 	if graphOpen {
 		renderGraphCompact(layout, subgraph)
 		return nil
 	}
 
-	if graphDOT {
-		renderGraphDOT(layout, subgraph)
-	} else if graphHTML {
+	if graphHTML {
 		renderGraphHTML(layout, subgraph)
 	} else if graphCompact {
 		renderGraphCompact(layout, subgraph)
@@ -347,6 +377,7 @@ func init() {
 	graphCmd.Flags().BoolVar(&graphCompact, "compact", false, "Tree format, one line per issue, more scannable")
 	graphCmd.Flags().BoolVar(&graphBox, "box", false, "ASCII boxes showing layers")
 	graphCmd.Flags().BoolVar(&graphDOT, "dot", false, "Output Graphviz DOT format (pipe to: dot -Tsvg > graph.svg)")
+	graphCmd.Flags().StringVar(&graphFormat, "format", "", "Diagram output format: dot or mermaid")
 	graphCmd.Flags().BoolVar(&graphHTML, "html", false, "Output self-contained interactive HTML (redirect to file)")
 	graphCmd.Flags().BoolVar(&graphOpen, "open", false, "Show only open issues (filters out closed/deferred), forces compact layer format")
 	// Defensive row cap (be-x42v): exits 2 on overage, default disabled.
@@ -763,10 +794,39 @@ func filterSubgraphOpen(subgraph *TemplateSubgraph) *TemplateSubgraph {
 	return filtered
 }
 
-// computeLayout assigns layers to nodes using topological sort
-// mergeSubgraphsForHTML joins disconnected components into one subgraph so
-// `bd graph --all --html` emits a single valid HTML document.
-func mergeSubgraphsForHTML(subgraphs []*TemplateSubgraph) *TemplateSubgraph {
+// resolveGraphFormat folds --dot into --format and validates it.
+func resolveGraphFormat() error {
+	if graphDOT {
+		if graphFormat != "" && graphFormat != "dot" {
+			return fmt.Errorf("--dot conflicts with --format %s", graphFormat)
+		}
+		graphFormat = "dot"
+	}
+	switch graphFormat {
+	case "", "dot", "mermaid":
+	default:
+		return fmt.Errorf("unknown graph format %q (want dot or mermaid)", graphFormat)
+	}
+	if graphFormat != "" && graphHTML {
+		return fmt.Errorf("--html cannot be combined with --format %s", graphFormat)
+	}
+	return nil
+}
+
+// renderGraphDiagram renders the graph in the --format diagram language.
+func renderGraphDiagram(layout *GraphLayout, subgraph *TemplateSubgraph) {
+	if graphFormat == "mermaid" {
+		renderGraphMermaid(layout, subgraph)
+		return
+	}
+	renderGraphDOT(layout, subgraph)
+}
+
+// mergeSubgraphs combines subgraphs into one, for rendering several
+// components or several roots' graphs as a single diagram. Issues and
+// dependencies shared between them appear once. This is also what lets
+// `bd graph --all --html` emit a single valid HTML document.
+func mergeSubgraphs(subgraphs []*TemplateSubgraph) *TemplateSubgraph {
 	switch len(subgraphs) {
 	case 0:
 		return &TemplateSubgraph{IssueMap: make(map[string]*types.Issue)}
@@ -776,11 +836,22 @@ func mergeSubgraphsForHTML(subgraphs []*TemplateSubgraph) *TemplateSubgraph {
 	merged := &TemplateSubgraph{
 		IssueMap: make(map[string]*types.Issue),
 	}
+	type depKey struct {
+		from, to string
+		depType  types.DependencyType
+	}
+	seenDeps := make(map[depKey]bool)
 	for _, sg := range subgraphs {
 		for _, issue := range sg.Issues {
 			merged.IssueMap[issue.ID] = issue
 		}
-		merged.Dependencies = append(merged.Dependencies, sg.Dependencies...)
+		for _, dep := range sg.Dependencies {
+			key := depKey{dep.IssueID, dep.DependsOnID, dep.Type}
+			if !seenDeps[key] {
+				seenDeps[key] = true
+				merged.Dependencies = append(merged.Dependencies, dep)
+			}
+		}
 	}
 	merged.Issues = make([]*types.Issue, 0, len(merged.IssueMap))
 	for _, issue := range merged.IssueMap {
@@ -792,6 +863,7 @@ func mergeSubgraphsForHTML(subgraphs []*TemplateSubgraph) *TemplateSubgraph {
 	return merged
 }
 
+// computeLayout assigns layers to nodes using topological sort
 func computeLayout(subgraph *TemplateSubgraph) *GraphLayout {
 	layout := &GraphLayout{
 		Nodes: make(map[string]*GraphNode),
@@ -90,6 +90,27 @@ func TestEmbeddedGraph(t *testing.T) {
 		}
 	})
 
+	// ===== --format mermaid =====
+
+	t.Run("mermaid_format", func(t *testing.T) {
+		out := bdGraph(t, bd, dir, "--format", "mermaid", epic.ID, standalone.ID)
+		if !strings.HasPrefix(out, "flowchart LR") {
+			t.Errorf("expected Mermaid flowchart: %s", out)
+		}
+		for _, id := range []string{epic.ID, taskC.ID, standalone.ID} {
+			if !strings.Contains(out, id) {
+				t.Errorf("expected %s in Mermaid output: %s", id, out)
+			}
+		}
+	})
+
+	t.Run("all_open_dot_single_digraph", func(t *testing.T) {
+		out := bdGraph(t, bd, dir, "--all", "--open", "--format", "dot")
+		if n := strings.Count(out, "digraph"); n != 1 {
+			t.Errorf("expected one digraph, got %d: %s", n, out)
+		}
+	})
+
 	// ===== --html =====
 
 	t.Run("html_format", func(t *testing.T) {
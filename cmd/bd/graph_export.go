@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"html"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -68,25 +69,25 @@ func dotNodeAttrs(node *GraphNode) (label, fillColor, fontColor string) {
 	icon := statusPlainIcon(node.Issue.Status)
 	title := truncateTitle(node.Issue.Title, 40)
 	label = fmt.Sprintf("%s %s\\nP%d | %s", icon, node.Issue.ID, node.Issue.Priority, title)
+	fillColor, fontColor = graphStatusColors(node.Issue.Status)
+	return
+}
 
-	switch node.Issue.Status {
+// graphStatusColors returns the fill and font colors for a status, shared
+// by the DOT and Mermaid exports so both diagrams look alike.
+func graphStatusColors(status types.Status) (fillColor, fontColor string) {
+	switch status {
 	case types.StatusOpen:
-		fillColor = "#e8f4fd"
-		fontColor = "#1a1a1a"
+		return "#e8f4fd", "#1a1a1a"
 	case types.StatusInProgress:
-		fillColor = "#fff3cd"
-		fontColor = "#664d03"
+		return "#fff3cd", "#664d03"
 	case types.StatusBlocked:
-		fillColor = "#f8d7da"
-		fontColor = "#842029"
+		return "#f8d7da", "#842029"
 	case types.StatusClosed:
-		fillColor = "#d4edda"
-		fontColor = "#888888"
+		return "#d4edda", "#888888"
 	default: // deferred, hooked, etc.
-		fillColor = "#e2e3e5"
-		fontColor = "#41464b"
+		return "#e2e3e5", "#41464b"
 	}
-	return
 }
 
 // dotEdgeStyle returns DOT edge attributes for a dependency type
@@ -124,6 +125,102 @@ func statusPlainIcon(status types.Status) string {
 	}
 }
 
+// mermaidStatusClasses are the classDef names used for node styling, in
+// output order, with a representative status for their colors.
+var mermaidStatusClasses = []struct {
+	name   string
+	status types.Status
+}{
+	{"open", types.StatusOpen},
+	{"in_progress", types.StatusInProgress},
+	{"blocked", types.StatusBlocked},
+	{"closed", types.StatusClosed},
+	{"other", types.StatusDeferred},
+}
+
+// renderGraphMermaid renders the graph as a Mermaid flowchart, for embedding
+// in Markdown (a mermaid code fence renders on GitHub and GitLab).
+// Blockers point to the issues they block; parents point to children with
+// dotted arrows. Output is sorted so an unchanged graph renders identically.
+func renderGraphMermaid(layout *GraphLayout, subgraph *TemplateSubgraph) {
+	fmt.Println("flowchart LR")
+	if len(layout.Nodes) == 0 {
+		return
+	}
+	for _, class := range mermaidStatusClasses {
+		fill, font := graphStatusColors(class.status)
+		fmt.Printf("  classDef %s fill:%s,color:%s,stroke:%s\n", class.name, fill, font, font)
+	}
+
+	for _, layer := range layout.Layers {
+		for _, id := range layer {
+			node := layout.Nodes[id]
+			if node == nil {
+				continue
+			}
+			issue := node.Issue
+			label := fmt.Sprintf("%s %s<br/>P%d | %s", statusPlainIcon(issue.Status),
+				mermaidEscapeLabel(issue.ID), issue.Priority, mermaidEscapeLabel(truncateTitle(issue.Title, 40)))
+			fmt.Printf("  %s[\"%s\"]:::%s\n", mermaidNodeID(id), label, mermaidStatusClass(issue.Status))
+		}
+	}
+
+	var edges []string
+	seen := make(map[string]bool)
+	for _, dep := range subgraph.Dependencies {
+		if layout.Nodes[dep.IssueID] == nil || layout.Nodes[dep.DependsOnID] == nil {
+			continue
+		}
+		var arrow string
+		switch dep.Type {
+		case types.DepBlocks:
+			arrow = "-->"
+		case types.DepParentChild:
+			arrow = "-.->"
+		default:
+			continue
+		}
+		edge := fmt.Sprintf("  %s %s %s", mermaidNodeID(dep.DependsOnID), arrow, mermaidNodeID(dep.IssueID))
+		if !seen[edge] {
+			seen[edge] = true
+			edges = append(edges, edge)
+		}
+	}
+	sort.Strings(edges)
+	for _, edge := range edges {
+		fmt.Println(edge)
+	}
+}
+
+// mermaidStatusClass returns the classDef name for a status.
+func mermaidStatusClass(status types.Status) string {
+	for _, class := range mermaidStatusClasses[:4] {
+		if class.status == status {
+			return class.name
+		}
+	}
+	return "other"
+}
+
+// mermaidNodeID maps an issue ID to a Mermaid node identifier. Mermaid IDs
+// cannot contain dots or most punctuation, so anything outside [A-Za-z0-9_-]
+// becomes an underscore; the real ID is shown in the label.
+func mermaidNodeID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// mermaidEscapeLabel escapes text for a quoted Mermaid label using
+// Mermaid's #name; entity codes.
+func mermaidEscapeLabel(s string) string {
+	r := strings.NewReplacer(`&`, "#amp;", `"`, "#quot;", `<`, "#lt;", `>`, "#gt;")
+	return r.Replace(s)
+}
+
 // renderGraphHTML generates a self-contained HTML file with an interactive D3.js
 // force-directed graph visualization. The output is a complete HTML document that
 // can be opened in any browser.
@@ -309,7 +309,7 @@ func TestMergeSubgraphsForHTML_SingleDOCTYPE(t *testing.T) {
 		IssueMap: map[string]*types.Issue{"comp-b": issueB},
 	}
 
-	merged := mergeSubgraphsForHTML([]*TemplateSubgraph{sg1, sg2})
+	merged := mergeSubgraphs([]*TemplateSubgraph{sg1, sg2})
 	layout := computeLayout(merged)
 
 	output := captureGraphOutput(func() {
@@ -367,3 +367,71 @@ func TestRenderGraphHTML_EmptyEdgesNotNull(t *testing.T) {
 		t.Error("nodes must never be null")
 	}
 }
+
+func TestRenderGraphMermaid(t *testing.T) {
+	// Not parallel: captureGraphOutput redirects global os.Stdout
+	subgraph, layout := makeTestSubgraph()
+
+	output := captureGraphOutput(func() {
+		renderGraphMermaid(layout, subgraph)
+	})
+
+	if !strings.HasPrefix(output, "flowchart LR\n") {
+		t.Errorf("Mermaid output should start with 'flowchart LR', got: %s", output)
+	}
+	for _, want := range []string{
+		"classDef blocked fill:#f8d7da",
+		`test-a["○ test-a<br/>P0 | Root issue"]:::open`,
+		`test-b["◐ test-b<br/>P1 | Child task"]:::in_progress`,
+		":::blocked",
+		"test-a --> test-b",
+		"test-b --> test-c",
+		"test-a -.-> test-b",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, output)
+		}
+	}
+
+	again := captureGraphOutput(func() {
+		renderGraphMermaid(layout, subgraph)
+	})
+	if again != output {
+		t.Error("Mermaid output is not deterministic")
+	}
+}
+
+func TestRenderGraphMermaid_Empty(t *testing.T) {
+	// Not parallel: captureGraphOutput redirects global os.Stdout
+	output := captureGraphOutput(func() {
+		renderGraphMermaid(&GraphLayout{Nodes: map[string]*GraphNode{}}, &TemplateSubgraph{})
+	})
+	if output != "flowchart LR\n" {
+		t.Errorf("empty Mermaid output = %q", output)
+	}
+}
+
+func TestMermaidEscaping(t *testing.T) {
+	t.Parallel()
+	if got := mermaidNodeID("bd-abc.1"); got != "bd-abc_1" {
+		t.Errorf("mermaidNodeID = %q, want bd-abc_1", got)
+	}
+	if got := mermaidEscapeLabel(`Fix "a" <b> & c`); got != "Fix #quot;a#quot; #lt;b#gt; #amp; c" {
+		t.Errorf("mermaidEscapeLabel = %q", got)
+	}
+	if got := mermaidStatusClass(types.StatusDeferred); got != "other" {
+		t.Errorf("mermaidStatusClass(deferred) = %q, want other", got)
+	}
+}
+
+func TestMergeSubgraphs_DedupesSharedEdges(t *testing.T) {
+	t.Parallel()
+	subgraph, _ := makeTestSubgraph()
+	merged := mergeSubgraphs([]*TemplateSubgraph{subgraph, subgraph})
+	if len(merged.Issues) != len(subgraph.Issues) {
+		t.Errorf("merged issues = %d, want %d", len(merged.Issues), len(subgraph.Issues))
+	}
+	if len(merged.Dependencies) != len(subgraph.Dependencies) {
+		t.Errorf("merged dependencies = %d, want %d", len(merged.Dependencies), len(subgraph.Dependencies))
+	}
+}
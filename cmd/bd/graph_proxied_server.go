@@ -24,15 +24,19 @@ func runGraphProxiedServer(ctx context.Context, args []string) error {
 		return renderGraphAllSubgraphs(subgraphs)
 	}
 
-	root, err := uw.IssueUseCase().GetIssue(ctx, args[0])
-	if err != nil || root == nil {
-		return HandleErrorRespectJSON("issue '%s' not found", args[0])
-	}
-	subgraph, err := loadGraphSubgraphUOW(ctx, uw, root)
-	if err != nil {
-		return HandleErrorRespectJSON("loading graph: %v", err)
+	var subgraphs []*TemplateSubgraph
+	for _, arg := range args {
+		root, err := uw.IssueUseCase().GetIssue(ctx, arg)
+		if err != nil || root == nil {
+			return HandleErrorRespectJSON("issue '%s' not found", arg)
+		}
+		sg, err := loadGraphSubgraphUOW(ctx, uw, root)
+		if err != nil {
+			return HandleErrorRespectJSON("loading graph: %v", err)
+		}
+		subgraphs = append(subgraphs, sg)
 	}
-	return renderGraphSingleSubgraph(subgraph)
+	return renderGraphSingleSubgraph(mergeSubgraphs(subgraphs))
 }
 
 func runGraphCheckProxiedServer(ctx context.Context) error {
@@ -14,6 +14,7 @@ import (
 var (
 	historyLimit  int
 	historyEvents bool
+	historyFields bool
 )
 
 var historyCmd = &cobra.Command{
@@ -26,7 +27,8 @@ where the issue was modified.
 Examples:
   bd history bd-123           # Show all history for issue bd-123
   bd history bd-123 --limit 5 # Show last 5 changes
-  bd history bd-123 --events  # Show database audit events`,
+  bd history bd-123 --events  # Show database audit events
+  bd history bd-123 --fields  # Show field-level changes (who changed what)`,
 	Args:          cobra.ExactArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -41,10 +43,10 @@ Examples:
 		issueID := args[0]
 
 		if usesProxiedServer() {
-			return runHistoryProxiedServer(rootCtx, issueID, historyLimit, historyEvents)
+			return runHistoryProxiedServer(rootCtx, issueID, historyLimit, historyEvents, historyFields)
 		}
 
-		return runHistory(rootCtx, store, issueID, historyLimit, historyEvents)
+		return runHistory(rootCtx, store, issueID, historyLimit, historyEvents, historyFields)
 	},
 }
 
@@ -53,7 +55,19 @@ type historyBackend interface {
 	IterEvents(ctx context.Context, id string, limit int) (storage.Iter[types.Event], error)
 }
 
-func runHistory(ctx context.Context, backend historyBackend, issueID string, limit int, showEvents bool) error {
+func runHistory(ctx context.Context, backend historyBackend, issueID string, limit int, showEvents, showFields bool) error {
+	if showFields {
+		events, err := collectHistoryEvents(ctx, backend, issueID, limit)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to get history events: %v", err)
+		}
+		changes := eventsFieldChanges(events)
+		if jsonOutput {
+			return outputJSON(changes)
+		}
+		printFieldChanges(issueID, changes)
+		return nil
+	}
 	if showEvents {
 		events, err := collectHistoryEvents(ctx, backend, issueID, limit)
 		if err != nil {
@@ -117,6 +131,8 @@ func runHistory(ctx context.Context, backend historyBackend, issueID string, lim
 func init() {
 	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Limit number of history entries (0 = all)")
 	historyCmd.Flags().BoolVar(&historyEvents, "events", false, "Show database audit events instead of commit snapshots")
+	historyCmd.Flags().BoolVar(&historyFields, "fields", false, "Show field-level changes (old and new value per field) instead of commit snapshots")
+	historyCmd.MarkFlagsMutuallyExclusive("events", "fields")
 	historyCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(historyCmd)
 }
@@ -102,6 +102,38 @@ func TestEmbeddedHistory(t *testing.T) {
 		}
 	})
 
+	// ===== --fields and bd blame =====
+
+	t.Run("history_fields", func(t *testing.T) {
+		changes := bdHistoryJSON(t, bd, dir, issue.ID, "--fields")
+		found := false
+		for _, c := range changes {
+			if c["field"] == "priority" && c["old"] == "3" && c["new"] == "1" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a priority 3 -> 1 field change, got %v", changes)
+		}
+	})
+
+	t.Run("blame_field", func(t *testing.T) {
+		cmd := exec.Command(bd, "blame", issue.ID, "--field", "title", "--json")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		stdout, stderr, err := runCommandBuffers(t, cmd)
+		if err != nil {
+			t.Fatalf("bd blame failed: %v\nstderr:\n%s", err, stderr.String())
+		}
+		var changes []map[string]interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(stdout.String())), &changes); err != nil {
+			t.Fatalf("parse blame JSON: %v\n%s", err, stdout.String())
+		}
+		if len(changes) != 1 || changes[0]["old"] != "History test issue" || changes[0]["new"] != "History test issue updated" {
+			t.Errorf("blame title = %v, want the one rename", changes)
+		}
+	})
+
 	// ===== --limit restricts entries =====
 
 	t.Run("limit_restricts_entries", func(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
@@ -20,7 +21,7 @@ import (
 
 // managedHookNames lists the git hooks managed by beads.
 // Hook content is generated dynamically by generateHookSection().
-var managedHookNames = []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
+var managedHookNames = []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "post-commit", "prepare-commit-msg"}
 
 const hookVersionPrefix = "# bd-hooks-version: "
 const shimVersionPrefix = "# bd-shim "
@@ -63,6 +64,16 @@ const hookTimeoutSeconds = 300
 //   - If the beads database is not initialized (exit code 3), the hook exits
 //     successfully with a warning so that git operations are not blocked.
 func generateHookSection(hookName string) string {
+	return generateHookSectionWithSnippet(hookName, "")
+}
+
+// generateHookSectionWithSnippet is generateHookSection with a team snippet
+// (see loadHookSnippet) appended inside the markers, after the bd logic, so
+// the snippet is reinstalled and migrated together with the managed section.
+func generateHookSectionWithSnippet(hookName, snippet string) string {
+	if snippet != "" {
+		snippet = "# Hook snippet from .beads/" + hookSnippetsDirName + "/" + hookName + ".sh\n" + snippet
+	}
 	return hookSectionBeginLine() + "\n" +
 		"# This section is managed by beads. Do not remove these markers.\n" +
 		"if command -v bd >/dev/null 2>&1; then\n" +
@@ -94,6 +105,7 @@ func generateHookSection(hookName string) string {
 		"  fi\n" +
 		"  if [ $_bd_exit -ne 0 ]; then exit $_bd_exit; fi\n" +
 		"fi\n" +
+		snippet +
 		hookSectionEndLine() + "\n"
 }
 
@@ -288,7 +300,8 @@ func isAllowedAfterExec(trimmed string) bool {
 }
 
 // removeOrphanedBeginBlock removes an orphaned BEGIN block starting at beginIdx.
-// Scans forward from the BEGIN line to the next blank line, next BEGIN marker, or EOF.
+// Scans forward from the BEGIN line to the next blank line, next BEGIN marker,
+// first line that cannot belong to a beads section (user content), or EOF.
 func removeOrphanedBeginBlock(content string, beginIdx int) string {
 	lineStart := strings.LastIndex(content[:beginIdx], "\n")
 	if lineStart == -1 {
@@ -319,12 +332,34 @@ func removeOrphanedBeginBlock(content string, beginIdx int) string {
 			blockEnd = scanned
 			break
 		}
+		if !isHookSectionBodyLine(line) {
+			// User content directly after the partial section — keep it
+			blockEnd = scanned
+			break
+		}
 		scanned += len(line)
 	}
 
 	return content[:lineStart] + content[blockEnd:]
 }
 
+// isHookSectionBodyLine reports whether line could come from a beads section
+// body (current or older templates): indented lines, comments, shell control
+// keywords, and top-level lines that invoke bd.
+func isHookSectionBodyLine(line string) bool {
+	if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+		return true
+	}
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(trimmed, "#"),
+		trimmed == "fi", trimmed == "then", trimmed == "else",
+		strings.HasPrefix(trimmed, "if "), strings.HasPrefix(trimmed, "elif "):
+		return true
+	}
+	return strings.Contains(trimmed, "bd ") || strings.Contains(trimmed, "_bd_") || strings.Contains(trimmed, "BD_")
+}
+
 // removeMarkerLine removes a single marker line from content.
 func removeMarkerLine(content string, markerIdx int, markerPrefix string) string {
 	lineStart := strings.LastIndex(content[:markerIdx], "\n")
@@ -474,7 +509,7 @@ type HookStatus struct {
 
 // CheckGitHooks checks the status of bd git hooks in .git/hooks/
 func CheckGitHooks() []HookStatus {
-	hooks := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
+	hooks := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "post-commit", "prepare-commit-msg"}
 	statuses := make([]HookStatus, 0, len(hooks))
 
 	// Get hooks directory from common git dir (hooks are shared across worktrees)
@@ -620,6 +655,7 @@ The hooks provide:
 - post-merge: Run chained hooks after pull/merge
 - pre-push: Run chained hooks before push
 - post-checkout: Run chained hooks after branch checkout
+- post-commit: Link the new commit to the issues it mentions
 - prepare-commit-msg: Add agent identity trailers for forensics`,
 }
 
@@ -636,11 +672,19 @@ committed to git and shared with team members.
 Hooks use section markers to coexist with existing hooks — any user content
 outside the markers is preserved across installs and upgrades.
 
+Use --manager when the repository's hooks are owned by a hook manager that
+regenerates them (husky, lefthook, pre-commit, prek). Instead of writing git
+hook files, bd adds itself to the manager: it appends 'bd hooks run' to the
+.husky/ scripts, or prints the lefthook or .pre-commit-config.yaml entries to
+add. --manager alone detects the manager; --manager=<name> picks one.
+
 Installed hooks:
   - pre-commit: Run chained hooks before commit
   - post-merge: Run chained hooks after pull/merge
   - pre-push: Run chained hooks before push
   - post-checkout: Run chained hooks after branch checkout
+  - post-commit: Link the new commit to the issues it mentions
+- post-commit: Link the new commit to the issues it mentions
   - prepare-commit-msg: Add agent identity trailers (for orchestrator agents)`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -656,6 +700,11 @@ Installed hooks:
 		shared, _ := cmd.Flags().GetBool("shared")
 		chain, _ := cmd.Flags().GetBool("chain")
 		beadsHooks, _ := cmd.Flags().GetBool("beads")
+		manager, _ := cmd.Flags().GetString("manager")
+
+		if manager != "" {
+			return runHookManagerInstall(manager)
+		}
 
 		if err := installHooksWithOptions(managedHookNames, force, shared, chain, beadsHooks); err != nil {
 			return HandleErrorRespectJSON("installing hooks: %v", err)
@@ -804,6 +853,12 @@ func installHooksWithOptions(hookNames []string, force bool, shared bool, chain
 		return fmt.Errorf("failed to create hooks directory: %w", err)
 	}
 
+	unlock, err := acquireHooksLock(hooksDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// When setting a local core.hooksPath (beads or shared mode), preserve any
 	// hooks from the previously effective hooks directory (e.g. a global
 	// core.hooksPath or the default .git/hooks). Without this, setting a local
@@ -817,7 +872,7 @@ func installHooksWithOptions(hookNames []string, force bool, shared bool, chain
 	// outside the markers is preserved across reinstalls and upgrades.
 	for _, hookName := range hookNames {
 		hookPath := filepath.Join(hooksDir, hookName)
-		section := generateHookSection(hookName)
+		section := managedHookSection(hooksDir, hookName)
 
 		// Read existing hook file (if any)
 		// #nosec G304 -- hook path constrained to hooks directory
@@ -832,7 +887,7 @@ func installHooksWithOptions(hookNames []string, force bool, shared bool, chain
 			// No existing file — create with shebang + section
 			newContent = "#!/usr/bin/env sh\n" + section
 		} else {
-			existingStr := string(existing)
+			existingStr := stripUTF8BOM(string(existing))
 			// Check if file already has section markers
 			if strings.Contains(existingStr, hookSectionBeginPrefix) {
 				// Update only the section between markers
@@ -858,6 +913,14 @@ func installHooksWithOptions(hookNames []string, force bool, shared bool, chain
 		if err := os.WriteFile(hookPath, []byte(newContent), 0755); err != nil {
 			return fmt.Errorf("failed to write %s: %w", hookName, err)
 		}
+
+		// On Windows, also write a cmd.exe companion for tools that run hooks
+		// without Git for Windows' bundled sh.
+		if hookTargetGOOS == "windows" {
+			if err := writeWindowsHookShim(hookPath, hookName); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Configure git to use the hooks directory
@@ -871,6 +934,14 @@ func installHooksWithOptions(hookNames []string, force bool, shared bool, chain
 		}
 	}
 
+	// Opt-in (merge.auto_resolve): route the JSONL export through the
+	// bd merge-file driver so concurrent edits merge by issue ID.
+	if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+		if err := ensureJSONLMergeDriver(beadsDir); err != nil {
+			return fmt.Errorf("failed to register JSONL merge driver: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -1208,11 +1279,22 @@ func uninstallHooks() error {
 	if err != nil {
 		return err
 	}
-	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
+	if _, statErr := os.Stat(hooksDir); statErr == nil {
+		unlock, err := acquireHooksLock(hooksDir)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "post-commit", "prepare-commit-msg"}
 
 	for _, hookName := range hookNames {
 		hookPath := filepath.Join(hooksDir, hookName)
 
+		if err := removeWindowsHookShim(hookPath); err != nil {
+			return err
+		}
+
 		// #nosec G304 -- hook path constrained to .git/hooks directory
 		content, err := os.ReadFile(hookPath)
 		if err != nil {
@@ -1339,7 +1421,10 @@ func runChainedHook(hookName string, args []string) int {
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 
-	if err := cmd.Run(); err != nil {
+	start := time.Now()
+	err = cmd.Run()
+	chainedHookElapsed += time.Since(start)
+	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return exitErr.ExitCode()
 		}
@@ -1362,7 +1447,9 @@ func runPreCommitHook() int {
 	// GH#2489, GH#1863: Export JSONL before commit so issue state lands in
 	// the same commit as code changes.  maybeAutoExport() skips when
 	// BD_GIT_HOOK=1, so we invoke `bd export` as a subprocess instead.
-	exportJSONLForCommit()
+	if hookBehaviorEnabled("hooks.pre-commit.export") {
+		exportJSONLForCommit()
+	}
 
 	return 0
 }
@@ -1572,8 +1659,9 @@ func filterEnv(env []string, key string) []string {
 	return out
 }
 
-// runPostMergeHook runs chained hooks after merge, then runs the legacy
-// JSONL import fallback only when no Dolt remote is configured. See GH#3729.
+// runPostMergeHook runs chained hooks after merge, checks the JSONL export for
+// leftover conflict markers, then runs the legacy JSONL import fallback only
+// when no Dolt remote is configured. See GH#3729.
 //
 // Returns 0 on success (or if not applicable).
 //
@@ -1583,7 +1671,12 @@ func runPostMergeHook() int {
 	if exitCode := runChainedHook("post-merge", nil); exitCode != 0 {
 		return exitCode
 	}
-	importJSONLForSync("post-merge")
+	if hookBehaviorEnabled("hooks.post-merge.conflict-check") && handleJSONLConflictMarkers("post-merge") {
+		return 0
+	}
+	if hookBehaviorEnabled("hooks.post-merge.import") {
+		importJSONLForSync("post-merge")
+	}
 	return 0
 }
 
@@ -1594,13 +1687,68 @@ func runPrePushHook(args []string) int {
 	if exitCode := runChainedHook("pre-push", args); exitCode != 0 {
 		return exitCode
 	}
+	if !hookBehaviorEnabled("hooks.pre-push.check-export") {
+		return 0
+	}
+	return checkJSONLBeforePush()
+}
+
+// checkJSONLBeforePush blocks a push that would publish a broken or stale
+// JSONL export:
+//   - the committed export still contains git conflict markers, or
+//   - export.auto is enabled and the export on disk differs from the
+//     committed one, i.e. issue changes were exported after the last commit
+//     and would be left behind by this push (wy-4ope).
+//
+// Returns 1 to block the push, 0 otherwise. `git push --no-verify` bypasses it.
+func checkJSONLBeforePush() int {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return 0
+	}
+	fullPath := filepath.Join(beadsDir, exportJSONLRelPath())
+	dir, name := filepath.Dir(fullPath), filepath.Base(fullPath)
+	rel, err := filepath.Rel(exportSubprocessDir(beadsDir), fullPath)
+	if err != nil {
+		rel = fullPath
+	}
+
+	show := exec.Command("git", "show", "HEAD:./"+name)
+	show.Dir = dir
+	show.Env = scrubGitHookEnv(os.Environ())
+	committed, err := show.Output()
+	if err != nil {
+		return 0 // export not tracked in HEAD: nothing of ours is being pushed
+	}
+	for _, line := range strings.Split(string(committed), "\n") {
+		if hasConflictMarkerPrefix(line) {
+			fmt.Fprintf(os.Stderr, "beads: pre-push: the committed %s contains unresolved merge conflict markers.\n", rel)
+			fmt.Fprintln(os.Stderr, "beads: repair: run 'bd doctor --fix' (or 'bd export -o "+rel+"'), commit the result, then push again.")
+			return 1
+		}
+	}
+
+	if !config.GetBool("export.auto") {
+		return 0
+	}
+	diff := exec.Command("git", "diff", "--quiet", "HEAD", "--", name)
+	diff.Dir = dir
+	diff.Env = scrubGitHookEnv(os.Environ())
+	if err := diff.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			fmt.Fprintf(os.Stderr, "beads: pre-push: %s has issue changes that are not committed; this push would publish a stale export.\n", rel)
+			fmt.Fprintf(os.Stderr, "beads: repair: git add %s && git commit, then push again (or push with --no-verify to skip this check).\n", rel)
+			return 1
+		}
+	}
 	return 0
 }
 
 // runPostCheckoutHook runs chained hooks after branch checkout, then runs
 // the legacy JSONL import fallback when the checkout was a branch switch
-// (flag=1) and no Dolt remote is configured. File-mode checkouts (flag=0)
-// are skipped to avoid spurious imports on `git checkout -- <file>`. See GH#3729.
+// (flag=1), the JSONL differs between the two branches, and no Dolt remote is
+// configured. File-mode checkouts (flag=0) are skipped to avoid spurious
+// imports on `git checkout -- <file>`. See GH#3729.
 //
 // args: [previous-HEAD, new-HEAD, flag] where flag=1 for branch checkout
 // Returns 0 on success (or if not applicable).
@@ -1611,12 +1759,82 @@ func runPostCheckoutHook(args []string) int {
 	if exitCode := runChainedHook("post-checkout", args); exitCode != 0 {
 		return exitCode
 	}
-	if len(args) >= 3 && args[2] == "1" {
+	if len(args) < 3 || args[2] != "1" || !jsonlChangedBetween(args[0], args[1]) {
+		return 0
+	}
+	if hookBehaviorEnabled("hooks.post-checkout.conflict-check") && handleJSONLConflictMarkers("post-checkout") {
+		return 0
+	}
+	if hookBehaviorEnabled("hooks.post-checkout.import") {
 		importJSONLForSync("post-checkout")
 	}
 	return 0
 }
 
+// jsonlChangedBetween reports whether the sync JSONL differs between two
+// commits, so a branch switch that leaves it untouched skips the import.
+// When git cannot tell (unknown commits, not a repo) it reports true and the
+// upsert-only import runs as before.
+func jsonlChangedBetween(prevHead, newHead string) bool {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" || prevHead == "" || newHead == "" {
+		return true
+	}
+	if prevHead == newHead {
+		return false
+	}
+	fullPath := syncImportJSONLPath(beadsDir)
+	cmd := exec.Command("git", "diff", "--quiet", prevHead, newHead, "--", filepath.Base(fullPath))
+	cmd.Dir = filepath.Dir(fullPath)
+	cmd.Env = scrubGitHookEnv(os.Environ())
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return true
+		}
+		debug.Logf("post-checkout: cannot diff JSONL between %s and %s: %v\n", prevHead, newHead, err)
+		return true
+	}
+	return false
+}
+
+// runPostCommitHook runs chained hooks after a commit, then links the new
+// commit to the issues its message mentions and, unless switched off, closes
+// the ones it names after a closing keyword (see 'bd backfill-commits').
+// Commits replayed by a rebase are skipped; run 'bd backfill-commits'
+// afterwards to link them.
+//
+// Returns 0 on success (or if not applicable).
+//
+//nolint:unparam // Always returns 0 by design - the commit already happened
+func runPostCommitHook(args []string) int {
+	// Run chained hook first (if exists)
+	if exitCode := runChainedHook("post-commit", args); exitCode != 0 {
+		return exitCode
+	}
+	if isRebaseInProgress() || !hookBehaviorEnabled("hooks.post-commit.link-commits") {
+		return 0
+	}
+	if beads.FindBeadsDir() == "" {
+		return 0
+	}
+
+	// Shell out like the pre-commit export so the subprocess opens its own
+	// store; clearing BD_GIT_HOOK lets its writes follow the normal
+	// auto-commit path.
+	cmdArgs := []string{"backfill-commits", "--max-count", "1"}
+	if hookBehaviorEnabled("hooks.post-commit.close-issues") {
+		cmdArgs = append(cmdArgs, "--close")
+	}
+	cmd := exec.Command("bd", append(cmdArgs, "HEAD")...)
+	cmd.Env = filterEnv(os.Environ(), "BD_GIT_HOOK")
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "beads: post-commit link warning: %v\n", err)
+	}
+	return 0
+}
+
 // runPrepareCommitMsgHook adds agent identity trailers to commit messages.
 // args: [commit-msg-file, source, sha1]
 // Returns 0 on success (or if not applicable), non-zero on error.
@@ -1628,8 +1846,8 @@ func runPrepareCommitMsgHook(args []string) int {
 		return exitCode
 	}
 
-	if len(args) < 1 {
-		return 0 // No message file provided
+	if len(args) < 1 || !hookBehaviorEnabled("hooks.prepare-commit-msg.trailers") {
+		return 0 // No message file provided, or trailers disabled
 	}
 
 	msgFile := args[0]
@@ -1704,10 +1922,16 @@ Supported hooks:
   - post-merge: Run chained hooks after pull/merge
   - pre-push: Run chained hooks before push
   - post-checkout: Run chained hooks after branch checkout
+  - post-commit: Link the new commit to the issues it mentions
+- post-commit: Link the new commit to the issues it mentions
   - prepare-commit-msg: Add agent identity trailers for forensics
 
+Each run's duration and exit code is recorded in .beads/hooks.log; see
+'bd hooks stats'.
+
 The thin shim pattern ensures hook logic is always in sync with the
-installed bd version - upgrading bd automatically updates hook behavior.`,
+installed bd version - upgrading bd automatically updates hook behavior.
+Individual behaviors can be switched off per repository with 'bd hooks config'.`,
 	Args:          cobra.MinimumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -1725,6 +1949,7 @@ installed bd version - upgrading bd automatically updates hook behavior.`,
 		hookName := args[0]
 		hookArgs := args[1:]
 
+		start := time.Now()
 		var exitCode int
 		switch hookName {
 		case "pre-commit":
@@ -1735,12 +1960,25 @@ installed bd version - upgrading bd automatically updates hook behavior.`,
 			exitCode = runPrePushHook(hookArgs)
 		case "post-checkout":
 			exitCode = runPostCheckoutHook(hookArgs)
+		case "post-commit":
+			exitCode = runPostCommitHook(hookArgs)
 		case "prepare-commit-msg":
 			exitCode = runPrepareCommitMsgHook(hookArgs)
 		default:
 			return HandleError("unknown hook: %s", hookName)
 		}
 
+		// Timing is best effort: a failure to log must never fail the hook.
+		if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+			_ = recordHookRun(beadsDir, hookRunRecord{
+				Hook:       hookName,
+				StartedAt:  start.UTC(),
+				DurationMs: time.Since(start).Milliseconds(),
+				ChainedMs:  chainedHookElapsed.Milliseconds(),
+				ExitCode:   exitCode,
+			})
+		}
+
 		if exitCode != 0 {
 			return &exitError{Code: exitCode}
 		}
@@ -1753,6 +1991,8 @@ func init() {
 	hooksInstallCmd.Flags().Bool("shared", false, "Install hooks to .beads-hooks/ (versioned) instead of .git/hooks/")
 	hooksInstallCmd.Flags().Bool("chain", false, "Chain with existing hooks (run them before bd hooks)")
 	hooksInstallCmd.Flags().Bool("beads", false, "Install hooks to .beads/hooks/ (recommended for Dolt backend)")
+	hooksInstallCmd.Flags().String("manager", "", "Integrate with a hook manager (husky, lefthook, pre-commit, prek) instead of writing git hooks; auto-detected when given without a value")
+	hooksInstallCmd.Flags().Lookup("manager").NoOptDefVal = "auto"
 
 	hooksCmd.AddCommand(hooksInstallCmd)
 	hooksCmd.AddCommand(hooksUninstallCmd)
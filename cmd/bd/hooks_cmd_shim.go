@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// windowsHookShimMarker tags the .cmd companions bd writes next to each hook,
+// so install/uninstall only ever touch companions bd created.
+const windowsHookShimMarker = "bd-windows-shim"
+
+// hookTargetGOOS decides whether install writes .cmd companions; a variable
+// so tests can exercise the Windows path on any platform.
+var hookTargetGOOS = runtime.GOOS
+
+// generateWindowsHookShim returns the <hook>.cmd companion for hookName.
+//
+// Git for Windows runs the extensionless hook through its bundled sh, so the
+// POSIX hook stays the primary entry point. The companion serves Windows tools
+// that invoke hooks through cmd.exe and have no POSIX shell (e.g. IDE git
+// integrations and hook runners configured to call <hook>.cmd).
+func generateWindowsHookShim(hookName string) string {
+	lines := []string{
+		"@echo off",
+		"rem " + windowsHookShimMarker + ": " + hookName,
+		"rem Windows companion to the " + hookName + " hook, managed by beads. Do not edit.",
+		"where bd >nul 2>nul || exit /b 0",
+		"set BD_GIT_HOOK=1",
+		"bd hooks run " + hookName + " %*",
+		"if %ERRORLEVEL% EQU 3 exit /b 0",
+		"exit /b %ERRORLEVEL%",
+	}
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+// stripUTF8BOM drops the byte order mark Windows editors may save hooks with;
+// a BOM ahead of "#!" hides the shebang from git's sh.
+func stripUTF8BOM(content string) string {
+	return strings.TrimPrefix(content, "\ufeff")
+}
+
+// isWindowsHookShim reports whether content is a bd-generated .cmd companion.
+func isWindowsHookShim(content string) bool {
+	return strings.Contains(content, windowsHookShimMarker)
+}
+
+// writeWindowsHookShim writes <hook>.cmd next to hookPath. An existing
+// companion that bd did not write is left alone.
+func writeWindowsHookShim(hookPath, hookName string) error {
+	shimPath := hookPath + ".cmd"
+	// #nosec G304 -- shim path constrained to hooks directory
+	if existing, err := os.ReadFile(shimPath); err == nil && !isWindowsHookShim(string(existing)) {
+		fmt.Fprintf(os.Stderr, "Warning: %s exists and was not written by bd; leaving it unchanged\n", filepath.Base(shimPath))
+		return nil
+	} else if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(shimPath), err)
+	}
+	// #nosec G306 -- hook companions must be executable
+	if err := os.WriteFile(shimPath, []byte(generateWindowsHookShim(hookName)), 0755); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(shimPath), err)
+	}
+	return nil
+}
+
+// removeWindowsHookShim deletes a bd-generated <hook>.cmd companion.
+func removeWindowsHookShim(hookPath string) error {
+	shimPath := hookPath + ".cmd"
+	// #nosec G304 -- shim path constrained to hooks directory
+	content, err := os.ReadFile(shimPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(shimPath), err)
+	}
+	if !isWindowsHookShim(string(content)) {
+		return nil
+	}
+	if err := os.Remove(shimPath); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", filepath.Base(shimPath), err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// hookBehavior is one bd action performed by a git hook. Each behavior is
+// toggled per repo with a hooks.<hook>.<behavior> key in config.yaml; all of
+// them default to enabled.
+type hookBehavior struct {
+	Key         string `json:"key"`
+	Hook        string `json:"hook"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+var hookBehaviors = []hookBehavior{
+	{Key: "hooks.pre-commit.export", Hook: "pre-commit", Description: "Export the JSONL into the commit (requires export.auto)"},
+	{Key: "hooks.post-merge.conflict-check", Hook: "post-merge", Description: "Check the JSONL export for leftover conflict markers"},
+	{Key: "hooks.post-merge.import", Hook: "post-merge", Description: "Import the JSONL (legacy fallback, requires import.auto)"},
+	{Key: "hooks.pre-push.check-export", Hook: "pre-push", Description: "Block pushes of a conflicted or uncommitted JSONL export"},
+	{Key: "hooks.post-checkout.conflict-check", Hook: "post-checkout", Description: "Check the JSONL export for leftover conflict markers"},
+	{Key: "hooks.post-checkout.import", Hook: "post-checkout", Description: "Import the JSONL on branch switch (legacy fallback, requires import.auto)"},
+	{Key: "hooks.post-commit.link-commits", Hook: "post-commit", Description: "Link the new commit to the issues its message mentions"},
+	{Key: "hooks.post-commit.close-issues", Hook: "post-commit", Description: "Close issues the commit names after a closing keyword (fixes, closes)"},
+	{Key: "hooks.prepare-commit-msg.trailers", Hook: "prepare-commit-msg", Description: "Add Executed-By: agent identity trailers"},
+}
+
+// hookBehaviorEnabled reports whether the behavior behind key is switched on.
+// Behaviors stay enabled when config has not been loaded, so hooks keep their
+// historical behavior.
+func hookBehaviorEnabled(key string) bool {
+	if config.GetString(key) == "" {
+		return true
+	}
+	return config.GetBool(key)
+}
+
+// lookupHookBehavior resolves a full key ("hooks.pre-commit.export") or its
+// short form ("pre-commit.export").
+func lookupHookBehavior(key string) (hookBehavior, bool) {
+	if !strings.HasPrefix(key, "hooks.") {
+		key = "hooks." + key
+	}
+	for _, b := range hookBehaviors {
+		if b.Key == key {
+			return b, true
+		}
+	}
+	return hookBehavior{}, false
+}
+
+var hooksConfigCmd = &cobra.Command{
+	Use:   "config [key] [true|false]",
+	Short: "Show or toggle individual hook behaviors",
+	Long: `Show or toggle the individual behaviors bd performs from git hooks.
+
+'bd hooks run' consults these settings every time a hook fires, so a behavior
+can be switched off for this repository without editing or reinstalling the
+hook scripts. Chained hooks always run. Settings are stored in
+.beads/config.yaml under the hooks.* keys; the "hooks." prefix may be omitted.
+
+Examples:
+  bd hooks config                                # List all behaviors
+  bd hooks config post-checkout.import           # Show one behavior
+  bd hooks config pre-commit.export false        # Stop exporting on commit
+  bd hooks config hooks.pre-push.check-export true`,
+	Args:          cobra.MaximumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("hooks-config")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if len(args) == 0 {
+			behaviors := make([]hookBehavior, len(hookBehaviors))
+			for i, b := range hookBehaviors {
+				b.Enabled = hookBehaviorEnabled(b.Key)
+				behaviors[i] = b
+			}
+			if jsonOutput {
+				return outputJSON(behaviors)
+			}
+			for _, b := range behaviors {
+				state := ui.RenderPass("on ")
+				if !b.Enabled {
+					state = ui.RenderMuted("off")
+				}
+				fmt.Printf("  %s  %-36s %s\n", state, b.Key, ui.RenderMuted(b.Description))
+			}
+			return nil
+		}
+
+		behavior, ok := lookupHookBehavior(args[0])
+		if !ok {
+			return HandleErrorRespectJSON("unknown hook behavior %q (run 'bd hooks config' to list them)", args[0])
+		}
+
+		if len(args) == 1 {
+			behavior.Enabled = hookBehaviorEnabled(behavior.Key)
+			if jsonOutput {
+				return outputJSON(behavior)
+			}
+			fmt.Printf("%s = %t\n", behavior.Key, behavior.Enabled)
+			return nil
+		}
+
+		enabled, err := strconv.ParseBool(args[1])
+		if err != nil {
+			return HandleErrorRespectJSON("%s must be true or false, got %q", behavior.Key, args[1])
+		}
+		if err := config.SetYamlConfig(behavior.Key, strconv.FormatBool(enabled)); err != nil {
+			return HandleErrorRespectJSON("setting %s: %v", behavior.Key, err)
+		}
+		behavior.Enabled = enabled
+		if jsonOutput {
+			return outputJSON(behavior)
+		}
+		fmt.Printf("Set %s = %t (in config.yaml)\n", behavior.Key, enabled)
+		return nil
+	},
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksConfigCmd)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+func TestHookBehaviorsDefaultEnabled(t *testing.T) {
+	initConfigForTest(t)
+	for _, b := range hookBehaviors {
+		if !hookBehaviorEnabled(b.Key) {
+			t.Errorf("%s should default to enabled", b.Key)
+		}
+		if !strings.HasPrefix(b.Key, "hooks."+b.Hook+".") {
+			t.Errorf("%s does not belong to hook %s", b.Key, b.Hook)
+		}
+	}
+
+	config.Set("hooks.pre-commit.export", false)
+	if hookBehaviorEnabled("hooks.pre-commit.export") {
+		t.Error("hooks.pre-commit.export = false should disable the behavior")
+	}
+}
+
+func TestLookupHookBehavior(t *testing.T) {
+	for _, key := range []string{"hooks.post-checkout.import", "post-checkout.import"} {
+		b, ok := lookupHookBehavior(key)
+		if !ok || b.Key != "hooks.post-checkout.import" {
+			t.Errorf("lookupHookBehavior(%q) = %+v, %v", key, b, ok)
+		}
+	}
+	if _, ok := lookupHookBehavior("post-checkout.export"); ok {
+		t.Error("unknown behavior should not resolve")
+	}
+}
+
+func TestPrepareCommitMsgTrailersToggle(t *testing.T) {
+	initConfigForTest(t)
+	t.Setenv("BD_ACTOR", "agent-7")
+	msgFile := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+
+	config.Set("hooks.prepare-commit-msg.trailers", false)
+	if err := os.WriteFile(msgFile, []byte("Fix bug\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	runPrepareCommitMsgHook([]string{msgFile, "message"})
+	if got, _ := os.ReadFile(msgFile); string(got) != "Fix bug\n" {
+		t.Errorf("disabled trailers should leave the message alone, got %q", got)
+	}
+
+	config.Set("hooks.prepare-commit-msg.trailers", true)
+	runPrepareCommitMsgHook([]string{msgFile, "message"})
+	if got, _ := os.ReadFile(msgFile); !strings.Contains(string(got), "Executed-By: agent-7") {
+		t.Errorf("enabled trailers should add Executed-By, got %q", got)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/metrics"
+)
+
+// hookMarkerRepair previews the rebuild of one hook with broken markers.
+type hookMarkerRepair struct {
+	HookName string `json:"hook_name"`
+	HookPath string `json:"hook_path"`
+	// DroppedLines are lines of the current file that the rebuilt hook no
+	// longer contains: remnants of the partial beads section.
+	DroppedLines []string `json:"dropped_lines"`
+}
+
+var hooksDoctorCmd = &cobra.Command{
+	Use:   "doctor [path]",
+	Short: "Diagnose and repair installed git hooks",
+	Long: `Check the git hooks for migration issues and optionally repair them.
+
+Without flags, reports each managed hook's state. --fix applies the hook
+migration plan (legacy shims, sidecar artifacts, Windows-style hooks).
+
+Hooks whose BEGIN/END BEADS INTEGRATION markers are broken (orphaned,
+duplicated, or reversed) are only repaired with --fix-broken-markers: the
+partial section is removed and a fresh one is rebuilt from the template,
+keeping every line outside the detected partial marker. The lines removed
+are listed so the repair can be reviewed.
+
+Examples:
+  bd hooks doctor
+  bd hooks doctor --fix
+  bd hooks doctor --fix --fix-broken-markers`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("hooks-doctor")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		fixBrokenMarkers, _ := cmd.Flags().GetBool("fix-broken-markers")
+		fix, _ := cmd.Flags().GetBool("fix")
+		fix = fix || fixBrokenMarkers
+		if fix {
+			CheckReadonly("hooks doctor --fix")
+		}
+
+		targetPath := "."
+		if len(args) == 1 {
+			targetPath = args[0]
+		}
+		absPath, err := filepath.Abs(targetPath)
+		if err != nil {
+			return HandleErrorRespectJSON("resolving path: %v", err)
+		}
+
+		plan, err := doctor.PlanHookMigration(absPath)
+		if err != nil {
+			return HandleErrorRespectJSON("building hook migration plan: %v", err)
+		}
+		execPlan, repairs, err := planHookDoctorRepairs(buildHookMigrationExecutionPlan(plan), fixBrokenMarkers)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		var summary *hookMigrationApplySummary
+		if fix && len(execPlan.BlockingErrors) == 0 && execPlan.operationCount() > 0 {
+			applied, err := applyHookMigrationExecution(execPlan)
+			if err != nil {
+				return HandleErrorRespectJSON("repairing hooks: %v", err)
+			}
+			summary = &applied
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"fix":                fix,
+				"fix_broken_markers": fixBrokenMarkers,
+				"plan":               plan,
+				"operations":         execPlan.outputOperations(),
+				"marker_repairs":     repairs,
+				"blocking_errors":    execPlan.BlockingErrors,
+				"result":             summary,
+			})
+		}
+
+		for _, line := range formatHookMigrationPlan(plan, hookMigrationMode{}) {
+			// The migrate-specific next-step lines are replaced by our own hints below.
+			if !strings.HasPrefix(line, "Next: ") && !strings.HasPrefix(line, "Applying ") {
+				fmt.Println(line)
+			}
+		}
+		fmt.Println()
+		fmt.Println(strings.Join(formatHookMigrationOperations(execPlan), "\n"))
+		for _, repair := range repairs {
+			fmt.Printf("\nRebuilding marker section in %s; removed lines:\n", repair.HookName)
+			if len(repair.DroppedLines) == 0 {
+				fmt.Println("  (none)")
+			}
+			for _, line := range repair.DroppedLines {
+				fmt.Printf("  - %s\n", line)
+			}
+		}
+		if plan.BrokenMarkerCount > 0 && !fixBrokenMarkers {
+			fmt.Println("\nBroken markers were left untouched; rerun with --fix-broken-markers to rebuild them.")
+		}
+		if summary != nil {
+			for _, line := range formatHookMigrationApplySummary(*summary) {
+				fmt.Println(line)
+			}
+		} else if !fix && execPlan.operationCount() > 0 {
+			fmt.Println("\nRun 'bd hooks doctor --fix' to apply these operations.")
+		}
+		if fix && len(execPlan.BlockingErrors) > 0 {
+			return HandleErrorRespectJSON("hook repair is blocked:\n- %s", strings.Join(execPlan.BlockingErrors, "\n- "))
+		}
+		return nil
+	},
+}
+
+// planHookDoctorRepairs removes broken-marker rewrites from execPlan unless
+// includeBroken is set; when it is, it previews each rewrite so the lines it
+// drops can be shown before anything is written.
+func planHookDoctorRepairs(execPlan hookMigrationExecutionPlan, includeBroken bool) (hookMigrationExecutionPlan, []hookMarkerRepair, error) {
+	writeOps := make([]hookMigrationWriteOp, 0, len(execPlan.WriteOps))
+	var repairs []hookMarkerRepair
+	for _, op := range execPlan.WriteOps {
+		if op.State != "marker_broken" {
+			writeOps = append(writeOps, op)
+			continue
+		}
+		if !includeBroken {
+			execPlan.NoopHooks = append(execPlan.NoopHooks, op.HookName)
+			continue
+		}
+		before, err := os.ReadFile(op.HookPath) // #nosec G304 -- path from migration planner
+		if err != nil {
+			return execPlan, nil, fmt.Errorf("reading %s: %w", op.HookPath, err)
+		}
+		after, err := renderMigratedHookContent(op)
+		if err != nil {
+			return execPlan, nil, err
+		}
+		repairs = append(repairs, hookMarkerRepair{
+			HookName:     op.HookName,
+			HookPath:     op.HookPath,
+			DroppedLines: droppedHookLines(string(before), string(after)),
+		})
+		writeOps = append(writeOps, op)
+	}
+	execPlan.WriteOps = writeOps
+	return execPlan, repairs, nil
+}
+
+// droppedHookLines returns the non-blank lines of before that after no longer
+// contains (each occurrence counted separately).
+func droppedHookLines(before, after string) []string {
+	remaining := make(map[string]int)
+	for _, line := range strings.Split(strings.ReplaceAll(after, "\r\n", "\n"), "\n") {
+		remaining[line]++
+	}
+	dropped := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(before, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if remaining[line] > 0 {
+			remaining[line]--
+			continue
+		}
+		dropped = append(dropped, line)
+	}
+	return dropped
+}
+
+func init() {
+	hooksDoctorCmd.Flags().Bool("fix", false, "Apply the hook migration plan")
+	hooksDoctorCmd.Flags().Bool("fix-broken-markers", false, "Also rebuild hooks with broken BEGIN/END BEADS INTEGRATION markers (implies --fix)")
+	hooksCmd.AddCommand(hooksDoctorCmd)
+}
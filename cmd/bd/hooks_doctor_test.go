@@ -0,0 +1,71 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+)
+
+func TestPlanHookDoctorRepairs(t *testing.T) {
+	repoDir, hooksDir := setupHookMigrationRepo(t)
+	preCommitPath := filepath.Join(hooksDir, "pre-commit")
+	postMergePath := filepath.Join(hooksDir, "post-merge")
+
+	broken := "#!/usr/bin/env sh\necho lint\n# --- BEGIN BEADS INTEGRATION v0.57.0 ---\nbd hook pre-commit \"$@\"\nnpm test\n"
+	writeHookMigrationFile(t, preCommitPath, broken)
+	writeHookMigrationFile(t, postMergePath, "#!/bin/sh\n# bd-shim v2\nexec bd hooks run post-merge \"$@\"\n")
+
+	plan, err := doctor.PlanHookMigration(repoDir)
+	if err != nil {
+		t.Fatalf("PlanHookMigration failed: %v", err)
+	}
+
+	t.Run("broken markers skipped without flag", func(t *testing.T) {
+		execPlan, repairs, err := planHookDoctorRepairs(buildHookMigrationExecutionPlan(plan), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(repairs) != 0 {
+			t.Errorf("expected no marker repairs, got %+v", repairs)
+		}
+		if len(execPlan.WriteOps) != 1 || execPlan.WriteOps[0].HookName != "post-merge" {
+			t.Errorf("expected only the legacy post-merge rewrite, got %+v", execPlan.WriteOps)
+		}
+	})
+
+	t.Run("broken markers rebuilt with flag", func(t *testing.T) {
+		execPlan, repairs, err := planHookDoctorRepairs(buildHookMigrationExecutionPlan(plan), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(repairs) != 1 || repairs[0].HookName != "pre-commit" {
+			t.Fatalf("expected one pre-commit repair, got %+v", repairs)
+		}
+		dropped := strings.Join(repairs[0].DroppedLines, "\n")
+		if !strings.Contains(dropped, "bd hook pre-commit") || strings.Contains(dropped, "npm test") || strings.Contains(dropped, "echo lint") {
+			t.Errorf("repair should drop only the partial section, dropped:\n%s", dropped)
+		}
+
+		if _, err := applyHookMigrationExecution(execPlan); err != nil {
+			t.Fatalf("applyHookMigrationExecution failed: %v", err)
+		}
+		rendered := mustReadHookMigrationFile(t, preCommitPath)
+		for _, want := range []string{"echo lint", "npm test", hookSectionEndPrefix, "bd hooks run pre-commit"} {
+			if !strings.Contains(rendered, want) {
+				t.Errorf("repaired hook missing %q:\n%s", want, rendered)
+			}
+		}
+		if strings.Count(rendered, hookSectionBeginPrefix) != 1 {
+			t.Errorf("expected exactly one section after repair:\n%s", rendered)
+		}
+	})
+}
+
+func TestDroppedHookLines(t *testing.T) {
+	got := droppedHookLines("a\nb\n\nb\nc\n", "a\r\nb\r\nd\r\n")
+	if strings.Join(got, ",") != "b,c" {
+		t.Errorf("droppedHookLines = %q, want [b c]", got)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/lockfile"
+)
+
+// hooksLockFileName is the advisory lock held while bd writes or retires hook
+// files, so concurrent `bd migrate hooks --apply`, `bd hooks install`, and
+// `bd hooks uninstall` runs cannot interleave their writes.
+const hooksLockFileName = ".bd-lock"
+
+// gitCommonDirFor returns the absolute common git directory of the repository
+// containing dir (which may be inside the git directory itself).
+func gitCommonDirFor(dir string) (string, error) {
+	// #nosec G204 -- fixed git arguments, dir only selects the working directory
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--git-common-dir").Output()
+	if err != nil {
+		return "", err
+	}
+	commonDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(dir, commonDir)
+	}
+	return filepath.Clean(commonDir), nil
+}
+
+// hooksLockPath returns the lock file guarding hooksDir: <git-common-dir>/hooks/.bd-lock
+// for the repository owning hooksDir, so every hooks location of one repository
+// (.git/hooks, .beads/hooks, .beads-hooks, worktrees) shares a single lock and
+// no lock file lands in a versioned directory. Outside a git repository the
+// lock lives in hooksDir itself.
+func hooksLockPath(hooksDir string) string {
+	commonDir, err := gitCommonDirFor(hooksDir)
+	if err != nil {
+		return filepath.Join(hooksDir, hooksLockFileName)
+	}
+	return filepath.Join(commonDir, "hooks", hooksLockFileName)
+}
+
+// acquireHooksLock takes the exclusive hooks lock for hooksDir without
+// waiting. The returned function releases it. If another bd process holds the
+// lock, the error says so instead of blocking a git operation indefinitely.
+func acquireHooksLock(hooksDir string) (func(), error) {
+	lockPath := hooksLockPath(hooksDir)
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, fmt.Errorf("creating hooks lock directory: %w", err)
+	}
+	// #nosec G304 -- lock path derived from the repository's git directory
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("creating hooks lock: %w", err)
+	}
+	if err := lockfile.FlockExclusiveNonBlocking(f); err != nil {
+		_ = f.Close()
+		if lockfile.IsLocked(err) {
+			return nil, fmt.Errorf("another bd process is modifying hooks in %s (lock: %s); retry once it finishes", hooksDir, lockPath)
+		}
+		return nil, fmt.Errorf("acquiring hooks lock: %w", err)
+	}
+	return func() {
+		_ = lockfile.FlockUnlock(f)
+		_ = f.Close()
+	}, nil
+}
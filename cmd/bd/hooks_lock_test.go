@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+)
+
+func TestHooksLockPathSharedAcrossHooksDirs(t *testing.T) {
+	repoDir, hooksDir := setupHookMigrationRepo(t)
+	beadsHooks := filepath.Join(repoDir, ".beads", "hooks")
+	if err := os.MkdirAll(beadsHooks, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	want := hooksLockPath(hooksDir)
+	if filepath.Base(want) != hooksLockFileName || !strings.Contains(filepath.ToSlash(want), ".git/hooks/") {
+		t.Fatalf("expected lock under .git/hooks, got %s", want)
+	}
+	if got := hooksLockPath(beadsHooks); got != want {
+		t.Errorf(".beads/hooks should share the repository lock %s, got %s", want, got)
+	}
+	notGit := t.TempDir()
+	if got := hooksLockPath(notGit); got != filepath.Join(notGit, hooksLockFileName) {
+		t.Errorf("outside git the lock should live in the hooks dir, got %s", got)
+	}
+}
+
+func TestApplyHookMigrationExecution_FailsWhileHooksLocked(t *testing.T) {
+	repoDir, hooksDir := setupHookMigrationRepo(t)
+	preCommitPath := filepath.Join(hooksDir, "pre-commit")
+	legacy := "#!/usr/bin/env sh\n# bd-shim v2\n# bd-hooks-version: 0.56.1\nexec bd hooks run pre-commit \"$@\"\n"
+	writeHookMigrationFile(t, preCommitPath, legacy)
+
+	plan, err := doctor.PlanHookMigration(repoDir)
+	if err != nil {
+		t.Fatalf("PlanHookMigration failed: %v", err)
+	}
+	execPlan := buildHookMigrationExecutionPlan(plan)
+
+	unlock, err := acquireHooksLock(hooksDir)
+	if err != nil {
+		t.Fatalf("acquireHooksLock: %v", err)
+	}
+	if _, err := acquireHooksLock(hooksDir); err == nil || !strings.Contains(err.Error(), "another bd process is modifying hooks") {
+		t.Fatalf("second acquire should report the concurrent writer, got %v", err)
+	}
+	if _, err := applyHookMigrationExecution(execPlan); err == nil || !strings.Contains(err.Error(), "another bd process") {
+		t.Fatalf("apply should refuse while hooks are locked, got %v", err)
+	}
+	if got := mustReadHookMigrationFile(t, preCommitPath); got != legacy {
+		t.Fatalf("hook must be untouched while locked, got:\n%s", got)
+	}
+
+	unlock()
+	if _, err := applyHookMigrationExecution(execPlan); err != nil {
+		t.Fatalf("apply after unlock failed: %v", err)
+	}
+}
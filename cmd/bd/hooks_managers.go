@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+	"github.com/steveyegge/beads/internal/git"
+)
+
+// runHookManagerInstall implements `bd hooks install --manager`.
+func runHookManagerInstall(requested string) error {
+	repoRoot := git.GetRepoRoot()
+	if repoRoot == "" {
+		return HandleErrorRespectJSON("not in a git repository")
+	}
+	manager, err := resolveHookManager(repoRoot, requested)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	result, err := integrateHookManager(repoRoot, manager, managedHookNames)
+	if err != nil {
+		return HandleErrorRespectJSON("integrating with %s: %v", manager, err)
+	}
+
+	if jsonOutput {
+		return outputJSON(result)
+	}
+	for _, written := range result.Written {
+		fmt.Printf("✓ Added 'bd hooks run' to %s\n", written)
+	}
+	if len(result.AlreadyIntegrated) > 0 {
+		fmt.Printf("Already calling bd in %s: %s\n", manager, strings.Join(result.AlreadyIntegrated, ", "))
+	}
+	if result.Snippet != "" {
+		fmt.Printf("Add this to %s:\n\n%s\n", result.ConfigFile, result.Snippet)
+		switch manager {
+		case "lefthook":
+			fmt.Println("Then run 'lefthook install'.")
+		case "pre-commit", "prek":
+			fmt.Printf("Then run '%s install --hook-type pre-commit --hook-type post-merge --hook-type pre-push --hook-type post-checkout --hook-type post-commit'.\n", manager)
+		}
+	} else if len(result.Written) == 0 {
+		fmt.Printf("✓ %s already runs bd for every hook\n", manager)
+	}
+	return nil
+}
+
+// hookManagerIntegration describes how bd was wired into an external hook
+// manager by `bd hooks install --manager`.
+type hookManagerIntegration struct {
+	Manager string `json:"manager"`
+	// Written lists files bd created or appended to (husky scripts).
+	Written []string `json:"written,omitempty"`
+	// ConfigFile and Snippet are set for managers whose config bd does not
+	// edit itself (lefthook, pre-commit/prek): the snippet to add there.
+	ConfigFile string `json:"config_file,omitempty"`
+	Snippet    string `json:"snippet,omitempty"`
+	// AlreadyIntegrated lists hooks whose manager config already calls bd.
+	AlreadyIntegrated []string `json:"already_integrated,omitempty"`
+}
+
+// resolveHookManager picks the manager to integrate with: the requested one,
+// or for "auto" the manager that installed the current git hooks, falling
+// back to the first one with a config file in the repository.
+func resolveHookManager(repoRoot, requested string) (string, error) {
+	if requested != "" && requested != "auto" {
+		switch requested {
+		case "husky", "lefthook", "pre-commit", "prek":
+			return requested, nil
+		}
+		return "", fmt.Errorf("unsupported hook manager %q (supported: husky, lefthook, pre-commit, prek)", requested)
+	}
+	if active := fix.DetectActiveHookManager(repoRoot); active != "" {
+		switch active {
+		case "husky", "lefthook", "pre-commit", "prek":
+			return active, nil
+		}
+	}
+	for _, m := range fix.DetectExternalHookManagers(repoRoot) {
+		switch m.Name {
+		case "husky", "lefthook", "pre-commit":
+			return m.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no supported hook manager detected (husky, lefthook, pre-commit, prek)")
+}
+
+// integrateHookManager adds bd to an external hook manager's own
+// configuration instead of writing git hook files the manager would overwrite.
+func integrateHookManager(repoRoot, manager string, hookNames []string) (hookManagerIntegration, error) {
+	switch manager {
+	case "husky":
+		return integrateHusky(repoRoot, hookNames)
+	case "lefthook":
+		return lefthookIntegration(repoRoot, hookNames)
+	case "pre-commit", "prek":
+		return precommitIntegration(repoRoot, manager, hookNames)
+	}
+	return hookManagerIntegration{}, fmt.Errorf("unsupported hook manager %q", manager)
+}
+
+// hookCallsBd reports whether content already runs `bd hooks run <hook>`.
+func hookCallsBd(content, hookName string) bool {
+	return regexp.MustCompile(`\bbd\s+hooks\s+run\s+` + regexp.QuoteMeta(hookName) + `\b`).MatchString(content)
+}
+
+// integrateHusky appends a bd call to each .husky/<hook> script, creating the
+// script when the repository has none for that hook. husky runs these scripts
+// itself, so the generated .husky/_ wrappers are never touched.
+func integrateHusky(repoRoot string, hookNames []string) (hookManagerIntegration, error) {
+	result := hookManagerIntegration{Manager: "husky"}
+	huskyDir := filepath.Join(repoRoot, ".husky")
+	if err := os.MkdirAll(huskyDir, 0755); err != nil {
+		return result, fmt.Errorf("failed to create .husky: %w", err)
+	}
+	for _, hookName := range hookNames {
+		scriptPath := filepath.Join(huskyDir, hookName)
+		// #nosec G304 -- path constrained to .husky and known hook names
+		existing, err := os.ReadFile(scriptPath)
+		if err != nil && !os.IsNotExist(err) {
+			return result, fmt.Errorf("failed to read %s: %w", scriptPath, err)
+		}
+		content := string(existing)
+		if hookCallsBd(content, hookName) {
+			result.AlreadyIntegrated = append(result.AlreadyIntegrated, hookName)
+			continue
+		}
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += "bd hooks run " + hookName + " \"$@\"\n"
+		// #nosec G306 -- husky scripts must be executable
+		if err := os.WriteFile(scriptPath, []byte(content), 0755); err != nil {
+			return result, fmt.Errorf("failed to write %s: %w", scriptPath, err)
+		}
+		result.Written = append(result.Written, filepath.Join(".husky", hookName))
+	}
+	return result, nil
+}
+
+// lefthookIntegration renders the commands to add to the lefthook config in
+// the config's own format. The config is not edited: it is usually
+// hand-maintained and may be YAML, TOML, or JSON.
+func lefthookIntegration(repoRoot string, hookNames []string) (hookManagerIntegration, error) {
+	result := hookManagerIntegration{Manager: "lefthook", ConfigFile: "lefthook.yml"}
+	var existing string
+	for _, m := range fix.DetectExternalHookManagers(repoRoot) {
+		if m.Name == "lefthook" {
+			result.ConfigFile = m.ConfigFile
+			// #nosec G304 -- config path from hook manager detection
+			if content, err := os.ReadFile(filepath.Join(repoRoot, m.ConfigFile)); err == nil {
+				existing = string(content)
+			}
+		}
+	}
+
+	var missing []string
+	for _, hookName := range hookNames {
+		if hookCallsBd(existing, hookName) {
+			result.AlreadyIntegrated = append(result.AlreadyIntegrated, hookName)
+			continue
+		}
+		missing = append(missing, hookName)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	// {0} expands to all arguments git passed to the hook.
+	run := func(hookName string) string { return "bd hooks run " + hookName + " {0}" }
+	var sb strings.Builder
+	switch strings.ToLower(filepath.Ext(result.ConfigFile)) {
+	case ".toml":
+		for _, hookName := range missing {
+			fmt.Fprintf(&sb, "[%s.commands.bd]\nrun = %q\n\n", hookName, run(hookName))
+		}
+	case ".json":
+		cfg := make(map[string]interface{}, len(missing))
+		for _, hookName := range missing {
+			cfg[hookName] = map[string]interface{}{
+				"commands": map[string]interface{}{"bd": map[string]string{"run": run(hookName)}},
+			}
+		}
+		data, _ := json.MarshalIndent(cfg, "", "  ")
+		sb.Write(data)
+		sb.WriteString("\n")
+	default:
+		for _, hookName := range missing {
+			fmt.Fprintf(&sb, "%s:\n  commands:\n    bd:\n      run: %s\n", hookName, run(hookName))
+		}
+	}
+	result.Snippet = strings.TrimRight(sb.String(), "\n") + "\n"
+	return result, nil
+}
+
+// precommitStageArgs maps a git hook to the pre-commit stage it runs in and
+// the arguments bd needs. pre-commit does not forward git's hook arguments;
+// for post-checkout it exposes them as environment variables instead.
+// prepare-commit-msg is omitted: pre-commit does not hand the message file to
+// `language: system` hooks that opt out of filenames.
+var precommitStageArgs = map[string]string{
+	"pre-commit":    "",
+	"post-merge":    "",
+	"pre-push":      "",
+	"post-checkout": ` "$PRE_COMMIT_FROM_REF" "$PRE_COMMIT_TO_REF" "$PRE_COMMIT_CHECKOUT_TYPE"`,
+	"post-commit":   "",
+}
+
+// precommitIntegration renders a `repo: local` entry for
+// .pre-commit-config.yaml (also read by prek).
+func precommitIntegration(repoRoot, manager string, hookNames []string) (hookManagerIntegration, error) {
+	result := hookManagerIntegration{Manager: manager, ConfigFile: ".pre-commit-config.yaml"}
+	var existing string
+	for _, name := range []string{".pre-commit-config.yaml", ".pre-commit-config.yml"} {
+		// #nosec G304 -- fixed config file names under the repo root
+		if content, err := os.ReadFile(filepath.Join(repoRoot, name)); err == nil {
+			result.ConfigFile = name
+			existing = string(content)
+			break
+		}
+	}
+
+	var sb strings.Builder
+	for _, hookName := range hookNames {
+		args, ok := precommitStageArgs[hookName]
+		if !ok {
+			continue
+		}
+		if hookCallsBd(existing, hookName) {
+			result.AlreadyIntegrated = append(result.AlreadyIntegrated, hookName)
+			continue
+		}
+		entry := "bd hooks run " + hookName
+		if args != "" {
+			entry = "sh -c 'bd hooks run " + hookName + args + "'"
+		}
+		fmt.Fprintf(&sb, "      - id: bd-%s\n        name: bd hooks run %s\n        entry: %s\n        language: system\n        pass_filenames: false\n        always_run: true\n        stages: [%s]\n",
+			hookName, hookName, entry, hookName)
+	}
+	if sb.Len() > 0 {
+		result.Snippet = "  - repo: local\n    hooks:\n" + sb.String()
+	}
+	return result, nil
+}
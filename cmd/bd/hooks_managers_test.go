@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIntegrateHusky(t *testing.T) {
+	repo := t.TempDir()
+	huskyDir := filepath.Join(repo, ".husky")
+	if err := os.MkdirAll(huskyDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(huskyDir, "pre-commit"), []byte("npm test"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(huskyDir, "pre-push"), []byte("bd hooks run pre-push \"$@\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := integrateHookManager(repo, "husky", []string{"pre-commit", "pre-push", "post-merge"})
+	if err != nil {
+		t.Fatalf("integrateHookManager: %v", err)
+	}
+	if strings.Join(result.Written, ",") != filepath.Join(".husky", "pre-commit")+","+filepath.Join(".husky", "post-merge") {
+		t.Errorf("Written = %v", result.Written)
+	}
+	if strings.Join(result.AlreadyIntegrated, ",") != "pre-push" {
+		t.Errorf("AlreadyIntegrated = %v", result.AlreadyIntegrated)
+	}
+	got, _ := os.ReadFile(filepath.Join(huskyDir, "pre-commit"))
+	if string(got) != "npm test\nbd hooks run pre-commit \"$@\"\n" {
+		t.Errorf("pre-commit script = %q", got)
+	}
+
+	// Re-running is a no-op.
+	again, err := integrateHookManager(repo, "husky", []string{"pre-commit", "pre-push", "post-merge"})
+	if err != nil || len(again.Written) != 0 {
+		t.Errorf("second run should not write, got %v (err %v)", again.Written, err)
+	}
+}
+
+func TestLefthookIntegrationSnippet(t *testing.T) {
+	repo := t.TempDir()
+	config := "pre-commit:\n  commands:\n    bd:\n      run: bd hooks run pre-commit {0}\n"
+	if err := os.WriteFile(filepath.Join(repo, "lefthook.yml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := integrateHookManager(repo, "lefthook", []string{"pre-commit", "pre-push"})
+	if err != nil {
+		t.Fatalf("integrateHookManager: %v", err)
+	}
+	if result.ConfigFile != "lefthook.yml" || strings.Join(result.AlreadyIntegrated, ",") != "pre-commit" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	want := "pre-push:\n  commands:\n    bd:\n      run: bd hooks run pre-push {0}\n"
+	if result.Snippet != want {
+		t.Errorf("Snippet = %q, want %q", result.Snippet, want)
+	}
+	if got, _ := os.ReadFile(filepath.Join(repo, "lefthook.yml")); string(got) != config {
+		t.Error("lefthook config must not be edited")
+	}
+}
+
+func TestPrecommitIntegrationSnippet(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, ".pre-commit-config.yaml"), []byte("repos: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := integrateHookManager(repo, "prek", managedHookNames)
+	if err != nil {
+		t.Fatalf("integrateHookManager: %v", err)
+	}
+	for _, want := range []string{
+		"- repo: local",
+		"id: bd-pre-push",
+		"stages: [post-merge]",
+		`entry: sh -c 'bd hooks run post-checkout "$PRE_COMMIT_FROM_REF" "$PRE_COMMIT_TO_REF" "$PRE_COMMIT_CHECKOUT_TYPE"'`,
+	} {
+		if !strings.Contains(result.Snippet, want) {
+			t.Errorf("snippet missing %q:\n%s", want, result.Snippet)
+		}
+	}
+	if strings.Contains(result.Snippet, "prepare-commit-msg") {
+		t.Errorf("prepare-commit-msg cannot be wired through pre-commit:\n%s", result.Snippet)
+	}
+}
+
+func TestResolveHookManager(t *testing.T) {
+	repo := t.TempDir()
+	if _, err := resolveHookManager(repo, "overcommit"); err == nil {
+		t.Error("expected unsupported manager error")
+	}
+	if _, err := resolveHookManager(repo, "auto"); err == nil {
+		t.Error("expected error when no manager is configured")
+	}
+	if err := os.MkdirAll(filepath.Join(repo, ".husky"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := resolveHookManager(repo, "auto"); err != nil || got != "husky" {
+		t.Errorf("resolveHookManager(auto) = %q, %v; want husky", got, err)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// setupHookJSONLRepo creates a git repo with a committed .beads/issues.jsonl
+// and points BEADS_DIR at it. Returns the repo root and the export path.
+func setupHookJSONLRepo(t *testing.T, content string) (string, string) {
+	t.Helper()
+	initConfigForTest(t)
+	repo := newGitRepo(t)
+	beadsDir := filepath.Join(repo, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(jsonlPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runHookTestGit(t, repo, "add", ".")
+	runHookTestGit(t, repo, "commit", "-qm", "init")
+	t.Setenv("BEADS_DIR", beadsDir)
+	return repo, jsonlPath
+}
+
+func runHookTestGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestCheckJSONLBeforePush(t *testing.T) {
+	t.Run("clean committed export passes", func(t *testing.T) {
+		setupHookJSONLRepo(t, `{"id":"bd-1"}`+"\n")
+		if got := checkJSONLBeforePush(); got != 0 {
+			t.Errorf("checkJSONLBeforePush() = %d, want 0", got)
+		}
+	})
+
+	t.Run("committed conflict markers block", func(t *testing.T) {
+		setupHookJSONLRepo(t, "<<<<<<< HEAD\n{\"id\":\"bd-1\"}\n=======\n{\"id\":\"bd-2\"}\n>>>>>>> main\n")
+		var got int
+		stderr := captureHookStderr(t, func() { got = checkJSONLBeforePush() })
+		if got != 1 || !strings.Contains(stderr, "conflict markers") {
+			t.Errorf("checkJSONLBeforePush() = %d, stderr %q; want 1 with a conflict marker message", got, stderr)
+		}
+	})
+
+	t.Run("uncommitted export blocks only with export.auto", func(t *testing.T) {
+		_, jsonlPath := setupHookJSONLRepo(t, `{"id":"bd-1"}`+"\n")
+		if err := os.WriteFile(jsonlPath, []byte(`{"id":"bd-1"}`+"\n"+`{"id":"bd-2"}`+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		config.Set("export.auto", false)
+		if got := checkJSONLBeforePush(); got != 0 {
+			t.Errorf("export.auto=false: checkJSONLBeforePush() = %d, want 0", got)
+		}
+
+		config.Set("export.auto", true)
+		var got int
+		stderr := captureHookStderr(t, func() { got = checkJSONLBeforePush() })
+		if got != 1 || !strings.Contains(stderr, "not committed") {
+			t.Errorf("export.auto=true: checkJSONLBeforePush() = %d, stderr %q; want 1", got, stderr)
+		}
+	})
+}
+
+func TestJSONLChangedBetween(t *testing.T) {
+	repo, jsonlPath := setupHookJSONLRepo(t, `{"id":"bd-1"}`+"\n")
+	first := runHookTestGit(t, repo, "rev-parse", "HEAD")
+
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runHookTestGit(t, repo, "add", ".")
+	runHookTestGit(t, repo, "commit", "-qm", "code only")
+	second := runHookTestGit(t, repo, "rev-parse", "HEAD")
+
+	if err := os.WriteFile(jsonlPath, []byte(`{"id":"bd-2"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runHookTestGit(t, repo, "commit", "-qam", "issues")
+	third := runHookTestGit(t, repo, "rev-parse", "HEAD")
+
+	if jsonlChangedBetween(first, second) {
+		t.Error("JSONL reported changed across a code-only commit")
+	}
+	if !jsonlChangedBetween(second, third) {
+		t.Error("JSONL change between commits not detected")
+	}
+	if !jsonlChangedBetween("0000000000000000000000000000000000000000", third) {
+		t.Error("unknown commit should fall back to importing")
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/beads"
+)
+
+// hookSnippetsDirName is the .beads/ subdirectory holding team-defined hook
+// snippets: <hook-name>.sh files whose contents bd places inside the managed
+// marker section, after its own hook logic.
+const hookSnippetsDirName = "hook-snippets"
+
+// hookSnippetPath returns the snippet file for hookName in beadsDir.
+func hookSnippetPath(beadsDir, hookName string) string {
+	return filepath.Join(beadsDir, hookSnippetsDirName, hookName+".sh")
+}
+
+// loadHookSnippet returns the snippet for hookName, normalized for embedding
+// in a hook: no BOM, LF line endings, no shebang, trailing newline. A missing
+// snippet yields "". A snippet containing BEADS INTEGRATION markers is
+// rejected, since it would break the managed section it is placed in.
+func loadHookSnippet(beadsDir, hookName string) (string, error) {
+	if beadsDir == "" {
+		return "", nil
+	}
+	path := hookSnippetPath(beadsDir, hookName)
+	// #nosec G304 -- path constrained to .beads/hook-snippets and known hook names
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read hook snippet %s: %w", path, err)
+	}
+
+	content := strings.ReplaceAll(stripUTF8BOM(string(data)), "\r\n", "\n")
+	if strings.HasPrefix(content, "#!") {
+		if idx := strings.Index(content, "\n"); idx >= 0 {
+			content = content[idx+1:]
+		} else {
+			content = ""
+		}
+	}
+	if strings.Contains(content, hookSectionBeginPrefix) || strings.Contains(content, hookSectionEndPrefix) {
+		return "", fmt.Errorf("hook snippet %s must not contain BEADS INTEGRATION markers", path)
+	}
+	content = strings.Trim(content, "\n")
+	if strings.TrimSpace(content) == "" {
+		return "", nil
+	}
+	return content + "\n", nil
+}
+
+// hookSnippetBeadsDir returns the .beads directory whose hook snippets apply
+// to hooks in hooksDir: the one at the root of the repository owning
+// hooksDir, falling back to the active workspace.
+func hookSnippetBeadsDir(hooksDir string) string {
+	if hooksDir != "" {
+		if commonDir, err := gitCommonDirFor(hooksDir); err == nil {
+			candidate := filepath.Join(filepath.Dir(commonDir), ".beads")
+			if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+				return candidate
+			}
+		}
+	}
+	return beads.FindBeadsDir()
+}
+
+// managedHookSection returns the marker section to install for hookName in
+// hooksDir, including the repository's snippet for that hook if it has one.
+// An unreadable or invalid snippet is reported and left out.
+func managedHookSection(hooksDir, hookName string) string {
+	snippet, err := loadHookSnippet(hookSnippetBeadsDir(hooksDir), hookName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; installing %s without it\n", err, hookName)
+	}
+	return generateHookSectionWithSnippet(hookName, snippet)
+}
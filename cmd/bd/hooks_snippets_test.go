@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/git"
+)
+
+func writeHookSnippet(t *testing.T, repoDir, hookName, content string) {
+	t.Helper()
+	dir := filepath.Join(repoDir, ".beads", hookSnippetsDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hookName+".sh"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadHookSnippet(t *testing.T) {
+	beadsDir := t.TempDir()
+	if snippet, err := loadHookSnippet(beadsDir, "pre-commit"); err != nil || snippet != "" {
+		t.Fatalf("missing snippet should be empty, got %q, %v", snippet, err)
+	}
+
+	dir := filepath.Join(beadsDir, hookSnippetsDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pre-commit.sh"), []byte("\ufeff#!/bin/sh\r\nmake lint\r\n\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	snippet, err := loadHookSnippet(beadsDir, "pre-commit")
+	if err != nil {
+		t.Fatalf("loadHookSnippet: %v", err)
+	}
+	if snippet != "make lint\n" {
+		t.Errorf("snippet should drop BOM, shebang, and CRLF, got %q", snippet)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "post-merge.sh"), []byte(hookSectionEndLine()+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadHookSnippet(beadsDir, "post-merge"); err == nil {
+		t.Error("a snippet containing section markers should be rejected")
+	}
+}
+
+func TestInstallHooksIncludesSnippet(t *testing.T) {
+	tmpDir := newGitRepo(t)
+	writeHookSnippet(t, tmpDir, "pre-commit", "#!/bin/sh\nmake lint || exit 1\n")
+	runInDir(t, tmpDir, func() {
+		hooksDir, err := git.GetGitHooksDir()
+		if err != nil {
+			t.Fatalf("git.GetGitHooksDir() failed: %v", err)
+		}
+		if err := installHooksWithOptions(managedHookNames, false, false, false, false); err != nil {
+			t.Fatalf("installHooksWithOptions() failed: %v", err)
+		}
+		content := mustReadHookMigrationFile(t, filepath.Join(hooksDir, "pre-commit"))
+		snippetIdx := strings.Index(content, "make lint || exit 1")
+		if snippetIdx < 0 {
+			t.Fatalf("snippet missing from hook:\n%s", content)
+		}
+		if snippetIdx < strings.Index(content, hookSectionBeginPrefix) || snippetIdx > strings.Index(content, hookSectionEndPrefix) {
+			t.Errorf("snippet must sit inside the managed section:\n%s", content)
+		}
+		if other := mustReadHookMigrationFile(t, filepath.Join(hooksDir, "post-merge")); strings.Contains(other, "make lint") {
+			t.Error("snippet must only go into its own hook")
+		}
+
+		// A changed snippet replaces the old one on reinstall rather than stacking.
+		writeHookSnippet(t, tmpDir, "pre-commit", "make test\n")
+		if err := installHooksWithOptions(managedHookNames, false, false, false, false); err != nil {
+			t.Fatalf("second installHooksWithOptions() failed: %v", err)
+		}
+		content = mustReadHookMigrationFile(t, filepath.Join(hooksDir, "pre-commit"))
+		if strings.Contains(content, "make lint") || strings.Count(content, "make test") != 1 {
+			t.Errorf("reinstall should swap the snippet:\n%s", content)
+		}
+
+		plan, err := doctor.PlanHookMigration(tmpDir)
+		if err != nil {
+			t.Fatalf("PlanHookMigration failed: %v", err)
+		}
+		if hook := findHookMigrationHook(plan, "pre-commit"); hook == nil || hook.State != "marker_managed" {
+			t.Errorf("hook with snippet should stay marker_managed, got %+v", hook)
+		}
+	})
+}
+
+func TestMigratedHookIncludesSnippet(t *testing.T) {
+	repoDir, hooksDir := setupHookMigrationRepo(t)
+	writeHookSnippet(t, repoDir, "pre-commit", "echo team-check\n")
+	preCommitPath := filepath.Join(hooksDir, "pre-commit")
+	writeHookMigrationFile(t, preCommitPath, "#!/usr/bin/env sh\n# bd-shim v2\n# bd-hooks-version: 0.56.1\nexec bd hooks run pre-commit \"$@\"\n")
+
+	plan, err := doctor.PlanHookMigration(repoDir)
+	if err != nil {
+		t.Fatalf("PlanHookMigration failed: %v", err)
+	}
+	if _, err := applyHookMigrationExecution(buildHookMigrationExecutionPlan(plan)); err != nil {
+		t.Fatalf("applyHookMigrationExecution failed: %v", err)
+	}
+	if content := mustReadHookMigrationFile(t, preCommitPath); !strings.Contains(content, "echo team-check") {
+		t.Errorf("migrated hook should carry the snippet:\n%s", content)
+	}
+}
+
+func findHookMigrationHook(plan doctor.HookMigrationPlan, name string) *doctor.HookMigrationHookPlan {
+	for i := range plan.Hooks {
+		if plan.Hooks[i].Name == name {
+			return &plan.Hooks[i]
+		}
+	}
+	return nil
+}
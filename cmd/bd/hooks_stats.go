@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// hooksLogFileName is the per-clone log of 'bd hooks run' executions, kept in
+// .beads/ and gitignored.
+const hooksLogFileName = "hooks.log"
+
+// hooksLogMaxEntries is how many runs hooks.log keeps. Appends are cheap; once
+// the file grows past hooksLogTrimBytes it is rewritten with only the newest
+// hooksLogMaxEntries runs, so it behaves as a ring buffer.
+const (
+	hooksLogMaxEntries = 500
+	hooksLogTrimBytes  = hooksLogMaxEntries * 128
+)
+
+// hookRunRecord is one line of hooks.log.
+type hookRunRecord struct {
+	Hook       string    `json:"hook"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+	// ChainedMs is the part of DurationMs spent in a chained (.old) hook,
+	// which separates slow user hooks from slow bd work.
+	ChainedMs int64 `json:"chained_ms,omitempty"`
+	ExitCode  int   `json:"exit_code"`
+}
+
+// chainedHookElapsed accumulates the time runChainedHook spends running .old
+// hooks during this process, for the hooks.log record.
+var chainedHookElapsed time.Duration
+
+// recordHookRun appends rec to beadsDir/hooks.log, trimming the log back to
+// hooksLogMaxEntries when it has grown too large.
+func recordHookRun(beadsDir string, rec hookRunRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	logPath := filepath.Join(beadsDir, hooksLogFileName)
+	// #nosec G304 -- fixed file name inside the beads directory
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", hooksLogFileName, err)
+	}
+	_, writeErr := f.Write(append(line, '\n'))
+	if err := f.Close(); err != nil && writeErr == nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		return fmt.Errorf("failed to write %s: %w", hooksLogFileName, writeErr)
+	}
+
+	if info, err := os.Stat(logPath); err != nil || info.Size() <= hooksLogTrimBytes {
+		return nil
+	}
+	records, err := readHookRuns(beadsDir)
+	if err != nil {
+		return err
+	}
+	if len(records) > hooksLogMaxEntries {
+		records = records[len(records)-hooksLogMaxEntries:]
+	}
+	var sb strings.Builder
+	for _, r := range records {
+		data, _ := json.Marshal(r)
+		sb.Write(data)
+		sb.WriteByte('\n')
+	}
+	return atomicWriteFile(logPath, []byte(sb.String()))
+}
+
+// readHookRuns returns the runs recorded in beadsDir/hooks.log, oldest first.
+// A missing log yields no runs; unparseable lines are skipped.
+func readHookRuns(beadsDir string) ([]hookRunRecord, error) {
+	// #nosec G304 -- fixed file name inside the beads directory
+	f, err := os.Open(filepath.Join(beadsDir, hooksLogFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", hooksLogFileName, err)
+	}
+	defer f.Close()
+
+	var records []hookRunRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec hookRunRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil || rec.Hook == "" {
+			continue
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", hooksLogFileName, err)
+	}
+	return records, nil
+}
+
+// hookRunStats summarizes the recorded runs of one hook.
+type hookRunStats struct {
+	Hook         string    `json:"hook"`
+	Runs         int       `json:"runs"`
+	Failures     int       `json:"failures"`
+	AvgMs        int64     `json:"avg_ms"`
+	P95Ms        int64     `json:"p95_ms"`
+	MaxMs        int64     `json:"max_ms"`
+	AvgChainedMs int64     `json:"avg_chained_ms"`
+	LastMs       int64     `json:"last_ms"`
+	LastRun      time.Time `json:"last_run"`
+}
+
+// summarizeHookRuns groups records by hook, slowest (by p95) first.
+func summarizeHookRuns(records []hookRunRecord) []hookRunStats {
+	byHook := make(map[string][]hookRunRecord)
+	for _, rec := range records {
+		byHook[rec.Hook] = append(byHook[rec.Hook], rec)
+	}
+
+	stats := make([]hookRunStats, 0, len(byHook))
+	for hook, runs := range byHook {
+		s := hookRunStats{Hook: hook, Runs: len(runs)}
+		durations := make([]int64, 0, len(runs))
+		var total, chained int64
+		for _, run := range runs {
+			if run.ExitCode != 0 {
+				s.Failures++
+			}
+			total += run.DurationMs
+			chained += run.ChainedMs
+			durations = append(durations, run.DurationMs)
+			if !run.StartedAt.Before(s.LastRun) {
+				s.LastRun = run.StartedAt
+				s.LastMs = run.DurationMs
+			}
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		s.AvgMs = total / int64(len(runs))
+		s.AvgChainedMs = chained / int64(len(runs))
+		s.MaxMs = durations[len(durations)-1]
+		s.P95Ms = durations[(len(durations)*95+99)/100-1]
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].P95Ms != stats[j].P95Ms {
+			return stats[i].P95Ms > stats[j].P95Ms
+		}
+		return stats[i].Hook < stats[j].Hook
+	})
+	return stats
+}
+
+// slowHookRuns returns the runs that took at least threshold, newest first.
+func slowHookRuns(records []hookRunRecord, threshold time.Duration) []hookRunRecord {
+	var slow []hookRunRecord
+	for i := len(records) - 1; i >= 0; i-- {
+		if time.Duration(records[i].DurationMs)*time.Millisecond >= threshold {
+			slow = append(slow, records[i])
+		}
+	}
+	return slow
+}
+
+// formatHookMs renders a millisecond count for the stats table.
+func formatHookMs(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
+
+var hooksStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show execution times of git hooks",
+	Long: `Summarize how long 'bd hooks run' took for each git hook.
+
+Every hook run is recorded in .beads/hooks.log (the newest 500 runs are kept)
+with its duration, the time spent in a chained hook, and its exit code. This
+command reports per hook the number of runs and failures, average, p95, and
+maximum duration, so a hook that suddenly became slow is easy to spot. A high
+"chained" time points at a chained (.old) hook rather than at bd itself.
+
+Examples:
+  bd hooks stats                # Per-hook summary, slowest first
+  bd hooks stats --slow 1s      # Also list individual runs that took >= 1s
+  bd hooks stats --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("hooks-stats")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		slow, _ := cmd.Flags().GetDuration("slow")
+
+		beadsDir := beads.FindBeadsDir()
+		if beadsDir == "" {
+			return HandleErrorRespectJSON("no .beads directory found")
+		}
+		records, err := readHookRuns(beadsDir)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		stats := summarizeHookRuns(records)
+		var slowRuns []hookRunRecord
+		if slow > 0 {
+			slowRuns = slowHookRuns(records, slow)
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"log":       filepath.Join(beadsDir, hooksLogFileName),
+				"hooks":     stats,
+				"slow_runs": slowRuns,
+			})
+		}
+
+		if len(stats) == 0 {
+			fmt.Println("No hook runs recorded yet.")
+			return nil
+		}
+		fmt.Printf("%-20s %6s %6s %10s %10s %10s %10s %10s  %s\n",
+			"HOOK", "RUNS", "FAILED", "AVG", "P95", "MAX", "CHAINED", "LAST", "LAST RUN")
+		for _, s := range stats {
+			failed := fmt.Sprintf("%6d", s.Failures)
+			if s.Failures > 0 {
+				failed = ui.RenderFail(failed)
+			}
+			fmt.Printf("%-20s %6d %s %10s %10s %10s %10s %10s  %s\n",
+				s.Hook, s.Runs, failed,
+				formatHookMs(s.AvgMs), formatHookMs(s.P95Ms), formatHookMs(s.MaxMs),
+				formatHookMs(s.AvgChainedMs), formatHookMs(s.LastMs),
+				ui.RenderMuted(s.LastRun.Local().Format("2006-01-02 15:04:05")))
+		}
+
+		if slow > 0 {
+			fmt.Printf("\nRuns taking %s or longer: %d\n", slow, len(slowRuns))
+			for _, run := range slowRuns {
+				fmt.Printf("  %s  %-20s %10s  chained %s  exit %d\n",
+					run.StartedAt.Local().Format("2006-01-02 15:04:05"), run.Hook,
+					formatHookMs(run.DurationMs), formatHookMs(run.ChainedMs), run.ExitCode)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	hooksStatsCmd.Flags().Duration("slow", 0, "Also list individual runs that took at least this long (e.g. 500ms, 2s)")
+	hooksCmd.AddCommand(hooksStatsCmd)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordHookRunTrimsToMaxEntries(t *testing.T) {
+	beadsDir := t.TempDir()
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	total := 2 * hooksLogMaxEntries
+	for i := 0; i < total; i++ {
+		rec := hookRunRecord{Hook: "pre-commit", StartedAt: start.Add(time.Duration(i) * time.Second), DurationMs: int64(i)}
+		if err := recordHookRun(beadsDir, rec); err != nil {
+			t.Fatalf("recordHookRun: %v", err)
+		}
+	}
+
+	records, err := readHookRuns(beadsDir)
+	if err != nil {
+		t.Fatalf("readHookRuns: %v", err)
+	}
+	if len(records) < hooksLogMaxEntries || len(records) >= total {
+		t.Fatalf("expected the log to be trimmed to about %d runs, got %d", hooksLogMaxEntries, len(records))
+	}
+	if last := records[len(records)-1]; last.DurationMs != int64(total-1) {
+		t.Errorf("newest run should be kept, got %+v", last)
+	}
+	info, err := os.Stat(filepath.Join(beadsDir, hooksLogFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > hooksLogTrimBytes {
+		t.Errorf("hooks.log should stay under %d bytes, is %d", hooksLogTrimBytes, info.Size())
+	}
+}
+
+func TestReadHookRunsSkipsMalformedLines(t *testing.T) {
+	beadsDir := t.TempDir()
+	if records, err := readHookRuns(beadsDir); err != nil || len(records) != 0 {
+		t.Fatalf("missing log should yield no runs, got %v, %v", records, err)
+	}
+	content := "not json\n{\"hook\":\"pre-push\",\"duration_ms\":12,\"exit_code\":1}\n{}\n"
+	if err := os.WriteFile(filepath.Join(beadsDir, hooksLogFileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	records, err := readHookRuns(beadsDir)
+	if err != nil {
+		t.Fatalf("readHookRuns: %v", err)
+	}
+	if len(records) != 1 || records[0].Hook != "pre-push" || records[0].ExitCode != 1 {
+		t.Errorf("unexpected records: %+v", records)
+	}
+}
+
+func TestSummarizeHookRuns(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var records []hookRunRecord
+	for i := 1; i <= 20; i++ {
+		records = append(records, hookRunRecord{Hook: "post-merge", StartedAt: start.Add(time.Duration(i) * time.Second), DurationMs: 10})
+	}
+	records = append(records,
+		hookRunRecord{Hook: "pre-commit", StartedAt: start, DurationMs: 50},
+		hookRunRecord{Hook: "pre-commit", StartedAt: start.Add(time.Minute), DurationMs: 3000, ChainedMs: 2900, ExitCode: 1},
+	)
+
+	stats := summarizeHookRuns(records)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 hooks, got %+v", stats)
+	}
+	pre := stats[0]
+	if pre.Hook != "pre-commit" {
+		t.Fatalf("slowest hook should sort first, got %+v", stats)
+	}
+	if pre.Runs != 2 || pre.Failures != 1 || pre.AvgMs != 1525 || pre.P95Ms != 3000 || pre.MaxMs != 3000 || pre.AvgChainedMs != 1450 {
+		t.Errorf("unexpected pre-commit stats: %+v", pre)
+	}
+	if pre.LastMs != 3000 || !pre.LastRun.Equal(start.Add(time.Minute)) {
+		t.Errorf("last run should be the newest one: %+v", pre)
+	}
+	if merge := stats[1]; merge.Runs != 20 || merge.P95Ms != 10 || merge.Failures != 0 {
+		t.Errorf("unexpected post-merge stats: %+v", merge)
+	}
+
+	slow := slowHookRuns(records, time.Second)
+	if len(slow) != 1 || slow[0].DurationMs != 3000 {
+		t.Errorf("expected one slow run, got %+v", slow)
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// hookVerifyResult is the verification outcome for one managed hook.
+type hookVerifyResult struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Status is one of: ok, drifted, stale_version, missing, broken_markers,
+	// manager_owned.
+	Status           string `json:"status"`
+	InstalledVersion string `json:"installed_version,omitempty"`
+	ExpectedSHA256   string `json:"expected_sha256"`
+	ActualSHA256     string `json:"actual_sha256,omitempty"`
+	Detail           string `json:"detail,omitempty"`
+}
+
+// passed reports whether the hook is in the state bd would install.
+func (r hookVerifyResult) passed() bool {
+	return r.Status == "ok" || r.Status == "manager_owned"
+}
+
+// hookSectionSHA256 hashes a marker section with line endings normalized.
+func hookSectionSHA256(section string) string {
+	sum := sha256.Sum256([]byte(strings.ReplaceAll(section, "\r\n", "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// extractHookSection returns the BEGIN..END BEADS INTEGRATION section of
+// content (both marker lines included) and the version in its BEGIN marker.
+// ok is false when content has no markers; broken is true when the markers do
+// not form exactly one ordered pair.
+func extractHookSection(content string) (section, version string, ok, broken bool) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	beginCount := strings.Count(content, hookSectionBeginPrefix)
+	endCount := strings.Count(content, hookSectionEndPrefix)
+	if beginCount == 0 && endCount == 0 {
+		return "", "", false, false
+	}
+	beginIdx := strings.Index(content, hookSectionBeginPrefix)
+	endIdx := strings.Index(content, hookSectionEndPrefix)
+	if beginCount != 1 || endCount != 1 || endIdx < beginIdx {
+		return "", "", true, true
+	}
+
+	lineStart := strings.LastIndex(content[:beginIdx], "\n") + 1
+	lineEnd := len(content)
+	if nl := strings.Index(content[endIdx:], "\n"); nl != -1 {
+		lineEnd = endIdx + nl + 1
+	}
+	section = content[lineStart:lineEnd]
+	if !strings.HasSuffix(section, "\n") {
+		section += "\n"
+	}
+
+	beginLine := content[beginIdx:]
+	if nl := strings.Index(beginLine, "\n"); nl != -1 {
+		beginLine = beginLine[:nl]
+	}
+	version = strings.TrimPrefix(beginLine, hookSectionBeginPrefix)
+	version = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(version), "---"))
+	version = strings.TrimPrefix(version, "v")
+	return section, version, true, false
+}
+
+// verifyHooks compares each managed hook in hooksDir against the section the
+// current bd version would install there (including any hook snippet).
+func verifyHooks(hooksDir string, hookNames []string) []hookVerifyResult {
+	results := make([]hookVerifyResult, 0, len(hookNames))
+	for _, hookName := range hookNames {
+		hookPath := filepath.Join(hooksDir, hookName)
+		expected := managedHookSection(hooksDir, hookName)
+		result := hookVerifyResult{
+			Name:           hookName,
+			Path:           hookPath,
+			ExpectedSHA256: hookSectionSHA256(expected),
+		}
+
+		// #nosec G304 -- hook path constrained to hooks directory and known hook names
+		data, err := os.ReadFile(hookPath)
+		if err != nil {
+			result.Status = "missing"
+			if !os.IsNotExist(err) {
+				result.Detail = err.Error()
+			}
+			results = append(results, result)
+			continue
+		}
+		content := stripUTF8BOM(string(data))
+
+		section, version, ok, broken := extractHookSection(content)
+		switch {
+		case broken:
+			result.Status = "broken_markers"
+			result.Detail = "BEGIN/END BEADS INTEGRATION markers are orphaned, duplicated, or reversed"
+		case !ok:
+			if manager := fix.HookManagerForContent(content); manager != "" {
+				result.Status = "manager_owned"
+				result.Detail = "managed by " + manager
+			} else {
+				result.Status = "missing"
+				result.Detail = "hook has no BEADS INTEGRATION section"
+			}
+		default:
+			result.InstalledVersion = version
+			result.ActualSHA256 = hookSectionSHA256(section)
+			switch {
+			case version != Version:
+				result.Status = "stale_version"
+			case result.ActualSHA256 != result.ExpectedSHA256:
+				result.Status = "drifted"
+				result.Detail = "managed section was edited or its hook snippet changed"
+			default:
+				result.Status = "ok"
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+var hooksVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify installed hooks match the current bd version",
+	Long: `Verify that every marker-managed git hook contains exactly the section the
+current bd version would install, by comparing SHA-256 hashes of the
+BEGIN/END BEADS INTEGRATION section (including any .beads/hook-snippets).
+
+Statuses:
+  ok              Section matches the expected content
+  drifted         Same version, but the section differs (hand edits, changed snippet)
+  stale_version   Section was installed by a different bd version
+  missing         Hook file or its managed section is missing
+  broken_markers  Markers are orphaned, duplicated, or reversed
+  manager_owned   Hook belongs to a hook manager (husky, lefthook, ...); not checked
+
+Exits with status 1 if any hook is not ok, so it can gate CI. Fix drift with
+'bd hooks install' (or 'bd hooks doctor --fix-broken-markers').
+
+Examples:
+  bd hooks verify
+  bd hooks verify --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("hooks-verify")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		hooksDir, err := git.GetGitHooksDir()
+		if err != nil {
+			return HandleErrorRespectJSON("not in a git repository: %v", err)
+		}
+		results := verifyHooks(hooksDir, managedHookNames)
+		failed := 0
+		for _, r := range results {
+			if !r.passed() {
+				failed++
+			}
+		}
+
+		if jsonOutput {
+			if err := outputJSON(map[string]interface{}{
+				"hooks_dir":    hooksDir,
+				"version":      Version,
+				"hooks":        results,
+				"failed_count": failed,
+				"ok":           failed == 0,
+			}); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("Hooks dir: %s\n", hooksDir)
+			for _, r := range results {
+				icon := ui.RenderPass("✓")
+				if !r.passed() {
+					icon = ui.RenderFail("✗")
+				}
+				line := fmt.Sprintf("  %s %-20s %s", icon, r.Name, r.Status)
+				if r.Status == "stale_version" {
+					line += fmt.Sprintf(" (installed v%s, current v%s)", r.InstalledVersion, Version)
+				} else if r.Detail != "" {
+					line += " (" + r.Detail + ")"
+				}
+				fmt.Println(line)
+			}
+			if failed > 0 {
+				fmt.Printf("\n%d hook(s) do not match bd %s; run 'bd hooks install' to reinstall them.\n", failed, Version)
+			} else {
+				fmt.Printf("\nAll hooks match bd %s.\n", Version)
+			}
+		}
+
+		if failed > 0 {
+			return &exitError{Code: 1}
+		}
+		return nil
+	},
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksVerifyCmd)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/git"
+)
+
+func TestVerifyHooks(t *testing.T) {
+	tmpDir := newGitRepo(t)
+	runInDir(t, tmpDir, func() {
+		hooksDir, err := git.GetGitHooksDir()
+		if err != nil {
+			t.Fatalf("git.GetGitHooksDir() failed: %v", err)
+		}
+		if err := installHooksWithOptions(managedHookNames, false, false, false, false); err != nil {
+			t.Fatalf("installHooksWithOptions() failed: %v", err)
+		}
+		for _, r := range verifyHooks(hooksDir, managedHookNames) {
+			if r.Status != "ok" || r.ActualSHA256 != r.ExpectedSHA256 {
+				t.Errorf("freshly installed %s should verify, got %+v", r.Name, r)
+			}
+		}
+
+		rewrite := func(hookName string, edit func(string) string) {
+			path := filepath.Join(hooksDir, hookName)
+			content := mustReadHookMigrationFile(t, path)
+			if err := os.WriteFile(path, []byte(edit(content)), 0o755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		rewrite("pre-commit", func(c string) string {
+			return strings.Replace(c, "export BD_GIT_HOOK=1", "export BD_GIT_HOOK=0", 1)
+		})
+		rewrite("post-merge", func(c string) string {
+			return strings.Replace(c, hookSectionBeginLine(), hookSectionBeginPrefix+" v0.1.0 ---", 1)
+		})
+		rewrite("pre-push", func(c string) string {
+			return strings.Replace(c, hookSectionEndLine(), "", 1) + "\n" + hookSectionBeginLine() + "\n"
+		})
+		if err := os.Remove(filepath.Join(hooksDir, "post-checkout")); err != nil {
+			t.Fatal(err)
+		}
+		// User content outside the section does not count as drift.
+		rewrite("prepare-commit-msg", func(c string) string { return c + "echo custom\n" })
+
+		want := map[string]string{
+			"pre-commit":         "drifted",
+			"post-merge":         "stale_version",
+			"pre-push":           "broken_markers",
+			"post-checkout":      "missing",
+			"post-commit":        "ok",
+			"prepare-commit-msg": "ok",
+		}
+		for _, r := range verifyHooks(hooksDir, managedHookNames) {
+			if r.Status != want[r.Name] {
+				t.Errorf("%s: status %q, want %q (%s)", r.Name, r.Status, want[r.Name], r.Detail)
+			}
+			if r.Name == "post-merge" && r.InstalledVersion != "0.1.0" {
+				t.Errorf("post-merge installed version = %q, want 0.1.0", r.InstalledVersion)
+			}
+		}
+	})
+}
+
+func TestVerifyHooksDetectsSnippetChange(t *testing.T) {
+	tmpDir := newGitRepo(t)
+	writeHookSnippet(t, tmpDir, "pre-commit", "make lint\n")
+	runInDir(t, tmpDir, func() {
+		hooksDir, err := git.GetGitHooksDir()
+		if err != nil {
+			t.Fatalf("git.GetGitHooksDir() failed: %v", err)
+		}
+		if err := installHooksWithOptions([]string{"pre-commit"}, false, false, false, false); err != nil {
+			t.Fatalf("installHooksWithOptions() failed: %v", err)
+		}
+		if r := verifyHooks(hooksDir, []string{"pre-commit"})[0]; r.Status != "ok" {
+			t.Fatalf("hook with snippet should verify, got %+v", r)
+		}
+		writeHookSnippet(t, tmpDir, "pre-commit", "make test\n")
+		if r := verifyHooks(hooksDir, []string{"pre-commit"})[0]; r.Status != "drifted" {
+			t.Errorf("changed snippet should report drift, got %+v", r)
+		}
+	})
+}
+
+func TestVerifyHooksManagerOwned(t *testing.T) {
+	hooksDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(hooksDir, "pre-commit"), []byte("#!/bin/sh\nlefthook run pre-commit \"$@\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	r := verifyHooks(hooksDir, []string{"pre-commit"})[0]
+	if r.Status != "manager_owned" || !r.passed() {
+		t.Errorf("lefthook hook should be manager_owned and pass, got %+v", r)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Effects that closing an issue has on its direct dependents.
+const (
+	impactUnblocked = "unblocked" // its last open hard blocker goes away
+	impactOrphaned  = "orphaned"  // it loses its parent
+)
+
+var impactCmd = &cobra.Command{
+	Use:     "impact <id>",
+	GroupID: "deps",
+	Short:   "Show the open work that depends on an issue",
+	Long: `Show everything that transitively depends on an issue: the open issues
+it blocks (blocks, conditional-blocks, waits-for), the issues those block,
+and so on, plus its open children through parent-child edges. Use it before
+closing, deferring or deprioritizing an issue to see what is affected.
+
+The dependents are computed in one recursive query and printed as a tree,
+each issue once at the shallowest depth it is reached. A summary lists the
+direct dependents that closing the issue would make ready (it is their last
+open hard blocker) and the children that would lose their parent.
+
+With --json the result is a flat list: depth is the distance from the
+issue, parent_id the issue it depends on along the way, edge_type that
+dependency's type and effect ("unblocked" or "orphaned") what closing the
+issue does to a direct dependent.
+
+Examples:
+  bd impact bd-42
+  bd impact bd-42 --max-depth 2
+  bd impact bd-42 --json`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("impact is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("impact")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		if maxDepth < 1 {
+			return HandleErrorRespectJSON("--max-depth must be >= 1")
+		}
+
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		querier, ok := storage.UnwrapStore(store).(storage.ImpactQuerier)
+		if !ok {
+			return HandleErrorRespectJSON("impact is not supported by this storage backend")
+		}
+		nodes, err := querier.GetImpact(ctx, id, maxDepth)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		// A direct dependent is unblocked only when the issue is its last
+		// open hard blocker, so load the other blockers of each of them.
+		var direct []string
+		for _, node := range nodes {
+			if node.Depth == 1 && node.EdgeFromParent.IsBlockingEdge() {
+				direct = append(direct, node.ID)
+			}
+		}
+		deps, err := store.GetDependencyRecordsForIssues(ctx, direct)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to get dependencies: %v", err)
+		}
+		var blockerIDs []string
+		for _, records := range deps {
+			for _, dep := range records {
+				if dep.Type.IsBlockingEdge() && dep.DependsOnID != id && !IsExternalRef(dep.DependsOnID) {
+					blockerIDs = append(blockerIDs, dep.DependsOnID)
+				}
+			}
+		}
+		blockers, err := store.GetIssuesByIDs(ctx, blockerIDs)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to get blockers: %v", err)
+		}
+		openBlockers := make(map[string]bool, len(blockers))
+		for _, issue := range blockers {
+			if issue.Status != types.StatusClosed {
+				openBlockers[issue.ID] = true
+			}
+		}
+		stillBlocked := make(map[string]bool)
+		for issueID, records := range deps {
+			for _, dep := range records {
+				if dep.Type.IsBlockingEdge() && openBlockers[dep.DependsOnID] {
+					stillBlocked[issueID] = true
+				}
+			}
+		}
+
+		entries := impactEntries(nodes, stillBlocked)
+		if jsonOutput {
+			return outputJSON(entries)
+		}
+
+		if len(nodes) < 2 {
+			fmt.Printf("\nNo open work depends on %s\n", id)
+			return nil
+		}
+		fmt.Printf("\n%s Impact of %s: %d open issue(s) depend on it\n\n", ui.RenderAccent("💥"), id, len(nodes)-1)
+		renderTree(nodes, maxDepth, "up")
+
+		var unblocked, orphaned []string
+		for _, e := range entries {
+			switch e.Effect {
+			case impactUnblocked:
+				unblocked = append(unblocked, e.ID)
+			case impactOrphaned:
+				orphaned = append(orphaned, e.ID)
+			}
+		}
+		fmt.Println()
+		if len(unblocked) > 0 {
+			fmt.Printf("Closing %s makes ready: %s\n", id, strings.Join(unblocked, ", "))
+		}
+		if len(orphaned) > 0 {
+			fmt.Printf("Closing %s leaves without a parent: %s\n", id, strings.Join(orphaned, ", "))
+		}
+		return nil
+	},
+}
+
+// impactEntry is one dependent in bd impact --json.
+type impactEntry struct {
+	ID       string               `json:"id"`
+	Title    string               `json:"title"`
+	Status   types.Status         `json:"status"`
+	Priority int                  `json:"priority"`
+	Assignee string               `json:"assignee,omitempty"`
+	Depth    int                  `json:"depth"`
+	ParentID string               `json:"parent_id"`
+	EdgeType types.DependencyType `json:"edge_type"`
+	Effect   string               `json:"effect,omitempty"`
+}
+
+// impactEntries flattens an impact tree into the dependents, without the
+// root, and marks what closing the root does to each direct dependent.
+// stillBlocked holds the direct dependents with another open hard blocker.
+func impactEntries(nodes []*types.TreeNode, stillBlocked map[string]bool) []impactEntry {
+	entries := []impactEntry{}
+	for _, node := range nodes {
+		if node.Depth == 0 {
+			continue
+		}
+		e := impactEntry{
+			ID:       node.ID,
+			Title:    node.Title,
+			Status:   node.Status,
+			Priority: node.Priority,
+			Assignee: node.Assignee,
+			Depth:    node.Depth,
+			ParentID: node.ParentID,
+			EdgeType: node.EdgeFromParent,
+		}
+		if node.Depth == 1 {
+			switch {
+			case node.EdgeFromParent == types.DepParentChild:
+				e.Effect = impactOrphaned
+			case node.EdgeFromParent.IsBlockingEdge() && !stillBlocked[node.ID]:
+				e.Effect = impactUnblocked
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func init() {
+	impactCmd.Flags().IntP("max-depth", "d", 50, "Maximum depth of dependents to follow")
+	rootCmd.AddCommand(impactCmd)
+}
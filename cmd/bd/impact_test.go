@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImpactEntries(t *testing.T) {
+	node := func(id string, depth int, parent string, edge types.DependencyType) *types.TreeNode {
+		return &types.TreeNode{
+			Issue:          types.Issue{ID: id, Title: id, Status: types.StatusOpen},
+			Depth:          depth,
+			ParentID:       parent,
+			EdgeFromParent: edge,
+		}
+	}
+	nodes := []*types.TreeNode{
+		node("root", 0, "", ""),
+		node("api", 1, "root", types.DepBlocks),
+		node("docs", 1, "root", types.DepWaitsFor),
+		node("task", 1, "root", types.DepParentChild),
+		node("release", 2, "api", types.DepBlocks),
+	}
+
+	entries := impactEntries(nodes, map[string]bool{"docs": true})
+	want := map[string]string{
+		"api":     impactUnblocked,
+		"docs":    "", // still waits on another open issue
+		"task":    impactOrphaned,
+		"release": "", // api is still open
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for _, e := range entries {
+		if e.Effect != want[e.ID] {
+			t.Errorf("%s effect = %q, want %q", e.ID, e.Effect, want[e.ID])
+		}
+	}
+	if entries[3].Depth != 2 || entries[3].ParentID != "api" {
+		t.Errorf("release entry = %+v, want depth 2 via api", entries[3])
+	}
+
+	if got := impactEntries(nodes[:1], nil); got == nil || len(got) != 0 {
+		t.Errorf("no dependents should give an empty list, got %#v", got)
+	}
+}
@@ -13,13 +13,14 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/importers"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
 var importCmd = &cobra.Command{
-	Use:   "import [file|-]",
+	Use:   "import [file|-]...",
 	Short: "Import issues from a JSONL file or stdin into the database",
 	Long: `Import issues from a JSONL file (newline-delimited JSON) into the database.
 
@@ -28,6 +29,12 @@ If no file is specified, imports from the configured import.path under .beads/
 'bd export': new issues are created and existing issues are updated (upsert
 semantics).
 
+Several files may be given; they are imported as one batch, which is how
+the shards written by 'bd export --shard' are read back. Both JSONL export
+formats are accepted: v1 (the default) and v2 ('bd export --schema-version
+2'), whose header line is checked so a file from a newer format is refused
+rather than half-read.
+
 Memory records (lines with "_type":"memory") are automatically detected and
 imported as persistent memories (equivalent to 'bd remember'). This makes
 'bd export | bd import' a full round-trip for both issues and memories.
@@ -73,6 +80,43 @@ an import is visible. To deliberately restore an older snapshot, pass
 --allow-stale, which imports every row even when it overwrites newer
 local state.
 
+A file with unresolved git merge conflict markers (<<<<<<<, =======,
+>>>>>>>) is refused with the line number of the first marker: resolve it
+with 'bd doctor --fix' (which re-merges the export with 'bd merge-file') or
+by hand. With --quarantine, the records inside conflict hunks are instead
+moved to a side file next to the source (<file>.quarantine) and the rest of
+the file is imported; review the side file and import the records you want
+to keep once the conflict is understood.
+
+With --format csv, the file is CSV with a header row (RFC 4180 quoting).
+Columns are matched to issue fields by name — the columns 'bd export
+--format csv' writes (id, title, description, status, priority, issue_type,
+assignee, labels, parent, depends_on, ...) plus common aliases such as
+Summary, Type and Tags — or explicitly with --map field=Column. Rows
+without an id get a new one. A row that fails to parse or validate is
+skipped and reported with its line number; the rest are imported.
+
+With --format jira, the file is a Jira export instead: the JSON of a REST
+search (or an array of issues) or a CSV export. Jira types, statuses and
+priorities map as in 'bd jira sync' (honoring jira.type_map.* and friends),
+epics become parents of their issues, "Blocks" links become blocking
+dependencies, "Duplicate" and "Relates" links become duplicates and related
+edges, and each issue's external_ref records its Jira key (the browse URL
+when jira.url is set). Re-importing an export updates the issues it created.
+
+With --format linear, the file is the JSON result of a Linear GraphQL
+issues query (data.issues.nodes, or just the nodes array); fields map as in
+'bd linear sync', including linear.*_map.* config, and parents and
+relations become dependencies. With --format shortcut, the file is Shortcut
+stories from the REST API (an array or a search response) or a story CSV
+export; completed stories are closed, started ones in progress, story
+links become dependencies, and shortcut.state_map.<state> config overrides
+the status of a workflow state. Both record the source URL (or
+linear-<ID> / shortcut-<id>) as external_ref, so re-imports update.
+
+Every format accepts --dry-run, which reports how many issues would be
+created and updated and how many dependencies would be written.
+
 Large imports are written in bounded transactions (a few hundred issues
 each, with a short pause between commits) with progress on stderr, so
 concurrent bd commands keep working while the import runs instead of
@@ -87,11 +131,17 @@ EXAMPLES:
   bd import                        # Import from configured import.path
   bd import backup.jsonl           # Import from a specific file
   bd import -i backup.jsonl        # Legacy alias for a specific file
+  bd import issues-*.jsonl         # Import the shards of a sharded export
   bd import -                      # Read JSONL from stdin
   cat issues.jsonl | bd import -   # Pipe JSONL from another tool
   bd import --dry-run              # Show what would be imported
   bd import --dedup                # Skip issues with duplicate titles
   bd import --allow-stale old.jsonl # Restore an older snapshot (overwrites newer local rows)
+  bd import --quarantine           # Import around conflict hunks, set them aside
+  bd import --format csv sheet.csv --map title=Summary,priority=Prio
+  bd import --format jira jira.csv # Import a Jira CSV or REST JSON export
+  bd import --format linear --dry-run linear.json  # Preview a Linear migration
+  bd import --format shortcut stories.csv
   bd import --json                 # Structured output with created and skipped IDs`,
 	GroupID:       "sync",
 	SilenceUsage:  true,
@@ -103,7 +153,10 @@ var (
 	importDryRun     bool
 	importDedup      bool
 	importAllowStale bool
+	importQuarantine bool
 	importInput      string
+	importFormat     string
+	importCSVMap     string
 )
 
 func init() {
@@ -111,6 +164,9 @@ func init() {
 	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without importing")
 	importCmd.Flags().BoolVar(&importDedup, "dedup", false, "Skip lines whose title matches an existing open issue")
 	importCmd.Flags().BoolVar(&importAllowStale, "allow-stale", false, "Import rows even when older than the local issue (required to restore an older snapshot)")
+	importCmd.Flags().BoolVar(&importQuarantine, "quarantine", false, "Move records inside git conflict hunks to <file>.quarantine and import the rest")
+	importCmd.Flags().StringVar(&importFormat, "format", "jsonl", "Input format: jsonl, csv, jira, linear, or shortcut")
+	importCmd.Flags().StringVar(&importCSVMap, "map", "", "CSV column mapping, e.g. title=Summary,priority=Prio (with --format csv)")
 	rootCmd.AddCommand(importCmd)
 }
 
@@ -140,9 +196,31 @@ func runImportInner(args []string) error {
 		return fmt.Errorf("use either --input or a positional file, not both")
 	}
 
+	if importFormat != "jsonl" {
+		if importers.Get(importFormat) == nil {
+			return fmt.Errorf("unknown import format %q (want %s)", importFormat, importFormatNames())
+		}
+		if importQuarantine {
+			return fmt.Errorf("--quarantine only applies to JSONL imports")
+		}
+		if importInput == "" && len(args) == 0 {
+			return fmt.Errorf("--format %s needs a file (or - for stdin)", importFormat)
+		}
+	}
+	if importCSVMap != "" && importFormat != "csv" {
+		return fmt.Errorf("--map only applies to --format csv")
+	}
+
+	if len(args) > 1 {
+		return runImportFiles(ctx, args)
+	}
+
 	fromStdin := importInput == "-" || (len(args) > 0 && args[0] == "-")
 
 	if fromStdin {
+		if importQuarantine {
+			return fmt.Errorf("--quarantine needs a file to place the side file next to; save stdin to a file first")
+		}
 		return runImportFromReader(ctx, os.Stdin, "stdin")
 	}
 
@@ -185,6 +263,40 @@ func runImportInner(args []string) error {
 	return runImportFromReader(ctx, f, jsonlPath)
 }
 
+// runImportFiles imports several JSONL files as one batch, so the shards of
+// a 'bd export --shard' land together and dependencies between shards
+// resolve regardless of file order.
+func runImportFiles(ctx context.Context, paths []string) error {
+	if importFormat != "jsonl" {
+		return fmt.Errorf("--format %s takes a single file", importFormat)
+	}
+	if importQuarantine {
+		return fmt.Errorf("--quarantine takes a single file")
+	}
+	if store == nil {
+		return fmt.Errorf("no database — run 'bd init' or 'bd bootstrap' first")
+	}
+	var all importRecords
+	for _, path := range paths {
+		if path == "-" {
+			return fmt.Errorf("stdin (-) cannot be combined with other files")
+		}
+		f, err := os.Open(path) //nolint:gosec // G304: CLI argument
+		if err != nil {
+			return fmt.Errorf("cannot open %s: %w", path, err)
+		}
+		recs, err := parseJSONLImport(f, path)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		all.issues = append(all.issues, recs.issues...)
+		all.memories = append(all.memories, recs.memories...)
+		all.labelDefs = append(all.labelDefs, recs.labelDefs...)
+	}
+	return importParsedRecords(ctx, strings.Join(paths, ", "), all)
+}
+
 type importResultJSON struct {
 	Source              string         `json:"source"`
 	Created             int            `json:"created"`
@@ -192,34 +304,79 @@ type importResultJSON struct {
 	Skipped             int            `json:"skipped"`
 	DedupHits           int            `json:"dedup_skipped,omitempty"`
 	Memories            int            `json:"memories,omitempty"`
+	Labels              int            `json:"labels,omitempty"`
 	IDs                 []string       `json:"ids,omitempty"`
 	UpdatedIssues       []ImportChange `json:"updated_issues,omitempty"`
 	TieKeptLocalIDs     []string       `json:"tie_kept_local_ids,omitempty"`
 	StaleSkippedIDs     []string       `json:"stale_skipped_ids,omitempty"`
 	SkippedDependencies []string       `json:"skipped_dependencies,omitempty"`
+	Quarantined         int            `json:"quarantined,omitempty"`
+	QuarantinePath      string         `json:"quarantine_path,omitempty"`
+	Dependencies        int            `json:"dependencies,omitempty"`
+	RowErrors           []string       `json:"row_errors,omitempty"`
 	DryRun              bool           `json:"dry_run,omitempty"`
 }
 
+// jsonlConflictMarkerError reports a git conflict marker found while reading
+// JSONL, pointing at the repair paths instead of a bare JSON parse error.
+func jsonlConflictMarkerError(source string, lineNo int) error {
+	return fmt.Errorf("%s:%d: unresolved git merge conflict marker; resolve it with 'bd doctor --fix' or by hand (or use 'bd import --quarantine' to set the conflicted records aside)", source, lineNo)
+}
+
 func runImportFromReader(ctx context.Context, r io.Reader, source string) error {
 	if store == nil {
 		return fmt.Errorf("no database — run 'bd init' or 'bd bootstrap' first")
 	}
+	if imp := importers.Get(importFormat); imp != nil {
+		recs, err := parseWithImporter(ctx, imp, r)
+		if err != nil {
+			return err
+		}
+		return importParsedRecords(ctx, source, recs)
+	}
+	recs, err := parseJSONLImport(r, source)
+	if err != nil {
+		return err
+	}
+	return importParsedRecords(ctx, source, recs)
+}
 
+// parseJSONLImport reads the records of a JSONL export (format v1 or v2).
+func parseJSONLImport(r io.Reader, source string) (importRecords, error) {
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
 
 	var issues []*types.Issue
 	var memories []memoryRecord
+	var labelDefs []*types.LabelDefinition
+
+	// Records inside conflict hunks (between <<<<<<< and >>>>>>>), set aside
+	// with --quarantine.
+	var quarantined []string
+	inConflict := false
+	lineNo := 0
 
 	for scanner.Scan() {
+		lineNo++
 		line := scanner.Text()
+		if hasConflictMarkerPrefix(line) {
+			if !importQuarantine {
+				return importRecords{}, jsonlConflictMarkerError(source, lineNo)
+			}
+			inConflict = !strings.HasPrefix(line, ">>>>>>>")
+			continue
+		}
 		if line == "" {
 			continue
 		}
+		if inConflict {
+			quarantined = append(quarantined, line)
+			continue
+		}
 
 		var peek map[string]json.RawMessage
 		if err := json.Unmarshal([]byte(line), &peek); err != nil {
-			return fmt.Errorf("failed to parse JSONL line: %w", err)
+			return importRecords{}, fmt.Errorf("failed to parse JSONL line: %w", err)
 		}
 
 		// Skip the optional beads-jsonl header record (§J1.3). A canonical
@@ -230,7 +387,13 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 		// the whole import with "title is required". parseJSONLFile (the
 		// bootstrap reader) has always skipped it; this loop — the one `bd
 		// import` and `bd import -` run through — did not.
-		if _, isHeader := peek["_schema"]; isHeader {
+		//
+		// Format v2 (bd export --schema-version 2) always writes one; a
+		// header from a newer format than this bd knows is refused.
+		if rawSchema, isHeader := peek["_schema"]; isHeader {
+			if err := checkJSONLSchema(rawSchema); err != nil {
+				return importRecords{}, fmt.Errorf("%s:%d: %w", source, lineNo, err)
+			}
 			continue
 		}
 
@@ -239,18 +402,28 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 			if err := json.Unmarshal(rawType, &typeStr); err == nil && typeStr == "memory" {
 				var mem memoryRecord
 				if err := json.Unmarshal([]byte(line), &mem); err != nil {
-					return fmt.Errorf("failed to parse memory record: %w", err)
+					return importRecords{}, fmt.Errorf("failed to parse memory record: %w", err)
 				}
 				if mem.Key != "" && mem.Value != "" {
 					memories = append(memories, mem)
 				}
 				continue
 			}
+			if typeStr == "label" {
+				var def types.LabelDefinition
+				if err := json.Unmarshal([]byte(line), &def); err != nil {
+					return importRecords{}, fmt.Errorf("failed to parse label record: %w", err)
+				}
+				if def.Name != "" {
+					labelDefs = append(labelDefs, &def)
+				}
+				continue
+			}
 		}
 
 		var issue types.Issue
 		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			return fmt.Errorf("failed to parse issue from JSONL: %w", err)
+			return importRecords{}, fmt.Errorf("failed to parse issue from JSONL: %w", err)
 		}
 		if issue.Status == "tombstone" {
 			continue
@@ -265,9 +438,32 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 		issues = append(issues, &issue)
 	}
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("failed to scan JSONL: %w", err)
+		return importRecords{}, fmt.Errorf("failed to scan JSONL: %w", err)
 	}
+	return importRecords{
+		issues:      issues,
+		memories:    memories,
+		labelDefs:   labelDefs,
+		quarantined: quarantined,
+	}, nil
+}
+
+// importRecords is what was read from an import source.
+type importRecords struct {
+	issues      []*types.Issue
+	memories    []memoryRecord
+	labelDefs   []*types.LabelDefinition
+	quarantined []string // raw lines set aside by --quarantine
+	rowErrors   []string // rows skipped as invalid, "line N: reason"
+	// stats is set for --format imports, whose IDs are resolved up front.
+	stats *importers.Stats
+}
 
+// importParsedRecords writes the records read from source: memories and
+// label definitions first, then issues through importIssuesCore, followed by
+// one Dolt commit.
+func importParsedRecords(ctx context.Context, source string, recs importRecords) error {
+	issues, memories, labelDefs, quarantined := recs.issues, recs.memories, recs.labelDefs, recs.quarantined
 	// Dedup: skip issues whose title matches an existing open issue
 	dedupHits := 0
 	if importDedup && len(issues) > 0 {
@@ -275,26 +471,51 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 	}
 
 	result := importResultJSON{
-		Source:    source,
-		DedupHits: dedupHits,
-		DryRun:    importDryRun,
+		Source:      source,
+		DedupHits:   dedupHits,
+		Quarantined: len(quarantined),
+		RowErrors:   recs.rowErrors,
+		DryRun:      importDryRun,
 	}
 
 	if importDryRun {
 		result.Created = len(issues)
 		result.Memories = len(memories)
+		result.Labels = len(labelDefs)
 		result.Skipped = dedupHits
+		if recs.stats != nil {
+			result.Updated = min(recs.stats.Existing, len(issues))
+			result.Created = len(issues) - result.Updated
+			result.Dependencies = recs.stats.Dependencies
+		}
 		if jsonOutput {
 			return outputJSON(result)
 		}
-		fmt.Fprintf(os.Stderr, "Would import %d issues and %d memories from %s", len(issues), len(memories), source)
+		if recs.stats != nil {
+			fmt.Fprintf(os.Stderr, "Would import %d issues (%d new, %d updating existing) with %d dependencies from %s",
+				len(issues), result.Created, result.Updated, result.Dependencies, source)
+		} else {
+			fmt.Fprintf(os.Stderr, "Would import %d issues and %d memories from %s", len(issues), len(memories), source)
+		}
 		if dedupHits > 0 {
 			fmt.Fprintf(os.Stderr, " (%d duplicates skipped)", dedupHits)
 		}
 		fmt.Fprintln(os.Stderr)
+		if len(quarantined) > 0 {
+			fmt.Fprintf(os.Stderr, "Would quarantine %d conflicted record(s) to %s\n", len(quarantined), source+".quarantine")
+		}
+		printImportRowErrors(result.RowErrors)
 		return nil
 	}
 
+	if len(quarantined) > 0 {
+		result.QuarantinePath = source + ".quarantine"
+		// #nosec G306 -- side file next to the user's own import source
+		if err := os.WriteFile(result.QuarantinePath, []byte(strings.Join(quarantined, "\n")+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write quarantine file: %w", err)
+		}
+	}
+
 	// Import memories
 	for _, mem := range memories {
 		storageKey := kvPrefix + memoryPrefix + mem.Key
@@ -304,6 +525,11 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 		result.Memories++
 	}
 
+	if err := importLabelDefinitions(ctx, store, labelDefs); err != nil {
+		return err
+	}
+	result.Labels = len(labelDefs)
+
 	// Import issues
 	if len(issues) > 0 {
 		opts := ImportOptions{SkipPrefixValidation: true, AllowStale: importAllowStale}
@@ -321,11 +547,14 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 		result.StaleSkippedIDs = append(result.StaleSkippedIDs, importResult.StaleSkippedIDs...)
 	}
 
-	if result.Created > 0 || result.Memories > 0 {
+	if result.Created > 0 || result.Memories > 0 || result.Labels > 0 {
 		commitMsg := fmt.Sprintf("bd import: %d issues", result.Created)
 		if result.Memories > 0 {
 			commitMsg += fmt.Sprintf(", %d memories", result.Memories)
 		}
+		if result.Labels > 0 {
+			commitMsg += fmt.Sprintf(", %d label definitions", result.Labels)
+		}
 		commitMsg += fmt.Sprintf(" from %s", filepath.Base(source))
 		if err := store.Commit(ctx, commitMsg); err != nil {
 			// An import can be a working-set no-op: re-importing an
@@ -367,6 +596,10 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 		fmt.Fprintf(os.Stderr, " (%d stale skipped; use --allow-stale to restore older rows)", staleSkipped)
 	}
 	fmt.Fprintln(os.Stderr)
+	if result.Quarantined > 0 {
+		fmt.Fprintf(os.Stderr, "Quarantined %d record(s) from conflict hunks to %s; review them and import the ones to keep\n",
+			result.Quarantined, result.QuarantinePath)
+	}
 	if len(result.UpdatedIssues) > 0 {
 		fmt.Fprintf(os.Stderr, "Updated %d existing issue(s):\n", len(result.UpdatedIssues))
 		for _, change := range result.UpdatedIssues {
@@ -380,9 +613,21 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 	for _, skipped := range result.SkippedDependencies {
 		fmt.Fprintf(os.Stderr, "Skipped dependency: %s\n", skipped)
 	}
+	printImportRowErrors(result.RowErrors)
 	return nil
 }
 
+// printImportRowErrors lists the rows an import skipped as invalid.
+func printImportRowErrors(rowErrors []string) {
+	if len(rowErrors) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Skipped %d invalid row(s):\n", len(rowErrors))
+	for _, rowErr := range rowErrors {
+		fmt.Fprintf(os.Stderr, "  %s\n", rowErr)
+	}
+}
+
 // filterDuplicatesByTitle removes issues whose title matches an existing open issue.
 func filterDuplicatesByTitle(ctx context.Context, st storage.DoltStorage, issues []*types.Issue) ([]*types.Issue, int) {
 	existing, err := st.SearchIssues(ctx, "", types.IssueFilter{})
@@ -134,6 +134,46 @@ func TestEmbeddedImport(t *testing.T) {
 		}
 	})
 
+	t.Run("conflict_markers_refused", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "imconf")
+
+		jsonlPath := filepath.Join(t.TempDir(), "conflicted.jsonl")
+		content := `{"id":"imconf-aaa","title":"Clean"}` + "\n" +
+			"<<<<<<< HEAD\n" + `{"id":"imconf-bbb","title":"Ours"}` + "\n" +
+			"=======\n" + `{"id":"imconf-bbb","title":"Theirs"}` + "\n" + ">>>>>>> other\n"
+		if err := os.WriteFile(jsonlPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := exec.Command(bd, "import", jsonlPath)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Fatalf("expected import of a conflicted file to fail, got: %s", out)
+		}
+		if !strings.Contains(string(out), "conflicted.jsonl:2: unresolved git merge conflict marker") {
+			t.Errorf("expected a conflict marker error pointing at line 2, got: %s", out)
+		}
+		// A refused import must not half-apply the clean records.
+		bdShowFail(t, bd, dir, "imconf-aaa")
+
+		quarantineOut := bdImport(t, bd, dir, "--quarantine", jsonlPath)
+		if !strings.Contains(quarantineOut, "Imported 1 issues") || !strings.Contains(quarantineOut, "Quarantined 2 record(s)") {
+			t.Errorf("expected 1 import and 2 quarantined records, got: %s", quarantineOut)
+		}
+		side, err := os.ReadFile(jsonlPath + ".quarantine")
+		if err != nil {
+			t.Fatalf("quarantine file: %v", err)
+		}
+		if got := strings.Count(string(side), "imconf-bbb"); got != 2 || strings.Contains(string(side), "<<<<<<<") {
+			t.Errorf("quarantine file should hold both sides without markers, got:\n%s", side)
+		}
+		if issue := bdShow(t, bd, dir, "imconf-aaa"); issue.Title != "Clean" {
+			t.Errorf("imconf-aaa title = %q, want Clean", issue.Title)
+		}
+	})
+
 	t.Run("with_memories", func(t *testing.T) {
 		dir, _, _ := bdInit(t, bd, "--prefix", "immem")
 
@@ -253,6 +293,141 @@ func TestEmbeddedImport(t *testing.T) {
 			t.Errorf("issue_prefix after import: got %q, want %q", val, "bd")
 		}
 	})
+
+	t.Run("csv_round_trip", func(t *testing.T) {
+		srcDir, _, _ := bdInit(t, bd, "--prefix", "imcsv")
+		bdCreateSilent(t, bd, srcDir, "CSV one, with comma")
+		bdCreateSilent(t, bd, srcDir, "CSV two")
+
+		csvPath := filepath.Join(t.TempDir(), "issues.csv")
+		exportCmd := exec.Command(bd, "export", "--format", "csv", "-o", csvPath)
+		exportCmd.Dir = srcDir
+		exportCmd.Env = bdEnv(srcDir)
+		if stdout, stderr, err := runCommandBuffers(t, exportCmd); err != nil {
+			t.Fatalf("bd export --format csv failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+		}
+
+		dstDir, _, _ := bdInit(t, bd, "--prefix", "imcsv")
+		out := bdImport(t, bd, dstDir, "--format", "csv", csvPath)
+		if !strings.Contains(out, "Imported 2 issues") {
+			t.Fatalf("expected 'Imported 2 issues', got: %s", out)
+		}
+		titles := map[string]bool{}
+		for _, issue := range bdListJSON(t, bd, dstDir, "--all") {
+			titles[issue.Title] = true
+		}
+		if !titles["CSV one, with comma"] || !titles["CSV two"] {
+			t.Errorf("imported titles = %v", titles)
+		}
+	})
+
+	t.Run("jira_format", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "imjira")
+
+		csvPath := filepath.Join(t.TempDir(), "jira.csv")
+		csvData := "Summary,Issue key,Issue id,Issue Type,Status,Priority,Parent,Outward issue link (Blocks)\n" +
+			"Checkout,PROJ-1,10001,Epic,In Progress,High,,\n" +
+			"Payment API,PROJ-2,10002,Story,To Do,Medium,10001,PROJ-3\n" +
+			"Checkout UI,PROJ-3,10003,Bug,To Do,Low,10001,\n"
+		if err := os.WriteFile(csvPath, []byte(csvData), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		out := bdImport(t, bd, dir, "--format", "jira", csvPath)
+		if !strings.Contains(out, "Imported 3 issues") {
+			t.Fatalf("expected 'Imported 3 issues', got: %s", out)
+		}
+
+		byRef := make(map[string]*types.IssueWithCounts)
+		for _, issue := range bdListJSON(t, bd, dir, "--all") {
+			if issue.ExternalRef != nil {
+				byRef[*issue.ExternalRef] = issue
+			}
+		}
+		epic, story, bug := byRef["jira-PROJ-1"], byRef["jira-PROJ-2"], byRef["jira-PROJ-3"]
+		if epic == nil || story == nil || bug == nil {
+			t.Fatalf("expected issues with jira-PROJ-1..3 external refs, got %v", byRef)
+		}
+		if epic.IssueType != types.TypeEpic || epic.Status != types.StatusInProgress {
+			t.Errorf("epic: type %s status %s", epic.IssueType, epic.Status)
+		}
+		if story.Parent == nil || *story.Parent != epic.ID {
+			t.Errorf("story parent = %v, want %s", story.Parent, epic.ID)
+		}
+		if !strings.Contains(bdShowJSON(t, bd, dir, bug.ID), story.ID) {
+			t.Errorf("expected %s to depend on %s", bug.ID, story.ID)
+		}
+
+		// Re-importing the same export updates in place.
+		bdImport(t, bd, dir, "--format", "jira", csvPath)
+		if n := len(bdListJSON(t, bd, dir, "--all")); n != 3 {
+			t.Errorf("after re-import: got %d issues, want 3", n)
+		}
+	})
+
+	t.Run("shortcut_dry_run", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "imsc")
+
+		jsonPath := filepath.Join(t.TempDir(), "stories.json")
+		data := `[{"id": 1, "name": "Cart", "story_type": "feature", "story_links": [{"verb": "blocks", "subject_id": 1, "object_id": 2}]},
+		          {"id": 2, "name": "Pay", "story_type": "feature"}]`
+		if err := os.WriteFile(jsonPath, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		out := bdImport(t, bd, dir, "--format", "shortcut", "--dry-run", "--json", jsonPath)
+		var result importResultJSON
+		if err := json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &result); err != nil {
+			t.Fatalf("parse dry-run JSON: %v\n%s", err, out)
+		}
+		if !result.DryRun || result.Created != 2 || result.Updated != 0 || result.Dependencies != 1 {
+			t.Errorf("dry run = %+v, want 2 new with 1 dependency", result)
+		}
+		if n := len(bdListJSON(t, bd, dir, "--all")); n != 0 {
+			t.Fatalf("dry run wrote %d issues", n)
+		}
+
+		bdImport(t, bd, dir, "--format", "shortcut", jsonPath)
+		out = bdImport(t, bd, dir, "--format", "shortcut", "--dry-run", "--json", jsonPath)
+		result = importResultJSON{}
+		if err := json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &result); err != nil {
+			t.Fatalf("parse dry-run JSON: %v\n%s", err, out)
+		}
+		if result.Created != 0 || result.Updated != 2 {
+			t.Errorf("dry run after import = %+v, want 2 updates", result)
+		}
+	})
+
+	t.Run("sharded_v2_round_trip", func(t *testing.T) {
+		srcDir, _, _ := bdInit(t, bd, "--prefix", "imshard")
+		blocker := bdCreateSilent(t, bd, srcDir, "Shard blocker")
+		blocked := bdCreateSilent(t, bd, srcDir, "Shard blocked")
+		bdCommand(t, bd, srcDir, "dep", "add", blocked, blocker)
+		bdCommand(t, bd, srcDir, "close", blocker)
+
+		base := filepath.Join(t.TempDir(), "issues.jsonl")
+		bdExport(t, bd, srcDir, "--shard", "status", "-o", base)
+		openPath, closedPath := exportShardPath(base, "open"), exportShardPath(base, "closed")
+		for _, path := range []string{openPath, closedPath} {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("expected shard %s: %v", path, err)
+			}
+			if !strings.HasPrefix(string(data), `{"_schema":"beads-jsonl/2"`) {
+				t.Errorf("%s does not start with a v2 header:\n%s", path, data)
+			}
+		}
+
+		// The dependency crosses shards; importing them together resolves it.
+		dstDir, _, _ := bdInit(t, bd, "--prefix", "imshard")
+		out := bdImport(t, bd, dstDir, openPath, closedPath)
+		if !strings.Contains(out, "Imported 2 issues") {
+			t.Fatalf("expected 'Imported 2 issues', got: %s", out)
+		}
+		if !strings.Contains(bdShowJSON(t, bd, dstDir, blocked), blocker) {
+			t.Errorf("expected %s to depend on %s after import", blocked, blocker)
+		}
+	})
 }
 
 func TestEmbeddedImportConcurrent(t *testing.T) {
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/importers"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// importFormatNames lists the accepted --format values for help and errors.
+func importFormatNames() string {
+	return strings.Join(append([]string{"jsonl"}, importers.List()...), ", ")
+}
+
+// parseWithImporter reads a non-JSONL import source through a registered
+// importer and resolves its records to beads issues. A record whose ID or
+// external_ref matches a local issue keeps that issue's ID, so re-importing
+// an export updates the earlier import; others get fresh hash IDs, which
+// lets dependencies between new records be wired before anything is written.
+func parseWithImporter(ctx context.Context, imp importers.Importer, r io.Reader) (importRecords, error) {
+	customStatuses, _ := store.GetCustomStatuses(ctx)
+	customTypes, _ := store.GetCustomTypes(ctx)
+	batch, err := imp.Parse(ctx, r, importers.Options{
+		Store:          store,
+		CustomStatuses: customStatuses,
+		CustomTypes:    customTypes,
+		ColumnMap:      importCSVMap,
+	})
+	if err != nil {
+		return importRecords{}, err
+	}
+	if !jsonOutput {
+		for _, w := range batch.Warnings {
+			fmt.Fprintln(os.Stderr, w)
+		}
+	}
+
+	prefix := "bd"
+	if p := config.GetString("issue-prefix"); p != "" {
+		prefix = p
+	} else if p, err := store.GetConfig(ctx, "issue_prefix"); err == nil && p != "" {
+		prefix = p
+	}
+	existingID := func(issue *types.Issue) string {
+		if issue.ID != "" {
+			if existing, err := store.GetIssue(ctx, issue.ID); err == nil && existing != nil {
+				return existing.ID
+			}
+			return ""
+		}
+		if issue.ExternalRef != nil && *issue.ExternalRef != "" {
+			if existing, err := store.GetIssueByExternalRef(ctx, *issue.ExternalRef); err == nil && existing != nil {
+				return existing.ID
+			}
+		}
+		return ""
+	}
+	used := make(map[string]bool)
+	newID := func(issue *types.Issue) string {
+		for length := 6; length <= 8; length++ {
+			for nonce := 0; nonce < 10; nonce++ {
+				id := idgen.GenerateHashID(prefix, issue.Title, issue.Description, actor, time.Now(), length, nonce)
+				if used[id] {
+					continue
+				}
+				if existing, err := store.GetIssue(ctx, id); err == nil && existing != nil {
+					continue
+				}
+				used[id] = true
+				return id
+			}
+		}
+		return generateIssueID(prefix)
+	}
+	issues, stats := batch.Resolve(existingID, newID)
+	return importRecords{issues: issues, rowErrors: batch.RowErrors, stats: &stats}, nil
+}
@@ -169,6 +169,9 @@ func importIssuesCore(ctx context.Context, _ string, store storage.DoltStorage,
 	if err != nil {
 		return nil, err
 	}
+	if err := importAttachmentRefs(ctx, store, issues, staleRejectedSet); err != nil {
+		return nil, err
+	}
 
 	importedIDs := make([]string, 0, len(issues))
 	for _, issue := range issues {
@@ -802,31 +805,37 @@ func importFromLocalJSONL(ctx context.Context, store storage.DoltStorage, localP
 	return result.Issues, nil
 }
 
-// parseJSONLFile reads a JSONL file and returns parsed issues and config
-// entries (memories). Pure function — no store I/O.
-func parseJSONLFile(path string) ([]*types.Issue, map[string]string, error) {
+// parseJSONLFile reads a JSONL file and returns parsed issues, config
+// entries (memories), and label definitions. Pure function — no store I/O.
+func parseJSONLFile(path string) ([]*types.Issue, map[string]string, []*types.LabelDefinition, error) {
 	//nolint:gosec // G304: path from user-provided CLI argument
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read JSONL file %s: %w", path, err)
+		return nil, nil, nil, fmt.Errorf("failed to read JSONL file %s: %w", path, err)
 	}
 
 	scanner := bufio.NewScanner(strings.NewReader(string(data)))
 	// Allow up to 64MB per line for large descriptions
 	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
 	var issues []*types.Issue
+	var labelDefs []*types.LabelDefinition
 	configEntries := make(map[string]string)
 
+	lineNo := 0
 	for scanner.Scan() {
+		lineNo++
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
+		if hasConflictMarkerPrefix(line) {
+			return nil, nil, nil, jsonlConflictMarkerError(path, lineNo)
+		}
 
 		// Peek at the record to check for _type field
 		var peek map[string]json.RawMessage
 		if err := json.Unmarshal([]byte(line), &peek); err != nil {
-			return nil, nil, fmt.Errorf("failed to parse JSONL line: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to parse JSONL line: %w", err)
 		}
 
 		// Skip the optional beads-jsonl metadata/header record.
@@ -839,7 +848,10 @@ func parseJSONLFile(path string) ([]*types.Issue, map[string]string, error) {
 		// and aborts the whole import with "validation failed for
 		// issue : title is required". Identified by the _schema
 		// sentinel, which real issue/memory records never carry.
-		if _, isHeader := peek["_schema"]; isHeader {
+		if rawSchema, isHeader := peek["_schema"]; isHeader {
+			if err := checkJSONLSchema(rawSchema); err != nil {
+				return nil, nil, nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+			}
 			continue
 		}
 
@@ -849,19 +861,29 @@ func parseJSONLFile(path string) ([]*types.Issue, map[string]string, error) {
 			if err := json.Unmarshal(rawType, &typeStr); err == nil && typeStr == "memory" {
 				var mem memoryRecord
 				if err := json.Unmarshal([]byte(line), &mem); err != nil {
-					return nil, nil, fmt.Errorf("failed to parse memory record: %w", err)
+					return nil, nil, nil, fmt.Errorf("failed to parse memory record: %w", err)
 				}
 				if mem.Key != "" && mem.Value != "" {
 					configEntries[kvPrefix+memoryPrefix+mem.Key] = mem.Value
 				}
 				continue
 			}
+			if typeStr == "label" {
+				var def types.LabelDefinition
+				if err := json.Unmarshal([]byte(line), &def); err != nil {
+					return nil, nil, nil, fmt.Errorf("failed to parse label record: %w", err)
+				}
+				if def.Name != "" {
+					labelDefs = append(labelDefs, &def)
+				}
+				continue
+			}
 		}
 
 		// Regular issue record
 		var issue types.Issue
 		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			return nil, nil, fmt.Errorf("failed to parse issue from JSONL: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to parse issue from JSONL: %w", err)
 		}
 		// Skip tombstone entries: these are deleted issues exported by older
 		// versions (pre-v0.50) with status "tombstone" and deleted_at set.
@@ -883,10 +905,10 @@ func parseJSONLFile(path string) ([]*types.Issue, map[string]string, error) {
 		issues = append(issues, &issue)
 	}
 	if err := scanner.Err(); err != nil {
-		return nil, nil, fmt.Errorf("failed to scan JSONL: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to scan JSONL: %w", err)
 	}
 
-	return issues, configEntries, nil
+	return issues, configEntries, labelDefs, nil
 }
 
 // importFromLocalJSONLFull imports issues and memories from a local JSONL file
@@ -911,13 +933,17 @@ func importFromLocalJSONLConflictSkip(ctx context.Context, store storage.DoltSto
 // SetConfig, while routing regular issue records through the normal path.
 // conflictSkip selects insert-if-new (true) vs UPSERT (false) for issue rows.
 func importFromLocalJSONLWithOpts(ctx context.Context, store storage.DoltStorage, localPath string, conflictSkip bool) (*importLocalResult, error) {
-	issues, configEntries, err := parseJSONLFile(localPath)
+	issues, configEntries, labelDefs, err := parseJSONLFile(localPath)
 	if err != nil {
 		return nil, err
 	}
 
 	result := &importLocalResult{}
 
+	if err := importLabelDefinitions(ctx, store, labelDefs); err != nil {
+		return nil, err
+	}
+
 	// Import memories
 	for key, value := range configEntries {
 		if err := store.SetConfig(ctx, key, value); err != nil {
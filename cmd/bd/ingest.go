@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/attachments"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// emailExternalRefPrefix marks issues created from an email; the rest of
+// the external_ref is the message's Message-ID.
+const emailExternalRefPrefix = "email:"
+
+var ingestCmd = &cobra.Command{
+	Use:     "ingest",
+	GroupID: "sync",
+	Short:   "Create issues from external sources",
+}
+
+var ingestEmailCmd = &cobra.Command{
+	Use:   "email [maildir]",
+	Short: "Create issues from email messages",
+	Long: `Create issues from RFC 822 email messages, read from stdin or from a
+Maildir (the messages in its new/ and cur/ directories).
+
+Each message becomes an issue titled with its subject and described by its
+text body (the HTML body, stripped, when there is no plain-text part). The
+sender is recorded as the issue's creator and as "reporter" in its
+metadata, file attachments are stored with 'bd attach', and the Message-ID
+is kept as external_ref (email:<message-id>).
+
+Replies are threaded: a message whose In-Reply-To or References names an
+ingested message is appended to that issue as a comment (its attachments
+are added to the issue) instead of opening a new one. Messages already
+ingested are skipped, so a Maildir can be ingested repeatedly, e.g. from
+cron; Maildir messages are processed oldest first so a thread's root is
+seen before its replies.
+
+Examples:
+  bd ingest email < message.eml
+  bd ingest email ~/Maildir/.bugs --label support --type bug
+  bd ingest email --dry-run --json < reply.eml`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("ingest email")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("ingest email is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("ingest-email")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		issueType, _ := cmd.Flags().GetString("type")
+		priorityStr, _ := cmd.Flags().GetString("priority")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		priority, err := validation.ValidatePriority(priorityStr)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		var messages []*emailMessage
+		if len(args) == 0 || args[0] == "-" {
+			msg, err := readEmailMessage(os.Stdin)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			messages = append(messages, msg)
+		} else {
+			messages, err = readMaildir(args[0])
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		}
+
+		ing := &emailIngester{
+			ctx:       rootCtx,
+			store:     store,
+			beadsDir:  beads.FindBeadsDir(),
+			issueType: types.IssueType(issueType),
+			priority:  priority,
+			labels:    labels,
+			assignee:  assignee,
+			dryRun:    dryRun,
+		}
+		var results []emailIngestResult
+		for _, msg := range messages {
+			result, err := ing.ingest(msg)
+			if err != nil {
+				return HandleErrorRespectJSON("message %s: %v", msg.MessageID, err)
+			}
+			results = append(results, result)
+			if result.Action != "skipped" && !dryRun {
+				commandDidWrite.Store(true)
+				SetLastTouchedID(result.IssueID)
+			}
+		}
+
+		if jsonOutput {
+			if results == nil {
+				results = []emailIngestResult{}
+			}
+			return outputJSON(results)
+		}
+		for _, r := range results {
+			switch r.Action {
+			case "created":
+				fmt.Printf("%s Created %s from %q\n", ui.RenderPass("✓"), r.IssueID, r.Subject)
+			case "commented":
+				fmt.Printf("%s Added reply to %s from %s\n", ui.RenderPass("✓"), r.IssueID, r.From)
+			default:
+				fmt.Printf("- Skipped %s (already ingested as %s)\n", r.MessageID, r.IssueID)
+			}
+			if r.Attachments > 0 {
+				fmt.Printf("  %d attachment(s)\n", r.Attachments)
+			}
+		}
+		if dryRun {
+			fmt.Println("(dry run: nothing was written)")
+		}
+		return nil
+	},
+}
+
+// emailIngestResult reports what happened to one message.
+type emailIngestResult struct {
+	MessageID   string `json:"message_id"`
+	Action      string `json:"action"` // created, commented, or skipped
+	IssueID     string `json:"issue_id,omitempty"`
+	Subject     string `json:"subject"`
+	From        string `json:"from,omitempty"`
+	Attachments int    `json:"attachments,omitempty"`
+}
+
+type emailIngester struct {
+	ctx       context.Context
+	store     storage.DoltStorage
+	beadsDir  string
+	issueType types.IssueType
+	priority  int
+	labels    []string
+	assignee  string
+	dryRun    bool
+}
+
+func (ing *emailIngester) ingest(msg *emailMessage) (emailIngestResult, error) {
+	result := emailIngestResult{MessageID: msg.MessageID, Subject: msg.Subject, From: msg.From}
+
+	if existing, err := ing.issueForMessage(msg.MessageID); err != nil {
+		return result, err
+	} else if existing != nil {
+		result.Action, result.IssueID = "skipped", existing.ID
+		return result, nil
+	}
+
+	var thread *types.Issue
+	for _, id := range msg.threadIDs() {
+		issue, err := ing.issueForMessage(id)
+		if err != nil {
+			return result, err
+		}
+		if issue != nil {
+			thread = issue
+			break
+		}
+	}
+
+	author := msg.FromAddress
+	if author == "" {
+		author = msg.From
+	}
+
+	if thread != nil {
+		result.IssueID = thread.ID
+		marker := "Message-ID: <" + msg.MessageID + ">"
+		comments, err := ing.store.GetIssueComments(ing.ctx, thread.ID)
+		if err != nil {
+			return result, err
+		}
+		for _, c := range comments {
+			if strings.Contains(c.Text, marker) {
+				result.Action = "skipped"
+				return result, nil
+			}
+		}
+		result.Action = "commented"
+		result.Attachments = len(msg.Attachments)
+		if ing.dryRun {
+			return result, nil
+		}
+		text := fmt.Sprintf("From: %s\n%s\n\n%s", msg.From, marker, msg.Body)
+		if _, err := ing.store.AddIssueComment(ing.ctx, thread.ID, author, strings.TrimSpace(text)); err != nil {
+			return result, fmt.Errorf("adding comment to %s: %w", thread.ID, err)
+		}
+		return result, ing.attach(thread.ID, author, msg.Attachments)
+	}
+
+	title := msg.Subject
+	if title == "" {
+		title = "(no subject)"
+	}
+	if runes := []rune(title); len(runes) > 500 {
+		title = string(runes[:500])
+	}
+	metadata, err := json.Marshal(map[string]string{"reporter": msg.From})
+	if err != nil {
+		return result, err
+	}
+	ref := emailExternalRefPrefix + msg.MessageID
+	issue := &types.Issue{
+		Title:       title,
+		Description: msg.Body,
+		Status:      types.StatusOpen,
+		Priority:    ing.priority,
+		IssueType:   ing.issueType,
+		Assignee:    ing.assignee,
+		Labels:      ing.labels,
+		CreatedBy:   author,
+		ExternalRef: &ref,
+		Metadata:    metadata,
+	}
+	if !msg.Date.IsZero() {
+		issue.CreatedAt = msg.Date
+	}
+	result.Action = "created"
+	result.Attachments = len(msg.Attachments)
+	if ing.dryRun {
+		return result, nil
+	}
+	if err := createIssueWithDeps(ing.ctx, ing.store, issue, actor, createDepEdges{}); err != nil {
+		return result, fmt.Errorf("creating issue: %w", err)
+	}
+	result.IssueID = issue.ID
+	return result, ing.attach(issue.ID, author, msg.Attachments)
+}
+
+// issueForMessage returns the issue created from a message, or nil.
+func (ing *emailIngester) issueForMessage(messageID string) (*types.Issue, error) {
+	issue, err := ing.store.GetIssueByExternalRef(ing.ctx, emailExternalRefPrefix+messageID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	return issue, err
+}
+
+func (ing *emailIngester) attach(issueID, author string, files []emailAttachment) error {
+	if len(files) == 0 {
+		return nil
+	}
+	attStore, ok := storage.UnwrapStore(ing.store).(storage.AttachmentStore)
+	if !ok {
+		return fmt.Errorf("storage backend does not support attachments")
+	}
+	for _, file := range files {
+		sha, size, err := attachments.Put(ing.beadsDir, bytes.NewReader(file.Data))
+		if err != nil {
+			return fmt.Errorf("storing %s: %w", file.Name, err)
+		}
+		err = attStore.AddAttachment(ing.ctx, &types.Attachment{
+			IssueID:   issueID,
+			SHA256:    sha,
+			Name:      filepath.Base(file.Name),
+			Size:      size,
+			MediaType: file.MediaType,
+			CreatedBy: author,
+		})
+		if err != nil {
+			return fmt.Errorf("attaching %s to %s: %w", file.Name, issueID, err)
+		}
+	}
+	return nil
+}
+
+// readEmailMessage parses one message. A message without a Message-ID gets
+// one derived from its content so re-ingesting it is still detected.
+func readEmailMessage(r io.Reader) (*emailMessage, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading message: %w", err)
+	}
+	msg, err := parseEmailMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	if msg.MessageID == "" {
+		sum := sha256.Sum256(raw)
+		msg.MessageID = hex.EncodeToString(sum[:12]) + "@bd-ingest"
+	}
+	return msg, nil
+}
+
+// readMaildir parses the messages in a Maildir's new/ and cur/ directories,
+// ordered by their Date header (then file name) so replies follow the
+// messages they answer.
+func readMaildir(dir string) ([]*emailMessage, error) {
+	var paths []string
+	for _, sub := range []string{"new", "cur"} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+				paths = append(paths, filepath.Join(dir, sub, e.Name()))
+			}
+		}
+	}
+	if paths == nil {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("%s is not a Maildir", dir)
+		}
+	}
+	sort.Strings(paths)
+
+	messages := make([]*emailMessage, 0, len(paths))
+	for _, path := range paths {
+		f, err := os.Open(path) //nolint:gosec // G304: user-specified Maildir
+		if err != nil {
+			return nil, err
+		}
+		msg, err := readEmailMessage(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		messages = append(messages, msg)
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Date.Before(messages[j].Date)
+	})
+	return messages, nil
+}
+
+func init() {
+	ingestEmailCmd.Flags().StringP("type", "t", "task", "Issue type for new issues")
+	ingestEmailCmd.Flags().StringP("priority", "p", "2", "Priority for new issues (0-4 or P0-P4)")
+	ingestEmailCmd.Flags().StringSlice("label", nil, "Label to add to new issues (repeatable)")
+	ingestEmailCmd.Flags().String("assignee", "", "Assignee for new issues")
+	ingestEmailCmd.Flags().Bool("dry-run", false, "Report what would be created without writing")
+	ingestCmd.AddCommand(ingestEmailCmd)
+	rootCmd.AddCommand(ingestCmd)
+}
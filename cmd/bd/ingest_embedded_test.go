@@ -0,0 +1,122 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// bdIngestEmail pipes a message into "bd ingest email --json".
+func bdIngestEmail(t *testing.T, bd, dir, message string, args ...string) []emailIngestResult {
+	t.Helper()
+	cmd := exec.Command(bd, append([]string{"ingest", "email", "--json"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = bdEnv(dir)
+	cmd.Stdin = strings.NewReader(message)
+	stdout, stderr, err := runCommandBuffers(t, cmd)
+	if err != nil {
+		t.Fatalf("bd ingest email failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+	}
+	var results []emailIngestResult
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("parse ingest output: %v\n%s", err, stdout.String())
+	}
+	return results
+}
+
+func TestEmbeddedIngestEmail(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "em")
+
+	reply := "From: Bob <bob@example.com>\r\n" +
+		"Subject: Re: Crash on save\r\n" +
+		"Date: Mon, 03 Mar 2025 11:00:00 +0000\r\n" +
+		"Message-ID: <reply-1@example.com>\r\n" +
+		"In-Reply-To: <root-1@example.com>\r\n" +
+		"\r\n" +
+		"Same here on Linux.\r\n"
+
+	t.Run("issue_then_reply", func(t *testing.T) {
+		results := bdIngestEmail(t, bd, dir, testMultipartEmail, "--label", "support")
+		if len(results) != 1 || results[0].Action != "created" || results[0].Attachments != 1 {
+			t.Fatalf("root results = %+v", results)
+		}
+		id := results[0].IssueID
+
+		var issue struct {
+			Title       string          `json:"title"`
+			ExternalRef string          `json:"external_ref"`
+			CreatedBy   string          `json:"created_by"`
+			Labels      []string        `json:"labels"`
+			Metadata    json.RawMessage `json:"metadata"`
+		}
+		if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, id)), &issue); err != nil {
+			t.Fatal(err)
+		}
+		if issue.Title != "Crash on “save”" || issue.ExternalRef != "email:root-1@example.com" || issue.CreatedBy != "ada@example.com" {
+			t.Errorf("issue = %+v", issue)
+		}
+		if strings.Join(issue.Labels, ",") != "support" || !strings.Contains(string(issue.Metadata), "Adá Lovelace") {
+			t.Errorf("labels %v metadata %s", issue.Labels, issue.Metadata)
+		}
+		if out := bdCommand(t, bd, dir, "attach", "list", id); !strings.Contains(out, "trace.log") {
+			t.Errorf("attachment not stored:\n%s", out)
+		}
+
+		results = bdIngestEmail(t, bd, dir, reply)
+		if len(results) != 1 || results[0].Action != "commented" || results[0].IssueID != id {
+			t.Fatalf("reply results = %+v", results)
+		}
+		if out := bdCommand(t, bd, dir, "comments", id); !strings.Contains(out, "Same here on Linux.") {
+			t.Errorf("reply not added as comment:\n%s", out)
+		}
+
+		// Re-ingesting either message changes nothing.
+		if results := bdIngestEmail(t, bd, dir, testMultipartEmail); results[0].Action != "skipped" {
+			t.Errorf("re-ingested root = %+v", results)
+		}
+		if results := bdIngestEmail(t, bd, dir, reply); results[0].Action != "skipped" {
+			t.Errorf("re-ingested reply = %+v", results)
+		}
+	})
+
+	t.Run("maildir", func(t *testing.T) {
+		maildir := t.TempDir()
+		for _, sub := range []string{"new", "cur", "tmp"} {
+			if err := os.MkdirAll(filepath.Join(maildir, sub), 0o755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// The reply sorts first by file name but is dated after its root.
+		files := map[string]string{
+			"new/1.eml": "From: carol@example.com\r\nSubject: Re: Docs typo\r\nDate: Tue, 04 Mar 2025 12:00:00 +0000\r\n" +
+				"Message-ID: <docs-2@example.com>\r\nReferences: <docs-1@example.com>\r\n\r\nFixed in my fork.\r\n",
+			"cur/2.eml:2,S": "From: dan@example.com\r\nSubject: Docs typo\r\nDate: Tue, 04 Mar 2025 09:00:00 +0000\r\n" +
+				"Message-ID: <docs-1@example.com>\r\n\r\nREADME says 'teh'.\r\n",
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(maildir, name), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		out := bdCommand(t, bd, dir, "ingest", "email", maildir, "--json")
+		var results []emailIngestResult
+		if err := json.Unmarshal([]byte(out), &results); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if len(results) != 2 || results[0].Action != "created" || results[1].Action != "commented" || results[0].IssueID != results[1].IssueID {
+			t.Errorf("maildir results = %+v", results)
+		}
+	})
+}
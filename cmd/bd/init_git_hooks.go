@@ -207,7 +207,7 @@ func installGitHooks() error {
 // buildPreCommitHook generates the pre-commit hook content using section markers (GH#1380).
 // If chainHooks is true, chained hooks (.old) are called before the beads section.
 func buildPreCommitHook(chainHooks bool, existingHooks []hookInfo) string {
-	section := generateHookSection("pre-commit")
+	section := managedHookSection("", "pre-commit")
 
 	if chainHooks {
 		var existingPreCommit string
@@ -235,7 +235,7 @@ func buildPreCommitHook(chainHooks bool, existingHooks []hookInfo) string {
 
 // buildPostMergeHook generates the post-merge hook content using section markers (GH#1380).
 func buildPostMergeHook(chainHooks bool, existingHooks []hookInfo) string {
-	section := generateHookSection("post-merge")
+	section := managedHookSection("", "post-merge")
 
 	if chainHooks {
 		var existingPostMerge string
@@ -273,7 +273,7 @@ func installJJHooks() error {
 // buildJJPreCommitHook generates the pre-commit hook for jujutsu repos using section markers (GH#1380).
 func buildJJPreCommitHook(chainHooks bool, existingHooks []hookInfo) string {
 	// jj uses the same shim as git — bd hooks run handles the differences internally
-	section := generateHookSection("pre-commit")
+	section := managedHookSection("", "pre-commit")
 
 	if chainHooks {
 		var existingPreCommit string
@@ -310,6 +310,11 @@ func TestInjectHookSection(t *testing.T) {
 				"# --- END BEADS INTEGRATION ---\n",
 			wantHas: []string{"#!/bin/sh\n", hookSectionBeginPrefix, "bd hooks run pre-commit"},
 		},
+		{
+			name:     "orphaned BEGIN followed directly by user content",
+			existing: "#!/bin/sh\n# --- BEGIN BEADS INTEGRATION v0.57.0 ---\nbd hook pre-commit \"$@\"\nnpm test\n",
+			wantHas:  []string{"npm test", hookSectionBeginPrefix, "bd hooks run pre-commit"},
+		},
 		{
 			name: "reversed markers (END before BEGIN)",
 			existing: "#!/bin/sh\necho user-linter\n" +
@@ -344,9 +349,10 @@ func TestInjectHookSection(t *testing.T) {
 			}
 			// Verify broken marker scenarios leave exactly one clean section
 			brokenCases := map[string]bool{
-				"orphaned BEGIN without END":             true,
-				"orphaned BEGIN followed by valid block": true,
-				"reversed markers (END before BEGIN)":    true,
+				"orphaned BEGIN without END":                       true,
+				"orphaned BEGIN followed by valid block":           true,
+				"orphaned BEGIN followed directly by user content": true,
+				"reversed markers (END before BEGIN)":              true,
 			}
 			if brokenCases[tt.name] {
 				beginCount := strings.Count(result, hookSectionBeginPrefix)
@@ -700,6 +706,60 @@ func TestUninstallHooksRemovesEmptyFile(t *testing.T) {
 	})
 }
 
+func TestInstallHooksWritesWindowsCompanions(t *testing.T) {
+	tmpDir := newGitRepo(t)
+	orig := hookTargetGOOS
+	hookTargetGOOS = "windows"
+	t.Cleanup(func() { hookTargetGOOS = orig })
+
+	runInDir(t, tmpDir, func() {
+		hooksDir, err := git.GetGitHooksDir()
+		if err != nil {
+			t.Fatalf("git.GetGitHooksDir() failed: %v", err)
+		}
+		if err := os.MkdirAll(hooksDir, 0750); err != nil {
+			t.Fatal(err)
+		}
+		// A user-owned companion must survive install and uninstall.
+		userCmd := filepath.Join(hooksDir, "pre-push.cmd")
+		if err := os.WriteFile(userCmd, []byte("@echo off\r\ncall lint.cmd\r\n"), 0700); err != nil {
+			t.Fatal(err)
+		}
+		// A BOM ahead of the shebang must not survive the rewrite.
+		if err := os.WriteFile(filepath.Join(hooksDir, "post-merge"), []byte("\ufeff#!/bin/sh\necho mine\n"), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := installHooksWithOptions(managedHookNames, false, false, false, false); err != nil {
+			t.Fatalf("installHooksWithOptions() failed: %v", err)
+		}
+
+		shim, err := os.ReadFile(filepath.Join(hooksDir, "pre-commit.cmd"))
+		if err != nil {
+			t.Fatalf("pre-commit.cmd companion not written: %v", err)
+		}
+		if !strings.Contains(string(shim), "bd hooks run pre-commit %*") || !strings.Contains(string(shim), "\r\n") {
+			t.Errorf("unexpected companion content:\n%s", shim)
+		}
+		if got, _ := os.ReadFile(userCmd); !strings.Contains(string(got), "call lint.cmd") {
+			t.Errorf("user pre-push.cmd was overwritten:\n%s", got)
+		}
+		if got, _ := os.ReadFile(filepath.Join(hooksDir, "post-merge")); !strings.HasPrefix(string(got), "#!/bin/sh\n") {
+			t.Errorf("post-merge should start with its shebang, got %q", got[:12])
+		}
+
+		if err := uninstallHooks(); err != nil {
+			t.Fatalf("uninstallHooks() failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(hooksDir, "pre-commit.cmd")); !os.IsNotExist(err) {
+			t.Error("bd companion should be removed by uninstall")
+		}
+		if _, err := os.Stat(userCmd); err != nil {
+			t.Error("user pre-push.cmd should survive uninstall")
+		}
+	})
+}
+
 // TestConfigureBeadsHooksPath_AbsolutePath verifies that core.hooksPath is set to
 // an absolute path so that git worktrees can find the hooks directory (GH#2414).
 func TestConfigureBeadsHooksPath_AbsolutePath(t *testing.T) {
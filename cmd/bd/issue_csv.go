@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/importers"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// writeIssuesCSV writes issues as RFC 4180 CSV with a header row of
+// importers.CSVFields, which the csv importer reads back. Labels and
+// blocking dependencies are comma-separated within their cells; the parent
+// column holds the parent-child target.
+func writeIssuesCSV(w io.Writer, issues []*types.Issue) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(importers.CSVFields); err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if err := cw.Write(csvIssueRow(issue)); err != nil {
+			return fmt.Errorf("failed to write issue %s: %w", issue.ID, err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvIssueRow(issue *types.Issue) []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil || t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	var parent string
+	var dependsOn []string
+	for _, dep := range issue.Dependencies {
+		switch dep.Type {
+		case types.DepParentChild:
+			parent = dep.DependsOnID
+		case types.DepBlocks:
+			dependsOn = append(dependsOn, dep.DependsOnID)
+		}
+	}
+	externalRef := ""
+	if issue.ExternalRef != nil {
+		externalRef = *issue.ExternalRef
+	}
+	return []string{
+		issue.ID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
+		string(issue.Status), strconv.Itoa(issue.Priority), string(issue.IssueType), issue.Assignee, issue.Owner,
+		strings.Join(issue.Labels, ","),
+		parent, strings.Join(dependsOn, ","), externalRef, formatTime(issue.DueAt),
+		formatTime(&issue.CreatedAt), formatTime(&issue.UpdatedAt), formatTime(issue.ClosedAt),
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/importers"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueCSVRoundTrip(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	closed := created.Add(48 * time.Hour)
+	ref := "gh-12"
+	issues := []*types.Issue{
+		{
+			ID: "bd-1", Title: `Quote "this", please`, Description: "line one\nline two",
+			Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug,
+			Labels: []string{"backend", "urgent"}, ExternalRef: &ref,
+			CreatedAt: created, UpdatedAt: created,
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-1", DependsOnID: "bd-epic", Type: types.DepParentChild},
+				{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+				{IssueID: "bd-1", DependsOnID: "bd-3", Type: types.DepRelated},
+			},
+		},
+		{
+			ID: "bd-2", Title: "Done thing", Status: types.StatusClosed, Priority: 3, IssueType: types.TypeTask,
+			CreatedAt: created, UpdatedAt: closed, ClosedAt: &closed,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeIssuesCSV(&buf, issues); err != nil {
+		t.Fatalf("writeIssuesCSV: %v", err)
+	}
+	got, ignored, rowErrors, err := importers.ParseIssuesCSV(&buf, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ParseIssuesCSV: %v", err)
+	}
+	if len(ignored) != 0 || len(rowErrors) != 0 {
+		t.Fatalf("ignored %v, row errors %v", ignored, rowErrors)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d issues, want 2", len(got))
+	}
+
+	a := got[0]
+	if a.Title != issues[0].Title || a.Description != issues[0].Description {
+		t.Errorf("title/description = %q / %q", a.Title, a.Description)
+	}
+	if a.Priority != 0 || a.IssueType != types.TypeBug || strings.Join(a.Labels, ",") != "backend,urgent" {
+		t.Errorf("priority %d type %s labels %v", a.Priority, a.IssueType, a.Labels)
+	}
+	if a.ExternalRef == nil || *a.ExternalRef != ref || !a.CreatedAt.Equal(created) {
+		t.Errorf("external_ref %v created_at %v", a.ExternalRef, a.CreatedAt)
+	}
+	// Only parent-child and blocks edges have columns.
+	if len(a.Dependencies) != 2 ||
+		a.Dependencies[0].DependsOnID != "bd-epic" || a.Dependencies[0].Type != types.DepParentChild ||
+		a.Dependencies[1].DependsOnID != "bd-2" || a.Dependencies[1].Type != types.DepBlocks {
+		t.Errorf("dependencies = %+v", a.Dependencies)
+	}
+	if b := got[1]; b.Status != types.StatusClosed || b.ClosedAt == nil || !b.ClosedAt.Equal(closed) {
+		t.Errorf("closed issue: status %s closed_at %v", b.Status, b.ClosedAt)
+	}
+}
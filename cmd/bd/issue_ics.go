@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// icsPriority maps P0-P4 onto the iCalendar PRIORITY scale (1 highest,
+// 9 lowest).
+var icsPriority = [...]int{1, 3, 5, 7, 9}
+
+// writeIssuesICS writes an iCalendar (RFC 5545) feed with one event per
+// issue that has a due date, so deadlines and milestones show up in
+// calendar apps subscribed to the file. Due dates at local midnight become
+// all-day events; other times become events at that instant. Each event's
+// UID is the bd ID, so calendar apps update events in place when the feed
+// is regenerated. Issues without a due date are skipped.
+func writeIssuesICS(w io.Writer, issues []*types.Issue) error {
+	var due []*types.Issue
+	for _, issue := range issues {
+		if issue.DueAt != nil && !issue.DueAt.IsZero() {
+			due = append(due, issue)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].DueAt.Equal(*due[j].DueAt) {
+			return due[i].DueAt.Before(*due[j].DueAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//beads//bd export//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:beads deadlines")
+	for _, issue := range due {
+		writeICSEvent(&b, issue)
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeICSEvent(b *strings.Builder, issue *types.Issue) {
+	writeICSLine(b, "BEGIN:VEVENT")
+	writeICSLine(b, "UID:"+icsText(issue.ID)+"@beads")
+	// DTSTAMP is the issue's last update rather than the export time so an
+	// unchanged issue renders identically across exports.
+	stamp := issue.UpdatedAt
+	if stamp.IsZero() {
+		stamp = issue.CreatedAt
+	}
+	writeICSLine(b, "DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"))
+
+	dueAt := issue.DueAt.Local()
+	if dueAt.Hour() == 0 && dueAt.Minute() == 0 && dueAt.Second() == 0 {
+		writeICSLine(b, "DTSTART;VALUE=DATE:"+dueAt.Format("20060102"))
+		writeICSLine(b, "DTEND;VALUE=DATE:"+dueAt.AddDate(0, 0, 1).Format("20060102"))
+	} else {
+		writeICSLine(b, "DTSTART:"+dueAt.UTC().Format("20060102T150405Z"))
+	}
+
+	summary := strings.Join(strings.Fields(issue.Title), " ")
+	if issue.IssueType == types.TypeMilestone {
+		summary = "Milestone: " + summary
+	}
+	if issue.Status == types.StatusClosed {
+		summary = "✓ " + summary
+	}
+	writeICSLine(b, "SUMMARY:"+icsText(fmt.Sprintf("%s (%s)", summary, issue.ID)))
+
+	desc := fmt.Sprintf("%s · P%d %s · %s", issue.ID, issue.Priority, issue.IssueType, issue.Status)
+	if issue.Assignee != "" {
+		desc += " · " + issue.Assignee
+	}
+	if text := strings.TrimSpace(issue.Description); text != "" {
+		desc += "\n\n" + text
+	}
+	writeICSLine(b, "DESCRIPTION:"+icsText(desc))
+
+	categories := []string{icsText(string(issue.IssueType))}
+	for _, label := range issue.Labels {
+		categories = append(categories, icsText(label))
+	}
+	writeICSLine(b, "CATEGORIES:"+strings.Join(categories, ","))
+	writeICSLine(b, fmt.Sprintf("PRIORITY:%d", icsPriority[min(max(issue.Priority, 0), 4)]))
+	if issue.Status == types.StatusClosed {
+		writeICSLine(b, "TRANSP:TRANSPARENT")
+	}
+	writeICSLine(b, "END:VEVENT")
+}
+
+// icsText escapes a TEXT property value (RFC 5545 §3.3.11).
+func icsText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeICSLine writes a content line, folded at 75 octets without
+// splitting UTF-8 sequences, with the CRLF line ending RFC 5545 requires.
+func writeICSLine(b *strings.Builder, line string) {
+	const limit = 75
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		width = limit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
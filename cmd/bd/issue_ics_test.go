@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteIssuesICS(t *testing.T) {
+	updated := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	allDay := time.Date(2025, 3, 14, 0, 0, 0, 0, time.Local)
+	timed := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{
+			ID: "bd-2", Title: "Launch", Description: "Ship it; then, celebrate\nfor real",
+			Status: types.StatusOpen, Priority: 0, IssueType: types.TypeMilestone,
+			Labels: []string{"release"}, Assignee: "alice", DueAt: &allDay, UpdatedAt: updated,
+		},
+		{ID: "bd-1", Title: "Review", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask, DueAt: &timed, UpdatedAt: updated},
+		{ID: "bd-3", Title: "No deadline", Status: types.StatusOpen, IssueType: types.TypeTask, UpdatedAt: updated},
+	}
+
+	var buf bytes.Buffer
+	if err := writeIssuesICS(&buf, issues); err != nil {
+		t.Fatalf("writeIssuesICS: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+		t.Fatalf("not an iCalendar feed:\n%s", out)
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 || strings.Contains(out, "bd-3") {
+		t.Errorf("want events for the two issues with due dates only:\n%s", out)
+	}
+	// Events are ordered by due date.
+	if strings.Index(out, "UID:bd-1@beads") > strings.Index(out, "UID:bd-2@beads") {
+		t.Errorf("events not ordered by due date:\n%s", out)
+	}
+
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	for _, want := range []string{
+		"DTSTAMP:20250301T090000Z\r\n",
+		"DTSTART;VALUE=DATE:20250314\r\nDTEND;VALUE=DATE:20250315\r\n",
+		"SUMMARY:Milestone: Launch (bd-2)\r\n",
+		`Ship it\; then\, celebrate\nfor real`,
+		"CATEGORIES:milestone,release\r\n",
+		"PRIORITY:1\r\n",
+		"DTSTART:20250310T153000Z\r\n",
+		"SUMMARY:✓ Review (bd-1)\r\n",
+		"TRANSP:TRANSPARENT\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("missing %q in:\n%s", want, unfolded)
+		}
+	}
+}
+
+func TestWriteICSLineFolding(t *testing.T) {
+	var b strings.Builder
+	writeICSLine(&b, "DESCRIPTION:"+strings.Repeat("é", 100))
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected the line to be folded, got %q", lines)
+	}
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line %d is %d octets", i, len(line))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d does not start with a space", i)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	if unfolded.String() != "DESCRIPTION:"+strings.Repeat("é", 100) {
+		t.Errorf("unfolding does not round-trip: %q", unfolded.String())
+	}
+}
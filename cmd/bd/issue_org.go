@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// orgTodoKeywords maps statuses to the keywords declared in the #+TODO
+// line of an org export; anything else is TODO.
+var orgTodoKeywords = map[types.Status]string{
+	types.StatusInProgress: "STARTED",
+	types.StatusBlocked:    "BLOCKED",
+	types.StatusDeferred:   "DEFERRED",
+	types.StatusClosed:     "DONE",
+}
+
+// writeIssuesOrg writes issues as an Emacs org-mode outline: one headline
+// per issue with its TODO state, priority (P0-P4 as [#A]-[#E]), labels as
+// tags, due and defer dates as DEADLINE and SCHEDULED, and the bd ID in an
+// :ID: property so [[id:...]] links resolve. Children are nested under
+// their parent when both are exported.
+func writeIssuesOrg(w io.Writer, issues []*types.Issue) error {
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	children := make(map[string][]*types.Issue)
+	var roots []*types.Issue
+	for _, issue := range issues {
+		if parent := issueParentID(issue); parent != "" && byID[parent] != nil && parent != issue.ID {
+			children[parent] = append(children[parent], issue)
+			continue
+		}
+		roots = append(roots, issue)
+	}
+
+	var b strings.Builder
+	b.WriteString("#+TODO: TODO STARTED BLOCKED DEFERRED | DONE\n")
+	b.WriteString("#+PRIORITIES: A E C\n")
+
+	written := make(map[string]bool, len(issues))
+	var emit func(issue *types.Issue, level int)
+	emit = func(issue *types.Issue, level int) {
+		if written[issue.ID] {
+			return
+		}
+		written[issue.ID] = true
+		writeOrgHeadline(&b, issue, level)
+		for _, child := range children[issue.ID] {
+			emit(child, level+1)
+		}
+	}
+	for _, issue := range roots {
+		emit(issue, 1)
+	}
+	// Issues only reachable through a parent cycle still get a headline.
+	for _, issue := range issues {
+		emit(issue, 1)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeOrgHeadline(b *strings.Builder, issue *types.Issue, level int) {
+	keyword := orgTodoKeywords[issue.Status]
+	if keyword == "" {
+		keyword = "TODO"
+	}
+	priority := min(max(issue.Priority, 0), 4)
+	title := strings.Join(strings.Fields(issue.Title), " ")
+	fmt.Fprintf(b, "%s %s [#%c] %s", strings.Repeat("*", level), keyword, 'A'+rune(priority), title)
+	if tags := orgTags(issue.Labels); tags != "" {
+		fmt.Fprintf(b, " %s", tags)
+	}
+	b.WriteString("\n")
+
+	var planning []string
+	if issue.ClosedAt != nil && issue.Status == types.StatusClosed {
+		planning = append(planning, "CLOSED: "+orgTimestamp(*issue.ClosedAt, false))
+	}
+	if issue.DueAt != nil {
+		planning = append(planning, "DEADLINE: "+orgTimestamp(*issue.DueAt, true))
+	}
+	if issue.DeferUntil != nil {
+		planning = append(planning, "SCHEDULED: "+orgTimestamp(*issue.DeferUntil, true))
+	}
+	if len(planning) > 0 {
+		fmt.Fprintf(b, "%s\n", strings.Join(planning, " "))
+	}
+
+	b.WriteString(":PROPERTIES:\n")
+	orgProperty(b, "ID", issue.ID)
+	orgProperty(b, "TYPE", string(issue.IssueType))
+	orgProperty(b, "ASSIGNEE", issue.Assignee)
+	if issue.ExternalRef != nil {
+		orgProperty(b, "EXTERNAL_REF", *issue.ExternalRef)
+	}
+	var blockers []string
+	for _, dep := range issue.Dependencies {
+		if dep.Type == types.DepBlocks {
+			blockers = append(blockers, fmt.Sprintf("[[id:%s][%s]]", dep.DependsOnID, dep.DependsOnID))
+		}
+	}
+	orgProperty(b, "BLOCKED_BY", strings.Join(blockers, " "))
+	orgProperty(b, "CREATED", orgTimestamp(issue.CreatedAt, false))
+	b.WriteString(":END:\n")
+
+	// Body lines are indented so a line starting with "*" in a description
+	// never becomes a headline.
+	if body := strings.TrimSpace(issue.Description); body != "" {
+		for _, line := range strings.Split(body, "\n") {
+			if line = strings.TrimRight(line, " \t\r"); line == "" {
+				b.WriteString("\n")
+			} else {
+				fmt.Fprintf(b, "  %s\n", line)
+			}
+		}
+	}
+}
+
+// issueParentID returns the target of an issue's parent-child edge.
+func issueParentID(issue *types.Issue) string {
+	for _, dep := range issue.Dependencies {
+		if dep.Type == types.DepParentChild {
+			return dep.DependsOnID
+		}
+	}
+	return ""
+}
+
+func orgProperty(b *strings.Builder, name, value string) {
+	if value = strings.Join(strings.Fields(value), " "); value != "" {
+		fmt.Fprintf(b, ":%s: %s\n", name, value)
+	}
+}
+
+// orgTags renders labels as an org tag string (":a:b:"). Org tags allow
+// only letters, digits, _, @, # and %, so other characters become _.
+func orgTags(labels []string) string {
+	var tags []string
+	for _, label := range labels {
+		tag := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_@#%", r) {
+				return r
+			}
+			return '_'
+		}, label)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return ":" + strings.Join(tags, ":") + ":"
+}
+
+// orgTimestamp formats an active (<...>) or inactive ([...]) org timestamp
+// in local time.
+func orgTimestamp(t time.Time, active bool) string {
+	s := t.Local().Format("2006-01-02 Mon 15:04")
+	if active {
+		return "<" + s + ">"
+	}
+	return "[" + s + "]"
+}
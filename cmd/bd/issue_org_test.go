@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteIssuesOrg(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := created.Add(72 * time.Hour)
+	issues := []*types.Issue{
+		{
+			ID: "bd-2", Title: "Login   form", Description: "* not a headline\n\nsecond paragraph",
+			Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask,
+			Labels: []string{"ui", "needs review"}, DueAt: &due, CreatedAt: created,
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild},
+				{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks},
+			},
+		},
+		{ID: "bd-1", Title: "Auth", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeEpic, CreatedAt: created},
+		{ID: "bd-3", Title: "Sessions", Status: types.StatusClosed, Priority: 4, IssueType: types.TypeTask, CreatedAt: created, ClosedAt: &due},
+	}
+
+	var buf bytes.Buffer
+	if err := writeIssuesOrg(&buf, issues); err != nil {
+		t.Fatalf("writeIssuesOrg: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"#+TODO: TODO STARTED BLOCKED DEFERRED | DONE\n",
+		"* TODO [#A] Auth\n",
+		"** STARTED [#B] Login form :ui:needs_review:\nDEADLINE: " + orgTimestamp(due, true) + "\n",
+		":ID: bd-2\n",
+		":BLOCKED_BY: [[id:bd-3][bd-3]]\n",
+		"  * not a headline\n\n  second paragraph\n",
+		"* DONE [#E] Sessions\nCLOSED: " + orgTimestamp(due, false) + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("org output missing %q:\n%s", want, out)
+		}
+	}
+	// The child is written once, under its parent.
+	if strings.Count(out, ":ID: bd-2\n") != 1 || strings.Index(out, "Auth") > strings.Index(out, "Login form") {
+		t.Errorf("child not nested under parent:\n%s", out)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// taskwarriorNamespace is the UUIDv5 namespace for TaskWarrior task UUIDs.
+// Deriving the UUID from the bd ID means re-importing an export with
+// 'task import' updates the tasks it created instead of duplicating them.
+var taskwarriorNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/gastownhall/beads#taskwarrior"))
+
+// taskwarriorTask is a task in TaskWarrior's import/export JSON. bdid and
+// bdtype are user-defined attributes; TaskWarrior keeps them even when no
+// matching uda.* is configured.
+type taskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Entry       string                  `json:"entry"`
+	Modified    string                  `json:"modified,omitempty"`
+	Start       string                  `json:"start,omitempty"`
+	End         string                  `json:"end,omitempty"`
+	Due         string                  `json:"due,omitempty"`
+	Wait        string                  `json:"wait,omitempty"`
+	Priority    string                  `json:"priority,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Depends     []string                `json:"depends,omitempty"`
+	Annotations []taskwarriorAnnotation `json:"annotations,omitempty"`
+	BdID        string                  `json:"bdid"`
+	BdType      string                  `json:"bdtype,omitempty"`
+}
+
+type taskwarriorAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// writeIssuesTaskwarrior writes issues as a JSON array for 'task import',
+// one task per line. Closed issues are completed tasks; in-progress issues
+// are started; P0-P1 map to priority H, P2 to M and P3-P4 to L; blocking
+// dependencies on exported issues become depends; the description is
+// attached as an annotation.
+func writeIssuesTaskwarrior(w io.Writer, issues []*types.Issue) error {
+	exported := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		exported[issue.ID] = true
+	}
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i, issue := range issues {
+		data, err := json.Marshal(taskwarriorFromIssue(issue, exported))
+		if err != nil {
+			return fmt.Errorf("failed to marshal issue %s: %w", issue.ID, err)
+		}
+		sep := ",\n"
+		if i == 0 {
+			sep = "\n"
+		}
+		if _, err := fmt.Fprintf(w, "%s%s", sep, data); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
+
+func taskwarriorFromIssue(issue *types.Issue, exported map[string]bool) taskwarriorTask {
+	task := taskwarriorTask{
+		UUID:        taskwarriorUUID(issue.ID),
+		Description: issue.Title,
+		Status:      "pending",
+		Entry:       taskwarriorTime(&issue.CreatedAt),
+		Modified:    taskwarriorTime(&issue.UpdatedAt),
+		Due:         taskwarriorTime(issue.DueAt),
+		Wait:        taskwarriorTime(issue.DeferUntil),
+		Tags:        issue.Labels,
+		BdID:        issue.ID,
+		BdType:      string(issue.IssueType),
+	}
+	switch {
+	case issue.Priority <= 1:
+		task.Priority = "H"
+	case issue.Priority == 2:
+		task.Priority = "M"
+	default:
+		task.Priority = "L"
+	}
+	switch issue.Status {
+	case types.StatusClosed:
+		task.Status = "completed"
+		task.End = taskwarriorTime(issue.ClosedAt)
+		if task.End == "" {
+			task.End = task.Modified
+		}
+	case types.StatusInProgress:
+		task.Start = taskwarriorTime(issue.StartedAt)
+		if task.Start == "" {
+			task.Start = task.Modified
+		}
+	}
+	for _, dep := range issue.Dependencies {
+		if dep.Type == types.DepBlocks && exported[dep.DependsOnID] {
+			task.Depends = append(task.Depends, taskwarriorUUID(dep.DependsOnID))
+		}
+	}
+	if issue.Description != "" {
+		task.Annotations = []taskwarriorAnnotation{{Entry: task.Entry, Description: issue.Description}}
+	}
+	return task
+}
+
+func taskwarriorUUID(issueID string) string {
+	return uuid.NewSHA1(taskwarriorNamespace, []byte(issueID)).String()
+}
+
+// taskwarriorTime formats a time in TaskWarrior's ISO 8601 basic UTC form.
+func taskwarriorTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("20060102T150405Z")
+}
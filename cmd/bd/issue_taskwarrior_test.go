@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteIssuesTaskwarrior(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	closed := created.Add(24 * time.Hour)
+	issues := []*types.Issue{
+		{
+			ID: "bd-1", Title: "Ship it", Description: "details", Status: types.StatusInProgress,
+			Priority: 2, IssueType: types.TypeFeature, Labels: []string{"release"},
+			CreatedAt: created, UpdatedAt: created, StartedAt: &created,
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+				{IssueID: "bd-1", DependsOnID: "bd-missing", Type: types.DepBlocks},
+			},
+		},
+		{ID: "bd-2", Title: "Prep", Status: types.StatusClosed, Priority: 0, CreatedAt: created, UpdatedAt: closed, ClosedAt: &closed},
+	}
+
+	var buf bytes.Buffer
+	if err := writeIssuesTaskwarrior(&buf, issues); err != nil {
+		t.Fatalf("writeIssuesTaskwarrior: %v", err)
+	}
+	var tasks []taskwarriorTask
+	if err := json.Unmarshal(buf.Bytes(), &tasks); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, buf.String())
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+
+	ship, prep := tasks[0], tasks[1]
+	if ship.UUID != taskwarriorUUID("bd-1") || ship.UUID == prep.UUID {
+		t.Errorf("uuids: %s, %s", ship.UUID, prep.UUID)
+	}
+	if ship.Status != "pending" || ship.Start != "20250301T090000Z" || ship.Priority != "M" || ship.BdID != "bd-1" {
+		t.Errorf("ship = %+v", ship)
+	}
+	if len(ship.Depends) != 1 || ship.Depends[0] != prep.UUID {
+		t.Errorf("ship depends = %v, want only %s", ship.Depends, prep.UUID)
+	}
+	if len(ship.Annotations) != 1 || ship.Annotations[0].Description != "details" {
+		t.Errorf("ship annotations = %+v", ship.Annotations)
+	}
+	if prep.Status != "completed" || prep.End != "20250302T090000Z" || prep.Priority != "H" {
+		t.Errorf("prep = %+v", prep)
+	}
+}
@@ -196,8 +196,9 @@ var labelRemoveCmd = &cobra.Command{
 }
 var labelListCmd = &cobra.Command{
 	Use:           "list [issue-id]",
-	Short:         "List labels for an issue",
-	Args:          cobra.ExactArgs(1),
+	Short:         "List labels for an issue, or all label definitions",
+	Long:          "With an issue ID, list that issue's labels. Without one, list the labels defined with 'bd label create', with their colors and descriptions.",
+	Args:          cobra.MaximumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -208,6 +209,9 @@ var labelListCmd = &cobra.Command{
 			}
 		}()
 
+		if len(args) == 0 {
+			return runLabelDefinitionList()
+		}
 		if usesProxiedServer() {
 			return runLabelListProxiedServer(rootCtx, args)
 		}
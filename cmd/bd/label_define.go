@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// labelDefinitionStore returns the global store's label-definition
+// capability, or an error naming the command when the backend lacks it.
+func labelDefinitionStore(command string) (storage.LabelDefinitionStore, error) {
+	if usesProxiedServer() {
+		return nil, fmt.Errorf("label %s is not supported in proxied-server mode", command)
+	}
+	defStore, ok := storage.UnwrapStore(store).(storage.LabelDefinitionStore)
+	if !ok {
+		return nil, fmt.Errorf("label %s is not supported by this storage backend", command)
+	}
+	return defStore, nil
+}
+
+var labelCreateCmd = &cobra.Command{
+	Use:   "create <label>",
+	Short: "Define a label with a color and description",
+	Long: `Define a label with an optional color and description.
+
+Labels can be used on issues without being defined; a definition only adds
+metadata, shown by 'bd label list' and carried in JSONL exports. Colors are
+hex, #rgb or #rrggbb.
+
+Examples:
+  bd label create backend --color '#1d76db' --description "Server-side work"
+  bd label create backend --color '#0e8a16' --force   # Replace an existing definition`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("label create")
+		defStore, err := labelDefinitionStore("create")
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		name := strings.TrimSpace(args[0])
+		if name == "" {
+			return HandleErrorRespectJSON("label cannot be empty")
+		}
+		color, _ := cmd.Flags().GetString("color")
+		description, _ := cmd.Flags().GetString("description")
+		force, _ := cmd.Flags().GetBool("force")
+
+		ctx := rootCtx
+		if !force {
+			defs, err := defStore.GetLabelDefinitions(ctx)
+			if err != nil {
+				return HandleErrorRespectJSON("listing labels: %v", err)
+			}
+			for _, d := range defs {
+				if d.Name == name {
+					return HandleErrorRespectJSON("label '%s' is already defined (use --force to replace it)", name)
+				}
+			}
+		}
+
+		def := &types.LabelDefinition{Name: name, Color: color, Description: description}
+		if err := defStore.SetLabelDefinition(ctx, def); err != nil {
+			return HandleErrorRespectJSON("creating label '%s': %v", name, err)
+		}
+		if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+			Command:         "label create",
+			MessageOverride: fmt.Sprintf("bd: label create '%s'", name),
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"status":      "created",
+				"label":       name,
+				"color":       def.Color,
+				"description": def.Description,
+			})
+		}
+		fmt.Printf("%s Defined label '%s'\n", ui.RenderPass("✓"), name)
+		return nil
+	},
+}
+
+// runLabelDefinitionList implements 'bd label list' without an issue ID.
+func runLabelDefinitionList() error {
+	defStore, err := labelDefinitionStore("list")
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	defs, err := defStore.GetLabelDefinitions(rootCtx)
+	if err != nil {
+		return HandleErrorRespectJSON("listing labels: %v", err)
+	}
+	if jsonOutput {
+		if defs == nil {
+			defs = []*types.LabelDefinition{}
+		}
+		return outputJSON(defs)
+	}
+	if len(defs) == 0 {
+		fmt.Println("\nNo labels defined (see 'bd label create'; 'bd label list-all' lists labels in use)")
+		return nil
+	}
+	fmt.Printf("\n%s Defined labels (%d):\n", ui.RenderAccent("🏷"), len(defs))
+	maxLen := 0
+	for _, d := range defs {
+		maxLen = max(maxLen, len(d.Name))
+	}
+	for _, d := range defs {
+		padding := strings.Repeat(" ", maxLen-len(d.Name))
+		fmt.Printf("  %s%s  %-7s  %s\n", d.Name, padding, d.Color, ui.RenderMuted(d.Description))
+	}
+	fmt.Println()
+	return nil
+}
+
+var labelRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a label on every issue and wisp",
+	Long: `Rename a label everywhere it is used, along with its definition, in one
+transaction. Each relabeled issue records the usual label removed/added events.
+Renaming onto a label that already exists merges the two; the target's
+definition is kept.`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("label rename")
+		defStore, err := labelDefinitionStore("rename")
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		oldName, newName := args[0], strings.TrimSpace(args[1])
+		if strings.HasPrefix(newName, "provides:") {
+			return HandleErrorRespectJSON("'provides:' labels are reserved for cross-project capabilities. Hint: use 'bd ship %s' instead", strings.TrimPrefix(newName, "provides:"))
+		}
+
+		ctx := rootCtx
+		n, err := defStore.RenameLabel(ctx, oldName, newName, actor)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return HandleErrorRespectJSON("label '%s' is not defined or used", oldName)
+			}
+			return HandleErrorRespectJSON("renaming label '%s': %v", oldName, err)
+		}
+		if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+			Command:         "label rename",
+			MessageOverride: fmt.Sprintf("bd: label rename '%s' to '%s' on %d issue(s)", oldName, newName, n),
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"status": "renamed",
+				"label":  oldName,
+				"to":     newName,
+				"issues": n,
+			})
+		}
+		fmt.Printf("%s Renamed label '%s' to '%s' on %d issue(s)\n", ui.RenderPass("✓"), oldName, newName, n)
+		return nil
+	},
+}
+
+var labelDeleteCmd = &cobra.Command{
+	Use:   "delete <label>",
+	Short: "Remove a label from every issue and wisp",
+	Long: `Remove a label from every issue and wisp that carries it, and drop its
+definition, in one transaction. Without --force, shows how many issues would
+be affected.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("label delete")
+		defStore, err := labelDefinitionStore("delete")
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		name := args[0]
+		force, _ := cmd.Flags().GetBool("force")
+
+		ctx := rootCtx
+		if !force {
+			issues, err := store.GetIssuesByLabel(ctx, name)
+			if err != nil {
+				return HandleErrorRespectJSON("finding issues labeled '%s': %v", name, err)
+			}
+			if jsonOutput {
+				return outputJSON(map[string]interface{}{
+					"status": "preview",
+					"label":  name,
+					"issues": len(issues),
+				})
+			}
+			fmt.Printf("Label '%s' is on %d issue(s); deleting removes it from all of them and drops its definition.\n", name, len(issues))
+			fmt.Printf("To proceed, run: %s\n", ui.RenderWarn("bd label delete "+name+" --force"))
+			return nil
+		}
+
+		n, err := defStore.DeleteLabel(ctx, name, actor)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return HandleErrorRespectJSON("label '%s' is not defined or used", name)
+			}
+			return HandleErrorRespectJSON("deleting label '%s': %v", name, err)
+		}
+		if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+			Command:         "label delete",
+			MessageOverride: fmt.Sprintf("bd: label delete '%s' from %d issue(s)", name, n),
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"status": "deleted",
+				"label":  name,
+				"issues": n,
+			})
+		}
+		fmt.Printf("%s Deleted label '%s' from %d issue(s)\n", ui.RenderPass("✓"), name, n)
+		return nil
+	},
+}
+
+// labelDefinitionRecord is the JSONL form of a label definition. Like memory
+// records it carries a _type discriminator so import can tell it from issues.
+type labelDefinitionRecord struct {
+	RecordType string `json:"_type"`
+	*types.LabelDefinition
+}
+
+// writeLabelDefinitionRecords writes one "_type":"label" JSONL line per
+// definition and returns the number written.
+func writeLabelDefinitionRecords(w io.Writer, defs []*types.LabelDefinition) (int, error) {
+	for i, def := range defs {
+		data, err := json.Marshal(&labelDefinitionRecord{RecordType: "label", LabelDefinition: def})
+		if err != nil {
+			return i, fmt.Errorf("failed to marshal label %s: %w", def.Name, err)
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return i, fmt.Errorf("failed to write label %s: %w", def.Name, err)
+		}
+	}
+	return len(defs), nil
+}
+
+// getLabelDefinitionsForExport returns the label definitions to write to
+// JSONL, or nil when the backend has none.
+func getLabelDefinitionsForExport(ctx context.Context, st storage.DoltStorage) []*types.LabelDefinition {
+	defStore, ok := storage.UnwrapStore(st).(storage.LabelDefinitionStore)
+	if !ok {
+		return nil
+	}
+	defs, err := defStore.GetLabelDefinitions(ctx)
+	if err != nil {
+		return nil
+	}
+	return defs
+}
+
+// importLabelDefinitions upserts label definitions read from JSONL.
+func importLabelDefinitions(ctx context.Context, st storage.DoltStorage, defs []*types.LabelDefinition) error {
+	if len(defs) == 0 {
+		return nil
+	}
+	defStore, ok := storage.UnwrapStore(st).(storage.LabelDefinitionStore)
+	if !ok {
+		return nil
+	}
+	for _, def := range defs {
+		if err := defStore.SetLabelDefinition(ctx, def); err != nil {
+			return fmt.Errorf("importing label definition %s: %w", def.Name, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	labelCreateCmd.Flags().String("color", "", "Label color (#rgb or #rrggbb)")
+	labelCreateCmd.Flags().String("description", "", "Label description")
+	labelCreateCmd.Flags().Bool("force", false, "Replace an existing definition")
+	labelDeleteCmd.Flags().BoolP("force", "f", false, "Actually delete (without this flag, shows preview)")
+
+	labelCmd.AddCommand(labelCreateCmd)
+	labelCmd.AddCommand(labelRenameCmd)
+	labelCmd.AddCommand(labelDeleteCmd)
+}
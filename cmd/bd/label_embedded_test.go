@@ -460,3 +460,96 @@ func TestEmbeddedLabelConcurrent(t *testing.T) {
 		}
 	}
 }
+
+// TestEmbeddedLabelDefinitions covers bd label create/list/rename/delete and
+// the JSONL round trip of label definitions.
+func TestEmbeddedLabelDefinitions(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "ld")
+
+	listDefs := func(t *testing.T, dir string) map[string]map[string]interface{} {
+		t.Helper()
+		s := strings.TrimSpace(bdLabelJSONOutput(t, bd, dir, "list", "--json"))
+		var defs []map[string]interface{}
+		if err := json.Unmarshal([]byte(s[strings.Index(s, "["):]), &defs); err != nil {
+			t.Fatalf("parse label definitions: %v\n%s", err, s)
+		}
+		byName := make(map[string]map[string]interface{}, len(defs))
+		for _, d := range defs {
+			byName[d["name"].(string)] = d
+		}
+		return byName
+	}
+
+	a := bdCreate(t, bd, dir, "Labeled A", "--type", "task", "--labels", "backend")
+	b := bdCreate(t, bd, dir, "Labeled B", "--type", "task", "--labels", "backend,server")
+
+	bdLabel(t, bd, dir, "create", "backend", "--color", "1D76DB", "--description", "Server-side work")
+	if out := bdLabelFail(t, bd, dir, "create", "backend"); !strings.Contains(out, "already defined") {
+		t.Errorf("expected already-defined error: %s", out)
+	}
+	if out := bdLabelFail(t, bd, dir, "create", "ui", "--color", "blue"); !strings.Contains(out, "invalid label color") {
+		t.Errorf("expected invalid color error: %s", out)
+	}
+	defs := listDefs(t, dir)
+	if d := defs["backend"]; d == nil || d["color"] != "#1d76db" || d["description"] != "Server-side work" {
+		t.Fatalf("backend definition = %v", d)
+	}
+
+	// Rename onto "server", which B already carries: the two merge.
+	bdLabel(t, bd, dir, "rename", "backend", "server")
+	for _, id := range []string{a.ID, b.ID} {
+		if labels := bdLabelListJSON(t, bd, dir, id); len(labels) != 1 || labels[0] != "server" {
+			t.Errorf("%s labels after rename = %v, want [server]", id, labels)
+		}
+	}
+	defs = listDefs(t, dir)
+	if defs["backend"] != nil || defs["server"] == nil || defs["server"]["color"] != "#1d76db" {
+		t.Fatalf("definitions after rename = %v", defs)
+	}
+
+	// Export, then import into a fresh database.
+	exportFile := dir + "/labels.jsonl"
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "export", "-o", exportFile); err != nil {
+		t.Fatalf("export: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(exportFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"_type":"label"`) {
+		t.Fatalf("export lacks label records:\n%s", data)
+	}
+	other, _, _ := bdInit(t, bd, "--prefix", "ld")
+	if out, err := bdRunWithFlockRetry(t, bd, other, "import", exportFile); err != nil {
+		t.Fatalf("import: %v\n%s", err, out)
+	}
+	if d := listDefs(t, other)["server"]; d == nil || d["description"] != "Server-side work" {
+		t.Fatalf("imported server definition = %v", d)
+	}
+
+	// Delete previews without --force, then removes everywhere.
+	if out := bdLabel(t, bd, dir, "delete", "server"); !strings.Contains(out, "--force") {
+		t.Errorf("expected preview hint: %s", out)
+	}
+	if labels := bdLabelListJSON(t, bd, dir, a.ID); len(labels) != 1 {
+		t.Fatalf("preview changed labels: %v", labels)
+	}
+	bdLabel(t, bd, dir, "delete", "server", "--force")
+	for _, id := range []string{a.ID, b.ID} {
+		if labels := bdLabelListJSON(t, bd, dir, id); len(labels) != 0 {
+			t.Errorf("%s labels after delete = %v, want none", id, labels)
+		}
+	}
+	if defs := listDefs(t, dir); len(defs) != 0 {
+		t.Errorf("definitions after delete = %v", defs)
+	}
+	if out := bdLabelFail(t, bd, dir, "delete", "server", "--force"); !strings.Contains(out, "not defined or used") {
+		t.Errorf("expected not-found error: %s", out)
+	}
+}
@@ -111,6 +111,19 @@ func readyWorkFilterFromIssueFilter(filter types.IssueFilter) types.WorkFilter {
 	return wf
 }
 
+// readyListFilter is the ready-work filter bd list --ready runs: the list
+// filter, minus the issues blockers in other workspaces still hold up (the
+// same exclusion bd ready applies).
+func readyListFilter(ctx context.Context, r remoteDepReader, filter types.IssueFilter) (types.WorkFilter, error) {
+	wf := readyWorkFilterFromIssueFilter(filter)
+	held, err := remoteHeldIDs(ctx, r)
+	if err != nil {
+		return wf, err
+	}
+	wf.ExcludeIDs = append(wf.ExcludeIDs, held...)
+	return wf, nil
+}
+
 // getHierarchicalChildren handles the --tree --parent combination logic.
 // baseFilter carries CLI filters (--type, --status, etc.) through the recursive walk.
 func getHierarchicalChildren(ctx context.Context, store storage.DoltStorage, dbPath string, parentID string, baseFilter types.IssueFilter) ([]*types.Issue, error) {
@@ -192,7 +205,11 @@ type watchListDependencyStore interface {
 
 func loadWatchedIssues(ctx context.Context, store storage.DoltStorage, filter types.IssueFilter, ready bool, parentID string, sortBy string, reverse bool) ([]*types.Issue, error) {
 	if ready {
-		issues, err := store.GetReadyWork(ctx, readyWorkFilterFromIssueFilter(withFetchOneExtra(filter)))
+		wf, err := readyListFilter(ctx, storeRemoteDeps{store}, withFetchOneExtra(filter))
+		if err != nil {
+			return nil, err
+		}
+		issues, err := store.GetReadyWork(ctx, wf)
 		if err != nil {
 			return nil, err
 		}
@@ -326,6 +343,20 @@ func compareIssuesBy(a, b *types.Issue, sortBy string) int {
 	case "assignee":
 		return cmp.Compare(a.Assignee, b.Assignee)
 	}
+	if key, ok := strings.CutPrefix(sortBy, "metadata."); ok {
+		// Issues without the field sort last, like unclosed issues under "closed".
+		av, aOK := storage.LookupMetadataValue(a.Metadata, key)
+		bv, bOK := storage.LookupMetadataValue(b.Metadata, key)
+		switch {
+		case !aOK && !bOK:
+			return 0
+		case !aOK:
+			return 1
+		case !bOK:
+			return -1
+		}
+		return storage.CompareMetadataValues(av, bv)
+	}
 	return 0
 }
 
@@ -573,7 +604,10 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 		var iwc []*types.IssueWithCounts
 		var err error
 		if in.readyFlag {
-			iwc, err = activeStore.GetReadyWorkWithCounts(ctx, readyWorkFilterFromIssueFilter(withFetchOneExtra(filter)))
+			var wf types.WorkFilter
+			if wf, err = readyListFilter(ctx, storeRemoteDeps{activeStore}, withFetchOneExtra(filter)); err == nil {
+				iwc, err = activeStore.GetReadyWorkWithCounts(ctx, wf)
+			}
 		} else {
 			iwc, err = activeStore.SearchIssuesWithCounts(ctx, "", withFetchOneExtra(filter))
 		}
@@ -607,9 +641,10 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 
 	var issues []*types.Issue
 	if in.readyFlag {
-		wf := readyWorkFilterFromIssueFilter(withFetchOneExtra(filter))
-		var err error
-		issues, err = activeStore.GetReadyWork(ctx, wf)
+		wf, err := readyListFilter(ctx, storeRemoteDeps{activeStore}, withFetchOneExtra(filter))
+		if err == nil {
+			issues, err = activeStore.GetReadyWork(ctx, wf)
+		}
 		if err != nil {
 			if capErr := handleMaxRowsError(err); capErr != nil {
 				return capErr
@@ -723,7 +758,8 @@ func init() {
 	listCmd.Flags().String("state", "", "Alias for --status")
 	_ = listCmd.Flags().MarkHidden("state")
 	registerPriorityFlag(listCmd, "")
-	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee or co-assignee (\"me\" for yourself)")
+	listCmd.Flags().String("team", "", "Filter by owning team")
 	listCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore, decision, merge-request, molecule, gate, convoy). Aliases: mr→merge-request, feat→feature, mol→molecule, dec/adr→decision")
 	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
@@ -738,7 +774,7 @@ func init() {
 	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
 	listCmd.Flags().Bool("all", false, "Show all issues including closed (overrides default filter)")
 	listCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee, metadata.<key>")
 	listCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 
 	// Pattern matching
@@ -148,9 +148,13 @@ func buildListFilter(in listInput, cfg listFilterConfig) (types.IssueFilter, err
 		filter.Priority = &p
 	}
 	if in.assignee != "" {
-		a := in.assignee
+		a := resolveAssignee(in.assignee)
 		filter.Assignee = &a
 	}
+	if in.team != "" {
+		team := in.team
+		filter.Team = &team
+	}
 	if in.issueType != "" {
 		t := types.IssueType(in.issueType)
 		if !t.IsValidWithCustom(cfg.customTypes) {
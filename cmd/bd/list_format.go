@@ -65,10 +65,28 @@ func formatPrettyIssue(issue *types.Issue) string {
 			ui.RenderMuted(issue.ID),
 			ui.RenderMuted(fmt.Sprintf("● P%d", issue.Priority)),
 			ui.RenderMuted(string(issue.IssueType)),
-			ui.RenderMuted(" "+issue.Title))
+			ui.RenderMuted(" "+issue.Title+checklistBadge(issue)))
 	}
 
-	return fmt.Sprintf("%s %s %s %s%s", statusIcon, issue.ID, priorityTag, typeBadge, issue.Title)
+	return fmt.Sprintf("%s %s %s %s%s%s", statusIcon, issue.ID, priorityTag, typeBadge, issue.Title, renderChecklistBadge(issue))
+}
+
+// checklistBadge returns " [done/total]" for an issue whose description has
+// a checklist, or "".
+func checklistBadge(issue *types.Issue) string {
+	p := types.ChecklistProgressOf(issue.Description)
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprintf(" [%d/%d]", p.Done, p.Total)
+}
+
+// renderChecklistBadge is checklistBadge, muted.
+func renderChecklistBadge(issue *types.Issue) string {
+	if badge := checklistBadge(issue); badge != "" {
+		return ui.RenderMuted(badge)
+	}
+	return ""
 }
 
 // formatPrettyIssueWithContext formats an issue with optional parent epic annotation
@@ -109,6 +127,9 @@ func formatIssueLong(buf *strings.Builder, issue *types.Issue, labels []string,
 			buf.WriteString(fmt.Sprintf("    %s\n", line))
 		}
 	}
+	if progress := types.ChecklistProgressOf(issue.Description); progress != nil {
+		buf.WriteString(fmt.Sprintf("  Checklist: %s\n", formatChecklistCount(progress)))
+	}
 	if labelsSkipped {
 		buf.WriteString("  Labels: (suppressed by --skip-labels)\n")
 	} else if len(labels) > 0 {
@@ -254,18 +275,18 @@ func formatIssueCompact(buf *strings.Builder, issue *types.Issue, labels []strin
 		// Closed issues: entire line muted (fades visually)
 		line := fmt.Sprintf("%s %s%s [P%d] [%s]%s%s - %s%s",
 			statusIcon, pinIndicator(issue), issue.ID, issue.Priority,
-			issue.IssueType, assigneeStr, labelsStr, issue.Title, depInfo)
+			issue.IssueType, assigneeStr, labelsStr, issue.Title+checklistBadge(issue), depInfo)
 		buf.WriteString(ui.RenderClosedLine(line))
 		buf.WriteString("\n")
 	} else {
 		// Active issues: status icon + semantic colors for priority/type
-		buf.WriteString(fmt.Sprintf("%s %s%s [%s] [%s]%s%s - %s%s\n",
+		buf.WriteString(fmt.Sprintf("%s %s%s [%s] [%s]%s%s - %s%s%s\n",
 			statusIcon,
 			pinIndicator(issue),
 			ui.RenderID(issue.ID),
 			ui.RenderPriority(issue.Priority),
 			ui.RenderType(string(issue.IssueType)),
-			assigneeStr, labelsStr, issue.Title, depInfo))
+			assigneeStr, labelsStr, issue.Title, renderChecklistBadge(issue), depInfo))
 	}
 }
 
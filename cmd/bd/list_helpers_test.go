@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -281,6 +282,23 @@ func TestListSortIssues_ClosedNilLast(t *testing.T) {
 	}
 }
 
+func TestListSortIssues_MetadataTypedMissingLast(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Metadata: json.RawMessage(`{"points":10}`)},
+		{ID: "bd-2"},
+		{ID: "bd-3", Metadata: json.RawMessage(`{"points":9}`)},
+		{ID: "bd-4", Metadata: json.RawMessage(`{"points":"2"}`)},
+	}
+	sortIssues(issues, "metadata.points", false)
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.ID)
+	}
+	if strings.Join(got, ",") != "bd-4,bd-3,bd-1,bd-2" {
+		t.Fatalf("unexpected order: %v", got)
+	}
+}
+
 func TestListDisplayPrettyList(t *testing.T) {
 	out := captureStdout(t, func() error {
 		displayPrettyList(nil, false)
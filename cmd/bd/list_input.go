@@ -19,6 +19,7 @@ type listInput struct {
 	status      string
 	issueType   string
 	assignee    string
+	team        string
 	titleSearch string
 	specPrefix  string
 	idFilter    string
@@ -107,6 +108,7 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 	}
 
 	in.assignee, _ = cmd.Flags().GetString("assignee")
+	in.team, _ = cmd.Flags().GetString("team")
 	rawType, _ := cmd.Flags().GetString("type")
 	in.issueType = utils.NormalizeIssueType(rawType)
 
@@ -295,8 +297,12 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 			"priority": true, "created": true, "updated": true, "closed": true,
 			"status": true, "id": true, "title": true, "type": true, "assignee": true,
 		}
-		if !validSortFields[in.sortBy] {
-			return in, HandleError("invalid sort field %q (valid: priority, created, updated, closed, status, id, title, type, assignee)", in.sortBy)
+		if key, ok := strings.CutPrefix(in.sortBy, "metadata."); ok {
+			if err := storage.ValidateMetadataKey(key); err != nil {
+				return in, HandleError("invalid sort field %q: %v", in.sortBy, err)
+			}
+		} else if !validSortFields[in.sortBy] {
+			return in, HandleError("invalid sort field %q (valid: priority, created, updated, closed, status, id, title, type, assignee, metadata.<key>)", in.sortBy)
 		}
 	}
 
@@ -325,10 +331,11 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 	}
 	in.sqlLimit = in.effectiveLimit
 	// --sort id requires natural-numeric comparison (bd-9 < bd-10) that
-	// SQL can't express without a schema-side sort column. Fall back to
-	// fetching everything and sorting client-side. Other sorts (including
-	// title via LOWER()) are pushed into SQL ORDER BY.
-	if in.sortBy == "id" {
+	// SQL can't express without a schema-side sort column, and metadata.<key>
+	// compares typed JSON values. Fall back to fetching everything and
+	// sorting client-side. Other sorts (including title via LOWER()) are
+	// pushed into SQL ORDER BY.
+	if isGoSideSort(in.sortBy) {
 		in.sqlLimit = 0
 	}
 
@@ -338,11 +345,11 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 			return in, HandleError("--offset must be >= 0")
 		}
 		// --offset only makes sense when pagination happens in SQL. Sorts
-		// that fall back to Go-side (currently --sort id) fetch everything
+		// that fall back to Go-side (--sort id, metadata.<key>) fetch everything
 		// regardless, so combining them with --offset is misleading — the
 		// caller would think they're paging when they're really pulling
 		// the whole result set.
-		if offset > 0 && in.sqlLimit == 0 && in.sortBy == "id" {
+		if offset > 0 && in.sqlLimit == 0 && isGoSideSort(in.sortBy) {
 			return in, HandleError("--offset is not supported with --sort %s (sort requires fetching the full result set)", in.sortBy)
 		}
 		in.offset = offset
@@ -354,6 +361,12 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 	return in, nil
 }
 
+// isGoSideSort mirrors sqlbuild.IsGoSideSort: sort keys applied by
+// sortIssues after an unlimited query instead of in SQL.
+func isGoSideSort(sortBy string) bool {
+	return sortBy == "id" || strings.HasPrefix(sortBy, "metadata.")
+}
+
 func parseListTimeFlag(cmd *cobra.Command, name string) (*time.Time, error) {
 	s, _ := cmd.Flags().GetString(name)
 	if s == "" {
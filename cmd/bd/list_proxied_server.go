@@ -142,7 +142,10 @@ func runListProxiedReady(_ *cobra.Command, ctx context.Context, in listInput) er
 	}
 	defer uw.Close(ctx)
 
-	wf := readyWorkFilterFromIssueFilter(filter)
+	wf, err := readyListFilter(ctx, proxiedRemoteDeps{uw}, filter)
+	if err != nil {
+		return err
+	}
 
 	if jsonOutput {
 		page, err := uw.IssueUseCase().GetReadyWorkWithCounts(ctx, wf)
@@ -184,7 +187,10 @@ func runListProxiedWatch(_ *cobra.Command, ctx context.Context, in listInput) er
 		var hasMore bool
 		switch {
 		case in.readyFlag:
-			wf := readyWorkFilterFromIssueFilter(filter)
+			wf, perr := readyListFilter(ctx, proxiedRemoteDeps{uw}, filter)
+			if perr != nil {
+				return nil, false, nil, perr
+			}
 			page, perr := uw.IssueUseCase().GetReadyWork(ctx, wf)
 			if perr != nil {
 				return nil, false, nil, perr
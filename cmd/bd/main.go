@@ -185,13 +185,13 @@ func isForcedMigrate(cmd *cobra.Command) bool {
 }
 
 // forcedMigratePreviewFlag returns the name of a preview flag (--dry-run,
-// --inspect) that conflicts with --force on a forced migrate invocation, or ""
-// when there is no conflict. The combination must be rejected BEFORE the store
-// opens: with the gate override set, the open itself applies pending schema
-// migrations, so the preview flag would be honored only after the destructive
-// work it exists to prevent had already happened.
+// --inspect, --status) that conflicts with --force on a forced migrate
+// invocation, or "" when there is no conflict. The combination must be
+// rejected BEFORE the store opens: with the gate override set, the open itself
+// applies pending schema migrations, so the preview flag would be honored only
+// after the destructive work it exists to prevent had already happened.
 func forcedMigratePreviewFlag(cmd *cobra.Command) string {
-	for _, name := range []string{"dry-run", "inspect"} {
+	for _, name := range []string{"dry-run", "inspect", "status"} {
 		if v, err := cmd.Flags().GetBool(name); err == nil && v {
 			return name
 		}
@@ -495,7 +495,8 @@ func refreshBoundCommandConfig(cmd *cobra.Command) {
 	if root == nil {
 		root = cmd
 	}
-	if !root.PersistentFlags().Changed("json") && !root.PersistentFlags().Changed("format") {
+	// cmd.Flags() also covers subcommands that bind jsonOutput to a local --json.
+	if !cmd.Flags().Changed("json") && !root.PersistentFlags().Changed("format") {
 		jsonOutput = config.GetBool("json")
 	}
 	if !root.PersistentFlags().Changed("readonly") {
@@ -594,6 +595,26 @@ func getOwner() string {
 	return ""
 }
 
+// resolveMe returns who "me" means in assignee filters and 'bd assign'.
+// Priority: identity config (BEADS_IDENTITY) > owner (git user.email) > actor
+func resolveMe() string {
+	if identity := config.GetString("identity"); identity != "" {
+		return identity
+	}
+	if owner := getOwner(); owner != "" {
+		return owner
+	}
+	return getActorWithGit()
+}
+
+// resolveAssignee maps "me" (any case) to resolveMe and leaves other names alone.
+func resolveAssignee(name string) string {
+	if strings.EqualFold(name, "me") {
+		return resolveMe()
+	}
+	return name
+}
+
 func init() {
 	// Initialize viper configuration
 	if err := config.Initialize(); err != nil {
@@ -610,7 +631,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&sandboxMode, "sandbox", false, "Sandbox mode: disables Dolt auto-push")
 	rootCmd.PersistentFlags().BoolVar(&readonlyMode, "readonly", false, "Read-only mode: block write operations (for worker sandboxes)")
 	rootCmd.PersistentFlags().BoolVar(&globalFlag, "global", false, "Use the global shared-server database (beads_global)")
-	rootCmd.PersistentFlags().StringVar(&doltAutoCommit, "dolt-auto-commit", "", "Dolt auto-commit policy (off|on|batch). 'on': commit after each write. 'batch': defer commits to bd dolt commit; uncommitted changes persist in the working set until then. SIGTERM/SIGHUP flush pending batch commits. Default: off. Override via config key dolt.auto-commit")
+	rootCmd.PersistentFlags().StringVar(&doltAutoCommit, "dolt-auto-commit", "", "Dolt auto-commit policy (off|on|batch). 'on': commit after each write. 'batch': defer commits to bd dolt commit (or, with config key dolt.auto-commit-interval, to the first write after that interval); uncommitted changes persist in the working set until then. SIGTERM/SIGHUP flush pending batch commits. Default: off. Override via config key dolt.auto-commit")
 	rootCmd.PersistentFlags().BoolVar(&profileEnabled, "profile", false, "Generate CPU profile for performance analysis")
 	rootCmd.PersistentFlags().StringVar(&memProfilePath, "mem-profile", "", "Write heap profile to FILE on exit (also respects BEADS_MEM_PROFILE)")
 	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose/debug output")
@@ -793,7 +814,7 @@ var rootCmd = &cobra.Command{
 			}
 		}
 		// If flag wasn't explicitly set, use viper value
-		if !cmd.Root().PersistentFlags().Changed("json") && !cmd.Root().PersistentFlags().Changed("format") {
+		if !cmd.Flags().Changed("json") && !cmd.Root().PersistentFlags().Changed("format") {
 			jsonOutput = config.GetBool("json")
 		} else {
 			flagOverrides["json"] = struct {
@@ -871,7 +892,8 @@ var rootCmd = &cobra.Command{
 			"human",
 			"init",
 			"merge",
-			"metrics", // config-only: status/on/off/example never touch the DB
+			"merge-file", // git merge driver: operates on the files git hands it, never the DB
+			"metrics",    // config-only: status/on/off/example never touch the DB
 			"onboard",
 			"powershell",
 			"prime",
@@ -1104,8 +1126,9 @@ var rootCmd = &cobra.Command{
 
 		// Check if this is a read-only command (GH#804)
 		// Read-only commands open the store in read-only mode to avoid modifying
-		// the database (which breaks file watchers).
-		useReadOnly := isReadOnlyCommand(cmd.Name())
+		// the database (which breaks file watchers). --readonly opens every
+		// command's store that way.
+		useReadOnly := isReadOnlyCommand(cmd.Name()) || isSchemaPreview(cmd) || readonlyMode
 
 		// If the operator passed --force on `bd migrate` or `bd migrate schema`,
 		// set the programmatic gate override before both autoMigrateOnVersionBump
@@ -1139,6 +1162,9 @@ var rootCmd = &cobra.Command{
 			ReadOnly:    useReadOnly,
 			BeadsDir:    beadsDir,
 			LenientOpen: isWorkingSetReconcileCommand(cmd),
+			// In server mode --readonly also begins every transaction READ
+			// ONLY, so the server rejects writes the CLI guard misses.
+			ReadOnlyTransactions: readonlyMode,
 		}
 
 		// Load config to get database name and server connection settings.
@@ -1350,6 +1376,7 @@ var rootCmd = &cobra.Command{
 		if dbPath != "" {
 			beadsDir := filepath.Dir(dbPath)
 			hookRunner = hooks.NewRunner(filepath.Join(beadsDir, "hooks"))
+			registerNotifyListener(hookRunner, beadsDir)
 		}
 
 		// Compose the storage decorator chain: OTel instrumentation (no-op
@@ -1445,6 +1472,13 @@ var rootCmd = &cobra.Command{
 				maybeAutoPush(rootCtx)
 			}
 
+			// Webhook notifications: run the periodic blocked/ready scan when
+			// due and deliver queued events. Read-only commands skip this so
+			// bd list/show never wait on the network.
+			if !isReadOnlyCommand(cmd.Name()) {
+				maybeNotify(rootCtx)
+			}
+
 			// Signal that store is closing (prevents background flush from accessing closed store)
 			storeMutex.Lock()
 			storeActive = false
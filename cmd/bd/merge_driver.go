@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/git"
+)
+
+// jsonlMergeDriverName is the git merge driver name bd registers for the JSONL
+// export (merge.<name>.driver in .git/config, merge=<name> in .gitattributes).
+const jsonlMergeDriverName = "beads"
+
+// jsonlMergeDriverCommand is the driver command line git runs: %O is the
+// ancestor, %A ours (the result is written back over it), %B theirs.
+const jsonlMergeDriverCommand = "bd merge-file %O %A %B"
+
+// exportJSONLRelPath returns the configured export path relative to .beads/.
+func exportJSONLRelPath() string {
+	exportPath := config.GetString("export.path")
+	if exportPath == "" {
+		exportPath = "issues.jsonl"
+	}
+	return exportPath
+}
+
+// ensureJSONLMergeDriver registers `bd merge-file` as the merge driver for the
+// export file when merge.auto_resolve is enabled: the driver definition goes
+// into the clone's git config (it is not versioned), and the path→driver
+// mapping into the repo's .gitattributes so every clone that also registers
+// the driver picks it up. Both steps are idempotent.
+func ensureJSONLMergeDriver(beadsDir string) error {
+	if !config.GetBool("merge.auto_resolve") || config.GetBool("no-git-ops") {
+		return nil
+	}
+	repoRoot, _ := git.GetMainRepoRoot()
+	if repoRoot == "" {
+		repoRoot = git.GetRepoRoot()
+	}
+	if repoRoot == "" {
+		return nil // not a git repo: nothing to merge
+	}
+
+	for _, kv := range [][2]string{
+		{"merge." + jsonlMergeDriverName + ".name", "bd JSONL merge"},
+		{"merge." + jsonlMergeDriverName + ".driver", jsonlMergeDriverCommand},
+	} {
+		cmd := exec.Command("git", "config", kv[0], kv[1])
+		cmd.Dir = repoRoot
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git config %s failed: %w (output: %s)", kv[0], err, string(output))
+		}
+	}
+
+	exportPath := filepath.Join(beadsDir, exportJSONLRelPath())
+	rel, err := filepath.Rel(repoRoot, exportPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil // export lives outside this repo (e.g. BEADS_DIR elsewhere)
+	}
+	return ensureGitAttributesLine(filepath.Join(repoRoot, ".gitattributes"),
+		filepath.ToSlash(rel)+" merge="+jsonlMergeDriverName)
+}
+
+// ensureGitAttributesLine appends line to the .gitattributes file at path
+// unless an identical line is already present.
+func ensureGitAttributesLine(path, line string) error {
+	existing, err := os.ReadFile(path) // #nosec G304 - path is <repo>/.gitattributes
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitattributes: %w", err)
+	}
+	for _, l := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(l) == line {
+			return nil
+		}
+	}
+	content := string(existing)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += line + "\n"
+	// #nosec G306 -- .gitattributes is a tracked, world-readable repo file
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write .gitattributes: %w", err)
+	}
+	return nil
+}
+
+// fileHasConflictMarkers reports whether the file at path contains git
+// conflict marker lines. A missing file has none.
+func fileHasConflictMarkers(path string) (bool, error) {
+	f, err := os.Open(path) // #nosec G304 - path constructed from beadsDir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if hasConflictMarkerPrefix(scanner.Text()) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// handleJSONLConflictMarkers is called by the post-merge and post-checkout
+// hooks before the legacy JSONL import. It returns true when the export still
+// has conflict markers (committed by hand or brought in by the merge), in which
+// case the import must be skipped.
+//
+// With merge.auto_resolve enabled and a Dolt remote configured, the export is
+// a derived artifact of the database, so it is simply regenerated from Dolt.
+// Otherwise the JSONL may carry the only copy of the other branch's edits and
+// bd prints repair guidance instead of touching it.
+func handleJSONLConflictMarkers(reason string) bool {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return false
+	}
+	fullPath := syncImportJSONLPath(beadsDir)
+	conflicted, err := fileHasConflictMarkers(fullPath)
+	if err != nil || !conflicted {
+		return false
+	}
+	rel, err := filepath.Rel(exportSubprocessDir(beadsDir), fullPath)
+	if err != nil {
+		rel = fullPath
+	}
+	fmt.Fprintf(os.Stderr, "beads: %s: %s contains unresolved merge conflict markers; skipping JSONL import.\n", reason, rel)
+
+	if config.GetBool("merge.auto_resolve") && resolveSyncRemote() != "" {
+		cmd := exec.Command("bd", "export", "-o", fullPath)
+		cmd.Dir = exportSubprocessDir(beadsDir)
+		cmd.Env = filterEnv(os.Environ(), "BD_GIT_HOOK")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			fmt.Fprintf(os.Stderr, "beads: %s: regenerating %s failed: %v\n%s", reason, rel, err, out)
+		} else {
+			fmt.Fprintf(os.Stderr, "beads: %s: regenerated %s from the Dolt database (run 'bd dolt pull' first if the merged branch's issue changes are not pulled yet).\n", reason, rel)
+			return true
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "beads: repair: resolve the markers by hand and run 'bd import %s',\n", rel)
+	fmt.Fprintf(os.Stderr, "beads:         or regenerate it from the database with 'bd export -o %s'.\n", rel)
+	if !config.GetBool("merge.auto_resolve") {
+		fmt.Fprintln(os.Stderr, "beads: to merge the export by issue ID at merge time: bd config set merge.auto_resolve true && bd hooks install")
+	}
+	return true
+}
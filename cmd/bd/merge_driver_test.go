@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnsureGitAttributesLine_Idempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitattributes")
+	if err := os.WriteFile(path, []byte("*.png binary"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	line := ".beads/issues.jsonl merge=beads"
+	for i := 0; i < 2; i++ {
+		if err := ensureGitAttributesLine(path, line); err != nil {
+			t.Fatalf("ensureGitAttributesLine: %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "*.png binary\n" + line + "\n"
+	if string(data) != want {
+		t.Errorf(".gitattributes = %q, want %q", data, want)
+	}
+}
+
+func TestFileHasConflictMarkers(t *testing.T) {
+	dir := t.TempDir()
+	clean := filepath.Join(dir, "clean.jsonl")
+	conflicted := filepath.Join(dir, "conflicted.jsonl")
+	if err := os.WriteFile(clean, []byte(`{"id":"bd-1","title":"a <<<<<<< b"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	body := strings.Join([]string{"<<<<<<< HEAD", `{"id":"bd-1"}`, "=======", `{"id":"bd-2"}`, ">>>>>>> main", ""}, "\n")
+	if err := os.WriteFile(conflicted, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]bool{
+		clean:                               false,
+		conflicted:                          true,
+		filepath.Join(dir, "missing.jsonl"): false,
+	} {
+		got, err := fileHasConflictMarkers(path)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if got != want {
+			t.Errorf("fileHasConflictMarkers(%s) = %v, want %v", filepath.Base(path), got, want)
+		}
+	}
+}
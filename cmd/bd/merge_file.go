@@ -0,0 +1,580 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+)
+
+var mergeFileCmd = &cobra.Command{
+	Use:   "merge-file <base> <ours> <theirs> [output]",
+	Short: "Three-way merge of JSONL export files (git merge driver)",
+	Long: `Perform a semantic three-way merge of two JSONL export files against
+their common ancestor.
+
+Records are matched by issue ID (memory records by key) instead of by line,
+so two branches that each touched different issues never conflict. When the
+same issue changed on both sides, fields are merged individually: a field
+changed on only one side takes that side's value, labels/dependencies/
+comments are merged as sets, and updated_at takes the later timestamp. An
+issue deleted on one side and modified on the other is kept. The header
+record of a v2 export (--schema-version 2, one file per --shard) is merged
+the same way and written first, with issues kept in ID order.
+
+A field changed differently on both sides is resolved by --strategy
+(default: the merge.strategy config key, else union):
+  union   Take the side with the newer updated_at (ours on a tie)
+  ours    Always take our side (the branch being merged into)
+  theirs  Always take their side (the branch being merged in)
+
+The merged result is written to [output], or over <ours> when omitted — the
+contract git expects from a merge driver. Register it once per clone:
+
+  git config merge.beads.name "bd JSONL merge"
+  git config merge.beads.driver "bd merge-file %O %A %B"
+
+and route the export file to it in .gitattributes:
+
+  .beads/issues.jsonl merge=beads
+
+Exits nonzero without writing when an input is not a JSONL export (for
+example, it already contains conflict markers), so git falls back to
+reporting a normal conflict.
+
+EXAMPLES:
+  bd merge-file base.jsonl ours.jsonl theirs.jsonl            # Merge into ours.jsonl
+  bd merge-file base.jsonl ours.jsonl theirs.jsonl out.jsonl  # Merge into out.jsonl
+  bd merge-file --strategy theirs base.jsonl ours.jsonl theirs.jsonl
+  bd merge-file --json base.jsonl ours.jsonl theirs.jsonl     # Print merge stats as JSON`,
+	GroupID:       "sync",
+	Args:          cobra.RangeArgs(3, 4),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runMergeFile,
+}
+
+var mergeFileStrategy string
+
+// Field collision policies for bd merge-file.
+const (
+	mergeStrategyUnion  = "union"
+	mergeStrategyOurs   = "ours"
+	mergeStrategyTheirs = "theirs"
+)
+
+func init() {
+	mergeFileCmd.Flags().StringVar(&mergeFileStrategy, "strategy", "", "Field collision policy: union, ours, theirs (default: merge.strategy config, else union)")
+	rootCmd.AddCommand(mergeFileCmd)
+}
+
+// resolveMergeFileStrategy returns the collision policy from the flag, then
+// the merge.strategy config key, then the union default.
+func resolveMergeFileStrategy(flagValue string) (string, error) {
+	strategy := strings.ToLower(strings.TrimSpace(flagValue))
+	if strategy == "" {
+		strategy = strings.ToLower(strings.TrimSpace(config.GetString("merge.strategy")))
+	}
+	switch strategy {
+	case "":
+		return mergeStrategyUnion, nil
+	case mergeStrategyUnion, mergeStrategyOurs, mergeStrategyTheirs:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid merge strategy %q (valid: union, ours, theirs)", strategy)
+	}
+}
+
+func runMergeFile(_ *cobra.Command, args []string) error {
+	evt := metrics.NewCommandEvent("merge-file")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	strategy, err := resolveMergeFileStrategy(mergeFileStrategy)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	basePath, oursPath, theirsPath := args[0], args[1], args[2]
+	outPath := oursPath
+	if len(args) == 4 {
+		outPath = args[3]
+	}
+
+	base, err := readJSONLRecordsFile(basePath)
+	if err != nil {
+		return HandleErrorRespectJSON("base: %v", err)
+	}
+	ours, err := readJSONLRecordsFile(oursPath)
+	if err != nil {
+		return HandleErrorRespectJSON("ours: %v", err)
+	}
+	theirs, err := readJSONLRecordsFile(theirsPath)
+	if err != nil {
+		return HandleErrorRespectJSON("theirs: %v", err)
+	}
+
+	merged, stats := mergeJSONLRecords(base, ours, theirs, strategy)
+
+	aw, err := atomicfile.Create(outPath, 0o644)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to create output file: %v", err)
+	}
+	defer func() { _ = aw.Abort() }()
+	if err := writeJSONLRecords(aw, merged); err != nil {
+		return HandleErrorRespectJSON("failed to write merged file: %v", err)
+	}
+	if err := aw.Close(); err != nil {
+		return HandleErrorRespectJSON("failed to finalize merged file: %v", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(stats)
+	}
+	if !quietFlag && (stats.FieldMerged > 0 || stats.Collisions > 0) {
+		fmt.Fprintf(os.Stderr, "bd merge-file: %d issue(s) merged field-by-field, %d field collision(s) resolved (%s)\n",
+			stats.FieldMerged, stats.Collisions, strategy)
+	}
+	return nil
+}
+
+// jsonlField is one key/value pair of a JSONL record, kept in source order so
+// merged records serialize with the same key order bd export produced.
+type jsonlField struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// jsonlRecord is one parsed line of a JSONL export file.
+type jsonlRecord struct {
+	Key    string // "issue:<id>", "label:<name>", "memory:<key>" or jsonlHeaderKey
+	Fields []jsonlField
+	Raw    []byte // original line, re-emitted verbatim when the record is unchanged
+}
+
+func (r *jsonlRecord) get(key string) (json.RawMessage, bool) {
+	for _, f := range r.Fields {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// jsonlHeaderKey keys the {"_schema":...} header record of a v2 export. A
+// file has at most one; merging it like any other record keeps the header
+// when both sides wrote one and lets a side that upgraded the format win.
+const jsonlHeaderKey = "header:_schema"
+
+func (r *jsonlRecord) isMemory() bool {
+	return strings.HasPrefix(r.Key, "memory:")
+}
+
+// jsonlMergeStats summarizes a three-way merge for `bd merge-file --json`.
+type jsonlMergeStats struct {
+	Strategy    string `json:"strategy"`
+	Records     int    `json:"records"`
+	Added       int    `json:"added"`
+	Deleted     int    `json:"deleted"`
+	FieldMerged int    `json:"field_merged"`
+	Collisions  int    `json:"collisions"`
+}
+
+// jsonlSetFields are array-valued issue fields merged as sets (element
+// identity is the compact JSON encoding) rather than as opaque values, so
+// labels or comments added on both branches all survive.
+var jsonlSetFields = map[string]bool{
+	"labels":       true,
+	"dependencies": true,
+	"comments":     true,
+}
+
+func readJSONLRecordsFile(path string) ([]*jsonlRecord, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path supplied by git or the user
+	if err != nil {
+		return nil, err
+	}
+	records, err := parseJSONLRecords(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return records, nil
+}
+
+// parseJSONLRecords parses an export file into keyed records. Lines that are
+// not JSON objects (including git conflict markers) are an error: merging
+// around them would silently drop data.
+func parseJSONLRecords(data []byte) ([]*jsonlRecord, error) {
+	var records []*jsonlRecord
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimRight(line, "\r")
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if hasConflictMarkerPrefix(string(line)) {
+			return nil, fmt.Errorf("line %d: unresolved git conflict marker", i+1)
+		}
+		fields, err := decodeOrderedObject(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		rec := &jsonlRecord{Fields: fields, Raw: append([]byte(nil), line...)}
+		if _, isHeader := rec.get("_schema"); isHeader {
+			rec.Key = jsonlHeaderKey
+			records = append(records, rec)
+			continue
+		}
+		var recType, id string
+		if v, ok := rec.get("_type"); ok {
+			_ = json.Unmarshal(v, &recType)
+		}
+		switch recType {
+		case "memory":
+			v, _ := rec.get("key")
+			_ = json.Unmarshal(v, &id)
+			rec.Key = "memory:" + id
+		case "label":
+			v, _ := rec.get("name")
+			_ = json.Unmarshal(v, &id)
+			rec.Key = "label:" + id
+		default:
+			v, _ := rec.get("id")
+			_ = json.Unmarshal(v, &id)
+			rec.Key = "issue:" + id
+		}
+		if id == "" {
+			return nil, fmt.Errorf("line %d: record has no id", i+1)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// hasConflictMarkerPrefix reports whether a line is a git conflict marker.
+func hasConflictMarkerPrefix(line string) bool {
+	return strings.HasPrefix(line, "<<<<<<<") ||
+		strings.HasPrefix(line, "=======") ||
+		strings.HasPrefix(line, ">>>>>>>") ||
+		strings.HasPrefix(line, "|||||||")
+}
+
+func decodeOrderedObject(data []byte) ([]jsonlField, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	var fields []jsonlField
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an object key")
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, jsonlField{Key: key, Value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("trailing data after JSON object")
+	}
+	return fields, nil
+}
+
+// mergeJSONLRecords three-way merges export records keyed by ID, resolving
+// field collisions with strategy. Output order follows ours, with records only
+// theirs added appended; memory records stay after issue records, matching
+// bd export. A v2 schema header is merged like a record and written first,
+// and when it declares ID order the issues are re-sorted so the result stays
+// a canonical v2 file.
+func mergeJSONLRecords(base, ours, theirs []*jsonlRecord, strategy string) ([]*jsonlRecord, jsonlMergeStats) {
+	stats := jsonlMergeStats{Strategy: strategy}
+	baseByKey := indexJSONLRecords(base)
+	oursByKey := indexJSONLRecords(ours)
+	theirsByKey := indexJSONLRecords(theirs)
+
+	var header *jsonlRecord
+	var issues, memories []*jsonlRecord
+	emit := func(r *jsonlRecord) {
+		switch {
+		case r.Key == jsonlHeaderKey:
+			header = r
+		case r.isMemory():
+			memories = append(memories, r)
+		default:
+			issues = append(issues, r)
+		}
+	}
+
+	for _, o := range ours {
+		b := baseByKey[o.Key]
+		t, inTheirs := theirsByKey[o.Key]
+		switch {
+		case inTheirs:
+			merged, fieldMerged, collisions := mergeJSONLRecord(b, o, t, strategy)
+			if fieldMerged {
+				stats.FieldMerged++
+			}
+			stats.Collisions += collisions
+			emit(merged)
+		case b != nil && jsonlRecordsEqual(b, o):
+			// Deleted on their side, untouched on ours: honor the delete.
+			stats.Deleted++
+		default:
+			// Added on our side, or modified here and deleted there
+			// (modification wins over deletion).
+			emit(o)
+		}
+	}
+	for _, t := range theirs {
+		if _, inOurs := oursByKey[t.Key]; inOurs {
+			continue
+		}
+		if b := baseByKey[t.Key]; b != nil && jsonlRecordsEqual(b, t) {
+			stats.Deleted++
+			continue
+		}
+		if t.Key != jsonlHeaderKey {
+			stats.Added++
+		}
+		emit(t)
+	}
+
+	merged := append(issues, memories...)
+	stats.Records = len(merged)
+	if header != nil {
+		var sortOrder string
+		if v, ok := header.get("sort"); ok {
+			_ = json.Unmarshal(v, &sortOrder)
+		}
+		if sortOrder == "id" {
+			// Issue keys sort before label keys, as v2 export writes them.
+			sort.SliceStable(issues, func(i, j int) bool { return issues[i].Key < issues[j].Key })
+			merged = append(issues, memories...)
+		}
+		merged = append([]*jsonlRecord{header}, merged...)
+	}
+	return merged, stats
+}
+
+func indexJSONLRecords(records []*jsonlRecord) map[string]*jsonlRecord {
+	m := make(map[string]*jsonlRecord, len(records))
+	for _, r := range records {
+		m[r.Key] = r
+	}
+	return m
+}
+
+func jsonlRecordsEqual(a, b *jsonlRecord) bool {
+	if bytes.Equal(a.Raw, b.Raw) {
+		return true
+	}
+	if len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for _, f := range a.Fields {
+		v, ok := b.get(f.Key)
+		if !ok || !jsonValuesEqual(f.Value, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeJSONLRecord merges one record present on both sides. base may be nil
+// when both branches added the same ID independently. It reports whether a
+// field-level merge was needed and how many fields collided.
+func mergeJSONLRecord(base, ours, theirs *jsonlRecord, strategy string) (*jsonlRecord, bool, int) {
+	if jsonlRecordsEqual(ours, theirs) {
+		return ours, false, 0
+	}
+	if base != nil && jsonlRecordsEqual(base, ours) {
+		return theirs, false, 0
+	}
+	if base != nil && jsonlRecordsEqual(base, theirs) {
+		return ours, false, 0
+	}
+
+	theirsNewer := jsonlUpdatedAt(theirs).After(jsonlUpdatedAt(ours))
+	var preferTheirs bool
+	switch strategy {
+	case mergeStrategyOurs:
+		preferTheirs = false
+	case mergeStrategyTheirs:
+		preferTheirs = true
+	default:
+		preferTheirs = theirsNewer
+	}
+	keys := make([]string, 0, len(ours.Fields))
+	seen := make(map[string]bool, len(ours.Fields))
+	for _, f := range ours.Fields {
+		keys = append(keys, f.Key)
+		seen[f.Key] = true
+	}
+	for _, f := range theirs.Fields {
+		if !seen[f.Key] {
+			keys = append(keys, f.Key)
+		}
+	}
+
+	merged := &jsonlRecord{Key: ours.Key}
+	collisions := 0
+	for _, key := range keys {
+		o, inOurs := ours.get(key)
+		t, inTheirs := theirs.get(key)
+		var b json.RawMessage
+		inBase := false
+		if base != nil {
+			b, inBase = base.get(key)
+		}
+
+		var value json.RawMessage
+		present := true
+		switch {
+		case inOurs == inTheirs && (!inOurs || jsonValuesEqual(o, t)):
+			value, present = o, inOurs
+		case inOurs == inBase && (!inOurs || jsonValuesEqual(o, b)):
+			value, present = t, inTheirs
+		case inTheirs == inBase && (!inTheirs || jsonValuesEqual(t, b)):
+			value, present = o, inOurs
+		case jsonlSetFields[key]:
+			value = mergeJSONSets(b, o, t)
+		case key == "updated_at":
+			value = o
+			if theirsNewer {
+				value = t
+			}
+		default:
+			collisions++
+			value, present = o, inOurs
+			if preferTheirs {
+				value, present = t, inTheirs
+			}
+		}
+		if present {
+			merged.Fields = append(merged.Fields, jsonlField{Key: key, Value: value})
+		}
+	}
+	return merged, true, collisions
+}
+
+func jsonlUpdatedAt(r *jsonlRecord) time.Time {
+	var ts time.Time
+	if v, ok := r.get("updated_at"); ok {
+		_ = json.Unmarshal(v, &ts)
+	}
+	return ts
+}
+
+// mergeJSONSets three-way merges two JSON arrays as sets: elements removed on
+// either side are dropped, elements added on either side are kept. Order
+// follows ours, then additions from theirs.
+func mergeJSONSets(base, ours, theirs json.RawMessage) json.RawMessage {
+	baseSet := make(map[string]bool)
+	for _, e := range decodeJSONArray(base) {
+		baseSet[compactJSON(e)] = true
+	}
+	theirElems := decodeJSONArray(theirs)
+	theirSet := make(map[string]bool, len(theirElems))
+	for _, e := range theirElems {
+		theirSet[compactJSON(e)] = true
+	}
+
+	var out []json.RawMessage
+	seen := make(map[string]bool)
+	for _, e := range decodeJSONArray(ours) {
+		k := compactJSON(e)
+		if seen[k] || (baseSet[k] && !theirSet[k]) {
+			continue
+		}
+		seen[k] = true
+		out = append(out, e)
+	}
+	for _, e := range theirElems {
+		k := compactJSON(e)
+		if seen[k] || baseSet[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, e)
+	}
+	if out == nil {
+		out = []json.RawMessage{}
+	}
+	data, _ := json.Marshal(out)
+	return data
+}
+
+func decodeJSONArray(raw json.RawMessage) []json.RawMessage {
+	var elems []json.RawMessage
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &elems)
+	}
+	return elems
+}
+
+func compactJSON(raw json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+func jsonValuesEqual(a, b json.RawMessage) bool {
+	return compactJSON(a) == compactJSON(b)
+}
+
+func writeJSONLRecords(w io.Writer, records []*jsonlRecord) error {
+	for _, r := range records {
+		line := r.Raw
+		if line == nil {
+			var buf bytes.Buffer
+			buf.WriteByte('{')
+			for i, f := range r.Fields {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				key, err := json.Marshal(f.Key)
+				if err != nil {
+					return err
+				}
+				buf.Write(key)
+				buf.WriteByte(':')
+				buf.WriteString(compactJSON(f.Value))
+			}
+			buf.WriteByte('}')
+			line = buf.Bytes()
+		}
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte{'\n'}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
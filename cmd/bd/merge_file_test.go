@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func mustParseJSONL(t *testing.T, lines ...string) []*jsonlRecord {
+	t.Helper()
+	records, err := parseJSONLRecords([]byte(strings.Join(lines, "\n") + "\n"))
+	if err != nil {
+		t.Fatalf("parseJSONLRecords: %v", err)
+	}
+	return records
+}
+
+func mergedFields(t *testing.T, records []*jsonlRecord) []map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := writeJSONLRecords(&buf, records); err != nil {
+		t.Fatalf("writeJSONLRecords: %v", err)
+	}
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("merged line %q is not JSON: %v", line, err)
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestMergeJSONLRecords_FieldLevel(t *testing.T) {
+	base := mustParseJSONL(t,
+		`{"id":"bd-1","title":"One","status":"open","description":"old","labels":["a"],"updated_at":"2026-01-01T00:00:00Z"}`,
+	)
+	ours := mustParseJSONL(t,
+		`{"id":"bd-1","title":"One","status":"closed","description":"old","labels":["a","ours"],"updated_at":"2026-01-02T00:00:00Z"}`,
+	)
+	theirs := mustParseJSONL(t,
+		`{"id":"bd-1","title":"One","status":"open","description":"new","labels":["theirs"],"updated_at":"2026-01-03T00:00:00Z"}`,
+	)
+
+	merged, stats := mergeJSONLRecords(base, ours, theirs, mergeStrategyUnion)
+	if stats.FieldMerged != 1 || stats.Collisions != 0 {
+		t.Fatalf("stats = %+v, want one field merge and no collisions", stats)
+	}
+	got := mergedFields(t, merged)
+	if len(got) != 1 {
+		t.Fatalf("got %d records, want 1", len(got))
+	}
+	if got[0]["status"] != "closed" || got[0]["description"] != "new" {
+		t.Errorf("merged = %v, want status from ours and description from theirs", got[0])
+	}
+	if got[0]["updated_at"] != "2026-01-03T00:00:00Z" {
+		t.Errorf("updated_at = %v, want the later timestamp", got[0]["updated_at"])
+	}
+	labels, _ := got[0]["labels"].([]interface{})
+	if len(labels) != 2 || labels[0] != "ours" || labels[1] != "theirs" {
+		t.Errorf("labels = %v, want [ours theirs] (a removed on their side)", labels)
+	}
+}
+
+func TestMergeJSONLRecords_CollisionNewerWins(t *testing.T) {
+	base := mustParseJSONL(t, `{"id":"bd-1","title":"Base","updated_at":"2026-01-01T00:00:00Z"}`)
+	ours := mustParseJSONL(t, `{"id":"bd-1","title":"Ours","updated_at":"2026-01-03T00:00:00Z"}`)
+	theirs := mustParseJSONL(t, `{"id":"bd-1","title":"Theirs","updated_at":"2026-01-02T00:00:00Z"}`)
+
+	merged, stats := mergeJSONLRecords(base, ours, theirs, mergeStrategyUnion)
+	if stats.Collisions != 1 {
+		t.Fatalf("collisions = %d, want 1", stats.Collisions)
+	}
+	if got := mergedFields(t, merged)[0]["title"]; got != "Ours" {
+		t.Errorf("title = %v, want the newer side (Ours)", got)
+	}
+}
+
+func TestMergeJSONLRecords_Strategies(t *testing.T) {
+	base := mustParseJSONL(t, `{"id":"bd-1","title":"Base","priority":2,"updated_at":"2026-01-01T00:00:00Z"}`)
+	ours := mustParseJSONL(t, `{"id":"bd-1","title":"Ours","priority":2,"updated_at":"2026-01-02T00:00:00Z"}`)
+	theirs := mustParseJSONL(t, `{"id":"bd-1","title":"Theirs","priority":0,"updated_at":"2026-01-03T00:00:00Z"}`)
+
+	for strategy, wantTitle := range map[string]string{
+		mergeStrategyUnion:  "Theirs",
+		mergeStrategyOurs:   "Ours",
+		mergeStrategyTheirs: "Theirs",
+	} {
+		merged, stats := mergeJSONLRecords(base, ours, theirs, strategy)
+		got := mergedFields(t, merged)[0]
+		if got["title"] != wantTitle {
+			t.Errorf("%s: title = %v, want %s", strategy, got["title"], wantTitle)
+		}
+		// priority changed only on their side, so no strategy may drop it.
+		if got["priority"] != float64(0) {
+			t.Errorf("%s: priority = %v, want 0 from theirs", strategy, got["priority"])
+		}
+		if stats.Strategy != strategy || stats.Collisions != 1 {
+			t.Errorf("%s: stats = %+v, want one collision", strategy, stats)
+		}
+	}
+}
+
+func TestResolveMergeFileStrategy(t *testing.T) {
+	if got, err := resolveMergeFileStrategy("THEIRS"); err != nil || got != mergeStrategyTheirs {
+		t.Errorf("resolveMergeFileStrategy(THEIRS) = %q, %v", got, err)
+	}
+	if _, err := resolveMergeFileStrategy("newest"); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
+
+func TestMergeJSONLRecords_AddsAndDeletes(t *testing.T) {
+	base := mustParseJSONL(t,
+		`{"id":"bd-1","title":"Keep"}`,
+		`{"id":"bd-2","title":"Deleted by theirs"}`,
+		`{"id":"bd-3","title":"Deleted by ours"}`,
+		`{"id":"bd-4","title":"Deleted by theirs, edited by ours"}`,
+	)
+	ours := mustParseJSONL(t,
+		`{"id":"bd-1","title":"Keep"}`,
+		`{"id":"bd-2","title":"Deleted by theirs"}`,
+		`{"id":"bd-4","title":"Edited"}`,
+		`{"_type":"memory","key":"k","value":"v"}`,
+	)
+	theirs := mustParseJSONL(t,
+		`{"id":"bd-1","title":"Keep"}`,
+		`{"id":"bd-3","title":"Deleted by ours"}`,
+		`{"id":"bd-5","title":"Added by theirs"}`,
+	)
+
+	merged, stats := mergeJSONLRecords(base, ours, theirs, mergeStrategyUnion)
+	var keys []string
+	for _, r := range merged {
+		keys = append(keys, r.Key)
+	}
+	want := "issue:bd-1,issue:bd-4,issue:bd-5,memory:k"
+	if got := strings.Join(keys, ","); got != want {
+		t.Errorf("merged keys = %s, want %s", got, want)
+	}
+	if stats.Added != 1 || stats.Deleted != 2 {
+		t.Errorf("stats = %+v, want 1 added and 2 deleted", stats)
+	}
+}
+
+func TestMergeJSONLRecords_UnchangedKeepsRawLine(t *testing.T) {
+	line := `{"id":"bd-1",  "title":"Spacing preserved"}`
+	records := mustParseJSONL(t, line)
+	merged, _ := mergeJSONLRecords(records, records, records, mergeStrategyUnion)
+	var buf bytes.Buffer
+	if err := writeJSONLRecords(&buf, merged); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != line {
+		t.Errorf("output = %q, want the original line verbatim", got)
+	}
+}
+
+func TestParseJSONLRecords_RejectsConflictMarkers(t *testing.T) {
+	data := "<<<<<<< HEAD\n{\"id\":\"bd-1\"}\n=======\n{\"id\":\"bd-1\"}\n>>>>>>> other\n"
+	if _, err := parseJSONLRecords([]byte(data)); err == nil || !strings.Contains(err.Error(), "conflict marker") {
+		t.Fatalf("err = %v, want conflict marker error", err)
+	}
+}
+
+func TestMergeJSONLRecords_SchemaV2Header(t *testing.T) {
+	header := `{"_schema":"beads-jsonl/2","issue_prefix":"bd","schema_version":2,"sort":"id"}`
+	base := mustParseJSONL(t, header,
+		`{"_type":"issue","id":"bd-2","title":"Two"}`,
+		`{"_type":"label","name":"backend"}`,
+	)
+	ours := mustParseJSONL(t, header,
+		`{"_type":"issue","id":"bd-2","title":"Two"}`,
+		`{"_type":"issue","id":"bd-3","title":"Added by ours"}`,
+		`{"_type":"label","name":"backend"}`,
+	)
+	theirs := mustParseJSONL(t, header,
+		`{"_type":"issue","id":"bd-1","title":"Added by theirs"}`,
+		`{"_type":"issue","id":"bd-2","title":"Two"}`,
+		`{"_type":"label","name":"backend"}`,
+	)
+	if ours[0].Key != jsonlHeaderKey {
+		t.Fatalf("header parsed with key %q, want %q", ours[0].Key, jsonlHeaderKey)
+	}
+
+	merged, stats := mergeJSONLRecords(base, ours, theirs, mergeStrategyUnion)
+	var buf bytes.Buffer
+	if err := writeJSONLRecords(&buf, merged); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != header {
+		t.Errorf("first line = %q, want the schema header", lines[0])
+	}
+	var keys []string
+	for _, r := range merged[1:] {
+		keys = append(keys, r.Key)
+	}
+	// v2 files are sorted by ID, so the issue added by theirs is not appended.
+	want := "issue:bd-1,issue:bd-2,issue:bd-3,label:backend"
+	if got := strings.Join(keys, ","); got != want {
+		t.Errorf("merged keys = %s, want %s", got, want)
+	}
+	if stats.Records != 4 || stats.Added != 1 {
+		t.Errorf("stats = %+v, want 4 records with 1 added", stats)
+	}
+}
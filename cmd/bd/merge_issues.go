@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var mergeIssuesCmd = &cobra.Command{
+	Use:     "merge-issues <target> <source>",
+	GroupID: "deps",
+	Short:   "Fold a duplicate issue into another",
+	Long: `Fold the source issue into the target and delete the source.
+
+In one transaction the target gains:
+- The source's labels it does not already have
+- The source's dependencies and dependents (edges between the two are dropped,
+  and a second parent is not added when the target already has one)
+- The source's comments, with their original author and time
+- A comment recording the source's title and description
+
+The source ID and its aliases become aliases of the target (see 'bd alias'),
+and references to the source in other issues' text are rewritten to the
+target. Unlike 'bd duplicate', which closes the duplicate and links it, the
+source issue no longer exists afterwards.
+
+Examples:
+  bd merge-issues bd-abc bd-xyz        # Fold bd-xyz into bd-abc
+  bd merge-issues bd-abc bd-xyz --json # Report what was carried over`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runMergeIssues,
+}
+
+// mergeIssuesResult is the `bd merge-issues --json` payload.
+type mergeIssuesResult struct {
+	Target            string   `json:"target"`
+	Source            string   `json:"source"`
+	LabelsAdded       []string `json:"labels_added"`
+	DependenciesMoved int      `json:"dependencies_moved"`
+	DependentsMoved   int      `json:"dependents_moved"`
+	CommentsCopied    int      `json:"comments_copied"`
+	Skipped           []string `json:"skipped,omitempty"`
+}
+
+func init() {
+	mergeIssuesCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(mergeIssuesCmd)
+}
+
+func runMergeIssues(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("merge-issues is not supported in proxied-server mode")
+	}
+	CheckReadonly("merge-issues")
+
+	evt := metrics.NewCommandEvent("merge-issues")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("failed to get storage: %v", err)
+	}
+	ctx := rootCtx
+
+	targetID, err := utils.ResolvePartialID(ctx, store, args[0])
+	if err != nil {
+		return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+	}
+	sourceID, err := utils.ResolvePartialID(ctx, store, args[1])
+	if err != nil {
+		return HandleErrorRespectJSON("resolving %s: %v", args[1], err)
+	}
+	if targetID == sourceID {
+		return HandleErrorRespectJSON("cannot merge %s into itself", targetID)
+	}
+
+	actor := getActorWithGit()
+	var result *mergeIssuesResult
+	err = transact(ctx, store, fmt.Sprintf("bd: merge-issues %s into %s", sourceID, targetID), func(tx storage.Transaction) error {
+		var err error
+		result, err = mergeIssueInto(ctx, tx, targetID, sourceID, actor)
+		return err
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("merging %s into %s: %v", sourceID, targetID, err)
+	}
+	commandDidWrite.Store(true)
+	SetLastTouchedID(targetID)
+
+	if jsonOutput {
+		if result.LabelsAdded == nil {
+			result.LabelsAdded = []string{}
+		}
+		return outputJSON(result)
+	}
+	fmt.Printf("%s Merged %s into %s\n", ui.RenderPass("✓"), ui.RenderWarn(sourceID), ui.RenderAccent(targetID))
+	if len(result.LabelsAdded) > 0 {
+		fmt.Printf("  Labels added: %s\n", strings.Join(result.LabelsAdded, ", "))
+	}
+	fmt.Printf("  Dependencies moved: %d, dependents moved: %d, comments copied: %d\n",
+		result.DependenciesMoved, result.DependentsMoved, result.CommentsCopied)
+	for _, s := range result.Skipped {
+		fmt.Printf("  %s\n", ui.RenderMuted("skipped "+s))
+	}
+	fmt.Printf("  %s now resolves to %s\n", sourceID, targetID)
+	return nil
+}
+
+// mergeIssueInto folds sourceID into targetID within tx and deletes the
+// source. Any failure rolls the whole merge back.
+func mergeIssueInto(ctx context.Context, tx storage.Transaction, targetID, sourceID, actor string) (*mergeIssuesResult, error) {
+	target, err := tx.GetIssue(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", targetID, err)
+	}
+	source, err := tx.GetIssue(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", sourceID, err)
+	}
+	for _, issue := range []*types.Issue{target, source} {
+		if issue.Ephemeral {
+			return nil, fmt.Errorf("%s is ephemeral; merging applies to durable issues only", issue.ID)
+		}
+		if issue.IsTemplate {
+			return nil, fmt.Errorf("%s is a template; templates cannot be merged", issue.ID)
+		}
+	}
+
+	result := &mergeIssuesResult{Target: targetID, Source: sourceID}
+
+	// Labels: union.
+	targetLabels, err := tx.GetLabels(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("get labels of %s: %w", targetID, err)
+	}
+	sourceLabels, err := tx.GetLabels(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("get labels of %s: %w", sourceID, err)
+	}
+	have := make(map[string]bool, len(targetLabels))
+	for _, l := range targetLabels {
+		have[l] = true
+	}
+	for _, l := range sourceLabels {
+		if have[l] {
+			continue
+		}
+		if err := tx.AddLabel(ctx, targetID, l, actor); err != nil {
+			return nil, fmt.Errorf("add label %s: %w", l, err)
+		}
+		result.LabelsAdded = append(result.LabelsAdded, l)
+	}
+
+	// Outgoing edges: the target takes over what the source depended on.
+	targetDeps, err := tx.GetDependencyRecords(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("get dependencies of %s: %w", targetID, err)
+	}
+	sourceDeps, err := tx.GetDependencyRecords(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("get dependencies of %s: %w", sourceID, err)
+	}
+	dependsOn := make(map[string]bool, len(targetDeps))
+	hasParent := false
+	for _, d := range targetDeps {
+		dependsOn[d.DependsOnID] = true
+		hasParent = hasParent || d.Type == types.DepParentChild
+	}
+	var newEdges [][2]string
+	addOpts := storage.DependencyAddOptions{SkipCycleCheck: true}
+	for _, d := range sourceDeps {
+		switch {
+		case d.DependsOnID == targetID || dependsOn[d.DependsOnID]:
+			continue
+		case d.Type == types.DepParentChild && hasParent:
+			result.Skipped = append(result.Skipped, fmt.Sprintf("parent %s: %s already has a parent", d.DependsOnID, targetID))
+			continue
+		}
+		dep := &types.Dependency{IssueID: targetID, DependsOnID: d.DependsOnID, Type: d.Type, Metadata: d.Metadata}
+		if err := tx.AddDependencyWithOptions(ctx, dep, actor, addOpts); err != nil {
+			return nil, fmt.Errorf("move dependency on %s: %w", d.DependsOnID, err)
+		}
+		dependsOn[d.DependsOnID] = true
+		hasParent = hasParent || d.Type == types.DepParentChild
+		newEdges = append(newEdges, [2]string{targetID, d.DependsOnID})
+		result.DependenciesMoved++
+	}
+
+	// Incoming edges: whatever depended on the source now depends on the target.
+	dependents, err := tx.GetDependentRecordsForIssues(ctx, []string{targetID, sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("get dependents: %w", err)
+	}
+	dependsOnTarget := make(map[string]bool, len(dependents[targetID]))
+	for _, d := range dependents[targetID] {
+		dependsOnTarget[d.IssueID] = true
+	}
+	for _, d := range dependents[sourceID] {
+		if d.IssueID == targetID || dependsOnTarget[d.IssueID] {
+			continue
+		}
+		dep := &types.Dependency{IssueID: d.IssueID, DependsOnID: targetID, Type: d.Type, Metadata: d.Metadata}
+		if err := tx.AddDependencyWithOptions(ctx, dep, actor, addOpts); err != nil {
+			return nil, fmt.Errorf("move dependent %s: %w", d.IssueID, err)
+		}
+		dependsOnTarget[d.IssueID] = true
+		newEdges = append(newEdges, [2]string{d.IssueID, targetID})
+		result.DependentsMoved++
+	}
+	if len(newEdges) > 0 {
+		cycle, err := tx.CycleThroughEdges(ctx, newEdges)
+		if err != nil {
+			return nil, fmt.Errorf("check for cycles: %w", err)
+		}
+		if cycle != "" {
+			return nil, fmt.Errorf("merging would create a dependency cycle: %s", cycle)
+		}
+	}
+
+	// Comments keep their author and time; the source's own text is kept as
+	// one more comment so nothing written on the duplicate is lost.
+	comments, err := tx.GetIssueComments(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("get comments of %s: %w", sourceID, err)
+	}
+	for _, c := range comments {
+		if _, err := tx.ImportIssueComment(ctx, targetID, c.Author, c.Text, c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("copy comment %s: %w", c.ID, err)
+		}
+		result.CommentsCopied++
+	}
+	note := fmt.Sprintf("Merged %s: %s", sourceID, source.Title)
+	if strings.TrimSpace(source.Description) != "" {
+		note += "\n\n" + source.Description
+	}
+	if _, err := tx.ImportIssueComment(ctx, targetID, actor, note, time.Now().UTC().Truncate(time.Second)); err != nil {
+		return nil, fmt.Errorf("record merged text: %w", err)
+	}
+
+	// Redirect aliases before the delete, whose cascade would drop them.
+	if err := tx.RedirectIssueAliases(ctx, sourceID, targetID, actor); err != nil {
+		return nil, err
+	}
+	if err := tx.DeleteIssue(ctx, sourceID); err != nil {
+		return nil, fmt.Errorf("delete %s: %w", sourceID, err)
+	}
+	if err := updateReferencesInAllIssues(ctx, tx, sourceID, targetID, actor); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
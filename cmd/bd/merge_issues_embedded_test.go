@@ -0,0 +1,76 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedMergeIssues(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "mi")
+	target := bdCreateSilent(t, bd, dir, "Login fails", "--labels", "bug")
+	source := bdCreateSilent(t, bd, dir, "Cannot log in", "--labels", "bug,auth", "--description", "Seen on staging.")
+	blocker := bdCreateSilent(t, bd, dir, "Fix session store")
+	dependent := bdCreateSilent(t, bd, dir, "Release 1.2")
+	mention := bdCreateSilent(t, bd, dir, "Follow-up", "--description", "See "+source+" for context.")
+	bdDepAdd(t, bd, dir, source, blocker)
+	bdDepAdd(t, bd, dir, dependent, source)
+	bdCommand(t, bd, dir, "comments", "add", source, "Repro attached")
+
+	out := bdCommand(t, bd, dir, "merge-issues", target, source, "--json")
+	var result mergeIssuesResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+	if result.DependenciesMoved != 1 || result.DependentsMoved != 1 || result.CommentsCopied != 1 {
+		t.Errorf("result = %+v", result)
+	}
+
+	// The source ID now resolves to the target.
+	var details types.IssueDetails
+	if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, source)), &details); err != nil {
+		t.Fatal(err)
+	}
+	if details.ID != target {
+		t.Fatalf("show %s = %s, want %s", source, details.ID, target)
+	}
+	if !reflect.DeepEqual(details.Aliases, []string{source}) {
+		t.Errorf("aliases = %v", details.Aliases)
+	}
+	labels := append([]string(nil), details.Labels...)
+	sort.Strings(labels)
+	if !reflect.DeepEqual(labels, []string{"auth", "bug"}) {
+		t.Errorf("labels = %v", labels)
+	}
+	if len(details.Dependencies) != 1 || details.Dependencies[0].ID != blocker {
+		t.Errorf("dependencies = %+v", details.Dependencies)
+	}
+	if details.DependentCount == nil || *details.DependentCount != 1 {
+		t.Errorf("dependent_count = %v, want 1", details.DependentCount)
+	}
+	out = bdCommand(t, bd, dir, "comments", target, "--json")
+	var comments []*types.Comment
+	if err := json.Unmarshal([]byte(out), &comments); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+	if len(comments) != 2 || comments[0].Text != "Repro attached" || !strings.Contains(comments[1].Text, "Seen on staging.") {
+		t.Errorf("comments = %s", out)
+	}
+
+	if got := bdShow(t, bd, dir, mention).Description; got != "See "+target+" for context." {
+		t.Errorf("reference not remapped: %q", got)
+	}
+}
@@ -776,8 +776,16 @@ Schema migrations also run automatically on store open, so this subcommand
 is typically a no-op. It exists to make migration explicit and observable
 in CI, release gates, and recovery scenarios.
 
+--status lists the schema version and any pending migrations, and --dry-run
+prints their SQL; neither applies anything. Migrations the open cannot apply
+(a remote-backed database held by the remote-migrate gate, or dirty tables
+the migration would touch) show up there as pending. There are no down
+migrations: to undo a schema change, reset to a Dolt commit from before it.
+
 Example:
   bd migrate schema
+  bd migrate schema --status
+  bd migrate schema --dry-run
   bd migrate schema --json`,
 	Args:          cobra.NoArgs,
 	SilenceUsage:  true,
@@ -786,6 +794,10 @@ Example:
 		if usesProxiedServer() {
 			return HandleErrorRespectJSON("migrate schema is not supported in proxied-server mode")
 		}
+		if isSchemaPreview(cmd) {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			return handleSchemaStatus(dryRun)
+		}
 		CheckReadonly("migrate schema")
 
 		evt := metrics.NewCommandEvent("migrate-schema")
@@ -816,10 +828,15 @@ func init() {
 	migrateHooksCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
 	migrateHooksCmd.Flags().Bool("apply", false, "Apply planned hook migration changes")
 	migrateHooksCmd.Flags().Bool("yes", false, "Skip confirmation prompt for --apply")
+	migrateHooksCmd.Flags().Bool("all-workspaces", false, "Migrate every workspace from the multi-repo config and external_projects")
+	migrateHooksCmd.Flags().Int("parallel", 4, "Workspaces to migrate concurrently with --all-workspaces")
 	migrateHooksCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	migrateCmd.AddCommand(migrateHooksCmd)
 
 	migrateSchemaCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	migrateSchemaCmd.Flags().Bool("status", false, "Show the schema version and pending migrations without applying them")
+	migrateSchemaCmd.Flags().Bool("dry-run", false, "Print the SQL of pending migrations without applying them")
+	migrateSchemaCmd.MarkFlagsMutuallyExclusive("status", "dry-run")
 	// --force on migrate schema mirrors the parent command's flag; both trip the
 	// same isForcedMigrate check in main.go's PersistentPreRunE.
 	migrateSchemaCmd.Flags().Bool("force", false, "Bypass the remote-migrate gate as the single designated migrator (equivalent to BD_ALLOW_REMOTE_MIGRATE=1)")
@@ -192,9 +192,7 @@ func TestEmbeddedMigrate(t *testing.T) {
 		s := strings.TrimSpace(out)
 		start := strings.Index(s, "{")
 		if start < 0 {
-			// --json flag may be shadowed by other migrate flags; tolerate
-			// non-JSON output so long as the command succeeded.
-			return
+			t.Fatalf("expected JSON output: %s", s)
 		}
 		var m map[string]interface{}
 		if err := json.Unmarshal([]byte(s[start:]), &m); err != nil {
@@ -220,11 +218,35 @@ func TestEmbeddedMigrate(t *testing.T) {
 		}
 	})
 
-	t.Run("migrate_schema_rejects_dry_run", func(t *testing.T) {
+	t.Run("migrate_schema_dry_run", func(t *testing.T) {
 		dir, _, _ := bdInit(t, bd, "--prefix", "sd")
-		out := bdMigrateFail(t, bd, dir, "schema", "--dry-run")
-		if !strings.Contains(out, "unknown flag") && !strings.Contains(out, "dry-run") {
-			t.Errorf("expected error about --dry-run on 'migrate schema': %s", out)
+		out := bdMigrate(t, bd, dir, "schema", "--dry-run")
+		if !strings.Contains(out, "No pending schema migrations") {
+			t.Errorf("expected no pending SQL on a fresh database: %s", out)
+		}
+	})
+
+	t.Run("migrate_schema_status_json", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "ss")
+		out := bdMigrate(t, bd, dir, "schema", "--status", "--json")
+		var m struct {
+			Current int               `json:"current_version"`
+			Latest  int               `json:"latest_version"`
+			Pending []json.RawMessage `json:"pending"`
+		}
+		if err := json.Unmarshal([]byte(out), &m); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		if m.Current == 0 || m.Current != m.Latest || len(m.Pending) != 0 {
+			t.Errorf("expected a fresh database at the latest version with nothing pending: %s", out)
+		}
+	})
+
+	t.Run("migrate_schema_status_rejects_dry_run", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "sr")
+		out := bdMigrateFail(t, bd, dir, "schema", "--status", "--dry-run")
+		if !strings.Contains(out, "status") || !strings.Contains(out, "dry-run") {
+			t.Errorf("expected --status/--dry-run conflict error: %s", out)
 		}
 	})
 
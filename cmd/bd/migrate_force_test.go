@@ -4,7 +4,7 @@ import "testing"
 
 // TestIsForcedMigrate covers the root-PersistentPreRunE helpers behind
 // `bd migrate --force`: only the migrate and migrate-schema commands report
-// forced, and --force conflicts with the preview flags (--dry-run/--inspect)
+// forced, and --force conflicts with the preview flags (--dry-run/--inspect/--status)
 // because the gate-overridden store open would apply pending migrations
 // before the preview ever ran.
 func TestIsForcedMigrate(t *testing.T) {
@@ -13,6 +13,7 @@ func TestIsForcedMigrate(t *testing.T) {
 		_ = migrateCmd.Flags().Set("dry-run", "false")
 		_ = migrateCmd.Flags().Set("inspect", "false")
 		_ = migrateSchemaCmd.Flags().Set("force", "false")
+		_ = migrateSchemaCmd.Flags().Set("status", "false")
 	})
 	set := func(name, v string) {
 		t.Helper()
@@ -52,6 +53,12 @@ func TestIsForcedMigrate(t *testing.T) {
 		t.Error("force set on migrate schema: want true")
 	}
 	if got := forcedMigratePreviewFlag(migrateSchemaCmd); got != "" {
-		t.Errorf("migrate schema has no preview flags: want \"\", got %q", got)
+		t.Errorf("migrate schema without preview flags: want \"\", got %q", got)
+	}
+	if err := migrateSchemaCmd.Flags().Set("status", "true"); err != nil {
+		t.Fatalf("set migrate schema --status: %v", err)
+	}
+	if got := forcedMigratePreviewFlag(migrateSchemaCmd); got != "status" {
+		t.Errorf("migrate schema --status set: want \"status\", got %q", got)
 	}
 }
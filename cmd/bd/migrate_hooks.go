@@ -21,11 +21,19 @@ Modes:
   --dry-run  Preview migration operations without changing files
   --apply    Apply migration operations
 
+With --all-workspaces, the current workspace plus every workspace listed in
+the multi-repo config (repos.primary, repos.additional) and external_projects
+is planned together. The dry-run shows an aggregate summary; --apply migrates
+the workspaces concurrently (see --parallel) and reports success or failure
+per workspace. A blocked or failing workspace does not stop the others.
+
 Examples:
   bd migrate hooks --dry-run
   bd migrate hooks --apply
   bd migrate hooks --apply --yes
-  bd migrate hooks --dry-run --json`,
+  bd migrate hooks --dry-run --json
+  bd migrate hooks --all-workspaces --dry-run
+  bd migrate hooks --all-workspaces --apply --yes --parallel 8`,
 	Args:          cobra.MaximumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -40,6 +48,8 @@ Examples:
 		requestedDryRun, _ := cmd.Flags().GetBool("dry-run")
 		requestedApply, _ := cmd.Flags().GetBool("apply")
 		requestedYes, _ := cmd.Flags().GetBool("yes")
+		allWorkspaces, _ := cmd.Flags().GetBool("all-workspaces")
+		parallel, _ := cmd.Flags().GetInt("parallel")
 
 		mode, err := validateHookMigrationMode(requestedDryRun, requestedApply, requestedYes)
 		if err != nil {
@@ -60,6 +70,10 @@ Examples:
 			return HandleErrorRespectJSON("resolving path: %v", err)
 		}
 
+		if allWorkspaces {
+			return runMigrateHooksAllWorkspaces(absPath, mode, parallel)
+		}
+
 		plan, err := doctor.PlanHookMigration(absPath)
 		if err != nil {
 			return HandleErrorRespectJSON("building hook migration plan: %v", err)
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/steveyegge/beads/cmd/bd/doctor"
@@ -62,6 +63,8 @@ type hookMigrationWriteOp struct {
 	State      string                   `json:"state"`
 	SourceKind hookMigrationWriteSource `json:"source_kind"`
 	SourcePath string                   `json:"source_path,omitempty"`
+	// WindowsCompanionPath, when set, is (re)written with the bd .cmd shim.
+	WindowsCompanionPath string `json:"windows_companion_path,omitempty"`
 }
 
 type hookMigrationRetireOp struct {
@@ -99,6 +102,18 @@ func (p hookMigrationExecutionPlan) operationCount() int {
 	return len(p.WriteOps) + len(p.RetireOps)
 }
 
+// hooksDir returns the hooks directory the plan's operations touch, or "" for
+// an empty plan.
+func (p hookMigrationExecutionPlan) hooksDir() string {
+	switch {
+	case len(p.WriteOps) > 0:
+		return filepath.Dir(p.WriteOps[0].HookPath)
+	case len(p.RetireOps) > 0:
+		return filepath.Dir(p.RetireOps[0].SourcePath)
+	}
+	return ""
+}
+
 func (p hookMigrationExecutionPlan) outputOperations() []hookMigrationOutputOperation {
 	ops := make([]hookMigrationOutputOperation, 0, p.operationCount())
 	for _, write := range p.WriteOps {
@@ -132,10 +147,10 @@ func buildHookMigrationExecutionPlan(plan doctor.HookMigrationPlan) hookMigratio
 
 	for _, hook := range plan.Hooks {
 		switch hook.State {
-		case "marker_managed", "unmanaged_custom", "missing_no_artifacts":
+		case "marker_managed", "unmanaged_custom", "missing_no_artifacts", "manager_owned":
 			execPlan.NoopHooks = append(execPlan.NoopHooks, hook.Name)
 			continue
-		case "read_error":
+		case "read_error", "windows_custom_script":
 			execPlan.BlockingErrors = append(execPlan.BlockingErrors, formatHookMigrationBlockingError(hook))
 			continue
 		case "marker_broken":
@@ -170,13 +185,23 @@ func buildHookMigrationExecutionPlan(plan doctor.HookMigrationPlan) hookMigratio
 			continue
 		}
 
-		execPlan.WriteOps = append(execPlan.WriteOps, hookMigrationWriteOp{
+		writeOp := hookMigrationWriteOp{
 			HookName:   hook.Name,
 			HookPath:   hook.HookPath,
 			State:      hook.State,
 			SourceKind: sourceKind,
 			SourcePath: sourcePath,
-		})
+		}
+		// Keep Windows tooling working: a bd-written (or bd-calling) .cmd
+		// companion is refreshed, and a Windows-script hook being replaced
+		// gets one, unless a user-owned .cmd file is already there.
+		ownsCmd := hook.WindowsBDShim && strings.HasSuffix(hook.WindowsCompanion, ".cmd")
+		if ownsCmd || hook.State == "legacy_windows_script" {
+			if cmdExists, _ := pathExists(hook.HookPath + ".cmd"); ownsCmd || !cmdExists {
+				writeOp.WindowsCompanionPath = hook.HookPath + ".cmd"
+			}
+		}
+		execPlan.WriteOps = append(execPlan.WriteOps, writeOp)
 
 		if hook.HasOldSidecar {
 			execPlan.RetireOps = append(execPlan.RetireOps, hookMigrationRetireOp{
@@ -210,7 +235,7 @@ func formatHookMigrationBlockingError(hook doctor.HookMigrationHookPlan) string
 
 func chooseHookMigrationWriteSource(hook doctor.HookMigrationHookPlan) (hookMigrationWriteSource, string, error) {
 	switch hook.State {
-	case "legacy_only":
+	case "legacy_only", "legacy_windows_script", "missing_with_windows_companion":
 		return hookMigrationWriteFromTemplate, "", nil
 	case "legacy_with_old_sidecar", "legacy_with_both_sidecars", "missing_with_old_sidecar", "missing_with_both_sidecars":
 		return hookMigrationWriteFromOld, hook.HookPath + ".old", nil
@@ -227,9 +252,10 @@ func chooseHookMigrationWriteSource(hook doctor.HookMigrationHookPlan) (hookMigr
 }
 
 type preparedHookWrite struct {
-	HookName string
-	Path     string
-	Content  []byte
+	HookName      string
+	Path          string
+	Content       []byte
+	CompanionPath string
 }
 
 func applyHookMigrationExecution(execPlan hookMigrationExecutionPlan) (hookMigrationApplySummary, error) {
@@ -241,6 +267,14 @@ func applyHookMigrationExecution(execPlan hookMigrationExecutionPlan) (hookMigra
 		)
 	}
 
+	if hooksDir := execPlan.hooksDir(); hooksDir != "" {
+		unlock, err := acquireHooksLock(hooksDir)
+		if err != nil {
+			return hookMigrationApplySummary{}, err
+		}
+		defer unlock()
+	}
+
 	preparedWrites, err := prepareHookMigrationWrites(execPlan.WriteOps)
 	if err != nil {
 		return hookMigrationApplySummary{}, err
@@ -261,6 +295,12 @@ func applyHookMigrationExecution(execPlan hookMigrationExecutionPlan) (hookMigra
 		if err := os.WriteFile(write.Path, write.Content, 0755); err != nil {
 			return summary, fmt.Errorf("writing migrated hook %s: %w", write.Path, err)
 		}
+		if write.CompanionPath != "" {
+			// #nosec G306 -- hook companions must be executable
+			if err := os.WriteFile(write.CompanionPath, []byte(generateWindowsHookShim(write.HookName)), 0755); err != nil {
+				return summary, fmt.Errorf("writing Windows hook companion %s: %w", write.CompanionPath, err)
+			}
+		}
 		summary.WrittenHooks = append(summary.WrittenHooks, write.HookName)
 	}
 
@@ -292,9 +332,10 @@ func prepareHookMigrationWrites(writeOps []hookMigrationWriteOp) ([]preparedHook
 			return nil, err
 		}
 		prepared = append(prepared, preparedHookWrite{
-			HookName: op.HookName,
-			Path:     op.HookPath,
-			Content:  rendered,
+			HookName:      op.HookName,
+			Path:          op.HookPath,
+			Content:       rendered,
+			CompanionPath: op.WindowsCompanionPath,
 		})
 	}
 
@@ -320,7 +361,7 @@ func renderMigratedHookContent(op hookMigrationWriteOp) ([]byte, error) {
 	baseContent = strings.ReplaceAll(baseContent, "\r\n", "\n")
 	baseContent = ensureHookShebang(baseContent)
 
-	content := injectHookSection(baseContent, generateHookSection(op.HookName))
+	content := injectHookSection(baseContent, managedHookSection(filepath.Dir(op.HookPath), op.HookName))
 	content = strings.ReplaceAll(content, "\r\n", "\n")
 	if !strings.HasSuffix(content, "\n") {
 		content += "\n"
@@ -330,6 +371,7 @@ func renderMigratedHookContent(op hookMigrationWriteOp) ([]byte, error) {
 }
 
 func ensureHookShebang(content string) string {
+	content = stripUTF8BOM(content)
 	if strings.HasPrefix(content, "#!") {
 		return content
 	}
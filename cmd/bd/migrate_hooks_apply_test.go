@@ -328,6 +328,57 @@ func TestBuildExecutionPlan_UnmodifiedLegacyHookProceeds(t *testing.T) {
 	}
 }
 
+func TestApplyHookMigrationExecution_WindowsScripts(t *testing.T) {
+	repoDir, hooksDir := setupHookMigrationRepo(t)
+	preCommitPath := filepath.Join(hooksDir, "pre-commit")
+	prePushPath := filepath.Join(hooksDir, "pre-push")
+
+	writeHookMigrationFile(t, preCommitPath, "@echo off\r\nbd hooks run pre-commit %*\r\n")
+	writeHookMigrationFile(t, prePushPath, "@echo off\r\ncall lint.cmd\r\n")
+
+	plan, err := doctor.PlanHookMigration(repoDir)
+	if err != nil {
+		t.Fatalf("PlanHookMigration failed: %v", err)
+	}
+	execPlan := buildHookMigrationExecutionPlan(plan)
+	if len(execPlan.BlockingErrors) != 1 || !strings.Contains(execPlan.BlockingErrors[0], "pre-push.cmd") {
+		t.Fatalf("expected the custom Windows pre-push to block with a rename hint, got %v", execPlan.BlockingErrors)
+	}
+
+	// Resolve the custom script the way the hint says, then migrate.
+	if err := os.Rename(prePushPath, prePushPath+".cmd"); err != nil {
+		t.Fatal(err)
+	}
+	plan, err = doctor.PlanHookMigration(repoDir)
+	if err != nil {
+		t.Fatalf("PlanHookMigration failed: %v", err)
+	}
+	if _, err := applyHookMigrationExecution(buildHookMigrationExecutionPlan(plan)); err != nil {
+		t.Fatalf("applyHookMigrationExecution failed: %v", err)
+	}
+
+	rendered := mustReadHookMigrationFile(t, preCommitPath)
+	if !strings.HasPrefix(rendered, "#!/usr/bin/env sh\n") || strings.Contains(rendered, "@echo off") {
+		t.Fatalf("expected a portable sh hook replacing the batch script, got:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, hookSectionBeginPrefix) {
+		t.Fatalf("expected migrated hook to contain marker section, got:\n%s", rendered)
+	}
+	if companion := mustReadHookMigrationFile(t, preCommitPath+".cmd"); !isWindowsHookShim(companion) {
+		t.Fatalf("expected a bd .cmd companion, got:\n%s", companion)
+	}
+	if custom := mustReadHookMigrationFile(t, prePushPath+".cmd"); !strings.Contains(custom, "call lint.cmd") {
+		t.Fatalf("user-owned pre-push.cmd must be preserved, got:\n%s", custom)
+	}
+}
+
+func TestEnsureHookShebangStripsBOM(t *testing.T) {
+	got := ensureHookShebang("\ufeff#!/bin/sh\necho hi\n")
+	if got != "#!/bin/sh\necho hi\n" {
+		t.Fatalf("ensureHookShebang kept the BOM or added a second shebang: %q", got)
+	}
+}
+
 func setupHookMigrationRepo(t *testing.T) (repoDir string, hooksDir string) {
 	t.Helper()
 	repoDir = newGitRepo(t)
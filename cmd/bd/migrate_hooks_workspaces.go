@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/config"
+	"golang.org/x/term"
+)
+
+// workspaceHookMigration is the hook migration state of one workspace in
+// `bd migrate hooks --all-workspaces`.
+type workspaceHookMigration struct {
+	Path string `json:"path"`
+	// Status is one of: not_git, up_to_date, pending, blocked, applied, failed.
+	Status         string                         `json:"status"`
+	Plan           doctor.HookMigrationPlan       `json:"plan"`
+	Operations     []hookMigrationOutputOperation `json:"operations"`
+	OperationCount int                            `json:"operation_count"`
+	BlockingErrors []string                       `json:"blocking_errors,omitempty"`
+	Error          string                         `json:"error,omitempty"`
+	Result         *hookMigrationApplySummary     `json:"result,omitempty"`
+
+	execPlan hookMigrationExecutionPlan
+}
+
+// discoverHookMigrationWorkspaces returns start plus every workspace listed in
+// the multi-repo config (repos.primary, repos.additional) and in
+// external_projects. Relative entries resolve from the repository holding
+// config.yaml; entries that do not exist are skipped.
+func discoverHookMigrationWorkspaces(start string) []string {
+	base := start
+	if configFile := config.ConfigFileUsed(); configFile != "" {
+		base = filepath.Dir(filepath.Dir(configFile)) // .beads/config.yaml -> repo/
+	}
+
+	candidates := []string{start}
+	if multiRepo := config.GetMultiRepoConfig(); multiRepo != nil {
+		candidates = append(candidates, multiRepo.Primary)
+		candidates = append(candidates, multiRepo.Additional...)
+	}
+	projects := config.GetExternalProjects()
+	names := make([]string, 0, len(projects))
+	for name := range projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		candidates = append(candidates, projects[name])
+	}
+
+	seen := make(map[string]bool)
+	var workspaces []string
+	for _, candidate := range candidates {
+		path := resolveWorkspacePath(base, candidate)
+		if path == "" || seen[path] {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			continue
+		}
+		seen[path] = true
+		workspaces = append(workspaces, path)
+	}
+	return workspaces
+}
+
+// resolveWorkspacePath expands ~ and makes path absolute relative to base.
+func resolveWorkspacePath(base, path string) string {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return ""
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path[1:], "/"))
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	return filepath.Clean(path)
+}
+
+// planWorkspaceHookMigrations builds a hook migration plan for every
+// workspace. Worktrees and clones that share a hooks directory are planned
+// once, under the first workspace that reaches it.
+func planWorkspaceHookMigrations(workspaces []string) []workspaceHookMigration {
+	results := make([]workspaceHookMigration, 0, len(workspaces))
+	seenHooksDirs := make(map[string]bool)
+	for _, path := range workspaces {
+		ws := workspaceHookMigration{Path: path}
+		plan, err := doctor.PlanHookMigration(path)
+		if err != nil {
+			ws.Status = "failed"
+			ws.Error = fmt.Sprintf("building hook migration plan: %v", err)
+			results = append(results, ws)
+			continue
+		}
+		ws.Plan = plan
+		if !plan.IsGitRepo {
+			ws.Status = "not_git"
+			results = append(results, ws)
+			continue
+		}
+		if seenHooksDirs[plan.HooksDir] {
+			continue
+		}
+		seenHooksDirs[plan.HooksDir] = true
+
+		ws.execPlan = buildHookMigrationExecutionPlan(plan)
+		ws.Operations = ws.execPlan.outputOperations()
+		ws.OperationCount = ws.execPlan.operationCount()
+		ws.BlockingErrors = ws.execPlan.BlockingErrors
+		switch {
+		case len(ws.BlockingErrors) > 0:
+			ws.Status = "blocked"
+		case ws.OperationCount > 0:
+			ws.Status = "pending"
+		default:
+			ws.Status = "up_to_date"
+		}
+		results = append(results, ws)
+	}
+	return results
+}
+
+// applyWorkspaceHookMigrations applies every pending workspace plan, running
+// up to parallel workspaces at once. A failure in one workspace does not stop
+// the others; each records its own status.
+func applyWorkspaceHookMigrations(workspaces []workspaceHookMigration, parallel int) {
+	if parallel < 1 {
+		parallel = 1
+	}
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := range workspaces {
+		if workspaces[i].Status != "pending" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ws *workspaceHookMigration) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			summary, err := applyHookMigrationExecution(ws.execPlan)
+			ws.Result = &summary
+			if err != nil {
+				ws.Status = "failed"
+				ws.Error = err.Error()
+				return
+			}
+			ws.Status = "applied"
+		}(&workspaces[i])
+	}
+	wg.Wait()
+}
+
+// runMigrateHooksAllWorkspaces implements `bd migrate hooks --all-workspaces`.
+func runMigrateHooksAllWorkspaces(start string, mode hookMigrationMode, parallel int) error {
+	workspaces := planWorkspaceHookMigrations(discoverHookMigrationWorkspaces(start))
+	totalOps := 0
+	for _, ws := range workspaces {
+		if ws.Status == "pending" {
+			totalOps += ws.OperationCount
+		}
+	}
+
+	canceled := false
+	if mode.RequestedApply && totalOps > 0 {
+		if err := validateHookMigrationApplyConsent(mode.RequestedYes, term.IsTerminal(int(os.Stdin.Fd())), jsonOutput); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if !jsonOutput {
+			fmt.Println(strings.Join(formatWorkspaceHookMigrations(workspaces, mode), "\n"))
+		}
+		if !mode.RequestedYes {
+			confirmed, err := confirmHookMigrationApply(totalOps)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			canceled = !confirmed
+		}
+		if !canceled {
+			applyWorkspaceHookMigrations(workspaces, parallel)
+		}
+	}
+
+	failed := 0
+	for _, ws := range workspaces {
+		if ws.Status == "failed" || (mode.RequestedApply && ws.Status == "blocked") {
+			failed++
+		}
+	}
+
+	if jsonOutput {
+		status := "preview"
+		if mode.RequestedApply {
+			status = "applied"
+			if canceled {
+				status = "canceled"
+			}
+		}
+		if err := outputJSON(map[string]interface{}{
+			"status":          status,
+			"dry_run":         mode.RequestedDryRun,
+			"apply":           mode.RequestedApply,
+			"workspaces":      workspaces,
+			"operation_count": totalOps,
+			"failed_count":    failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		switch {
+		case canceled:
+			fmt.Println("\nMigration canceled.")
+			return nil
+		case mode.RequestedApply && totalOps > 0:
+			fmt.Println()
+			fmt.Println(strings.Join(formatWorkspaceHookMigrationResults(workspaces), "\n"))
+		default:
+			fmt.Println(strings.Join(formatWorkspaceHookMigrations(workspaces, mode), "\n"))
+		}
+	}
+
+	if failed > 0 && mode.RequestedApply && !canceled {
+		return &exitError{Code: 1}
+	}
+	return nil
+}
+
+// formatWorkspaceHookMigrations renders the aggregate plan across workspaces.
+func formatWorkspaceHookMigrations(workspaces []workspaceHookMigration, mode hookMigrationMode) []string {
+	lines := []string{"Hook migration plan (all workspaces)"}
+	if mode.RequestedDryRun {
+		lines = append(lines, "Mode: dry-run")
+	} else if mode.RequestedApply {
+		lines = append(lines, "Mode: apply")
+	}
+
+	counts := make(map[string]int)
+	totalOps := 0
+	for _, ws := range workspaces {
+		counts[ws.Status]++
+		if ws.Status == "pending" {
+			totalOps += ws.OperationCount
+		}
+	}
+	lines = append(lines, fmt.Sprintf("Workspaces: %d (pending: %d, up to date: %d, blocked: %d, not git: %d, failed: %d)",
+		len(workspaces), counts["pending"], counts["up_to_date"], counts["blocked"], counts["not_git"], counts["failed"]))
+	lines = append(lines, fmt.Sprintf("Operations: %d", totalOps))
+
+	for _, ws := range workspaces {
+		line := fmt.Sprintf("- %s: %s", ws.Path, ws.Status)
+		if ws.Status == "pending" {
+			line += fmt.Sprintf(" (%d operation(s), %d/%d hooks need migration)", ws.OperationCount, ws.Plan.NeedsMigrationCount, ws.Plan.TotalHooks)
+		}
+		lines = append(lines, line)
+		for _, op := range ws.Operations {
+			switch op.Action {
+			case "write_hook":
+				lines = append(lines, fmt.Sprintf("  write %s: %s", op.HookName, op.Path))
+			case "retire_sidecar":
+				lines = append(lines, fmt.Sprintf("  retire %s: %s -> %s", op.HookName, op.SourcePath, op.Destination))
+			}
+		}
+		for _, blocking := range ws.BlockingErrors {
+			lines = append(lines, "  blocked: "+blocking)
+		}
+		if ws.Error != "" {
+			lines = append(lines, "  error: "+ws.Error)
+		}
+	}
+
+	if mode.RequestedDryRun && totalOps > 0 {
+		lines = append(lines, "Next: run 'bd migrate hooks --all-workspaces --apply' to execute these plans.")
+	}
+	return lines
+}
+
+// formatWorkspaceHookMigrationResults renders the per-workspace apply report.
+func formatWorkspaceHookMigrationResults(workspaces []workspaceHookMigration) []string {
+	lines := []string{"Hook migration results"}
+	for _, ws := range workspaces {
+		switch ws.Status {
+		case "applied":
+			lines = append(lines, fmt.Sprintf("✓ %s: %d hook(s) written, %d artifact(s) retired",
+				ws.Path, ws.Result.WrittenHookCount, ws.Result.RetiredCount))
+		case "failed":
+			lines = append(lines, fmt.Sprintf("✗ %s: %s", ws.Path, ws.Error))
+		case "blocked":
+			lines = append(lines, fmt.Sprintf("✗ %s: blocked: %s", ws.Path, strings.Join(ws.BlockingErrors, "; ")))
+		default:
+			lines = append(lines, fmt.Sprintf("- %s: %s", ws.Path, ws.Status))
+		}
+	}
+	return lines
+}
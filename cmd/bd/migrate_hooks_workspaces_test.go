@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWorkspaceHookMigrations_PlanAndApply(t *testing.T) {
+	legacyShim := "#!/usr/bin/env sh\n# bd-shim v2\n# bd-hooks-version: 0.56.1\nexec bd hooks run pre-commit \"$@\"\n"
+
+	repoA, hooksA := setupHookMigrationRepo(t)
+	writeHookMigrationFile(t, filepath.Join(hooksA, "pre-commit"), legacyShim)
+	repoB, hooksB := setupHookMigrationRepo(t)
+	writeHookMigrationFile(t, filepath.Join(hooksB, "pre-commit"), legacyShim)
+	repoC, _ := setupHookMigrationRepo(t)
+	notGit := t.TempDir()
+
+	// repoA listed twice (e.g. a subdirectory) shares a hooks dir and is planned once.
+	subdir := filepath.Join(repoA, "sub")
+	if err := os.MkdirAll(subdir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	workspaces := planWorkspaceHookMigrations([]string{repoA, repoB, repoC, notGit, subdir})
+	if len(workspaces) != 4 {
+		t.Fatalf("expected 4 workspaces, got %d: %+v", len(workspaces), workspaces)
+	}
+	statuses := make(map[string]string)
+	for _, ws := range workspaces {
+		statuses[ws.Path] = ws.Status
+	}
+	want := map[string]string{repoA: "pending", repoB: "pending", repoC: "up_to_date", notGit: "not_git"}
+	for path, status := range want {
+		if statuses[path] != status {
+			t.Errorf("%s: status %q, want %q", path, statuses[path], status)
+		}
+	}
+
+	applyWorkspaceHookMigrations(workspaces, 2)
+	for _, ws := range workspaces {
+		if ws.Path == repoA || ws.Path == repoB {
+			if ws.Status != "applied" || ws.Result == nil || ws.Result.WrittenHookCount != 1 {
+				t.Errorf("%s: expected applied with 1 hook written, got %s %+v (%s)", ws.Path, ws.Status, ws.Result, ws.Error)
+			}
+		}
+	}
+	for _, hooksDir := range []string{hooksA, hooksB} {
+		if rendered := mustReadHookMigrationFile(t, filepath.Join(hooksDir, "pre-commit")); !strings.Contains(rendered, hookSectionBeginPrefix) {
+			t.Errorf("expected %s to be migrated, got:\n%s", hooksDir, rendered)
+		}
+	}
+
+	report := strings.Join(formatWorkspaceHookMigrationResults(workspaces), "\n")
+	if !strings.Contains(report, "✓ "+repoA) || !strings.Contains(report, notGit+": not_git") {
+		t.Errorf("unexpected results report:\n%s", report)
+	}
+}
+
+func TestResolveWorkspacePath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	root := t.TempDir()
+	base := filepath.Join(root, "main")
+	cases := map[string]string{
+		"":               "",
+		"../other":       filepath.Join(root, "other"),
+		base:             base,
+		"~/code/project": filepath.Join(home, "code", "project"),
+	}
+	for in, want := range cases {
+		if got := resolveWorkspacePath(base, in); got != want {
+			t.Errorf("resolveWorkspacePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
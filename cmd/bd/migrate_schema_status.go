@@ -0,0 +1,101 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/schema"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// isSchemaPreview reports whether cmd is `bd migrate schema --status` or
+// `bd migrate schema --dry-run`. Those open the store like a read-only
+// command, so a migration the open may not apply (remote-migrate gate, dirty
+// tables) stays pending and can be inspected instead of failing the open.
+func isSchemaPreview(cmd *cobra.Command) bool {
+	if cmd.Name() != "schema" || cmd.Parent() == nil || cmd.Parent().Name() != "migrate" {
+		return false
+	}
+	status, _ := cmd.Flags().GetBool("status")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	return status || dryRun
+}
+
+// handleSchemaStatus reports the migration cursor and, for --dry-run, the SQL
+// of pending migrations. It never applies anything.
+func handleSchemaStatus(dryRun bool) error {
+	st := getStore()
+	if st == nil {
+		return HandleErrorWithHint("no database", "Run 'bd init' to create a new database")
+	}
+	inspector, ok := storage.UnwrapStore(st).(storage.SchemaInspector)
+	if !ok {
+		return HandleErrorRespectJSON("current storage backend does not support schema inspection")
+	}
+
+	var statuses []schema.MigrationStatus
+	var pendingSQL string
+	err := inspector.InspectSchema(rootCtx, func(tx *sql.Tx) error {
+		var err error
+		if statuses, err = schema.Status(rootCtx, tx); err != nil {
+			return err
+		}
+		if dryRun {
+			pendingSQL, err = schema.PendingSQL(rootCtx, tx)
+		}
+		return err
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("reading schema status: %v", err)
+	}
+
+	current, pending := 0, []schema.MigrationStatus{}
+	for _, m := range statuses {
+		switch {
+		case !m.Applied:
+			pending = append(pending, m)
+		case !m.Ignored && m.Version > current:
+			current = m.Version
+		}
+	}
+
+	if jsonOutput {
+		result := map[string]interface{}{
+			"current_version": current,
+			"latest_version":  schema.LatestVersion(),
+			"pending":         pending,
+		}
+		if dryRun {
+			result["sql"] = pendingSQL
+		}
+		return outputJSON(result)
+	}
+
+	if dryRun {
+		if pendingSQL == "" {
+			fmt.Println("-- No pending schema migrations")
+			return nil
+		}
+		fmt.Print(pendingSQL)
+		return nil
+	}
+
+	fmt.Printf("Schema version: v%d (binary expects v%d)\n", current, schema.LatestVersion())
+	if len(pending) == 0 {
+		fmt.Printf("%s\n", ui.RenderPass("✓ No pending migrations"))
+		return nil
+	}
+	fmt.Printf("\nPending migrations (%d):\n", len(pending))
+	for _, m := range pending {
+		scope := ""
+		if m.Ignored {
+			scope = ui.RenderMuted(" (clone-local tables)")
+		}
+		fmt.Printf("  %04d  %s%s\n", m.Version, m.Name, scope)
+	}
+	fmt.Println("\nRun 'bd migrate schema' to apply them, or --dry-run to preview the SQL.")
+	return nil
+}
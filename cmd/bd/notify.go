@@ -0,0 +1,426 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/notify"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// notifyFlushBudget bounds how long post-command delivery may delay exit.
+// Anything not delivered in time stays queued for the next command.
+const notifyFlushBudget = 5 * time.Second
+
+var notifyCmd = &cobra.Command{
+	Use:     "notify",
+	GroupID: "advanced",
+	Short:   "Manage webhook notifications",
+	Long: `Manage webhook notifications for issue lifecycle events.
+
+Webhooks are configured under notify.webhooks in .beads/config.yaml (or
+config.local.yaml, or ~/.config/bd/config.yaml to apply to every project):
+
+  notify:
+    webhooks:
+      team-slack:
+        url: ${SLACK_WEBHOOK_URL}
+        events: [closed, p0_created, blocked_stale]
+        blocked_days: 5
+      alice-ready:
+        url: https://example.com/hooks/bd
+        events: [ready]
+        assignee: alice
+
+Events:
+  closed          An issue was closed
+  p0_created      A P0 issue was created
+  blocked_stale   An issue has been blocked for blocked_days (default 3)
+  ready           Issues became ready for an assignee (all assignees, or
+                  only the webhook's assignee when set)
+
+Payloads are Slack ({"text": ...}), Discord ({"content": ...}) or generic
+JSON (the event itself). The format is inferred from the URL unless set
+with format:. URLs may reference environment variables to keep secrets out
+of tracked config.
+
+Deliveries are queued in .beads/notify/outbox.jsonl and sent after the
+command that raised them finishes, so endpoints never slow down writes.
+Failures are retried with exponential backoff on later commands; after
+5 attempts, or a 4xx rejection, they move to .beads/notify/dead-letter.jsonl.
+blocked_stale and ready are found by a periodic scan (every
+notify.check-interval, default 1h) that runs after write commands, or on
+demand with 'bd notify check'. Each condition is reported once.
+
+closed and p0_created are raised by the storage hooks, so BD_NO_HOOKS=1 (or
+no-hooks: true) suppresses them as well.
+
+Examples:
+  bd notify list                 # Webhooks and queue status
+  bd notify test team-slack      # Send a sample notification now
+  bd notify check                # Scan blocked/ready now and deliver
+  bd notify flush                # Deliver due queued notifications`,
+}
+
+var notifyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured webhooks and queue status",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhooks, outbox, err := loadNotifySetup()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		pending, err := outbox.Pending()
+		if err != nil {
+			return HandleErrorRespectJSON("read outbox: %v", err)
+		}
+		dead, err := outbox.DeadLetters()
+		if err != nil {
+			return HandleErrorRespectJSON("read dead-letter log: %v", err)
+		}
+
+		if jsonOutput {
+			if webhooks == nil {
+				webhooks = []*notify.Webhook{}
+			}
+			return outputJSON(map[string]interface{}{
+				"webhooks":     webhooks,
+				"pending":      len(pending),
+				"dead_letters": len(dead),
+			})
+		}
+		if len(webhooks) == 0 {
+			fmt.Println("No webhooks configured (see 'bd notify --help').")
+		}
+		for _, w := range webhooks {
+			line := fmt.Sprintf("%s  %s  %v", ui.RenderBold(w.Name), w.Format, w.Events)
+			if w.Assignee != "" {
+				line += "  assignee=" + w.Assignee
+			}
+			if slices.Contains(w.Events, notify.EventBlockedStale) {
+				line += fmt.Sprintf("  blocked_days=%d", w.BlockedDays)
+			}
+			fmt.Println(line)
+		}
+		fmt.Printf("\n%d pending, %d dead-lettered\n", len(pending), len(dead))
+		return nil
+	},
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test <webhook>",
+	Short: "Send a test notification to a webhook",
+	Long: `Send a sample "closed" notification straight to a webhook, bypassing the
+outbox, and report whether the endpoint accepted it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhooks, outbox, err := loadNotifySetup()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		idx := slices.IndexFunc(webhooks, func(w *notify.Webhook) bool { return w.Name == args[0] })
+		if idx < 0 {
+			return HandleErrorRespectJSON("no webhook named %q in notify.webhooks", args[0])
+		}
+		ev := &notify.Event{
+			Kind: notify.EventClosed,
+			Time: time.Now().UTC(),
+			Issue: &notify.IssueSummary{
+				ID:          "bd-test",
+				Title:       "Test notification from bd",
+				Status:      string(types.StatusClosed),
+				Priority:    2,
+				CloseReason: "webhook test",
+			},
+		}
+		ctx, cancel := context.WithTimeout(rootCtx, 15*time.Second)
+		defer cancel()
+		if err := outbox.Send(ctx, webhooks[idx], ev); err != nil {
+			return HandleErrorRespectJSON("webhook %s: %v", args[0], err)
+		}
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"webhook": args[0], "delivered": true})
+		}
+		fmt.Printf("%s Test notification delivered to %s\n", ui.RenderPass("✓"), args[0])
+		return nil
+	},
+}
+
+var notifyCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Scan for blocked and ready conditions now and deliver",
+	Long: `Scan for issues blocked longer than each webhook's blocked_days and for
+newly ready work, queue notifications for anything not reported before,
+and deliver the outbox. This runs automatically every notify.check-interval
+after write commands; use it from cron for a steady cadence.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("notify check is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("notify")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		webhooks, outbox, err := loadNotifySetup()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		queued, err := runNotifyCheck(rootCtx, store, webhooks, outbox, time.Now())
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		result, err := outbox.Flush(rootCtx, webhooks)
+		if err != nil {
+			return HandleErrorRespectJSON("flush outbox: %v", err)
+		}
+		return printNotifyResult(queued, result)
+	},
+}
+
+var notifyFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Deliver queued notifications that are due",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhooks, outbox, err := loadNotifySetup()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		result, err := outbox.Flush(rootCtx, webhooks)
+		if err != nil {
+			return HandleErrorRespectJSON("flush outbox: %v", err)
+		}
+		return printNotifyResult(-1, result)
+	},
+}
+
+func printNotifyResult(queued int, result notify.FlushResult) error {
+	if jsonOutput {
+		out := map[string]interface{}{
+			"delivered":     result.Delivered,
+			"retrying":      result.Retrying,
+			"dead_lettered": result.DeadLettered,
+		}
+		if queued >= 0 {
+			out["queued"] = queued
+		}
+		return outputJSON(out)
+	}
+	if queued >= 0 {
+		fmt.Printf("Queued %d notification(s)\n", queued)
+	}
+	fmt.Printf("Delivered %d, retrying %d, dead-lettered %d\n", result.Delivered, result.Retrying, result.DeadLettered)
+	return nil
+}
+
+// loadNotifySetup parses the configured webhooks and opens the outbox of
+// the active workspace.
+func loadNotifySetup() ([]*notify.Webhook, *notify.Outbox, error) {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return nil, nil, fmt.Errorf("%s", activeWorkspaceNotFoundError())
+	}
+	webhooks, err := notify.ParseWebhooks(config.NotifyWebhooks())
+	if err != nil {
+		return nil, nil, err
+	}
+	return webhooks, notify.NewOutbox(beadsDir), nil
+}
+
+// registerNotifyListener queues closed and p0_created notifications as the
+// hook runner observes mutations. Invalid webhook config is reported as a
+// warning and disables notifications for the command.
+func registerNotifyListener(runner *hooks.Runner, beadsDir string) {
+	raw := config.NotifyWebhooks()
+	if runner == nil || len(raw) == 0 {
+		return
+	}
+	webhooks, err := notify.ParseWebhooks(raw)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: notifications disabled: %v\n", err)
+		return
+	}
+	outbox := notify.NewOutbox(beadsDir)
+	runner.AddListener(func(event string, issue *types.Issue) {
+		ev := notify.LifecycleEvent(event, issue, time.Now())
+		if ev == nil {
+			return
+		}
+		if _, err := outbox.Enqueue(webhooks, ev); err != nil {
+			debug.Logf("notify: failed to queue %s for %s: %v\n", ev.Kind, issue.ID, err)
+		}
+	})
+}
+
+// maybeNotify runs after write commands: it performs the periodic
+// blocked/ready scan when due and delivers whatever is queued, within
+// notifyFlushBudget.
+func maybeNotify(ctx context.Context) {
+	if isSandboxMode() || len(config.NotifyWebhooks()) == 0 {
+		return
+	}
+	webhooks, outbox, err := loadNotifySetup()
+	if err != nil {
+		debug.Logf("notify: %v\n", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, notifyFlushBudget)
+	defer cancel()
+
+	st := getStore()
+	if st != nil && slices.ContainsFunc(webhooks, (*notify.Webhook).Polled) {
+		if lm, ok := storage.UnwrapStore(st).(storage.LifecycleManager); !ok || !lm.IsClosed() {
+			interval := config.GetDuration("notify.check-interval")
+			if interval == 0 {
+				interval = time.Hour
+			}
+			if state, err := outbox.LoadState(); err != nil {
+				debug.Logf("notify: %v\n", err)
+			} else if time.Since(state.LastCheck) >= interval {
+				if _, err := runNotifyCheck(ctx, st, webhooks, outbox, time.Now()); err != nil {
+					debug.Logf("notify: check failed: %v\n", err)
+				}
+			}
+		}
+	}
+
+	result, err := outbox.Flush(ctx, webhooks)
+	if err != nil {
+		debug.Logf("notify: flush failed: %v\n", err)
+		return
+	}
+	debug.Logf("notify: delivered %d, retrying %d, dead-lettered %d\n", result.Delivered, result.Retrying, result.DeadLettered)
+}
+
+// runNotifyCheck queues blocked_stale and ready notifications that have not
+// been sent before and records the scan in the notify state. Conditions
+// that no longer hold are forgotten, so an issue that is blocked (or
+// ready) again later is reported again.
+func runNotifyCheck(ctx context.Context, st storage.DoltStorage, webhooks []*notify.Webhook, outbox *notify.Outbox, now time.Time) (int, error) {
+	var blocked []*types.BlockedIssue
+	var ready []*types.Issue
+	var err error
+	if slices.ContainsFunc(webhooks, func(w *notify.Webhook) bool { return slices.Contains(w.Events, notify.EventBlockedStale) }) {
+		if blocked, err = st.GetBlockedIssues(ctx, types.WorkFilter{}); err != nil {
+			return 0, fmt.Errorf("get blocked issues: %w", err)
+		}
+	}
+	if slices.ContainsFunc(webhooks, func(w *notify.Webhook) bool { return slices.Contains(w.Events, notify.EventReady) }) {
+		if ready, err = st.GetReadyWork(ctx, types.WorkFilter{}); err != nil {
+			return 0, fmt.Errorf("get ready work: %w", err)
+		}
+	}
+
+	blockedDays := make(map[string]int, len(blocked))
+	for _, b := range blocked {
+		since, err := blockedSince(ctx, st, b)
+		if err != nil {
+			return 0, err
+		}
+		blockedDays[b.ID] = int(now.Sub(since).Hours() / 24)
+	}
+
+	return outbox.UpdateState(func(state *notify.State) ([]notify.Notification, error) {
+		var out []notify.Notification
+		for _, w := range webhooks {
+			sent := make(map[string]bool)
+			for _, key := range state.Sent[w.Name] {
+				sent[key] = true
+			}
+			var keep []string
+			var events []*notify.Event
+
+			if slices.Contains(w.Events, notify.EventBlockedStale) {
+				for _, b := range blocked {
+					key := "blocked:" + b.ID
+					switch {
+					case sent[key]:
+						keep = append(keep, key)
+					case blockedDays[b.ID] >= w.BlockedDays:
+						summary := notify.Summarize(&b.Issue)
+						events = append(events, &notify.Event{Kind: notify.EventBlockedStale, Time: now.UTC(), Issue: &summary, BlockedDays: blockedDays[b.ID]})
+						keep = append(keep, key)
+					}
+				}
+			}
+
+			if slices.Contains(w.Events, notify.EventReady) {
+				byAssignee := make(map[string][]notify.IssueSummary)
+				for _, issue := range ready {
+					if issue.Assignee == "" || (w.Assignee != "" && issue.Assignee != w.Assignee) {
+						continue
+					}
+					key := "ready:" + issue.ID
+					keep = append(keep, key)
+					if !sent[key] {
+						byAssignee[issue.Assignee] = append(byAssignee[issue.Assignee], notify.Summarize(issue))
+					}
+				}
+				assignees := make([]string, 0, len(byAssignee))
+				for a := range byAssignee {
+					assignees = append(assignees, a)
+				}
+				sort.Strings(assignees)
+				for _, a := range assignees {
+					events = append(events, &notify.Event{Kind: notify.EventReady, Time: now.UTC(), Assignee: a, Issues: byAssignee[a]})
+				}
+			}
+
+			for _, ev := range events {
+				out = append(out, notify.Notification{Webhook: w, Event: ev})
+			}
+			sort.Strings(keep)
+			if len(keep) == 0 {
+				delete(state.Sent, w.Name)
+			} else {
+				state.Sent[w.Name] = keep
+			}
+		}
+		state.LastCheck = now.UTC()
+		return out, nil
+	})
+}
+
+// blockedSince estimates when an issue became blocked: the oldest blocking
+// edge to one of its current open blockers, falling back to its last update.
+func blockedSince(ctx context.Context, st storage.DoltStorage, b *types.BlockedIssue) (time.Time, error) {
+	deps, err := st.GetDependencyRecords(ctx, b.ID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get dependencies of %s: %w", b.ID, err)
+	}
+	var since time.Time
+	for _, dep := range deps {
+		if !slices.Contains(b.BlockedBy, dep.DependsOnID) || dep.CreatedAt.IsZero() {
+			continue
+		}
+		if since.IsZero() || dep.CreatedAt.Before(since) {
+			since = dep.CreatedAt
+		}
+	}
+	if since.IsZero() {
+		since = b.UpdatedAt
+	}
+	return since, nil
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyListCmd, notifyTestCmd, notifyCheckCmd, notifyFlushCmd)
+	rootCmd.AddCommand(notifyCmd)
+}
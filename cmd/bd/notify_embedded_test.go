@@ -0,0 +1,97 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEmbeddedNotify(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	var mu sync.Mutex
+	var received []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		_ = json.Unmarshal(body, &payload)
+		mu.Lock()
+		received = append(received, payload)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+	events := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		var out []string
+		for _, p := range received {
+			out = append(out, p["event"].(string))
+		}
+		return out
+	}
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "nt")
+
+	cfg, err := os.OpenFile(filepath.Join(beadsDir, "config.yaml"), os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = cfg.WriteString("\nnotify:\n  webhooks:\n    ci:\n      url: " + srv.URL + "\n      events: [closed, p0_created, ready]\n")
+	_ = cfg.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("lifecycle_events", func(t *testing.T) {
+		bdCreateSilent(t, bd, dir, "Routine task", "-p", "2")
+		if got := events(); len(got) != 0 {
+			t.Fatalf("P2 create sent %v, want nothing", got)
+		}
+		critical := bdCreateSilent(t, bd, dir, "Prod down", "-p", "0")
+		bdCommand(t, bd, dir, "close", critical, "--reason", "fixed")
+
+		got := events()
+		if strings.Join(got, ",") != "p0_created,closed" {
+			t.Fatalf("events = %v, want p0_created then closed", got)
+		}
+		if issue, _ := received[1]["issue"].(map[string]interface{}); issue["id"] != critical {
+			t.Errorf("closed payload = %v", received[1])
+		}
+	})
+
+	t.Run("ready_reported_once", func(t *testing.T) {
+		bdCreateSilent(t, bd, dir, "Alice work", "--assignee", "alice")
+		before := len(events())
+
+		out := bdCommand(t, bd, dir, "notify", "check", "--json")
+		if !strings.Contains(out, `"queued": 1`) || !strings.Contains(out, `"delivered": 1`) {
+			t.Fatalf("first check = %s", out)
+		}
+		out = bdCommand(t, bd, dir, "notify", "check", "--json")
+		if !strings.Contains(out, `"queued": 0`) {
+			t.Errorf("second check = %s, want nothing new", out)
+		}
+		if got := events(); len(got) != before+1 || got[before] != "ready" {
+			t.Errorf("events = %v, want one ready after %d", got, before)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		out := bdCommand(t, bd, dir, "notify", "list", "--json")
+		if !strings.Contains(out, `"name": "ci"`) || !strings.Contains(out, `"pending": 0`) {
+			t.Errorf("notify list = %s", out)
+		}
+	})
+}
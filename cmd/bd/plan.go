@@ -0,0 +1,361 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// planWorkdayMinutes is how much work a "d" of --capacity holds; a "w" is
+// five of them.
+const planWorkdayMinutes = 8 * 60
+
+var planCmd = &cobra.Command{
+	Use:     "plan",
+	GroupID: "deps",
+	Short:   "Plan a sprint of ready work that fits a capacity",
+	Long: `Select the open work that fits a time budget, most important first,
+without breaking dependency order.
+
+--capacity is each person's budget: minutes (90m), hours (30h), working
+days of 8 hours (10d) or weeks of 5 days (2w); a bare number is days.
+Issues are picked by priority, then smaller estimate first, as long as they
+fit the remaining capacity. An issue qualifies when it is open or in
+progress and every open issue blocking it (blocks, conditional-blocks,
+waits-for) is already in the plan, so the plan lists issues in an order
+they can be worked. Epics, deferred issues and issues whose defer date has
+not come are left out. Issues without an estimate count as
+--default-estimate minutes.
+
+With --assignee each listed person gets the capacity. Only their issues
+(as assignee or co-assignee) and unassigned issues are planned; an
+unassigned issue goes to the person with the most capacity left.
+
+By default bd plan only reports. --label adds a label (e.g. a sprint
+name) to every planned issue and --assign makes each planned unassigned
+issue its person's, in one transaction.
+
+Examples:
+  bd plan --capacity 10d
+  bd plan --capacity 10d --assignee alice,bob
+  bd plan --capacity 2w --assignee alice,bob --label sprint-12 --assign
+  bd plan --capacity 30h --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("plan is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("plan")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		capacityStr, _ := cmd.Flags().GetString("capacity")
+		people, _ := cmd.Flags().GetStringSlice("assignee")
+		defaultEstimate, _ := cmd.Flags().GetInt("default-estimate")
+		label, _ := cmd.Flags().GetString("label")
+		assign, _ := cmd.Flags().GetBool("assign")
+
+		capacity, err := parsePlanCapacity(capacityStr)
+		if err != nil {
+			return HandleErrorRespectJSON("invalid --capacity %q: %v", capacityStr, err)
+		}
+		if defaultEstimate < 0 {
+			return HandleErrorRespectJSON("--default-estimate must not be negative")
+		}
+		for i, name := range people {
+			people[i] = resolveAssignee(strings.TrimSpace(name))
+		}
+		people = slices.DeleteFunc(people, func(name string) bool { return name == "" })
+		if assign && len(people) == 0 {
+			return HandleErrorRespectJSON("--assign needs --assignee")
+		}
+		label = strings.TrimSpace(label)
+		if label != "" || assign {
+			CheckReadonly("plan")
+		}
+
+		ctx := rootCtx
+		g, err := loadOpenBlockingGraph(ctx, store)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		issues := make([]*types.Issue, 0, len(g.issues))
+		for _, id := range g.sortedIDs() {
+			issues = append(issues, g.issues[id])
+		}
+		hydrateAssignments(ctx, store, issues)
+
+		plan := g.plan(people, capacity, defaultEstimate, time.Now())
+		plan.Label = label
+		if (label != "" || assign) && len(plan.Issues) > 0 {
+			if err := applyPlan(plan, assign); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			plan.Applied = true
+		}
+
+		if jsonOutput {
+			return outputJSON(plan)
+		}
+		printPlan(plan, g)
+		return nil
+	},
+}
+
+// sprintPlan is the output of bd plan.
+type sprintPlan struct {
+	CapacityMinutes int            `json:"capacity_minutes"` // per person
+	People          []*planPerson  `json:"people"`
+	Issues          []*plannedItem `json:"issues"` // in an order that respects dependencies
+	PlannedMinutes  int            `json:"planned_minutes"`
+	Label           string         `json:"label,omitempty"`
+	Applied         bool           `json:"applied"`
+}
+
+// planPerson is one person's share of a plan. Assignee is empty when no
+// --assignee was given and the capacity is a single pool.
+type planPerson struct {
+	Assignee        string `json:"assignee,omitempty"`
+	CapacityMinutes int    `json:"capacity_minutes"`
+	PlannedMinutes  int    `json:"planned_minutes"`
+}
+
+// plannedItem is one issue in a plan.
+type plannedItem struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Priority         int      `json:"priority"`
+	Assignee         string   `json:"assignee,omitempty"` // who it is planned for
+	Assign           bool     `json:"assign,omitempty"`   // unassigned until --assign
+	EstimatedMinutes int      `json:"estimated_minutes"`
+	Unestimated      bool     `json:"unestimated,omitempty"` // counted at --default-estimate
+	After            []string `json:"after,omitempty"`       // planned issues it waits on
+}
+
+// parsePlanCapacity parses a --capacity value into minutes.
+func parsePlanCapacity(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty capacity")
+	}
+	unit := planWorkdayMinutes
+	switch s[len(s)-1] {
+	case 'm', 'M':
+		unit, s = 1, s[:len(s)-1]
+	case 'h', 'H':
+		unit, s = 60, s[:len(s)-1]
+	case 'd', 'D':
+		s = s[:len(s)-1]
+	case 'w', 'W':
+		unit, s = 5*planWorkdayMinutes, s[:len(s)-1]
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("use a number with m, h, d or w")
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("capacity must be positive")
+	}
+	return int(n * float64(unit)), nil
+}
+
+// plan greedily picks issues for the people's capacity: each round takes,
+// among the qualifying issues whose open blockers are all planned and that
+// fit someone's remaining capacity, the one with the best priority, then the
+// smallest estimate. It stops when nothing else fits, so the plan is
+// maximal: every issue left out is blocked, taken by someone else or too big.
+func (g *blockingGraph) plan(people []string, capacity, defaultMinutes int, now time.Time) *sprintPlan {
+	p := &sprintPlan{CapacityMinutes: capacity, Issues: []*plannedItem{}}
+	if len(people) == 0 {
+		p.People = []*planPerson{{CapacityMinutes: capacity}}
+	}
+	for _, name := range people {
+		if !slices.ContainsFunc(p.People, func(pp *planPerson) bool { return pp.Assignee == name }) {
+			p.People = append(p.People, &planPerson{Assignee: name, CapacityMinutes: capacity})
+		}
+	}
+
+	// candidates maps each plannable issue to the people it may go to.
+	candidates := make(map[string][]*planPerson)
+	for id, issue := range g.issues {
+		if issue.Status != types.StatusOpen && issue.Status != types.StatusInProgress {
+			continue
+		}
+		if issue.IssueType == types.TypeEpic || (issue.DeferUntil != nil && issue.DeferUntil.After(now)) {
+			continue
+		}
+		if len(people) == 0 {
+			candidates[id] = p.People
+			continue
+		}
+		var on []*planPerson
+		for _, pp := range p.People {
+			if issue.Assignee == pp.Assignee || slices.Contains(issue.Assignees, pp.Assignee) {
+				on = append(on, pp)
+			}
+		}
+		if len(on) == 0 && issue.Assignee == "" && len(issue.Assignees) == 0 {
+			on = p.People
+		}
+		if len(on) > 0 {
+			candidates[id] = on
+		}
+	}
+
+	estimate := func(issue *types.Issue) (int, bool) {
+		if issue.EstimatedMinutes == nil {
+			return defaultMinutes, true
+		}
+		return *issue.EstimatedMinutes, false
+	}
+	planned := make(map[string]bool)
+	ids := g.sortedIDs()
+	for {
+		var best *types.Issue
+		var bestPerson *planPerson
+		bestMinutes := 0
+		for _, id := range ids {
+			on, ok := candidates[id]
+			if !ok || planned[id] {
+				continue
+			}
+			if slices.ContainsFunc(g.blockers[id], func(b string) bool { return !planned[b] }) {
+				continue
+			}
+			issue := g.issues[id]
+			minutes, _ := estimate(issue)
+			// The person with the most capacity left, if it fits at all.
+			var person *planPerson
+			for _, pp := range on {
+				left := pp.CapacityMinutes - pp.PlannedMinutes
+				if left >= minutes && (person == nil || left > person.CapacityMinutes-person.PlannedMinutes) {
+					person = pp
+				}
+			}
+			if person == nil {
+				continue
+			}
+			if best == nil || issue.Priority < best.Priority || (issue.Priority == best.Priority && minutes < bestMinutes) {
+				best, bestPerson, bestMinutes = issue, person, minutes
+			}
+		}
+		if best == nil {
+			break
+		}
+		planned[best.ID] = true
+		bestPerson.PlannedMinutes += bestMinutes
+		p.PlannedMinutes += bestMinutes
+		_, unestimated := estimate(best)
+		p.Issues = append(p.Issues, &plannedItem{
+			ID:               best.ID,
+			Title:            best.Title,
+			Priority:         best.Priority,
+			Assignee:         bestPerson.Assignee,
+			Assign:           bestPerson.Assignee != "" && best.Assignee == "",
+			EstimatedMinutes: bestMinutes,
+			Unestimated:      unestimated,
+			After:            g.blockers[best.ID],
+		})
+	}
+	return p
+}
+
+// applyPlan labels and assigns the planned issues in one transaction.
+// Issues that already carry the label are not labelled again.
+func applyPlan(plan *sprintPlan, assign bool) error {
+	ctx := rootCtx
+	actor := getActorWithGit()
+	ids := make([]string, len(plan.Issues))
+	for i, item := range plan.Issues {
+		ids[i] = item.ID
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("loading labels: %w", err)
+	}
+	msg := fmt.Sprintf("bd: plan %d issue(s)", len(plan.Issues))
+	if plan.Label != "" {
+		msg += " for " + plan.Label
+	}
+	err = transact(ctx, store, msg, func(tx storage.Transaction) error {
+		for _, item := range plan.Issues {
+			if plan.Label != "" && !slices.Contains(labels[item.ID], plan.Label) {
+				if err := tx.AddLabel(ctx, item.ID, plan.Label, actor); err != nil {
+					return fmt.Errorf("label %s: %w", item.ID, err)
+				}
+			}
+			if assign && item.Assign {
+				if err := tx.UpdateIssue(ctx, item.ID, map[string]interface{}{"assignee": item.Assignee}, actor); err != nil {
+					return fmt.Errorf("assign %s: %w", item.ID, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	commandDidWrite.Store(true)
+	return nil
+}
+
+func printPlan(plan *sprintPlan, g *blockingGraph) {
+	if len(plan.Issues) == 0 {
+		fmt.Println("No ready work fits the capacity")
+		return
+	}
+	fmt.Printf("\n%s Plan: %d issue(s), %d of %d min\n\n", ui.RenderAccent("📅"),
+		len(plan.Issues), plan.PlannedMinutes, plan.CapacityMinutes*len(plan.People))
+	for i, item := range plan.Issues {
+		line := fmt.Sprintf("%3d. %s  %d min", i+1,
+			formatTreeNode(&types.TreeNode{Issue: *g.issues[item.ID], Depth: 1}, false), item.EstimatedMinutes)
+		if item.Unestimated {
+			line += ui.RenderMuted(" (unestimated)")
+		}
+		if item.Assignee != "" {
+			line += "  → " + item.Assignee
+		}
+		if len(item.After) > 0 {
+			line += ui.RenderMuted("  after " + strings.Join(item.After, ", "))
+		}
+		fmt.Println(line)
+	}
+	if plan.People[0].Assignee != "" {
+		fmt.Println()
+		for _, pp := range plan.People {
+			fmt.Printf("  %s: %d of %d min\n", pp.Assignee, pp.PlannedMinutes, pp.CapacityMinutes)
+		}
+	}
+	if plan.Applied {
+		fmt.Println()
+		if plan.Label != "" {
+			fmt.Printf("%s Labelled the planned issues %s\n", ui.RenderPass("✓"), plan.Label)
+		}
+		if slices.ContainsFunc(plan.Issues, func(item *plannedItem) bool { return item.Assign }) {
+			fmt.Printf("%s Assigned the planned unassigned issues\n", ui.RenderPass("✓"))
+		}
+	}
+}
+
+func init() {
+	planCmd.Flags().String("capacity", "", "Each person's time budget (e.g. 90m, 30h, 10d, 2w)")
+	planCmd.Flags().StringSlice("assignee", nil, "People to plan for, comma-separated (\"me\" for yourself)")
+	planCmd.Flags().Int("default-estimate", 60, "Minutes counted for issues without an estimate")
+	planCmd.Flags().String("label", "", "Add this label to every planned issue")
+	planCmd.Flags().Bool("assign", false, "Assign each planned unassigned issue to its person")
+	_ = planCmd.MarkFlagRequired("capacity")
+	rootCmd.AddCommand(planCmd)
+}
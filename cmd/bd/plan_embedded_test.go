@@ -0,0 +1,51 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedPlan(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "pl")
+	schema := bdCreateSilent(t, bd, dir, "Schema", "--estimate", "120", "--priority", "2")
+	api := bdCreateSilent(t, bd, dir, "API", "--estimate", "240", "--priority", "0", "--assignee", "alice")
+	big := bdCreateSilent(t, bd, dir, "Rewrite", "--estimate", "5000", "--priority", "1")
+	bdDep(t, bd, dir, "add", api, schema)
+
+	out := bdCommand(t, bd, dir, "plan", "--capacity", "1d", "--assignee", "alice,bob", "--json")
+	var plan sprintPlan
+	if err := json.Unmarshal([]byte(out), &plan); err != nil {
+		t.Fatalf("parse plan JSON: %v\n%s", err, out)
+	}
+	if len(plan.Issues) != 2 || plan.Issues[0].ID != schema || plan.Issues[1].ID != api || plan.Applied {
+		t.Fatalf("plan = %s", out)
+	}
+
+	bdCommand(t, bd, dir, "plan", "--capacity", "1d", "--assignee", "alice,bob", "--label", "sprint-1", "--assign")
+	for _, id := range []string{schema, api} {
+		if issue := bdShow(t, bd, dir, id); !slices.Contains(issue.Labels, "sprint-1") {
+			t.Errorf("%s labels = %v, want sprint-1", id, issue.Labels)
+		}
+	}
+	if got := bdShow(t, bd, dir, schema).Assignee; got != "alice" {
+		t.Errorf("schema assignee = %q, want alice (first listed on a tie)", got)
+	}
+	if issue := bdShow(t, bd, dir, big); len(issue.Labels) != 0 || issue.Assignee != "" {
+		t.Errorf("an issue over capacity was planned: %+v", issue)
+	}
+
+	if out := bdCommand(t, bd, dir, "plan", "--capacity", "30m"); !strings.Contains(out, "No ready work fits") {
+		t.Errorf("expected nothing to fit: %s", out)
+	}
+}
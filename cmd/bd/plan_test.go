@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParsePlanCapacity(t *testing.T) {
+	for in, want := range map[string]int{"90m": 90, "30h": 1800, "10d": 4800, "2": 960, "1w": 2400, "1.5d": 720} {
+		if got, err := parsePlanCapacity(in); err != nil || got != want {
+			t.Errorf("parsePlanCapacity(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "d", "0h", "-1d", "10x"} {
+		if _, err := parsePlanCapacity(in); err == nil {
+			t.Errorf("parsePlanCapacity(%q) should fail", in)
+		}
+	}
+}
+
+func planIDs(p *sprintPlan) string {
+	var ids []string
+	for _, item := range p.Issues {
+		ids = append(ids, item.ID+"@"+item.Assignee)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestPlan(t *testing.T) {
+	minutes := func(m int) *int { return &m }
+	later := time.Now().Add(24 * time.Hour)
+	issue := func(id string, priority int, estimate *int, assignee string, blockers ...string) *types.Issue {
+		is := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: priority, EstimatedMinutes: estimate, Assignee: assignee}
+		for _, b := range blockers {
+			is.Dependencies = append(is.Dependencies, &types.Dependency{IssueID: id, DependsOnID: b, Type: types.DepBlocks})
+		}
+		return is
+	}
+	issues := []*types.Issue{
+		issue("schema", 2, minutes(120), ""),
+		issue("api", 0, minutes(240), "alice", "schema"), // waits for schema despite its priority
+		issue("docs", 1, nil, "bob"),
+		issue("big", 1, minutes(2000), ""),
+		issue("carol", 0, minutes(30), "carol"),
+		issue("blocked", 0, minutes(30), "", "held"),
+		{ID: "held", Status: types.StatusBlocked, Priority: 3},
+		{ID: "epic", Status: types.StatusOpen, IssueType: types.TypeEpic},
+		{ID: "later", Status: types.StatusOpen, DeferUntil: &later},
+	}
+
+	p := newBlockingGraph(issues).plan([]string{"alice", "bob"}, 480, 60, time.Now())
+	if got := planIDs(p); got != "docs@bob schema@alice api@alice" {
+		t.Errorf("plan = %s", got)
+	}
+	if p.PlannedMinutes != 420 || p.People[0].PlannedMinutes != 360 || p.People[1].PlannedMinutes != 60 {
+		t.Errorf("planned %d min (alice %d, bob %d), want 420 (360, 60)", p.PlannedMinutes, p.People[0].PlannedMinutes, p.People[1].PlannedMinutes)
+	}
+	if !p.Issues[0].Unestimated || !p.Issues[1].Assign || p.Issues[2].Assign {
+		t.Errorf("unexpected item flags: %+v %+v %+v", p.Issues[0], p.Issues[1], p.Issues[2])
+	}
+	if after := p.Issues[2].After; len(after) != 1 || after[0] != "schema" {
+		t.Errorf("api after = %v, want [schema]", after)
+	}
+
+	// A single pool takes everyone's work, still in dependency order.
+	p = newBlockingGraph(issues).plan(nil, 400, 60, time.Now())
+	if got := planIDs(p); got != "carol@ docs@ schema@" {
+		t.Errorf("pooled plan = %s", got)
+	}
+}
@@ -35,15 +35,20 @@ Boolean operators (case-insensitive):
   expr AND expr     Both conditions must match
   expr OR expr      Either condition can match
   NOT expr          Negates the condition
+  expr expr         Juxtaposed terms are an implicit AND
   (expr)            Grouping with parentheses
 
+Value lists:
+  field=a,b         Matches any of the values (label, assignee, status, type, ...)
+  field!=a,b        Matches none of the values
+
 Supported fields:
-  status            Stored status (open, in_progress, blocked, deferred, closed). Note: dependency-blocked issues stay "open"; use 'bd blocked' to find them
+  status            Stored status (open, in_progress, blocked, deferred, closed). Note: dependency-blocked issues stay "open"; use the blocked term to find them
   priority          Priority level (0-4)
   type              Issue type (bug, feature, task, epic, chore, decision)
   assignee          Assigned user (use "none" for unassigned)
   owner             Issue owner
-  label             Issue label (use "none" for unlabeled)
+  label             Issue label (use "none" for unlabeled; quoted globs like "tech-*" match patterns)
   title             Search in title (contains)
   description       Search in description (contains, "none" for empty)
   notes             Search in notes (contains)
@@ -51,6 +56,8 @@ Supported fields:
   updated           Last update date/time
   started           Date/time issue first transitioned to in_progress
   closed            Close date/time
+  due               Due date/time
+  defer             Defer-until date/time
   id                Issue ID (supports wildcards: bd-*)
   spec              Spec ID (supports wildcards)
   pinned            Boolean (true/false)
@@ -58,9 +65,17 @@ Supported fields:
   template          Boolean (true/false)
   parent            Parent issue ID
   mol_type          Molecule type (swarm, patrol, work)
+  metadata.<key>    Custom field; ordering compares numbers, dates and strings
+
+Dependency terms (combine with AND only, not under OR or NOT; NOT blocked is allowed):
+  blocks:ID         Issues that block ID
+  blocked-by:ID     Issues blocked by ID
+  blocked           Issues blocked by an open dependency (same rule as bd blocked)
+  no-blockers       Issues with nothing blocking them (same rule as bd ready)
 
 Date values:
   Relative durations: 7d (7 days ago), 24h (24 hours ago), 2w (2 weeks ago)
+  Signed offsets from now: -30d (30 days ago), +3d (3 days from now)
   Absolute dates: 2025-01-15, 2025-01-15T10:00:00Z
   Natural language: tomorrow, "next monday", "in 3 days"
 
@@ -73,7 +88,12 @@ Examples:
   bd query "assignee=none AND type=task"
   bd query "created>30d AND status!=closed"
   bd query "label=frontend OR label=backend"
-  bd query "title=authentication AND priority=0"`,
+  bd query "title=authentication AND priority=0"
+  bd query "status=open label=stale created<-30d"
+  bd query "assignee=alice,bob label!=wontfix no-blockers"
+  bd query "blocks:bd-12 status!=closed"
+  bd query "due<+3d"
+  bd query "metadata.points>=5 AND metadata.review<+7d"`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -122,7 +142,7 @@ Examples:
 			return nil
 		}
 
-		eval := query.NewEvaluator(time.Now())
+		eval := query.NewEvaluator(time.Now()).WithMe(resolveMe)
 		result, err := eval.Evaluate(node)
 		if err != nil {
 			return HandleErrorRespectJSON("evaluating query: %v", err)
@@ -284,7 +304,7 @@ func init() {
 	queryCmd.Flags().Int("offset", 0, "Skip the first N matching results (0-based). Only supported under --proxied-server.")
 	queryCmd.Flags().BoolP("all", "a", false, "Include closed issues (default: exclude closed)")
 	queryCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	queryCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	queryCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee, metadata.<key>")
 	queryCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 	queryCmd.Flags().Bool("parse-only", false, "Only parse the query and show the AST (for debugging)")
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -237,6 +238,48 @@ func TestEmbeddedQuery(t *testing.T) {
 		}
 	})
 
+	// ===== Lists and Dependency Terms =====
+
+	queryIDs := func(t *testing.T, expr string) string {
+		t.Helper()
+		var ids []string
+		for _, r := range bdQueryJSON(t, bd, dir, expr) {
+			ids = append(ids, fmt.Sprint(r["id"]))
+		}
+		sort.Strings(ids)
+		return strings.Join(ids, ",")
+	}
+
+	t.Run("query_value_list", func(t *testing.T) {
+		want := []string{taskHigh.ID, bugMed.ID}
+		sort.Strings(want)
+		if got := queryIDs(t, "assignee=alice,bob"); got != strings.Join(want, ",") {
+			t.Errorf("assignee=alice,bob = %s, want %v", got, want)
+		}
+		if got := queryIDs(t, "type!=task,bug"); got != featureLow.ID {
+			t.Errorf("type!=task,bug = %s, want %s", got, featureLow.ID)
+		}
+	})
+
+	t.Run("query_dependency_terms", func(t *testing.T) {
+		bdCommand(t, bd, dir, "dep", "add", featureLow.ID, bugMed.ID)
+		if got := queryIDs(t, "blocks:"+featureLow.ID); got != bugMed.ID {
+			t.Errorf("blocks:%s = %s, want %s", featureLow.ID, got, bugMed.ID)
+		}
+		if got := queryIDs(t, "blocked-by:"+bugMed.ID); got != featureLow.ID {
+			t.Errorf("blocked-by:%s = %s, want %s", bugMed.ID, got, featureLow.ID)
+		}
+		if got := queryIDs(t, "blocked"); got != featureLow.ID {
+			t.Errorf("blocked = %s, want %s", got, featureLow.ID)
+		}
+		if got := queryIDs(t, "no-blockers type=feature"); got != "" {
+			t.Errorf("no-blockers type=feature = %s, want none", got)
+		}
+		if got := queryIDs(t, "(type=feature OR type=bug) NOT blocked"); got != bugMed.ID {
+			t.Errorf("NOT blocked = %s, want %s", got, bugMed.ID)
+		}
+	})
+
 	// ===== Error Cases =====
 
 	t.Run("query_no_expression", func(t *testing.T) {
@@ -45,7 +45,7 @@ func runQueryProxiedServer(cmd *cobra.Command, ctx context.Context, args []strin
 		return nil
 	}
 
-	eval := query.NewEvaluator(time.Now())
+	eval := query.NewEvaluator(time.Now()).WithMe(resolveMe)
 	result, err := eval.Evaluate(node)
 	if err != nil {
 		return HandleErrorRespectJSON("evaluating query: %v", err)
@@ -26,6 +26,11 @@ GetReadyWork API which applies blocker-aware semantics to find truly claimable w
 
 Note: 'bd list --ready' uses the same blocker-aware ready-work semantics.
 
+A blocker in another workspace (a dependency on an issue with another prefix)
+is looked up through the prefix routes in routes.jsonl. The issue stays out
+of ready work, and --claim skips it, until that blocker is seen closed; an
+unreachable workspace keeps it out too.
+
 Use --mol to filter to a specific molecule's steps:
   bd ready --mol bd-patrol   # Show ready steps within molecule
 
@@ -177,6 +182,7 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			filter.Priority = &priority
 		}
 		if assignee != "" && !unassigned {
+			assignee = resolveAssignee(assignee)
 			filter.Assignee = &assignee
 		}
 		if parentID != "" {
@@ -228,6 +234,12 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			}
 		}
 
+		held, err := remoteHeldIDs(ctx, storeRemoteDeps{activeStore})
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		filter.ExcludeIDs = append(filter.ExcludeIDs, held...)
+
 		if claimReady {
 			claimed, err := activeStore.ClaimReadyIssue(ctx, filter, actor)
 			if err != nil {
@@ -131,6 +131,7 @@ func gatherReadyInput(cmd *cobra.Command) (readyInput, error) {
 		in.filter.Priority = &priority
 	}
 	if assignee != "" && !unassigned {
+		assignee = resolveAssignee(assignee)
 		in.filter.Assignee = &assignee
 	}
 	if in.parentID != "" {
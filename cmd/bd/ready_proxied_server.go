@@ -25,6 +25,14 @@ func runReadyProxiedServer(cmd *cobra.Command, ctx context.Context) error {
 		return HandleError("proxied-server UOW provider not initialized")
 	}
 
+	// Issues held by blockers in other workspaces are read in a unit of
+	// work of their own, closed before --claim opens its write.
+	held, err := readRemoteHeldIDsProxied(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	in.filter.ExcludeIDs = append(in.filter.ExcludeIDs, held...)
+
 	if in.claim {
 		return runReadyProxiedClaim(ctx, nil, in)
 	}
@@ -47,6 +55,16 @@ func runReadyProxiedServer(cmd *cobra.Command, ctx context.Context) error {
 	}
 }
 
+// readRemoteHeldIDsProxied is remoteHeldIDs over a short-lived unit of work.
+func readRemoteHeldIDsProxied(ctx context.Context) ([]string, error) {
+	uw, err := uowProvider.NewUOW(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open unit of work: %w", err)
+	}
+	defer uw.Close(ctx)
+	return remoteHeldIDs(ctx, proxiedRemoteDeps{uw})
+}
+
 func runBlockedProxiedServer(cmd *cobra.Command, ctx context.Context) error {
 	if uowProvider == nil {
 		return HandleError("proxied-server UOW provider not initialized")
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var recurCmd = &cobra.Command{
+	Use:     "recur [id]",
+	GroupID: "issues",
+	Short:   "Make an issue repeat on a schedule",
+	Long: `Make an issue repeat on a schedule.
+
+--every takes a count and a unit: h (hours), d (days), w (weeks), m (months)
+or y (years). The first occurrence is due one interval from now, or at
+--next. 'bd tick' creates due occurrences; run it from cron or CI.
+
+Each occurrence copies the issue's title, description, design, acceptance
+criteria, type, priority, assignee, estimate, labels and parent, and is
+linked to the previous occurrence with a "related" dependency. On a
+template issue (a proto, see 'bd cook'), occurrences are created on
+schedule. On an ordinary issue, the next one is created only once the
+previous occurrence is closed, so a missed week does not pile up
+duplicates.
+
+With no flags, shows the issue's rule; with no ID, lists every rule.
+
+Examples:
+  bd recur bd-42 --every 2w
+  bd recur bd-42 --every 1m --next 2026-11-01
+  bd recur bd-42 --stop
+  bd recur --json`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("recur is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("recur")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		every, _ := cmd.Flags().GetString("every")
+		nextStr, _ := cmd.Flags().GetString("next")
+		stop, _ := cmd.Flags().GetBool("stop")
+		if stop && (every != "" || nextStr != "") {
+			return HandleErrorRespectJSON("--stop cannot be combined with --every or --next")
+		}
+		if nextStr != "" && every == "" {
+			return HandleErrorRespectJSON("--next requires --every")
+		}
+
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("failed to get storage: %v", err)
+		}
+		ctx := rootCtx
+		recurStore, ok := storage.UnwrapStore(store).(storage.RecurrenceStore)
+		if !ok {
+			return HandleErrorRespectJSON("storage backend does not support recurring issues")
+		}
+
+		if len(args) == 0 {
+			if every != "" || stop {
+				return HandleErrorRespectJSON("an issue ID is required with --every or --stop")
+			}
+			rules, err := recurStore.GetIssueRecurrences(ctx)
+			if err != nil {
+				return HandleErrorRespectJSON("listing recurrences: %v", err)
+			}
+			if jsonOutput {
+				if rules == nil {
+					rules = []*types.Recurrence{}
+				}
+				return outputJSON(rules)
+			}
+			if len(rules) == 0 {
+				fmt.Println("No recurring issues")
+				return nil
+			}
+			for _, r := range rules {
+				fmt.Printf("%s  %s\n", ui.RenderAccent(r.IssueID), formatRecurrence(r))
+			}
+			return nil
+		}
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+
+		switch {
+		case stop:
+			CheckReadonly("recur")
+			if err := recurStore.RemoveIssueRecurrence(ctx, id); err != nil {
+				return HandleErrorRespectJSON("stopping recurrence of %s: %v", id, err)
+			}
+			if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{Command: "recur", IssueIDs: []string{id}}); err != nil {
+				return HandleErrorRespectJSON("failed to commit: %v", err)
+			}
+			commandDidWrite.Store(true)
+			if jsonOutput {
+				return outputJSON(map[string]interface{}{"issue_id": id, "stopped": true})
+			}
+			fmt.Printf("%s %s no longer recurs\n", ui.RenderPass("✓"), ui.RenderAccent(id))
+			return nil
+
+		case every != "":
+			CheckReadonly("recur")
+			now := time.Now().UTC().Truncate(time.Second)
+			next, err := nextRecurrence(every, now)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			if nextStr != "" {
+				if next, err = timeparsing.ParseRelativeTime(nextStr, time.Now()); err != nil {
+					return HandleErrorRespectJSON("invalid --next %q: %v", nextStr, err)
+				}
+			}
+			r := &types.Recurrence{IssueID: id, Every: every, NextAt: next.UTC().Truncate(time.Second), CreatedBy: getActorWithGit()}
+			// Keep the series going from its latest occurrence when only the
+			// schedule changes.
+			if existing := findRecurrence(ctx, recurStore, id); existing != nil {
+				r.LastInstanceID = existing.LastInstanceID
+			}
+			if err := recurStore.SetIssueRecurrence(ctx, r); err != nil {
+				return HandleErrorRespectJSON("setting recurrence of %s: %v", id, err)
+			}
+			if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{Command: "recur", IssueIDs: []string{id}}); err != nil {
+				return HandleErrorRespectJSON("failed to commit: %v", err)
+			}
+			commandDidWrite.Store(true)
+			SetLastTouchedID(id)
+			if jsonOutput {
+				return outputJSON(r)
+			}
+			fmt.Printf("%s %s recurs %s\n", ui.RenderPass("✓"), ui.RenderAccent(id), formatRecurrence(r))
+			return nil
+
+		default:
+			r := findRecurrence(ctx, recurStore, id)
+			if jsonOutput {
+				return outputJSON(r)
+			}
+			if r == nil {
+				fmt.Printf("%s does not recur\n", id)
+				return nil
+			}
+			fmt.Printf("%s recurs %s\n", ui.RenderAccent(id), formatRecurrence(r))
+			return nil
+		}
+	},
+}
+
+var tickCmd = &cobra.Command{
+	Use:     "tick",
+	GroupID: "maint",
+	Short:   "Create the recurring issues that are due",
+	Long: `Create the next occurrence of every recurring issue that is due (see
+'bd recur'). Meant to be run periodically from cron or CI; running it again
+before anything new is due does nothing.
+
+Each occurrence is created in its own transaction together with the update
+to its rule. When a rule has fallen several intervals behind, one
+occurrence is created and the rule skips ahead to its next future slot.
+
+Examples:
+  bd tick
+  bd tick --dry-run --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("tick is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("tick")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			CheckReadonly("tick")
+		}
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("failed to get storage: %v", err)
+		}
+		ctx := rootCtx
+		recurStore, ok := storage.UnwrapStore(store).(storage.RecurrenceStore)
+		if !ok {
+			return HandleErrorRespectJSON("storage backend does not support recurring issues")
+		}
+		rules, err := recurStore.GetIssueRecurrences(ctx)
+		if err != nil {
+			return HandleErrorRespectJSON("listing recurrences: %v", err)
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		actor := getActorWithGit()
+		results := []*tickResult{}
+		for _, r := range rules {
+			if r.NextAt.After(now) {
+				continue
+			}
+			res := &tickResult{IssueID: r.IssueID}
+			if dryRun {
+				res.Waiting, err = recurrenceWaitingOn(ctx, store, r)
+				if err != nil {
+					return HandleErrorRespectJSON("checking %s: %v", r.IssueID, err)
+				}
+				results = append(results, res)
+				continue
+			}
+			err := transact(ctx, store, "bd: tick "+r.IssueID, func(tx storage.Transaction) error {
+				created, waiting, err := materializeRecurrence(ctx, tx, r, now, actor)
+				if created != nil {
+					res.Created = created.ID
+				}
+				res.Waiting = waiting
+				return err
+			})
+			if err != nil {
+				return HandleErrorRespectJSON("recurrence of %s: %v", r.IssueID, err)
+			}
+			if res.Created != "" {
+				commandDidWrite.Store(true)
+			}
+			results = append(results, res)
+		}
+
+		if jsonOutput {
+			return outputJSON(results)
+		}
+		if len(results) == 0 {
+			fmt.Println("Nothing is due")
+			return nil
+		}
+		for _, res := range results {
+			switch {
+			case res.Created != "":
+				fmt.Printf("%s Created %s (recurs from %s)\n", ui.RenderPass("✓"), ui.RenderAccent(res.Created), res.IssueID)
+			case res.Waiting != "":
+				fmt.Printf("  %s\n", ui.RenderMuted(fmt.Sprintf("%s: waiting for %s to close", res.IssueID, res.Waiting)))
+			default:
+				fmt.Printf("  %s is due (dry run)\n", res.IssueID)
+			}
+		}
+		return nil
+	},
+}
+
+// tickResult is one due rule in `bd tick --json`: the occurrence created,
+// or the open occurrence it is waiting for.
+type tickResult struct {
+	IssueID string `json:"issue_id"`
+	Created string `json:"created,omitempty"`
+	Waiting string `json:"waiting,omitempty"`
+}
+
+// nextRecurrence returns from plus one interval.
+func nextRecurrence(every string, from time.Time) (time.Time, error) {
+	if every == "" || every[0] == '+' || every[0] == '-' {
+		return time.Time{}, fmt.Errorf("invalid interval %q (use a count and h, d, w, m or y, e.g. 2w)", every)
+	}
+	next, err := timeparsing.ParseCompactDuration(every, from)
+	if err != nil || !next.After(from) {
+		return time.Time{}, fmt.Errorf("invalid interval %q (use a count and h, d, w, m or y, e.g. 2w)", every)
+	}
+	return next, nil
+}
+
+// advanceRecurrence moves next forward by whole intervals until it is after
+// now, so a rule that fell behind resumes on its own schedule.
+func advanceRecurrence(every string, next, now time.Time) (time.Time, error) {
+	for !next.After(now) {
+		var err error
+		if next, err = nextRecurrence(every, next); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return next, nil
+}
+
+// findRecurrence returns the rule of issueID, or nil. Best effort, like the
+// other show sections.
+func findRecurrence(ctx context.Context, rs storage.RecurrenceStore, issueID string) *types.Recurrence {
+	rules, err := rs.GetIssueRecurrences(ctx)
+	if err != nil {
+		return nil
+	}
+	for _, r := range rules {
+		if r.IssueID == issueID {
+			return r
+		}
+	}
+	return nil
+}
+
+// getIssueRecurrence returns the rule of issueID, or nil when it has none or
+// the store does not support recurrence.
+func getIssueRecurrence(ctx context.Context, st storage.DoltStorage, issueID string) *types.Recurrence {
+	rs, ok := storage.UnwrapStore(st).(storage.RecurrenceStore)
+	if !ok {
+		return nil
+	}
+	return findRecurrence(ctx, rs, issueID)
+}
+
+func formatRecurrence(r *types.Recurrence) string {
+	s := fmt.Sprintf("every %s, next %s", r.Every, r.NextAt.Local().Format("2006-01-02 15:04"))
+	if r.LastInstanceID != "" {
+		s += ", last " + r.LastInstanceID
+	}
+	return s
+}
+
+// recurrencePrevious returns the occurrence the next one follows: the last
+// one created, else the issue itself unless it is a template. It returns ""
+// when there is none or it was deleted.
+func recurrencePrevious(r *types.Recurrence, source *types.Issue) string {
+	if r.LastInstanceID != "" {
+		return r.LastInstanceID
+	}
+	if source.IsTemplate {
+		return ""
+	}
+	return source.ID
+}
+
+// recurrenceWaitingOn reports the open occurrence that holds back a due
+// ordinary rule, or "" when bd tick would create the next one.
+func recurrenceWaitingOn(ctx context.Context, st storage.DoltStorage, r *types.Recurrence) (string, error) {
+	source, err := st.GetIssue(ctx, r.IssueID)
+	if err != nil {
+		return "", err
+	}
+	prevID := recurrencePrevious(r, source)
+	if prevID == "" || source.IsTemplate {
+		return "", nil
+	}
+	prev, err := st.GetIssue(ctx, prevID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if prev.Status != types.StatusClosed {
+		return prevID, nil
+	}
+	return "", nil
+}
+
+// materializeRecurrence creates the next occurrence of r within tx and
+// advances the rule. For an ordinary issue whose previous occurrence is still
+// open, it creates nothing and returns that occurrence's ID instead.
+func materializeRecurrence(ctx context.Context, tx storage.Transaction, r *types.Recurrence, now time.Time, actor string) (*types.Issue, string, error) {
+	source, err := tx.GetIssue(ctx, r.IssueID)
+	if err != nil {
+		return nil, "", fmt.Errorf("get %s: %w", r.IssueID, err)
+	}
+	prevID := recurrencePrevious(r, source)
+	if prevID != "" {
+		prev, err := tx.GetIssue(ctx, prevID)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			prevID = ""
+		case err != nil:
+			return nil, "", fmt.Errorf("get %s: %w", prevID, err)
+		case !source.IsTemplate && prev.Status != types.StatusClosed:
+			return nil, prevID, nil
+		}
+	}
+
+	occurrence := &types.Issue{
+		Title:              source.Title,
+		Description:        source.Description,
+		Design:             source.Design,
+		AcceptanceCriteria: source.AcceptanceCriteria,
+		Status:             types.StatusOpen,
+		Priority:           source.Priority,
+		IssueType:          source.IssueType,
+		Assignee:           source.Assignee,
+		EstimatedMinutes:   source.EstimatedMinutes,
+	}
+	if err := tx.CreateIssue(ctx, occurrence, actor); err != nil {
+		return nil, "", fmt.Errorf("create occurrence: %w", err)
+	}
+	labels, err := tx.GetLabels(ctx, source.ID)
+	if err != nil {
+		return nil, "", fmt.Errorf("get labels of %s: %w", source.ID, err)
+	}
+	for _, l := range labels {
+		if l == BeadsTemplateLabel {
+			continue
+		}
+		if err := tx.AddLabel(ctx, occurrence.ID, l, actor); err != nil {
+			return nil, "", fmt.Errorf("add label %s: %w", l, err)
+		}
+	}
+	deps, err := tx.GetDependencyRecords(ctx, source.ID)
+	if err != nil {
+		return nil, "", fmt.Errorf("get dependencies of %s: %w", source.ID, err)
+	}
+	for _, d := range deps {
+		if d.Type != types.DepParentChild {
+			continue
+		}
+		dep := &types.Dependency{IssueID: occurrence.ID, DependsOnID: d.DependsOnID, Type: types.DepParentChild}
+		if err := tx.AddDependency(ctx, dep, actor); err != nil {
+			return nil, "", fmt.Errorf("attach to parent %s: %w", d.DependsOnID, err)
+		}
+	}
+	if prevID != "" {
+		dep := &types.Dependency{IssueID: occurrence.ID, DependsOnID: prevID, Type: types.DepRelated}
+		if err := tx.AddDependency(ctx, dep, actor); err != nil {
+			return nil, "", fmt.Errorf("link to %s: %w", prevID, err)
+		}
+	}
+
+	next, err := advanceRecurrence(r.Every, r.NextAt, now)
+	if err != nil {
+		return nil, "", err
+	}
+	advanced := *r
+	advanced.NextAt = next
+	advanced.LastInstanceID = occurrence.ID
+	if err := tx.SetIssueRecurrence(ctx, &advanced); err != nil {
+		return nil, "", err
+	}
+	return occurrence, "", nil
+}
+
+func init() {
+	recurCmd.Flags().String("every", "", "Interval between occurrences, e.g. 1d, 2w, 1m")
+	recurCmd.Flags().String("next", "", "When the first occurrence is due (default: one interval from now)")
+	recurCmd.Flags().Bool("stop", false, "Stop the issue from recurring")
+	recurCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(recurCmd)
+
+	tickCmd.Flags().Bool("dry-run", false, "List what is due without creating anything")
+	rootCmd.AddCommand(tickCmd)
+}
@@ -0,0 +1,87 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedRecurAndTick(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "rc")
+	source := bdCreateSilent(t, bd, dir, "Rotate keys", "--labels", "ops", "--priority", "1")
+
+	tick := func(t *testing.T) []tickResult {
+		t.Helper()
+		var results []tickResult
+		out := bdCommand(t, bd, dir, "tick", "--json")
+		if err := json.Unmarshal([]byte(out), &results); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		return results
+	}
+
+	// Due an hour ago.
+	bdCommand(t, bd, dir, "recur", source, "--every", "1w", "--next", "-1h")
+
+	t.Run("waits_for_open_occurrence", func(t *testing.T) {
+		if got := tick(t); len(got) != 1 || got[0].Created != "" || got[0].Waiting != source {
+			t.Errorf("tick with source open = %+v", got)
+		}
+	})
+
+	var occurrence string
+	t.Run("creates_next_occurrence", func(t *testing.T) {
+		bdCommand(t, bd, dir, "close", source)
+		got := tick(t)
+		if len(got) != 1 || got[0].Created == "" {
+			t.Fatalf("tick after close = %+v", got)
+		}
+		occurrence = got[0].Created
+
+		var details types.IssueDetails
+		if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, occurrence)), &details); err != nil {
+			t.Fatal(err)
+		}
+		if details.Title != "Rotate keys" || details.Priority != 1 || details.Status != types.StatusOpen {
+			t.Errorf("occurrence = %+v", details.Issue)
+		}
+		if !reflect.DeepEqual(details.Labels, []string{"ops"}) {
+			t.Errorf("occurrence labels = %v", details.Labels)
+		}
+		if len(details.Dependencies) != 1 || details.Dependencies[0].ID != source || details.Dependencies[0].DependencyType != types.DepRelated {
+			t.Errorf("occurrence dependencies = %+v", details.Dependencies)
+		}
+	})
+
+	t.Run("rule_advanced", func(t *testing.T) {
+		if got := tick(t); len(got) != 0 {
+			t.Errorf("second tick = %+v, want nothing due", got)
+		}
+		var r types.Recurrence
+		out := bdCommand(t, bd, dir, "recur", source, "--json")
+		if err := json.Unmarshal([]byte(out), &r); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if r.LastInstanceID != occurrence || r.Every != "1w" {
+			t.Errorf("rule = %+v", r)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		bdCommand(t, bd, dir, "recur", source, "--stop")
+		if out := bdCommand(t, bd, dir, "recur", "--json"); out != "[]\n" {
+			t.Errorf("rules after stop = %q", out)
+		}
+	})
+}
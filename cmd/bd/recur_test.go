@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRecurrence(t *testing.T) {
+	from := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		every string
+		want  time.Time
+	}{
+		{"12h", from.Add(12 * time.Hour)},
+		{"1d", from.AddDate(0, 0, 1)},
+		{"2w", from.AddDate(0, 0, 14)},
+		{"1m", from.AddDate(0, 1, 0)},
+		{"1y", from.AddDate(1, 0, 0)},
+	}
+	for _, tt := range tests {
+		got, err := nextRecurrence(tt.every, from)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("nextRecurrence(%q) = %v, %v; want %v", tt.every, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "0d", "-1d", "+1d", "2", "fortnight"} {
+		if _, err := nextRecurrence(bad, from); err == nil {
+			t.Errorf("nextRecurrence(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestAdvanceRecurrence(t *testing.T) {
+	next := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // a Monday
+	now := next.AddDate(0, 0, 17)
+
+	// Three weeks behind: skip to the first slot after now, on the same weekday.
+	got, err := advanceRecurrence("1w", next, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := next.AddDate(0, 0, 21); !got.Equal(want) {
+		t.Errorf("advanceRecurrence = %v, want %v", got, want)
+	}
+	// A slot exactly at now is due, so it moves on too.
+	if got, _ := advanceRecurrence("1d", now, now); !got.Equal(now.AddDate(0, 0, 1)) {
+		t.Errorf("advanceRecurrence at now = %v", got)
+	}
+}
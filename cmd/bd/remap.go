@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// renamedEventType is the audit event UpdateIssueID records for every ID
+// change (bd rename, bd rename-prefix, prefix repair), with the old ID in
+// old_value and the new one in new_value.
+const renamedEventType types.EventType = "renamed"
+
+// remapEntry is one ID rename, as emitted by `bd remap list --json`.
+type remapEntry struct {
+	OldID     string    `json:"old_id"`
+	NewID     string    `json:"new_id"`
+	Actor     string    `json:"actor,omitempty"`
+	RenamedAt time.Time `json:"renamed_at"`
+}
+
+// remapLookupResult is the `bd remap lookup --json` payload.
+type remapLookupResult struct {
+	ID        string       `json:"id"`
+	CurrentID string       `json:"current_id"`
+	Renamed   bool         `json:"renamed"`
+	Alias     bool         `json:"alias,omitempty"`
+	Chain     []remapEntry `json:"chain"`
+}
+
+var remapCmd = &cobra.Command{
+	Use:     "remap",
+	GroupID: "views",
+	Short:   "Trace issue ID renames",
+	Long: `Trace issue IDs that were renamed by bd rename or bd rename-prefix.
+
+Every rename is recorded as a "renamed" audit event in the database, so the
+remap table travels with 'bd dolt push/pull' like the rest of the issue data.
+Use these commands when a commit message, PR description, or CI annotation
+still refers to an old ID. lookup also resolves aliases added with bd alias.
+
+Examples:
+  bd remap lookup bd-abc        # Show the current ID for a renamed issue
+  bd remap lookup bd-abc --json # Machine-readable rename chain
+  bd remap list --json          # Full remap table for downstream tooling`,
+}
+
+var remapListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List all recorded ID renames",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("remap-list")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("remap is not supported in proxied-server mode")
+		}
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("failed to get storage: %v", err)
+		}
+
+		entries, err := collectRemaps(rootCtx, store)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to read rename events: %v", err)
+		}
+		if jsonOutput {
+			if entries == nil {
+				entries = []remapEntry{}
+			}
+			return outputJSON(entries)
+		}
+		if len(entries) == 0 {
+			fmt.Println("No issue renames recorded")
+			return nil
+		}
+		for _, e := range entries {
+			fmt.Printf("%s %s -> %s  %s\n",
+				ui.RenderMuted(e.RenamedAt.Format("2006-01-02 15:04:05")),
+				ui.RenderWarn(e.OldID), ui.RenderAccent(e.NewID),
+				ui.RenderMuted(e.Actor))
+		}
+		return nil
+	},
+}
+
+var remapLookupCmd = &cobra.Command{
+	Use:           "lookup <old-id>",
+	Short:         "Find the current ID of a renamed issue",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("remap-lookup")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("remap is not supported in proxied-server mode")
+		}
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("failed to get storage: %v", err)
+		}
+
+		id := args[0]
+		entries, err := collectRemaps(rootCtx, store)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to read rename events: %v", err)
+		}
+		chain := resolveRemapChain(entries, id)
+		result := remapLookupResult{ID: id, CurrentID: id, Renamed: len(chain) > 0, Chain: chain}
+		if len(chain) > 0 {
+			result.CurrentID = chain[len(chain)-1].NewID
+		} else {
+			if _, err := store.GetIssue(rootCtx, id); err != nil {
+				if !errors.Is(err, storage.ErrNotFound) {
+					return HandleErrorRespectJSON("failed to get issue %s: %v", id, err)
+				}
+				aliasStore, ok := storage.UnwrapStore(store).(storage.AliasStore)
+				if !ok {
+					return HandleErrorRespectJSON("no issue or rename found for %s", id)
+				}
+				target, aliasErr := aliasStore.ResolveIssueAlias(rootCtx, id)
+				if aliasErr != nil {
+					return HandleErrorRespectJSON("no issue, rename or alias found for %s", id)
+				}
+				result.CurrentID = target
+				result.Alias = true
+			}
+			result.Chain = []remapEntry{}
+		}
+
+		if jsonOutput {
+			return outputJSON(result)
+		}
+		if result.Alias {
+			fmt.Printf("%s is an alias of %s\n", ui.RenderWarn(id), ui.RenderAccent(result.CurrentID))
+			return nil
+		}
+		if !result.Renamed {
+			fmt.Printf("%s has not been renamed\n", ui.RenderAccent(id))
+			return nil
+		}
+		fmt.Printf("%s is now %s\n", ui.RenderWarn(id), ui.RenderAccent(result.CurrentID))
+		if len(chain) > 1 {
+			for _, e := range chain {
+				fmt.Printf("  %s %s -> %s\n", ui.RenderMuted(e.RenamedAt.Format("2006-01-02 15:04:05")), e.OldID, e.NewID)
+			}
+		}
+		return nil
+	},
+}
+
+type remapBackend interface {
+	IterAllEventsSince(ctx context.Context, since time.Time) (storage.Iter[types.Event], error)
+}
+
+// collectRemaps returns every recorded rename in chronological order.
+func collectRemaps(ctx context.Context, backend remapBackend) ([]remapEntry, error) {
+	iter, err := backend.IterAllEventsSince(ctx, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = iter.Close() }()
+
+	var entries []remapEntry
+	for iter.Next(ctx) {
+		event := iter.Value()
+		if event == nil || event.EventType != renamedEventType || event.OldValue == nil || event.NewValue == nil {
+			continue
+		}
+		entries = append(entries, remapEntry{
+			OldID:     *event.OldValue,
+			NewID:     *event.NewValue,
+			Actor:     event.Actor,
+			RenamedAt: event.CreatedAt,
+		})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].RenamedAt.Before(entries[j].RenamedAt)
+	})
+	return entries, nil
+}
+
+// resolveRemapChain follows id through successive renames (a -> b -> c) and
+// returns the steps taken. entries must be in chronological order, so an ID
+// that was later reused by another rename is only followed forward in time.
+func resolveRemapChain(entries []remapEntry, id string) []remapEntry {
+	var chain []remapEntry
+	current := id
+	for _, e := range entries {
+		if e.OldID == current {
+			chain = append(chain, e)
+			current = e.NewID
+		}
+	}
+	return chain
+}
+
+func init() {
+	remapLookupCmd.ValidArgsFunction = issueIDCompletion
+	remapCmd.AddCommand(remapListCmd)
+	remapCmd.AddCommand(remapLookupCmd)
+	rootCmd.AddCommand(remapCmd)
+}
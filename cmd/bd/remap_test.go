@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type fakeRemapBackend struct {
+	events []*types.Event
+}
+
+func (f fakeRemapBackend) IterAllEventsSince(_ context.Context, _ time.Time) (storage.Iter[types.Event], error) {
+	return storage.NewSliceIter(f.events), nil
+}
+
+func renamedEvent(oldID, newID string, at time.Time) *types.Event {
+	return &types.Event{IssueID: newID, EventType: renamedEventType, Actor: "alice", OldValue: &oldID, NewValue: &newID, CreatedAt: at}
+}
+
+func TestCollectRemapsAndResolveChain(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	other := "x"
+	backend := fakeRemapBackend{events: []*types.Event{
+		// Out of order on purpose: collectRemaps sorts chronologically.
+		renamedEvent("bd-b", "bd-c", t0.Add(2*time.Hour)),
+		renamedEvent("bd-a", "bd-b", t0.Add(time.Hour)),
+		{IssueID: "bd-z", EventType: types.EventUpdated, OldValue: &other, NewValue: &other, CreatedAt: t0},
+		renamedEvent("bd-c", "bd-a", t0.Add(3*time.Hour)),
+	}}
+
+	entries, err := collectRemaps(context.Background(), backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || entries[0].OldID != "bd-a" {
+		t.Fatalf("entries = %+v, want 3 renames starting with bd-a", entries)
+	}
+
+	chain := resolveRemapChain(entries, "bd-a")
+	if len(chain) != 3 || chain[len(chain)-1].NewID != "bd-a" {
+		t.Errorf("chain from bd-a = %+v, want a->b->c->a", chain)
+	}
+	chain = resolveRemapChain(entries, "bd-b")
+	if len(chain) != 2 || chain[len(chain)-1].NewID != "bd-a" {
+		t.Errorf("chain from bd-b = %+v, want b->c->a", chain)
+	}
+	if chain := resolveRemapChain(entries, "bd-z"); len(chain) != 0 {
+		t.Errorf("chain from bd-z = %+v, want none", chain)
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/uow"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// A dependency on an issue with another prefix (bd dep add bd-1 hr-7) is
+// stored with its raw target ID, since the target lives in another
+// workspace. The store cannot see that issue: it drops the edge from
+// dependency listings and never counts it as a blocker. The helpers here
+// resolve such targets through the prefix routes in routes.jsonl, the same
+// way 'bd show hr-7' finds the issue, so bd show can list them with their
+// title and status, and bd ready and bd list --ready can leave out work a
+// remote issue still blocks.
+
+// remoteIssueTitleUnknown stands in for the title of a remote dependency
+// whose workspace cannot be reached or no longer has the issue.
+const remoteIssueTitleUnknown = "(in another workspace; state unknown)"
+
+// isRemoteDepTarget reports whether a dependency points at an issue with
+// another prefix, which the store keeps as an unresolved reference.
+// external:<project>:<capability> references are a separate mechanism.
+func isRemoteDepTarget(dep *types.Dependency) bool {
+	if IsExternalRef(dep.DependsOnID) {
+		return false
+	}
+	target := types.ExtractPrefix(dep.DependsOnID)
+	return target != "" && target != types.ExtractPrefix(dep.IssueID)
+}
+
+// remoteDepReader is what the remote-dependency helpers read from this
+// workspace. storeRemoteDeps reads a direct store, proxiedRemoteDeps a
+// proxied-server unit of work.
+type remoteDepReader interface {
+	GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
+	GetDependencyRecordsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Dependency, error)
+	GetIssuesByIDs(ctx context.Context, ids []string) ([]*types.Issue, error)
+}
+
+// storeRemoteDeps reads a direct store. A backend without the
+// ExternalDependencyLister capability has no remote dependencies to report.
+type storeRemoteDeps struct{ storage.DoltStorage }
+
+func (s storeRemoteDeps) GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	lister, ok := storage.UnwrapStore(s.DoltStorage).(storage.ExternalDependencyLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.GetExternalDependencyRecords(ctx)
+}
+
+// proxiedRemoteDeps reads through a proxied-server unit of work.
+type proxiedRemoteDeps struct{ uw uow.UnitOfWork }
+
+func (p proxiedRemoteDeps) GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	return p.uw.DependencyUseCase().GetExternalDependencyRecords(ctx)
+}
+
+func (p proxiedRemoteDeps) GetDependencyRecordsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Dependency, error) {
+	return p.uw.DependencyUseCase().GetIssueDependencyRecords(ctx, issueIDs)
+}
+
+func (p proxiedRemoteDeps) GetIssuesByIDs(ctx context.Context, ids []string) ([]*types.Issue, error) {
+	return p.uw.IssueUseCase().GetIssuesByIDs(ctx, ids)
+}
+
+// remoteIssueResolver looks up issues in the workspaces routes.jsonl maps
+// their prefixes to. Each routed store is opened read-only once and kept
+// until close; a prefix whose store cannot be opened is not retried.
+type remoteIssueResolver struct {
+	ctx    context.Context
+	stores map[string]storage.DoltStorage // by prefix
+	failed map[string]error               // by prefix
+}
+
+func newRemoteIssueResolver(ctx context.Context) *remoteIssueResolver {
+	return &remoteIssueResolver{
+		ctx:    ctx,
+		stores: make(map[string]storage.DoltStorage),
+		failed: make(map[string]error),
+	}
+}
+
+// lookup returns the remote issue, or an error when no route covers its
+// prefix, the workspace cannot be opened or it has no such issue.
+func (r *remoteIssueResolver) lookup(id string) (*types.Issue, error) {
+	prefix := extractBeadPrefix(id)
+	if err, ok := r.failed[prefix]; ok {
+		return nil, err
+	}
+	st := r.stores[prefix]
+	if st == nil {
+		var err error
+		if st, _, err = openPrefixRoutedStore(r.ctx, prefix, false); err != nil {
+			debug.Logf("[routing] cannot resolve remote dependency %s: %v\n", id, err)
+			r.failed[prefix] = err
+			return nil, err
+		}
+		r.stores[prefix] = st
+	}
+	issue, err := st.GetIssue(r.ctx, id)
+	if err == nil && issue == nil {
+		err = fmt.Errorf("issue %s not found", id)
+	}
+	return issue, err
+}
+
+func (r *remoteIssueResolver) close() {
+	for _, st := range r.stores {
+		_ = st.Close()
+	}
+}
+
+// withRemoteDependencies appends to deps, an issue's dependencies as the
+// store lists them, the dependencies on issues in other workspaces. A
+// target that cannot be resolved is listed by ID with an empty status and
+// a title saying its state is unknown.
+func withRemoteDependencies(ctx context.Context, r remoteDepReader, issueID string, deps []*types.IssueWithDependencyMetadata) []*types.IssueWithDependencyMetadata {
+	records, err := r.GetDependencyRecordsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return deps
+	}
+	listed := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		listed[dep.ID] = true
+	}
+	resolver := newRemoteIssueResolver(ctx)
+	defer resolver.close()
+	for _, rec := range records[issueID] {
+		if !isRemoteDepTarget(rec) || listed[rec.DependsOnID] {
+			continue
+		}
+		issue := types.Issue{ID: rec.DependsOnID, Title: remoteIssueTitleUnknown}
+		if remote, err := resolver.lookup(rec.DependsOnID); err == nil {
+			issue = *remote
+		}
+		listed[rec.DependsOnID] = true
+		deps = append(deps, &types.IssueWithDependencyMetadata{Issue: issue, DependencyType: rec.Type})
+	}
+	return deps
+}
+
+// remoteHeldIDs returns the open issues of this workspace that a blocking
+// dependency on an issue in another workspace still holds up, sorted. Ready
+// work excludes them through WorkFilter.ExcludeIDs, so limits, counts and
+// claims see the same set. The check is conservative: a remote blocker
+// counts until it is seen closed (or pinned), so one whose workspace cannot
+// be reached keeps its dependents out of ready work.
+func remoteHeldIDs(ctx context.Context, r remoteDepReader) ([]string, error) {
+	records, err := r.GetExternalDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list dependencies on other workspaces: %w", err)
+	}
+	blockers := make(map[string][]string) // dependent -> remote blockers
+	var ids []string
+	for issueID, deps := range records {
+		for _, dep := range deps {
+			if dep.Type.IsBlockingEdge() && isRemoteDepTarget(dep) {
+				if len(blockers[issueID]) == 0 {
+					ids = append(ids, issueID)
+				}
+				blockers[issueID] = append(blockers[issueID], dep.DependsOnID)
+				ids = append(ids, dep.DependsOnID)
+			}
+		}
+	}
+	if len(blockers) == 0 {
+		return nil, nil
+	}
+
+	// Dependents that are already closed need no exclusion, and a remote
+	// issue that happens to be in this database (imported from its
+	// workspace) is trusted as is.
+	status := make(map[string]types.Status, len(ids))
+	local, err := r.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load dependencies on other workspaces: %w", err)
+	}
+	for _, issue := range local {
+		status[issue.ID] = issue.Status
+	}
+
+	resolver := newRemoteIssueResolver(ctx)
+	defer resolver.close()
+	var held []string
+	for issueID, targets := range blockers {
+		if status[issueID] == types.StatusClosed {
+			continue
+		}
+		for _, target := range targets {
+			s, ok := status[target]
+			if !ok {
+				if remote, err := resolver.lookup(target); err == nil {
+					s = remote.Status
+				}
+				status[target] = s
+			}
+			if s != types.StatusClosed && s != types.StatusPinned {
+				held = append(held, issueID)
+				break
+			}
+		}
+	}
+	sort.Strings(held)
+	return held, nil
+}
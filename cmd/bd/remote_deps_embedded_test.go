@@ -0,0 +1,106 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedRemoteDependencies(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "src")
+
+	targetDir := filepath.Join(dir, "target-repo")
+	if err := os.MkdirAll(targetDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	initGitRepoAt(t, targetDir)
+	runBDInit(t, bd, targetDir, "--prefix", "tgt")
+	remote := bdCreateSilent(t, bd, targetDir, "Remote blocker")
+
+	routes := filepath.Join(beadsDir, "routes.jsonl")
+	if err := os.WriteFile(routes, []byte(`{"prefix":"tgt-","path":"target-repo"}`+"\n"), 0644); err != nil {
+		t.Fatalf("write routes.jsonl: %v", err)
+	}
+
+	work := bdCreateSilent(t, bd, dir, "Waits on the other workspace")
+	free := bdCreateSilent(t, bd, dir, "Unblocked")
+	bdDepAdd(t, bd, dir, work, remote)
+
+	ready := func() []string {
+		t.Helper()
+		out := bdCommand(t, bd, dir, "ready", "--json")
+		var rows []struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(out), &rows); err != nil {
+			t.Fatalf("parse ready JSON: %v\n%s", err, out)
+		}
+		var ids []string
+		for _, r := range rows {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+	remoteDep := func() map[string]interface{} {
+		t.Helper()
+		deps, _ := bdShowDetails(t, bd, dir, work)["dependencies"].([]interface{})
+		for _, d := range deps {
+			if m, ok := d.(map[string]interface{}); ok && m["id"] == remote {
+				return m
+			}
+		}
+		t.Fatalf("bd show %s does not list %s: %v", work, remote, deps)
+		return nil
+	}
+
+	t.Run("open_remote_blocker", func(t *testing.T) {
+		if ids := ready(); slices.Contains(ids, work) || !slices.Contains(ids, free) {
+			t.Errorf("ready = %v, want %s without %s", ids, free, work)
+		}
+		if dep := remoteDep(); dep["title"] != "Remote blocker" || dep["status"] != "open" {
+			t.Errorf("remote dependency = %v", dep)
+		}
+		// The exclusion happens in the query, so a limit of one still
+		// returns an issue and list --ready agrees with ready.
+		if out := bdCommand(t, bd, dir, "ready", "--limit", "1", "--json"); !strings.Contains(out, free) {
+			t.Errorf("ready --limit 1 = %s, want %s", out, free)
+		}
+		if out := bdCommand(t, bd, dir, "list", "--ready", "--json"); strings.Contains(out, work) {
+			t.Errorf("list --ready lists %s, which a remote issue blocks:\n%s", work, out)
+		}
+	})
+
+	t.Run("closed_remote_blocker", func(t *testing.T) {
+		bdClose(t, bd, targetDir, remote)
+		if ids := ready(); !slices.Contains(ids, work) {
+			t.Errorf("ready = %v, want %s once its remote blocker is closed", ids, work)
+		}
+	})
+
+	t.Run("unreachable_workspace", func(t *testing.T) {
+		if err := os.Remove(routes); err != nil {
+			t.Fatal(err)
+		}
+		if ids := ready(); slices.Contains(ids, work) {
+			t.Errorf("ready = %v, want %s held back without a route", ids, work)
+		}
+		bdClose(t, bd, dir, free)
+		if out := bdCommand(t, bd, dir, "ready", "--claim", "--json"); strings.Contains(out, work) {
+			t.Errorf("ready --claim claimed %s without a route to its blocker:\n%s", work, out)
+		}
+		if dep := remoteDep(); dep["title"] != remoteIssueTitleUnknown {
+			t.Errorf("remote dependency = %v, want an unknown state", dep)
+		}
+	})
+}
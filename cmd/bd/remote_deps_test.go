@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIsRemoteDepTarget(t *testing.T) {
+	for _, tt := range []struct {
+		from, to string
+		want     bool
+	}{
+		{"bd-1", "hr-7", true},
+		{"bd-1", "bd-2", false},
+		{"bd-1", "bd-1.3", false},
+		{"bd-1", "external:hr:auth", false},
+		{"bd-1", "nodash", false},
+	} {
+		dep := &types.Dependency{IssueID: tt.from, DependsOnID: tt.to, Type: types.DepBlocks}
+		if got := isRemoteDepTarget(dep); got != tt.want {
+			t.Errorf("isRemoteDepTarget(%s -> %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+// fakeRemoteDepReader serves remoteHeldIDs from memory.
+type fakeRemoteDepReader struct {
+	deps   map[string][]*types.Dependency
+	issues []*types.Issue
+}
+
+func (f fakeRemoteDepReader) GetExternalDependencyRecords(context.Context) (map[string][]*types.Dependency, error) {
+	return f.deps, nil
+}
+
+func (f fakeRemoteDepReader) GetDependencyRecordsForIssues(_ context.Context, ids []string) (map[string][]*types.Dependency, error) {
+	out := make(map[string][]*types.Dependency)
+	for _, id := range ids {
+		out[id] = f.deps[id]
+	}
+	return out, nil
+}
+
+func (f fakeRemoteDepReader) GetIssuesByIDs(_ context.Context, ids []string) ([]*types.Issue, error) {
+	var out []*types.Issue
+	for _, issue := range f.issues {
+		if slices.Contains(ids, issue.ID) {
+			out = append(out, issue)
+		}
+	}
+	return out, nil
+}
+
+func TestRemoteHeldIDs(t *testing.T) {
+	// No routes.jsonl is reachable from the test, so every routed lookup
+	// fails and only an imported copy tells a remote issue's status.
+	t.Chdir(t.TempDir())
+	dep := func(from, to string, typ types.DependencyType) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: typ}
+	}
+	r := fakeRemoteDepReader{
+		deps: map[string][]*types.Dependency{
+			"bd-1": {dep("bd-1", "hr-1", types.DepBlocks)},             // unreachable: held
+			"bd-2": {dep("bd-2", "hr-2", types.DepBlocks)},             // imported copy is closed
+			"bd-3": {dep("bd-3", "hr-1", types.DepBlocks)},             // dependent is closed
+			"bd-4": {dep("bd-4", "hr-1", types.DepRelated)},            // not a blocking edge
+			"bd-5": {dep("bd-5", "external:hr:auth", types.DepBlocks)}, // external ref, not a route
+			"bd-6": {dep("bd-6", "hr-2", types.DepBlocks), dep("bd-6", "hr-3", types.DepWaitsFor)},
+		},
+		issues: []*types.Issue{
+			{ID: "bd-3", Status: types.StatusClosed},
+			{ID: "hr-2", Status: types.StatusClosed},
+		},
+	}
+	held, err := remoteHeldIDs(t.Context(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"bd-1", "bd-6"}; !slices.Equal(held, want) {
+		t.Errorf("remoteHeldIDs = %v, want %v", held, want)
+	}
+}
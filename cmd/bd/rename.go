@@ -24,6 +24,8 @@ This updates:
 - Dependencies pointing to/from this issue
 - Labels, comments, and events
 
+The old ID is kept as an alias (see 'bd alias'), so it still resolves.
+
 Examples:
   bd rename bd-w382l bd-dolt     # Rename to memorable ID
   bd rename gt-abc123 gt-auth    # Use descriptive ID
@@ -100,8 +102,15 @@ func runRename(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// referenceRewriter is the part of a store or transaction that
+// updateReferencesInAllIssues needs.
+type referenceRewriter interface {
+	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
+}
+
 // updateReferencesInAllIssues updates text references to the old ID in all issues
-func updateReferencesInAllIssues(ctx context.Context, store storage.DoltStorage, oldID, newID, actor string) error {
+func updateReferencesInAllIssues(ctx context.Context, store referenceRewriter, oldID, newID, actor string) error {
 	// Get all issues
 	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
 	if err != nil {
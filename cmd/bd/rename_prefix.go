@@ -26,6 +26,7 @@ var renamePrefixCmd = &cobra.Command{
 	Short:   "Rename the issue prefix for all issues in the database",
 	Long: `Rename the issue prefix for all issues in the database.
 This will update all issue IDs and all text references across all fields.
+Each old ID is kept as an alias (see 'bd alias'), so it still resolves.
 
 USE CASES:
 - Shortening long prefixes (e.g., 'knowledge-work-' → 'kw-')
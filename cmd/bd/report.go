@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ProjectReport is the snapshot rendered by 'bd report'.
+type ProjectReport struct {
+	GeneratedAt    time.Time             `json:"generated_at"`
+	Since          time.Time             `json:"since"`
+	Summary        *types.Statistics     `json:"summary"`
+	Ready          []*types.Issue        `json:"ready"`
+	RecentlyClosed []*types.Issue        `json:"recently_closed"`
+	Blocked        []*types.BlockedIssue `json:"blocked"`
+}
+
+var reportCmd = &cobra.Command{
+	Use:     "report",
+	GroupID: "views",
+	Short:   "Render a project status report as Markdown",
+	Long: `Render a snapshot of the project as Markdown: open, in-progress and
+blocked counts, ready work ordered by priority, issues closed within the
+--since window, and issues blocked by open dependencies.
+
+The output is meant for pasting into PR descriptions or committing as
+STATUS.md. --since accepts a duration (7d, 2w, 1m), a date (2025-01-31) or
+RFC3339; a bare duration counts back from now.
+
+Examples:
+  bd report                            # Last 7 days, to stdout
+  bd report --since 2w -o STATUS.md    # Two-week window, written to a file
+  bd report --since 2025-01-01 --limit 50
+  bd report --json                     # Report data as JSON`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("report is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("report")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		format, _ := cmd.Flags().GetString("format")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		limit, _ := cmd.Flags().GetInt("limit")
+		outputPath, _ := cmd.Flags().GetString("output")
+		if format != "markdown" && format != "md" {
+			return HandleErrorRespectJSON("invalid --format %q (valid: markdown)", format)
+		}
+		if limit < 0 {
+			return HandleErrorRespectJSON("--limit must not be negative")
+		}
+
+		now := time.Now()
+		since, err := parseReportSince(sinceStr, now)
+		if err != nil {
+			return HandleErrorRespectJSON("invalid --since: %v", err)
+		}
+
+		ctx := rootCtx
+		report := &ProjectReport{GeneratedAt: now.UTC(), Since: since.UTC()}
+		if report.Summary, err = store.GetStatistics(ctx); err != nil {
+			return HandleErrorRespectJSON("failed to get statistics: %v", err)
+		}
+		if report.Ready, err = store.GetReadyWork(ctx, types.WorkFilter{Limit: limit, SortPolicy: types.SortPolicyPriority}); err != nil {
+			return HandleErrorRespectJSON("failed to get ready work: %v", err)
+		}
+		closed := types.StatusClosed
+		if report.RecentlyClosed, err = store.SearchIssues(ctx, "", types.IssueFilter{Status: &closed, ClosedAfter: &since}); err != nil {
+			return HandleErrorRespectJSON("failed to get closed issues: %v", err)
+		}
+		sortClosedNewestFirst(report.RecentlyClosed)
+		if limit > 0 && len(report.RecentlyClosed) > limit {
+			report.RecentlyClosed = report.RecentlyClosed[:limit]
+		}
+		if report.Blocked, err = store.GetBlockedIssues(ctx, types.WorkFilter{Limit: limit}); err != nil {
+			return HandleErrorRespectJSON("failed to get blocked issues: %v", err)
+		}
+
+		if jsonOutput {
+			return outputJSON(report)
+		}
+
+		var w io.Writer = os.Stdout
+		if outputPath != "" {
+			f, err := os.Create(outputPath) // #nosec G304 -- user-specified output path
+			if err != nil {
+				return HandleErrorRespectJSON("failed to create %s: %v", outputPath, err)
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := renderMarkdownReport(w, report); err != nil {
+			return HandleErrorRespectJSON("failed to write report: %v", err)
+		}
+		return nil
+	},
+}
+
+// reportBareDurationRe matches an unsigned compact duration such as "7d".
+var reportBareDurationRe = regexp.MustCompile(`^\d+[hdwmy]$`)
+
+// parseReportSince parses --since. Unlike the deadline flags, a bare
+// duration here means "this long ago", so "7d" is treated as "-7d".
+func parseReportSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if reportBareDurationRe.MatchString(s) {
+		s = "-" + s
+	}
+	t, err := timeparsing.ParseRelativeTime(s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.After(now) {
+		return time.Time{}, fmt.Errorf("%q is in the future", s)
+	}
+	return t, nil
+}
+
+func sortClosedNewestFirst(issues []*types.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].ClosedAt, issues[j].ClosedAt
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.After(*b)
+	})
+}
+
+// renderMarkdownReport writes the report as GitHub-flavored Markdown.
+func renderMarkdownReport(w io.Writer, r *ProjectReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Project Status\n\n")
+	fmt.Fprintf(&b, "_Generated %s; changes since %s._\n\n", r.GeneratedAt.Format("2006-01-02 15:04 MST"), r.Since.Format("2006-01-02"))
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Open | In progress | Blocked | Ready | Closed |\n")
+	b.WriteString("|---:|---:|---:|---:|---:|\n")
+	s := r.Summary
+	fmt.Fprintf(&b, "| %d | %d | %s | %s | %d |\n\n", s.OpenIssues, s.InProgressIssues,
+		reportCount(s.BlockedIssues, len(r.Blocked)), reportCount(s.ReadyIssues, len(r.Ready)), s.ClosedIssues)
+
+	b.WriteString("## Ready Work\n\n")
+	if len(r.Ready) == 0 {
+		b.WriteString("_No ready work._\n\n")
+	}
+	for _, issue := range r.Ready {
+		fmt.Fprintf(&b, "- %s\n", reportIssueLine(issue))
+	}
+	if len(r.Ready) > 0 {
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "## Closed Since %s\n\n", r.Since.Format("2006-01-02"))
+	if len(r.RecentlyClosed) == 0 {
+		b.WriteString("_Nothing closed in this window._\n\n")
+	}
+	for _, issue := range r.RecentlyClosed {
+		line := reportIssueLine(issue)
+		if issue.ClosedAt != nil {
+			line += fmt.Sprintf(" (closed %s)", issue.ClosedAt.Format("2006-01-02"))
+		}
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	if len(r.RecentlyClosed) > 0 {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Blocked\n\n")
+	if len(r.Blocked) == 0 {
+		b.WriteString("_Nothing is blocked._\n")
+	}
+	for _, blocked := range r.Blocked {
+		fmt.Fprintf(&b, "- %s, blocked by %s\n", reportIssueLine(&blocked.Issue), reportIDList(blocked.BlockedBy))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// reportCount renders an optional statistic, falling back to the length of
+// the listed section when the store skipped it.
+func reportCount(n *int, fallback int) string {
+	if n != nil {
+		return fmt.Sprintf("%d", *n)
+	}
+	return fmt.Sprintf("%d", fallback)
+}
+
+func reportIssueLine(issue *types.Issue) string {
+	line := fmt.Sprintf("**%s** P%d %s: %s", issue.ID, issue.Priority, issue.IssueType, escapeMarkdownInline(issue.Title))
+	if issue.Assignee != "" {
+		line += fmt.Sprintf(" — @%s", issue.Assignee)
+	}
+	return line
+}
+
+func reportIDList(ids []string) string {
+	quoted := make([]string, len(ids))
+	for i, id := range ids {
+		quoted[i] = "**" + id + "**"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// escapeMarkdownInline escapes characters in a title that would otherwise
+// start emphasis, links or HTML in rendered Markdown.
+var escapeMarkdownInline = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`,
+).Replace
+
+func init() {
+	reportCmd.Flags().String("format", "markdown", "Output format (markdown)")
+	reportCmd.Flags().String("since", "7d", "Report issues closed since this time (duration like 7d/2w, date, or RFC3339)")
+	reportCmd.Flags().IntP("limit", "n", 0, "Maximum issues per section (0 = no limit)")
+	reportCmd.Flags().StringP("output", "o", "", "Write the report to a file instead of stdout")
+	rootCmd.AddCommand(reportCmd)
+}
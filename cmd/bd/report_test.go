@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseReportSince(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	for in, want := range map[string]time.Time{
+		"7d":         now.AddDate(0, 0, -7),
+		"-2w":        now.AddDate(0, 0, -14),
+		"2025-06-01": time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local),
+	} {
+		got, err := parseReportSince(in, now)
+		if err != nil {
+			t.Errorf("parseReportSince(%q): %v", in, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseReportSince(%q) = %v, want %v", in, got, want)
+		}
+	}
+	if _, err := parseReportSince("+3d", now); err == nil {
+		t.Error("expected an error for a future --since")
+	}
+}
+
+func TestRenderMarkdownReport(t *testing.T) {
+	generated := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	closedOld := generated.Add(-72 * time.Hour)
+	closedNew := generated.Add(-time.Hour)
+	blockedCount := 1
+	report := &ProjectReport{
+		GeneratedAt: generated,
+		Since:       generated.AddDate(0, 0, -7),
+		Summary:     &types.Statistics{OpenIssues: 4, InProgressIssues: 1, ClosedIssues: 9, BlockedIssues: &blockedCount},
+		Ready: []*types.Issue{
+			{ID: "bd-1", Title: "Fix *all* the [things]", Priority: 0, IssueType: types.TypeBug, Assignee: "ana"},
+		},
+		RecentlyClosed: []*types.Issue{
+			{ID: "bd-7", Title: "Older", Priority: 2, IssueType: types.TypeTask, ClosedAt: &closedOld},
+			{ID: "bd-8", Title: "Newer", Priority: 2, IssueType: types.TypeTask, ClosedAt: &closedNew},
+		},
+		Blocked: []*types.BlockedIssue{
+			{Issue: types.Issue{ID: "bd-3", Title: "Ship", Priority: 1, IssueType: types.TypeFeature}, BlockedByCount: 2, BlockedBy: []string{"bd-1", "bd-2"}},
+		},
+	}
+	sortClosedNewestFirst(report.RecentlyClosed)
+
+	var buf bytes.Buffer
+	if err := renderMarkdownReport(&buf, report); err != nil {
+		t.Fatalf("renderMarkdownReport: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"| 4 | 1 | 1 | 1 | 9 |",
+		`- **bd-1** P0 bug: Fix \*all\* the \[things\] — @ana`,
+		"## Closed Since 2025-06-08",
+		"- **bd-3** P1 feature: Ship, blocked by **bd-1**, **bd-2**",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "bd-8") > strings.Index(out, "bd-7") {
+		t.Errorf("recently closed issues should be newest first:\n%s", out)
+	}
+
+	empty := &ProjectReport{GeneratedAt: generated, Since: generated, Summary: &types.Statistics{}}
+	buf.Reset()
+	if err := renderMarkdownReport(&buf, empty); err != nil {
+		t.Fatalf("renderMarkdownReport(empty): %v", err)
+	}
+	if !strings.Contains(buf.String(), "_No ready work._") || !strings.Contains(buf.String(), "_Nothing is blocked._") {
+		t.Errorf("empty report placeholders missing:\n%s", buf.String())
+	}
+}
@@ -165,17 +165,39 @@ func resolveViaPrefixRoutingWithAccess(ctx context.Context, id string, writable
 	if prefix == "" {
 		return nil, fmt.Errorf("no prefix in ID %q", id)
 	}
+	targetStore, routePath, err := openPrefixRoutedStore(ctx, prefix, writable)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := resolveAndGetFromStore(ctx, targetStore, id, true)
+	if err != nil {
+		_ = targetStore.Close()
+		return nil, err
+	}
+	result.closeFn = func() { _ = targetStore.Close() }
+
+	if os.Getenv("BD_DEBUG_ROUTING") != "" {
+		fmt.Fprintf(os.Stderr, "[routing] Resolved %s via prefix route to %s\n", id, routePath)
+	}
+
+	return result, nil
+}
 
+// openPrefixRoutedStore opens the store of the rig routes.jsonl maps prefix
+// (e.g. "hr-") to, and returns it with the route's path. The caller closes
+// the store.
+func openPrefixRoutedStore(ctx context.Context, prefix string, writable bool) (storage.DoltStorage, string, error) {
 	// Find the resolved beads directory (where routes.jsonl lives)
 	currentBeadsDir := resolveCommandBeadsDir(dbPath)
 	if currentBeadsDir == "" {
-		return nil, fmt.Errorf("no beads directory available")
+		return nil, "", fmt.Errorf("no beads directory available")
 	}
 
 	// Load routes from routes.jsonl
 	routes, err := loadPrefixRoutes(currentBeadsDir)
 	if err != nil || len(routes) == 0 {
-		return nil, fmt.Errorf("no routes available")
+		return nil, "", fmt.Errorf("no routes available")
 	}
 
 	// Find matching route for this prefix
@@ -187,12 +209,12 @@ func resolveViaPrefixRoutingWithAccess(ctx context.Context, id string, writable
 		}
 	}
 	if matchedRoute == nil {
-		return nil, fmt.Errorf("no route for prefix %q", prefix)
+		return nil, "", fmt.Errorf("no route for prefix %q", prefix)
 	}
 
 	// Skip if the route points to current directory (town-level, already checked)
 	if matchedRoute.Path == "." {
-		return nil, fmt.Errorf("route points to current database")
+		return nil, "", fmt.Errorf("route points to current database")
 	}
 
 	// Derive the town root from the current beads dir.
@@ -206,7 +228,7 @@ func resolveViaPrefixRoutingWithAccess(ctx context.Context, id string, writable
 	// Check that the target has a different dolt_database
 	targetDB := readDoltDatabase(targetBeadsDir)
 	if targetDB == "" {
-		return nil, fmt.Errorf("target rig has no dolt_database configured")
+		return nil, "", fmt.Errorf("target rig has no dolt_database configured")
 	}
 
 	debug.Logf("[routing] Prefix %q matched route to %s (database: %s)\n", prefix, matchedRoute.Path, targetDB)
@@ -229,21 +251,9 @@ func resolveViaPrefixRoutingWithAccess(ctx context.Context, id string, writable
 		_ = os.Unsetenv("BEADS_DOLT_SERVER_DATABASE")
 	}
 	if openErr != nil {
-		return nil, fmt.Errorf("opening routed store for %s: %w", matchedRoute.Path, openErr)
-	}
-
-	result, err := resolveAndGetFromStore(ctx, targetStore, id, true)
-	if err != nil {
-		_ = targetStore.Close()
-		return nil, err
+		return nil, "", fmt.Errorf("opening routed store for %s: %w", matchedRoute.Path, openErr)
 	}
-	result.closeFn = func() { _ = targetStore.Close() }
-
-	if os.Getenv("BD_DEBUG_ROUTING") != "" {
-		fmt.Fprintf(os.Stderr, "[routing] Resolved %s via prefix route to %s (database: %s)\n", id, matchedRoute.Path, targetDB)
-	}
-
-	return result, nil
+	return targetStore, matchedRoute.Path, nil
 }
 
 // extractBeadPrefix extracts the prefix from a bead ID.
@@ -9,6 +9,7 @@ import (
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
 	"github.com/steveyegge/beads/internal/validation"
 )
@@ -17,14 +18,24 @@ var searchCmd = &cobra.Command{
 	Use:     "search [query]",
 	GroupID: "issues",
 	Short:   "Search issues by text query",
-	Long: `Search issues across title and ID (excludes closed issues by default).
+	Long: `Search issues by text (excludes closed issues by default).
 
 ID-like queries (e.g., "bd-123", "hq-319") use fast exact/prefix matching.
-Text queries search titles. Use --desc-contains for description search.
-Use --status all to include closed issues.
+Other queries are text searches: every word or "quoted phrase" must appear
+in the title, description, design, acceptance criteria, notes, comments, or
+ID. Results are ranked by relevance (title hits first) and show a snippet of
+the matching text; --sort overrides the ranking.
+
+Scope a term to one field with field:value or field:"a phrase"
+(title, desc, design, notes, acceptance, comment). label:, status:, type:,
+and assignee: filter like the matching flags.
+Use --status all to include closed issues, and --archived to search the
+beads moved to .beads/archive.jsonl by 'bd archive' instead of the database.
 
 Examples:
   bd search "authentication bug"
+  bd search 'title:crash label:bug'
+  bd search 'desc:"null pointer" type:bug'
   bd search "login" --status open
   bd search "database" --label backend --limit 10
   bd search --query "performance" --assignee alice
@@ -32,6 +43,7 @@ Examples:
   bd search "security" --priority-min 0 --priority-max 2
   bd search "bug" --created-after 2025-01-01
   bd search "refactor" --status all  # Include closed issues
+  bd search "migration" --archived   # Search archived beads
   bd search "bug" --sort priority
   bd search "task" --sort created --reverse
   bd search "api" --desc-contains "endpoint"
@@ -46,6 +58,10 @@ Examples:
 			}
 		}()
 
+		if archived, _ := cmd.Flags().GetBool("archived"); archived {
+			return runArchivedSearch(cmd, args)
+		}
+
 		if usesProxiedServer() {
 			return runSearchProxiedServer(cmd, rootCtx, args)
 		}
@@ -98,6 +114,15 @@ Examples:
 		noAssignee, _ := cmd.Flags().GetBool("no-assignee")
 		noLabels, _ := cmd.Flags().GetBool("no-labels")
 
+		text, err := parseSearchText(query)
+		if err != nil {
+			return HandleError("%v", err)
+		}
+		if err := text.mergeFlags(&status, &issueType, &assignee, &labels); err != nil {
+			return HandleError("%v", err)
+		}
+		ranked := text.rankedSearch(sortBy)
+
 		// Normalize labels
 		labels = utils.NormalizeLabels(labels)
 		labelsAny = utils.NormalizeLabels(labelsAny)
@@ -124,6 +149,7 @@ Examples:
 		}
 
 		if assignee != "" {
+			assignee = resolveAssignee(assignee)
 			filter.Assignee = &assignee
 		}
 
@@ -245,13 +271,21 @@ Examples:
 
 		ctx := rootCtx
 
-		issues, err := store.SearchIssues(ctx, query, filter)
+		issues, err := store.SearchIssues(ctx, applySearchText(text, query, &filter, ranked), filter)
 		if err != nil {
 			return HandleError("%v", err)
 		}
 
-		// Apply sorting
-		sortIssues(issues, sortBy, reverse)
+		// Rank text matches by relevance unless --sort asks for another order
+		var hits map[string]searchHit
+		if ranked {
+			hits = rankSearchResults(issues, text.Terms)
+			if limit > 0 && len(issues) > limit {
+				issues = issues[:limit]
+			}
+		} else {
+			sortIssues(issues, sortBy, reverse)
+		}
 
 		if jsonOutput {
 			// Get labels and dependency counts
@@ -307,13 +341,14 @@ Examples:
 			issue.Labels = labelsMap[issue.ID]
 		}
 
-		outputSearchResults(issues, query, longFormat)
+		outputSearchResults(issues, query, longFormat, hits)
 		return nil
 	},
 }
 
-// outputSearchResults formats and displays search results
-func outputSearchResults(issues []*types.Issue, query string, longFormat bool) {
+// outputSearchResults formats and displays search results. hits, when set,
+// holds the snippets of a ranked text search.
+func outputSearchResults(issues []*types.Issue, query string, longFormat bool, hits map[string]searchHit) {
 	if len(issues) == 0 {
 		fmt.Printf("No issues found matching '%s'\n", query)
 		return
@@ -331,6 +366,9 @@ func outputSearchResults(issues []*types.Issue, query string, longFormat bool) {
 			if len(issue.Labels) > 0 {
 				fmt.Printf("  Labels: %v\n", issue.Labels)
 			}
+			if snippet := hits[issue.ID].Snippet; snippet != "" {
+				fmt.Printf("  %s\n", snippet)
+			}
 			fmt.Println()
 		}
 	} else {
@@ -348,6 +386,9 @@ func outputSearchResults(issues []*types.Issue, query string, longFormat bool) {
 			fmt.Printf("%s [P%d] [%s] %s%s%s - %s\n",
 				issue.ID, issue.Priority, issue.IssueType, issue.Status,
 				assigneeStr, labelsStr, issue.Title)
+			if snippet := hits[issue.ID].Snippet; snippet != "" {
+				fmt.Printf("    %s\n", ui.RenderMuted(snippet))
+			}
 		}
 	}
 }
@@ -363,6 +404,7 @@ func init() {
 	searchCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
 	searchCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
 	searchCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
+	searchCmd.Flags().Bool("archived", false, "Search beads archived to .beads/archive.jsonl (supports text, --type, --assignee, --label, --limit, --sort)")
 
 	// Date range flags
 	searchCmd.Flags().String("created-after", "", "Filter issues created after date (YYYY-MM-DD or RFC3339)")
@@ -116,6 +116,39 @@ func TestEmbeddedSearch(t *testing.T) {
 		}
 	})
 
+	// ===== Text Search =====
+
+	t.Run("search_matches_description", func(t *testing.T) {
+		results := bdSearchJSON(t, bd, dir, "important")
+		if len(results) != 1 || results[0]["id"] != taskA.ID {
+			t.Errorf("expected only %s for description term 'important', got %v", taskA.ID, results)
+		}
+	})
+
+	t.Run("search_field_scope", func(t *testing.T) {
+		results := bdSearchJSON(t, bd, dir, "title:bug")
+		if len(results) != 1 || results[0]["id"] != taskB.ID {
+			t.Errorf("expected only %s for title:bug, got %v", taskB.ID, results)
+		}
+		if results := bdSearchJSON(t, bd, dir, "desc:gamma"); len(results) != 0 {
+			t.Errorf("expected no description match for desc:gamma, got %d", len(results))
+		}
+	})
+
+	t.Run("search_label_scope", func(t *testing.T) {
+		results := bdSearchJSON(t, bd, dir, "task label:urgent")
+		if len(results) != 1 || results[0]["id"] != taskA.ID {
+			t.Errorf("expected only %s for 'task label:urgent', got %v", taskA.ID, results)
+		}
+	})
+
+	t.Run("search_ranks_title_first", func(t *testing.T) {
+		results := bdSearchJSON(t, bd, dir, "beta")
+		if len(results) == 0 || results[0]["id"] != taskB.ID {
+			t.Errorf("expected %s ranked first for 'beta', got %v", taskB.ID, results)
+		}
+	})
+
 	// ===== Status Filter =====
 
 	t.Run("search_status_open", func(t *testing.T) {
@@ -57,6 +57,15 @@ func runSearchProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 	noAssignee, _ := cmd.Flags().GetBool("no-assignee")
 	noLabels, _ := cmd.Flags().GetBool("no-labels")
 
+	text, err := parseSearchText(query)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if err := text.mergeFlags(&status, &issueType, &assignee, &labels); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	ranked := text.rankedSearch(sortBy)
+
 	labels = utils.NormalizeLabels(labels)
 	labelsAny = utils.NormalizeLabels(labelsAny)
 
@@ -69,6 +78,7 @@ func runSearchProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 	}
 
 	if assignee != "" {
+		assignee = resolveAssignee(assignee)
 		filter.Assignee = &assignee
 	}
 
@@ -201,25 +211,50 @@ func runSearchProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 		}
 	}
 
+	searchQuery := applySearchText(text, query, &filter, ranked)
 	if jsonOutput {
-		page, err := uw.IssueUseCase().SearchIssuesWithCounts(ctx, query, filter)
+		page, err := uw.IssueUseCase().SearchIssuesWithCounts(ctx, searchQuery, filter)
 		if err != nil {
 			return HandleErrorRespectJSON("%v", err)
 		}
 		items := page.Items
-		sortIssuesWithCounts(items, sortBy, reverse)
+		if ranked {
+			issues := make([]*types.Issue, len(items))
+			byID := make(map[string]*types.IssueWithCounts, len(items))
+			for i, item := range items {
+				issues[i] = item.Issue
+				byID[item.ID] = item
+			}
+			rankSearchResults(issues, text.Terms)
+			for i, issue := range issues {
+				items[i] = byID[issue.ID]
+			}
+			if limit > 0 && len(items) > limit {
+				items = items[:limit]
+			}
+		} else {
+			sortIssuesWithCounts(items, sortBy, reverse)
+		}
 		if items == nil {
 			items = []*types.IssueWithCounts{}
 		}
 		return outputJSON(items)
 	}
 
-	page, err := uw.IssueUseCase().SearchIssues(ctx, query, filter)
+	page, err := uw.IssueUseCase().SearchIssues(ctx, searchQuery, filter)
 	if err != nil {
 		return HandleErrorRespectJSON("%v", err)
 	}
 	issues := page.Items
-	sortIssues(issues, sortBy, reverse)
-	outputSearchResults(issues, query, longFormat)
+	var hits map[string]searchHit
+	if ranked {
+		hits = rankSearchResults(issues, text.Terms)
+		if limit > 0 && len(issues) > limit {
+			issues = issues[:limit]
+		}
+	} else {
+		sortIssues(issues, sortBy, reverse)
+	}
+	outputSearchResults(issues, query, longFormat, hits)
 	return nil
 }
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// searchRankCandidates is how many matches a ranked search fetches before
+// ranking and trimming to --limit, so the best hits are not cut off by the
+// storage order.
+const searchRankCandidates = 1000
+
+// searchSnippetRadius is how many characters of context a snippet keeps on
+// each side of the matched term.
+const searchSnippetRadius = 40
+
+// searchTextScopes maps the field prefixes of a text query to the field they
+// search.
+var searchTextScopes = map[string]types.TextField{
+	"title":       types.TextFieldTitle,
+	"desc":        types.TextFieldDescription,
+	"description": types.TextFieldDescription,
+	"design":      types.TextFieldDesign,
+	"notes":       types.TextFieldNotes,
+	"acceptance":  types.TextFieldAcceptance,
+	"comment":     types.TextFieldComments,
+	"comments":    types.TextFieldComments,
+}
+
+// searchText is a parsed 'bd search' query: free and field-scoped text terms,
+// plus the filter scopes (label:, status:, type:, assignee:) that stand in for
+// the corresponding flags.
+type searchText struct {
+	Terms    []types.TextTerm
+	Labels   []string
+	Status   string
+	Type     string
+	Assignee string
+	// IDLookup is set for a bare ID-like query ("bd-12"), which keeps the
+	// fast exact/prefix ID match instead of text search.
+	IDLookup bool
+}
+
+// parseSearchText splits query into terms. Words and "quoted phrases" become
+// terms matched anywhere; field:value and field:"a phrase" scope a term to one
+// field. A prefix that is not a known field is kept as part of the term, so
+// "error:" or a URL searches literally.
+func parseSearchText(query string) (searchText, error) {
+	var st searchText
+	if issueops.LooksLikeIssueID(strings.TrimSpace(query)) {
+		st.IDLookup = true
+		return st, nil
+	}
+	for _, tok := range tokenizeSearchText(query) {
+		scope, value, scoped := strings.Cut(tok.text, ":")
+		if !scoped || tok.quotedWhole || value == "" {
+			st.Terms = append(st.Terms, types.TextTerm{Text: tok.text})
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch scope = strings.ToLower(scope); scope {
+		case "label":
+			st.Labels = append(st.Labels, value)
+		case "status", "type", "assignee":
+			if err := st.setScope(scope, value); err != nil {
+				return st, err
+			}
+		default:
+			field, ok := searchTextScopes[scope]
+			if !ok {
+				st.Terms = append(st.Terms, types.TextTerm{Text: tok.text})
+				continue
+			}
+			st.Terms = append(st.Terms, types.TextTerm{Field: field, Text: value})
+		}
+	}
+	return st, nil
+}
+
+func (st *searchText) setScope(scope, value string) error {
+	dst := map[string]*string{"status": &st.Status, "type": &st.Type, "assignee": &st.Assignee}[scope]
+	if *dst != "" && *dst != value {
+		return fmt.Errorf("%s: given twice in query (%q and %q)", scope, *dst, value)
+	}
+	*dst = value
+	return nil
+}
+
+// mergeFlags folds the filter scopes into the values of the matching flags,
+// so the rest of the command builds the filter as if they had been passed as
+// --status, --type, --assignee, and --label.
+func (st searchText) mergeFlags(status, issueType, assignee *string, labels *[]string) error {
+	for _, f := range []struct {
+		name  string
+		scope string
+		flag  *string
+	}{
+		{"status", st.Status, status},
+		{"type", st.Type, issueType},
+		{"assignee", st.Assignee, assignee},
+	} {
+		if f.scope == "" {
+			continue
+		}
+		if *f.flag != "" && *f.flag != f.scope {
+			return fmt.Errorf("%s:%s in the query conflicts with --%s %s", f.name, f.scope, f.name, *f.flag)
+		}
+		*f.flag = f.scope
+	}
+	*labels = append(*labels, st.Labels...)
+	return nil
+}
+
+type searchToken struct {
+	text        string
+	quotedWhole bool // the whole token was a "quoted phrase"
+}
+
+// tokenizeSearchText splits on whitespace outside double quotes. Quotes are
+// removed from the token text except after a field prefix, where
+// parseSearchText strips them from the value.
+func tokenizeSearchText(query string) []searchToken {
+	var tokens []searchToken
+	var cur strings.Builder
+	inQuote, quotedWhole := false, false
+	flush := func() {
+		if text := strings.TrimSpace(cur.String()); text != "" {
+			tokens = append(tokens, searchToken{text: text, quotedWhole: quotedWhole})
+		}
+		cur.Reset()
+		quotedWhole = false
+	}
+	for _, r := range query {
+		switch {
+		case r == '"':
+			if !inQuote && cur.Len() == 0 {
+				quotedWhole = true
+			}
+			inQuote = !inQuote
+			if !quotedWhole {
+				cur.WriteRune(r)
+			}
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// searchFieldWeights rank where a term matched: a title hit outweighs a hit
+// buried in notes. Comments are not loaded with results, so a comment-only
+// match ranks below every field hit.
+var searchFieldWeights = []struct {
+	field  types.TextField
+	weight float64
+	text   func(*types.Issue) string
+}{
+	{types.TextFieldTitle, 10, func(i *types.Issue) string { return i.Title }},
+	{types.TextFieldDescription, 3, func(i *types.Issue) string { return i.Description }},
+	{types.TextFieldDesign, 2, func(i *types.Issue) string { return i.Design }},
+	{types.TextFieldAcceptance, 2, func(i *types.Issue) string { return i.AcceptanceCriteria }},
+	{types.TextFieldNotes, 1, func(i *types.Issue) string { return i.Notes }},
+}
+
+// searchHit is a ranked text search result.
+type searchHit struct {
+	Score   float64
+	Snippet string // context around the best non-title match; empty when the title matched
+}
+
+// scoreSearchHit ranks issue against terms: each occurrence adds its field's
+// weight (capped per field so repetition cannot dominate), and an exact ID
+// match ranks first.
+func scoreSearchHit(issue *types.Issue, terms []types.TextTerm) searchHit {
+	var hit searchHit
+	titleMatched := false
+	for _, term := range terms {
+		needle := strings.ToLower(term.Text)
+		if term.Field == types.TextFieldAny && strings.EqualFold(issue.ID, term.Text) {
+			hit.Score += 100
+		}
+		for _, f := range searchFieldWeights {
+			if term.Field != types.TextFieldAny && term.Field != f.field {
+				continue
+			}
+			text := f.text(issue)
+			n := strings.Count(strings.ToLower(text), needle)
+			if n == 0 {
+				continue
+			}
+			hit.Score += f.weight * float64(min(n, 3))
+			if f.field == types.TextFieldTitle {
+				titleMatched = true
+			} else if hit.Snippet == "" {
+				hit.Snippet = searchSnippet(text, needle)
+			}
+		}
+	}
+	if titleMatched {
+		hit.Snippet = ""
+	}
+	return hit
+}
+
+// searchSnippet returns the text around the first occurrence of needle (given
+// in lower case) on one line, with the match highlighted.
+func searchSnippet(text, needle string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	idx := strings.Index(strings.ToLower(text), needle)
+	// ToLower can change byte lengths outside ASCII; skip the snippet then.
+	if idx < 0 || idx+len(needle) > len(text) {
+		return ""
+	}
+	start, end := max(0, idx-searchSnippetRadius), min(len(text), idx+len(needle)+searchSnippetRadius)
+	// Move the window edges onto rune boundaries.
+	for start > 0 && !isRuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !isRuneStart(text[end]) {
+		end++
+	}
+	snippet := text[start:idx] + ui.RenderAccent(text[idx:idx+len(needle)]) + text[idx+len(needle):end]
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+func isRuneStart(b byte) bool { return b&0xC0 != 0x80 }
+
+// rankSearchResults orders issues by relevance, keeping the storage order
+// among equal scores, and returns each issue's hit.
+func rankSearchResults(issues []*types.Issue, terms []types.TextTerm) map[string]searchHit {
+	hits := make(map[string]searchHit, len(issues))
+	for _, issue := range issues {
+		hits[issue.ID] = scoreSearchHit(issue, terms)
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return hits[issues[i].ID].Score > hits[issues[j].ID].Score
+	})
+	return hits
+}
+
+// applySearchText puts the text terms of st into filter and returns the query
+// string for SearchIssues: the raw query for an ID lookup, empty otherwise.
+// When ranked, filter.Limit is widened to searchRankCandidates; the caller
+// trims to the requested limit after rankSearchResults.
+func applySearchText(st searchText, query string, filter *types.IssueFilter, ranked bool) string {
+	if st.IDLookup {
+		return query
+	}
+	filter.TextTerms = st.Terms
+	if ranked && filter.Limit > 0 && filter.Limit < searchRankCandidates {
+		filter.Limit = searchRankCandidates
+	}
+	return ""
+}
+
+// rankedSearch reports whether results are ordered by relevance: a text
+// search without an explicit --sort.
+func (st searchText) rankedSearch(sortBy string) bool {
+	return !st.IDLookup && len(st.Terms) > 0 && sortBy == ""
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseSearchText(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  searchText
+	}{
+		{
+			name:  "id lookup",
+			query: "bd-12",
+			want:  searchText{IDLookup: true},
+		},
+		{
+			name:  "words and phrase",
+			query: `login "null pointer"`,
+			want: searchText{Terms: []types.TextTerm{
+				{Text: "login"},
+				{Text: "null pointer"},
+			}},
+		},
+		{
+			name:  "field scopes",
+			query: `title:crash desc:"stack trace" label:bug type:bug status:open assignee:alice`,
+			want: searchText{
+				Terms: []types.TextTerm{
+					{Field: types.TextFieldTitle, Text: "crash"},
+					{Field: types.TextFieldDescription, Text: "stack trace"},
+				},
+				Labels:   []string{"bug"},
+				Type:     "bug",
+				Status:   "open",
+				Assignee: "alice",
+			},
+		},
+		{
+			name:  "unknown prefix stays literal",
+			query: `https://example.com "error: timeout"`,
+			want: searchText{Terms: []types.TextTerm{
+				{Text: "https://example.com"},
+				{Text: "error: timeout"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchText(tt.query)
+			if err != nil {
+				t.Fatalf("parseSearchText(%q): %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSearchText(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseSearchText("type:bug type:task"); err == nil {
+		t.Error("conflicting type: scopes: want error")
+	}
+}
+
+func TestSearchTextMergeFlags(t *testing.T) {
+	st := searchText{Type: "bug", Labels: []string{"ui"}}
+	status, issueType, assignee := "", "", "bob"
+	labels := []string{"backend"}
+	if err := st.mergeFlags(&status, &issueType, &assignee, &labels); err != nil {
+		t.Fatalf("mergeFlags: %v", err)
+	}
+	if issueType != "bug" || assignee != "bob" || !reflect.DeepEqual(labels, []string{"backend", "ui"}) {
+		t.Errorf("merged type=%q assignee=%q labels=%v", issueType, assignee, labels)
+	}
+
+	issueType = "task"
+	if err := st.mergeFlags(&status, &issueType, &assignee, &labels); err == nil {
+		t.Error("type:bug with --type task: want conflict error")
+	}
+}
+
+func TestRankSearchResults(t *testing.T) {
+	inNotes := &types.Issue{ID: "bd-1", Title: "Unrelated", Notes: "saw a crash while testing"}
+	inTitle := &types.Issue{ID: "bd-2", Title: "Crash on startup"}
+	inDesc := &types.Issue{ID: "bd-3", Title: "Startup", Description: "The app will crash when the config file is missing and the cache is cold."}
+	issues := []*types.Issue{inNotes, inTitle, inDesc}
+
+	hits := rankSearchResults(issues, []types.TextTerm{{Text: "crash"}})
+
+	var order []string
+	for _, issue := range issues {
+		order = append(order, issue.ID)
+	}
+	if want := []string{"bd-2", "bd-3", "bd-1"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("ranked order = %v, want %v", order, want)
+	}
+	if hits["bd-2"].Snippet != "" {
+		t.Errorf("title hit snippet = %q, want none", hits["bd-2"].Snippet)
+	}
+	if s := hits["bd-3"].Snippet; !strings.Contains(s, "crash") || !strings.HasSuffix(s, "…") {
+		t.Errorf("description snippet = %q, want the match with a trailing ellipsis", s)
+	}
+}
+
+func TestRankSearchResultsScopedTerm(t *testing.T) {
+	inTitle := &types.Issue{ID: "bd-1", Title: "crash", Description: "x"}
+	inNotes := &types.Issue{ID: "bd-2", Title: "other", Notes: "crash"}
+	issues := []*types.Issue{inTitle, inNotes}
+
+	hits := rankSearchResults(issues, []types.TextTerm{{Field: types.TextFieldNotes, Text: "crash"}})
+	if issues[0] != inNotes {
+		t.Errorf("notes-scoped search ranked %s first, want bd-2", issues[0].ID)
+	}
+	if hits["bd-1"].Score != 0 {
+		t.Errorf("title match scored %v for a notes-scoped term, want 0", hits["bd-1"].Score)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/uimd"
@@ -46,6 +47,7 @@ var showCmd = &cobra.Command{
 		currentMode, _ := cmd.Flags().GetBool("current")
 		includeDepends, _ := cmd.Flags().GetBool("include-dependents")
 		includeComments, _ := cmd.Flags().GetBool("include-comments")
+		fullMode, _ := cmd.Flags().GetBool("full")
 		ctx := rootCtx
 
 		// Helper to format timestamp based on --local-time flag
@@ -140,13 +142,34 @@ var showCmd = &cobra.Command{
 				result.Close()
 				continue
 			}
+			hydrateAssignments(ctx, issueStore, []*types.Issue{issue})
+
+			// --full: every section plus recent events and computed fields,
+			// read in one storage transaction.
+			var full *types.IssueDetails
+			if fullMode {
+				full, err = getIssueContext(ctx, issueStore, issue.ID)
+				if err != nil {
+					result.Close()
+					return HandleErrorRespectJSON("load context of %s: %v", issue.ID, err)
+				}
+				full.Dependencies = withRemoteDependencies(ctx, storeRemoteDeps{issueStore}, issue.ID, full.Dependencies)
+				full.Version = issue.VersionToken()
+			}
 
+			if jsonOutput && full != nil {
+				allDetails = append(allDetails, full)
+				result.Close()
+				continue
+			}
 			if jsonOutput {
 				// be-ijck6q: default is count-only (no dependents/comments slice in output).
 				// Use --include-dependents / --include-comments to stream the full lists.
-				details := &types.IssueDetails{Issue: *issue}
+				details := &types.IssueDetails{Issue: *issue, Version: issue.VersionToken()}
+				details.Checklist = types.ChecklistProgressOf(issue.Description)
 				details.Labels, _ = issueStore.GetLabels(ctx, issue.ID)
 				details.Dependencies, _ = issueStore.GetDependenciesWithMetadata(ctx, issue.ID)
+				details.Dependencies = withRemoteDependencies(ctx, storeRemoteDeps{issueStore}, issue.ID, details.Dependencies)
 
 				// Aggregate counts — O(1) queries, no row materialization.
 				depCount, _ := issueStore.CountDependents(ctx, issue.ID)
@@ -155,6 +178,10 @@ var showCmd = &cobra.Command{
 				details.DependencyCount = &depnCount
 				cmtCount, _ := issueStore.CountIssueComments(ctx, issue.ID)
 				details.CommentCount = &cmtCount
+				details.Attachments = getIssueAttachments(ctx, issueStore, issue.ID)
+				details.Commits = getIssueCommitLinks(ctx, issueStore, issue.ID)
+				details.Aliases = getIssueAliases(ctx, issueStore, issue.ID)
+				details.Recurrence = getIssueRecurrence(ctx, issueStore, issue.ID)
 
 				// --include-dependents: stream via Iter, shallow-copy each item.
 				// May be slow on hub beads with many dependents.
@@ -243,6 +270,16 @@ var showCmd = &cobra.Command{
 
 			// Metadata: Owner · Type | Created · Updated
 			fmt.Println(formatIssueMetadata(issue))
+			if full != nil {
+				fmt.Println(formatIssueContextSummary(full))
+			}
+
+			// Related sections come from the --full context when loaded,
+			// otherwise from one best-effort query each.
+			sections := full
+			if sections == nil {
+				sections = loadShowSections(ctx, issueStore, issue.ID)
+			}
 
 			// Compaction info (if applicable)
 			if issue.CompactionLevel > 0 {
@@ -265,6 +302,9 @@ var showCmd = &cobra.Command{
 			} else {
 				fmt.Printf("\n%s\n  %s\n", ui.RenderBold("DESCRIPTION"), ui.RenderMuted("(none)"))
 			}
+			if progress := types.ChecklistProgressOf(issue.Description); progress != nil {
+				fmt.Println(formatChecklistProgress(progress))
+			}
 			if issue.Design != "" {
 				fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), uimd.RenderMarkdown(issue.Design))
 			}
@@ -276,9 +316,18 @@ var showCmd = &cobra.Command{
 			}
 
 			// Show labels
-			labels, _ := issueStore.GetLabels(ctx, issue.ID) // Best effort: show issue even if label fetch fails
-			if len(labels) > 0 {
-				fmt.Printf("\n%s %s\n", ui.RenderBold("LABELS:"), strings.Join(labels, ", "))
+			if len(sections.Labels) > 0 {
+				fmt.Printf("\n%s %s\n", ui.RenderBold("LABELS:"), strings.Join(sections.Labels, ", "))
+			}
+
+			// Show aliases
+			if len(sections.Aliases) > 0 {
+				fmt.Printf("\n%s %s\n", ui.RenderBold("ALIASES:"), strings.Join(sections.Aliases, ", "))
+			}
+
+			// Show recurrence
+			if r := sections.Recurrence; r != nil {
+				fmt.Printf("\n%s %s\n", ui.RenderBold("RECURS:"), formatRecurrence(r))
 			}
 
 			// Show custom metadata (GH#1406)
@@ -291,11 +340,11 @@ var showCmd = &cobra.Command{
 			relatedSeen := make(map[string]*types.IssueWithDependencyMetadata)
 
 			// Show dependencies - grouped by dependency type for clarity
-			depsWithMeta, _ := issueStore.GetDependenciesWithMetadata(ctx, issue.ID) // Best effort: show issue even if deps unavailable
+			depsWithMeta := sections.Dependencies
 
 			if len(depsWithMeta) > 0 {
 				// Group by dependency type
-				var blocks, parent, discovered []*types.IssueWithDependencyMetadata
+				var blocks, parent, discovered, duplicateOf, causedBy []*types.IssueWithDependencyMetadata
 				for _, dep := range depsWithMeta {
 					switch dep.DependencyType {
 					case types.DepBlocks:
@@ -304,6 +353,10 @@ var showCmd = &cobra.Command{
 						parent = append(parent, dep)
 					case types.DepRelated, types.DepRelatesTo:
 						relatedSeen[dep.ID] = dep
+					case types.DepDuplicates:
+						duplicateOf = append(duplicateOf, dep)
+					case types.DepCausedBy:
+						causedBy = append(causedBy, dep)
 					case types.DepDiscoveredFrom:
 						discovered = append(discovered, dep)
 					default:
@@ -329,13 +382,25 @@ var showCmd = &cobra.Command{
 						fmt.Println(formatDependencyLine("◊", dep))
 					}
 				}
+				if len(duplicateOf) > 0 {
+					fmt.Printf("\n%s\n", ui.RenderBold("DUPLICATE OF"))
+					for _, dep := range duplicateOf {
+						fmt.Println(formatDependencyLine("≡", dep))
+					}
+				}
+				if len(causedBy) > 0 {
+					fmt.Printf("\n%s\n", ui.RenderBold("CAUSED BY"))
+					for _, dep := range causedBy {
+						fmt.Println(formatDependencyLine("⇠", dep))
+					}
+				}
 			}
 
 			// Show dependents - grouped by dependency type for clarity
-			dependentsWithMeta, _ := issueStore.GetDependentsWithMetadata(ctx, issue.ID) // Best effort: show issue even if dependents unavailable
+			dependentsWithMeta := sections.Dependents
 			if len(dependentsWithMeta) > 0 {
 				// Group by dependency type
-				var blocks, children, discovered []*types.IssueWithDependencyMetadata
+				var blocks, children, discovered, duplicates, caused []*types.IssueWithDependencyMetadata
 				for _, dep := range dependentsWithMeta {
 					switch dep.DependencyType {
 					case types.DepBlocks:
@@ -344,6 +409,10 @@ var showCmd = &cobra.Command{
 						children = append(children, dep)
 					case types.DepRelated, types.DepRelatesTo:
 						relatedSeen[dep.ID] = dep
+					case types.DepDuplicates:
+						duplicates = append(duplicates, dep)
+					case types.DepCausedBy:
+						caused = append(caused, dep)
 					case types.DepDiscoveredFrom:
 						discovered = append(discovered, dep)
 					default:
@@ -356,24 +425,23 @@ var showCmd = &cobra.Command{
 					for _, dep := range children {
 						fmt.Println(formatDependencyLine("↳", dep))
 					}
-					// Epic progress summary
-					if issue.IssueType == types.TypeEpic {
-						closedCount := 0
-						for _, dep := range children {
-							if dep.Issue.Status == types.StatusClosed {
-								closedCount++
-							}
-						}
-						pct := 0
-						if len(children) > 0 {
-							pct = (closedCount * 100) / len(children)
-						}
-						if closedCount == len(children) {
-							fmt.Printf("  %s %d/%d complete (%d%%) — eligible for close\n", ui.RenderPass("✓"), closedCount, len(children), pct)
-						} else {
-							fmt.Printf("  %s %d/%d complete (%d%%)\n", ui.RenderMuted("◐"), closedCount, len(children), pct)
+					// Progress summary for any parent; epics are also flagged as
+					// eligible for close once every child is.
+					closedCount := 0
+					for _, dep := range children {
+						if dep.Issue.Status == types.StatusClosed {
+							closedCount++
 						}
 					}
+					pct := 0
+					if len(children) > 0 {
+						pct = (closedCount * 100) / len(children)
+					}
+					if closedCount == len(children) && issue.IssueType == types.TypeEpic {
+						fmt.Printf("  %s %d/%d complete (%d%%) — eligible for close\n", ui.RenderPass("✓"), closedCount, len(children), pct)
+					} else {
+						fmt.Printf("  %s %d/%d complete (%d%%)\n", ui.RenderMuted("◐"), closedCount, len(children), pct)
+					}
 				}
 				if len(blocks) > 0 {
 					fmt.Printf("\n%s\n", ui.RenderBold("BLOCKS"))
@@ -387,6 +455,18 @@ var showCmd = &cobra.Command{
 						fmt.Println(formatDependencyLine("◊", dep))
 					}
 				}
+				if len(duplicates) > 0 {
+					fmt.Printf("\n%s\n", ui.RenderBold("DUPLICATES"))
+					for _, dep := range duplicates {
+						fmt.Println(formatDependencyLine("≡", dep))
+					}
+				}
+				if len(caused) > 0 {
+					fmt.Printf("\n%s\n", ui.RenderBold("CAUSED"))
+					for _, dep := range caused {
+						fmt.Println(formatDependencyLine("⇢", dep))
+					}
+				}
 			}
 
 			// Print deduplicated RELATED section (bidirectional links shown once)
@@ -398,10 +478,9 @@ var showCmd = &cobra.Command{
 			}
 
 			// Show comments
-			comments, _ := issueStore.GetIssueComments(ctx, issue.ID) // Best effort: show issue even if comments unavailable
-			if len(comments) > 0 {
+			if len(sections.Comments) > 0 {
 				fmt.Printf("\n%s\n", ui.RenderBold("COMMENTS"))
-				for _, comment := range comments {
+				for _, comment := range sections.Comments {
 					fmt.Printf("  %s %s\n", ui.RenderMuted(formatTime(comment.CreatedAt)), comment.Author)
 					rendered := uimd.RenderMarkdown(comment.Text)
 					// TrimRight removes trailing newlines that Glamour adds, preventing extra blank lines
@@ -411,6 +490,26 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			// Show attachments
+			if list := sections.Attachments; len(list) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("ATTACHMENTS"))
+				printAttachments(list)
+			}
+
+			// Show linked commits
+			if list := sections.Commits; len(list) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("COMMITS"))
+				printCommitLinks(list)
+			}
+
+			// Show recent events (--full only)
+			if len(sections.Events) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("HISTORY"))
+				for _, event := range sections.Events {
+					fmt.Println(formatIssueEventLine(event, formatTime))
+				}
+			}
+
 			// Long mode: show all extended fields
 			if longMode {
 				fmt.Print(formatIssueLongExtras(issue, formatTime))
@@ -491,6 +590,7 @@ func init() {
 	showCmd.Flags().Bool("current", false, "Show the currently active issue (in-progress, hooked, or last touched)")
 	showCmd.Flags().Bool("include-dependents", false, "Stream full dependent issues in JSON output (--json only; may be slow on hub beads)")
 	showCmd.Flags().Bool("include-comments", false, "Stream full comment bodies in JSON output (--json only; may be slow on issues with many comments)")
+	showCmd.Flags().Bool("full", false, "Show full relational context (dependents, comments, recent events, blocked_by_count, days_open) read in one storage call")
 	showCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(showCmd)
 }
@@ -534,3 +634,32 @@ func resolveCurrentIssueID(ctx context.Context) string {
 	// 3. Last touched issue (fallback)
 	return GetLastTouchedID()
 }
+
+// showEventLimit caps the recent events bd show --full prints.
+const showEventLimit = 20
+
+// getIssueContext reads an issue's full relational context through the
+// store's IssueContextStore capability (bd show --full).
+func getIssueContext(ctx context.Context, st storage.DoltStorage, issueID string) (*types.IssueDetails, error) {
+	cs, ok := storage.UnwrapStore(st).(storage.IssueContextStore)
+	if !ok {
+		return nil, fmt.Errorf("--full is not supported by this storage backend")
+	}
+	return cs.GetIssueContext(ctx, issueID, showEventLimit)
+}
+
+// loadShowSections gathers the related sections bd show prints for an issue,
+// one best-effort query each: the issue is still shown when a section fails.
+func loadShowSections(ctx context.Context, st storage.DoltStorage, issueID string) *types.IssueDetails {
+	d := &types.IssueDetails{}
+	d.Labels, _ = st.GetLabels(ctx, issueID)
+	d.Aliases = getIssueAliases(ctx, st, issueID)
+	d.Recurrence = getIssueRecurrence(ctx, st, issueID)
+	d.Dependencies, _ = st.GetDependenciesWithMetadata(ctx, issueID)
+	d.Dependencies = withRemoteDependencies(ctx, storeRemoteDeps{st}, issueID, d.Dependencies)
+	d.Dependents, _ = st.GetDependentsWithMetadata(ctx, issueID)
+	d.Comments, _ = st.GetIssueComments(ctx, issueID)
+	d.Attachments = getIssueAttachments(ctx, st, issueID)
+	d.Commits = getIssueCommitLinks(ctx, st, issueID)
+	return d
+}
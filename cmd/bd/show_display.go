@@ -96,6 +96,7 @@ func displayShowIssueReturn(ctx context.Context, issueID string) *types.Issue {
 	}
 	issue := result.Issue
 	issueStore := result.Store
+	hydrateAssignments(ctx, issueStore, []*types.Issue{issue})
 
 	// Display the issue header and metadata
 	fmt.Println(formatIssueHeader(issue))
@@ -121,6 +122,16 @@ func displayShowIssueReturn(ctx context.Context, issueID string) *types.Issue {
 		fmt.Printf("\n%s %s\n", ui.RenderBold("LABELS:"), strings.Join(labels, ", "))
 	}
 
+	// Aliases
+	if aliases := getIssueAliases(ctx, issueStore, issue.ID); len(aliases) > 0 {
+		fmt.Printf("\n%s %s\n", ui.RenderBold("ALIASES:"), strings.Join(aliases, ", "))
+	}
+
+	// Recurrence
+	if r := getIssueRecurrence(ctx, issueStore, issue.ID); r != nil {
+		fmt.Printf("\n%s %s\n", ui.RenderBold("RECURS:"), formatRecurrence(r))
+	}
+
 	// Dependencies (what this issue depends on)
 	relatedSeen := make(map[string]*types.IssueWithDependencyMetadata)
 	depsWithMeta, _ := issueStore.GetDependenciesWithMetadata(ctx, issue.ID)
@@ -220,6 +231,18 @@ func displayShowIssueReturn(ctx context.Context, issueID string) *types.Issue {
 		}
 	}
 
+	// Attachments
+	if list := getIssueAttachments(ctx, issueStore, issue.ID); len(list) > 0 {
+		fmt.Printf("\n%s\n", ui.RenderBold("ATTACHMENTS"))
+		printAttachments(list)
+	}
+
+	// Linked commits
+	if list := getIssueCommitLinks(ctx, issueStore, issue.ID); len(list) > 0 {
+		fmt.Printf("\n%s\n", ui.RenderBold("COMMITS"))
+		printCommitLinks(list)
+	}
+
 	fmt.Println()
 	return issue
 }
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"sync"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // bdShowRaw runs "bd show" with the given args and returns raw stdout.
@@ -363,6 +365,65 @@ func TestEmbeddedShow(t *testing.T) {
 			}
 		}
 	})
+
+	// ===== --full =====
+
+	t.Run("show_full", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Full context", "--type", "task")
+		blocker := bdCreate(t, bd, dir, "Full blocker", "--type", "task")
+		dependent := bdCreate(t, bd, dir, "Full dependent", "--type", "task")
+		bdDepAdd(t, bd, dir, issue.ID, blocker.ID)
+		bdDepAdd(t, bd, dir, dependent.ID, issue.ID)
+		bdComment(t, bd, dir, issue.ID, "full context comment")
+		bdUpdate(t, bd, dir, issue.ID, "--priority", "1")
+
+		out, err := bdRunWithFlockRetry(t, bd, dir, "show", issue.ID, "--full", "--json")
+		if err != nil {
+			t.Fatalf("bd show --full --json failed: %v\n%s", err, out)
+		}
+		var details []types.IssueDetails
+		s := string(out)
+		if err := json.Unmarshal([]byte(s[strings.Index(s, "["):]), &details); err != nil {
+			t.Fatalf("parse show --full JSON: %v\n%s", err, s)
+		}
+		if len(details) != 1 {
+			t.Fatalf("expected 1 issue, got %d", len(details))
+		}
+		d := details[0]
+		if d.BlockedByCount == nil || *d.BlockedByCount != 1 {
+			t.Errorf("blocked_by_count = %v, want 1", d.BlockedByCount)
+		}
+		if d.DaysOpen == nil || *d.DaysOpen != 0 {
+			t.Errorf("days_open = %v, want 0", d.DaysOpen)
+		}
+		if len(d.Dependencies) != 1 || d.Dependencies[0].ID != blocker.ID {
+			t.Errorf("dependencies = %+v, want %s", d.Dependencies, blocker.ID)
+		}
+		if len(d.Dependents) != 1 || d.Dependents[0].ID != dependent.ID {
+			t.Errorf("dependents = %+v, want %s", d.Dependents, dependent.ID)
+		}
+		if len(d.Comments) != 1 || d.Comments[0].Text != "full context comment" {
+			t.Errorf("comments = %+v", d.Comments)
+		}
+		var sawCreated, sawUpdated bool
+		for _, e := range d.Events {
+			sawCreated = sawCreated || e.EventType == types.EventCreated
+			sawUpdated = sawUpdated || e.EventType == types.EventUpdated
+		}
+		if !sawCreated || !sawUpdated {
+			t.Errorf("expected created and updated events, got %+v", d.Events)
+		}
+
+		text := bdShowRaw(t, bd, dir, issue.ID, "--full")
+		for _, want := range []string{"Blocked by: 1", "Open: 0d", "HISTORY", "full context comment", dependent.ID} {
+			if !strings.Contains(text, want) {
+				t.Errorf("expected %q in show --full output:\n%s", want, text)
+			}
+		}
+		if plain := bdShowRaw(t, bd, dir, issue.ID); strings.Contains(plain, "HISTORY") {
+			t.Errorf("HISTORY should only appear with --full:\n%s", plain)
+		}
+	})
 }
 
 // TestEmbeddedShowConcurrent exercises show operations concurrently.
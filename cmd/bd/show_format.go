@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -82,13 +84,20 @@ func formatIssueHeader(issue *types.Issue) string {
 func formatIssueMetadata(issue *types.Issue) string {
 	var lines []string
 
-	// Line 1: Owner/Assignee · Type
+	// Line 1: Owner/Assignee/Team · Type
 	metaParts := []string{}
 	if issue.CreatedBy != "" {
 		metaParts = append(metaParts, fmt.Sprintf("Owner: %s", issue.CreatedBy))
 	}
-	if issue.Assignee != "" {
-		metaParts = append(metaParts, fmt.Sprintf("Assignee: %s", issue.Assignee))
+	if issue.Assignee != "" || len(issue.Assignees) > 0 {
+		assignees := issue.Assignee
+		if len(issue.Assignees) > 0 {
+			assignees = strings.TrimPrefix(assignees+" (+"+strings.Join(issue.Assignees, ", ")+")", " ")
+		}
+		metaParts = append(metaParts, fmt.Sprintf("Assignee: %s", assignees))
+	}
+	if issue.Team != "" {
+		metaParts = append(metaParts, fmt.Sprintf("Team: %s", issue.Team))
 	}
 
 	// Type with semantic color
@@ -154,6 +163,60 @@ func formatIssueMetadata(issue *types.Issue) string {
 	return strings.Join(lines, "\n")
 }
 
+// formatChecklistProgress formats the checklist summary shown under the
+// description, in the style of the epic progress line.
+func formatChecklistProgress(p *types.ChecklistProgress) string {
+	if p.Done == p.Total {
+		return fmt.Sprintf("  %s Checklist %s", ui.RenderPass("✓"), formatChecklistCount(p))
+	}
+	return fmt.Sprintf("  %s Checklist %s", ui.RenderMuted("◐"), formatChecklistCount(p))
+}
+
+// formatIssueContextSummary formats the computed fields of bd show --full:
+// live blocker count, days open, and relation counts.
+func formatIssueContextSummary(d *types.IssueDetails) string {
+	var parts []string
+	if d.BlockedByCount != nil {
+		if *d.BlockedByCount > 0 {
+			parts = append(parts, ui.RenderWarn(fmt.Sprintf("Blocked by: %d", *d.BlockedByCount)))
+		} else {
+			parts = append(parts, "Blocked by: 0")
+		}
+	}
+	if d.DaysOpen != nil {
+		label := "Open"
+		if d.Status == types.StatusClosed {
+			label = "Was open"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %dd", label, *d.DaysOpen))
+	}
+	parts = append(parts, fmt.Sprintf("Deps: %d · Dependents: %d · Comments: %d",
+		len(d.Dependencies), len(d.Dependents), len(d.Comments)))
+	return strings.Join(parts, " · ")
+}
+
+// formatIssueEventLine formats one audit event for the HISTORY section:
+// time, actor, event type, and the old → new value when recorded.
+func formatIssueEventLine(event *types.Event, formatTime func(time.Time) string) string {
+	line := fmt.Sprintf("  %s %s %s", ui.RenderMuted(formatTime(event.CreatedAt)), event.Actor, event.EventType)
+	switch {
+	case event.OldValue != nil && event.NewValue != nil:
+		line += fmt.Sprintf(": %s → %s", truncateEventValue(*event.OldValue), truncateEventValue(*event.NewValue))
+	case event.NewValue != nil:
+		line += ": " + truncateEventValue(*event.NewValue)
+	}
+	if event.Comment != nil && *event.Comment != "" {
+		line += ui.RenderMuted(" (" + truncateEventValue(*event.Comment) + ")")
+	}
+	return line
+}
+
+// truncateEventValue keeps event values to one short line; update events
+// can carry whole JSON payloads.
+func truncateEventValue(v string) string {
+	return truncate(strings.Join(strings.Fields(v), " "), 60)
+}
+
 // formatDependencyLine formats a single dependency with semantic colors
 // Closed items get entire row muted - the work is done, no need for attention
 func formatDependencyLine(prefix string, dep *types.IssueWithDependencyMetadata) string {
@@ -209,9 +272,17 @@ func formatSimpleDependencyLine(prefix string, dep *types.Issue) string {
 
 // formatIssueCustomMetadata renders the issue's custom JSON metadata field
 // for bd show output. Returns empty string if no metadata is set.
-// Top-level keys are displayed sorted alphabetically, one per line.
+// Keys declared as custom fields (validation.metadata.fields) are listed
+// under FIELDS, the rest under METADATA; each section is sorted
+// alphabetically, one key per line.
 // Scalar values are shown inline; objects/arrays are shown as compact JSON.
 func formatIssueCustomMetadata(issue *types.Issue) string {
+	return formatIssueCustomFields(issue, issueops.ConfiguredMetadataFields())
+}
+
+// formatIssueCustomFields is formatIssueCustomMetadata for an explicit set
+// of declared fields.
+func formatIssueCustomFields(issue *types.Issue, fields map[string]storage.MetadataFieldSchema) string {
 	if len(issue.Metadata) == 0 {
 		return ""
 	}
@@ -237,13 +308,24 @@ func formatIssueCustomMetadata(issue *types.Issue) string {
 	}
 	sort.Strings(keys)
 
-	var lines []string
+	var declared, other []string
 	for _, k := range keys {
-		v := data[k]
-		lines = append(lines, fmt.Sprintf("  %s: %s", k, formatMetadataValue(v)))
+		line := fmt.Sprintf("  %s: %s", k, formatMetadataValue(data[k]))
+		if _, ok := fields[k]; ok {
+			declared = append(declared, line)
+		} else {
+			other = append(other, line)
+		}
 	}
 
-	return fmt.Sprintf("%s\n%s", ui.RenderBold("METADATA"), strings.Join(lines, "\n"))
+	var sections []string
+	if len(declared) > 0 {
+		sections = append(sections, fmt.Sprintf("%s\n%s", ui.RenderBold("FIELDS"), strings.Join(declared, "\n")))
+	}
+	if len(other) > 0 {
+		sections = append(sections, fmt.Sprintf("%s\n%s", ui.RenderBold("METADATA"), strings.Join(other, "\n")))
+	}
+	return strings.Join(sections, "\n\n")
 }
 
 // formatIssueLongExtras returns additional detail sections for --long mode.
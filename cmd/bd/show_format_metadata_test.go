@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -263,3 +264,25 @@ func TestFormatIssueLong_EmptyMetadata(t *testing.T) {
 		t.Errorf("expected no Metadata line for empty metadata, got: %q", result)
 	}
 }
+
+func TestFormatIssueCustomFields_DeclaredFieldsSection(t *testing.T) {
+	t.Parallel()
+	issue := &types.Issue{Metadata: json.RawMessage(`{"sprint":"S12","points":5,"source":"import"}`)}
+	fields := map[string]storage.MetadataFieldSchema{
+		"sprint": {Type: storage.MetadataFieldString},
+		"points": {Type: storage.MetadataFieldInt},
+	}
+	result := formatIssueCustomFields(issue, fields)
+	fieldsAt := strings.Index(result, "FIELDS")
+	metaAt := strings.Index(result, "METADATA")
+	if fieldsAt < 0 || metaAt < fieldsAt {
+		t.Fatalf("expected FIELDS then METADATA sections, got: %q", result)
+	}
+	declared, other := result[fieldsAt:metaAt], result[metaAt:]
+	if !strings.Contains(declared, "points: 5") || !strings.Contains(declared, "sprint: S12") {
+		t.Errorf("declared fields missing from FIELDS: %q", declared)
+	}
+	if !strings.Contains(other, "source: import") || strings.Contains(other, "sprint") {
+		t.Errorf("METADATA should hold only undeclared keys: %q", other)
+	}
+}
@@ -32,6 +32,7 @@ type showProxiedInput struct {
 	currentMode     bool
 	includeDepends  bool
 	includeComments bool
+	fullMode        bool
 }
 
 func gatherShowProxiedInput(cmd *cobra.Command, args []string) *showProxiedInput {
@@ -47,6 +48,7 @@ func gatherShowProxiedInput(cmd *cobra.Command, args []string) *showProxiedInput
 	in.currentMode, _ = cmd.Flags().GetBool("current")
 	in.includeDepends, _ = cmd.Flags().GetBool("include-dependents")
 	in.includeComments, _ = cmd.Flags().GetBool("include-comments")
+	in.fullMode, _ = cmd.Flags().GetBool("full")
 
 	idFlags, _ := cmd.Flags().GetStringArray("id")
 	in.ids = append(in.ids, args...)
@@ -71,6 +73,9 @@ func runShowProxiedServer(cmd *cobra.Command, ctx context.Context, args []string
 	if in.watchMode {
 		return HandleErrorRespectJSON("watch mode not supported in proxied-server mode")
 	}
+	if in.fullMode {
+		return HandleErrorRespectJSON("--full is not supported in proxied-server mode")
+	}
 
 	uw, err := proxiedOpenReadUOW(ctx)
 	if err != nil {
@@ -476,7 +481,8 @@ func runShowProxiedDefault(ctx context.Context, uw uow.UnitOfWork, in *showProxi
 }
 
 func proxiedBuildDetails(ctx context.Context, uw uow.UnitOfWork, issue *types.Issue, isWisp bool, in *showProxiedInput) *types.IssueDetails {
-	details := &types.IssueDetails{Issue: *issue}
+	details := &types.IssueDetails{Issue: *issue, Version: issue.VersionToken()}
+	details.Checklist = types.ChecklistProgressOf(issue.Description)
 
 	if isWisp {
 		details.Labels, _ = uw.LabelUseCase().GetWispLabels(ctx, issue.ID)
@@ -485,7 +491,7 @@ func proxiedBuildDetails(ctx context.Context, uw uow.UnitOfWork, issue *types.Is
 	}
 
 	deps, _ := proxiedListDeps(ctx, uw, issue.ID, isWisp, domain.DepListFilter{Direction: domain.DepDirectionOut})
-	details.Dependencies = deps
+	details.Dependencies = withRemoteDependencies(ctx, proxiedRemoteDeps{uw}, issue.ID, deps)
 
 	depCount, _ := proxiedCountDeps(ctx, uw, issue.ID, isWisp, domain.DepListFilter{Direction: domain.DepDirectionIn})
 	details.DependentCount = &depCount
@@ -573,6 +579,9 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 	} else {
 		fmt.Printf("\n%s\n  %s\n", ui.RenderBold("DESCRIPTION"), ui.RenderMuted("(none)"))
 	}
+	if progress := types.ChecklistProgressOf(issue.Description); progress != nil {
+		fmt.Println(formatChecklistProgress(progress))
+	}
 	if issue.Design != "" {
 		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), uimd.RenderMarkdown(issue.Design))
 	}
@@ -600,8 +609,9 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 	relatedSeen := make(map[string]*types.IssueWithDependencyMetadata)
 
 	depsWithMeta, _ := proxiedListDeps(ctx, uw, issue.ID, isWisp, domain.DepListFilter{Direction: domain.DepDirectionOut})
+	depsWithMeta = withRemoteDependencies(ctx, proxiedRemoteDeps{uw}, issue.ID, depsWithMeta)
 	if len(depsWithMeta) > 0 {
-		var blocks, parent, discovered []*types.IssueWithDependencyMetadata
+		var blocks, parent, discovered, duplicateOf, causedBy []*types.IssueWithDependencyMetadata
 		for _, dep := range depsWithMeta {
 			switch dep.DependencyType {
 			case types.DepBlocks:
@@ -610,6 +620,10 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 				parent = append(parent, dep)
 			case types.DepRelated, types.DepRelatesTo:
 				relatedSeen[dep.ID] = dep
+			case types.DepDuplicates:
+				duplicateOf = append(duplicateOf, dep)
+			case types.DepCausedBy:
+				causedBy = append(causedBy, dep)
 			case types.DepDiscoveredFrom:
 				discovered = append(discovered, dep)
 			default:
@@ -634,11 +648,23 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 				fmt.Println(formatDependencyLine("◊", dep))
 			}
 		}
+		if len(duplicateOf) > 0 {
+			fmt.Printf("\n%s\n", ui.RenderBold("DUPLICATE OF"))
+			for _, dep := range duplicateOf {
+				fmt.Println(formatDependencyLine("≡", dep))
+			}
+		}
+		if len(causedBy) > 0 {
+			fmt.Printf("\n%s\n", ui.RenderBold("CAUSED BY"))
+			for _, dep := range causedBy {
+				fmt.Println(formatDependencyLine("⇠", dep))
+			}
+		}
 	}
 
 	dependentsWithMeta, _ := proxiedListDeps(ctx, uw, issue.ID, isWisp, domain.DepListFilter{Direction: domain.DepDirectionIn})
 	if len(dependentsWithMeta) > 0 {
-		var blocks, children, discovered []*types.IssueWithDependencyMetadata
+		var blocks, children, discovered, duplicates, caused []*types.IssueWithDependencyMetadata
 		for _, dep := range dependentsWithMeta {
 			switch dep.DependencyType {
 			case types.DepBlocks:
@@ -647,6 +673,10 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 				children = append(children, dep)
 			case types.DepRelated, types.DepRelatesTo:
 				relatedSeen[dep.ID] = dep
+			case types.DepDuplicates:
+				duplicates = append(duplicates, dep)
+			case types.DepCausedBy:
+				caused = append(caused, dep)
 			case types.DepDiscoveredFrom:
 				discovered = append(discovered, dep)
 			default:
@@ -658,23 +688,21 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 			for _, dep := range children {
 				fmt.Println(formatDependencyLine("↳", dep))
 			}
-			if issue.IssueType == types.TypeEpic {
-				closedCount := 0
-				for _, dep := range children {
-					if dep.Status == types.StatusClosed {
-						closedCount++
-					}
-				}
-				pct := 0
-				if len(children) > 0 {
-					pct = (closedCount * 100) / len(children)
-				}
-				if closedCount == len(children) {
-					fmt.Printf("  %s %d/%d complete (%d%%) — eligible for close\n", ui.RenderPass("✓"), closedCount, len(children), pct)
-				} else {
-					fmt.Printf("  %s %d/%d complete (%d%%)\n", ui.RenderMuted("◐"), closedCount, len(children), pct)
+			closedCount := 0
+			for _, dep := range children {
+				if dep.Status == types.StatusClosed {
+					closedCount++
 				}
 			}
+			pct := 0
+			if len(children) > 0 {
+				pct = (closedCount * 100) / len(children)
+			}
+			if closedCount == len(children) && issue.IssueType == types.TypeEpic {
+				fmt.Printf("  %s %d/%d complete (%d%%) — eligible for close\n", ui.RenderPass("✓"), closedCount, len(children), pct)
+			} else {
+				fmt.Printf("  %s %d/%d complete (%d%%)\n", ui.RenderMuted("◐"), closedCount, len(children), pct)
+			}
 		}
 		if len(blocks) > 0 {
 			fmt.Printf("\n%s\n", ui.RenderBold("BLOCKS"))
@@ -688,6 +716,18 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 				fmt.Println(formatDependencyLine("◊", dep))
 			}
 		}
+		if len(duplicates) > 0 {
+			fmt.Printf("\n%s\n", ui.RenderBold("DUPLICATES"))
+			for _, dep := range duplicates {
+				fmt.Println(formatDependencyLine("≡", dep))
+			}
+		}
+		if len(caused) > 0 {
+			fmt.Printf("\n%s\n", ui.RenderBold("CAUSED"))
+			for _, dep := range caused {
+				fmt.Println(formatDependencyLine("⇢", dep))
+			}
+		}
 	}
 
 	if len(relatedSeen) > 0 {
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var splitCmd = &cobra.Command{
+	Use:     "split <id>",
+	GroupID: "issues",
+	Short:   "Carve checklist items or description sections into child issues",
+	Long: `Carve parts of an issue's description into child issues.
+
+The candidates are the unchecked checklist items ("- [ ] ...") and the
+"##" / "###" sections of the description. Each selected candidate becomes a
+task under the issue (parent-child dependency) with the parent's priority:
+a checklist item becomes the child's title, a section its title (the heading)
+and description (the section body). The carved text is removed from the
+parent's description, along with headings left with nothing under them.
+Everything happens in one transaction.
+
+Without --items or --all the candidates are listed and you are asked which to
+split; answer with numbers and ranges ("1,3-4") or "all".
+
+Examples:
+  bd split bd-abc                  # Choose interactively
+  bd split bd-abc --all --chain    # Split everything; each child blocks the next
+  bd split bd-abc --items 2-3      # Split candidates 2 and 3
+  bd split bd-abc --dry-run        # List the candidates only`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runSplit,
+}
+
+// splitCandidate is a part of a description that bd split can turn into a
+// child issue. Lines [start, end) of the description belong to it.
+type splitCandidate struct {
+	Kind  string `json:"kind"` // "checklist" or "section"
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	start int
+	end   int
+}
+
+// splitResult is the `bd split --json` payload.
+type splitResult struct {
+	Parent  string         `json:"parent"`
+	Created []*types.Issue `json:"created"`
+	Chained bool           `json:"chained,omitempty"`
+}
+
+var (
+	splitChecklistPattern = regexp.MustCompile(`^\s*[-*+] \[ \]\s+(.+?)\s*$`)
+	splitHeadingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	splitFencePattern     = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+func init() {
+	splitCmd.Flags().String("items", "", "Candidates to split, by number (e.g. 1,3-4)")
+	splitCmd.Flags().Bool("all", false, "Split every candidate")
+	splitCmd.Flags().Bool("chain", false, "Make each new child block the next one")
+	splitCmd.Flags().Bool("dry-run", false, "List the candidates without changing anything")
+	splitCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(splitCmd)
+}
+
+func runSplit(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("split is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("split")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	items, _ := cmd.Flags().GetString("items")
+	all, _ := cmd.Flags().GetBool("all")
+	chain, _ := cmd.Flags().GetBool("chain")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if items != "" && all {
+		return HandleErrorRespectJSON("--items and --all are mutually exclusive")
+	}
+	if !dryRun {
+		CheckReadonly("split")
+	}
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("failed to get storage: %v", err)
+	}
+	ctx := rootCtx
+
+	parentID, err := utils.ResolvePartialID(ctx, store, args[0])
+	if err != nil {
+		return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+	}
+	parent, err := store.GetIssue(ctx, parentID)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to get issue %s: %v", parentID, err)
+	}
+	if parent.Ephemeral {
+		return HandleErrorRespectJSON("cannot split ephemeral issue %s", parentID)
+	}
+	candidates := parseSplitCandidates(parent.Description)
+	if len(candidates) == 0 {
+		return HandleErrorRespectJSON("%s has no unchecked checklist items or ## sections to split", parentID)
+	}
+
+	if dryRun {
+		if jsonOutput {
+			return outputJSON(candidates)
+		}
+		printSplitCandidates(candidates)
+		return nil
+	}
+
+	var selected []int
+	switch {
+	case all:
+		selected, err = parseSplitSelection("all", len(candidates))
+	case items != "":
+		selected, err = parseSplitSelection(items, len(candidates))
+	default:
+		selected, err = promptSplitSelection(candidates)
+	}
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if len(selected) == 0 {
+		fmt.Println("Nothing selected")
+		return nil
+	}
+	picked := make([]splitCandidate, len(selected))
+	for i, idx := range selected {
+		picked[i] = candidates[idx]
+	}
+	remaining, err := carveSplitCandidates(parent.Description, picked)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	nextChild, err := store.GetNextChildID(ctx, parentID)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	actor := getActorWithGit()
+	result := &splitResult{Parent: parentID, Chained: chain}
+	err = transact(ctx, store, fmt.Sprintf("bd: split %s into %d issues", parentID, len(picked)), func(tx storage.Transaction) error {
+		var err error
+		result.Created, err = createSplitChildren(ctx, tx, parent, picked, nextChild, chain, actor)
+		if err != nil {
+			return err
+		}
+		return tx.UpdateIssue(ctx, parentID, map[string]interface{}{"description": remaining}, actor)
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("splitting %s: %v", parentID, err)
+	}
+	commandDidWrite.Store(true)
+	SetLastTouchedID(parentID)
+
+	if jsonOutput {
+		return outputJSON(result)
+	}
+	fmt.Printf("%s Split %d issue(s) from %s\n", ui.RenderPass("✓"), len(result.Created), formatFeedbackID(parentID, parent.Title))
+	for i, child := range result.Created {
+		line := "  " + formatFeedbackID(child.ID, child.Title)
+		if chain && i > 0 {
+			line += ui.RenderMuted(" (blocked by " + result.Created[i-1].ID + ")")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// createSplitChildren creates one task per candidate under parent, numbered
+// from nextChild (as returned by GetNextChildID) and skipping IDs in use.
+func createSplitChildren(ctx context.Context, tx storage.Transaction, parent *types.Issue, picked []splitCandidate, nextChild string, chain bool, actor string) ([]*types.Issue, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(nextChild, parent.ID+"."))
+	if err != nil {
+		return nil, fmt.Errorf("unexpected child ID %s for %s", nextChild, parent.ID)
+	}
+	children := make([]*types.Issue, 0, len(picked))
+	for _, c := range picked {
+		id := fmt.Sprintf("%s.%d", parent.ID, n)
+		for {
+			_, err := tx.GetIssue(ctx, id)
+			if errors.Is(err, storage.ErrNotFound) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("check %s: %w", id, err)
+			}
+			n++
+			id = fmt.Sprintf("%s.%d", parent.ID, n)
+		}
+		n++
+		children = append(children, &types.Issue{
+			ID:          id,
+			Title:       c.Title,
+			Description: c.Body,
+			Status:      types.StatusOpen,
+			Priority:    parent.Priority,
+			IssueType:   types.TypeTask,
+		})
+	}
+	if err := tx.CreateIssues(ctx, children, actor); err != nil {
+		return nil, fmt.Errorf("create children: %w", err)
+	}
+	for i, child := range children {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}
+		if err := tx.AddDependency(ctx, dep, actor); err != nil {
+			return nil, fmt.Errorf("link %s to %s: %w", child.ID, parent.ID, err)
+		}
+		if chain && i > 0 {
+			dep := &types.Dependency{IssueID: child.ID, DependsOnID: children[i-1].ID, Type: types.DepBlocks}
+			if err := tx.AddDependency(ctx, dep, actor); err != nil {
+				return nil, fmt.Errorf("chain %s after %s: %w", child.ID, children[i-1].ID, err)
+			}
+		}
+	}
+	return children, nil
+}
+
+// parseSplitCandidates finds the unchecked checklist items and the level 2
+// and 3 sections of a description, in document order. Fenced code blocks are
+// skipped. A section runs to the next heading of the same or a higher level.
+func parseSplitCandidates(desc string) []splitCandidate {
+	lines := strings.Split(desc, "\n")
+	type heading struct{ level, line int }
+	var headings []heading
+	var candidates []splitCandidate
+	inFence := false
+	for i, line := range lines {
+		if splitFencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := splitHeadingPattern.FindStringSubmatch(line); m != nil {
+			headings = append(headings, heading{level: len(m[1]), line: i})
+			if level := len(m[1]); level == 2 || level == 3 {
+				candidates = append(candidates, splitCandidate{Kind: "section", Title: m[2], start: i})
+			}
+			continue
+		}
+		if m := splitChecklistPattern.FindStringSubmatch(line); m != nil {
+			candidates = append(candidates, splitCandidate{Kind: "checklist", Title: m[1], start: i, end: i + 1})
+		}
+	}
+
+	for i := range candidates {
+		c := &candidates[i]
+		if c.Kind != "section" {
+			continue
+		}
+		level := len(splitHeadingPattern.FindStringSubmatch(lines[c.start])[1])
+		c.end = len(lines)
+		for _, h := range headings {
+			if h.line > c.start && h.level <= level {
+				c.end = h.line
+				break
+			}
+		}
+		c.Body = strings.TrimSpace(strings.Join(lines[c.start+1:c.end], "\n"))
+	}
+	return candidates
+}
+
+// parseSplitSelection turns "all" or a list like "1,3-4" into sorted,
+// de-duplicated 0-based candidate indexes.
+func parseSplitSelection(s string, count int) ([]int, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "all") {
+		all := make([]int, count)
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+	seen := make(map[int]bool)
+	var out []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi := part, part
+		if a, b, ok := strings.Cut(part, "-"); ok {
+			lo, hi = a, b
+		}
+		start, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		end, err := strconv.Atoi(strings.TrimSpace(hi))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		if start < 1 || end > count || start > end {
+			return nil, fmt.Errorf("selection %q is outside 1-%d", part, count)
+		}
+		for i := start; i <= end; i++ {
+			if !seen[i-1] {
+				seen[i-1] = true
+				out = append(out, i-1)
+			}
+		}
+	}
+	sort.Ints(out)
+	return out, nil
+}
+
+// carveSplitCandidates returns desc without the picked candidates. Headings
+// whose sections lose all their remaining content go too. Picking a section
+// together with a checklist item inside it is an error, since the item would
+// be split twice.
+func carveSplitCandidates(desc string, picked []splitCandidate) (string, error) {
+	lines := strings.Split(desc, "\n")
+	removed := make([]bool, len(lines))
+	for i, c := range picked {
+		for _, other := range picked[:i] {
+			if c.start < other.end && other.start < c.end {
+				return "", fmt.Errorf("%q overlaps %q; pick one of them", c.Title, other.Title)
+			}
+		}
+		for l := c.start; l < c.end; l++ {
+			removed[l] = true
+		}
+	}
+
+	// Innermost sections first, so emptying a ### can empty its ## too.
+	sections := parseSplitCandidates(desc)
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].start > sections[j].start })
+	for _, s := range sections {
+		if s.Kind != "section" || removed[s.start] {
+			continue
+		}
+		touched, empty := false, true
+		for l := s.start + 1; l < s.end; l++ {
+			if removed[l] {
+				touched = true
+			} else if strings.TrimSpace(lines[l]) != "" {
+				empty = false
+			}
+		}
+		if touched && empty {
+			for l := s.start; l < s.end; l++ {
+				removed[l] = true
+			}
+		}
+	}
+
+	var kept []string
+	blank := false
+	for i, line := range lines {
+		if removed[i] {
+			continue
+		}
+		isBlank := strings.TrimSpace(line) == ""
+		if isBlank && (blank || len(kept) == 0) {
+			continue
+		}
+		kept = append(kept, line)
+		blank = isBlank
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n")), nil
+}
+
+func printSplitCandidates(candidates []splitCandidate) {
+	for i, c := range candidates {
+		kind := "[ ]"
+		if c.Kind == "section" {
+			kind = "##"
+		}
+		fmt.Printf("  %2d. %s %s\n", i+1, ui.RenderMuted(kind), c.Title)
+	}
+}
+
+// promptSplitSelection lists the candidates and reads a selection. Without a
+// terminal it refuses rather than guessing, so scripts must pass --items or
+// --all.
+func promptSplitSelection(candidates []splitCandidate) ([]int, error) {
+	if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, fmt.Errorf("no candidates selected; pass --items or --all (see --dry-run for the list)")
+	}
+	printSplitCandidates(candidates)
+	fmt.Fprintf(os.Stderr, "Split which? (e.g. 1,3-4 or all; empty to cancel) ")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if strings.TrimSpace(line) == "" {
+		return nil, nil
+	}
+	return parseSplitSelection(line, len(candidates))
+}
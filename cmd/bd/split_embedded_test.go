@@ -0,0 +1,81 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedSplit(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "sp")
+	desc := "Plan:\n\n- [ ] First step\n- [ ] Second step\n\n## Rollout\nShip it behind a flag."
+	parent := bdCreateSilent(t, bd, dir, "Big feature", "--priority", "1", "--description", desc)
+
+	t.Run("dry_run_lists_candidates", func(t *testing.T) {
+		out := bdCommand(t, bd, dir, "split", parent, "--dry-run", "--json")
+		var candidates []splitCandidate
+		if err := json.Unmarshal([]byte(out), &candidates); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if len(candidates) != 3 || candidates[2].Title != "Rollout" {
+			t.Errorf("candidates = %+v", candidates)
+		}
+	})
+
+	t.Run("no_selection_without_terminal", func(t *testing.T) {
+		cmd := exec.Command(bd, "split", parent)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		out, err := cmd.CombinedOutput()
+		if err == nil || !strings.Contains(string(out), "--items") {
+			t.Errorf("split without selection: err=%v out=%s", err, out)
+		}
+	})
+
+	t.Run("split_and_chain", func(t *testing.T) {
+		out := bdCommand(t, bd, dir, "split", parent, "--items", "1,3", "--chain", "--json")
+		var result splitResult
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if len(result.Created) != 2 {
+			t.Fatalf("created = %+v", result.Created)
+		}
+		first, second := result.Created[0], result.Created[1]
+		if first.ID != parent+".1" || second.ID != parent+".2" {
+			t.Errorf("child IDs = %s, %s", first.ID, second.ID)
+		}
+
+		got := bdShow(t, bd, dir, second.ID)
+		if got.Title != "Rollout" || got.Description != "Ship it behind a flag." || got.Priority != 1 {
+			t.Errorf("section child = %+v", got)
+		}
+		var details types.IssueDetails
+		if err := json.Unmarshal(parseShowJSON(t, bdShowJSON(t, bd, dir, second.ID)), &details); err != nil {
+			t.Fatal(err)
+		}
+		deps := map[string]types.DependencyType{}
+		for _, d := range details.Dependencies {
+			deps[d.ID] = d.DependencyType
+		}
+		if deps[parent] != types.DepParentChild || deps[first.ID] != types.DepBlocks {
+			t.Errorf("dependencies of %s = %v", second.ID, deps)
+		}
+
+		if got := bdShow(t, bd, dir, parent).Description; got != "Plan:\n\n- [ ] Second step" {
+			t.Errorf("parent description = %q", got)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const splitTestDescription = `Intro text.
+
+- [ ] Write the parser
+- [x] Already done
+- [ ] Wire the command
+
+## Storage
+Add the table.
+
+### Migration
+Write 0063.
+
+` + "```" + `
+## not a heading
+- [ ] not an item
+` + "```" + `
+
+## Docs
+- [ ] Update README`
+
+func TestParseSplitCandidates(t *testing.T) {
+	got := parseSplitCandidates(splitTestDescription)
+	var titles []string
+	for _, c := range got {
+		titles = append(titles, c.Kind+":"+c.Title)
+	}
+	want := []string{
+		"checklist:Write the parser",
+		"checklist:Wire the command",
+		"section:Storage",
+		"section:Migration",
+		"section:Docs",
+		"checklist:Update README",
+	}
+	if !reflect.DeepEqual(titles, want) {
+		t.Fatalf("candidates = %v, want %v", titles, want)
+	}
+	if body := got[2].Body; !strings.HasPrefix(body, "Add the table.\n\n### Migration\nWrite 0063.") {
+		t.Errorf("Storage body = %q, want it to include the ### subsection", body)
+	}
+	if body := got[3].Body; !strings.HasPrefix(body, "Write 0063.") || strings.Contains(body, "Docs") {
+		t.Errorf("Migration body = %q", body)
+	}
+}
+
+func TestParseSplitSelection(t *testing.T) {
+	got, err := parseSplitSelection("3, 1-2,2", 4)
+	if err != nil || !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("parseSplitSelection = %v, %v", got, err)
+	}
+	if got, _ := parseSplitSelection("all", 3); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("all = %v", got)
+	}
+	for _, bad := range []string{"0", "5", "2-1", "x"} {
+		if _, err := parseSplitSelection(bad, 4); err == nil {
+			t.Errorf("parseSplitSelection(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestCarveSplitCandidates(t *testing.T) {
+	candidates := parseSplitCandidates(splitTestDescription)
+
+	// Carving the only item of "## Docs" drops the emptied heading too.
+	got, err := carveSplitCandidates(splitTestDescription, []splitCandidate{candidates[0], candidates[3], candidates[5]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, gone := range []string{"Write the parser", "### Migration", "Write 0063", "## Docs", "Update README"} {
+		if strings.Contains(got, gone) {
+			t.Errorf("carved description still contains %q:\n%s", gone, got)
+		}
+	}
+	for _, kept := range []string{"Intro text.", "- [x] Already done", "- [ ] Wire the command", "## Storage\nAdd the table."} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("carved description lost %q:\n%s", kept, got)
+		}
+	}
+	if strings.Contains(got, "\n\n\n") {
+		t.Errorf("carved description has runs of blank lines:\n%s", got)
+	}
+
+	if _, err := carveSplitCandidates(splitTestDescription, []splitCandidate{candidates[2], candidates[3]}); err == nil {
+		t.Error("overlapping section and subsection were accepted")
+	}
+}
@@ -18,7 +18,10 @@ var staleCmd = &cobra.Command{
 This helps identify:
 - In-progress issues with no recent activity (may be abandoned)
 - Open issues that have been forgotten
-- Issues that might be outdated or no longer relevant`,
+- Issues that might be outdated or no longer relevant
+
+To label, comment on or propose closing such issues automatically, configure
+triage rules and run 'bd triage' (see 'bd triage --help').`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -44,6 +45,14 @@ Categories control behavior:
 
 Statuses without a category (legacy format) are valid but excluded from 'bd ready'.
 
+A workflow restricts which status changes bd update and bd close accept.
+Rules are "from>to" with an optional ":guard" (no-blockers, assigned,
+no-open-children); "*" matches any status. Unlisted changes are refused:
+
+  bd config set status.transitions "open>in_progress,in_progress>in_review:assigned,in_review>closed:no-blockers,*>deferred,deferred>open"
+
+With no status.transitions set, every change is allowed.
+
 Examples:
   bd statuses            # List all statuses with icons and categories
   bd statuses --json     # Output as JSON
@@ -67,22 +76,27 @@ Examples:
 		}
 
 		var customStatuses []types.CustomStatus
+		var transitions []types.StatusTransition
 		ctx := context.Background()
 		if store != nil {
 			if cs, err := store.GetCustomStatusesDetailed(ctx); err == nil {
 				customStatuses = cs
 			}
+			if value, err := store.GetConfig(ctx, issueops.StatusTransitionsConfigKey); err == nil {
+				transitions, _ = types.ParseStatusTransitions(value)
+			}
 		}
 
-		return renderStatuses(customStatuses)
+		return renderStatuses(customStatuses, transitions)
 	},
 }
 
-func renderStatuses(customStatuses []types.CustomStatus) error {
+func renderStatuses(customStatuses []types.CustomStatus, transitions []types.StatusTransition) error {
 	if jsonOutput {
 		result := struct {
-			BuiltInStatuses []statusInfo         `json:"built_in_statuses"`
-			CustomStatuses  []types.CustomStatus `json:"custom_statuses,omitempty"`
+			BuiltInStatuses []statusInfo             `json:"built_in_statuses"`
+			CustomStatuses  []types.CustomStatus     `json:"custom_statuses,omitempty"`
+			Transitions     []types.StatusTransition `json:"transitions,omitempty"`
 		}{}
 
 		for _, s := range builtInStatuses {
@@ -94,6 +108,7 @@ func renderStatuses(customStatuses []types.CustomStatus) error {
 			})
 		}
 		result.CustomStatuses = customStatuses
+		result.Transitions = transitions
 		return outputJSON(result)
 	}
 
@@ -114,6 +129,17 @@ func renderStatuses(customStatuses []types.CustomStatus) error {
 		fmt.Println("Configure with: bd config set status.custom \"name:category,...\"")
 		fmt.Println("Categories: active, wip, done, frozen")
 	}
+
+	if len(transitions) > 0 {
+		fmt.Println("\nWorkflow transitions:")
+		for _, t := range transitions {
+			guard := ""
+			if t.Guard != "" {
+				guard = ui.RenderMuted("  requires " + t.Guard)
+			}
+			fmt.Printf("  %-14s → %-14s%s\n", t.From, t.To, guard)
+		}
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -18,5 +19,10 @@ func runStatusesProxiedServer(ctx context.Context) error {
 		customStatuses = cs
 	}
 
-	return renderStatuses(customStatuses)
+	var transitions []types.StatusTransition
+	if value, err := uw.ConfigUseCase().GetConfig(ctx, issueops.StatusTransitionsConfigKey); err == nil {
+		transitions, _ = types.ParseStatusTransitions(value)
+	}
+
+	return renderStatuses(customStatuses, transitions)
 }
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var trashCmd = &cobra.Command{
+	Use:     "trash",
+	GroupID: "issues",
+	Short:   "List and restore deleted issues from Dolt history",
+	Long: `List and restore deleted issues.
+
+'bd delete' removes an issue from the working set, but every Dolt commit that
+contained it still does. The trash is that history: an issue deleted after it
+was committed at least once can be listed and restored until the history is
+flattened or garbage collected ('bd flatten', 'bd gc'), which is the retention
+policy for deleted issues.
+
+Restoring recreates the issue as of the last commit that contained it, with
+the labels, comments, and dependencies it had in that commit; a dependency on
+or from an issue that is gone too is skipped and reported. An ID that
+'bd rename' kept as an alias is not deleted, so it is neither listed nor
+restored. Issues moved out by 'bd archive' are not in the trash either; bring
+them back with 'bd unarchive'. Wisps never reach Dolt history, so they cannot
+be restored.
+
+Examples:
+  bd trash list             # Deleted issues, most recent first
+  bd trash restore bd-123   # Bring bd-123 back`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List deleted issues that can still be restored",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("trash is not supported in proxied-server mode")
+		}
+		querier, ok := storage.UnwrapStore(store).(storage.DeletedIssueQuerier)
+		if !ok {
+			return HandleErrorRespectJSON("trash requires a Dolt history-capable store")
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		deleted, err := querier.DeletedIssues(rootCtx)
+		if err != nil {
+			return HandleErrorRespectJSON("listing deleted issues: %v", err)
+		}
+		archived, err := archivedIssueIDs()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		deleted = slices.DeleteFunc(deleted, func(e *storage.HistoryEntry) bool {
+			return archived[e.Issue.ID]
+		})
+		if limit > 0 && limit < len(deleted) {
+			deleted = deleted[:limit]
+		}
+
+		if jsonOutput {
+			if deleted == nil {
+				deleted = []*storage.HistoryEntry{}
+			}
+			return outputJSON(deleted)
+		}
+		if len(deleted) == 0 {
+			fmt.Println("Trash is empty")
+			return nil
+		}
+		fmt.Printf("\n%s Deleted issues (%d)\n\n", ui.RenderAccent("🗑"), len(deleted))
+		for _, entry := range deleted {
+			fmt.Printf("%s %s [P%d - %s]  %s\n",
+				entry.Issue.ID,
+				entry.Issue.Title,
+				entry.Issue.Priority,
+				entry.Issue.Status,
+				ui.RenderMuted("last seen "+entry.CommitDate.Format("2006-01-02 15:04:05")+" in "+entry.CommitHash[:8]))
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:           "restore <issue-id> [issue-id...]",
+	Short:         "Restore deleted issues from Dolt history",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("trash restore")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("trash is not supported in proxied-server mode")
+		}
+		var restored, skippedDeps []string
+		var failed int
+		for _, id := range args {
+			skipped, err := restoreDeletedIssue(rootCtx, id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error restoring %s: %v\n", id, err)
+				failed++
+				continue
+			}
+			commandDidWrite.Store(true)
+			restored = append(restored, id)
+			skippedDeps = append(skippedDeps, skipped...)
+			if !jsonOutput {
+				fmt.Printf("%s Restored %s\n", ui.RenderPass("✓"), id)
+				for _, dep := range skipped {
+					WarnError("dependency not restored: %s", dep)
+				}
+			}
+		}
+
+		if jsonOutput {
+			if err := outputJSON(map[string]interface{}{"restored": restored, "skipped_dependencies": skippedDeps}); err != nil {
+				return err
+			}
+		}
+		if failed > 0 {
+			return SilentExit()
+		}
+		return nil
+	},
+}
+
+// archivedIssueIDs returns the IDs in .beads/archive.jsonl. 'bd archive'
+// removes those issues from the database, so they show up in Dolt history
+// like deleted ones, but they are kept in the archive and come back with
+// 'bd unarchive', not from the trash.
+func archivedIssueIDs() (map[string]bool, error) {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return nil, nil
+	}
+	records, err := readArchive(filepath.Join(beadsDir, archiveFileName))
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(records))
+	for _, rec := range records {
+		ids[rec.ID] = true
+	}
+	return ids, nil
+}
+
+// restoreDeletedIssue recreates id from the newest history entry that
+// contains it, with the labels, comments, and dependencies it had in that
+// commit. It refuses when the issue still exists, under its own ID or as an
+// alias left by 'bd rename', so a restore never overwrites live data. It
+// returns the dependencies it could not bring back.
+func restoreDeletedIssue(ctx context.Context, id string) ([]string, error) {
+	if aliases, ok := storage.UnwrapStore(store).(storage.AliasStore); ok {
+		if current, err := aliases.ResolveIssueAlias(ctx, id); err == nil {
+			return nil, fmt.Errorf("renamed to %s, not deleted", current)
+		} else if !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+	}
+	if archived, err := archivedIssueIDs(); err != nil {
+		return nil, err
+	} else if archived[id] {
+		return nil, fmt.Errorf("archived, not deleted; use 'bd unarchive %s'", id)
+	}
+	if existing, err := store.GetIssue(ctx, id); err == nil && existing != nil {
+		return nil, fmt.Errorf("issue exists; only deleted issues can be restored")
+	} else if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+	querier, ok := storage.UnwrapStore(store).(storage.DeletedIssueQuerier)
+	if !ok {
+		return nil, fmt.Errorf("trash requires a Dolt history-capable store")
+	}
+
+	history, err := querier.History(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	if len(history) == 0 || history[0].Issue == nil {
+		return nil, fmt.Errorf("not found in Dolt history (never committed, or history was flattened)")
+	}
+	last := history[0]
+	rel, err := querier.IssueRelationsAsOf(ctx, id, last.CommitHash)
+	if err != nil {
+		return nil, fmt.Errorf("reading labels, comments, and dependencies as of %s: %w", last.CommitHash, err)
+	}
+	issue := last.Issue
+	issue.ContentHash = ""
+	issue.Labels = rel.Labels
+	issue.Dependencies = rel.Dependencies
+	issue.Comments = rel.Comments
+
+	result, err := importIssuesCore(ctx, "", store, []*types.Issue{issue}, ImportOptions{
+		SkipPrefixValidation: true,
+		ConflictSkip:         true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recreating issue: %w", err)
+	}
+	if result.Created == 0 {
+		return nil, fmt.Errorf("issue exists; only deleted issues can be restored")
+	}
+	skipped := result.SkippedDependencies
+
+	// Edges other issues had onto this one were deleted with it; put back
+	// those whose issue is still here.
+	for _, dep := range rel.Dependents {
+		if err := store.AddDependency(ctx, dep, actor); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s -> %s: %v", dep.IssueID, dep.DependsOnID, err))
+		}
+	}
+	return skipped, nil
+}
+
+func init() {
+	trashListCmd.Flags().Int("limit", 50, "Maximum number of deleted issues to show (0 = all)")
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	rootCmd.AddCommand(trashCmd)
+}
@@ -0,0 +1,106 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestEmbeddedTrash(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "tt")
+
+	trashList := func(t *testing.T) []*storage.HistoryEntry {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, "trash", "list", "--json")
+		if err != nil {
+			t.Fatalf("bd trash list failed: %v\n%s", err, out)
+		}
+		var entries []*storage.HistoryEntry
+		start := strings.Index(string(out), "[")
+		if start < 0 {
+			t.Fatalf("no JSON array in trash list output: %s", out)
+		}
+		if err := json.NewDecoder(strings.NewReader(string(out[start:]))).Decode(&entries); err != nil {
+			t.Fatalf("parse trash list: %v\n%s", err, out)
+		}
+		return entries
+	}
+	inTrash := func(entries []*storage.HistoryEntry, id string) bool {
+		for _, e := range entries {
+			if e.Issue != nil && e.Issue.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	issue := bdCreate(t, bd, dir, "Trash me", "--type", "bug", "--priority", "1", "--labels", "regression")
+	keep := bdCreate(t, bd, dir, "Keep me", "--type", "task")
+	follow := bdCreate(t, bd, dir, "Follow-up", "--type", "task")
+	bdCommand(t, bd, dir, "comments", "add", issue.ID, "seen on staging")
+	bdDepAdd(t, bd, dir, issue.ID, keep.ID)
+	bdDepAdd(t, bd, dir, follow.ID, issue.ID)
+	bdDelete(t, bd, dir, issue.ID, "--force")
+	bdShowFail(t, bd, dir, issue.ID)
+
+	entries := trashList(t)
+	if !inTrash(entries, issue.ID) {
+		t.Fatalf("deleted issue %s missing from trash: %+v", issue.ID, entries)
+	}
+	if inTrash(entries, keep.ID) {
+		t.Errorf("live issue %s listed in trash", keep.ID)
+	}
+
+	out, err := bdRunWithFlockRetry(t, bd, dir, "trash", "restore", keep.ID)
+	if err == nil || !strings.Contains(string(out), "only deleted issues") {
+		t.Errorf("restoring a live issue: want refusal, got err=%v\n%s", err, out)
+	}
+
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "trash", "restore", issue.ID); err != nil {
+		t.Fatalf("bd trash restore failed: %v\n%s", err, out)
+	}
+	got := bdShow(t, bd, dir, issue.ID)
+	if got.Title != "Trash me" || got.Priority != 1 || string(got.IssueType) != "bug" {
+		t.Errorf("restored issue = %q P%d %s, want the deleted state", got.Title, got.Priority, got.IssueType)
+	}
+	if inTrash(trashList(t), issue.ID) {
+		t.Errorf("restored issue %s still listed in trash", issue.ID)
+	}
+
+	// Labels, comments, and dependencies in both directions come back as
+	// of the last commit that had the issue.
+	if len(got.Labels) != 1 || got.Labels[0] != "regression" {
+		t.Errorf("restored labels = %v, want [regression]", got.Labels)
+	}
+	if out := bdCommand(t, bd, dir, "comments", issue.ID); !strings.Contains(out, "seen on staging") {
+		t.Errorf("restored comments lack the original comment:\n%s", out)
+	}
+	if out := bdCommand(t, bd, dir, "dep", "list", issue.ID); !strings.Contains(out, keep.ID) {
+		t.Errorf("restored issue lost its dependency on %s:\n%s", keep.ID, out)
+	}
+	if out := bdCommand(t, bd, dir, "dep", "list", follow.ID); !strings.Contains(out, issue.ID) {
+		t.Errorf("%s lost its dependency on the restored issue:\n%s", follow.ID, out)
+	}
+
+	// A renamed issue's old ID stays as an alias; it is not deleted.
+	renamed := bdCreate(t, bd, dir, "Renamed", "--type", "task")
+	bdCommand(t, bd, dir, "rename", renamed.ID, "tt-renamed")
+	if inTrash(trashList(t), renamed.ID) {
+		t.Errorf("renamed issue's old ID %s listed in trash", renamed.ID)
+	}
+	out, err = bdRunWithFlockRetry(t, bd, dir, "trash", "restore", renamed.ID)
+	if err == nil || !strings.Contains(string(out), "renamed to tt-renamed") {
+		t.Errorf("restoring a renamed issue's old ID: want refusal, got err=%v\n%s", err, out)
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/notify"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/triage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var triageCmd = &cobra.Command{
+	Use:     "triage",
+	GroupID: "maint",
+	Short:   "Run the triage policy rules from config.yaml",
+	Long: `Run the triage policy rules configured under triage.rules in config.yaml.
+
+A rule matches open issues by status, priority, type, labels, time since the
+last update (untouched) and time since creation (age), and acts on them by
+adding labels, commenting, proposing them for closing (the close-proposed
+label and an explanatory comment) and queueing a "triage" webhook
+notification (see notify.webhooks):
+
+  triage:
+    rules:
+      stale-wip:
+        status: in_progress
+        untouched: 14d
+        add_label: stale
+        notify: true
+      old-backlog:
+        status: open
+        priority: 3
+        age: 180d
+        propose_close: true
+
+Without --apply, bd triage only reports what each rule would do. A rule
+skips issues that already carry every label it adds, so running
+'bd triage --apply' from cron or CI acts on each issue once.
+
+Examples:
+  bd triage                        # Dry-run report
+  bd triage --apply                # Act on the matches
+  bd triage --rule stale-wip --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runTriage,
+}
+
+// triageReport is the `bd triage --json` payload.
+type triageReport struct {
+	Applied bool             `json:"applied"`
+	Rules   []*triage.Rule   `json:"rules"`
+	Actions []*triage.Action `json:"actions"`
+}
+
+func init() {
+	triageCmd.Flags().Bool("apply", false, "Act on the matching issues instead of reporting them")
+	triageCmd.Flags().StringSlice("rule", nil, "Only run these rules (repeatable)")
+	rootCmd.AddCommand(triageCmd)
+}
+
+func runTriage(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("triage is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("triage")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	apply, _ := cmd.Flags().GetBool("apply")
+	only, _ := cmd.Flags().GetStringSlice("rule")
+	if apply {
+		CheckReadonly("triage")
+	}
+	rules, err := triage.ParseRules(config.TriageRules())
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if len(rules) == 0 {
+		return HandleErrorRespectJSON("no triage rules configured; add triage.rules to .beads/config.yaml (see 'bd triage --help')")
+	}
+	if len(only) > 0 {
+		for _, name := range only {
+			if !slices.ContainsFunc(rules, func(r *triage.Rule) bool { return r.Name == name }) {
+				return HandleErrorRespectJSON("unknown triage rule %q", name)
+			}
+		}
+		rules = slices.DeleteFunc(rules, func(r *triage.Rule) bool { return !slices.Contains(only, r.Name) })
+	}
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("failed to get storage: %v", err)
+	}
+	ctx := rootCtx
+
+	notTemplate, persistent := false, false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		ExcludeStatus: []types.Status{types.StatusClosed},
+		IsTemplate:    &notTemplate,
+		Ephemeral:     &persistent,
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("listing issues: %v", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return HandleErrorRespectJSON("loading labels: %v", err)
+	}
+
+	now := time.Now().UTC()
+	report := &triageReport{Applied: apply, Rules: rules, Actions: triage.Plan(rules, issues, labels, now)}
+	if report.Actions == nil {
+		report.Actions = []*triage.Action{}
+	}
+	if apply && len(report.Actions) > 0 {
+		if err := applyTriage(report.Actions, issues, now); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(report)
+	}
+	printTriageReport(report)
+	return nil
+}
+
+// applyTriage writes each action in its own transaction, then queues the
+// notifications of the applied actions.
+func applyTriage(actions []*triage.Action, issues []*types.Issue, now time.Time) error {
+	ctx := rootCtx
+	actor := getActorWithGit()
+	for _, a := range actions {
+		err := transact(ctx, store, fmt.Sprintf("bd: triage %s %s", a.Rule, a.IssueID), func(tx storage.Transaction) error {
+			for _, label := range a.AddLabels {
+				if err := tx.AddLabel(ctx, a.IssueID, label, actor); err != nil {
+					return fmt.Errorf("add label %s: %w", label, err)
+				}
+			}
+			if a.Comment != "" {
+				if _, err := tx.ImportIssueComment(ctx, a.IssueID, actor, a.Comment, now.Truncate(time.Second)); err != nil {
+					return fmt.Errorf("add comment: %w", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("triage rule %s on %s: %w", a.Rule, a.IssueID, err)
+		}
+		commandDidWrite.Store(true)
+	}
+
+	if !slices.ContainsFunc(actions, func(a *triage.Action) bool { return a.Notify }) {
+		return nil
+	}
+	webhooks, outbox, err := loadNotifySetup()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(webhooks, func(w *notify.Webhook) bool { return slices.Contains(w.Events, notify.EventTriage) }) {
+		fmt.Fprintf(os.Stderr, "Warning: no webhook subscribes to %q events; notify: true rules send nothing\n", notify.EventTriage)
+		return nil
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	for _, a := range actions {
+		if !a.Notify {
+			continue
+		}
+		summary := notify.Summarize(byID[a.IssueID])
+		ev := &notify.Event{Kind: notify.EventTriage, Time: now, Issue: &summary, Rule: a.Rule, Reason: a.Reason}
+		if _, err := outbox.Enqueue(webhooks, ev); err != nil {
+			return fmt.Errorf("queue notification for %s: %w", a.IssueID, err)
+		}
+	}
+	return nil
+}
+
+func printTriageReport(report *triageReport) {
+	if len(report.Actions) == 0 {
+		fmt.Printf("%s No issues match the triage rules (%d rule(s) checked)\n", ui.RenderPass("✓"), len(report.Rules))
+		return
+	}
+	for _, r := range report.Rules {
+		var matched []*triage.Action
+		for _, a := range report.Actions {
+			if a.Rule == r.Name {
+				matched = append(matched, a)
+			}
+		}
+		fmt.Printf("%s %s\n", ui.RenderBold(r.Name), ui.RenderMuted(fmt.Sprintf("(%d issue(s))", len(matched))))
+		for _, a := range matched {
+			fmt.Printf("  %s %s\n", ui.RenderID(a.IssueID), a.Title)
+			fmt.Printf("    %s\n", ui.RenderMuted(a.Reason+" → "+describeTriageAction(a)))
+		}
+	}
+	if report.Applied {
+		fmt.Printf("\n%s Applied %d triage action(s)\n", ui.RenderPass("✓"), len(report.Actions))
+	} else {
+		fmt.Printf("\nDry run: %d action(s). Run 'bd triage --apply' to apply them.\n", len(report.Actions))
+	}
+}
+
+// describeTriageAction lists what an action does, e.g.
+// "add label stale, comment, notify".
+func describeTriageAction(a *triage.Action) string {
+	var parts []string
+	if a.ProposeClose {
+		parts = append(parts, "propose close")
+	}
+	var labels []string
+	for _, label := range a.AddLabels {
+		if !a.ProposeClose || label != triage.ProposeCloseLabel {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) > 0 {
+		parts = append(parts, "add label "+strings.Join(labels, ", "))
+	}
+	if a.Comment != "" && !a.ProposeClose {
+		parts = append(parts, "comment")
+	}
+	if a.Notify {
+		parts = append(parts, "notify")
+	}
+	return strings.Join(parts, ", ")
+}
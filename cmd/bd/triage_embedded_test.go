@@ -0,0 +1,124 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEmbeddedTriage(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	var mu sync.Mutex
+	var received []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		_ = json.Unmarshal(body, &payload)
+		mu.Lock()
+		received = append(received, payload)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "tr")
+
+	t.Run("no_rules", func(t *testing.T) {
+		out, err := bdRunWithFlockRetry(t, bd, dir, "triage")
+		if err == nil || !strings.Contains(string(out), "no triage rules configured") {
+			t.Errorf("expected a missing-rules error, got err=%v:\n%s", err, out)
+		}
+	})
+
+	cfg, err := os.OpenFile(filepath.Join(beadsDir, "config.yaml"), os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = cfg.WriteString("\nnotify:\n  webhooks:\n    ci:\n      url: " + srv.URL + "\n      events: [triage]\n" +
+		"triage:\n  rules:\n    urgent-bugs:\n      type: bug\n      label: customer\n      add_label: escalated\n      comment: Escalated by triage\n      notify: true\n" +
+		"    stale-wip:\n      status: in_progress\n      untouched: 14d\n      add_label: stale\n")
+	_ = cfg.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bug := bdCreate(t, bd, dir, "Checkout fails", "--type", "bug", "--labels", "customer")
+	bdCreate(t, bd, dir, "Internal bug", "--type", "bug")
+
+	report := func(t *testing.T, args ...string) triageReport {
+		t.Helper()
+		out := bdCommand(t, bd, dir, append([]string{"triage", "--json"}, args...)...)
+		var r triageReport
+		if err := json.Unmarshal([]byte(out), &r); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		return r
+	}
+
+	t.Run("dry_run", func(t *testing.T) {
+		r := report(t)
+		if r.Applied || len(r.Rules) != 2 || len(r.Actions) != 1 {
+			t.Fatalf("report = %+v", r)
+		}
+		if a := r.Actions[0]; a.Rule != "urgent-bugs" || a.IssueID != bug.ID || !slices.Equal(a.AddLabels, []string{"escalated"}) {
+			t.Errorf("action = %+v", a)
+		}
+		if got := bdShow(t, bd, dir, bug.ID); slices.Contains(got.Labels, "escalated") {
+			t.Errorf("dry run changed labels: %v", got.Labels)
+		}
+		out := bdCommand(t, bd, dir, "triage")
+		if !strings.Contains(out, "add label escalated, comment, notify") || !strings.Contains(out, "Dry run: 1 action(s)") {
+			t.Errorf("text report:\n%s", out)
+		}
+	})
+
+	t.Run("apply_once", func(t *testing.T) {
+		if r := report(t, "--apply"); !r.Applied || len(r.Actions) != 1 {
+			t.Fatalf("apply report = %+v", r)
+		}
+		got := bdShow(t, bd, dir, bug.ID)
+		if !slices.Contains(got.Labels, "escalated") {
+			t.Errorf("labels after apply = %v", got.Labels)
+		}
+		if out := bdCommand(t, bd, dir, "comments", bug.ID); !strings.Contains(out, "Escalated by triage") {
+			t.Errorf("comment missing:\n%s", out)
+		}
+		mu.Lock()
+		n := len(received)
+		var first map[string]interface{}
+		if n > 0 {
+			first = received[0]
+		}
+		mu.Unlock()
+		if n != 1 || first["event"] != "triage" || first["rule"] != "urgent-bugs" {
+			t.Errorf("notifications = %d, first %v", n, first)
+		}
+
+		if r := report(t, "--apply"); len(r.Actions) != 0 {
+			t.Errorf("second apply acted again: %+v", r.Actions)
+		}
+	})
+
+	t.Run("rule_filter", func(t *testing.T) {
+		if r := report(t, "--rule", "stale-wip"); len(r.Rules) != 1 || r.Rules[0].Name != "stale-wip" {
+			t.Errorf("rules = %+v", r.Rules)
+		}
+		out, err := bdRunWithFlockRetry(t, bd, dir, "triage", "--rule", "nope")
+		if err == nil || !strings.Contains(string(out), `unknown triage rule "nope"`) {
+			t.Errorf("unknown rule: err=%v:\n%s", err, out)
+		}
+	})
+}
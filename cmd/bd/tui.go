@@ -0,0 +1,612 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"golang.org/x/term"
+)
+
+var uiCmd = &cobra.Command{
+	Use:     "ui",
+	GroupID: "views",
+	Short:   "Browse and edit issues in an interactive terminal UI",
+	Long: `Open a full-screen terminal UI with three panes: the issue list, the
+selected issue's details, and its dependency tree.
+
+Keys:
+  j/k, ↑/↓     move the selection (g/G: first/last)
+  /            fuzzy search by ID, title, type and assignee (esc clears)
+  s / S        cycle status forward / back (open, in_progress, blocked, deferred)
+  x            close the selected issue
+  0-4, +/-     set, raise or lower priority
+  a            show or hide closed issues
+  r            refresh now
+  q, ctrl+c    quit
+
+The panes refresh every --refresh interval, so changes made by other bd
+commands or agents show up while the UI is open. Edits go through the same
+storage calls as bd update and bd close: events are recorded and status
+workflows (status.transitions) apply.
+
+Examples:
+  bd ui
+  bd ui --all --refresh 2s`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("ui is not supported in proxied-server mode")
+		}
+		if !term.IsTerminal(int(os.Stdout.Fd())) || !term.IsTerminal(int(os.Stdin.Fd())) {
+			return HandleError("bd ui needs an interactive terminal; use bd list / bd show instead")
+		}
+		showClosed, _ := cmd.Flags().GetBool("all")
+		refresh, _ := cmd.Flags().GetDuration("refresh")
+		if refresh < time.Second {
+			return HandleError("--refresh must be at least 1s")
+		}
+
+		m := newTUIModel(rootCtx, store, refresh)
+		m.showClosed = showClosed
+		if _, err := tea.NewProgram(m, tea.WithContext(rootCtx)).Run(); err != nil {
+			return HandleError("ui: %v", err)
+		}
+		return nil
+	},
+}
+
+// tuiStatusCycle is the order s / S step through. Closing is a separate key
+// (x) so it goes through the close guards.
+var tuiStatusCycle = []types.Status{
+	types.StatusOpen,
+	types.StatusInProgress,
+	types.StatusBlocked,
+	types.StatusDeferred,
+}
+
+// tuiTreeDepth bounds the dependency tree pane.
+const tuiTreeDepth = 3
+
+type (
+	tuiIssuesMsg struct {
+		issues []*types.Issue
+		err    error
+	}
+	tuiDetailMsg struct {
+		id     string
+		detail *types.IssueDetails
+		tree   []*types.TreeNode
+		err    error
+	}
+	tuiEditMsg struct {
+		flash string
+		err   error
+	}
+	tuiTickMsg struct{}
+)
+
+// tuiModel is the bubbletea model behind bd ui. Store calls run as tea.Cmds
+// on other goroutines; mu serializes them.
+type tuiModel struct {
+	ctx        context.Context
+	store      storage.DoltStorage
+	mu         *sync.Mutex
+	refresh    time.Duration
+	showClosed bool
+
+	issues    []*types.Issue // everything loaded, in display order
+	visible   []*types.Issue // issues matching query
+	cursor    int
+	query     string
+	searching bool
+
+	detailID string
+	detail   *types.IssueDetails
+	tree     []*types.TreeNode
+
+	flash         string
+	width, height int
+}
+
+func newTUIModel(ctx context.Context, st storage.DoltStorage, refresh time.Duration) *tuiModel {
+	return &tuiModel{ctx: ctx, store: st, mu: &sync.Mutex{}, refresh: refresh}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return tea.Batch(m.loadIssues(), m.tick())
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tuiTickMsg:
+		return m, tea.Batch(m.loadIssues(), m.tick())
+	case tuiIssuesMsg:
+		if msg.err != nil {
+			m.flash = "refresh failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.issues = msg.issues
+		m.applyQuery()
+		if cmd := m.selectionChanged(); cmd != nil || m.detailID == "" {
+			return m, cmd
+		}
+		// Same selection: reload its panes in place so edits made
+		// elsewhere show up.
+		return m, m.loadDetail(m.detailID)
+	case tuiDetailMsg:
+		if msg.id != m.detailID {
+			return m, nil // the selection moved on
+		}
+		if msg.err != nil {
+			m.flash = "load " + msg.id + ": " + msg.err.Error()
+			return m, nil
+		}
+		m.detail, m.tree = msg.detail, msg.tree
+	case tuiEditMsg:
+		m.flash = msg.flash
+		if msg.err != nil {
+			m.flash = msg.err.Error()
+		}
+		return m, m.loadIssues()
+	case tea.KeyPressMsg:
+		if m.searching {
+			return m, m.handleSearchKey(msg)
+		}
+		return m, m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *tuiModel) handleSearchKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c":
+		return tea.Quit
+	case "esc":
+		m.searching, m.query = false, ""
+	case "enter":
+		m.searching = false
+		return nil
+	case "backspace":
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+		}
+	default:
+		if msg.Text == "" {
+			return nil
+		}
+		m.query += msg.Text
+	}
+	m.applyQuery()
+	m.cursor = 0 // best match first
+	return m.selectionChanged()
+}
+
+func (m *tuiModel) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	m.flash = ""
+	switch key := msg.String(); key {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "j", "down":
+		m.moveCursor(1)
+	case "k", "up":
+		m.moveCursor(-1)
+	case "g", "home":
+		m.moveCursor(-len(m.visible))
+	case "G", "end":
+		m.moveCursor(len(m.visible))
+	case "/":
+		m.searching = true
+		return nil
+	case "esc":
+		m.query = ""
+		m.applyQuery()
+	case "r":
+		return m.loadIssues()
+	case "a":
+		m.showClosed = !m.showClosed
+		return m.loadIssues()
+	case "s", "S":
+		if issue := m.selected(); issue != nil {
+			step := 1
+			if key == "S" {
+				step = -1
+			}
+			next := nextTUIStatus(issue.Status, step)
+			return m.updateIssue(issue, map[string]interface{}{"status": string(next)},
+				fmt.Sprintf("%s → %s", issue.ID, next))
+		}
+	case "+", "-":
+		if issue := m.selected(); issue != nil {
+			p := issue.Priority - 1 // + raises priority: P2 → P1
+			if key == "-" {
+				p = issue.Priority + 1
+			}
+			if p >= 0 && p <= 4 {
+				return m.updateIssue(issue, map[string]interface{}{"priority": p},
+					fmt.Sprintf("%s → P%d", issue.ID, p))
+			}
+		}
+	case "0", "1", "2", "3", "4":
+		if issue := m.selected(); issue != nil {
+			p := int(key[0] - '0')
+			return m.updateIssue(issue, map[string]interface{}{"priority": p},
+				fmt.Sprintf("%s → P%d", issue.ID, p))
+		}
+	case "x":
+		if issue := m.selected(); issue != nil {
+			return m.closeIssue(issue)
+		}
+	}
+	return m.selectionChanged()
+}
+
+func (m *tuiModel) moveCursor(delta int) {
+	m.cursor = max(0, min(len(m.visible)-1, m.cursor+delta))
+}
+
+func (m *tuiModel) selected() *types.Issue {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return m.visible[m.cursor]
+}
+
+// applyQuery recomputes the visible list, keeping the selected issue
+// selected when it still matches.
+func (m *tuiModel) applyQuery() {
+	var selectedID string
+	if issue := m.selected(); issue != nil {
+		selectedID = issue.ID
+	}
+	m.visible = fuzzyFilterIssues(m.issues, m.query)
+	m.cursor = max(0, min(len(m.visible)-1, m.cursor))
+	for i, issue := range m.visible {
+		if issue.ID == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// selectionChanged starts loading the detail and tree panes when the
+// selected issue differs from the one they show.
+func (m *tuiModel) selectionChanged() tea.Cmd {
+	issue := m.selected()
+	if issue == nil {
+		m.detailID, m.detail, m.tree = "", nil, nil
+		return nil
+	}
+	if issue.ID == m.detailID {
+		return nil
+	}
+	m.detailID, m.detail, m.tree = issue.ID, nil, nil
+	return m.loadDetail(issue.ID)
+}
+
+func (m *tuiModel) tick() tea.Cmd {
+	return tea.Tick(m.refresh, func(time.Time) tea.Msg { return tuiTickMsg{} })
+}
+
+func (m *tuiModel) loadIssues() tea.Cmd {
+	showClosed := m.showClosed
+	return func() tea.Msg {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		notTemplate := false
+		filter := types.IssueFilter{IsTemplate: &notTemplate}
+		if !showClosed {
+			filter.ExcludeStatus = []types.Status{types.StatusClosed}
+		}
+		issues, err := m.store.SearchIssues(m.ctx, "", filter)
+		if err != nil {
+			return tuiIssuesMsg{err: err}
+		}
+		sortTUIIssues(issues)
+		return tuiIssuesMsg{issues: issues}
+	}
+}
+
+func (m *tuiModel) loadDetail(id string) tea.Cmd {
+	return func() tea.Msg {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		detail, err := getIssueContext(m.ctx, m.store, id)
+		if err != nil {
+			issue, gerr := m.store.GetIssue(m.ctx, id)
+			if gerr != nil {
+				return tuiDetailMsg{id: id, err: gerr}
+			}
+			detail = loadShowSections(m.ctx, m.store, id)
+			detail.Issue = *issue
+		}
+		tree, err := m.store.GetDependencyTree(m.ctx, id, tuiTreeDepth, false, false)
+		if err != nil {
+			return tuiDetailMsg{id: id, err: err}
+		}
+		return tuiDetailMsg{id: id, detail: detail, tree: tree}
+	}
+}
+
+func (m *tuiModel) updateIssue(issue *types.Issue, updates map[string]interface{}, flash string) tea.Cmd {
+	id := issue.ID
+	if err := validateIssueUpdatable(id, issue); err != nil {
+		m.flash = err.Error()
+		return nil
+	}
+	return func() tea.Msg {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if err := m.store.UpdateIssue(m.ctx, id, updates, actor); err != nil {
+			return tuiEditMsg{err: fmt.Errorf("update %s: %w", id, err)}
+		}
+		if err := commitPendingIfEmbedded(m.ctx, m.store, actor, doltAutoCommitParams{
+			Command:  "ui",
+			IssueIDs: []string{id},
+		}); err != nil {
+			return tuiEditMsg{err: fmt.Errorf("commit %s: %w", id, err)}
+		}
+		return tuiEditMsg{flash: flash}
+	}
+}
+
+func (m *tuiModel) closeIssue(issue *types.Issue) tea.Cmd {
+	id := issue.ID
+	if err := validateIssueClosable(id, issue, actor, false); err != nil {
+		m.flash = err.Error()
+		return nil
+	}
+	return func() tea.Msg {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, err := m.store.CloseIssueChecked(m.ctx, id, actor, storage.CloseIssueOptions{Reason: "Closed"}); err != nil {
+			return tuiEditMsg{err: fmt.Errorf("close %s: %w", id, err)}
+		}
+		if err := commitPendingIfEmbedded(m.ctx, m.store, actor, doltAutoCommitParams{
+			Command:  "ui",
+			IssueIDs: []string{id},
+		}); err != nil {
+			return tuiEditMsg{err: fmt.Errorf("commit %s: %w", id, err)}
+		}
+		return tuiEditMsg{flash: id + " closed"}
+	}
+}
+
+func (m *tuiModel) View() tea.View {
+	width, height := m.width, m.height
+	if width <= 0 || height <= 0 {
+		width, height = 100, 30
+	}
+	bodyHeight := max(1, height-2)
+	listWidth := max(20, width*2/5)
+	rightWidth := max(10, width-listWidth-3)
+	detailHeight := max(1, bodyHeight*3/5)
+
+	list := m.renderList(bodyHeight)
+	right := append(m.renderDetail(detailHeight), ui.RenderMuted(strings.Repeat("─", rightWidth)))
+	right = append(right, m.renderTree(bodyHeight-len(right))...)
+
+	var b strings.Builder
+	title := fmt.Sprintf("bd ui — %d issues", len(m.visible))
+	if m.query != "" || m.searching {
+		title += " · search: " + m.query
+		if m.searching {
+			title += "▌"
+		}
+	}
+	b.WriteString(ui.RenderBold(title) + "\n")
+	for i := 0; i < bodyHeight; i++ {
+		b.WriteString(fitTUILine(lineAt(list, i), listWidth))
+		b.WriteString(ui.RenderMuted(" │ "))
+		b.WriteString(fitTUILine(lineAt(right, i), rightWidth))
+		b.WriteString("\n")
+	}
+	footer := "j/k move · / search · s/S status · x close · 0-4 +/- priority · a closed · r refresh · q quit"
+	if m.flash != "" {
+		footer = m.flash
+	}
+	b.WriteString(ui.RenderMuted(ansi.Truncate(footer, width, "…")))
+
+	v := tea.NewView(b.String())
+	v.AltScreen = true
+	return v
+}
+
+// renderList renders the issue list, scrolled so the cursor stays visible.
+func (m *tuiModel) renderList(height int) []string {
+	if len(m.visible) == 0 {
+		if len(m.issues) == 0 {
+			return []string{ui.RenderMuted("  (no issues)")}
+		}
+		return []string{ui.RenderMuted("  (no matches)")}
+	}
+	start := max(0, m.cursor-height+1)
+	end := min(len(m.visible), start+height)
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		marker := "  "
+		if i == m.cursor {
+			marker = ui.RenderAccent("▶ ")
+		}
+		lines = append(lines, marker+formatShortIssue(m.visible[i]))
+	}
+	return lines
+}
+
+func (m *tuiModel) renderDetail(height int) []string {
+	if m.detail == nil {
+		if m.detailID == "" {
+			return nil
+		}
+		return []string{ui.RenderMuted("loading " + m.detailID + "…")}
+	}
+	d := m.detail
+	issue := &d.Issue
+	lines := []string{formatIssueHeader(issue)}
+	lines = append(lines, strings.Split(formatIssueMetadata(issue), "\n")...)
+	if d.BlockedByCount != nil {
+		lines = append(lines, formatIssueContextSummary(d))
+	}
+	if len(d.Labels) > 0 {
+		lines = append(lines, ui.RenderBold("LABELS: ")+strings.Join(d.Labels, ", "))
+	}
+	if issue.Description != "" {
+		lines = append(lines, "", ui.RenderBold("DESCRIPTION"))
+		lines = append(lines, strings.Split(strings.TrimSpace(issue.Description), "\n")...)
+	}
+	if issue.AcceptanceCriteria != "" {
+		lines = append(lines, "", ui.RenderBold("ACCEPTANCE CRITERIA"))
+		lines = append(lines, strings.Split(strings.TrimSpace(issue.AcceptanceCriteria), "\n")...)
+	}
+	if len(lines) > height {
+		lines = append(lines[:height-1], ui.RenderMuted("…"))
+	}
+	return lines
+}
+
+func (m *tuiModel) renderTree(height int) []string {
+	if height <= 0 {
+		return nil
+	}
+	lines := []string{ui.RenderBold("DEPENDENCIES")}
+	if len(m.tree) <= 1 {
+		if m.detail != nil {
+			lines = append(lines, ui.RenderMuted("  (none)"))
+		}
+		return lines
+	}
+	var b strings.Builder
+	renderTreeTo(&b, m.tree, tuiTreeDepth, "down")
+	lines = append(lines, strings.Split(strings.TrimRight(b.String(), "\n"), "\n")...)
+	if len(lines) > height {
+		lines = append(lines[:height-1], ui.RenderMuted("…"))
+	}
+	return lines
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+// fitTUILine truncates or pads a styled line to exactly width cells.
+func fitTUILine(line string, width int) string {
+	line = ansi.Truncate(line, width, "…")
+	if pad := width - ansi.StringWidth(line); pad > 0 {
+		line += strings.Repeat(" ", pad)
+	}
+	return line
+}
+
+// nextTUIStatus steps through tuiStatusCycle. Statuses outside the cycle
+// (closed, custom) step to open.
+func nextTUIStatus(current types.Status, step int) types.Status {
+	for i, s := range tuiStatusCycle {
+		if s == current {
+			n := len(tuiStatusCycle)
+			return tuiStatusCycle[((i+step)%n+n)%n]
+		}
+	}
+	return types.StatusOpen
+}
+
+// sortTUIIssues orders the list: open work before closed, then by priority,
+// then most recently updated first.
+func sortTUIIssues(issues []*types.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if ac, bc := a.Status == types.StatusClosed, b.Status == types.StatusClosed; ac != bc {
+			return !ac
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.UpdatedAt.After(b.UpdatedAt)
+	})
+}
+
+// fuzzyFilterIssues returns the issues whose ID, title, type or assignee
+// fuzzy-match every whitespace-separated term of query, best match first.
+// An empty query returns issues unchanged.
+func fuzzyFilterIssues(issues []*types.Issue, query string) []*types.Issue {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return issues
+	}
+	type scored struct {
+		issue *types.Issue
+		score int
+	}
+	var matches []scored
+	for _, issue := range issues {
+		haystack := strings.ToLower(strings.Join([]string{issue.ID, issue.Title, string(issue.IssueType), issue.Assignee}, " "))
+		total := 0
+		for _, term := range terms {
+			score, ok := fuzzyScore(term, haystack)
+			if !ok {
+				total = -1
+				break
+			}
+			total += score
+		}
+		if total >= 0 {
+			matches = append(matches, scored{issue, total})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	out := make([]*types.Issue, len(matches))
+	for i, s := range matches {
+		out[i] = s.issue
+	}
+	return out
+}
+
+// fuzzyScore reports whether the runes of pattern appear in order in text,
+// scoring consecutive runs and matches at word starts higher. Both are
+// expected lower-cased.
+func fuzzyScore(pattern, text string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+	p := []rune(pattern)
+	score, pi, prevMatch := 0, 0, -2
+	prev := ' '
+	for ti, r := range []rune(text) {
+		if pi < len(p) && r == p[pi] {
+			score++
+			if ti == prevMatch+1 {
+				score += 3
+			}
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += 2
+			}
+			prevMatch = ti
+			pi++
+		}
+		prev = r
+	}
+	return score, pi == len(p)
+}
+
+func init() {
+	uiCmd.Flags().Bool("all", false, "Include closed issues")
+	uiCmd.Flags().Duration("refresh", 5*time.Second, "How often to reload issues from storage")
+	rootCmd.AddCommand(uiCmd)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		want          bool
+	}{
+		{"", "anything", true},
+		{"lgn", "fix login page", true},
+		{"login", "fix login page", true},
+		{"nigol", "fix login page", false},
+		{"bd-12", "bd-12 crash on start", true},
+		{"xyz", "fix login page", false},
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.pattern, tt.text); ok != tt.want {
+			t.Errorf("fuzzyScore(%q, %q) matched = %v, want %v", tt.pattern, tt.text, ok, tt.want)
+		}
+	}
+
+	contiguous, _ := fuzzyScore("login", "fix login page")
+	scattered, _ := fuzzyScore("login", "large old igloo in north")
+	if contiguous <= scattered {
+		t.Errorf("contiguous match scored %d, scattered %d; want contiguous higher", contiguous, scattered)
+	}
+}
+
+func TestFuzzyFilterIssues(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Large old igloo in north", IssueType: types.TypeTask},
+		{ID: "bd-2", Title: "Fix login page", IssueType: types.TypeBug, Assignee: "alice"},
+		{ID: "bd-3", Title: "Write docs", IssueType: types.TypeTask},
+	}
+
+	if got := fuzzyFilterIssues(issues, "  "); len(got) != 3 {
+		t.Fatalf("blank query returned %d issues, want all 3", len(got))
+	}
+
+	got := fuzzyFilterIssues(issues, "login")
+	if len(got) != 2 || got[0].ID != "bd-2" {
+		t.Fatalf("login: got %v, want bd-2 ranked first of 2", tuiIssueIDs(got))
+	}
+
+	got = fuzzyFilterIssues(issues, "bug alice")
+	if len(got) != 1 || got[0].ID != "bd-2" {
+		t.Errorf("every term must match: got %v, want [bd-2]", tuiIssueIDs(got))
+	}
+}
+
+func TestNextTUIStatus(t *testing.T) {
+	tests := []struct {
+		from types.Status
+		step int
+		want types.Status
+	}{
+		{types.StatusOpen, 1, types.StatusInProgress},
+		{types.StatusDeferred, 1, types.StatusOpen},
+		{types.StatusOpen, -1, types.StatusDeferred},
+		{types.StatusClosed, 1, types.StatusOpen},
+		{types.Status("in_review"), -1, types.StatusOpen},
+	}
+	for _, tt := range tests {
+		if got := nextTUIStatus(tt.from, tt.step); got != tt.want {
+			t.Errorf("nextTUIStatus(%s, %d) = %s, want %s", tt.from, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestSortTUIIssues(t *testing.T) {
+	now := time.Now()
+	issues := []*types.Issue{
+		{ID: "closed", Priority: 0, Status: types.StatusClosed, UpdatedAt: now},
+		{ID: "p2-old", Priority: 2, Status: types.StatusOpen, UpdatedAt: now.Add(-time.Hour)},
+		{ID: "p2-new", Priority: 2, Status: types.StatusOpen, UpdatedAt: now},
+		{ID: "p1", Priority: 1, Status: types.StatusInProgress, UpdatedAt: now},
+	}
+	sortTUIIssues(issues)
+	if got := strings.Join(tuiIssueIDs(issues), ","); got != "p1,p2-new,p2-old,closed" {
+		t.Errorf("order = %s", got)
+	}
+}
+
+func TestTUIModelNavigationAndSearch(t *testing.T) {
+	m := newTUIModel(context.Background(), nil, time.Minute)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	m.Update(tuiIssuesMsg{issues: []*types.Issue{
+		{ID: "bd-1", Title: "Fix login page", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "Write docs", Status: types.StatusOpen},
+		{ID: "bd-3", Title: "Login rate limit", Status: types.StatusOpen},
+	}})
+	if m.detailID != "bd-1" {
+		t.Fatalf("initial selection = %q, want bd-1", m.detailID)
+	}
+
+	press := func(keys ...tea.KeyPressMsg) {
+		for _, k := range keys {
+			m.Update(k)
+		}
+	}
+	text := func(s string) tea.KeyPressMsg { return tea.KeyPressMsg{Code: rune(s[0]), Text: s} }
+
+	press(text("j"), tea.KeyPressMsg{Code: tea.KeyDown})
+	if sel := m.selected(); sel == nil || sel.ID != "bd-3" {
+		t.Fatalf("after two moves down selected %v, want bd-3", sel)
+	}
+	press(text("j"))
+	if m.cursor != 2 {
+		t.Errorf("cursor moved past the end: %d", m.cursor)
+	}
+
+	press(text("/"), text("d"), text("o"), text("c"))
+	if !m.searching || m.query != "doc" {
+		t.Fatalf("searching=%v query=%q, want search for doc", m.searching, m.query)
+	}
+	if len(m.visible) != 1 || m.selected().ID != "bd-2" || m.detailID != "bd-2" {
+		t.Errorf("search doc: visible %v, selected %q", tuiIssueIDs(m.visible), m.detailID)
+	}
+	press(tea.KeyPressMsg{Code: tea.KeyEnter}, text("q"))
+	if m.searching || m.query != "doc" {
+		t.Errorf("enter should keep the query and leave search mode")
+	}
+
+	press(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.query != "" || len(m.visible) != 3 {
+		t.Errorf("esc should clear the query, visible %v", tuiIssueIDs(m.visible))
+	}
+	if m.selected().ID != "bd-2" {
+		t.Errorf("clearing the query should keep bd-2 selected, got %s", m.selected().ID)
+	}
+
+	view := m.View().Content
+	for _, want := range []string{"bd ui — 3 issues", "bd-1", "Write docs", "DEPENDENCIES"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if lines := strings.Count(view, "\n") + 1; lines != 20 {
+		t.Errorf("view is %d lines, want the window height 20", lines)
+	}
+}
+
+func tuiIssueIDs(issues []*types.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
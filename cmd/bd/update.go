@@ -31,7 +31,17 @@ create, update, show, or close operation).
 
 Updates are applied per issue ID, not atomically across IDs: when some IDs
 fail, the remaining issues are still updated, every failed ID is reported on
-stderr, and the command exits nonzero.`,
+stderr, and the command exits nonzero.
+
+With --filter, updates every issue matching a query expression (see
+'bd query --help'; juxtaposed terms are ANDed) instead of listed IDs. The
+matching issues and the change are previewed, then applied in a single
+transaction after confirmation. Only --set, --add-label and --remove-label
+may be combined with --filter:
+
+  bd update --filter 'status=open label=stale' --set priority=3 --add-label backlog
+  bd update --filter 'assignee=alice' --set assignee=bob --dry-run
+  bd update --filter 'type=bug priority>2' --set status=deferred --yes`,
 	Args:          cobra.MinimumNArgs(0),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -45,6 +55,23 @@ stderr, and the command exits nonzero.`,
 			}
 		}()
 
+		filterExpr, _ := cmd.Flags().GetString("filter")
+		if cmd.Flags().Changed("filter") {
+			if len(args) > 0 {
+				return HandleErrorRespectJSON("--filter cannot be combined with issue IDs")
+			}
+			if strings.TrimSpace(filterExpr) == "" {
+				return HandleErrorRespectJSON("--filter requires a query expression")
+			}
+			if usesProxiedServer() {
+				return HandleErrorRespectJSON("--filter is not supported in proxied-server mode")
+			}
+			return runBulkUpdate(cmd, filterExpr)
+		}
+		if cmd.Flags().Changed("set") || cmd.Flags().Changed("yes") || cmd.Flags().Changed("dry-run") {
+			return HandleErrorRespectJSON("--set, --yes and --dry-run require --filter")
+		}
+
 		if usesProxiedServer() {
 			return runUpdateProxiedServer(cmd, rootCtx, args)
 		}
@@ -315,6 +342,11 @@ stderr, and the command exits nonzero.`,
 		// Get claim flag
 		claimFlag, _ := cmd.Flags().GetBool("claim")
 
+		expectedVersion, err := getExpectedVersion(cmd)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
 		if len(updates) == 0 && !claimFlag {
 			fmt.Println("No updates specified")
 			return nil
@@ -385,6 +417,12 @@ stderr, and the command exits nonzero.`,
 				closeIfUnmutated(result)
 				continue
 			}
+			if err := checkExpectedVersion(issue, expectedVersion); err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating %v\n", err)
+				recordFailure(id, err.Error())
+				closeIfUnmutated(result)
+				continue
+			}
 
 			// Handle claim operation atomically using compare-and-swap semantics
 			if claimFlag {
@@ -419,7 +457,18 @@ stderr, and the command exits nonzero.`,
 			notesOverwritten := replacesExistingNotes(issue.Notes, updates)
 
 			if len(regularUpdates) > 0 {
-				if err := issueStore.UpdateIssue(ctx, result.ResolvedID, regularUpdates, actor); err != nil {
+				// With --expect-version the write is a compare-and-swap on the
+				// row version read above, closing the window between that read
+				// and this write. A claim rewrites the version itself, so the
+				// claim's own CAS stands in for it there.
+				var updateOpts storage.UpdateIssueOptions
+				if expectedVersion != "" && !claimFlag {
+					updateOpts.ExpectedVersion = &issue.RowVersion
+				}
+				if err := issueStore.UpdateIssueChecked(ctx, result.ResolvedID, regularUpdates, actor, updateOpts); err != nil {
+					if isIssueChanged(err) {
+						err = errIssueChanged
+					}
 					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
 					recordFailure(id, fmt.Sprintf("updating issue: %v", err))
 					closeIfUnmutated(result)
@@ -708,6 +757,14 @@ func init() {
 	// Incremental metadata edits (GH#1406)
 	updateCmd.Flags().StringArray("set-metadata", nil, "Set metadata key=value (repeatable, e.g., --set-metadata team=platform)")
 	updateCmd.Flags().StringArray("unset-metadata", nil, "Remove metadata key (repeatable, e.g., --unset-metadata team)")
+	// Optimistic concurrency
+	updateCmd.Flags().String("expect-version", "", "Only update if the issue still has this version (from 'bd show --json'); fails if it changed since you read it")
+	updateCmd.Flags().Bool("force", false, "Ignore --expect-version and overwrite concurrent changes")
+	// Bulk updates
+	updateCmd.Flags().String("filter", "", "Update every issue matching a query expression (e.g. 'status=open label=stale') instead of listed IDs")
+	updateCmd.Flags().StringArray("set", nil, "With --filter: set a field, key=value (status, priority, assignee; repeatable)")
+	updateCmd.Flags().BoolP("yes", "y", false, "With --filter: apply without asking for confirmation")
+	updateCmd.Flags().Bool("dry-run", false, "With --filter: preview the matching issues without changing them")
 	updateCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(updateCmd)
 }
@@ -553,6 +553,37 @@ func TestEmbeddedUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("update_expect_version", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Expect version", "--type", "task")
+		version := func() string {
+			t.Helper()
+			v, _ := bdShowDetails(t, bd, dir, issue.ID)["version"].(string)
+			if v == "" {
+				t.Fatalf("bd show %s --json has no version", issue.ID)
+			}
+			return v
+		}
+		bdUpdate(t, bd, dir, issue.ID, "--expect-version", version(), "--priority", "1")
+
+		// The concurrent write lands in the same second as the read it
+		// invalidates; the version token still tells them apart.
+		stale := version()
+		bdUpdate(t, bd, dir, issue.ID, "--assignee", "alice")
+
+		out := bdUpdateFail(t, bd, dir, issue.ID, "--expect-version", stale, "--title", "Lost update")
+		if !strings.Contains(out, "changed since you read it") {
+			t.Errorf("expected a changed-since-read conflict, got:\n%s", out)
+		}
+		if got := bdShow(t, bd, dir, issue.ID); got.Title != "Expect version" {
+			t.Errorf("conflicting update was applied: title %q", got.Title)
+		}
+
+		bdUpdate(t, bd, dir, issue.ID, "--expect-version", stale, "--force", "--title", "Forced")
+		if got := bdShow(t, bd, dir, issue.ID); got.Title != "Forced" {
+			t.Errorf("--force: expected title Forced, got %q", got.Title)
+		}
+	})
+
 	t.Run("close_via_status_update", func(t *testing.T) {
 		issue := bdCreate(t, bd, dir, "Close via update", "--type", "task")
 		bdUpdate(t, bd, dir, issue.ID, "--status", "closed")
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// bulkUpdatePreviewLimit is how many matching issues 'bd update --filter'
+// lists before asking for confirmation.
+const bulkUpdatePreviewLimit = 10
+
+// bulkUpdateFlags are the update flags that may be combined with --filter.
+var bulkUpdateFlags = map[string]bool{
+	"filter":       true,
+	"set":          true,
+	"add-label":    true,
+	"remove-label": true,
+	"yes":          true,
+	"dry-run":      true,
+}
+
+// bulkUpdateChanges is the change 'bd update --filter' applies to every
+// matching issue.
+type bulkUpdateChanges struct {
+	Fields       map[string]interface{}
+	AddLabels    []string
+	RemoveLabels []string
+}
+
+// parseBulkUpdateChanges reads --set, --add-label and --remove-label,
+// rejecting any other update flag: per-issue flags such as --title or
+// --description make no sense across a filtered set.
+func parseBulkUpdateChanges(cmd *cobra.Command) (*bulkUpdateChanges, error) {
+	var rejected []string
+	cmd.LocalFlags().Visit(func(f *pflag.Flag) {
+		if !bulkUpdateFlags[f.Name] {
+			rejected = append(rejected, "--"+f.Name)
+		}
+	})
+	if len(rejected) > 0 {
+		return nil, fmt.Errorf("%s cannot be combined with --filter (use --set key=value, --add-label or --remove-label)", strings.Join(rejected, ", "))
+	}
+
+	kvs, _ := cmd.Flags().GetStringArray("set")
+	fields, err := parseUpdateKVs(kvs)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fields["title"]; ok {
+		return nil, fmt.Errorf("title cannot be set with --filter")
+	}
+	if p, ok := fields["priority"].(int); ok && (p < 0 || p > 4) {
+		return nil, fmt.Errorf("invalid priority %d (expected 0-4)", p)
+	}
+	changes := &bulkUpdateChanges{Fields: fields}
+	changes.AddLabels, _ = cmd.Flags().GetStringSlice("add-label")
+	changes.RemoveLabels, _ = cmd.Flags().GetStringSlice("remove-label")
+	if len(changes.Fields) == 0 && len(changes.AddLabels) == 0 && len(changes.RemoveLabels) == 0 {
+		return nil, fmt.Errorf("nothing to change: use --set key=value, --add-label or --remove-label")
+	}
+	return changes, nil
+}
+
+// String summarizes the change, e.g. "priority=3, +label backlog".
+func (c *bulkUpdateChanges) String() string {
+	keys := make([]string, 0, len(c.Fields))
+	for k := range c.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys)+len(c.AddLabels)+len(c.RemoveLabels))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, c.Fields[k]))
+	}
+	for _, l := range c.AddLabels {
+		parts = append(parts, "+label "+l)
+	}
+	for _, l := range c.RemoveLabels {
+		parts = append(parts, "-label "+l)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// forIssue narrows the change to what actually differs on issue: fields
+// already at the target value and labels already present (or absent) are
+// dropped. An empty result means the issue is left alone.
+func (c *bulkUpdateChanges) forIssue(issue *types.Issue) (fields map[string]interface{}, add, remove []string) {
+	fields = make(map[string]interface{}, len(c.Fields))
+	for k, v := range c.Fields {
+		switch k {
+		case "status":
+			if string(issue.Status) == v {
+				continue
+			}
+		case "priority":
+			if issue.Priority == v {
+				continue
+			}
+		case "assignee":
+			if issue.Assignee == v {
+				continue
+			}
+		}
+		fields[k] = v
+	}
+	has := make(map[string]bool, len(issue.Labels))
+	for _, l := range issue.Labels {
+		has[l] = true
+	}
+	for _, l := range c.AddLabels {
+		if !has[l] {
+			add = append(add, l)
+		}
+	}
+	for _, l := range c.RemoveLabels {
+		if has[l] {
+			remove = append(remove, l)
+		}
+	}
+	return fields, add, remove
+}
+
+// findIssuesByFilter returns the issues matching a query expression, with
+// the same semantics as 'bd query': closed issues are excluded unless the
+// expression mentions status.
+func findIssuesByFilter(ctx context.Context, s storage.DoltStorage, expr string) ([]*types.Issue, error) {
+	node, err := query.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("parsing filter: %w", err)
+	}
+	result, err := query.NewEvaluator(time.Now()).WithMe(resolveMe).Evaluate(node)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating filter: %w", err)
+	}
+	if result.Filter.Status == nil && !hasExplicitStatusFilter(node) {
+		result.Filter.ExcludeStatus = append(result.Filter.ExcludeStatus, types.StatusClosed)
+	}
+	issues, err := s.SearchIssues(ctx, "", result.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if result.RequiresPredicate && result.Predicate != nil {
+		filtered := issues[:0]
+		for _, issue := range issues {
+			if result.Predicate(issue) {
+				filtered = append(filtered, issue)
+			}
+		}
+		issues = filtered
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+	return issues, nil
+}
+
+// confirmBulkUpdate asks before a bulk update. Unlike confirmPrompt it
+// defaults to no, and without a terminal it refuses rather than assuming
+// yes, so scripts must pass --yes.
+func confirmBulkUpdate(count int) (bool, error) {
+	if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("refusing to update %d issue(s) without confirmation", count)
+	}
+	fmt.Fprintf(os.Stderr, "Update %d issue(s)? [y/N] ", count)
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.TrimSpace(strings.ToLower(line))
+	return line == "y" || line == "yes", nil
+}
+
+// runBulkUpdate implements 'bd update --filter': it previews the matching
+// issues and the change, asks for confirmation unless --yes is given, then
+// updates every issue in one transaction. Each issue gets a single
+// "updated" event for its field changes, plus its label events.
+func runBulkUpdate(cmd *cobra.Command, expr string) error {
+	changes, err := parseBulkUpdateChanges(cmd)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if store == nil {
+		return HandleErrorRespectJSON("no storage available")
+	}
+	ctx := rootCtx
+
+	matched, err := findIssuesByFilter(ctx, store, expr)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	ids := make([]string, len(matched))
+	for i, issue := range matched {
+		ids[i] = issue.ID
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if !jsonOutput {
+		fmt.Printf("%d issue(s) match %s\n", len(matched), ui.RenderAccent(expr))
+		for _, issue := range matched[:min(len(matched), bulkUpdatePreviewLimit)] {
+			fmt.Printf("  %s [P%d] %s  %s\n", issue.ID, issue.Priority, issue.Status, issue.Title)
+		}
+		if len(matched) > bulkUpdatePreviewLimit {
+			fmt.Printf("  %s\n", ui.RenderMuted(fmt.Sprintf("... and %d more", len(matched)-bulkUpdatePreviewLimit)))
+		}
+		fmt.Printf("Change: %s\n", changes)
+	}
+	if len(matched) == 0 || dryRun {
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"filter":  expr,
+				"matched": ids,
+				"updated": []string{},
+				"dry_run": dryRun,
+			})
+		}
+		return nil
+	}
+
+	if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+		ok, err := confirmBulkUpdate(len(matched))
+		if err != nil {
+			return HandleErrorWithHintRespectJSON(err.Error(), "Re-run with --yes to apply, or --dry-run to preview only")
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return nil
+		}
+	}
+
+	actor := getActor()
+	updated := []string{}
+	commitMsg := fmt.Sprintf("bd: update %d issue(s) matching %s", len(matched), expr)
+	err = transact(ctx, store, commitMsg, func(tx storage.Transaction) error {
+		updated = updated[:0]
+		for _, issue := range matched {
+			fields, add, remove := changes.forIssue(issue)
+			if len(fields) == 0 && len(add) == 0 && len(remove) == 0 {
+				continue
+			}
+			if len(fields) > 0 {
+				if err := tx.UpdateIssue(ctx, issue.ID, fields, actor); err != nil {
+					return fmt.Errorf("%s: %w", issue.ID, err)
+				}
+			}
+			for _, label := range add {
+				if err := tx.AddLabel(ctx, issue.ID, label, actor); err != nil {
+					return fmt.Errorf("%s: %w", issue.ID, err)
+				}
+			}
+			for _, label := range remove {
+				if err := tx.RemoveLabel(ctx, issue.ID, label, actor); err != nil {
+					return fmt.Errorf("%s: %w", issue.ID, err)
+				}
+			}
+			updated = append(updated, issue.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("bulk update: %v", err)
+	}
+	if len(updated) > 0 {
+		commandDidWrite.Store(true)
+	}
+
+	for _, issue := range matched {
+		fields, _, _ := changes.forIssue(issue)
+		if s, ok := fields["status"].(string); ok {
+			audit.LogFieldChange(issue.ID, "status", string(issue.Status), s, actor, "")
+		}
+		if a, ok := fields["assignee"].(string); ok {
+			audit.LogFieldChange(issue.ID, "assignee", issue.Assignee, a, actor, "")
+		}
+		if p, ok := fields["priority"].(int); ok {
+			audit.LogFieldChange(issue.ID, "priority", fmt.Sprintf("%d", issue.Priority), fmt.Sprintf("%d", p), actor, "")
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"filter":  expr,
+			"matched": ids,
+			"updated": updated,
+			"dry_run": false,
+		})
+	}
+	fmt.Printf("%s Updated %d of %d issue(s)\n", ui.RenderPass("✓"), len(updated), len(matched))
+	return nil
+}
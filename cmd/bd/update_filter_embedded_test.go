@@ -0,0 +1,94 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedUpdateFilter(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "bu")
+	stale1 := bdCreateSilent(t, bd, dir, "Old idea", "--labels", "stale")
+	stale2 := bdCreateSilent(t, bd, dir, "Older idea", "--labels", "stale,urgent")
+	fresh := bdCreateSilent(t, bd, dir, "New idea")
+	closed := bdCreateSilent(t, bd, dir, "Done idea", "--labels", "stale")
+	bdCommand(t, bd, dir, "close", closed)
+
+	t.Run("requires_confirmation", func(t *testing.T) {
+		cmd := exec.Command(bd, "update", "--filter", "label=stale", "--set", "priority=3")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		out, err := cmd.CombinedOutput()
+		if err == nil || !strings.Contains(string(out), "without confirmation") {
+			t.Fatalf("expected refusal without --yes, got err=%v\n%s", err, out)
+		}
+		if !strings.Contains(string(out), "2 issue(s) match") || !strings.Contains(string(out), stale1) {
+			t.Errorf("missing preview:\n%s", out)
+		}
+	})
+
+	t.Run("dry_run", func(t *testing.T) {
+		out := bdCommand(t, bd, dir, "update", "--filter", "label=stale", "--set", "priority=3", "--dry-run")
+		if !strings.Contains(out, "priority=3") {
+			t.Errorf("dry run output:\n%s", out)
+		}
+		for _, issue := range bdListJSON(t, bd, dir, "--label", "stale") {
+			if issue.Priority == 3 {
+				t.Errorf("dry run changed %s", issue.ID)
+			}
+		}
+	})
+
+	t.Run("applies", func(t *testing.T) {
+		var result struct {
+			Matched []string `json:"matched"`
+			Updated []string `json:"updated"`
+		}
+		out := bdCommand(t, bd, dir, "update", "--filter", "status=open label=stale", "--set", "priority=3",
+			"--add-label", "backlog", "--remove-label", "urgent", "--yes", "--json")
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if strings.Join(result.Updated, ",") != strings.Join([]string{stale1, stale2}, ",") {
+			t.Fatalf("updated = %v, want [%s %s]", result.Updated, stale1, stale2)
+		}
+
+		for _, issue := range bdListJSON(t, bd, dir, "--all") {
+			switch issue.ID {
+			case stale1, stale2:
+				if issue.Priority != 3 || strings.Join(issue.Labels, ",") != "backlog,stale" {
+					t.Errorf("%s: priority %d labels %v", issue.ID, issue.Priority, issue.Labels)
+				}
+			case fresh, closed:
+				if issue.Priority == 3 {
+					t.Errorf("%s should not match the filter", issue.ID)
+				}
+			}
+		}
+	})
+
+	t.Run("rejects_ids_and_other_flags", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"update", fresh, "--filter", "label=stale", "--set", "priority=1"},
+			{"update", "--filter", "label=stale", "--title", "x"},
+			{"update", "--filter", "label=stale"},
+		} {
+			cmd := exec.Command(bd, args...)
+			cmd.Dir = dir
+			cmd.Env = bdEnv(dir)
+			if out, err := cmd.CombinedOutput(); err == nil {
+				t.Errorf("%v succeeded:\n%s", args, out)
+			}
+		}
+	})
+}
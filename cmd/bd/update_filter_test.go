@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBulkUpdateChangesForIssue(t *testing.T) {
+	changes := &bulkUpdateChanges{
+		Fields:       map[string]interface{}{"priority": 3, "status": "open"},
+		AddLabels:    []string{"backlog", "stale"},
+		RemoveLabels: []string{"urgent", "triage"},
+	}
+	if got, want := changes.String(), "priority=3, status=open, +label backlog, +label stale, -label urgent, -label triage"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	issue := &types.Issue{ID: "bd-1", Status: types.StatusOpen, Priority: 1, Labels: []string{"stale", "urgent"}}
+	fields, add, remove := changes.forIssue(issue)
+	if !reflect.DeepEqual(fields, map[string]interface{}{"priority": 3}) {
+		t.Errorf("fields = %v, want only priority (status already open)", fields)
+	}
+	if !reflect.DeepEqual(add, []string{"backlog"}) || !reflect.DeepEqual(remove, []string{"urgent"}) {
+		t.Errorf("add = %v, remove = %v", add, remove)
+	}
+
+	done := &types.Issue{ID: "bd-2", Status: types.StatusOpen, Priority: 3, Labels: []string{"backlog", "stale"}}
+	if fields, add, remove := changes.forIssue(done); len(fields)+len(add)+len(remove) != 0 {
+		t.Errorf("already-updated issue got changes %v %v %v", fields, add, remove)
+	}
+}
@@ -30,6 +30,8 @@ type updateInput struct {
 	unsetMetadata    []string
 	mergeMetadataIn  json.RawMessage
 	clearDeferStatus bool
+	// expectVersion is --expect-version ("" when unset or --force).
+	expectVersion string
 }
 
 func gatherUpdateInput(ctx context.Context, cmd *cobra.Command) (*updateInput, error) {
@@ -238,6 +240,9 @@ func gatherUpdateInput(ctx context.Context, cmd *cobra.Command) (*updateInput, e
 	in.unsetMetadata = unsetMetadataFlags
 
 	in.claim, _ = cmd.Flags().GetBool("claim")
+	if in.expectVersion, err = getExpectedVersion(cmd); err != nil {
+		return nil, HandleErrorRespectJSON("%v", err)
+	}
 	return in, nil
 }
 
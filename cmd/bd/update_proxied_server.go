@@ -157,6 +157,13 @@ func applyUpdateProxiedAttempt(ctx context.Context, id string, in *updateInput)
 		fmt.Fprintf(os.Stderr, "%s\n", err)
 		return nil, err.Error(), false, nil
 	}
+	// The read above and the write below share this unit of work, so a
+	// concurrent writer either lands first (and fails this check on the
+	// retry) or loses the commit-time merge.
+	if err := checkExpectedVersion(current, in.expectVersion); err != nil {
+		fmt.Fprintf(os.Stderr, "Error updating %v\n", err)
+		return nil, err.Error(), false, nil
+	}
 
 	spec := buildUpdateSpecForIssue(current, in)
 	notesOverwritten := replacesExistingNotes(current.Notes, in.fields)
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// errIssueChanged is the conflict reported when --expect-version no longer
+// matches the issue. It wraps storage.ErrVersionMismatch, so callers can
+// errors.Is either one.
+var errIssueChanged = fmt.Errorf("issue changed since you read it; use --force or re-fetch: %w", storage.ErrVersionMismatch)
+
+// getExpectedVersion returns --expect-version, or "" when the flag is unset
+// or --force overrides it.
+func getExpectedVersion(cmd *cobra.Command) (string, error) {
+	if !cmd.Flags().Changed("expect-version") {
+		return "", nil
+	}
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return "", nil
+	}
+	version, _ := cmd.Flags().GetString("expect-version")
+	if version == "" {
+		return "", fmt.Errorf("--expect-version is empty: want the version value from 'bd show --json'")
+	}
+	return version, nil
+}
+
+// checkExpectedVersion reports errIssueChanged when issue no longer has the
+// version token the caller read. The token covers the issue's content,
+// labels and full-precision timestamps, so unlike updated_at alone it also
+// catches a write in the same second as the read.
+func checkExpectedVersion(issue *types.Issue, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if current := issue.VersionToken(); current != expected {
+		return fmt.Errorf("%s: %w (version is now %s)", issue.ID, errIssueChanged, current)
+	}
+	return nil
+}
+
+// isIssueChanged reports whether err is an optimistic-concurrency conflict.
+func isIssueChanged(err error) bool {
+	return errors.Is(err, storage.ErrVersionMismatch)
+}
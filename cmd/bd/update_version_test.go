@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckExpectedVersion(t *testing.T) {
+	read := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	issue := &types.Issue{ID: "bd-1", Title: "Before", UpdatedAt: read, RowVersion: 7}
+	version := issue.VersionToken()
+
+	if err := checkExpectedVersion(issue, ""); err != nil {
+		t.Errorf("no expectation: %v", err)
+	}
+	if err := checkExpectedVersion(issue, version); err != nil {
+		t.Errorf("unchanged issue: %v", err)
+	}
+
+	// A write within the same second as the read still changes the token.
+	issue.Title = "After"
+	err := checkExpectedVersion(issue, version)
+	if !errors.Is(err, storage.ErrVersionMismatch) || !isIssueChanged(err) {
+		t.Fatalf("changed issue: got %v, want a version mismatch", err)
+	}
+}
@@ -53,85 +53,89 @@ Examples:
 			}
 		}()
 
-		ctx := rootCtx
-		branchName := args[0]
-
-		// Pre-merge HEAD scopes the post-resolution is_blocked recompute
-		// (bd-578h9.11); empty degrades to a full-graph pass.
-		preHead, _ := store.GetCurrentCommit(ctx)
-
-		// Perform merge
-		conflicts, err := store.Merge(ctx, branchName)
-		if err != nil {
-			return HandleErrorRespectJSON("failed to merge branch: %v", err)
-		}
+		return runBranchMerge(rootCtx, args[0], vcMergeStrategy)
+	},
+}
 
-		if len(conflicts) > 0 {
-			if vcMergeStrategy != "" {
-				for _, conflict := range conflicts {
-					table := conflict.Field
-					if table == "" {
-						table = "issues"
-					}
-					if err := store.ResolveConflicts(ctx, table, vcMergeStrategy); err != nil {
-						return HandleErrorRespectJSON("failed to resolve conflicts: %v", err)
-					}
-				}
-				// Conclude the merge: an unresolved-then-resolved working set
-				// stays uncommitted otherwise, and the merged-in writes
-				// bypassed every is_blocked hook (bd-578h9.11). Use
-				// CommitMergeResolution, not Commit: server-mode Commit excludes
-				// config (GH#2455), so a resolved config conflict — routine now
-				// that kv.* user data syncs through config — would be silently
-				// dropped, leaving the merge unconcluded and re-wedging the next
-				// pull/sync (GH#2474).
-				if err := store.CommitMergeResolution(ctx, fmt.Sprintf("Resolve merge conflicts from %s using %s strategy", branchName, vcMergeStrategy)); err != nil {
-					return HandleErrorRespectJSON("conflicts resolved but commit failed: %v", err)
+// runBranchMerge merges branchName into the current branch and reports the
+// outcome, resolving conflicts with strategy ("ours" or "theirs") when set.
+// Shared by bd vc merge and bd branch merge.
+func runBranchMerge(ctx context.Context, branchName, strategy string) error {
+	// Pre-merge HEAD scopes the post-resolution is_blocked recompute
+	// (bd-578h9.11); empty degrades to a full-graph pass.
+	preHead, _ := store.GetCurrentCommit(ctx)
+
+	// Perform merge
+	conflicts, err := store.Merge(ctx, branchName)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to merge branch: %v", err)
+	}
+
+	if len(conflicts) > 0 {
+		if strategy != "" {
+			for _, conflict := range conflicts {
+				table := conflict.Field
+				if table == "" {
+					table = "issues"
 				}
-				if rs, ok := store.(interface {
-					RecomputeBlockedAfterMerge(ctx context.Context, fromCommit string) error
-				}); ok {
-					if err := rs.RecomputeBlockedAfterMerge(ctx, preHead); err != nil {
-						return HandleErrorRespectJSON("conflicts resolved but is_blocked recompute failed: %v", err)
-					}
+				if err := store.ResolveConflicts(ctx, table, strategy); err != nil {
+					return HandleErrorRespectJSON("failed to resolve conflicts: %v", err)
 				}
-				if jsonOutput {
-					return outputJSON(map[string]interface{}{
-						"merged":        branchName,
-						"conflicts":     len(conflicts),
-						"resolved_with": vcMergeStrategy,
-					})
+			}
+			// Conclude the merge: an unresolved-then-resolved working set
+			// stays uncommitted otherwise, and the merged-in writes
+			// bypassed every is_blocked hook (bd-578h9.11). Use
+			// CommitMergeResolution, not Commit: server-mode Commit excludes
+			// config (GH#2455), so a resolved config conflict — routine now
+			// that kv.* user data syncs through config — would be silently
+			// dropped, leaving the merge unconcluded and re-wedging the next
+			// pull/sync (GH#2474).
+			if err := store.CommitMergeResolution(ctx, fmt.Sprintf("Resolve merge conflicts from %s using %s strategy", branchName, strategy)); err != nil {
+				return HandleErrorRespectJSON("conflicts resolved but commit failed: %v", err)
+			}
+			if rs, ok := store.(interface {
+				RecomputeBlockedAfterMerge(ctx context.Context, fromCommit string) error
+			}); ok {
+				if err := rs.RecomputeBlockedAfterMerge(ctx, preHead); err != nil {
+					return HandleErrorRespectJSON("conflicts resolved but is_blocked recompute failed: %v", err)
 				}
-				fmt.Printf("Merged %s with %d conflicts resolved using '%s' strategy\n",
-					ui.RenderAccent(branchName), len(conflicts), vcMergeStrategy)
-				return nil
 			}
-
 			if jsonOutput {
 				return outputJSON(map[string]interface{}{
-					"merged":    branchName,
-					"conflicts": conflicts,
+					"merged":        branchName,
+					"conflicts":     len(conflicts),
+					"resolved_with": strategy,
 				})
 			}
-
-			fmt.Printf("\n%s Merge completed with conflicts:\n\n", ui.RenderAccent("!!"))
-			for _, conflict := range conflicts {
-				fmt.Printf("  - %s\n", conflict.Field)
-			}
-			fmt.Printf("\nResolve conflicts with: bd vc merge %s --strategy [ours|theirs]\n\n", branchName)
+			fmt.Printf("Merged %s with %d conflicts resolved using '%s' strategy\n",
+				ui.RenderAccent(branchName), len(conflicts), strategy)
 			return nil
 		}
 
 		if jsonOutput {
 			return outputJSON(map[string]interface{}{
 				"merged":    branchName,
-				"conflicts": 0,
+				"conflicts": conflicts,
 			})
 		}
 
-		fmt.Printf("Successfully merged %s\n", ui.RenderAccent(branchName))
+		fmt.Printf("\n%s Merge completed with conflicts:\n\n", ui.RenderAccent("!!"))
+		for _, conflict := range conflicts {
+			fmt.Printf("  - %s\n", conflict.Field)
+		}
+		fmt.Printf("\nResolve conflicts with: bd vc merge %s --strategy [ours|theirs]\n\n", branchName)
 		return nil
-	},
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"merged":    branchName,
+			"conflicts": 0,
+		})
+	}
+
+	fmt.Printf("Successfully merged %s\n", ui.RenderAccent(branchName))
+	return nil
 }
 
 var vcCommitMessage string
@@ -148,6 +148,12 @@ func autoMigrateOnVersionBump(beadsDir string) {
 		return
 	}
 
+	// --readonly promises no writes; the migration writes and commits.
+	if readonlyMode {
+		debug.Logf("auto-migrate: skipping migration in read-only mode")
+		return
+	}
+
 	// Validate beadsDir
 	if beadsDir == "" {
 		debug.Logf("auto-migrate: skipping migration, no beads directory")
@@ -0,0 +1,86 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedStatusWorkflow(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "wf")
+	bdCommand(t, bd, dir, "config", "set", "status.custom", "in_review:wip")
+	bdCommand(t, bd, dir, "config", "set", "status.transitions",
+		"open>in_progress,in_progress>in_review:assigned,in_review>closed:no-blockers,*>deferred,deferred>open")
+
+	t.Run("rejects_invalid_config", func(t *testing.T) {
+		out := bdConfigFail(t, bd, dir, "set", "status.transitions", "open>closed:approved")
+		if !strings.Contains(out, "invalid guard") {
+			t.Errorf("expected invalid guard error, got: %s", out)
+		}
+	})
+
+	id := bdCreateSilent(t, bd, dir, "Workflow task")
+
+	t.Run("unlisted_transition_refused", func(t *testing.T) {
+		out := bdUpdateFail(t, bd, dir, id, "--status", "in_review")
+		if !strings.Contains(out, "status transition not allowed") || !strings.Contains(out, "in_progress") {
+			t.Errorf("expected refusal naming allowed targets, got: %s", out)
+		}
+		out = bdCloseFail(t, bd, dir, id)
+		if !strings.Contains(out, "status transition not allowed") {
+			t.Errorf("expected close refusal, got: %s", out)
+		}
+	})
+
+	t.Run("guard_assigned", func(t *testing.T) {
+		bdCommand(t, bd, dir, "update", id, "--status", "in_progress")
+		out := bdUpdateFail(t, bd, dir, id, "--status", "in_review")
+		if !strings.Contains(out, "no assignee") {
+			t.Errorf("expected assigned guard failure, got: %s", out)
+		}
+		bdCommand(t, bd, dir, "update", id, "--assignee", "Test", "--status", "in_review")
+		if got := bdShow(t, bd, dir, id); got.Status != types.Status("in_review") {
+			t.Errorf("status = %s, want in_review", got.Status)
+		}
+	})
+
+	t.Run("guard_no_blockers", func(t *testing.T) {
+		blocker := bdCreateSilent(t, bd, dir, "Blocker")
+		bdCommand(t, bd, dir, "dep", "add", id, blocker)
+		out := bdCloseFail(t, bd, dir, id)
+		if !strings.Contains(out, blocker) {
+			t.Errorf("expected close refusal naming %s, got: %s", blocker, out)
+		}
+		bdCommand(t, bd, dir, "update", blocker, "--status", "deferred")
+		bdCommand(t, bd, dir, "update", blocker, "--status", "open")
+		bdCommand(t, bd, dir, "close", blocker, "--force")
+		bdCommand(t, bd, dir, "close", id)
+		if got := bdShow(t, bd, dir, id); got.Status != types.StatusClosed {
+			t.Errorf("status = %s, want closed", got.Status)
+		}
+	})
+
+	t.Run("statuses_lists_transitions", func(t *testing.T) {
+		var result struct {
+			Transitions []types.StatusTransition `json:"transitions"`
+		}
+		out := bdCommand(t, bd, dir, "statuses", "--json")
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if len(result.Transitions) != 5 {
+			t.Errorf("transitions = %+v", result.Transitions)
+		}
+	})
+}
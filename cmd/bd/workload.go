@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage/domain"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var workloadCmd = &cobra.Command{
+	Use:     "workload",
+	GroupID: "views",
+	Short:   "Show open work and estimates per person",
+	Long: `Show, for each person, how many issues they have open and in progress
+and the total estimate of everything not yet closed.
+
+An issue counts for its assignee and for each co-assignee (see 'bd assign').
+Issues nobody is assigned to are listed as (unassigned) unless --assignee
+narrows the report. "me" resolves to the identity config, then git
+user.email.
+
+Examples:
+  bd workload
+  bd workload --team infra
+  bd workload --assignee me --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("workload is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("workload")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		assignee, _ := cmd.Flags().GetString("assignee")
+		team, _ := cmd.Flags().GetString("team")
+
+		ctx := rootCtx
+		filter := types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}}
+		infraSet := store.GetInfraTypes(ctx)
+		infraTypes := domain.DefaultInfraTypes()
+		if len(infraSet) > 0 {
+			infraTypes = infraTypes[:0]
+			for t := range infraSet {
+				infraTypes = append(infraTypes, t)
+			}
+		}
+		for _, t := range infraTypes {
+			filter.ExcludeTypes = append(filter.ExcludeTypes, types.IssueType(t))
+		}
+		notTemplate, persistent := false, false
+		filter.IsTemplate = &notTemplate
+		filter.Ephemeral = &persistent
+		if assignee != "" {
+			assignee = resolveAssignee(assignee)
+			filter.Assignee = &assignee
+		}
+		if team != "" {
+			filter.Team = &team
+		}
+
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to search issues: %v", err)
+		}
+		hydrateAssignments(ctx, store, issues)
+		rows := buildWorkload(issues, assignee)
+
+		if jsonOutput {
+			if rows == nil {
+				rows = []*workloadRow{}
+			}
+			return outputJSON(rows)
+		}
+		if len(rows) == 0 {
+			fmt.Println("No open work")
+			return nil
+		}
+		width := len("ASSIGNEE")
+		for _, r := range rows {
+			width = max(width, len(r.Assignee))
+		}
+		fmt.Println(ui.RenderBold(fmt.Sprintf("%-*s  %6s  %11s  %6s  %9s", width, "ASSIGNEE", "OPEN", "IN PROGRESS", "TOTAL", "ESTIMATE")))
+		for _, r := range rows {
+			estimate := "-"
+			if r.EstimatedMinutes > 0 {
+				estimate = fmt.Sprintf("%d min", r.EstimatedMinutes)
+			}
+			name := fmt.Sprintf("%-*s", width, r.Assignee)
+			if r.Assignee == dashboardUnassigned {
+				name = ui.RenderMuted(name)
+			}
+			fmt.Printf("%s  %6d  %11d  %6d  %9s\n", name, r.Open, r.InProgress, r.Total, estimate)
+		}
+		return nil
+	},
+}
+
+// workloadRow is one person's line in `bd workload`.
+type workloadRow struct {
+	Assignee         string `json:"assignee"`
+	Open             int    `json:"open"`
+	InProgress       int    `json:"in_progress"`
+	Total            int    `json:"total"` // every status but closed
+	EstimatedMinutes int    `json:"estimated_minutes"`
+}
+
+// buildWorkload tallies issues with their co-assignees loaded. An issue
+// counts once for each distinct person on it. When only is set, the other
+// people's rows (and the unassigned row) are left out.
+func buildWorkload(issues []*types.Issue, only string) []*workloadRow {
+	byName := make(map[string]*workloadRow)
+	for _, issue := range issues {
+		if issue.Status == types.StatusClosed {
+			continue
+		}
+		people := make([]string, 0, 1+len(issue.Assignees))
+		seen := make(map[string]bool)
+		for _, name := range append([]string{issue.Assignee}, issue.Assignees...) {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				people = append(people, name)
+			}
+		}
+		if len(people) == 0 {
+			people = append(people, dashboardUnassigned)
+		}
+		for _, name := range people {
+			if only != "" && name != only {
+				continue
+			}
+			r := byName[name]
+			if r == nil {
+				r = &workloadRow{Assignee: name}
+				byName[name] = r
+			}
+			r.Total++
+			switch issue.Status {
+			case types.StatusOpen:
+				r.Open++
+			case types.StatusInProgress:
+				r.InProgress++
+			}
+			if issue.EstimatedMinutes != nil {
+				r.EstimatedMinutes += *issue.EstimatedMinutes
+			}
+		}
+	}
+
+	var rows []*workloadRow
+	for _, r := range byName {
+		rows = append(rows, r)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if (a.Assignee == dashboardUnassigned) != (b.Assignee == dashboardUnassigned) {
+			return b.Assignee == dashboardUnassigned
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Assignee < b.Assignee
+	})
+	return rows
+}
+
+func init() {
+	workloadCmd.Flags().StringP("assignee", "a", "", "Only this person (\"me\" for yourself)")
+	workloadCmd.Flags().String("team", "", "Only issues owned by this team")
+	rootCmd.AddCommand(workloadCmd)
+}
@@ -0,0 +1,104 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedAssignAndWorkload(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "wl")
+	shared := bdCreateSilent(t, bd, dir, "Shared work", "--estimate", "30")
+	solo := bdCreateSilent(t, bd, dir, "Solo work", "--estimate", "45")
+	bdCreateSilent(t, bd, dir, "Nobody's work")
+
+	// bdAsMe runs bd with "me" resolving to me@example.com.
+	bdAsMe := func(t *testing.T, args ...string) string {
+		t.Helper()
+		cmd := exec.Command(bd, args...)
+		cmd.Dir = dir
+		cmd.Env = append(bdEnv(dir), "BEADS_IDENTITY=me@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("bd %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+
+	bdCommand(t, bd, dir, "assign", shared, "alice", "bob", "--team", "infra")
+	bdAsMe(t, "assign", solo, "me")
+	bdCommand(t, bd, dir, "update", solo, "--status", "in_progress")
+
+	t.Run("show", func(t *testing.T) {
+		issue := bdShow(t, bd, dir, shared)
+		if issue.Assignee != "alice" || !reflect.DeepEqual(issue.Assignees, []string{"bob"}) || issue.Team != "infra" {
+			t.Errorf("assignment = %q %v %q", issue.Assignee, issue.Assignees, issue.Team)
+		}
+		if got := bdShow(t, bd, dir, solo).Assignee; got != "me@example.com" {
+			t.Errorf("assign me = %q", got)
+		}
+	})
+
+	t.Run("filters_match_co_assignees", func(t *testing.T) {
+		out := bdCommand(t, bd, dir, "list", "--assignee", "bob", "--json")
+		if !strings.Contains(out, shared) || strings.Contains(out, solo) {
+			t.Errorf("list --assignee bob = %s", out)
+		}
+		out = bdAsMe(t, "list", "--assignee", "me", "--json")
+		if !strings.Contains(out, solo) || strings.Contains(out, shared) {
+			t.Errorf("list --assignee me = %s", out)
+		}
+		out = bdAsMe(t, "query", "assignee=me", "--json")
+		if !strings.Contains(out, solo) || strings.Contains(out, shared) {
+			t.Errorf("query assignee=me = %s", out)
+		}
+	})
+
+	t.Run("workload", func(t *testing.T) {
+		var rows []workloadRow
+		out := bdCommand(t, bd, dir, "workload", "--json")
+		if err := json.Unmarshal([]byte(out), &rows); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		want := []workloadRow{
+			{Assignee: "alice", Open: 1, Total: 1, EstimatedMinutes: 30},
+			{Assignee: "bob", Open: 1, Total: 1, EstimatedMinutes: 30},
+			{Assignee: "me@example.com", InProgress: 1, Total: 1, EstimatedMinutes: 45},
+			{Assignee: dashboardUnassigned, Open: 1, Total: 1},
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Errorf("workload = %+v\nwant %+v", rows, want)
+		}
+
+		out = bdCommand(t, bd, dir, "workload", "--team", "infra", "--json")
+		if err := json.Unmarshal([]byte(out), &rows); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		if len(rows) != 2 || rows[0].Assignee != "alice" || rows[1].Assignee != "bob" {
+			t.Errorf("workload --team infra = %+v", rows)
+		}
+	})
+
+	t.Run("unassign", func(t *testing.T) {
+		bdCommand(t, bd, dir, "unassign", shared, "alice", "--team")
+		issue := bdShow(t, bd, dir, shared)
+		if issue.Assignee != "" || !reflect.DeepEqual(issue.Assignees, []string{"bob"}) || issue.Team != "" {
+			t.Errorf("after unassign alice --team = %q %v %q", issue.Assignee, issue.Assignees, issue.Team)
+		}
+		bdCommand(t, bd, dir, "unassign", shared)
+		if issue := bdShow(t, bd, dir, shared); issue.Assignee != "" || len(issue.Assignees) != 0 {
+			t.Errorf("after unassign = %q %v", issue.Assignee, issue.Assignees)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildWorkload(t *testing.T) {
+	thirty, sixty := 30, 60
+	issues := []*types.Issue{
+		{ID: "wl-1", Status: types.StatusOpen, Assignee: "alice", Assignees: []string{"bob"}, EstimatedMinutes: &thirty},
+		{ID: "wl-2", Status: types.StatusInProgress, Assignee: "alice", EstimatedMinutes: &sixty},
+		// Listing the assignee again as a co-assignee does not double count.
+		{ID: "wl-3", Status: types.StatusBlocked, Assignee: "bob", Assignees: []string{"bob"}},
+		{ID: "wl-4", Status: types.StatusOpen},
+		{ID: "wl-5", Status: types.StatusClosed, Assignee: "alice"},
+	}
+
+	want := []workloadRow{
+		{Assignee: "alice", Open: 1, InProgress: 1, Total: 2, EstimatedMinutes: 90},
+		{Assignee: "bob", Open: 1, Total: 2, EstimatedMinutes: 30},
+		{Assignee: dashboardUnassigned, Open: 1, Total: 1},
+	}
+	var got []workloadRow
+	for _, r := range buildWorkload(issues, "") {
+		got = append(got, *r)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildWorkload = %+v\nwant %+v", got, want)
+	}
+
+	only := buildWorkload(issues, "bob")
+	if len(only) != 1 || only[0].Assignee != "bob" || only[0].Total != 2 {
+		t.Errorf("buildWorkload(bob) = %+v", only)
+	}
+}
@@ -0,0 +1,103 @@
+// Package attachments stores issue attachment blobs content-addressed under
+// .beads/attachments.
+//
+// A blob lives at attachments/<sha[:2]>/<sha>, where sha is the lower-case
+// hex SHA256 of its content. Storing the same content twice is a no-op, so
+// blobs can be shared by any number of issues and merged across clones with
+// plain git. Metadata (which issue references which blob, under what name)
+// lives in the attachments table; this package only handles the bytes.
+package attachments
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// DirName is the directory under .beads that holds attachment blobs.
+const DirName = "attachments"
+
+// ErrNotFound is returned when a blob is not present in the local store,
+// e.g. because the commit that added it has not been pulled yet.
+var ErrNotFound = errors.New("attachment blob not found")
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Path returns the location of the blob sha under beadsDir.
+func Path(beadsDir, sha string) (string, error) {
+	if !shaPattern.MatchString(sha) {
+		return "", fmt.Errorf("invalid attachment sha256 %q", sha)
+	}
+	return filepath.Join(beadsDir, DirName, sha[:2], sha), nil
+}
+
+// Put copies r into the blob store under beadsDir and returns the content's
+// SHA256 and size. The content is hashed while it is written to a temp file,
+// which is then renamed into place, so a partially written blob is never
+// visible under its final name.
+func Put(beadsDir string, r io.Reader) (sha string, size int64, err error) {
+	root := filepath.Join(beadsDir, DirName)
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return "", 0, fmt.Errorf("create attachments dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(root, ".incoming-")
+	if err != nil {
+		return "", 0, fmt.Errorf("create temp blob: %w", err)
+	}
+	defer func() {
+		if tmp != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	h := sha256.New()
+	size, err = io.Copy(io.MultiWriter(tmp, h), r)
+	if err != nil {
+		return "", 0, fmt.Errorf("write blob: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return "", 0, fmt.Errorf("sync blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", 0, fmt.Errorf("close blob: %w", err)
+	}
+	sha = hex.EncodeToString(h.Sum(nil))
+
+	dst, _ := Path(beadsDir, sha)
+	if _, err := os.Stat(dst); err == nil {
+		// Already stored: content addressing makes the copies identical.
+		_ = os.Remove(tmp.Name())
+		tmp = nil
+		return sha, size, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", 0, fmt.Errorf("create attachments dir: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", 0, fmt.Errorf("chmod blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return "", 0, fmt.Errorf("store blob: %w", err)
+	}
+	tmp = nil
+	return sha, size, nil
+}
+
+// Open opens the blob sha under beadsDir for reading.
+func Open(beadsDir, sha string) (*os.File, error) {
+	p, err := Path(beadsDir, sha)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p) //nolint:gosec // G304: path is derived from a validated hex digest
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, sha)
+	}
+	return f, err
+}
@@ -0,0 +1,58 @@
+package attachments
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPutAndOpen(t *testing.T) {
+	dir := t.TempDir()
+
+	sha, size, err := Put(dir, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	const want = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if sha != want || size != 5 {
+		t.Fatalf("Put = %s, %d; want %s, 5", sha, size, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, DirName, "2c", want)); err != nil {
+		t.Fatalf("blob not stored content-addressed: %v", err)
+	}
+
+	// Storing identical content again is a no-op.
+	if sha2, _, err := Put(dir, strings.NewReader("hello")); err != nil || sha2 != sha {
+		t.Fatalf("second Put = %s, %v", sha2, err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, DirName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the blob shard dir, got %d entries (temp file left behind?)", len(entries))
+	}
+
+	f, err := Open(dir, sha)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("read back %q, %v", data, err)
+	}
+}
+
+func TestOpenMissingAndInvalid(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Open(dir, strings.Repeat("a", 64)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open missing blob: got %v, want ErrNotFound", err)
+	}
+	if _, err := Path(dir, "../../etc/passwd"); err == nil {
+		t.Error("Path accepted a non-digest")
+	}
+}
@@ -185,6 +185,9 @@ func Initialize() error {
 	// Controls whether beads should automatically create Dolt commits after write commands.
 	// Values: off | on
 	v.SetDefault("dolt.auto-commit", "on")
+	// In batch mode, commit the accumulated working set on the first write at
+	// least this long after the last Dolt commit (e.g. "5m"). 0 disables.
+	v.SetDefault("dolt.auto-commit-interval", "0")
 
 	// Routing configuration defaults
 	v.SetDefault("routing.mode", "")
@@ -265,6 +268,25 @@ func Initialize() error {
 	v.SetDefault("import.auto", true)
 	v.SetDefault("import.path", "issues.jsonl") // relative to .beads/; canonical import name
 
+	// Merge auto-resolution: when enabled, bd hooks install registers the
+	// bd merge-file driver for the JSONL export, and post-merge regenerates an
+	// export left with conflict markers from Dolt (when a Dolt remote is set).
+	v.SetDefault("merge.auto_resolve", false)
+	v.SetDefault("merge.strategy", "union") // bd merge-file field collisions: union | ours | theirs
+
+	// Per-repo git hook behaviors, consulted by 'bd hooks run' at dispatch
+	// time so a behavior can be switched off without editing hook scripts.
+	// Keys are listed by 'bd hooks config'.
+	v.SetDefault("hooks.pre-commit.export", true)
+	v.SetDefault("hooks.post-merge.conflict-check", true)
+	v.SetDefault("hooks.post-merge.import", true)
+	v.SetDefault("hooks.pre-push.check-export", true)
+	v.SetDefault("hooks.post-checkout.conflict-check", true)
+	v.SetDefault("hooks.post-checkout.import", true)
+	v.SetDefault("hooks.post-commit.link-commits", true)
+	v.SetDefault("hooks.post-commit.close-issues", true)
+	v.SetDefault("hooks.prepare-commit-msg.trailers", true)
+
 	// AI configuration defaults
 	v.SetDefault("ai.model", "claude-haiku-4-5-20251001")
 
@@ -953,6 +975,32 @@ func MetadataSchemaFields() map[string]interface{} {
 	return nil
 }
 
+// NotifyWebhooks returns the raw notify.webhooks definitions from config.
+// Returns nil if config is not initialized or no webhooks are defined.
+// Each entry maps webhook name → map of settings (url, format, events, ...).
+func NotifyWebhooks() map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	if m, ok := v.Get("notify.webhooks").(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
+// TriageRules returns the raw triage.rules definitions from config.
+// Returns nil if config is not initialized or no rules are defined.
+// Each entry maps rule name → map of conditions and actions.
+func TriageRules() map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	if m, ok := v.Get("triage.rules").(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
 // DefaultAgentsFile is the default filename for agent instructions.
 const DefaultAgentsFile = "AGENTS.md"
 
@@ -65,6 +65,10 @@ var YamlOnlyKeys = map[string]bool{
 	// Hierarchy settings (GH#995)
 	"hierarchy.max-depth": true,
 
+	// Counter-mode ID block reserved for this clone or agent, e.g. "5000-5999".
+	// Per-machine: set it in config.local.yaml or BD_ID_COUNTER_RANGE.
+	"id.counter-range": true,
+
 	// Backup settings (must be in yaml so GetValueSource can detect overrides)
 	"backup.enabled":  true,
 	"backup.interval": true,
@@ -101,7 +105,7 @@ func IsYamlOnlyKey(key string) bool {
 	}
 
 	// Check prefix matches for nested keys
-	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "hierarchy.", "ai.", "backup.", "export.", "dolt.", "federation.", "metrics.", "list.", "audit."}
+	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "hierarchy.", "ai.", "backup.", "export.", "dolt.", "federation.", "metrics.", "list.", "audit.", "merge.", "hooks."}
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(key, prefix) {
 			return true
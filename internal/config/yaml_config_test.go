@@ -47,6 +47,10 @@ func TestIsYamlOnlyKey(t *testing.T) {
 		{"import.path", true},
 		{"import.orphan_handling", false},
 
+		// Git hook behavior toggles
+		{"hooks.pre-commit.export", true},
+		{"hooks.post-checkout.import", true},
+
 		// Secret keys (stored in yaml to avoid leaking via Dolt push)
 		{"github.token", true},
 		{"linear.api_key", true},
@@ -26,10 +26,16 @@ const (
 
 // Runner handles hook execution
 type Runner struct {
-	hooksDir string
-	timeout  time.Duration
+	hooksDir  string
+	timeout   time.Duration
+	listeners []Listener
 }
 
+// Listener is an in-process observer of hook events. Listeners run
+// synchronously for every event, whether or not a hook script exists,
+// so they must be quick (e.g. append to a queue) and must not fail.
+type Listener func(event string, issue *types.Issue)
+
 // NewRunner creates a new hook runner.
 // hooksDir is typically .beads/hooks/ relative to workspace root.
 func NewRunner(hooksDir string) *Runner {
@@ -44,6 +50,17 @@ func NewRunnerFromWorkspace(workspaceRoot string) *Runner {
 	return NewRunner(filepath.Join(workspaceRoot, ".beads", "hooks"))
 }
 
+// AddListener registers an in-process listener for hook events.
+func (r *Runner) AddListener(l Listener) {
+	r.listeners = append(r.listeners, l)
+}
+
+func (r *Runner) notifyListeners(event string, issue *types.Issue) {
+	for _, l := range r.listeners {
+		l(event, issue)
+	}
+}
+
 // Run executes a hook if it exists.
 // Runs asynchronously - returns immediately, hook runs in background.
 func (r *Runner) Run(event string, issue *types.Issue) {
@@ -51,6 +68,7 @@ func (r *Runner) Run(event string, issue *types.Issue) {
 	if hookName == "" {
 		return
 	}
+	r.notifyListeners(event, issue)
 
 	hookPath := filepath.Join(r.hooksDir, hookName)
 
@@ -78,6 +96,7 @@ func (r *Runner) RunSync(event string, issue *types.Issue) error {
 	if hookName == "" {
 		return nil
 	}
+	r.notifyListeners(event, issue)
 
 	hookPath := filepath.Join(r.hooksDir, hookName)
 
@@ -128,6 +128,25 @@ func TestRunSync_NoHook(t *testing.T) {
 	}
 }
 
+func TestListener_FiresWithoutHookScript(t *testing.T) {
+	runner := NewRunner(t.TempDir())
+	var got []string
+	runner.AddListener(func(event string, issue *types.Issue) {
+		got = append(got, event+":"+issue.ID)
+	})
+
+	issue := &types.Issue{ID: "bd-test", Title: "Test"}
+	runner.Run(EventCreate, issue)
+	if err := runner.RunSync(EventClose, issue); err != nil {
+		t.Fatalf("RunSync: %v", err)
+	}
+	runner.Run("unknown", issue)
+
+	if strings.Join(got, ",") != "create:bd-test,close:bd-test" {
+		t.Errorf("listener saw %v, want create then close", got)
+	}
+}
+
 func TestRunSync_NotExecutable(t *testing.T) {
 	tmpDir := t.TempDir()
 	hookPath := filepath.Join(tmpDir, HookOnCreate)
@@ -0,0 +1,296 @@
+package importers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+func init() {
+	Register(csvImporter{})
+}
+
+// CSVFields are the issue fields 'bd export --format csv' writes, in column
+// order, and the names the csv importer maps columns onto.
+var CSVFields = []string{
+	"id", "title", "description", "design", "acceptance_criteria", "notes",
+	"status", "priority", "issue_type", "assignee", "owner", "labels",
+	"parent", "depends_on", "external_ref", "due_at",
+	"created_at", "updated_at", "closed_at",
+}
+
+// csvFieldAliases lets header inference recognize column names common in
+// spreadsheets and other trackers' exports.
+var csvFieldAliases = map[string]string{
+	"summary":      "title",
+	"name":         "title",
+	"body":         "description",
+	"state":        "status",
+	"type":         "issue_type",
+	"tags":         "labels",
+	"blocked_by":   "depends_on",
+	"dependencies": "depends_on",
+	"due":          "due_at",
+	"due_date":     "due_at",
+	"created":      "created_at",
+	"updated":      "updated_at",
+	"closed":       "closed_at",
+}
+
+// csvImporter reads RFC 4180 CSV with a header row. Rows reference each
+// other by beads ID (parent, depends_on), so records carry no Key and their
+// dependencies are set on the issue directly.
+type csvImporter struct{}
+
+func (csvImporter) Name() string { return "csv" }
+
+func (csvImporter) Description() string {
+	return "CSV with a header row (bd export --format csv, or any spreadsheet)"
+}
+
+func (csvImporter) Parse(_ context.Context, r io.Reader, opts Options) (*Batch, error) {
+	fieldMap, err := ParseCSVFieldMap(opts.ColumnMap)
+	if err != nil {
+		return nil, err
+	}
+	issues, ignored, rowErrors, err := ParseIssuesCSV(r, fieldMap, opts.CustomStatuses, opts.CustomTypes)
+	if err != nil {
+		return nil, err
+	}
+	batch := &Batch{RowErrors: rowErrors}
+	for _, issue := range issues {
+		batch.Records = append(batch.Records, Record{Issue: issue})
+	}
+	if len(ignored) > 0 {
+		batch.Warnings = append(batch.Warnings, fmt.Sprintf("Ignoring unmapped column(s): %s", strings.Join(ignored, ", ")))
+	}
+	return batch, nil
+}
+
+// splitList splits a comma-separated cell (labels, depends_on).
+func splitList(cell string) []string {
+	var out []string
+	for _, v := range strings.Split(cell, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// ParseCSVFieldMap parses a --map value such as "title=Summary,priority=Prio"
+// into field → column.
+func ParseCSVFieldMap(spec string) (map[string]string, error) {
+	known := make(map[string]bool, len(CSVFields))
+	for _, f := range CSVFields {
+		known[f] = true
+	}
+	fieldMap := make(map[string]string)
+	for _, pair := range splitList(spec) {
+		field, column, ok := strings.Cut(pair, "=")
+		field, column = strings.TrimSpace(field), strings.TrimSpace(column)
+		if !ok || field == "" || column == "" {
+			return nil, fmt.Errorf("invalid --map entry %q (want field=Column)", pair)
+		}
+		if !known[field] {
+			return nil, fmt.Errorf("unknown field %q in --map (valid: %s)", field, strings.Join(CSVFields, ", "))
+		}
+		fieldMap[field] = column
+	}
+	return fieldMap, nil
+}
+
+// csvColumnFields resolves each header column to an issue field: columns
+// named in fieldMap first, then columns whose normalized name is a field or
+// a known alias. Unmapped columns resolve to "" and are returned in ignored.
+func csvColumnFields(header []string, fieldMap map[string]string) (fields, ignored []string, err error) {
+	known := make(map[string]bool, len(CSVFields))
+	for _, f := range CSVFields {
+		known[f] = true
+	}
+	byColumn := make(map[string]string, len(fieldMap))
+	for field, column := range fieldMap {
+		byColumn[column] = field
+	}
+
+	fields = make([]string, len(header))
+	assigned := make(map[string]bool)
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if field, ok := byColumn[column]; ok {
+			fields[i], assigned[field] = field, true
+		}
+	}
+	for field, column := range fieldMap {
+		if !assigned[field] {
+			return nil, nil, fmt.Errorf("--map %s=%s: no column named %q", field, column, column)
+		}
+	}
+	for i, column := range header {
+		if fields[i] != "" {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(column))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+		if alias, ok := csvFieldAliases[name]; ok {
+			name = alias
+		}
+		if known[name] && !assigned[name] {
+			fields[i], assigned[name] = name, true
+			continue
+		}
+		ignored = append(ignored, column)
+	}
+	if !assigned["title"] {
+		return nil, nil, fmt.Errorf("no title column found (name one title, or use --map title=<Column>)")
+	}
+	return fields, ignored, nil
+}
+
+// ParseIssuesCSV reads issues from CSV with a header row. A row that fails
+// to parse or validate is skipped and reported in rowErrors ("line N: ...")
+// so one bad row does not abort the import; err is reserved for problems
+// with the file as a whole.
+func ParseIssuesCSV(r io.Reader, fieldMap map[string]string, customStatuses, customTypes []string) (issues []*types.Issue, ignored, rowErrors []string, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	fields, ignored, err := csvColumnFields(header, fieldMap)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	now := time.Now()
+	for {
+		row, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(readErr, &parseErr) {
+			rowErrors = append(rowErrors, fmt.Sprintf("line %d: %v", parseErr.StartLine, parseErr.Err))
+			continue
+		}
+		if readErr != nil {
+			return nil, nil, nil, fmt.Errorf("failed to read CSV: %w", readErr)
+		}
+		line, _ := reader.FieldPos(0)
+		issue, rowErr := csvRowIssue(fields, row, now, customStatuses, customTypes)
+		if rowErr != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("line %d: %v", line, rowErr))
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues, ignored, rowErrors, nil
+}
+
+// csvRowIssue builds and validates one issue from a CSV row.
+func csvRowIssue(fields, row []string, now time.Time, customStatuses, customTypes []string) (*types.Issue, error) {
+	issue := &types.Issue{Priority: 2}
+	var parent string
+	var dependsOn []string
+	for i, value := range row {
+		if i >= len(fields) || fields[i] == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		var err error
+		switch fields[i] {
+		case "id":
+			issue.ID = value
+		case "title":
+			issue.Title = value
+		case "description":
+			issue.Description = value
+		case "design":
+			issue.Design = value
+		case "acceptance_criteria":
+			issue.AcceptanceCriteria = value
+		case "notes":
+			issue.Notes = value
+		case "status":
+			issue.Status = types.Status(strings.ToLower(value))
+		case "priority":
+			issue.Priority, err = validation.ValidatePriority(value)
+		case "issue_type":
+			issue.IssueType = types.IssueType(strings.ToLower(value)).Normalize()
+		case "assignee":
+			issue.Assignee = value
+		case "owner":
+			issue.Owner = value
+		case "labels":
+			issue.Labels = splitList(value)
+		case "parent":
+			parent = value
+		case "depends_on":
+			dependsOn = splitList(value)
+		case "external_ref":
+			ref := value
+			issue.ExternalRef = &ref
+		case "due_at":
+			var t time.Time
+			if t, err = timeparsing.ParseRelativeTime(value, now); err == nil {
+				issue.DueAt = &t
+			}
+		case "created_at":
+			issue.CreatedAt, err = timeparsing.ParseRelativeTime(value, now)
+		case "updated_at":
+			issue.UpdatedAt, err = timeparsing.ParseRelativeTime(value, now)
+		case "closed_at":
+			var t time.Time
+			if t, err = timeparsing.ParseRelativeTime(value, now); err == nil {
+				issue.ClosedAt = &t
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fields[i], err)
+		}
+	}
+	issue.SetDefaults()
+	deriveClosedAt(issue, now)
+	if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
+		return nil, err
+	}
+
+	if parent != "" {
+		issue.Dependencies = append(issue.Dependencies, &types.Dependency{IssueID: issue.ID, DependsOnID: parent, Type: types.DepParentChild, CreatedAt: now})
+	}
+	for _, id := range dependsOn {
+		issue.Dependencies = append(issue.Dependencies, &types.Dependency{IssueID: issue.ID, DependsOnID: id, Type: types.DepBlocks, CreatedAt: now})
+	}
+	return issue, nil
+}
+
+// deriveClosedAt makes closed_at agree with the status. Exports rarely
+// carry closed_at, so a closed issue without one is stamped with its
+// updated_at (or now) rather than rejected, and a stray closed_at on an
+// open issue is dropped.
+func deriveClosedAt(issue *types.Issue, now time.Time) {
+	if issue.Status == types.StatusClosed && issue.ClosedAt == nil {
+		closedAt := issue.UpdatedAt
+		if closedAt.IsZero() {
+			closedAt = now
+		}
+		issue.ClosedAt = &closedAt
+	} else if issue.Status != types.StatusClosed {
+		issue.ClosedAt = nil
+	}
+}
@@ -0,0 +1,62 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseIssuesCSVMappingAndRowErrors(t *testing.T) {
+	data := "Summary,Prio,Type,Tags,Sprint\n" +
+		"First,P1,feature,\"ui, web\",7\n" +
+		"Bad priority,urgent,task,,7\n" +
+		"\"Broken \"quote,2,task,,7\n" +
+		",2,task,,7\n" +
+		"Last,3,Bug,,8\n"
+
+	fieldMap, err := ParseCSVFieldMap("priority=Prio")
+	if err != nil {
+		t.Fatalf("ParseCSVFieldMap: %v", err)
+	}
+	issues, ignored, rowErrors, err := ParseIssuesCSV(strings.NewReader(data), fieldMap, nil, nil)
+	if err != nil {
+		t.Fatalf("ParseIssuesCSV: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Title != "First" || issues[1].Title != "Last" {
+		t.Fatalf("issues = %+v", issues)
+	}
+	if issues[0].Priority != 1 || issues[0].IssueType != types.TypeFeature || strings.Join(issues[0].Labels, ",") != "ui,web" {
+		t.Errorf("first: priority %d type %s labels %v", issues[0].Priority, issues[0].IssueType, issues[0].Labels)
+	}
+	if issues[1].IssueType != types.TypeBug {
+		t.Errorf("last: type %s, want bug", issues[1].IssueType)
+	}
+	if strings.Join(ignored, ",") != "Sprint" {
+		t.Errorf("ignored = %v, want [Sprint]", ignored)
+	}
+	if len(rowErrors) != 3 {
+		t.Fatalf("row errors = %v, want 3", rowErrors)
+	}
+	for i, want := range []string{"line 3: priority", "line 4:", "line 5: title is required"} {
+		if !strings.HasPrefix(rowErrors[i], want) {
+			t.Errorf("rowErrors[%d] = %q, want prefix %q", i, rowErrors[i], want)
+		}
+	}
+}
+
+func TestCSVFieldMapErrors(t *testing.T) {
+	if _, err := ParseCSVFieldMap("titel=Summary"); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+	if _, err := ParseCSVFieldMap("title"); err == nil {
+		t.Error("expected an error for an entry without '='")
+	}
+	fieldMap, _ := ParseCSVFieldMap("title=Headline")
+	if _, _, err := csvColumnFields([]string{"Summary"}, fieldMap); err == nil {
+		t.Error("expected an error when a mapped column is missing")
+	}
+	if _, _, err := csvColumnFields([]string{"Foo", "Bar"}, nil); err == nil {
+		t.Error("expected an error when no title column can be inferred")
+	}
+}
@@ -0,0 +1,162 @@
+// Package importers converts other tools' export files into beads issues.
+//
+// Each importer parses one format into a Batch, the common intermediate
+// form: issues keyed by their source-system key plus dependency edges
+// between those keys. Batch.Resolve then assigns beads IDs and attaches the
+// edges, so every format shares the same upsert and dry-run path in
+// 'bd import --format <name>'.
+//
+// Importers register themselves by name from init(), like tracker adapters.
+package importers
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Importer parses one export format.
+type Importer interface {
+	// Name is the --format value that selects this importer.
+	Name() string
+	// Description is a one-line summary for help text.
+	Description() string
+	// Parse reads an export. Problems with individual rows belong in
+	// Batch.RowErrors; an error return means the file as a whole is unusable.
+	Parse(ctx context.Context, r io.Reader, opts Options) (*Batch, error)
+}
+
+// Options carries the import context shared by all importers.
+type Options struct {
+	// Store supplies tracker config such as jira.type_map.* and
+	// linear.state_map.*. It may be nil, in which case importers use their
+	// default mappings.
+	Store storage.Storage
+	// CustomStatuses and CustomTypes are accepted during validation in
+	// addition to the built-in ones.
+	CustomStatuses []string
+	CustomTypes    []string
+	// ColumnMap is a "field=Column,..." override for column-based formats.
+	ColumnMap string
+}
+
+// Record is one imported issue. Key is the issue's identity in the source
+// system (a Jira key, a Linear identifier, a Shortcut story id) and is what
+// Batch.Dependencies refer to; it may be empty for sources without one.
+// Issue.ID is set only when the source carries beads IDs.
+type Record struct {
+	Key   string
+	Issue *types.Issue
+}
+
+// Dependency is an edge between two records by Key: From depends on To.
+type Dependency struct {
+	FromKey string
+	ToKey   string
+	Type    types.DependencyType
+}
+
+// Batch is the common intermediate form every importer produces.
+type Batch struct {
+	Records      []Record
+	Dependencies []Dependency
+	// RowErrors lists records skipped as invalid, e.g. "line 4: title is required".
+	RowErrors []string
+	// Warnings are non-fatal notes for the user, e.g. ignored columns.
+	Warnings []string
+}
+
+// Stats summarizes a resolved batch.
+type Stats struct {
+	New          int `json:"new"`
+	Existing     int `json:"existing"`
+	Dependencies int `json:"dependencies"`
+}
+
+// Resolve assigns beads IDs and returns the issues ready for import.
+// existingID reports the ID of the local issue a record corresponds to
+// (matched by ID or external_ref), or "" when there is none; such records
+// keep that ID so re-importing an export updates the earlier import.
+// Other records without an ID get newID(issue). Dependencies whose ends are both
+// in the batch are then attached to the dependent issue.
+func (b *Batch) Resolve(existingID func(*types.Issue) string, newID func(*types.Issue) string) ([]*types.Issue, Stats) {
+	var stats Stats
+	issues := make([]*types.Issue, 0, len(b.Records))
+	byKey := make(map[string]*types.Issue, len(b.Records))
+	for _, rec := range b.Records {
+		issue := rec.Issue
+		if id := existingID(issue); id != "" {
+			issue.ID = id
+			stats.Existing++
+		} else {
+			if issue.ID == "" {
+				issue.ID = newID(issue)
+			}
+			stats.New++
+		}
+		issue.SetDefaults()
+		for _, dep := range issue.Dependencies {
+			if dep.IssueID == "" {
+				dep.IssueID = issue.ID
+			}
+		}
+		if rec.Key != "" {
+			byKey[rec.Key] = issue
+		}
+		issues = append(issues, issue)
+	}
+
+	now := time.Now().UTC()
+	for _, dep := range b.Dependencies {
+		from, to := byKey[dep.FromKey], byKey[dep.ToKey]
+		if from == nil || to == nil || from == to {
+			continue
+		}
+		from.Dependencies = append(from.Dependencies, &types.Dependency{
+			IssueID:     from.ID,
+			DependsOnID: to.ID,
+			Type:        dep.Type,
+			CreatedAt:   now,
+		})
+	}
+	for _, issue := range issues {
+		stats.Dependencies += len(issue.Dependencies)
+	}
+	return issues, stats
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Importer)
+)
+
+// Register adds an importer to the registry under its Name.
+func Register(imp Importer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[imp.Name()] = imp
+}
+
+// Get returns the named importer, or nil if none is registered.
+func Get(name string) Importer {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registry[name]
+}
+
+// List returns the names of all registered importers, sorted.
+func List() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,78 @@
+package importers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRegistry(t *testing.T) {
+	for _, name := range []string{"csv", "jira", "linear", "shortcut"} {
+		imp := Get(name)
+		if imp == nil {
+			t.Errorf("importer %q not registered", name)
+			continue
+		}
+		if imp.Name() != name || imp.Description() == "" {
+			t.Errorf("importer %q: Name() = %q, Description() = %q", name, imp.Name(), imp.Description())
+		}
+	}
+	if Get("nope") != nil {
+		t.Error("Get returned an importer for an unknown name")
+	}
+	names := List()
+	for i := 1; i < len(names); i++ {
+		if names[i] < names[i-1] {
+			t.Errorf("List() not sorted: %v", names)
+		}
+	}
+}
+
+func TestBatchResolve(t *testing.T) {
+	existingRef := "https://example.com/A-1"
+	batch := &Batch{
+		Records: []Record{
+			{Key: "A-1", Issue: &types.Issue{Title: "Existing", ExternalRef: &existingRef}},
+			{Key: "A-2", Issue: &types.Issue{Title: "New"}},
+			{Issue: &types.Issue{ID: "bd-keep", Title: "Has ID", Dependencies: []*types.Dependency{
+				{DependsOnID: "bd-other", Type: types.DepBlocks},
+			}}},
+		},
+		Dependencies: []Dependency{
+			{FromKey: "A-2", ToKey: "A-1", Type: types.DepBlocks},
+			{FromKey: "A-2", ToKey: "MISSING-9", Type: types.DepRelated},
+		},
+	}
+
+	n := 0
+	issues, stats := batch.Resolve(
+		func(issue *types.Issue) string {
+			if issue.ExternalRef != nil && *issue.ExternalRef == existingRef {
+				return "bd-old"
+			}
+			return ""
+		},
+		func(*types.Issue) string {
+			n++
+			return fmt.Sprintf("bd-new%d", n)
+		},
+	)
+
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues, want 3", len(issues))
+	}
+	if issues[0].ID != "bd-old" || issues[1].ID != "bd-new1" || issues[2].ID != "bd-keep" {
+		t.Errorf("IDs = %s, %s, %s", issues[0].ID, issues[1].ID, issues[2].ID)
+	}
+	if stats != (Stats{New: 2, Existing: 1, Dependencies: 2}) {
+		t.Errorf("stats = %+v", stats)
+	}
+	deps := issues[1].Dependencies
+	if len(deps) != 1 || deps[0].IssueID != "bd-new1" || deps[0].DependsOnID != "bd-old" || deps[0].Type != types.DepBlocks {
+		t.Errorf("new issue dependencies = %+v", deps)
+	}
+	if issues[2].Dependencies[0].IssueID != "bd-keep" {
+		t.Errorf("preset dependency IssueID = %q, want bd-keep", issues[2].Dependencies[0].IssueID)
+	}
+}
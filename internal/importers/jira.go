@@ -0,0 +1,51 @@
+package importers
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/beads/internal/jira"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func init() {
+	Register(jiraImporter{})
+}
+
+// jiraImporter reads Jira exports via jira.ParseExport, mapping fields as
+// 'bd jira sync' does (including jira.*_map.* overrides from the store).
+type jiraImporter struct{}
+
+func (jiraImporter) Name() string { return "jira" }
+
+func (jiraImporter) Description() string {
+	return "Jira REST search JSON, a JSON array of issues, or a Jira CSV export"
+}
+
+func (jiraImporter) Parse(ctx context.Context, r io.Reader, opts Options) (*Batch, error) {
+	exported, err := jira.ParseExport(r)
+	if err != nil {
+		return nil, err
+	}
+	jt := &jira.Tracker{}
+	if opts.Store != nil {
+		if err := jt.InitOffline(ctx, opts.Store); err != nil {
+			return nil, fmt.Errorf("loading Jira field maps: %w", err)
+		}
+	}
+	converted, deps := jt.ConvertExport(exported)
+
+	batch := &Batch{}
+	for _, c := range converted {
+		batch.Records = append(batch.Records, Record{Key: c.Key, Issue: c.Issue})
+	}
+	for _, dep := range deps {
+		batch.Dependencies = append(batch.Dependencies, Dependency{
+			FromKey: dep.FromExternalID,
+			ToKey:   dep.ToExternalID,
+			Type:    types.DependencyType(dep.Type),
+		})
+	}
+	return batch, nil
+}
@@ -0,0 +1,133 @@
+package importers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/steveyegge/beads/internal/linear"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func init() {
+	Register(linearImporter{})
+}
+
+// linearImporter reads Linear issues as returned by its GraphQL API, so the
+// output of an issues query can be saved and imported without an API key.
+// Fields map as in 'bd linear sync', honoring linear.*_map.* config.
+type linearImporter struct{}
+
+func (linearImporter) Name() string { return "linear" }
+
+func (linearImporter) Description() string {
+	return "Linear GraphQL issues query result (data.issues.nodes, issues.nodes, nodes, or an array)"
+}
+
+func (linearImporter) Parse(ctx context.Context, r io.Reader, opts Options) (*Batch, error) {
+	issues, err := parseLinearExport(r)
+	if err != nil {
+		return nil, err
+	}
+	config := linear.DefaultMappingConfig()
+	if opts.Store != nil {
+		config = linear.LoadMappingConfig(&storeConfigLoader{ctx: ctx, store: opts.Store})
+	}
+
+	batch := &Batch{}
+	seen := make(map[Dependency]bool)
+	now := time.Now()
+	for i := range issues {
+		li := &issues[i]
+		if li.Identifier == "" {
+			batch.RowErrors = append(batch.RowErrors, fmt.Sprintf("issue %d: missing identifier", i+1))
+			continue
+		}
+		conv := linear.IssueToBeads(li, config)
+		issue, ok := conv.Issue.(*types.Issue)
+		if !ok {
+			continue
+		}
+		if issue.ExternalRef == nil || *issue.ExternalRef == "" {
+			ref := "linear-" + li.Identifier
+			issue.ExternalRef = &ref
+		}
+		deriveClosedAt(issue, now)
+		if err := issue.ValidateWithCustom(opts.CustomStatuses, opts.CustomTypes); err != nil {
+			batch.RowErrors = append(batch.RowErrors, fmt.Sprintf("%s: %v", li.Identifier, err))
+			continue
+		}
+		batch.Records = append(batch.Records, Record{Key: li.Identifier, Issue: issue})
+
+		for _, d := range conv.Dependencies {
+			dep := Dependency{FromKey: d.FromLinearID, ToKey: d.ToLinearID, Type: types.DependencyType(d.Type)}
+			if !seen[dep] {
+				seen[dep] = true
+				batch.Dependencies = append(batch.Dependencies, dep)
+			}
+		}
+	}
+	return batch, nil
+}
+
+// parseLinearExport accepts a full GraphQL response, its data object, a
+// bare connection ({"nodes": [...]}) or an array of issues.
+func parseLinearExport(r io.Reader) ([]linear.Issue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read Linear export: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] == '[' {
+		var issues []linear.Issue
+		if err := json.Unmarshal(data, &issues); err != nil {
+			return nil, fmt.Errorf("parse Linear export: %w", err)
+		}
+		return issues, nil
+	}
+
+	type connection struct {
+		Nodes []linear.Issue `json:"nodes"`
+	}
+	var doc struct {
+		Data *struct {
+			Issues *connection `json:"issues"`
+		} `json:"data"`
+		Issues *connection    `json:"issues"`
+		Nodes  []linear.Issue `json:"nodes"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse Linear export: %w", err)
+	}
+	switch {
+	case doc.Data != nil && doc.Data.Issues != nil:
+		return doc.Data.Issues.Nodes, nil
+	case doc.Issues != nil:
+		return doc.Issues.Nodes, nil
+	case doc.Nodes != nil:
+		return doc.Nodes, nil
+	case len(doc.Errors) > 0:
+		return nil, fmt.Errorf("Linear export is a GraphQL error response: %s", doc.Errors[0].Message)
+	}
+	return nil, fmt.Errorf("parse Linear export: no issues found (expected data.issues.nodes, issues.nodes, nodes, or an array)")
+}
+
+// storeConfigLoader adapts a store to linear.ConfigLoader.
+type storeConfigLoader struct {
+	ctx   context.Context
+	store storage.Storage
+}
+
+func (l *storeConfigLoader) GetAllConfig() (map[string]string, error) {
+	return l.store.GetAllConfig(l.ctx)
+}
@@ -0,0 +1,78 @@
+package importers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const linearExport = `{"data": {"issues": {"nodes": [
+  {"id": "u1", "identifier": "ENG-1", "title": "Auth epic", "priority": 1,
+   "url": "https://linear.app/acme/issue/ENG-1/auth-epic",
+   "state": {"name": "In Progress", "type": "started"},
+   "createdAt": "2024-02-01T10:00:00Z", "updatedAt": "2024-02-02T10:00:00Z"},
+  {"id": "u2", "identifier": "ENG-2", "title": "Login form", "priority": 3,
+   "state": {"name": "Done", "type": "completed"},
+   "labels": {"nodes": [{"name": "Bug"}]},
+   "parent": {"id": "u1", "identifier": "ENG-1"},
+   "relations": {"nodes": [{"type": "blocks", "relatedIssue": {"id": "u3", "identifier": "ENG-3"}}]},
+   "createdAt": "2024-02-01T11:00:00Z", "updatedAt": "2024-02-03T10:00:00Z"},
+  {"id": "u3", "identifier": "ENG-3", "title": "Session refresh", "priority": 0,
+   "createdAt": "2024-02-01T12:00:00Z", "updatedAt": "2024-02-01T12:00:00Z"}
+]}}}`
+
+func TestLinearImporter(t *testing.T) {
+	batch, err := Get("linear").Parse(context.Background(), strings.NewReader(linearExport), Options{})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(batch.Records) != 3 || len(batch.RowErrors) != 0 {
+		t.Fatalf("records %d, row errors %v", len(batch.Records), batch.RowErrors)
+	}
+
+	epic, login, session := batch.Records[0].Issue, batch.Records[1].Issue, batch.Records[2].Issue
+	if epic.Status != types.StatusInProgress || epic.Priority != 0 {
+		t.Errorf("epic: status %s priority %d", epic.Status, epic.Priority)
+	}
+	if epic.ExternalRef == nil || !strings.Contains(*epic.ExternalRef, "linear.app") {
+		t.Errorf("epic external_ref = %v", epic.ExternalRef)
+	}
+	if login.Status != types.StatusClosed || login.ClosedAt == nil || login.IssueType != types.TypeBug {
+		t.Errorf("login: status %s closed_at %v type %s", login.Status, login.ClosedAt, login.IssueType)
+	}
+	if session.ExternalRef == nil || *session.ExternalRef != "linear-ENG-3" {
+		t.Errorf("session external_ref = %v, want linear-ENG-3", session.ExternalRef)
+	}
+
+	want := map[Dependency]bool{
+		{FromKey: "ENG-2", ToKey: "ENG-1", Type: types.DepParentChild}: true,
+		{FromKey: "ENG-3", ToKey: "ENG-2", Type: types.DepBlocks}:      true,
+	}
+	if len(batch.Dependencies) != len(want) {
+		t.Fatalf("dependencies = %+v", batch.Dependencies)
+	}
+	for _, dep := range batch.Dependencies {
+		if !want[dep] {
+			t.Errorf("unexpected dependency %+v", dep)
+		}
+	}
+}
+
+func TestParseLinearExportShapes(t *testing.T) {
+	for name, input := range map[string]string{
+		"array":      `[{"identifier": "ENG-1", "title": "x"}]`,
+		"connection": `{"nodes": [{"identifier": "ENG-1", "title": "x"}]}`,
+		"data":       `{"issues": {"nodes": [{"identifier": "ENG-1", "title": "x"}]}}`,
+	} {
+		issues, err := parseLinearExport(strings.NewReader(input))
+		if err != nil || len(issues) != 1 || issues[0].Identifier != "ENG-1" {
+			t.Errorf("%s: got %+v, %v", name, issues, err)
+		}
+	}
+	if _, err := parseLinearExport(strings.NewReader(`{"errors": [{"message": "Authentication required"}]}`)); err == nil ||
+		!strings.Contains(err.Error(), "Authentication required") {
+		t.Errorf("GraphQL error response: err = %v", err)
+	}
+}
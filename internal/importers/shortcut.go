@@ -0,0 +1,372 @@
+package importers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func init() {
+	Register(shortcutImporter{})
+}
+
+// shortcutImporter reads Shortcut stories: the JSON of the REST API (an
+// array of stories, or a search response with a "data" array) or the CSV
+// written by Shortcut's story export.
+//
+// Completed stories are closed and started ones in progress. Workflow
+// states can be mapped explicitly with shortcut.state_map.<state> config,
+// keyed by state name (CSV) or workflow_state_id (JSON). Story links become
+// blocks, duplicates and related edges; the epic, iteration and estimate are
+// kept in metadata since Shortcut epics are not stories.
+type shortcutImporter struct{}
+
+func (shortcutImporter) Name() string { return "shortcut" }
+
+func (shortcutImporter) Description() string {
+	return "Shortcut stories as API JSON (array or search response) or a story CSV export"
+}
+
+// shortcutStory is the subset of a Shortcut API story that maps to beads.
+type shortcutStory struct {
+	ID              int64    `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	StoryType       string   `json:"story_type"`
+	AppURL          string   `json:"app_url"`
+	Completed       bool     `json:"completed"`
+	Started         bool     `json:"started"`
+	WorkflowStateID *int64   `json:"workflow_state_id"`
+	EpicID          *int64   `json:"epic_id"`
+	IterationID     *int64   `json:"iteration_id"`
+	Estimate        *float64 `json:"estimate"`
+	Deadline        string   `json:"deadline"`
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
+	CompletedAt     string   `json:"completed_at"`
+	StartedAt       string   `json:"started_at"`
+	Labels          []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	StoryLinks []struct {
+		Verb      string `json:"verb"`
+		SubjectID int64  `json:"subject_id"`
+		ObjectID  int64  `json:"object_id"`
+	} `json:"story_links"`
+}
+
+// shortcutTimeLayouts covers the API's RFC3339 and the CSV export's dates.
+var shortcutTimeLayouts = []string{
+	time.RFC3339,
+	"2006/01/02 15:04:05",
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+	"2006-01-02",
+}
+
+func parseShortcutTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range shortcutTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (shortcutImporter) Parse(ctx context.Context, r io.Reader, opts Options) (*Batch, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read Shortcut export: %w", err)
+	}
+	stateMap := make(map[string]string)
+	if opts.Store != nil {
+		if all, err := opts.Store.GetAllConfig(ctx); err == nil {
+			const prefix = "shortcut.state_map."
+			for key, value := range all {
+				if strings.HasPrefix(key, prefix) && value != "" {
+					stateMap[strings.ToLower(strings.TrimPrefix(key, prefix))] = value
+				}
+			}
+		}
+	}
+	sc := &shortcutConverter{batch: &Batch{}, stateMap: stateMap, opts: opts, seen: make(map[Dependency]bool), now: time.Now()}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return sc.batch, nil
+	}
+	if trimmed[0] == '[' || trimmed[0] == '{' {
+		stories, err := parseShortcutJSON(trimmed)
+		if err != nil {
+			return nil, err
+		}
+		for i := range stories {
+			sc.addStory(&stories[i])
+		}
+		return sc.batch, nil
+	}
+	if err := sc.addCSV(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return sc.batch, nil
+}
+
+func parseShortcutJSON(data []byte) ([]shortcutStory, error) {
+	if data[0] == '[' {
+		var stories []shortcutStory
+		if err := json.Unmarshal(data, &stories); err != nil {
+			return nil, fmt.Errorf("parse Shortcut export: %w", err)
+		}
+		return stories, nil
+	}
+	var resp struct {
+		Data    []shortcutStory `json:"data"`
+		Stories []shortcutStory `json:"stories"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parse Shortcut export: %w", err)
+	}
+	if resp.Data != nil {
+		return resp.Data, nil
+	}
+	return resp.Stories, nil
+}
+
+type shortcutConverter struct {
+	batch    *Batch
+	stateMap map[string]string
+	opts     Options
+	seen     map[Dependency]bool
+	now      time.Time
+}
+
+func (sc *shortcutConverter) addStory(s *shortcutStory) {
+	key := strconv.FormatInt(s.ID, 10)
+	issue := &types.Issue{
+		Title:       s.Name,
+		Description: s.Description,
+		Priority:    2,
+		IssueType:   shortcutType(s.StoryType),
+		Status:      types.StatusOpen,
+	}
+	switch {
+	case s.Completed:
+		issue.Status = types.StatusClosed
+	case s.Started:
+		issue.Status = types.StatusInProgress
+	}
+	if s.WorkflowStateID != nil {
+		if mapped, ok := sc.stateMap[strconv.FormatInt(*s.WorkflowStateID, 10)]; ok {
+			issue.Status = types.Status(mapped)
+		}
+	}
+	for _, l := range s.Labels {
+		if l.Name != "" {
+			issue.Labels = append(issue.Labels, l.Name)
+		}
+	}
+	issue.CreatedAt, _ = parseShortcutTime(s.CreatedAt)
+	issue.UpdatedAt, _ = parseShortcutTime(s.UpdatedAt)
+	if t, ok := parseShortcutTime(s.CompletedAt); ok {
+		issue.ClosedAt = &t
+	}
+	if t, ok := parseShortcutTime(s.StartedAt); ok {
+		issue.StartedAt = &t
+	}
+	if t, ok := parseShortcutTime(s.Deadline); ok {
+		issue.DueAt = &t
+	}
+
+	meta := map[string]interface{}{"shortcut_id": s.ID}
+	if s.EpicID != nil {
+		meta["shortcut_epic_id"] = *s.EpicID
+	}
+	if s.IterationID != nil {
+		meta["shortcut_iteration_id"] = *s.IterationID
+	}
+	if s.Estimate != nil {
+		meta["shortcut_estimate"] = *s.Estimate
+	}
+	if !sc.add(fmt.Sprintf("story %s", key), key, s.AppURL, issue, meta) {
+		return
+	}
+
+	for _, link := range s.StoryLinks {
+		subject, object := strconv.FormatInt(link.SubjectID, 10), strconv.FormatInt(link.ObjectID, 10)
+		switch strings.ToLower(link.Verb) {
+		case "blocks":
+			sc.addDep(object, subject, types.DepBlocks)
+		case "duplicates":
+			sc.addDep(subject, object, types.DepDuplicates)
+		case "relates to":
+			sc.addDep(subject, object, types.DepRelated)
+		}
+	}
+}
+
+// addCSV converts the rows of a Shortcut story CSV export. Labels and owners
+// are separated by semicolons or commas within their cells.
+func (sc *shortcutConverter) addCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("parse Shortcut CSV export: header: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	column := make(map[string]int, len(header))
+	for i, name := range header {
+		column[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := column["name"]; !ok {
+		return fmt.Errorf("parse Shortcut CSV export: no \"name\" column")
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("parse Shortcut CSV export: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		get := func(name string) string {
+			if i, ok := column[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		key := get("id")
+		issue := &types.Issue{
+			Title:       get("name"),
+			Description: get("description"),
+			Priority:    shortcutPriority(get("priority")),
+			IssueType:   shortcutType(get("type")),
+			Status:      types.StatusOpen,
+			Labels:      splitShortcutList(get("labels")),
+		}
+		if owners := splitShortcutList(get("owners")); len(owners) > 0 {
+			issue.Assignee = owners[0]
+		}
+		state := get("state")
+		switch {
+		case strings.EqualFold(get("is_completed"), "true"):
+			issue.Status = types.StatusClosed
+		case get("started_at") != "":
+			issue.Status = types.StatusInProgress
+		}
+		if mapped, ok := sc.stateMap[strings.ToLower(state)]; ok && state != "" {
+			issue.Status = types.Status(mapped)
+		}
+		issue.CreatedAt, _ = parseShortcutTime(get("created_at"))
+		issue.UpdatedAt, _ = parseShortcutTime(get("updated_at"))
+		if t, ok := parseShortcutTime(get("completed_at")); ok {
+			issue.ClosedAt = &t
+		}
+		if t, ok := parseShortcutTime(get("started_at")); ok {
+			issue.StartedAt = &t
+		}
+		if t, ok := parseShortcutTime(get("due_date")); ok {
+			issue.DueAt = &t
+		}
+
+		meta := map[string]interface{}{}
+		if key != "" {
+			meta["shortcut_id"] = key
+		}
+		for _, name := range []string{"state", "epic", "iteration", "estimate"} {
+			if v := get(name); v != "" {
+				meta["shortcut_"+name] = v
+			}
+		}
+		url := get("app_url")
+		if url == "" {
+			url = get("url")
+		}
+		sc.add(fmt.Sprintf("line %d", line), key, url, issue, meta)
+	}
+}
+
+// add validates an issue and appends it to the batch, recording a row error
+// under label when it is invalid.
+func (sc *shortcutConverter) add(label, key, url string, issue *types.Issue, meta map[string]interface{}) bool {
+	ref := url
+	if ref == "" && key != "" {
+		ref = "shortcut-" + key
+	}
+	if ref != "" {
+		issue.ExternalRef = &ref
+	}
+	if len(meta) > 0 {
+		if raw, err := json.Marshal(meta); err == nil {
+			issue.Metadata = raw
+		}
+	}
+	issue.SetDefaults()
+	deriveClosedAt(issue, sc.now)
+	if err := issue.ValidateWithCustom(sc.opts.CustomStatuses, sc.opts.CustomTypes); err != nil {
+		sc.batch.RowErrors = append(sc.batch.RowErrors, fmt.Sprintf("%s: %v", label, err))
+		return false
+	}
+	sc.batch.Records = append(sc.batch.Records, Record{Key: key, Issue: issue})
+	return true
+}
+
+func (sc *shortcutConverter) addDep(from, to string, depType types.DependencyType) {
+	dep := Dependency{FromKey: from, ToKey: to, Type: depType}
+	if !sc.seen[dep] {
+		sc.seen[dep] = true
+		sc.batch.Dependencies = append(sc.batch.Dependencies, dep)
+	}
+}
+
+// shortcutType maps a Shortcut story type (feature, bug, chore).
+func shortcutType(storyType string) types.IssueType {
+	switch strings.ToLower(strings.TrimSpace(storyType)) {
+	case "feature":
+		return types.TypeFeature
+	case "bug":
+		return types.TypeBug
+	case "chore":
+		return types.TypeChore
+	}
+	return types.TypeTask
+}
+
+// shortcutPriority maps the values of Shortcut's Priority field.
+func shortcutPriority(value string) int {
+	switch strings.ToLower(value) {
+	case "highest", "urgent", "critical":
+		return 0
+	case "high":
+		return 1
+	case "low":
+		return 3
+	case "lowest":
+		return 4
+	}
+	return 2
+}
+
+func splitShortcutList(cell string) []string {
+	var out []string
+	for _, v := range strings.FieldsFunc(cell, func(r rune) bool { return r == ';' || r == ',' }) {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
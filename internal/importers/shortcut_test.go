@@ -0,0 +1,94 @@
+package importers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestShortcutImporterJSON(t *testing.T) {
+	data := `{"data": [
+	  {"id": 101, "name": "Checkout", "story_type": "feature", "started": true,
+	   "app_url": "https://app.shortcut.com/acme/story/101", "epic_id": 7, "estimate": 3,
+	   "labels": [{"name": "payments"}], "created_at": "2024-03-01T09:00:00Z", "updated_at": "2024-03-02T09:00:00Z",
+	   "story_links": [{"verb": "blocks", "subject_id": 101, "object_id": 102}]},
+	  {"id": 102, "name": "Receipt email", "story_type": "bug", "completed": true,
+	   "completed_at": "2024-03-05T09:00:00Z",
+	   "story_links": [{"verb": "blocks", "subject_id": 101, "object_id": 102},
+	                   {"verb": "relates to", "subject_id": 102, "object_id": 999}]},
+	  {"id": 103, "name": "", "story_type": "chore"}
+	]}`
+
+	batch, err := Get("shortcut").Parse(context.Background(), strings.NewReader(data), Options{})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(batch.Records) != 2 {
+		t.Fatalf("got %d records, want 2", len(batch.Records))
+	}
+	if len(batch.RowErrors) != 1 || !strings.HasPrefix(batch.RowErrors[0], "story 103:") {
+		t.Errorf("row errors = %v, want one for story 103", batch.RowErrors)
+	}
+
+	checkout, receipt := batch.Records[0].Issue, batch.Records[1].Issue
+	if checkout.Status != types.StatusInProgress || checkout.IssueType != types.TypeFeature {
+		t.Errorf("checkout: status %s type %s", checkout.Status, checkout.IssueType)
+	}
+	if checkout.ExternalRef == nil || *checkout.ExternalRef != "https://app.shortcut.com/acme/story/101" {
+		t.Errorf("checkout external_ref = %v", checkout.ExternalRef)
+	}
+	if !strings.Contains(string(checkout.Metadata), `"shortcut_epic_id":7`) {
+		t.Errorf("checkout metadata = %s", checkout.Metadata)
+	}
+	if receipt.Status != types.StatusClosed || receipt.ClosedAt == nil || *receipt.ExternalRef != "shortcut-102" {
+		t.Errorf("receipt: status %s closed_at %v ref %v", receipt.Status, receipt.ClosedAt, receipt.ExternalRef)
+	}
+
+	// The blocks link is listed on both stories but yields one edge, on the
+	// blocked story.
+	want := []Dependency{
+		{FromKey: "102", ToKey: "101", Type: types.DepBlocks},
+		{FromKey: "102", ToKey: "999", Type: types.DepRelated},
+	}
+	if len(batch.Dependencies) != len(want) {
+		t.Fatalf("dependencies = %+v", batch.Dependencies)
+	}
+	for i := range want {
+		if batch.Dependencies[i] != want[i] {
+			t.Errorf("dependencies[%d] = %+v, want %+v", i, batch.Dependencies[i], want[i])
+		}
+	}
+}
+
+func TestShortcutImporterCSV(t *testing.T) {
+	data := "id,name,type,owners,description,is_completed,created_at,started_at,updated_at,completed_at,labels,state,epic,priority\n" +
+		"201,Search page,feature,ana@example.com;bo@example.com,Find things,FALSE,2024/03/01 09:00:00,2024/03/02 09:00:00,2024/03/02 09:00:00,,ui;web,In Development,Discovery,High\n" +
+		"202,Typo,bug,,,TRUE,2024/03/01 09:00:00,,2024/03/03 09:00:00,2024/03/03 09:00:00,,Done,,\n"
+
+	batch, err := Get("shortcut").Parse(context.Background(), strings.NewReader(data), Options{})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(batch.Records) != 2 || len(batch.RowErrors) != 0 {
+		t.Fatalf("records %d, row errors %v", len(batch.Records), batch.RowErrors)
+	}
+	search := batch.Records[0].Issue
+	if search.Status != types.StatusInProgress || search.Priority != 1 || search.Assignee != "ana@example.com" {
+		t.Errorf("search: status %s priority %d assignee %q", search.Status, search.Priority, search.Assignee)
+	}
+	if strings.Join(search.Labels, ",") != "ui,web" || search.StartedAt == nil {
+		t.Errorf("search: labels %v started_at %v", search.Labels, search.StartedAt)
+	}
+	if !strings.Contains(string(search.Metadata), `"shortcut_epic":"Discovery"`) {
+		t.Errorf("search metadata = %s", search.Metadata)
+	}
+	if typo := batch.Records[1].Issue; typo.Status != types.StatusClosed || typo.ClosedAt == nil {
+		t.Errorf("typo: status %s closed_at %v", typo.Status, typo.ClosedAt)
+	}
+
+	if _, err := Get("shortcut").Parse(context.Background(), strings.NewReader("title,state\nx,y\n"), Options{}); err == nil {
+		t.Error("expected an error for a CSV without a name column")
+	}
+}
@@ -38,6 +38,8 @@ type IssueFields struct {
 	Created     string           `json:"created"`
 	Updated     string           `json:"updated"`
 	Resolution  *ResolutionField `json:"resolution"`
+	Parent      *LinkedIssue     `json:"parent"`
+	IssueLinks  []IssueLink      `json:"issuelinks"`
 }
 
 // StatusField represents a Jira issue status.
@@ -77,6 +79,28 @@ type ResolutionField struct {
 	Name string `json:"name"`
 }
 
+// LinkedIssue identifies the other end of an issue link or a parent.
+type LinkedIssue struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// IssueLinkType describes a Jira link type, e.g. Name "Blocks" with
+// Inward "is blocked by" and Outward "blocks".
+type IssueLinkType struct {
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// IssueLink is one entry of an issue's issuelinks field. Exactly one of
+// InwardIssue and OutwardIssue is set.
+type IssueLink struct {
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  *LinkedIssue  `json:"inwardIssue"`
+	OutwardIssue *LinkedIssue  `json:"outwardIssue"`
+}
+
 // Transition represents a Jira workflow transition.
 type Transition struct {
 	ID   string      `json:"id"`
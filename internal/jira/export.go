@@ -0,0 +1,282 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ParseExport reads a Jira export: either the JSON of a REST search
+// response ({"issues": [...]}) or a bare JSON array of issues, or a CSV file
+// as written by Jira's "Export > CSV (all fields)". The format is detected
+// from the first non-blank byte.
+func ParseExport(r io.Reader) ([]Issue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read Jira export: %w", err)
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	switch trimmed[0] {
+	case '{':
+		var result SearchResult
+		if err := json.Unmarshal(trimmed, &result); err != nil {
+			return nil, fmt.Errorf("parse Jira JSON export: %w", err)
+		}
+		return result.Issues, nil
+	case '[':
+		var issues []Issue
+		if err := json.Unmarshal(trimmed, &issues); err != nil {
+			return nil, fmt.Errorf("parse Jira JSON export: %w", err)
+		}
+		return issues, nil
+	}
+	return parseExportCSV(bytes.NewReader(data))
+}
+
+// csvTimestampLayouts are the date formats Jira writes into CSV exports,
+// which depend on the instance's locale settings.
+var csvTimestampLayouts = []string{
+	"02/Jan/06 3:04 PM",
+	"02/Jan/06 15:04",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+}
+
+// parseExportCSV converts Jira CSV rows into Issues. Columns Jira repeats
+// (Labels, issue links) are collected across all occurrences. Parents come
+// from the "Parent" column (an issue id in Jira Cloud, resolved to a key
+// after all rows are read) or the legacy "Custom field (Epic Link)" column.
+func parseExportCSV(r io.Reader) ([]Issue, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("parse Jira CSV export: header: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	var issues []Issue
+	keyByID := make(map[string]string)
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse Jira CSV export: line %d: %w", line, err)
+		}
+
+		var ji Issue
+		for i, value := range row {
+			if i >= len(header) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			column := strings.TrimSpace(header[i])
+			switch column {
+			case "Issue key":
+				ji.Key = value
+			case "Issue id":
+				ji.ID = value
+			case "Summary":
+				ji.Fields.Summary = value
+			case "Description":
+				ji.Fields.Description, _ = json.Marshal(value)
+			case "Issue Type":
+				ji.Fields.IssueType = &IssueTypeField{Name: value}
+			case "Status":
+				ji.Fields.Status = &StatusField{Name: value}
+			case "Priority":
+				ji.Fields.Priority = &PriorityField{Name: value}
+			case "Assignee":
+				ji.Fields.Assignee = &UserField{DisplayName: value}
+			case "Project key":
+				ji.Fields.Project = &ProjectField{Key: value}
+			case "Labels":
+				ji.Fields.Labels = append(ji.Fields.Labels, value)
+			case "Created":
+				ji.Fields.Created = csvTimestamp(value)
+			case "Updated":
+				ji.Fields.Updated = csvTimestamp(value)
+			case "Parent", "Parent id":
+				ji.Fields.Parent = &LinkedIssue{ID: value}
+			case "Custom field (Epic Link)":
+				if ji.Fields.Parent == nil {
+					ji.Fields.Parent = &LinkedIssue{Key: value}
+				}
+			default:
+				if name, ok := csvLinkColumn(column, "Outward issue link ("); ok {
+					ji.Fields.IssueLinks = append(ji.Fields.IssueLinks, IssueLink{Type: IssueLinkType{Name: name}, OutwardIssue: &LinkedIssue{Key: value}})
+				} else if name, ok := csvLinkColumn(column, "Inward issue link ("); ok {
+					ji.Fields.IssueLinks = append(ji.Fields.IssueLinks, IssueLink{Type: IssueLinkType{Name: name}, InwardIssue: &LinkedIssue{Key: value}})
+				}
+			}
+		}
+		if ji.Key == "" {
+			return nil, fmt.Errorf("parse Jira CSV export: line %d: missing \"Issue key\" column", line)
+		}
+		if ji.ID != "" {
+			keyByID[ji.ID] = ji.Key
+		}
+		issues = append(issues, ji)
+	}
+
+	for i := range issues {
+		if parent := issues[i].Fields.Parent; parent != nil && parent.Key == "" {
+			if key, ok := keyByID[parent.ID]; ok {
+				parent.Key = key
+			} else if strings.Contains(parent.ID, "-") {
+				parent.Key = parent.ID // some exports write the parent's key
+			}
+		}
+	}
+	return issues, nil
+}
+
+// csvLinkColumn extracts the link type name from a column header such as
+// "Outward issue link (Blocks)".
+func csvLinkColumn(column, prefix string) (string, bool) {
+	if !strings.HasPrefix(column, prefix) || !strings.HasSuffix(column, ")") {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(column, prefix), ")"), true
+}
+
+// csvTimestamp rewrites a CSV export date into the REST format understood
+// by ParseTimestamp. Values it cannot parse are returned unchanged.
+func csvTimestamp(value string) string {
+	for _, layout := range csvTimestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return value
+}
+
+// ExportIssue is one issue from a Jira export converted to beads form. The
+// beads ID is left for the caller to assign.
+type ExportIssue struct {
+	Key   string
+	Issue *types.Issue
+}
+
+// ConvertExport converts exported Jira issues with the tracker's field
+// mapper. Each issue's external_ref records its Jira key: the browse URL
+// when jira.url is configured or the export carries self links, otherwise
+// "jira-<KEY>".
+//
+// The returned dependencies are keyed by Jira key (FromExternalID depends on
+// ToExternalID) and only connect issues present in the export: a parent
+// becomes a parent-child edge from the child, "Blocks" links become blocks
+// edges on the blocked issue, "Duplicate" links duplicates edges and
+// "Relates" links related edges. A link listed on both of its issues yields
+// one edge.
+func (t *Tracker) ConvertExport(issues []Issue) ([]ExportIssue, []tracker.DependencyInfo) {
+	mapper := t.FieldMapper()
+	inExport := make(map[string]bool, len(issues))
+	for i := range issues {
+		inExport[issues[i].Key] = true
+	}
+
+	var converted []ExportIssue
+	var deps []tracker.DependencyInfo
+	seen := make(map[tracker.DependencyInfo]bool)
+	addDep := func(from, to string, depType types.DependencyType, source tracker.DependencySource) {
+		dep := tracker.DependencyInfo{FromExternalID: from, ToExternalID: to, Type: string(depType), Source: source}
+		if from == to || !inExport[from] || !inExport[to] || seen[dep] {
+			return
+		}
+		seen[dep] = true
+		deps = append(deps, dep)
+	}
+
+	for i := range issues {
+		ji := &issues[i]
+		ti := jiraToTrackerIssue(ji, t.priorityMap)
+		conv := mapper.IssueToBeads(&ti)
+		if conv == nil || conv.Issue == nil {
+			continue
+		}
+		issue := conv.Issue
+		ref := t.exportExternalRef(ji)
+		issue.ExternalRef = &ref
+		issue.CreatedAt = ti.CreatedAt
+		issue.UpdatedAt = ti.UpdatedAt
+		if issue.Status == types.StatusClosed && !ti.UpdatedAt.IsZero() {
+			closedAt := ti.UpdatedAt
+			issue.ClosedAt = &closedAt
+		}
+		if raw, err := json.Marshal(ti.Metadata); err == nil {
+			issue.Metadata = raw
+		}
+		converted = append(converted, ExportIssue{Key: ji.Key, Issue: issue})
+
+		if ji.Fields.Parent != nil {
+			addDep(ji.Key, ji.Fields.Parent.Key, types.DepParentChild, tracker.DependencySourceParent)
+		}
+		for _, link := range ji.Fields.IssueLinks {
+			depType, ok := linkDependencyType(link.Type)
+			if !ok {
+				continue
+			}
+			// For Blocks the outward side ("blocks") is the blocker, so the
+			// edge belongs to the other issue. For the other types the
+			// outward side is the dependent ("duplicates", "relates to").
+			switch {
+			case link.OutwardIssue != nil && depType == types.DepBlocks:
+				addDep(link.OutwardIssue.Key, ji.Key, depType, tracker.DependencySourceRelation)
+			case link.OutwardIssue != nil:
+				addDep(ji.Key, link.OutwardIssue.Key, depType, tracker.DependencySourceRelation)
+			case link.InwardIssue != nil && depType == types.DepBlocks:
+				addDep(ji.Key, link.InwardIssue.Key, depType, tracker.DependencySourceRelation)
+			case link.InwardIssue != nil:
+				addDep(link.InwardIssue.Key, ji.Key, depType, tracker.DependencySourceRelation)
+			}
+		}
+	}
+	return converted, deps
+}
+
+// exportExternalRef builds the external_ref for an exported issue.
+func (t *Tracker) exportExternalRef(ji *Issue) string {
+	if t.jiraURL != "" {
+		return fmt.Sprintf("%s/browse/%s", t.jiraURL, ji.Key)
+	}
+	if ref := extractBrowseURL(ji); ref != "" {
+		return ref
+	}
+	return "jira-" + ji.Key
+}
+
+// linkDependencyType maps a Jira link type to a beads dependency type. The
+// stock link types are matched by name; custom types whose outward phrase is
+// "blocks" are treated as blocking.
+func linkDependencyType(lt IssueLinkType) (types.DependencyType, bool) {
+	switch strings.ToLower(lt.Name) {
+	case "blocks":
+		return types.DepBlocks, true
+	case "duplicate":
+		return types.DepDuplicates, true
+	case "relates":
+		return types.DepRelated, true
+	}
+	if strings.EqualFold(lt.Outward, "blocks") {
+		return types.DepBlocks, true
+	}
+	return "", false
+}
@@ -0,0 +1,134 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const exportJSON = `{
+  "issues": [
+    {"id": "10001", "key": "PROJ-1", "self": "https://acme.atlassian.net/rest/api/3/issue/10001",
+     "fields": {"summary": "Checkout epic", "issuetype": {"name": "Epic"}, "status": {"name": "In Progress"},
+                "priority": {"name": "High"}, "created": "2024-01-15T10:30:00.000+0000", "updated": "2024-01-16T10:30:00.000+0000"}},
+    {"id": "10002", "key": "PROJ-2", "self": "https://acme.atlassian.net/rest/api/3/issue/10002",
+     "fields": {"summary": "Payment API", "issuetype": {"name": "Story"}, "status": {"name": "Done"},
+                "priority": {"name": "Low"}, "labels": ["backend"], "parent": {"id": "10001", "key": "PROJ-1"},
+                "updated": "2024-01-17T10:30:00.000+0000",
+                "issuelinks": [{"type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"}, "outwardIssue": {"key": "PROJ-3"}}]}},
+    {"id": "10003", "key": "PROJ-3", "self": "https://acme.atlassian.net/rest/api/3/issue/10003",
+     "fields": {"summary": "Checkout UI", "issuetype": {"name": "Bug"}, "status": {"name": "To Do"},
+                "issuelinks": [
+                  {"type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"}, "inwardIssue": {"key": "PROJ-2"}},
+                  {"type": {"name": "Relates", "inward": "relates to", "outward": "relates to"}, "outwardIssue": {"key": "OTHER-9"}}
+                ]}}
+  ]
+}`
+
+func TestParseExportJSON(t *testing.T) {
+	issues, err := ParseExport(strings.NewReader(exportJSON))
+	if err != nil {
+		t.Fatalf("ParseExport: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues, want 3", len(issues))
+	}
+	if p := issues[1].Fields.Parent; p == nil || p.Key != "PROJ-1" {
+		t.Errorf("PROJ-2 parent = %+v, want PROJ-1", p)
+	}
+	if len(issues[2].Fields.IssueLinks) != 2 {
+		t.Errorf("PROJ-3 has %d links, want 2", len(issues[2].Fields.IssueLinks))
+	}
+
+	arr, err := ParseExport(strings.NewReader(`[{"key": "PROJ-7", "fields": {"summary": "Bare array"}}]`))
+	if err != nil || len(arr) != 1 || arr[0].Key != "PROJ-7" {
+		t.Errorf("bare array: got %+v, %v", arr, err)
+	}
+}
+
+func TestParseExportCSV(t *testing.T) {
+	csvData := "\ufeffSummary,Issue key,Issue id,Issue Type,Status,Priority,Labels,Labels,Created,Parent,Outward issue link (Blocks)\n" +
+		"Epic,PROJ-1,10001,Epic,To Do,Medium,,,15/Jan/24 10:30 AM,,\n" +
+		"\"Story, with comma\",PROJ-2,10002,Story,Done,High,api,backend,16/Jan/24 2:05 PM,10001,PROJ-3\n" +
+		"Bug,PROJ-3,10003,Bug,To Do,Low,,,,,\n"
+
+	issues, err := ParseExport(strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("ParseExport: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues, want 3", len(issues))
+	}
+	story := issues[1]
+	if story.Fields.Summary != "Story, with comma" {
+		t.Errorf("summary = %q", story.Fields.Summary)
+	}
+	if got := strings.Join(story.Fields.Labels, ","); got != "api,backend" {
+		t.Errorf("labels = %q, want api,backend", got)
+	}
+	if story.Fields.Parent == nil || story.Fields.Parent.Key != "PROJ-1" {
+		t.Errorf("parent = %+v, want key PROJ-1 resolved from id", story.Fields.Parent)
+	}
+	if story.Fields.Created != "2024-01-16T14:05:00Z" {
+		t.Errorf("created = %q", story.Fields.Created)
+	}
+	if len(story.Fields.IssueLinks) != 1 || story.Fields.IssueLinks[0].OutwardIssue.Key != "PROJ-3" {
+		t.Errorf("links = %+v", story.Fields.IssueLinks)
+	}
+
+	if _, err := ParseExport(strings.NewReader("Summary,Status\nNo key,Open\n")); err == nil {
+		t.Error("expected an error for rows without an issue key")
+	}
+}
+
+func TestConvertExport(t *testing.T) {
+	issues, err := ParseExport(strings.NewReader(exportJSON))
+	if err != nil {
+		t.Fatalf("ParseExport: %v", err)
+	}
+	tr := &Tracker{}
+	converted, deps := tr.ConvertExport(issues)
+	if len(converted) != 3 {
+		t.Fatalf("got %d converted issues, want 3", len(converted))
+	}
+
+	epic := converted[0].Issue
+	if epic.IssueType != types.TypeEpic || epic.Status != types.StatusInProgress || epic.Priority != 1 {
+		t.Errorf("epic = type %s status %s priority %d", epic.IssueType, epic.Status, epic.Priority)
+	}
+	if epic.ExternalRef == nil || *epic.ExternalRef != "https://acme.atlassian.net/browse/PROJ-1" {
+		t.Errorf("epic external_ref = %v", epic.ExternalRef)
+	}
+	story := converted[1].Issue
+	if story.Status != types.StatusClosed || story.ClosedAt == nil {
+		t.Errorf("story status %s closed_at %v, want closed with closed_at", story.Status, story.ClosedAt)
+	}
+
+	// The Blocks link appears on both PROJ-2 and PROJ-3 but yields one edge;
+	// the Relates link to OTHER-9 is outside the export and is dropped.
+	want := []tracker.DependencyInfo{
+		{FromExternalID: "PROJ-2", ToExternalID: "PROJ-1", Type: string(types.DepParentChild), Source: tracker.DependencySourceParent},
+		{FromExternalID: "PROJ-3", ToExternalID: "PROJ-2", Type: string(types.DepBlocks), Source: tracker.DependencySourceRelation},
+	}
+	if len(deps) != len(want) {
+		t.Fatalf("deps = %+v, want %+v", deps, want)
+	}
+	for i := range want {
+		if deps[i] != want[i] {
+			t.Errorf("deps[%d] = %+v, want %+v", i, deps[i], want[i])
+		}
+	}
+
+	tr.jiraURL = "https://jira.example.com"
+	converted, _ = tr.ConvertExport([]Issue{{Key: "ABC-5", Fields: IssueFields{Summary: "x"}}})
+	if ref := *converted[0].Issue.ExternalRef; ref != "https://jira.example.com/browse/ABC-5" {
+		t.Errorf("external_ref with jira.url = %q", ref)
+	}
+	tr.jiraURL = ""
+	converted, _ = tr.ConvertExport([]Issue{{Key: "ABC-5", Fields: IssueFields{Summary: "x"}}})
+	if ref := *converted[0].Issue.ExternalRef; ref != "jira-ABC-5" {
+		t.Errorf("external_ref without URL = %q", ref)
+	}
+}
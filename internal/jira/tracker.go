@@ -92,6 +92,23 @@ func (t *Tracker) Init(ctx context.Context, store storage.Storage) error {
 	t.apiVersion = apiVersion
 	t.client.APIVersion = apiVersion
 
+	return t.loadFieldMaps(ctx)
+}
+
+// InitOffline prepares the tracker for converting exported Jira issues
+// (see ConvertExport) without API access: jira.url is optional and no
+// client is created, but the configured status, type and priority maps
+// still apply.
+func (t *Tracker) InitOffline(ctx context.Context, store storage.Storage) error {
+	t.store = store
+	t.jiraURL, _ = t.getConfig(ctx, "jira.url", "JIRA_URL")
+	t.jiraURL = strings.TrimSuffix(t.jiraURL, "/")
+	return t.loadFieldMaps(ctx)
+}
+
+// loadFieldMaps reads the optional jira.status_map.*, jira.type_map.*,
+// jira.priority_map.* and jira.custom_fields.* config keys.
+func (t *Tracker) loadFieldMaps(ctx context.Context) error {
 	// Load optional custom status map from all jira.status_map.* config keys.
 	// Using GetAllConfig supports arbitrary (including custom) beads status names.
 	if allConfig, err := t.store.GetAllConfig(ctx); err == nil {
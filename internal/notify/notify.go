@@ -0,0 +1,290 @@
+// Package notify delivers issue lifecycle notifications to webhooks.
+//
+// Webhooks are configured under notify.webhooks in config.yaml (project,
+// config.local.yaml, or the user-level ~/.config/bd/config.yaml):
+//
+//	notify:
+//	  webhooks:
+//	    team-slack:
+//	      url: ${SLACK_WEBHOOK_URL}
+//	      events: [closed, p0_created, blocked_stale]
+//	      blocked_days: 5
+//	    alice:
+//	      url: https://example.com/hooks/bd
+//	      format: generic
+//	      events: [ready, triage]
+//	      assignee: alice
+//
+// Events are queued in an on-disk outbox (.beads/notify/outbox.jsonl) when
+// they happen and delivered after the command that produced them, so a
+// slow or unreachable endpoint never delays a write. Failed deliveries are
+// retried with exponential backoff on later runs and, once they exhaust
+// their attempts, moved to a dead-letter log.
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Event kinds a webhook can subscribe to.
+const (
+	// EventClosed fires when an issue is closed.
+	EventClosed = "closed"
+	// EventP0Created fires when a P0 (critical) issue is created.
+	EventP0Created = "p0_created"
+	// EventBlockedStale fires once when an issue has been blocked for at
+	// least the webhook's blocked_days.
+	EventBlockedStale = "blocked_stale"
+	// EventReady fires when issues become ready for an assignee.
+	EventReady = "ready"
+	// EventTriage fires when bd triage --apply acts on an issue through a
+	// rule with notify: true.
+	EventTriage = "triage"
+)
+
+// EventKinds lists every event kind, in documentation order.
+var EventKinds = []string{EventClosed, EventP0Created, EventBlockedStale, EventReady, EventTriage}
+
+// Payload formats.
+const (
+	FormatGeneric = "generic"
+	FormatSlack   = "slack"
+	FormatDiscord = "discord"
+)
+
+// DefaultBlockedDays is the blocked_stale threshold when a webhook sets none.
+const DefaultBlockedDays = 3
+
+// Webhook is one configured notification endpoint.
+type Webhook struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"-"`
+	Format      string   `json:"format"`
+	Events      []string `json:"events"`
+	BlockedDays int      `json:"blocked_days,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+}
+
+// Wants reports whether the webhook subscribes to an event. Ready events
+// are further limited to the webhook's assignee when it has one.
+func (w *Webhook) Wants(ev *Event) bool {
+	if !slices.Contains(w.Events, ev.Kind) {
+		return false
+	}
+	if ev.Kind == EventReady && w.Assignee != "" && ev.Assignee != w.Assignee {
+		return false
+	}
+	return true
+}
+
+// Polled reports whether the webhook subscribes to an event that is found
+// by scanning the database (blocked_stale, ready) rather than raised by a
+// mutation.
+func (w *Webhook) Polled() bool {
+	return slices.Contains(w.Events, EventBlockedStale) || slices.Contains(w.Events, EventReady)
+}
+
+// ParseWebhooks decodes the notify.webhooks config map (name → settings).
+// URLs may reference environment variables (${NAME}) so secrets can stay
+// out of tracked config. The format defaults from the URL host and events
+// default to closed and p0_created.
+func ParseWebhooks(raw map[string]interface{}) ([]*Webhook, error) {
+	var hooks []*Webhook
+	for name, v := range raw {
+		settings, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("notify.webhooks.%s: expected a map of settings", name)
+		}
+		w := &Webhook{Name: name}
+		for key, val := range settings {
+			switch strings.ToLower(key) {
+			case "url":
+				w.URL = strings.TrimSpace(os.ExpandEnv(fmt.Sprint(val)))
+			case "format":
+				w.Format = strings.ToLower(fmt.Sprint(val))
+			case "events":
+				events, err := stringList(val)
+				if err != nil {
+					return nil, fmt.Errorf("notify.webhooks.%s.events: %w", name, err)
+				}
+				w.Events = events
+			case "blocked_days":
+				days, ok := val.(int)
+				if !ok || days < 1 {
+					return nil, fmt.Errorf("notify.webhooks.%s.blocked_days: want a positive number of days, got %v", name, val)
+				}
+				w.BlockedDays = days
+			case "assignee":
+				w.Assignee = fmt.Sprint(val)
+			default:
+				return nil, fmt.Errorf("notify.webhooks.%s: unknown setting %q", name, key)
+			}
+		}
+		if w.URL == "" {
+			return nil, fmt.Errorf("notify.webhooks.%s: url is required (or its environment variable is unset)", name)
+		}
+		if w.Format == "" {
+			w.Format = formatForURL(w.URL)
+		}
+		if w.Format != FormatGeneric && w.Format != FormatSlack && w.Format != FormatDiscord {
+			return nil, fmt.Errorf("notify.webhooks.%s.format: unknown format %q (want generic, slack, or discord)", name, w.Format)
+		}
+		if len(w.Events) == 0 {
+			w.Events = []string{EventClosed, EventP0Created}
+		}
+		for _, ev := range w.Events {
+			if !slices.Contains(EventKinds, ev) {
+				return nil, fmt.Errorf("notify.webhooks.%s.events: unknown event %q (want %s)", name, ev, strings.Join(EventKinds, ", "))
+			}
+		}
+		if w.BlockedDays == 0 {
+			w.BlockedDays = DefaultBlockedDays
+		}
+		hooks = append(hooks, w)
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].Name < hooks[j].Name })
+	return hooks, nil
+}
+
+func stringList(val interface{}) ([]string, error) {
+	switch v := val.(type) {
+	case string:
+		var out []string
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, s := range v {
+			out = append(out, strings.TrimSpace(fmt.Sprint(s)))
+		}
+		return out, nil
+	case []string:
+		return v, nil
+	}
+	return nil, fmt.Errorf("expected a list, got %T", val)
+}
+
+func formatForURL(url string) string {
+	switch {
+	case strings.Contains(url, "hooks.slack.com/"):
+		return FormatSlack
+	case strings.Contains(url, "discord.com/api/webhooks/"), strings.Contains(url, "discordapp.com/api/webhooks/"):
+		return FormatDiscord
+	}
+	return FormatGeneric
+}
+
+// IssueSummary is the part of an issue carried in a notification.
+type IssueSummary struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	Priority    int    `json:"priority"`
+	IssueType   string `json:"issue_type,omitempty"`
+	Assignee    string `json:"assignee,omitempty"`
+	CloseReason string `json:"close_reason,omitempty"`
+}
+
+// Summarize extracts the notification fields of an issue.
+func Summarize(issue *types.Issue) IssueSummary {
+	return IssueSummary{
+		ID:          issue.ID,
+		Title:       issue.Title,
+		Status:      string(issue.Status),
+		Priority:    issue.Priority,
+		IssueType:   string(issue.IssueType),
+		Assignee:    issue.Assignee,
+		CloseReason: issue.CloseReason,
+	}
+}
+
+// Event is one notification. Generic webhooks receive it as JSON as is.
+type Event struct {
+	Kind        string         `json:"event"`
+	Time        time.Time      `json:"timestamp"`
+	Issue       *IssueSummary  `json:"issue,omitempty"`
+	Issues      []IssueSummary `json:"issues,omitempty"`
+	Assignee    string         `json:"assignee,omitempty"`
+	BlockedDays int            `json:"blocked_days,omitempty"`
+	Rule        string         `json:"rule,omitempty"`   // triage
+	Reason      string         `json:"reason,omitempty"` // triage
+}
+
+// LifecycleEvent maps a hook event (create, update, close) on an issue to
+// a notification event, or nil when nothing should be sent.
+func LifecycleEvent(hookEvent string, issue *types.Issue, now time.Time) *Event {
+	if issue == nil {
+		return nil
+	}
+	var kind string
+	switch {
+	case hookEvent == "close" && issue.Status == types.StatusClosed:
+		kind = EventClosed
+	case hookEvent == "create" && issue.Priority == 0 && !issue.Ephemeral:
+		kind = EventP0Created
+	default:
+		return nil
+	}
+	summary := Summarize(issue)
+	return &Event{Kind: kind, Time: now.UTC(), Issue: &summary}
+}
+
+// Text renders the one-line human message used by Slack and Discord.
+func (ev *Event) Text() string {
+	switch ev.Kind {
+	case EventClosed:
+		text := fmt.Sprintf("✓ Closed %s: %s", ev.Issue.ID, ev.Issue.Title)
+		if ev.Issue.CloseReason != "" {
+			text += " (" + ev.Issue.CloseReason + ")"
+		}
+		return text
+	case EventP0Created:
+		text := fmt.Sprintf("🚨 P0 created %s: %s", ev.Issue.ID, ev.Issue.Title)
+		if ev.Issue.Assignee != "" {
+			text += " → " + ev.Issue.Assignee
+		}
+		return text
+	case EventBlockedStale:
+		return fmt.Sprintf("⏳ %s has been blocked for %d days: %s", ev.Issue.ID, ev.BlockedDays, ev.Issue.Title)
+	case EventReady:
+		lines := []string{fmt.Sprintf("%d issue(s) ready for %s:", len(ev.Issues), ev.Assignee)}
+		for _, issue := range ev.Issues {
+			lines = append(lines, fmt.Sprintf("• %s [P%d] %s", issue.ID, issue.Priority, issue.Title))
+		}
+		return strings.Join(lines, "\n")
+	case EventTriage:
+		return fmt.Sprintf("🧹 Triage %s: %s %s (%s)", ev.Rule, ev.Issue.ID, ev.Issue.Title, ev.Reason)
+	}
+	return ev.Kind
+}
+
+// discordContentLimit is Discord's maximum message length.
+const discordContentLimit = 2000
+
+// Render builds the request body of an event for a payload format.
+func Render(format string, ev *Event) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": ev.Text()})
+	case FormatDiscord:
+		text := ev.Text()
+		if runes := []rune(text); len(runes) > discordContentLimit {
+			text = string(runes[:discordContentLimit-1]) + "…"
+		}
+		return json.Marshal(map[string]string{"content": text})
+	default:
+		return json.Marshal(ev)
+	}
+}
@@ -0,0 +1,242 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseWebhooks(t *testing.T) {
+	t.Setenv("BD_TEST_SLACK_URL", "https://hooks.slack.com/services/T/B/X")
+	hooks, err := ParseWebhooks(map[string]interface{}{
+		"team": map[string]interface{}{"url": "${BD_TEST_SLACK_URL}"},
+		"alice": map[string]interface{}{
+			"url":          "https://example.com/hook",
+			"events":       []interface{}{"ready", "blocked_stale"},
+			"assignee":     "alice",
+			"blocked_days": 7,
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseWebhooks: %v", err)
+	}
+	if len(hooks) != 2 || hooks[0].Name != "alice" || hooks[1].Name != "team" {
+		t.Fatalf("hooks = %+v, want alice then team", hooks)
+	}
+	alice, team := hooks[0], hooks[1]
+	if alice.Format != FormatGeneric || alice.BlockedDays != 7 || !alice.Polled() {
+		t.Errorf("alice = %+v", alice)
+	}
+	if team.Format != FormatSlack || team.URL != "https://hooks.slack.com/services/T/B/X" {
+		t.Errorf("team format %q url %q", team.Format, team.URL)
+	}
+	if strings.Join(team.Events, ",") != "closed,p0_created" || team.BlockedDays != DefaultBlockedDays || team.Polled() {
+		t.Errorf("team defaults: events %v blocked_days %d", team.Events, team.BlockedDays)
+	}
+
+	for name, raw := range map[string]map[string]interface{}{
+		"missing url":   {"x": map[string]interface{}{"events": "closed"}},
+		"unset env url": {"x": map[string]interface{}{"url": "${BD_TEST_UNSET_URL}"}},
+		"bad event":     {"x": map[string]interface{}{"url": "https://e.com", "events": "opened"}},
+		"bad format":    {"x": map[string]interface{}{"url": "https://e.com", "format": "teams"}},
+		"bad setting":   {"x": map[string]interface{}{"url": "https://e.com", "retries": 3}},
+	} {
+		if _, err := ParseWebhooks(raw); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLifecycleEventAndRender(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	p0 := &types.Issue{ID: "bd-1", Title: "Prod down", Priority: 0, Status: types.StatusOpen, Assignee: "bob"}
+	if ev := LifecycleEvent("create", p0, now); ev == nil || ev.Kind != EventP0Created {
+		t.Fatalf("create P0: got %+v", ev)
+	}
+	if ev := LifecycleEvent("create", &types.Issue{ID: "bd-2", Priority: 2}, now); ev != nil {
+		t.Errorf("create P2: got %+v, want nil", ev)
+	}
+	if ev := LifecycleEvent("update", p0, now); ev != nil {
+		t.Errorf("update: got %+v, want nil", ev)
+	}
+
+	closed := &types.Issue{ID: "bd-1", Title: "Prod down", Status: types.StatusClosed, CloseReason: "fixed"}
+	ev := LifecycleEvent("close", closed, now)
+	if ev == nil || ev.Kind != EventClosed {
+		t.Fatalf("close: got %+v", ev)
+	}
+
+	generic, err := Render(FormatGeneric, ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(generic, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["event"] != "closed" || decoded["issue"].(map[string]interface{})["id"] != "bd-1" {
+		t.Errorf("generic payload = %s", generic)
+	}
+
+	slack, _ := Render(FormatSlack, ev)
+	if string(slack) != `{"text":"✓ Closed bd-1: Prod down (fixed)"}` {
+		t.Errorf("slack payload = %s", slack)
+	}
+
+	ready := &Event{Kind: EventReady, Assignee: "alice"}
+	for i := 0; i < 200; i++ {
+		ready.Issues = append(ready.Issues, IssueSummary{ID: "bd-x", Title: strings.Repeat("t", 20)})
+	}
+	discord, _ := Render(FormatDiscord, ready)
+	var msg map[string]string
+	_ = json.Unmarshal(discord, &msg)
+	if n := len([]rune(msg["content"])); n != discordContentLimit {
+		t.Errorf("discord content length = %d, want %d", n, discordContentLimit)
+	}
+}
+
+func TestWebhookWants(t *testing.T) {
+	w := &Webhook{Events: []string{EventReady}, Assignee: "alice"}
+	if !w.Wants(&Event{Kind: EventReady, Assignee: "alice"}) {
+		t.Error("want ready for alice")
+	}
+	if w.Wants(&Event{Kind: EventReady, Assignee: "bob"}) {
+		t.Error("ready for bob should be filtered out")
+	}
+	if w.Wants(&Event{Kind: EventClosed}) {
+		t.Error("closed is not subscribed")
+	}
+}
+
+func TestOutboxDelivery(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	}))
+	defer srv.Close()
+
+	hooks := []*Webhook{
+		{Name: "slack", URL: srv.URL, Format: FormatSlack, Events: []string{EventClosed}},
+		{Name: "p0", URL: srv.URL, Format: FormatGeneric, Events: []string{EventP0Created}},
+	}
+	ob := NewOutbox(t.TempDir())
+	issue := IssueSummary{ID: "bd-1", Title: "Done"}
+	n, err := ob.Enqueue(hooks, &Event{Kind: EventClosed, Issue: &issue})
+	if err != nil || n != 1 {
+		t.Fatalf("Enqueue = %d, %v; want 1", n, err)
+	}
+
+	res, err := ob.Flush(context.Background(), hooks)
+	if err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if res.Delivered != 1 || len(bodies) != 1 || !strings.Contains(bodies[0], `"text"`) {
+		t.Errorf("result %+v, bodies %v", res, bodies)
+	}
+	if pending, _ := ob.Pending(); len(pending) != 0 {
+		t.Errorf("pending after delivery = %+v", pending)
+	}
+}
+
+func TestOutboxRetryAndDeadLetter(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	hooks := []*Webhook{{Name: "h", URL: srv.URL, Format: FormatGeneric, Events: []string{EventClosed}}}
+	ob := NewOutbox(t.TempDir())
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ob.now = func() time.Time { return clock }
+	issue := IssueSummary{ID: "bd-1"}
+	if _, err := ob.Enqueue(hooks, &Event{Kind: EventClosed, Issue: &issue}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A 503 is retried with backoff; the item is not due again immediately.
+	res, err := ob.Flush(context.Background(), hooks)
+	if err != nil || res.Retrying != 1 {
+		t.Fatalf("first flush = %+v, %v", res, err)
+	}
+	if res, _ := ob.Flush(context.Background(), hooks); res != (FlushResult{}) {
+		t.Errorf("flush before backoff elapsed = %+v, want nothing attempted", res)
+	}
+	pending, _ := ob.Pending()
+	if len(pending) != 1 || pending[0].Attempts != 1 || !strings.Contains(pending[0].LastError, "503") {
+		t.Fatalf("pending = %+v", pending)
+	}
+
+	// Keep failing until the attempts are exhausted.
+	for i := 1; i < MaxAttempts; i++ {
+		clock = clock.Add(retryMax)
+		if _, err := ob.Flush(context.Background(), hooks); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pending, _ := ob.Pending(); len(pending) != 0 {
+		t.Errorf("pending after %d attempts = %+v", MaxAttempts, pending)
+	}
+	dead, _ := ob.DeadLetters()
+	if len(dead) != 1 || dead[0].Attempts != MaxAttempts || dead[0].FailedAt == nil {
+		t.Fatalf("dead letters = %+v", dead)
+	}
+
+	// A 4xx rejection is dead-lettered on the first attempt.
+	status.Store(http.StatusNotFound)
+	if _, err := ob.Enqueue(hooks, &Event{Kind: EventClosed, Issue: &issue}); err != nil {
+		t.Fatal(err)
+	}
+	if res, _ := ob.Flush(context.Background(), hooks); res.DeadLettered != 1 {
+		t.Errorf("404 flush = %+v, want dead-lettered", res)
+	}
+
+	// Items for a webhook that was removed from config are dead-lettered.
+	if _, err := ob.Enqueue(hooks, &Event{Kind: EventClosed, Issue: &issue}); err != nil {
+		t.Fatal(err)
+	}
+	if res, _ := ob.Flush(context.Background(), nil); res.DeadLettered != 1 {
+		t.Errorf("orphaned flush = %+v, want dead-lettered", res)
+	}
+	if dead, _ := ob.DeadLetters(); len(dead) != 3 {
+		t.Errorf("dead letters = %d, want 3", len(dead))
+	}
+}
+
+func TestOutboxState(t *testing.T) {
+	ob := NewOutbox(t.TempDir())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	w := &Webhook{Name: "h", Format: FormatGeneric, Events: []string{EventBlockedStale}}
+	issue := IssueSummary{ID: "bd-1"}
+	queued, err := ob.UpdateState(func(s *State) ([]Notification, error) {
+		s.LastCheck = now
+		s.Sent["h"] = []string{"blocked:bd-1"}
+		return []Notification{
+			{Webhook: w, Event: &Event{Kind: EventBlockedStale, Issue: &issue, BlockedDays: 4}},
+			{Webhook: w, Event: &Event{Kind: EventClosed, Issue: &issue}}, // not subscribed
+		}, nil
+	})
+	if err != nil || queued != 1 {
+		t.Fatalf("UpdateState = %d, %v; want 1 queued", queued, err)
+	}
+	state, err := ob.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.LastCheck.Equal(now) || len(state.Sent["h"]) != 1 {
+		t.Errorf("state = %+v", state)
+	}
+	if pending, _ := ob.Pending(); len(pending) != 1 || pending[0].Event != EventBlockedStale {
+		t.Errorf("pending = %+v", pending)
+	}
+}
@@ -0,0 +1,480 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/beads/internal/lockfile"
+)
+
+// Delivery tuning. Backoff doubles from retryBase up to retryMax, so an item
+// that keeps failing is dead-lettered after roughly 7.5 minutes of retries
+// spread over however many commands it takes to reach them.
+const (
+	MaxAttempts  = 5
+	retryBase    = 30 * time.Second
+	retryMax     = time.Hour
+	leaseTimeout = 2 * time.Minute
+	postTimeout  = 10 * time.Second
+)
+
+const (
+	outboxFile     = "outbox.jsonl"
+	deadLetterFile = "dead-letter.jsonl"
+	stateFile      = "state.json"
+	lockFile       = "notify.lock"
+)
+
+// Item is one queued delivery of an event to one webhook.
+type Item struct {
+	ID          string          `json:"id"`
+	Webhook     string          `json:"webhook"`
+	Event       string          `json:"event"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Attempts    int             `json:"attempts"`
+	NextAttempt time.Time       `json:"next_attempt"`
+	LastError   string          `json:"last_error,omitempty"`
+	FailedAt    *time.Time      `json:"failed_at,omitempty"`
+}
+
+// State remembers what the periodic scan has already reported so that
+// blocked_stale and ready notifications fire once per condition.
+type State struct {
+	LastCheck time.Time `json:"last_check"`
+	// Sent maps webhook name → keys of conditions already notified.
+	Sent map[string][]string `json:"sent,omitempty"`
+}
+
+// FlushResult summarizes one Flush.
+type FlushResult struct {
+	Delivered    int `json:"delivered"`
+	Retrying     int `json:"retrying"`
+	DeadLettered int `json:"dead_lettered"`
+}
+
+// Outbox is the on-disk delivery queue under .beads/notify/. All reads and
+// writes of its files happen under an exclusive file lock, but HTTP
+// requests are made with the lock released: due items are leased first so
+// a concurrent flush skips them.
+type Outbox struct {
+	dir    string
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewOutbox returns the outbox for a .beads directory.
+func NewOutbox(beadsDir string) *Outbox {
+	return &Outbox{
+		dir:    filepath.Join(beadsDir, "notify"),
+		client: &http.Client{Timeout: postTimeout},
+		now:    time.Now,
+	}
+}
+
+// Enqueue renders an event for every webhook subscribed to it and queues
+// the deliveries. It returns the number of items queued.
+func (o *Outbox) Enqueue(hooks []*Webhook, ev *Event) (int, error) {
+	items, err := o.render(hooks, ev)
+	if err != nil || len(items) == 0 {
+		return 0, err
+	}
+	err = o.withLock(func() error {
+		queued, err := readItems(o.path(outboxFile))
+		if err != nil {
+			return err
+		}
+		return writeItems(o.path(outboxFile), append(queued, items...))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+func (o *Outbox) render(hooks []*Webhook, ev *Event) ([]Item, error) {
+	var items []Item
+	for _, w := range hooks {
+		if !w.Wants(ev) {
+			continue
+		}
+		payload, err := Render(w.Format, ev)
+		if err != nil {
+			return nil, fmt.Errorf("render %s for %s: %w", ev.Kind, w.Name, err)
+		}
+		now := o.now().UTC()
+		items = append(items, Item{
+			ID:          newItemID(),
+			Webhook:     w.Name,
+			Event:       ev.Kind,
+			Payload:     payload,
+			CreatedAt:   now,
+			NextAttempt: now,
+		})
+	}
+	return items, nil
+}
+
+// Flush delivers every due item. Items for webhooks that are no longer
+// configured are dead-lettered; failures are rescheduled with exponential
+// backoff until MaxAttempts, or dead-lettered at once when the endpoint
+// rejects the request outright (a 4xx other than 408 or 429).
+func (o *Outbox) Flush(ctx context.Context, hooks []*Webhook) (FlushResult, error) {
+	var result FlushResult
+	byName := make(map[string]*Webhook, len(hooks))
+	for _, w := range hooks {
+		byName[w.Name] = w
+	}
+
+	// Lease due items so a concurrent flush does not send them twice.
+	var leased []Item
+	err := o.withLock(func() error {
+		queued, err := readItems(o.path(outboxFile))
+		if err != nil {
+			return err
+		}
+		now := o.now().UTC()
+		var keep, dead []Item
+		for _, item := range queued {
+			switch {
+			case byName[item.Webhook] == nil:
+				item.LastError = "webhook is no longer configured"
+				item.FailedAt = &now
+				dead = append(dead, item)
+				continue
+			case !item.NextAttempt.After(now):
+				leased = append(leased, item)
+				item.NextAttempt = now.Add(leaseTimeout)
+			}
+			keep = append(keep, item)
+		}
+		if len(dead) > 0 {
+			if err := appendItems(o.path(deadLetterFile), dead); err != nil {
+				return err
+			}
+			result.DeadLettered += len(dead)
+		}
+		if len(leased) == 0 && len(dead) == 0 {
+			return nil
+		}
+		return writeItems(o.path(outboxFile), keep)
+	})
+	if err != nil || len(leased) == 0 {
+		return result, err
+	}
+
+	leasedIDs := make(map[string]bool, len(leased))
+	outcomes := make(map[string]error, len(leased))
+	for _, item := range leased {
+		leasedIDs[item.ID] = true
+	}
+	for _, item := range leased {
+		if ctx.Err() != nil {
+			break
+		}
+		outcomes[item.ID] = o.post(ctx, byName[item.Webhook].URL, item.Payload)
+	}
+
+	err = o.withLock(func() error {
+		queued, err := readItems(o.path(outboxFile))
+		if err != nil {
+			return err
+		}
+		now := o.now().UTC()
+		var keep, dead []Item
+		for _, item := range queued {
+			postErr, attempted := outcomes[item.ID]
+			switch {
+			case !attempted:
+				// Leased but not attempted (ctx cancelled): due again now.
+				if leasedIDs[item.ID] {
+					item.NextAttempt = now
+				}
+				keep = append(keep, item)
+			case postErr == nil:
+				result.Delivered++
+			default:
+				item.Attempts++
+				item.LastError = postErr.Error()
+				if item.Attempts >= MaxAttempts || isPermanent(postErr) {
+					item.FailedAt = &now
+					dead = append(dead, item)
+					continue
+				}
+				item.NextAttempt = now.Add(backoff(item.Attempts))
+				keep = append(keep, item)
+				result.Retrying++
+			}
+		}
+		if len(dead) > 0 {
+			if err := appendItems(o.path(deadLetterFile), dead); err != nil {
+				return err
+			}
+			result.DeadLettered += len(dead)
+		}
+		return writeItems(o.path(outboxFile), keep)
+	})
+	return result, err
+}
+
+// Send delivers an event to one webhook immediately, bypassing the queue.
+// It is used by `bd notify test`.
+func (o *Outbox) Send(ctx context.Context, w *Webhook, ev *Event) error {
+	payload, err := Render(w.Format, ev)
+	if err != nil {
+		return err
+	}
+	return o.post(ctx, w.URL, payload)
+}
+
+// Pending returns the queued items.
+func (o *Outbox) Pending() ([]Item, error) {
+	var items []Item
+	err := o.withLock(func() (err error) {
+		items, err = readItems(o.path(outboxFile))
+		return err
+	})
+	return items, err
+}
+
+// DeadLetters returns the items that could not be delivered.
+func (o *Outbox) DeadLetters() ([]Item, error) {
+	var items []Item
+	err := o.withLock(func() (err error) {
+		items, err = readItems(o.path(deadLetterFile))
+		return err
+	})
+	return items, err
+}
+
+// Notification pairs an event with the webhook it is for.
+type Notification struct {
+	Webhook *Webhook
+	Event   *Event
+}
+
+// LoadState returns the scan state.
+func (o *Outbox) LoadState() (*State, error) {
+	var state *State
+	err := o.withLock(func() (err error) {
+		state, err = o.readState()
+		return err
+	})
+	return state, err
+}
+
+// UpdateState lets fn modify the scan state and queues the notifications
+// it returns, saving both under one hold of the outbox lock so a condition
+// is never marked as sent without its notification being queued. It
+// returns the number of items queued.
+func (o *Outbox) UpdateState(fn func(*State) ([]Notification, error)) (int, error) {
+	queued := 0
+	err := o.withLock(func() error {
+		state, err := o.readState()
+		if err != nil {
+			return err
+		}
+		notifications, err := fn(state)
+		if err != nil {
+			return err
+		}
+		var items []Item
+		for _, n := range notifications {
+			rendered, err := o.render([]*Webhook{n.Webhook}, n.Event)
+			if err != nil {
+				return err
+			}
+			items = append(items, rendered...)
+		}
+		if len(items) > 0 {
+			existing, err := readItems(o.path(outboxFile))
+			if err != nil {
+				return err
+			}
+			if err := writeItems(o.path(outboxFile), append(existing, items...)); err != nil {
+				return err
+			}
+		}
+		data, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			return err
+		}
+		queued = len(items)
+		return writeFileAtomic(o.path(stateFile), append(data, '\n'))
+	})
+	return queued, err
+}
+
+func (o *Outbox) readState() (*State, error) {
+	state := &State{Sent: map[string][]string{}}
+	data, err := os.ReadFile(o.path(stateFile))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read notify state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse notify state: %w", err)
+	}
+	if state.Sent == nil {
+		state.Sent = map[string][]string{}
+	}
+	return state, nil
+}
+
+// httpError is a non-2xx response.
+type httpError struct {
+	status int
+	body   string
+}
+
+func (e *httpError) Error() string {
+	if e.body == "" {
+		return fmt.Sprintf("HTTP %d", e.status)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.status, e.body)
+}
+
+func isPermanent(err error) bool {
+	var he *httpError
+	if !errors.As(err, &he) {
+		return false
+	}
+	return he.status >= 400 && he.status < 500 && he.status != http.StatusRequestTimeout && he.status != http.StatusTooManyRequests
+}
+
+func backoff(attempts int) time.Duration {
+	d := retryBase << (attempts - 1)
+	if d > retryMax || d <= 0 {
+		return retryMax
+	}
+	return d
+}
+
+func (o *Outbox) post(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bd-notify")
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &httpError{status: resp.StatusCode, body: string(bytes.TrimSpace(body))}
+	}
+	return nil
+}
+
+func (o *Outbox) path(name string) string {
+	return filepath.Join(o.dir, name)
+}
+
+func (o *Outbox) withLock(fn func() error) error {
+	if err := os.MkdirAll(o.dir, 0o750); err != nil {
+		return fmt.Errorf("create notify directory: %w", err)
+	}
+	// #nosec G304 -- path is inside the .beads directory
+	f, err := os.OpenFile(o.path(lockFile), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("open notify lock: %w", err)
+	}
+	defer f.Close()
+	if err := lockfile.FlockExclusiveBlocking(f); err != nil {
+		return fmt.Errorf("lock notify outbox: %w", err)
+	}
+	defer func() { _ = lockfile.FlockUnlock(f) }()
+	return fn()
+}
+
+func readItems(path string) ([]Item, error) {
+	// #nosec G304 -- path is inside the .beads directory
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var items []Item
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var item Item
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filepath.Base(path), line, err)
+		}
+		items = append(items, item)
+	}
+	return items, scanner.Err()
+}
+
+func encodeItems(items []Item) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func writeItems(path string, items []Item) error {
+	data, err := encodeItems(items)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+func appendItems(path string, items []Item) error {
+	data, err := encodeItems(items)
+	if err != nil {
+		return err
+	}
+	// #nosec G304 -- path is inside the .beads directory
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func newItemID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +35,7 @@ type QueryResult struct {
 // Evaluator converts a query AST to an IssueFilter and/or predicate function.
 type Evaluator struct {
 	now time.Time
+	me  func() string
 }
 
 // NewEvaluator creates a new Evaluator with the given reference time.
@@ -41,6 +43,23 @@ func NewEvaluator(now time.Time) *Evaluator {
 	return &Evaluator{now: now}
 }
 
+// WithMe sets how assignee=me is resolved. The function is only called when
+// a query uses "me"; without it, "me" matches the literal name.
+func (e *Evaluator) WithMe(me func() string) *Evaluator {
+	e.me = me
+	return e
+}
+
+// assigneeValue resolves "me" (any case) to the current user.
+func (e *Evaluator) assigneeValue(value string) string {
+	if e.me != nil && strings.EqualFold(value, "me") {
+		if me := e.me(); me != "" {
+			return me
+		}
+	}
+	return value
+}
+
 // Evaluate evaluates the query AST and returns a QueryResult.
 func (e *Evaluator) Evaluate(node Node) (*QueryResult, error) {
 	result := &QueryResult{
@@ -56,14 +75,15 @@ func (e *Evaluator) Evaluate(node Node) (*QueryResult, error) {
 	}
 
 	// Complex query: build predicate and extract base filters
-	pred, err := e.buildPredicate(node)
+	pred, err := e.buildTopPredicate(node)
 	if err != nil {
 		return nil, err
 	}
 	result.Predicate = pred
 	result.RequiresPredicate = true
 
-	// Extract base filters for pre-filtering (optional optimization)
+	// Extract base filters for pre-filtering (optional optimization; required
+	// for the database-only fields the predicate skips)
 	e.extractBaseFilters(node, &result.Filter)
 
 	return result, nil
@@ -77,16 +97,23 @@ func (e *Evaluator) Evaluate(node Node) (*QueryResult, error) {
 func (e *Evaluator) canUseFilterOnly(node Node) bool {
 	switch n := node.(type) {
 	case *ComparisonNode:
-		return true
+		// label!=none has no filter form
+		if isLabelField(n.Field) && n.Op == OpNotEquals && isNoneValue(n.Value) {
+			return false
+		}
+		// metadata.<key> only has a filter form for equality
+		return !(strings.HasPrefix(n.Field, "metadata.") && n.Op != OpEquals)
 	case *AndNode:
 		return e.canUseFilterOnly(n.Left) && e.canUseFilterOnly(n.Right)
 	case *NotNode:
 		// NOT is only filter-compatible for certain fields
 		if comp, ok := n.Operand.(*ComparisonNode); ok {
-			switch comp.Field {
-			case "status":
+			switch {
+			case comp.Field == "status", comp.Field == "type":
 				return comp.Op == OpEquals
-			case "type":
+			case isLabelField(comp.Field):
+				return comp.Op == OpEquals && !isNoneValue(comp.Value) && !isGlob(comp.Value)
+			case comp.Field == "blocked":
 				return comp.Op == OpEquals
 			default:
 				return false
@@ -94,31 +121,50 @@ func (e *Evaluator) canUseFilterOnly(node Node) bool {
 		}
 		return false
 	case *OrNode:
-		// OR can be filter-compatible for labels
-		return e.canUseLabelsAnyOptimization(n)
+		// OR can be filter-compatible for value lists of a single field
+		field, _ := e.collectOrList(n)
+		return field != ""
 	default:
 		return false
 	}
 }
 
-// canUseLabelsAnyOptimization checks if an OR node can use LabelsAny.
-func (e *Evaluator) canUseLabelsAnyOptimization(node *OrNode) bool {
-	labels := e.collectOrLabels(node)
-	return len(labels) > 0
+// orListFields are the fields whose OR chains compile to a single IN
+// filter: LabelsAny, Assignees and Statuses.
+var orListFields = []string{"label", "assignee", "status"}
+
+// collectOrList returns the field and values of an OR chain of field=X
+// comparisons on one of orListFields (as written by label=a,b), or "" when
+// the chain needs predicate filtering.
+func (e *Evaluator) collectOrList(node *OrNode) (string, []string) {
+	for _, field := range orListFields {
+		if values := e.collectOrValues(node, field); len(values) > 0 {
+			return field, values
+		}
+	}
+	return "", nil
 }
 
-// collectOrLabels collects label values from an OR chain of label=X comparisons.
-// Returns nil if the OR chain contains non-label comparisons.
-func (e *Evaluator) collectOrLabels(node Node) []string {
+// collectOrValues collects the values from an OR chain of field=X
+// comparisons. Returns nil if the chain contains any other comparison, or a
+// value (none, a glob, an invalid status) the IN filter cannot express.
+func (e *Evaluator) collectOrValues(node Node, field string) []string {
 	switch n := node.(type) {
 	case *ComparisonNode:
-		if (n.Field == "label" || n.Field == "labels") && n.Op == OpEquals {
-			return []string{n.Value}
+		name := n.Field
+		if isLabelField(name) {
+			name = "label"
 		}
-		return nil
+		if name != field || n.Op != OpEquals || isNoneValue(n.Value) || isGlob(n.Value) {
+			return nil
+		}
+		if field == "status" && !types.Status(strings.ToLower(n.Value)).IsValid() {
+			return nil
+		}
+		return []string{n.Value}
 	case *OrNode:
-		left := e.collectOrLabels(n.Left)
-		right := e.collectOrLabels(n.Right)
+		left := e.collectOrValues(n.Left, field)
+		right := e.collectOrValues(n.Right, field)
 		if left == nil || right == nil {
 			return nil
 		}
@@ -128,6 +174,20 @@ func (e *Evaluator) collectOrLabels(node Node) []string {
 	}
 }
 
+func isLabelField(field string) bool {
+	return field == "label" || field == "labels"
+}
+
+// isNoneValue reports whether a value means "unset" (none, null, or empty).
+func isNoneValue(value string) bool {
+	return value == "" || strings.EqualFold(value, "none") || strings.EqualFold(value, "null")
+}
+
+// isGlob reports whether a label value is a glob pattern such as tech-*.
+func isGlob(value string) bool {
+	return strings.ContainsAny(value, "*?")
+}
+
 // buildFilter populates the IssueFilter from a filter-compatible AST.
 func (e *Evaluator) buildFilter(node Node, filter *types.IssueFilter) error {
 	switch n := node.(type) {
@@ -141,13 +201,22 @@ func (e *Evaluator) buildFilter(node Node, filter *types.IssueFilter) error {
 	case *NotNode:
 		return e.applyNot(n, filter)
 	case *OrNode:
-		// Only reached for LabelsAny optimization
-		labels := e.collectOrLabels(n)
-		if labels != nil {
-			filter.LabelsAny = append(filter.LabelsAny, labels...)
-			return nil
+		// Only reached for value lists of orListFields
+		switch field, values := e.collectOrList(n); field {
+		case "label":
+			filter.LabelsAny = append(filter.LabelsAny, values...)
+		case "assignee":
+			for _, v := range values {
+				filter.Assignees = append(filter.Assignees, e.assigneeValue(v))
+			}
+		case "status":
+			for _, v := range values {
+				filter.Statuses = append(filter.Statuses, types.Status(strings.ToLower(v)))
+			}
+		default:
+			return fmt.Errorf("OR not supported for this field combination")
 		}
-		return fmt.Errorf("OR not supported for this field combination")
+		return nil
 	default:
 		return fmt.Errorf("unexpected node type: %T", node)
 	}
@@ -182,6 +251,16 @@ func (e *Evaluator) applyComparison(comp *ComparisonNode, filter *types.IssueFil
 		return e.applyClosedFilter(comp, filter)
 	case "started", "started_at":
 		return e.applyStartedFilter(comp, filter)
+	case "due", "due_at":
+		return e.applyTimeRangeFilter(comp, "due", &filter.DueAfter, &filter.DueBefore)
+	case "defer", "defer_until":
+		return e.applyTimeRangeFilter(comp, "defer", &filter.DeferAfter, &filter.DeferBefore)
+	case "blocks":
+		return e.applyBlocksFilter(comp, &filter.BlocksIDs)
+	case "blocked_by":
+		return e.applyBlocksFilter(comp, &filter.BlockedByIDs)
+	case "blocked":
+		return e.applyBoolFilter(comp, filter, "blocked")
 	case "id":
 		return e.applyIDFilter(comp, filter)
 	case "spec", "spec_id":
@@ -279,7 +358,8 @@ func (e *Evaluator) applyAssigneeFilter(comp *ComparisonNode, filter *types.Issu
 	if comp.Value == "" || strings.ToLower(comp.Value) == "none" || strings.ToLower(comp.Value) == "null" {
 		filter.NoAssignee = true
 	} else {
-		filter.Assignee = &comp.Value
+		value := e.assigneeValue(comp.Value)
+		filter.Assignee = &value
 	}
 	return nil
 }
@@ -290,12 +370,22 @@ func (e *Evaluator) applyOwnerFilter(comp *ComparisonNode, filter *types.IssueFi
 }
 
 func (e *Evaluator) applyLabelFilter(comp *ComparisonNode, filter *types.IssueFilter) error {
-	if comp.Op != OpEquals {
-		return fmt.Errorf("label only supports = operator")
-	}
-	if comp.Value == "" || strings.ToLower(comp.Value) == "none" || strings.ToLower(comp.Value) == "null" {
+	switch {
+	case comp.Op == OpNotEquals:
+		if isNoneValue(comp.Value) || isGlob(comp.Value) {
+			return fmt.Errorf("label!=%s requires predicate filtering", comp.Value)
+		}
+		filter.ExcludeLabels = append(filter.ExcludeLabels, comp.Value)
+	case comp.Op != OpEquals:
+		return fmt.Errorf("label only supports = and != operators")
+	case isNoneValue(comp.Value):
 		filter.NoLabels = true
-	} else {
+	case isGlob(comp.Value):
+		if filter.LabelPattern != "" {
+			return fmt.Errorf("only one label pattern per query (got %s and %s)", filter.LabelPattern, comp.Value)
+		}
+		filter.LabelPattern = comp.Value
+	default:
 		filter.Labels = append(filter.Labels, comp.Value)
 	}
 	return nil
@@ -424,6 +514,42 @@ func (e *Evaluator) applyStartedFilter(comp *ComparisonNode, filter *types.Issue
 	return nil
 }
 
+// applyTimeRangeFilter applies a comparison on a nullable timestamp column
+// with after/before filter bounds, such as due and defer.
+func (e *Evaluator) applyTimeRangeFilter(comp *ComparisonNode, name string, after, before **time.Time) error {
+	t, err := e.parseTimeValue(comp)
+	if err != nil {
+		return fmt.Errorf("invalid %s time: %w", name, err)
+	}
+	switch comp.Op {
+	case OpEquals:
+		dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		dayEnd := dayStart.Add(24 * time.Hour)
+		*after = &dayStart
+		*before = &dayEnd
+	case OpGreater, OpGreaterEq:
+		*after = &t
+	case OpLess:
+		*before = &t
+	case OpLessEq:
+		endOfDay := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
+		*before = &endOfDay
+	default:
+		return fmt.Errorf("%s does not support %s operator", name, comp.Op.String())
+	}
+	return nil
+}
+
+// applyBlocksFilter handles blocks:ID and blocked-by:ID, which compile to
+// dependency subqueries.
+func (e *Evaluator) applyBlocksFilter(comp *ComparisonNode, ids *[]string) error {
+	if comp.Op != OpEquals {
+		return fmt.Errorf("%s only supports = operator", comp.Field)
+	}
+	*ids = append(*ids, comp.Value)
+	return nil
+}
+
 func (e *Evaluator) applyIDFilter(comp *ComparisonNode, filter *types.IssueFilter) error {
 	if comp.Op != OpEquals {
 		return fmt.Errorf("id only supports = operator")
@@ -480,6 +606,8 @@ func (e *Evaluator) applyBoolFilter(comp *ComparisonNode, filter *types.IssueFil
 		filter.Ephemeral = &boolVal
 	case "template":
 		filter.IsTemplate = &boolVal
+	case "blocked":
+		filter.IsBlocked = &boolVal
 	}
 	return nil
 }
@@ -524,36 +652,47 @@ func (e *Evaluator) applyHasMetadataKeyFilter(comp *ComparisonNode, filter *type
 	return nil
 }
 
-// buildMetadataPredicate builds a predicate for metadata.<key>=<value> in OR queries.
-// Parses the issue's JSON metadata and compares the top-level scalar at the given key.
+// buildMetadataPredicate builds a predicate for metadata.<key> comparisons in
+// OR queries and for the ordering operators, which have no filter form.
+// Ordering compares numerically when both sides are numbers, by time when the
+// stored value is a date (so metadata.due<+7d works), and as strings
+// otherwise. An issue without the key only matches !=.
 func (e *Evaluator) buildMetadataPredicate(comp *ComparisonNode) (func(*types.Issue) bool, error) {
-	if comp.Op != OpEquals {
-		return nil, fmt.Errorf("metadata fields only support = operator")
-	}
 	key := strings.TrimPrefix(comp.Field, "metadata.")
 	if err := storage.ValidateMetadataKey(key); err != nil {
 		return nil, err
 	}
 	value := comp.Value
+	target, targetErr := e.parseTimeValue(comp)
 	return func(i *types.Issue) bool {
-		if len(i.Metadata) == 0 {
-			return false
+		actual, ok := storage.LookupMetadataValue(i.Metadata, key)
+		if !ok {
+			return comp.Op == OpNotEquals
 		}
-		var data map[string]json.RawMessage
-		if err := json.Unmarshal(i.Metadata, &data); err != nil {
-			return false
+		switch comp.Op {
+		case OpEquals:
+			return actual == value
+		case OpNotEquals:
+			return actual != value
 		}
-		raw, ok := data[key]
-		if !ok {
-			return false
+		var c int
+		if t, isDate := storage.ParseMetadataDate(actual); isDate && targetErr == nil {
+			c = t.Compare(target)
+		} else {
+			c = storage.CompareMetadataValues(actual, value)
 		}
-		// Try to unmarshal as a string first (most common case)
-		var s string
-		if err := json.Unmarshal(raw, &s); err == nil {
-			return s == value
+		switch comp.Op {
+		case OpLess:
+			return c < 0
+		case OpLessEq:
+			return c <= 0
+		case OpGreater:
+			return c > 0
+		case OpGreaterEq:
+			return c >= 0
+		default:
+			return false
 		}
-		// Fall back to comparing the raw JSON representation (numbers, bools)
-		return strings.Trim(string(raw), "\"") == value
 	}, nil
 }
 
@@ -601,13 +740,29 @@ func (e *Evaluator) applyNot(not *NotNode, filter *types.IssueFilter) error {
 		issueType := types.IssueType(strings.ToLower(comp.Value))
 		filter.ExcludeTypes = append(filter.ExcludeTypes, issueType)
 		return nil
+	case "label", "labels":
+		if comp.Op != OpEquals || isNoneValue(comp.Value) || isGlob(comp.Value) {
+			return fmt.Errorf("NOT label only supports = with a plain label")
+		}
+		filter.ExcludeLabels = append(filter.ExcludeLabels, comp.Value)
+		return nil
+	case "blocked":
+		var positive types.IssueFilter
+		if err := e.applyBoolFilter(comp, &positive, "blocked"); err != nil {
+			return err
+		}
+		negated := !*positive.IsBlocked
+		filter.IsBlocked = &negated
+		return nil
 	default:
 		return fmt.Errorf("NOT not supported for field %s in filter mode", comp.Field)
 	}
 }
 
 // parseTimeValue parses a time value from a comparison node.
-// Supports duration values (7d, 24h) which are interpreted as "now - duration".
+// Supports duration values (7d, 24h) which are interpreted as "now - duration";
+// signed durations are offsets from now, so -30d is 30 days ago and +3d is
+// three days from now.
 func (e *Evaluator) parseTimeValue(comp *ComparisonNode) (time.Time, error) {
 	if comp.ValueType == TokenDuration {
 		// Duration values like 7d mean "7 days ago" for < comparisons
@@ -619,11 +774,14 @@ func (e *Evaluator) parseTimeValue(comp *ComparisonNode) (time.Time, error) {
 	return timeparsing.ParseRelativeTime(comp.Value, e.now)
 }
 
-// parseDurationAgo parses a duration and returns now - duration.
+// parseDurationAgo parses an unsigned duration as now - duration, and a
+// signed one as an offset from now.
 func (e *Evaluator) parseDurationAgo(s string) (time.Time, error) {
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return timeparsing.ParseCompactDuration(s, e.now)
+	}
 	// Negate the duration to get time in the past
-	negated := "-" + strings.TrimPrefix(s, "+")
-	return timeparsing.ParseCompactDuration(negated, e.now)
+	return timeparsing.ParseCompactDuration("-"+s, e.now)
 }
 
 // extractBaseFilters extracts filter-compatible portions from a complex query.
@@ -644,6 +802,54 @@ func (e *Evaluator) extractBaseFilters(node Node, filter *types.IssueFilter) {
 	}
 }
 
+// Predicate returns an in-memory predicate for the whole query, for callers
+// that already hold the issues (with labels loaded) rather than searching
+// the store. Fields that only have a filter form, such as parent and
+// mol_type, are rejected as unknown.
+func (e *Evaluator) Predicate(node Node) (func(*types.Issue) bool, error) {
+	return e.buildPredicate(node)
+}
+
+// dbOnlyFields are answered by the database alone: the dependency graph is
+// not loaded on the issues a predicate sees.
+var dbOnlyFields = map[string]bool{"blocks": true, "blocked_by": true, "blocked": true}
+
+// buildTopPredicate builds the predicate for a complex query. Database-only
+// comparisons in the top-level AND chain are left to the base filter (see
+// extractBaseFilters) and match everything here; anywhere else they are an
+// error.
+func (e *Evaluator) buildTopPredicate(node Node) (func(*types.Issue) bool, error) {
+	switch n := node.(type) {
+	case *ComparisonNode:
+		if dbOnlyFields[n.Field] {
+			if err := e.applyComparison(n, &types.IssueFilter{}); err != nil {
+				return nil, err
+			}
+			return func(*types.Issue) bool { return true }, nil
+		}
+	case *NotNode:
+		if comp, ok := n.Operand.(*ComparisonNode); ok && dbOnlyFields[comp.Field] {
+			if err := e.applyNot(n, &types.IssueFilter{}); err != nil {
+				return nil, err
+			}
+			return func(*types.Issue) bool { return true }, nil
+		}
+	case *AndNode:
+		left, err := e.buildTopPredicate(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := e.buildTopPredicate(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(issue *types.Issue) bool {
+			return left(issue) && right(issue)
+		}, nil
+	}
+	return e.buildPredicate(node)
+}
+
 // buildPredicate builds a predicate function for complex queries.
 func (e *Evaluator) buildPredicate(node Node) (func(*types.Issue) bool, error) {
 	switch n := node.(type) {
@@ -715,6 +921,12 @@ func (e *Evaluator) buildComparisonPredicate(comp *ComparisonNode) (func(*types.
 		return e.buildClosedPredicate(comp)
 	case "started", "started_at":
 		return e.buildStartedPredicate(comp)
+	case "due", "due_at":
+		return e.buildOptionalTimePredicate(comp, "due", func(i *types.Issue) *time.Time { return i.DueAt })
+	case "defer", "defer_until":
+		return e.buildOptionalTimePredicate(comp, "defer", func(i *types.Issue) *time.Time { return i.DeferUntil })
+	case "blocks", "blocked_by", "blocked":
+		return nil, fmt.Errorf("%s cannot be used under OR or NOT (only combined with AND)", comp.Field)
 	case "id":
 		return e.buildIDPredicate(comp)
 	case "spec", "spec_id":
@@ -783,19 +995,31 @@ func (e *Evaluator) buildTypePredicate(comp *ComparisonNode) (func(*types.Issue)
 }
 
 func (e *Evaluator) buildAssigneePredicate(comp *ComparisonNode) (func(*types.Issue) bool, error) {
-	value := comp.Value
+	value := e.assigneeValue(comp.Value)
 	isNone := value == "" || strings.ToLower(value) == "none" || strings.ToLower(value) == "null"
+	// Co-assignees only count when the caller has populated Assignees.
+	assignedTo := func(i *types.Issue) bool {
+		if strings.EqualFold(i.Assignee, value) {
+			return true
+		}
+		for _, a := range i.Assignees {
+			if strings.EqualFold(a, value) {
+				return true
+			}
+		}
+		return false
+	}
 	switch comp.Op {
 	case OpEquals:
 		if isNone {
 			return func(i *types.Issue) bool { return i.Assignee == "" }, nil
 		}
-		return func(i *types.Issue) bool { return strings.EqualFold(i.Assignee, value) }, nil
+		return assignedTo, nil
 	case OpNotEquals:
 		if isNone {
 			return func(i *types.Issue) bool { return i.Assignee != "" }, nil
 		}
-		return func(i *types.Issue) bool { return !strings.EqualFold(i.Assignee, value) }, nil
+		return func(i *types.Issue) bool { return !assignedTo(i) }, nil
 	default:
 		return nil, fmt.Errorf("assignee does not support %s operator", comp.Op.String())
 	}
@@ -816,6 +1040,17 @@ func (e *Evaluator) buildOwnerPredicate(comp *ComparisonNode) (func(*types.Issue
 func (e *Evaluator) buildLabelPredicate(comp *ComparisonNode) (func(*types.Issue) bool, error) {
 	value := comp.Value
 	isNone := value == "" || strings.ToLower(value) == "none" || strings.ToLower(value) == "null"
+	matches := func(l string) bool { return strings.EqualFold(l, value) }
+	if isGlob(value) {
+		pattern := strings.ToLower(value)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid label pattern %q: %w", value, err)
+		}
+		matches = func(l string) bool {
+			ok, _ := path.Match(pattern, strings.ToLower(l))
+			return ok
+		}
+	}
 	switch comp.Op {
 	case OpEquals:
 		if isNone {
@@ -823,7 +1058,7 @@ func (e *Evaluator) buildLabelPredicate(comp *ComparisonNode) (func(*types.Issue
 		}
 		return func(i *types.Issue) bool {
 			for _, l := range i.Labels {
-				if strings.EqualFold(l, value) {
+				if matches(l) {
 					return true
 				}
 			}
@@ -835,7 +1070,7 @@ func (e *Evaluator) buildLabelPredicate(comp *ComparisonNode) (func(*types.Issue
 		}
 		return func(i *types.Issue) bool {
 			for _, l := range i.Labels {
-				if strings.EqualFold(l, value) {
+				if matches(l) {
 					return false
 				}
 			}
@@ -943,6 +1178,19 @@ func (e *Evaluator) buildStartedPredicate(comp *ComparisonNode) (func(*types.Iss
 	}, nil
 }
 
+// buildOptionalTimePredicate builds a predicate on a nullable timestamp;
+// issues without one never match.
+func (e *Evaluator) buildOptionalTimePredicate(comp *ComparisonNode, name string, getter func(*types.Issue) *time.Time) (func(*types.Issue) bool, error) {
+	t, err := e.parseTimeValue(comp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s time: %w", name, err)
+	}
+	return func(i *types.Issue) bool {
+		v := getter(i)
+		return v != nil && e.compareTime(comp.Op, *v, t)
+	}, nil
+}
+
 func (e *Evaluator) buildTimePredicate(op ComparisonOp, t time.Time, getter func(*types.Issue) time.Time) (func(*types.Issue) bool, error) {
 	return func(i *types.Issue) bool {
 		return e.compareTime(op, getter(i), t)
@@ -171,16 +171,19 @@ func (p *Parser) parseOr() (Node, error) {
 	return left, nil
 }
 
-// parseAnd parses AND expressions.
+// parseAnd parses AND expressions. Juxtaposed terms are an implicit AND,
+// so "status=open label=stale" means "status=open AND label=stale".
 func (p *Parser) parseAnd() (Node, error) {
 	left, err := p.parseNot()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.current.Type == TokenAnd {
-		if err := p.advance(); err != nil {
-			return nil, err
+	for p.current.Type == TokenAnd || p.startsTerm() {
+		if p.current.Type == TokenAnd {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
 		}
 		right, err := p.parseNot()
 		if err != nil {
@@ -192,6 +195,15 @@ func (p *Parser) parseAnd() (Node, error) {
 	return left, nil
 }
 
+// startsTerm reports whether the current token can begin a term.
+func (p *Parser) startsTerm() bool {
+	switch p.current.Type {
+	case TokenIdent, TokenNot, TokenLParen:
+		return true
+	}
+	return false
+}
+
 // parseNot parses NOT expressions.
 func (p *Parser) parseNot() (Node, error) {
 	if p.current.Type == TokenNot {
@@ -230,13 +242,15 @@ func (p *Parser) parsePrimary() (Node, error) {
 	return p.parseComparison()
 }
 
-// parseComparison parses a field comparison.
+// parseComparison parses a field comparison, a comma-separated value list,
+// or a bare dependency term.
 func (p *Parser) parseComparison() (Node, error) {
 	if p.current.Type != TokenIdent {
 		return nil, fmt.Errorf("expected field name at position %d, got %s", p.current.Pos, p.current.Type.String())
 	}
 
-	field := strings.ToLower(p.current.Value)
+	ident := p.current
+	field := strings.ToLower(ident.Value)
 	if err := p.advance(); err != nil {
 		return nil, err
 	}
@@ -256,6 +270,9 @@ func (p *Parser) parseComparison() (Node, error) {
 	case TokenGreaterEq:
 		op = OpGreaterEq
 	default:
+		if node := bareTerm(ident.Value); node != nil {
+			return node, nil
+		}
 		return nil, fmt.Errorf("expected comparison operator at position %d, got %s", p.current.Pos, p.current.Type.String())
 	}
 
@@ -263,36 +280,80 @@ func (p *Parser) parseComparison() (Node, error) {
 		return nil, err
 	}
 
-	// Value can be identifier, string, number, or duration
-	var value string
-	var valueType TokenType
-	switch p.current.Type {
-	case TokenIdent:
-		value = p.current.Value
-		valueType = TokenIdent
-	case TokenString:
-		value = p.current.Value
-		valueType = TokenString
-	case TokenNumber:
-		value = p.current.Value
-		valueType = TokenNumber
-	case TokenDuration:
-		value = p.current.Value
-		valueType = TokenDuration
-	default:
-		return nil, fmt.Errorf("expected value at position %d, got %s", p.current.Pos, p.current.Type.String())
+	comps := []*ComparisonNode{}
+	for {
+		value, valueType, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		comps = append(comps, &ComparisonNode{
+			Field:     field,
+			Op:        op,
+			Value:     value,
+			ValueType: valueType,
+		})
+		if p.current.Type != TokenComma {
+			break
+		}
+		if op != OpEquals && op != OpNotEquals {
+			return nil, fmt.Errorf("value lists only support = and != (position %d)", p.current.Pos)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
 	}
 
+	// field=a,b matches any value; field!=a,b matches none of them.
+	var node Node = comps[0]
+	for _, comp := range comps[1:] {
+		if op == OpEquals {
+			node = &OrNode{Left: node, Right: comp}
+		} else {
+			node = &AndNode{Left: node, Right: comp}
+		}
+	}
+	return node, nil
+}
+
+// parseValue parses the value side of a comparison: an identifier, string,
+// number, or duration.
+func (p *Parser) parseValue() (string, TokenType, error) {
+	tok := p.current
+	switch tok.Type {
+	case TokenIdent, TokenString, TokenNumber, TokenDuration:
+	default:
+		return "", 0, fmt.Errorf("expected value at position %d, got %s", tok.Pos, tok.Type.String())
+	}
 	if err := p.advance(); err != nil {
-		return nil, err
+		return "", 0, err
 	}
+	return tok.Value, tok.Type, nil
+}
 
-	return &ComparisonNode{
-		Field:     field,
-		Op:        op,
-		Value:     value,
-		ValueType: valueType,
-	}, nil
+// bareTerm expands the operator-less dependency terms: "blocks:ID",
+// "blocked-by:ID", "blocked" and "no-blockers". It returns nil for any
+// other identifier.
+func bareTerm(ident string) Node {
+	name, id, hasID := strings.Cut(ident, ":")
+	switch strings.ToLower(name) {
+	case "blocks":
+		if hasID && id != "" {
+			return &ComparisonNode{Field: "blocks", Op: OpEquals, Value: id, ValueType: TokenIdent}
+		}
+	case "blocked-by", "blocked_by":
+		if hasID && id != "" {
+			return &ComparisonNode{Field: "blocked_by", Op: OpEquals, Value: id, ValueType: TokenIdent}
+		}
+	case "blocked":
+		if !hasID {
+			return &ComparisonNode{Field: "blocked", Op: OpEquals, Value: "true", ValueType: TokenIdent}
+		}
+	case "no-blockers", "no_blockers":
+		if !hasID {
+			return &ComparisonNode{Field: "blocked", Op: OpEquals, Value: "false", ValueType: TokenIdent}
+		}
+	}
+	return nil
 }
 
 // Parse is a convenience function that parses a query string.
@@ -315,17 +376,28 @@ var KnownFields = map[string]bool{
 	"owner":       true,
 
 	// Timestamps
-	"created":    true,
-	"updated":    true,
-	"closed":     true,
-	"created_at": true, // alias
-	"updated_at": true, // alias
-	"closed_at":  true, // alias
+	"created":     true,
+	"updated":     true,
+	"closed":      true,
+	"created_at":  true, // alias
+	"updated_at":  true, // alias
+	"closed_at":   true, // alias
+	"started":     true,
+	"started_at":  true, // alias
+	"due":         true,
+	"due_at":      true, // alias
+	"defer":       true,
+	"defer_until": true, // alias
 
 	// Labels
 	"label":  true,
 	"labels": true, // alias
 
+	// Dependencies
+	"blocks":     true,
+	"blocked_by": true,
+	"blocked":    true,
+
 	// Flags
 	"pinned":    true,
 	"ephemeral": true,
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -232,6 +233,26 @@ func TestParser(t *testing.T) {
 			input:    "status=open OR priority>1 AND type=bug",
 			expected: "(status=open OR (priority>1 AND type=bug))",
 		},
+		{
+			name:     "implicit AND",
+			input:    "status=open label=stale NOT type=epic",
+			expected: "((status=open AND label=stale) AND NOT type=epic)",
+		},
+		{
+			name:     "implicit AND binds tighter than OR",
+			input:    "status=open OR priority>1 type=bug",
+			expected: "(status=open OR (priority>1 AND type=bug))",
+		},
+		{
+			name:     "value list",
+			input:    "label=a,b assignee!=x,y",
+			expected: "((label=a OR label=b) AND (assignee!=x AND assignee!=y))",
+		},
+		{
+			name:     "dependency terms",
+			input:    "blocks:bd-12 blocked-by:bd-7 no-blockers",
+			expected: "((blocks=bd-12 AND blocked_by=bd-7) AND blocked=false)",
+		},
 		{
 			name:     "NOT with parentheses",
 			input:    "NOT (status=closed OR status=deferred)",
@@ -449,6 +470,65 @@ func TestEvaluatorSimpleQueries(t *testing.T) {
 				return len(f.LabelsAny) == 2
 			},
 		},
+		{
+			name:  "label list uses LabelsAny",
+			query: "label=frontend,backend",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return reflect.DeepEqual(f.LabelsAny, []string{"frontend", "backend"})
+			},
+		},
+		{
+			name:  "label exclusion list",
+			query: "label!=wontfix,dup NOT label=stale",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return reflect.DeepEqual(f.ExcludeLabels, []string{"wontfix", "dup", "stale"})
+			},
+		},
+		{
+			name:  "label glob",
+			query: `label="tech-*"`,
+			expectFilter: func(f *types.IssueFilter) bool {
+				return f.LabelPattern == "tech-*"
+			},
+		},
+		{
+			name:  "assignee list",
+			query: "assignee=alice,bob",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return reflect.DeepEqual(f.Assignees, []string{"alice", "bob"})
+			},
+		},
+		{
+			name:  "status list",
+			query: "status=open,in_progress",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return len(f.Statuses) == 2 && f.Statuses[1] == types.StatusInProgress
+			},
+		},
+		{
+			name:  "dependency terms",
+			query: "blocks:bd-12 blocked-by:bd-7 no-blockers",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return reflect.DeepEqual(f.BlocksIDs, []string{"bd-12"}) &&
+					reflect.DeepEqual(f.BlockedByIDs, []string{"bd-7"}) &&
+					f.IsBlocked != nil && !*f.IsBlocked
+			},
+		},
+		{
+			name:  "NOT blocked",
+			query: "NOT blocked",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return f.IsBlocked != nil && !*f.IsBlocked
+			},
+		},
+		{
+			name:  "signed duration is an offset from now",
+			query: "created<-30d due<+3d",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return f.CreatedBefore != nil && f.CreatedBefore.Equal(now.AddDate(0, 0, -30)) &&
+					f.DueBefore != nil && f.DueBefore.Equal(now.AddDate(0, 0, 3))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -484,9 +564,14 @@ func TestEvaluatorComplexQueries(t *testing.T) {
 		},
 		{
 			name:              "nested OR requires predicate",
-			query:             "(status=open OR status=blocked) AND priority<2",
+			query:             "(status=open OR type=bug) AND priority<2",
 			requiresPredicate: true,
 		},
+		{
+			name:              "status OR compiles to an IN filter",
+			query:             "(status=open OR status=blocked) AND priority<2",
+			requiresPredicate: false,
+		},
 		{
 			name:              "NOT with complex expression requires predicate",
 			query:             "NOT (status=closed AND type=bug)",
@@ -512,6 +597,29 @@ func TestEvaluatorComplexQueries(t *testing.T) {
 	}
 }
 
+func TestEvaluatorDependencyTermsWithPredicate(t *testing.T) {
+	// Dependency terms in the top-level AND chain go to the database filter
+	// even when the rest of the query needs a predicate.
+	result, err := EvaluateAt("(label=a OR priority=0) no-blockers", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.RequiresPredicate || result.Filter.IsBlocked == nil || *result.Filter.IsBlocked {
+		t.Fatalf("result = %+v", result)
+	}
+	if !result.Predicate(&types.Issue{Priority: 0}) || result.Predicate(&types.Issue{Priority: 2}) {
+		t.Error("predicate should match on the OR alone")
+	}
+
+	result, err = EvaluateAt("(label=a OR priority=0) NOT blocked", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Filter.IsBlocked == nil || *result.Filter.IsBlocked {
+		t.Errorf("NOT blocked filter = %v", result.Filter.IsBlocked)
+	}
+}
+
 func TestPredicateEvaluation(t *testing.T) {
 	now := time.Date(2025, 2, 4, 12, 0, 0, 0, time.UTC)
 
@@ -545,6 +653,9 @@ func TestPredicateEvaluation(t *testing.T) {
 		UpdatedAt: now,
 	}
 
+	tomorrow := now.AddDate(0, 0, 1)
+	dueSoon := &types.Issue{ID: "bd-4", Status: types.StatusOpen, DueAt: &tomorrow}
+
 	tests := []struct {
 		name    string
 		query   string
@@ -595,6 +706,18 @@ func TestPredicateEvaluation(t *testing.T) {
 		{"label=urgent OR label=backend matches urgent", "label=urgent OR label=backend", openBug, true},
 		{"label=urgent OR label=backend matches backend", "label=urgent OR label=backend", closedTask, true},
 		{"label=urgent OR label=backend doesn't match unlabeled", "label=urgent OR label=backend", blockedFeature, false},
+
+		// Lists and globs
+		{"label glob matches", `label="front*"`, openBug, true},
+		{"label glob doesn't match", `label="back*"`, openBug, false},
+		{"label!= list excludes", "label!=urgent,backend", openBug, false},
+		{"label!= list keeps others", "label!=urgent,backend", blockedFeature, true},
+		{"type list matches", "type=bug,feature", blockedFeature, true},
+		{"type list doesn't match", "type=bug,feature", closedTask, false},
+
+		// Due dates
+		{"due matches issue due soon", "due<+3d", dueSoon, true},
+		{"due doesn't match issue without due date", "due<+3d", openBug, false},
 	}
 
 	for _, tt := range tests {
@@ -609,9 +732,9 @@ func TestPredicateEvaluation(t *testing.T) {
 				// Build predicate anyway for testing
 				eval := NewEvaluator(now)
 				node, _ := Parse(tt.query)
-				pred, err := eval.buildPredicate(node)
+				pred, err := eval.Predicate(node)
 				if err != nil {
-					t.Fatalf("buildPredicate() error = %v", err)
+					t.Fatalf("Predicate() error = %v", err)
 				}
 				got := pred(tt.issue)
 				if got != tt.matches {
@@ -638,6 +761,8 @@ func TestEvaluatorErrors(t *testing.T) {
 		{"priority out of range", "priority=5"},
 		{"invalid boolean", "pinned=maybe"},
 		{"unknown field", "unknown=value"},
+		{"dependency term under OR", "blocks:bd-1 OR priority=0"},
+		{"list with ordering operator", "priority<1,2"},
 	}
 
 	for _, tt := range tests {
@@ -718,8 +843,13 @@ func TestEvaluatorMetadataQueries(t *testing.T) {
 			requiresPredicate: true,
 		},
 		{
-			name:        "metadata with unsupported operator",
-			query:       "metadata.team>platform",
+			name:              "metadata ordering triggers predicate",
+			query:             "metadata.points>3",
+			requiresPredicate: true,
+		},
+		{
+			name:        "metadata with invalid key",
+			query:       `metadata.bad-key>3`,
 			expectError: true,
 		},
 	}
@@ -843,6 +973,44 @@ func TestHasMetadataKeyPredicateEvaluation(t *testing.T) {
 	}
 }
 
+func TestMetadataOrderingPredicate(t *testing.T) {
+	now := time.Date(2025, 2, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		query    string
+		metadata string
+		want     bool
+	}{
+		{"metadata.points>3", `{"points":5}`, true},
+		{"metadata.points>3", `{"points":"10"}`, true},
+		{"metadata.points>3", `{"points":2}`, false},
+		{"metadata.points<=3", `{}`, false},
+		{"metadata.points!=3", `{}`, true},
+		{"metadata.points!=3", `{"points":3}`, false},
+		{"metadata.review<2025-03-01", `{"review":"2025-02-10"}`, true},
+		{"metadata.review<2025-03-01", `{"review":"2025-04-01"}`, false},
+		{"metadata.review<+7d", `{"review":"2025-02-08"}`, true},
+		{"metadata.review<+7d", `{"review":"2025-02-20T09:00:00Z"}`, false},
+		{"metadata.component>=m", `{"component":"storage"}`, true},
+		{"metadata.component>=m", `{"component":"cli"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query+" "+tt.metadata, func(t *testing.T) {
+			result, err := EvaluateAt(tt.query, now)
+			if err != nil {
+				t.Fatalf("EvaluateAt(%q) error = %v", tt.query, err)
+			}
+			if result.Predicate == nil {
+				t.Fatal("expected predicate")
+			}
+			issue := &types.Issue{Metadata: []byte(tt.metadata)}
+			if got := result.Predicate(issue); got != tt.want {
+				t.Errorf("predicate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMetadataPredicateEvaluation(t *testing.T) {
 	now := time.Date(2025, 2, 4, 12, 0, 0, 0, time.UTC)
 
@@ -880,3 +1048,60 @@ func TestMetadataPredicateEvaluation(t *testing.T) {
 		t.Error("predicate should match closed issue via OR")
 	}
 }
+
+func TestEvaluatorAssigneeMe(t *testing.T) {
+	now := time.Date(2025, 2, 4, 12, 0, 0, 0, time.UTC)
+	me := func() string { return "alice@example.com" }
+
+	node, err := Parse("assignee=me")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := NewEvaluator(now).WithMe(me).Evaluate(node)
+	if err != nil {
+		t.Fatalf("Evaluate error: %v", err)
+	}
+	if result.Filter.Assignee == nil || *result.Filter.Assignee != "alice@example.com" {
+		t.Errorf("Assignee = %v, want alice@example.com", result.Filter.Assignee)
+	}
+
+	node, err = Parse("assignee=ME,bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = NewEvaluator(now).WithMe(me).Evaluate(node)
+	if err != nil {
+		t.Fatalf("Evaluate error: %v", err)
+	}
+	if want := []string{"alice@example.com", "bob"}; !reflect.DeepEqual(result.Filter.Assignees, want) {
+		t.Errorf("Assignees = %v, want %v", result.Filter.Assignees, want)
+	}
+
+	// Without WithMe, "me" is an ordinary name.
+	result, err = EvaluateAt("assignee=me", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Filter.Assignee == nil || *result.Filter.Assignee != "me" {
+		t.Errorf("Assignee without resolver = %v, want me", result.Filter.Assignee)
+	}
+
+	// The predicate also matches co-assignees.
+	node, err = Parse("assignee=me OR priority=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = NewEvaluator(now).WithMe(me).Evaluate(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Predicate == nil {
+		t.Fatal("expected predicate for OR query")
+	}
+	if !result.Predicate(&types.Issue{Assignee: "bob", Assignees: []string{"alice@example.com"}, Priority: 2}) {
+		t.Error("predicate should match a co-assignee")
+	}
+	if result.Predicate(&types.Issue{Assignee: "bob", Priority: 2}) {
+		t.Error("predicate should not match someone else's issue")
+	}
+}
@@ -15,3 +15,84 @@ type AnnotationStore interface {
 	GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error)
 	GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error)
 }
+
+// AttachmentStore is implemented by storage backends with an attachments
+// table (migration 0059). It records which issue references which
+// content-addressed blob; the blobs themselves live in .beads/attachments and
+// are managed by the caller. Like the other optional capabilities, reach it
+// through UnwrapStore when the store may be decorated.
+type AttachmentStore interface {
+	AddAttachment(ctx context.Context, attachment *types.Attachment) error
+	RemoveAttachment(ctx context.Context, issueID, name string) error
+	GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error)
+}
+
+// CommitLinkStore is implemented by storage backends with a commit_links
+// table (migration 0061). AddCommitLinks records links in one transaction,
+// ignoring ones already present, and returns how many were new.
+type CommitLinkStore interface {
+	AddCommitLinks(ctx context.Context, links []*types.CommitLink) (int, error)
+	GetCommitLinksForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.CommitLink, error)
+}
+
+// AliasStore is implemented by storage backends with an issue_aliases table
+// (migration 0062). An alias is unique across the database and follows its
+// issue through renames. ResolveIssueAlias returns ErrNotFound for an
+// unknown alias.
+type AliasStore interface {
+	AddIssueAlias(ctx context.Context, alias *types.IssueAlias) error
+	RemoveIssueAlias(ctx context.Context, alias string) error
+	GetIssueAliases(ctx context.Context, issueIDs []string) (map[string][]*types.IssueAlias, error)
+	ResolveIssueAlias(ctx context.Context, alias string) (string, error)
+}
+
+// AssignmentStore is implemented by storage backends with the issue_assignees
+// and issue_teams tables (migration 0063). The assignee column stays the
+// issue's primary assignee; co-assignees and the team are kept beside it.
+// RemoveIssueAssignees with nil removes every co-assignee, and SetIssueTeam
+// with "" clears the team.
+type AssignmentStore interface {
+	AddIssueAssignees(ctx context.Context, issueID string, assignees []string) error
+	RemoveIssueAssignees(ctx context.Context, issueID string, assignees []string) error
+	SetIssueTeam(ctx context.Context, issueID, team string) error
+	GetIssueAssignments(ctx context.Context, issueIDs []string) (map[string]*types.IssueAssignment, error)
+}
+
+// RecurrenceStore is implemented by storage backends with an
+// issue_recurrences table (migration 0064). An issue has at most one rule;
+// SetIssueRecurrence replaces it and RemoveIssueRecurrence returns
+// ErrNotFound when there is none. GetIssueRecurrences lists every rule,
+// soonest due first.
+type RecurrenceStore interface {
+	SetIssueRecurrence(ctx context.Context, r *types.Recurrence) error
+	RemoveIssueRecurrence(ctx context.Context, issueID string) error
+	GetIssueRecurrences(ctx context.Context) ([]*types.Recurrence, error)
+}
+
+// IssueContextStore is implemented by storage backends that can read an
+// issue's full relational context (bd show --full) in one transaction
+// instead of one query per section. eventLimit caps the recent events
+// returned; <= 0 returns all. Returns ErrNotFound for an unknown issue.
+type IssueContextStore interface {
+	GetIssueContext(ctx context.Context, id string, eventLimit int) (*types.IssueDetails, error)
+}
+
+// CommentEditor is implemented by storage backends that can rewrite or remove
+// existing comments. commentID may be a unique prefix of the comment's ID;
+// both methods return ErrNotFound when no comment of issueID matches it.
+type CommentEditor interface {
+	EditIssueComment(ctx context.Context, issueID, commentID, text string) (*types.Comment, error)
+	DeleteIssueComment(ctx context.Context, issueID, commentID string) error
+}
+
+// LabelDefinitionStore is implemented by storage backends with a
+// label_definitions table (migration 0060). Definitions attach a color and
+// description to a label name; RenameLabel and DeleteLabel rewrite every issue
+// and wisp carrying the label, and the definition, in one transaction, and
+// return the number of issues and wisps touched.
+type LabelDefinitionStore interface {
+	SetLabelDefinition(ctx context.Context, def *types.LabelDefinition) error
+	GetLabelDefinitions(ctx context.Context) ([]*types.LabelDefinition, error)
+	RenameLabel(ctx context.Context, oldName, newName, actor string) (int, error)
+	DeleteLabel(ctx context.Context, name, actor string) (int, error)
+}
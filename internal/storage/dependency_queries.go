@@ -6,6 +6,23 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
+// ImpactQuerier is implemented by storage backends that can compute the
+// transitive impact of an issue in one recursive query: the open work that
+// depends on it through blocking or parent-child edges, up to maxDepth
+// levels. The result is a tree with the issue itself at depth 0 and every
+// dependent once, at the shallowest depth it is reached.
+type ImpactQuerier interface {
+	GetImpact(ctx context.Context, issueID string, maxDepth int) ([]*types.TreeNode, error)
+}
+
+// ExternalDependencyLister is implemented by storage backends that can list
+// the dependencies whose target is not stored locally: external references
+// and issues with another prefix, which live in other workspaces. The result
+// is keyed by the dependent issue ID.
+type ExternalDependencyLister interface {
+	GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
+}
+
 // DependencyQueryStore provides extended dependency queries beyond the base Storage interface.
 type DependencyQueryStore interface {
 	GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error)
@@ -0,0 +1,59 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddIssueAlias records an alternate ID for an issue.
+// Implements storage.AliasStore.
+func (s *DoltStore) AddIssueAlias(ctx context.Context, alias *types.IssueAlias) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.AddIssueAliasInTx(ctx, tx, alias)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"issue_aliases"}, fmt.Sprintf("bd: alias %s to %s", alias.Alias, alias.IssueID))
+}
+
+// RemoveIssueAlias deletes an alias.
+// Implements storage.AliasStore.
+func (s *DoltStore) RemoveIssueAlias(ctx context.Context, alias string) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RemoveIssueAliasInTx(ctx, tx, alias)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"issue_aliases"}, fmt.Sprintf("bd: remove alias %s", alias))
+}
+
+// GetIssueAliases returns the aliases of the given issues.
+// Implements storage.AliasStore.
+func (s *DoltStore) GetIssueAliases(ctx context.Context, issueIDs []string) (map[string][]*types.IssueAlias, error) {
+	var result map[string][]*types.IssueAlias
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueAliasesInTx(ctx, tx, issueIDs)
+		if err != nil {
+			return wrapQueryError("get issue aliases", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ResolveIssueAlias returns the ID of the issue an alias refers to.
+// Implements storage.AliasStore.
+func (s *DoltStore) ResolveIssueAlias(ctx context.Context, alias string) (string, error) {
+	var issueID string
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		issueID, err = issueops.ResolveIssueAliasInTx(ctx, tx, alias)
+		return err
+	})
+	return issueID, err
+}
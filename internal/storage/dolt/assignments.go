@@ -0,0 +1,58 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddIssueAssignees adds co-assignees to an issue.
+// Implements storage.AssignmentStore.
+func (s *DoltStore) AddIssueAssignees(ctx context.Context, issueID string, assignees []string) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.AddIssueAssigneesInTx(ctx, tx, issueID, assignees)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"issue_assignees"}, fmt.Sprintf("bd: add assignees to %s", issueID))
+}
+
+// RemoveIssueAssignees removes co-assignees from an issue.
+// Implements storage.AssignmentStore.
+func (s *DoltStore) RemoveIssueAssignees(ctx context.Context, issueID string, assignees []string) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RemoveIssueAssigneesInTx(ctx, tx, issueID, assignees)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"issue_assignees"}, fmt.Sprintf("bd: remove assignees from %s", issueID))
+}
+
+// SetIssueTeam sets or clears the team of an issue.
+// Implements storage.AssignmentStore.
+func (s *DoltStore) SetIssueTeam(ctx context.Context, issueID, team string) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.SetIssueTeamInTx(ctx, tx, issueID, team)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"issue_teams"}, fmt.Sprintf("bd: set team of %s", issueID))
+}
+
+// GetIssueAssignments returns the co-assignees and team of the given issues.
+// Implements storage.AssignmentStore.
+func (s *DoltStore) GetIssueAssignments(ctx context.Context, issueIDs []string) (map[string]*types.IssueAssignment, error) {
+	var result map[string]*types.IssueAssignment
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueAssignmentsInTx(ctx, tx, issueIDs)
+		if err != nil {
+			return wrapQueryError("get issue assignments", err)
+		}
+		return nil
+	})
+	return result, err
+}
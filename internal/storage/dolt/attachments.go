@@ -0,0 +1,47 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAttachment records an attachment reference on an issue.
+// Implements storage.AttachmentStore.
+func (s *DoltStore) AddAttachment(ctx context.Context, attachment *types.Attachment) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.AddAttachmentInTx(ctx, tx, attachment)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"attachments"}, fmt.Sprintf("bd: attach %s to %s", attachment.Name, attachment.IssueID))
+}
+
+// RemoveAttachment removes an attachment reference from an issue.
+// Implements storage.AttachmentStore.
+func (s *DoltStore) RemoveAttachment(ctx context.Context, issueID, name string) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RemoveAttachmentInTx(ctx, tx, issueID, name)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"attachments"}, fmt.Sprintf("bd: detach %s from %s", name, issueID))
+}
+
+// GetAttachmentsForIssues returns the attachments of the given issues.
+// Implements storage.AttachmentStore.
+func (s *DoltStore) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	var result map[string][]*types.Attachment
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetAttachmentsForIssuesInTx(ctx, tx, issueIDs)
+		if err != nil {
+			return wrapQueryError("get attachments", err)
+		}
+		return nil
+	})
+	return result, err
+}
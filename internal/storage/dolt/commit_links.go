@@ -0,0 +1,42 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddCommitLinks records links between commits and issues.
+// Implements storage.CommitLinkStore.
+func (s *DoltStore) AddCommitLinks(ctx context.Context, links []*types.CommitLink) (int, error) {
+	var added int
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		added, err = issueops.AddCommitLinksInTx(ctx, tx, links)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, s.doltAddAndCommit(ctx, []string{"commit_links"}, fmt.Sprintf("bd: link %d commit(s)", added))
+}
+
+// GetCommitLinksForIssues returns the commits linked to the given issues.
+// Implements storage.CommitLinkStore.
+func (s *DoltStore) GetCommitLinksForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.CommitLink, error) {
+	var result map[string][]*types.CommitLink
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetCommitLinksForIssuesInTx(ctx, tx, issueIDs)
+		if err != nil {
+			return wrapQueryError("get commit links", err)
+		}
+		return nil
+	})
+	return result, err
+}
@@ -90,7 +90,7 @@ func (s *DoltStore) RemoveDependency(ctx context.Context, issueID, dependsOnID s
 func (s *DoltStore) RemoveDependencyWithOptions(ctx context.Context, issueID, dependsOnID string, actor string, rmOpts storage.DependencyRemoveOptions) error {
 	// Wisps live in dolt_ignored tables — skip Dolt versioning entirely.
 	if s.isActiveWisp(ctx, issueID) {
-		tx, err := s.db.BeginTx(ctx, nil)
+		tx, err := s.db.BeginTx(ctx, s.txOptions())
 		if err != nil {
 			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
@@ -101,7 +101,7 @@ func (s *DoltStore) RemoveDependencyWithOptions(ctx context.Context, issueID, de
 		return wrapTransactionError("commit remove wisp dependency", tx.Commit())
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -354,6 +354,31 @@ func (s *DoltStore) GetDependencyTree(ctx context.Context, issueID string, maxDe
 	return result, err
 }
 
+// GetImpact returns the open work that transitively depends on issueID.
+// Implements storage.ImpactQuerier.
+func (s *DoltStore) GetImpact(ctx context.Context, issueID string, maxDepth int) ([]*types.TreeNode, error) {
+	var result []*types.TreeNode
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetImpactInTx(ctx, tx, issueID, maxDepth)
+		return err
+	})
+	return result, err
+}
+
+// GetExternalDependencyRecords returns the dependencies whose target is not
+// stored in this database.
+// Implements storage.ExternalDependencyLister.
+func (s *DoltStore) GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	var result map[string][]*types.Dependency
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetExternalDependencyRecordsInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
 // DetectCycles finds circular dependencies.
 // Queries both dependencies and wisp_dependencies tables to detect cross-table
 // cycles (e.g., permanent A -> wisp B -> permanent A). (bd-xe27)
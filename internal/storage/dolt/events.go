@@ -14,7 +14,7 @@ import (
 // AddComment adds a comment event to an issue
 func (s *DoltStore) AddComment(ctx context.Context, issueID, actor, comment string) error {
 	isWisp := s.isActiveWisp(ctx, issueID)
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
 	}
@@ -68,6 +68,19 @@ func (s *DoltStore) EventsSince(ctx context.Context, cursor storage.EventCursor,
 	return result, err
 }
 
+// ImportEvents restores events verbatim. Implements storage.EventImporter.
+func (s *DoltStore) ImportEvents(ctx context.Context, events []*types.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.ImportEventsInTx(ctx, tx, events)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"events"}, fmt.Sprintf("bd: import %d event(s)", len(events)))
+}
+
 // AddIssueComment adds a comment to an issue (structured comment)
 func (s *DoltStore) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
 	return s.ImportIssueComment(ctx, issueID, author, text, time.Now().UTC())
@@ -95,6 +108,41 @@ func (s *DoltStore) ImportIssueComment(ctx context.Context, issueID, author, tex
 	return result, nil
 }
 
+// EditIssueComment replaces the text of a comment. Implements storage.CommentEditor.
+func (s *DoltStore) EditIssueComment(ctx context.Context, issueID, commentID, text string) (*types.Comment, error) {
+	isWisp := s.isActiveWisp(ctx, issueID)
+	var result *types.Comment
+	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.EditIssueCommentInTx(ctx, tx, issueID, commentID, text)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if isWisp {
+		return result, nil
+	}
+	if err := s.doltAddAndCommit(ctx, []string{"comments"}, fmt.Sprintf("bd: edit comment on %s", issueID)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteIssueComment removes a comment. Implements storage.CommentEditor.
+func (s *DoltStore) DeleteIssueComment(ctx context.Context, issueID, commentID string) error {
+	isWisp := s.isActiveWisp(ctx, issueID)
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.DeleteIssueCommentInTx(ctx, tx, issueID, commentID)
+	}); err != nil {
+		return err
+	}
+	if isWisp {
+		return nil
+	}
+	return s.doltAddAndCommit(ctx, []string{"comments"}, fmt.Sprintf("bd: delete comment on %s", issueID))
+}
+
 // GetIssueComments retrieves all comments for an issue
 func (s *DoltStore) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
 	table := "comments"
@@ -0,0 +1,25 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetIssueContext reads an issue with its full relational context in one
+// read transaction. Implements storage.IssueContextStore.
+func (s *DoltStore) GetIssueContext(ctx context.Context, id string, eventLimit int) (*types.IssueDetails, error) {
+	var result *types.IssueDetails
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueContextInTx(ctx, tx, id, eventLimit, time.Now())
+		if err != nil {
+			return wrapQueryError("get issue context", err)
+		}
+		return nil
+	})
+	return result, err
+}
@@ -580,7 +580,7 @@ func (s *DoltStore) DeleteIssue(ctx context.Context, id string) error {
 			return err
 		}
 
-		for _, table := range []string{"issues", "dependencies", "labels", "comments", "events", "child_counters", "issue_snapshots", "compaction_snapshots"} {
+		for _, table := range []string{"issues", "dependencies", "labels", "comments", "attachments", "commit_links", "issue_aliases", "issue_assignees", "issue_teams", "issue_recurrences", "events", "child_counters", "issue_snapshots", "compaction_snapshots"} {
 			_, _ = tx.ExecContext(ctx, "CALL DOLT_ADD(?)", table)
 		}
 		commitMsg := fmt.Sprintf("bd: delete %s", id)
@@ -657,7 +657,7 @@ func (s *DoltStore) DeleteIssues(ctx context.Context, ids []string, cascade bool
 			return nil
 		}
 
-		for _, table := range []string{"issues", "dependencies", "labels", "comments", "events", "child_counters", "issue_snapshots", "compaction_snapshots"} {
+		for _, table := range []string{"issues", "dependencies", "labels", "comments", "attachments", "commit_links", "issue_aliases", "issue_assignees", "issue_teams", "issue_recurrences", "events", "child_counters", "issue_snapshots", "compaction_snapshots"} {
 			_, _ = tx.ExecContext(ctx, "CALL DOLT_ADD(?)", table)
 		}
 		commitMsg := fmt.Sprintf("bd: delete %d issue(s)", result.DeletedCount)
@@ -769,6 +769,17 @@ func seedCounterFromExistingIssuesTx(ctx context.Context, tx *sql.Tx, prefix str
 // for the given prefix within an existing transaction. Returns the full ID string
 // (e.g., "bd-1"). Used by both generateIssueID and generateIssueIDInTable.
 func nextCounterIDTx(ctx context.Context, tx *sql.Tx, prefix string) (string, error) {
+	// A reserved id.counter-range allocates from its own counter row.
+	if r, err := issueops.ConfiguredIDRange(); err != nil {
+		return "", err
+	} else if r != nil {
+		n, err := issueops.NextRangedCounterIDTx(ctx, tx, prefix, *r)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s-%d", prefix, n), nil
+	}
+
 	// Increment atomically at the DB level to avoid duplicate IDs under
 	// concurrent transactions (GH#2002). "last_id = last_id + 1" is evaluated
 	// by the DB engine atomically within Dolt's MVCC.
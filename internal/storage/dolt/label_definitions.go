@@ -0,0 +1,68 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// labelDefinitionTables are the committed tables a label rename or delete
+// touches. wisp_labels and wisp_events are dolt-ignored and need no commit.
+var labelDefinitionTables = []string{"label_definitions", "labels", "events"}
+
+// SetLabelDefinition creates or replaces a label's color and description.
+// Implements storage.LabelDefinitionStore.
+func (s *DoltStore) SetLabelDefinition(ctx context.Context, def *types.LabelDefinition) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.SetLabelDefinitionInTx(ctx, tx, def)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"label_definitions"}, fmt.Sprintf("bd: label define %s", def.Name))
+}
+
+// GetLabelDefinitions returns every label definition ordered by name.
+// Implements storage.LabelDefinitionStore.
+func (s *DoltStore) GetLabelDefinitions(ctx context.Context) ([]*types.LabelDefinition, error) {
+	var defs []*types.LabelDefinition
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		defs, err = issueops.GetLabelDefinitionsInTx(ctx, tx)
+		if err != nil {
+			return wrapQueryError("get label definitions", err)
+		}
+		return nil
+	})
+	return defs, err
+}
+
+// RenameLabel renames a label on every issue and wisp, and its definition.
+// Implements storage.LabelDefinitionStore.
+func (s *DoltStore) RenameLabel(ctx context.Context, oldName, newName, actor string) (int, error) {
+	var n int
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		n, err = issueops.RenameLabelInTx(ctx, tx, oldName, newName, actor)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return n, s.doltAddAndCommit(ctx, labelDefinitionTables, fmt.Sprintf("bd: label rename %s to %s", oldName, newName))
+}
+
+// DeleteLabel removes a label from every issue and wisp, and its definition.
+// Implements storage.LabelDefinitionStore.
+func (s *DoltStore) DeleteLabel(ctx context.Context, name, actor string) (int, error) {
+	var n int
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		n, err = issueops.DeleteLabelInTx(ctx, tx, name, actor)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return n, s.doltAddAndCommit(ctx, labelDefinitionTables, fmt.Sprintf("bd: label delete %s", name))
+}
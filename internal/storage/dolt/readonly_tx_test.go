@@ -0,0 +1,37 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+// TestReadOnlyTransactionsRefuseWrites pins the --readonly store mode: write
+// helpers refuse before touching the connection pool, and every transaction
+// the store begins is READ ONLY so the server rejects writes on any path that
+// bypasses those helpers.
+func TestReadOnlyTransactionsRefuseWrites(t *testing.T) {
+	driver := &failureDriver{}
+	store := &DoltStore{db: sql.OpenDB(driver), readOnly: true, readOnlyTx: true}
+	defer func() { _ = store.db.Close() }()
+	ctx := context.Background()
+
+	if err := store.withRetryTx(ctx, func(*sql.Tx) error { return nil }); !errors.Is(err, ErrReadOnlyTransactions) {
+		t.Errorf("withRetryTx err = %v, want ErrReadOnlyTransactions", err)
+	}
+	if _, err := store.execContext(ctx, "DELETE FROM issues"); !errors.Is(err, ErrReadOnlyTransactions) {
+		t.Errorf("execContext err = %v, want ErrReadOnlyTransactions", err)
+	}
+	if got := driver.begins.Load(); got != 0 {
+		t.Errorf("transactions begun by refused writes = %d, want 0", got)
+	}
+	if opts := store.txOptions(); opts == nil || !opts.ReadOnly {
+		t.Errorf("txOptions = %+v, want ReadOnly", opts)
+	}
+
+	readOnlyCommand := &DoltStore{readOnly: true}
+	if opts := readOnlyCommand.txOptions(); opts != nil {
+		t.Errorf("txOptions without ReadOnlyTransactions = %+v, want nil", opts)
+	}
+}
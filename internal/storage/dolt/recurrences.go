@@ -0,0 +1,47 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetIssueRecurrence creates or replaces the recurrence rule of an issue.
+// Implements storage.RecurrenceStore.
+func (s *DoltStore) SetIssueRecurrence(ctx context.Context, r *types.Recurrence) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.SetIssueRecurrenceInTx(ctx, tx, r)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"issue_recurrences"}, fmt.Sprintf("bd: recur %s every %s", r.IssueID, r.Every))
+}
+
+// RemoveIssueRecurrence deletes the recurrence rule of an issue.
+// Implements storage.RecurrenceStore.
+func (s *DoltStore) RemoveIssueRecurrence(ctx context.Context, issueID string) error {
+	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RemoveIssueRecurrenceInTx(ctx, tx, issueID)
+	}); err != nil {
+		return err
+	}
+	return s.doltAddAndCommit(ctx, []string{"issue_recurrences"}, fmt.Sprintf("bd: stop recurrence of %s", issueID))
+}
+
+// GetIssueRecurrences returns every recurrence rule.
+// Implements storage.RecurrenceStore.
+func (s *DoltStore) GetIssueRecurrences(ctx context.Context) ([]*types.Recurrence, error) {
+	var result []*types.Recurrence
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueRecurrencesInTx(ctx, tx)
+		if err != nil {
+			return wrapQueryError("get recurrences", err)
+		}
+		return nil
+	})
+	return result, err
+}
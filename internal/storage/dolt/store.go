@@ -250,7 +250,21 @@ var _ storage.GarbageCollector = (*DoltStore)(nil)
 var _ storage.Flattener = (*DoltStore)(nil)
 var _ storage.Compactor = (*DoltStore)(nil)
 var _ storage.SchemaMigrator = (*DoltStore)(nil)
+var _ storage.SchemaInspector = (*DoltStore)(nil)
 var _ storage.ExternalRefHistoryQuerier = (*DoltStore)(nil)
+var _ storage.DeletedIssueQuerier = (*DoltStore)(nil)
+var _ storage.AttachmentStore = (*DoltStore)(nil)
+var _ storage.CommitLinkStore = (*DoltStore)(nil)
+var _ storage.AliasStore = (*DoltStore)(nil)
+var _ storage.AssignmentStore = (*DoltStore)(nil)
+var _ storage.RecurrenceStore = (*DoltStore)(nil)
+var _ storage.IssueContextStore = (*DoltStore)(nil)
+var _ storage.LabelDefinitionStore = (*DoltStore)(nil)
+var _ storage.CommentEditor = (*DoltStore)(nil)
+var _ storage.EventImporter = (*DoltStore)(nil)
+var _ storage.ImpactQuerier = (*DoltStore)(nil)
+var _ storage.ExternalDependencyLister = (*DoltStore)(nil)
+var _ storage.MergePreviewer = (*DoltStore)(nil)
 
 // DoltStore implements the Storage interface using Dolt
 type DoltStore struct {
@@ -262,6 +276,7 @@ type DoltStore struct {
 	connStr       string       // Connection string for reconnection
 	mu            sync.RWMutex // Protects concurrent access
 	readOnly      bool         // True if opened in read-only mode
+	readOnlyTx    bool         // True if every transaction begins READ ONLY (Config.ReadOnlyTransactions)
 	credentialKey []byte       // Random encryption key for federation credentials
 
 	customStatusDetailedCache []types.CustomStatus
@@ -305,6 +320,13 @@ type Config struct {
 	Database       string // Database name within Dolt (default: "beads")
 	ReadOnly       bool   // Open in read-only mode (skip schema init)
 
+	// ReadOnlyTransactions begins every transaction on the store's connection
+	// pool as START TRANSACTION READ ONLY, so the server itself rejects writes
+	// and a reader never takes write locks. Implies ReadOnly. Set for
+	// bd --readonly (or readonly: true in config) so dashboards and agents
+	// that only query cannot mutate a shared server. Server mode only.
+	ReadOnlyTransactions bool
+
 	// LenientOpen opens the store leniently: embedded mode only. A migration
 	// gate refusal (#4259) or a dirty-working-set refusal (#4566) skips the
 	// migration instead of failing the open. Set for working-set-reconcile
@@ -797,6 +819,20 @@ func endSpan(span trace.Span, err error) {
 // ErrStoreClosed is returned when an operation is attempted on a closed store.
 var ErrStoreClosed = errors.New("store is closed")
 
+// ErrReadOnlyTransactions is returned when a write is attempted on a store
+// opened with Config.ReadOnlyTransactions.
+var ErrReadOnlyTransactions = errors.New("store is read-only (--readonly): write refused")
+
+// txOptions returns the options every transaction on the store begins with:
+// READ ONLY when the store was opened with Config.ReadOnlyTransactions, so the
+// server enforces the read-only mode even on paths that do not check it.
+func (s *DoltStore) txOptions() *sql.TxOptions {
+	if s.readOnlyTx {
+		return &sql.TxOptions{ReadOnly: true}
+	}
+	return nil
+}
+
 // withReadTx runs fn inside a transaction while holding the store's read-lock.
 // Used for read operations that need a *sql.Tx to share issueops functions.
 //
@@ -812,7 +848,7 @@ func (s *DoltStore) withReadTx(ctx context.Context, fn func(tx *sql.Tx) error) e
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	return s.withRetry(ctx, func() error {
-		tx, err := s.db.BeginTx(ctx, nil)
+		tx, err := s.db.BeginTx(ctx, s.txOptions())
 		if err != nil {
 			return fmt.Errorf("begin read tx: %w", err)
 		}
@@ -859,7 +895,10 @@ func (s *DoltStore) withWriteTx(ctx context.Context, fn func(tx *sql.Tx) error)
 	if s.closed.Load() {
 		return ErrStoreClosed
 	}
-	tx, err := s.db.BeginTx(ctx, nil)
+	if s.readOnlyTx {
+		return ErrReadOnlyTransactions
+	}
+	tx, err := s.db.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return fmt.Errorf("begin write tx: %w", err)
 	}
@@ -880,6 +919,9 @@ func (s *DoltStore) execContext(ctx context.Context, query string, args ...any)
 	if s.closed.Load() {
 		return nil, ErrStoreClosed
 	}
+	if s.readOnlyTx {
+		return nil, ErrReadOnlyTransactions
+	}
 	ctx, span := doltTracer.Start(ctx, "dolt.exec",
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(append(s.doltSpanAttrs(),
@@ -889,7 +931,7 @@ func (s *DoltStore) execContext(ctx context.Context, query string, args ...any)
 	)
 	var result sql.Result
 	err := s.withRetry(ctx, func() error {
-		tx, txErr := s.db.BeginTx(ctx, nil)
+		tx, txErr := s.db.BeginTx(ctx, s.txOptions())
 		if txErr != nil {
 			return txErr
 		}
@@ -1396,7 +1438,8 @@ func newServerMode(ctx context.Context, cfg *Config) (*DoltStore, error) {
 		remoteUser:           cfg.RemoteUser,
 		remotePassword:       cfg.RemotePassword,
 		serverMode:           true,
-		readOnly:             cfg.ReadOnly,
+		readOnly:             cfg.ReadOnly || cfg.ReadOnlyTransactions,
+		readOnlyTx:           cfg.ReadOnlyTransactions,
 		autoStartedServerDir: autoStartedDir,
 	}
 
@@ -1413,7 +1456,7 @@ func newServerMode(ctx context.Context, cfg *Config) (*DoltStore, error) {
 	// version, so a client must never run migrations (DDL) against it. Treat it like
 	// ReadOnly for schema — the forward-drift guard above still protects a stale client
 	// binary.
-	if !cfg.ReadOnly && !cfg.Gateway {
+	if !store.readOnly && !cfg.Gateway {
 		if err := store.initSchema(ctx); err != nil {
 			return nil, fmt.Errorf("failed to initialize schema: %w", err)
 		}
@@ -1578,7 +1621,7 @@ func (s *DoltStore) execWithLongTimeout(ctx context.Context, query string, args
 	}
 	defer db.Close()
 	db.SetMaxOpenConns(1)
-	tx, err := db.BeginTx(ctx, nil)
+	tx, err := db.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -1925,6 +1968,11 @@ func (s *DoltStore) ApplySchemaMigrations(ctx context.Context) (int, error) {
 	return initSchemaOnDBWithRetry(ctx, migDB)
 }
 
+// InspectSchema runs fn in a read transaction. Implements storage.SchemaInspector.
+func (s *DoltStore) InspectSchema(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return s.withReadTx(ctx, fn)
+}
+
 // openMigrationDB opens a one-off connection pool for schema migrations with no
 // read/write timeout. Migrations may run far longer than the default 10s pool
 // timeout, and timing out part-way leaves the database in a dirty, half-migrated
@@ -3056,7 +3104,7 @@ func (s *DoltStore) pullWithAutoResolve(ctx context.Context, remote string, quer
 		return err
 	}
 	defer db.Close()
-	tx, err := db.BeginTx(ctx, nil)
+	tx, err := db.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -3206,7 +3254,7 @@ func (s *DoltStore) recomputeBlockedAfterPull(ctx context.Context, fromCommit st
 // operator resolved by hand — leaves is_blocked stale until this full pass runs.
 // Idempotent: a consistent database corrects nothing.
 func (s *DoltStore) RecomputeAllBlocked(ctx context.Context) (int, error) {
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return 0, fmt.Errorf("begin is_blocked recompute: %w", err)
 	}
@@ -3240,7 +3288,7 @@ func (s *DoltStore) RecomputeAllBlocked(ctx context.Context) (int, error) {
 // recomputeBlockedTx runs the post-merge is_blocked recompute in its own
 // transaction.
 func (s *DoltStore) recomputeBlockedTx(ctx context.Context, fromCommit string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return fmt.Errorf("begin is_blocked recompute: %w", err)
 	}
@@ -3258,7 +3306,7 @@ func (s *DoltStore) recomputeBlockedTx(ctx context.Context, fromCommit string) e
 // is_blocked recompute (bd-578h9.11); see
 // issueops.MarkIsBlockedRecomputePendingInTx.
 func (s *DoltStore) markBlockedRecomputePending(ctx context.Context, fromCommit string) {
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return
 	}
@@ -3333,7 +3381,7 @@ func (s *DoltStore) autoResolveConflictsAfterCLIPull(ctx context.Context) (bool,
 		return false, fmt.Errorf("failed to set dolt_allow_commit_conflicts: %w", err)
 	}
 	varSet = true
-	tx, err := conn.BeginTx(ctx, nil)
+	tx, err := conn.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return false, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -3470,6 +3518,12 @@ func (s *DoltStore) Merge(ctx context.Context, branch string) (conflicts []stora
 	return conflicts, err
 }
 
+// PreviewMerge reports the conflicts merging branch into the current branch
+// would produce without merging. Implements storage.MergePreviewer.
+func (s *DoltStore) PreviewMerge(ctx context.Context, branch string) ([]storage.Conflict, error) {
+	return versioncontrolops.PreviewMerge(ctx, s.db, branch)
+}
+
 // RecomputeBlockedAfterMerge recomputes the denormalized is_blocked column
 // for the rows changed since fromCommit and commits the result — the hook a
 // caller that resolved merge conflicts itself must run after committing the
@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/domain"
 	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/storage/sqlbuild"
 	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -105,7 +106,7 @@ func (s *DoltStore) runDoltTransaction(ctx context.Context, commitMsg string, fn
 		return fmt.Errorf("failed to read active branch: %w", err)
 	}
 
-	regularTx, err := conn.BeginTx(ctx, nil)
+	regularTx, err := conn.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		return fmt.Errorf("failed to begin regular tx: %w", err)
 	}
@@ -174,7 +175,7 @@ func (s *DoltStore) beginIgnoredTxOnBranch(ctx context.Context, branch string) (
 		return nil, nil, nil, fmt.Errorf("failed to checkout ignored tx branch %s: %w", branch, err)
 	}
 
-	tx, err := conn.BeginTx(ctx, nil)
+	tx, err := conn.BeginTx(ctx, s.txOptions())
 	if err != nil {
 		_ = conn.Close()
 		_ = db.Close()
@@ -353,6 +354,20 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "external_ref = ?")
 		args = append(args, *filter.ExternalRef)
 	}
+	if len(filter.TextTerms) > 0 {
+		tables := sqlbuild.IssuesFilterTables
+		if table == "wisps" {
+			tables = sqlbuild.WispsFilterTables
+		}
+		for _, term := range filter.TextTerms {
+			clause, termArgs, err := sqlbuild.TextTermClause(term, tables)
+			if err != nil {
+				return nil, err
+			}
+			whereClauses = append(whereClauses, clause)
+			args = append(args, termArgs...)
+		}
+	}
 
 	// Status
 	if filter.Status != nil {
@@ -1000,6 +1015,26 @@ func (t *doltTransaction) RemoveLabel(ctx context.Context, issueID, label, actor
 	return nil
 }
 
+// RedirectIssueAliases makes fromID and its aliases resolve to toID within the
+// transaction. Aliases are durable-only, so it always runs on the regular session.
+func (t *doltTransaction) RedirectIssueAliases(ctx context.Context, fromID, toID, actor string) error {
+	if err := issueops.RedirectIssueAliasesInTx(ctx, t.regularTx, fromID, toID, actor); err != nil {
+		return wrapExecError("redirect aliases in tx", err)
+	}
+	t.dirty.MarkDirty("issue_aliases")
+	return nil
+}
+
+// SetIssueRecurrence creates or replaces a recurrence rule within the
+// transaction. Rules are durable-only, so it always runs on the regular session.
+func (t *doltTransaction) SetIssueRecurrence(ctx context.Context, r *types.Recurrence) error {
+	if err := issueops.SetIssueRecurrenceInTx(ctx, t.regularTx, r); err != nil {
+		return wrapExecError("set recurrence in tx", err)
+	}
+	t.dirty.MarkDirty("issue_recurrences")
+	return nil
+}
+
 // SetConfig sets a config value within the transaction
 func (t *doltTransaction) SetConfig(ctx context.Context, key, value string) error {
 	_, err := t.regularTx.ExecContext(ctx, `
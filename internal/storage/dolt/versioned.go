@@ -61,6 +61,38 @@ func (s *DoltStore) PreviousExternalRef(ctx context.Context, issueID string, asO
 	return ref, found, err
 }
 
+// DeletedIssues returns the issues deleted from the working set that are
+// still in commit history.
+// Implements storage.DeletedIssueQuerier.
+func (s *DoltStore) DeletedIssues(ctx context.Context) ([]*storage.HistoryEntry, error) {
+	var result []*storage.HistoryEntry
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.DeletedIssuesInTx(ctx, tx)
+		if err != nil {
+			return wrapQueryError("list deleted issues", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// IssueRelationsAsOf returns the labels, comments, and dependencies issueID
+// had as of ref.
+// Implements storage.DeletedIssueQuerier.
+func (s *DoltStore) IssueRelationsAsOf(ctx context.Context, issueID, ref string) (*storage.IssueRelations, error) {
+	var result *storage.IssueRelations
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.IssueRelationsAsOfInTx(ctx, tx, issueID, ref)
+		if err != nil {
+			return wrapQueryError("get deleted issue relations", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
 // ListBranches returns the names of all branches.
 // Implements storage.VersionedStorage.
 func (s *DoltStore) ListBranches(ctx context.Context) ([]string, error) {
@@ -847,6 +847,14 @@ func (r *dependencySQLRepositoryImpl) GetDependencyRecordsForIssues(ctx context.
 	return out, nil
 }
 
+func (r *dependencySQLRepositoryImpl) GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	out, err := issueops.GetExternalDependencyRecordsInTx(ctx, r.runner)
+	if err != nil {
+		return nil, fmt.Errorf("db: DependencySQLRepository.GetExternalDependencyRecords: %w", err)
+	}
+	return out, nil
+}
+
 func (r *dependencySQLRepositoryImpl) GetWispDependencyRecordsForIDs(ctx context.Context, wispIDs []string) (map[string][]*types.Dependency, error) {
 	if len(wispIDs) == 0 {
 		return map[string][]*types.Dependency{}, nil
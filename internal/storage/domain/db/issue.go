@@ -235,6 +235,13 @@ func (r *issueSQLRepositoryImpl) Update(ctx context.Context, id string, updates
 				return fmt.Errorf("db: Update %s: recompute is_blocked: %w", id, err)
 			}
 		}
+		// Duplicate closure parity with issueops.updateIssueInTx.
+		if newStatus == types.StatusClosed && oldIssue.Status != types.StatusClosed {
+			session, _ := updates["closed_by_session"].(string)
+			if err := issueops.CloseOpenDuplicatesInTx(ctx, r.runner, id, actor, session); err != nil {
+				return fmt.Errorf("db: Update %s: %w", id, err)
+			}
+		}
 	}
 	return nil
 }
@@ -484,6 +491,15 @@ func (r *issueSQLRepositoryImpl) NextCounterID(ctx context.Context, prefix strin
 	if prefix == "" {
 		return 0, errors.New("db: NextCounterID: prefix must not be empty")
 	}
+	if rng, err := issueops.ConfiguredIDRange(); err != nil {
+		return 0, fmt.Errorf("db: NextCounterID: %w", err)
+	} else if rng != nil {
+		n, err := issueops.NextRangedCounterIDTx(ctx, r.runner, prefix, *rng)
+		if err != nil {
+			return 0, fmt.Errorf("db: NextCounterID: %w", err)
+		}
+		return n, nil
+	}
 
 	res, err := r.runner.ExecContext(ctx, "UPDATE issue_counter SET last_id = last_id + 1 WHERE prefix = ?", prefix)
 	if err != nil {
@@ -181,6 +181,7 @@ type DependencySQLRepository interface {
 	CycleThroughEdges(ctx context.Context, edges [][2]string) (string, error)
 	GetDependencyRecordsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Dependency, error)
 	GetWispDependencyRecordsForIDs(ctx context.Context, wispIDs []string) (map[string][]*types.Dependency, error)
+	GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
 }
 
 type DependencyUseCase interface {
@@ -200,6 +201,9 @@ type DependencyUseCase interface {
 	GetDependencyTree(ctx context.Context, rootID string, opts DepTreeOpts) ([]*types.TreeNode, error)
 	AddDependencies(ctx context.Context, deps []*types.Dependency, actor string, opts BulkAddDepsOpts) (BulkAddDepsResult, error)
 	GetIssueDependencyRecords(ctx context.Context, issueIDs []string) (map[string][]*types.Dependency, error)
+	// GetExternalDependencyRecords returns the dependencies whose target is
+	// not stored here (external references, issues with another prefix).
+	GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
 
 	AddWispDependencies(ctx context.Context, deps []*types.Dependency, actor string, opts BulkAddDepsOpts) (BulkAddDepsResult, error)
 	GetWispDependencyRecords(ctx context.Context, wispIDs []string) (map[string][]*types.Dependency, error)
@@ -660,6 +664,14 @@ func (u *dependencyUseCaseImpl) GetIssueDependencyRecords(ctx context.Context, i
 	return out, nil
 }
 
+func (u *dependencyUseCaseImpl) GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	out, err := u.depRepo.GetExternalDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetExternalDependencyRecords: %w", err)
+	}
+	return out, nil
+}
+
 func (u *dependencyUseCaseImpl) GetWispDependencyRecords(ctx context.Context, wispIDs []string) (map[string][]*types.Dependency, error) {
 	if len(wispIDs) == 0 {
 		return map[string][]*types.Dependency{}, nil
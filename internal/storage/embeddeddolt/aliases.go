@@ -0,0 +1,51 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddIssueAlias records an alternate ID for an issue.
+// Implements storage.AliasStore.
+func (s *EmbeddedDoltStore) AddIssueAlias(ctx context.Context, alias *types.IssueAlias) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.AddIssueAliasInTx(ctx, tx, alias)
+	})
+}
+
+// RemoveIssueAlias deletes an alias.
+// Implements storage.AliasStore.
+func (s *EmbeddedDoltStore) RemoveIssueAlias(ctx context.Context, alias string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RemoveIssueAliasInTx(ctx, tx, alias)
+	})
+}
+
+// GetIssueAliases returns the aliases of the given issues.
+// Implements storage.AliasStore.
+func (s *EmbeddedDoltStore) GetIssueAliases(ctx context.Context, issueIDs []string) (map[string][]*types.IssueAlias, error) {
+	var result map[string][]*types.IssueAlias
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueAliasesInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
+
+// ResolveIssueAlias returns the ID of the issue an alias refers to.
+// Implements storage.AliasStore.
+func (s *EmbeddedDoltStore) ResolveIssueAlias(ctx context.Context, alias string) (string, error) {
+	var issueID string
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		issueID, err = issueops.ResolveIssueAliasInTx(ctx, tx, alias)
+		return err
+	})
+	return issueID, err
+}
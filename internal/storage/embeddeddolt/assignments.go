@@ -0,0 +1,47 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddIssueAssignees adds co-assignees to an issue.
+// Implements storage.AssignmentStore.
+func (s *EmbeddedDoltStore) AddIssueAssignees(ctx context.Context, issueID string, assignees []string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.AddIssueAssigneesInTx(ctx, tx, issueID, assignees)
+	})
+}
+
+// RemoveIssueAssignees removes co-assignees from an issue.
+// Implements storage.AssignmentStore.
+func (s *EmbeddedDoltStore) RemoveIssueAssignees(ctx context.Context, issueID string, assignees []string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RemoveIssueAssigneesInTx(ctx, tx, issueID, assignees)
+	})
+}
+
+// SetIssueTeam sets or clears the team of an issue.
+// Implements storage.AssignmentStore.
+func (s *EmbeddedDoltStore) SetIssueTeam(ctx context.Context, issueID, team string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.SetIssueTeamInTx(ctx, tx, issueID, team)
+	})
+}
+
+// GetIssueAssignments returns the co-assignees and team of the given issues.
+// Implements storage.AssignmentStore.
+func (s *EmbeddedDoltStore) GetIssueAssignments(ctx context.Context, issueIDs []string) (map[string]*types.IssueAssignment, error) {
+	var result map[string]*types.IssueAssignment
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueAssignmentsInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
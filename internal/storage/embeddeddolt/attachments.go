@@ -0,0 +1,39 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAttachment records an attachment reference on an issue.
+// Implements storage.AttachmentStore.
+func (s *EmbeddedDoltStore) AddAttachment(ctx context.Context, attachment *types.Attachment) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.AddAttachmentInTx(ctx, tx, attachment)
+	})
+}
+
+// RemoveAttachment removes an attachment reference from an issue.
+// Implements storage.AttachmentStore.
+func (s *EmbeddedDoltStore) RemoveAttachment(ctx context.Context, issueID, name string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RemoveAttachmentInTx(ctx, tx, issueID, name)
+	})
+}
+
+// GetAttachmentsForIssues returns the attachments of the given issues.
+// Implements storage.AttachmentStore.
+func (s *EmbeddedDoltStore) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	var result map[string][]*types.Attachment
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetAttachmentsForIssuesInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
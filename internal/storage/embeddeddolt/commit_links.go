@@ -0,0 +1,35 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddCommitLinks records links between commits and issues.
+// Implements storage.CommitLinkStore.
+func (s *EmbeddedDoltStore) AddCommitLinks(ctx context.Context, links []*types.CommitLink) (int, error) {
+	var added int
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		added, err = issueops.AddCommitLinksInTx(ctx, tx, links)
+		return err
+	})
+	return added, err
+}
+
+// GetCommitLinksForIssues returns the commits linked to the given issues.
+// Implements storage.CommitLinkStore.
+func (s *EmbeddedDoltStore) GetCommitLinksForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.CommitLink, error) {
+	var result map[string][]*types.CommitLink
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetCommitLinksForIssuesInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
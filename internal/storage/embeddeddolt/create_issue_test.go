@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
@@ -470,6 +471,37 @@ func TestCreateIssue(t *testing.T) {
 		}
 	})
 
+	t.Run("counter_skips_reserved_range", func(t *testing.T) {
+		t.Setenv("BEADS_TEST_IGNORE_REPO_CONFIG", "1")
+		if err := config.Initialize(); err != nil {
+			t.Fatalf("config.Initialize: %v", err)
+		}
+		defer config.ResetForTesting()
+		te := newTestEnv(t, "cr")
+		ctx := t.Context()
+		if err := te.store.SetConfig(ctx, "issue_id_mode", "counter"); err != nil {
+			t.Fatalf("SetConfig(issue_id_mode): %v", err)
+		}
+
+		create := func(want string) {
+			t.Helper()
+			issue := &types.Issue{Title: want, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			if err := te.store.CreateIssue(ctx, issue, "tester"); err != nil {
+				t.Fatalf("CreateIssue %s: %v", want, err)
+			}
+			if issue.ID != want {
+				t.Errorf("counter ID: got %q, want %q", issue.ID, want)
+			}
+		}
+		create("cr-1")
+		// Another writer reserves 2-3 and allocates from it; the shared
+		// counter must step over the whole block.
+		config.Set("id.counter-range", "2-3")
+		create("cr-2")
+		config.Set("id.counter-range", "")
+		create("cr-4")
+	})
+
 	t.Run("counter_explicit_id_overrides", func(t *testing.T) {
 		te := newTestEnv(t, "co")
 		ctx := t.Context()
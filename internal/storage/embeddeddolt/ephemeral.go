@@ -0,0 +1,36 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// OpenEphemeral opens a throwaway store in a private temporary directory,
+// initialized with the given issue prefix ("bd" when empty). The directory is
+// deleted when the store is closed. The store bypasses the process cache:
+// every call returns a new, empty database.
+func OpenEphemeral(ctx context.Context, prefix string) (*EmbeddedDoltStore, error) {
+	dir, err := os.MkdirTemp("", "beads-ephemeral-")
+	if err != nil {
+		return nil, fmt.Errorf("embeddeddolt: creating ephemeral database directory: %w", err)
+	}
+	s, err := newStore(ctx, filepath.Join(dir, ".beads"), "beads", "main", openStrict)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	s.removeOnClose = dir
+
+	if prefix == "" {
+		prefix = "bd"
+	}
+	if err := s.SetConfig(ctx, "issue_prefix", prefix); err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("embeddeddolt: initializing ephemeral database: %w", err)
+	}
+	return s, nil
+}
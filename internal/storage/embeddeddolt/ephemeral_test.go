@@ -0,0 +1,56 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestOpenEphemeral(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	ctx := t.Context()
+
+	store, err := embeddeddolt.OpenEphemeral(ctx, "eph")
+	if err != nil {
+		t.Fatalf("OpenEphemeral: %v", err)
+	}
+	issue := &types.Issue{Title: "Transient", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if got, err := store.GetIssue(ctx, issue.ID); err != nil || got.Title != "Transient" {
+		t.Fatalf("GetIssue(%s) = %v, %v", issue.ID, got, err)
+	}
+	if len(issue.ID) < 4 || issue.ID[:4] != "eph-" {
+		t.Errorf("issue ID %q does not use the ephemeral prefix", issue.ID)
+	}
+
+	// A second ephemeral store is independent, not a cache hit.
+	other, err := embeddeddolt.OpenEphemeral(ctx, "")
+	if err != nil {
+		t.Fatalf("second OpenEphemeral: %v", err)
+	}
+	if _, err := other.GetIssue(ctx, issue.ID); err == nil {
+		t.Error("second ephemeral store sees the first store's issue")
+	}
+	if err := other.Close(); err != nil {
+		t.Fatalf("Close second store: %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("ephemeral directories left behind after Close: %v", entries)
+	}
+}
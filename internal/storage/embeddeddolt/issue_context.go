@@ -0,0 +1,24 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetIssueContext reads an issue with its full relational context in one
+// transaction. Implements storage.IssueContextStore.
+func (s *EmbeddedDoltStore) GetIssueContext(ctx context.Context, id string, eventLimit int) (*types.IssueDetails, error) {
+	var result *types.IssueDetails
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueContextInTx(ctx, tx, id, eventLimit, time.Now())
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,55 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetLabelDefinition creates or replaces a label's color and description.
+// Implements storage.LabelDefinitionStore.
+func (s *EmbeddedDoltStore) SetLabelDefinition(ctx context.Context, def *types.LabelDefinition) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.SetLabelDefinitionInTx(ctx, tx, def)
+	})
+}
+
+// GetLabelDefinitions returns every label definition ordered by name.
+// Implements storage.LabelDefinitionStore.
+func (s *EmbeddedDoltStore) GetLabelDefinitions(ctx context.Context) ([]*types.LabelDefinition, error) {
+	var defs []*types.LabelDefinition
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		defs, err = issueops.GetLabelDefinitionsInTx(ctx, tx)
+		return err
+	})
+	return defs, err
+}
+
+// RenameLabel renames a label on every issue and wisp, and its definition.
+// Implements storage.LabelDefinitionStore.
+func (s *EmbeddedDoltStore) RenameLabel(ctx context.Context, oldName, newName, actor string) (int, error) {
+	var n int
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		n, err = issueops.RenameLabelInTx(ctx, tx, oldName, newName, actor)
+		return err
+	})
+	return n, err
+}
+
+// DeleteLabel removes a label from every issue and wisp, and its definition.
+// Implements storage.LabelDefinitionStore.
+func (s *EmbeddedDoltStore) DeleteLabel(ctx context.Context, name, actor string) (int, error) {
+	var n int
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		n, err = issueops.DeleteLabelInTx(ctx, tx, name, actor)
+		return err
+	})
+	return n, err
+}
@@ -0,0 +1,90 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+)
+
+// TestEmbeddedPreviewMerge diverges one issue on main and a peer branch and
+// checks that PreviewMerge reports only the cell both sides changed, leaving
+// the fields only one side touched to Dolt's cell-level merge, and that the
+// preview merges nothing.
+func TestEmbeddedPreviewMerge(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt tests")
+	}
+	te := newTestEnv(t, "mp")
+	ctx := t.Context()
+	// The engine admits one opener at a time: seed on a pinned connection and
+	// release it before the store opens its own.
+	db, cleanup, err := embeddeddolt.OpenSQL(ctx, te.dataDir, te.database, "main")
+	if err != nil {
+		t.Fatalf("OpenSQL: %v", err)
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		_ = cleanup()
+		t.Fatalf("pin connection: %v", err)
+	}
+	exec := func(query string, args ...any) {
+		t.Helper()
+		if _, err := conn.ExecContext(ctx, query, args...); err != nil {
+			_ = conn.Close()
+			_ = cleanup()
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+	exec("INSERT INTO issues (id, title, description, design, acceptance_criteria, notes, status, priority, issue_type) VALUES ('mp-1', 'base', '', '', '', '', 'open', 2, 'task')")
+	exec("CALL DOLT_COMMIT('-Am', 'seed issue')")
+	exec("CALL DOLT_BRANCH('peer', 'HEAD')")
+	exec("UPDATE issues SET title = 'ours' WHERE id = 'mp-1'")
+	exec("CALL DOLT_COMMIT('-Am', 'main edit')")
+	exec("CALL DOLT_CHECKOUT('peer')")
+	exec("UPDATE issues SET title = 'theirs', priority = 0 WHERE id = 'mp-1'")
+	exec("CALL DOLT_COMMIT('-Am', 'peer edit')")
+	exec("CALL DOLT_CHECKOUT('main')")
+	_ = conn.Close()
+	if err := cleanup(); err != nil {
+		t.Fatalf("close seed connection: %v", err)
+	}
+
+	head, err := te.store.GetCurrentCommit(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentCommit: %v", err)
+	}
+	conflicts, err := te.store.PreviewMerge(ctx, "peer")
+	if err != nil {
+		t.Fatalf("PreviewMerge: %v", err)
+	}
+	var sawTitle bool
+	for _, c := range conflicts {
+		if c.IssueID != "mp-1" {
+			t.Errorf("conflict on unexpected row: %+v", c)
+		}
+		switch c.Field {
+		case "title":
+			sawTitle = true
+			if c.OursValue != "ours" || c.TheirsValue != "theirs" {
+				t.Errorf("title conflict = %+v, want ours/theirs", c)
+			}
+		case "priority":
+			t.Errorf("priority changed only on peer and must merge cleanly: %+v", c)
+		}
+	}
+	if !sawTitle {
+		t.Errorf("expected a title conflict, got %+v", conflicts)
+	}
+
+	var title string
+	te.queryScalar(t, ctx, "SELECT title FROM issues WHERE id = 'mp-1'", nil, &title)
+	if title != "ours" {
+		t.Errorf("preview changed main: title = %q", title)
+	}
+	if after, _ := te.store.GetCurrentCommit(ctx); after != head {
+		t.Errorf("preview moved HEAD from %s to %s", head, after)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetIssueRecurrence creates or replaces the recurrence rule of an issue.
+// Implements storage.RecurrenceStore.
+func (s *EmbeddedDoltStore) SetIssueRecurrence(ctx context.Context, r *types.Recurrence) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.SetIssueRecurrenceInTx(ctx, tx, r)
+	})
+}
+
+// RemoveIssueRecurrence deletes the recurrence rule of an issue.
+// Implements storage.RecurrenceStore.
+func (s *EmbeddedDoltStore) RemoveIssueRecurrence(ctx context.Context, issueID string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RemoveIssueRecurrenceInTx(ctx, tx, issueID)
+	})
+}
+
+// GetIssueRecurrences returns every recurrence rule.
+// Implements storage.RecurrenceStore.
+func (s *EmbeddedDoltStore) GetIssueRecurrences(ctx context.Context) ([]*types.Recurrence, error) {
+	var result []*types.Recurrence
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueRecurrencesInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
@@ -29,7 +29,21 @@ var _ storage.GarbageCollector = (*EmbeddedDoltStore)(nil)
 var _ storage.Flattener = (*EmbeddedDoltStore)(nil)
 var _ storage.Compactor = (*EmbeddedDoltStore)(nil)
 var _ storage.SchemaMigrator = (*EmbeddedDoltStore)(nil)
+var _ storage.SchemaInspector = (*EmbeddedDoltStore)(nil)
 var _ storage.ExternalRefHistoryQuerier = (*EmbeddedDoltStore)(nil)
+var _ storage.DeletedIssueQuerier = (*EmbeddedDoltStore)(nil)
+var _ storage.AttachmentStore = (*EmbeddedDoltStore)(nil)
+var _ storage.CommitLinkStore = (*EmbeddedDoltStore)(nil)
+var _ storage.AliasStore = (*EmbeddedDoltStore)(nil)
+var _ storage.AssignmentStore = (*EmbeddedDoltStore)(nil)
+var _ storage.RecurrenceStore = (*EmbeddedDoltStore)(nil)
+var _ storage.IssueContextStore = (*EmbeddedDoltStore)(nil)
+var _ storage.LabelDefinitionStore = (*EmbeddedDoltStore)(nil)
+var _ storage.EventImporter = (*EmbeddedDoltStore)(nil)
+var _ storage.ImpactQuerier = (*EmbeddedDoltStore)(nil)
+var _ storage.ExternalDependencyLister = (*EmbeddedDoltStore)(nil)
+var _ storage.CommentEditor = (*EmbeddedDoltStore)(nil)
+var _ storage.MergePreviewer = (*EmbeddedDoltStore)(nil)
 
 // EmbeddedDoltStore implements storage.DoltStorage backed by the embedded Dolt engine.
 // Each method call opens a short-lived connection, executes within an explicit
@@ -56,6 +70,9 @@ type EmbeddedDoltStore struct {
 	// (e.g. the post-command autocommit net, or the commit itself) - only the
 	// migration step is skipped.
 	intent openIntent
+	// removeOnClose is the temporary directory of a store opened with
+	// OpenEphemeral, deleted when the store is closed.
+	removeOnClose string
 }
 
 // openIntent classifies why a store is being opened. openStrict fails the
@@ -262,6 +279,11 @@ func (s *EmbeddedDoltStore) ApplySchemaMigrations(ctx context.Context) (int, err
 	return schema.MigrateUp(ctx, conn)
 }
 
+// InspectSchema runs fn in a read transaction. Implements storage.SchemaInspector.
+func (s *EmbeddedDoltStore) InspectSchema(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return s.withConn(ctx, false, fn)
+}
+
 func (s *EmbeddedDoltStore) initSchema(ctx context.Context) error {
 	db, cleanup, err := OpenSQL(ctx, s.dataDir, "", "")
 	if err != nil {
@@ -476,6 +498,31 @@ func (s *EmbeddedDoltStore) GetDependencyTree(ctx context.Context, issueID strin
 	return result, err
 }
 
+// GetImpact returns the open work that transitively depends on issueID.
+// Implements storage.ImpactQuerier.
+func (s *EmbeddedDoltStore) GetImpact(ctx context.Context, issueID string, maxDepth int) ([]*types.TreeNode, error) {
+	var result []*types.TreeNode
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetImpactInTx(ctx, tx, issueID, maxDepth)
+		return err
+	})
+	return result, err
+}
+
+// GetExternalDependencyRecords returns the dependencies whose target is not
+// stored in this database.
+// Implements storage.ExternalDependencyLister.
+func (s *EmbeddedDoltStore) GetExternalDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	var result map[string][]*types.Dependency
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetExternalDependencyRecordsInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
 // AddLabel is implemented in labels.go.
 
 // RemoveLabel is implemented in labels.go.
@@ -527,6 +574,31 @@ func (s *EmbeddedDoltStore) AddIssueComment(ctx context.Context, issueID, author
 	return result, err
 }
 
+// ImportEvents restores events verbatim. Implements storage.EventImporter.
+func (s *EmbeddedDoltStore) ImportEvents(ctx context.Context, events []*types.Event) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.ImportEventsInTx(ctx, tx, events)
+	})
+}
+
+// EditIssueComment replaces the text of a comment. Implements storage.CommentEditor.
+func (s *EmbeddedDoltStore) EditIssueComment(ctx context.Context, issueID, commentID, text string) (*types.Comment, error) {
+	var result *types.Comment
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.EditIssueCommentInTx(ctx, tx, issueID, commentID, text)
+		return err
+	})
+	return result, err
+}
+
+// DeleteIssueComment removes a comment. Implements storage.CommentEditor.
+func (s *EmbeddedDoltStore) DeleteIssueComment(ctx context.Context, issueID, commentID string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.DeleteIssueCommentInTx(ctx, tx, issueID, commentID)
+	})
+}
+
 func (s *EmbeddedDoltStore) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
 	var result []*types.Comment
 	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
@@ -599,6 +671,11 @@ func (s *EmbeddedDoltStore) Close() error {
 	}
 	if s.closed.CompareAndSwap(false, true) {
 		s.cleanGitRemoteCacheGarbage()
+		if s.removeOnClose != "" {
+			if err := os.RemoveAll(s.removeOnClose); err != nil {
+				return fmt.Errorf("embeddeddolt: removing ephemeral database: %w", err)
+			}
+		}
 	}
 	return nil
 }
@@ -820,6 +897,32 @@ func (s *EmbeddedDoltStore) Diff(ctx context.Context, fromRef, toRef string) ([]
 	return result, err
 }
 
+// DeletedIssues returns the issues deleted from the working set that are
+// still in commit history.
+// Implements storage.DeletedIssueQuerier.
+func (s *EmbeddedDoltStore) DeletedIssues(ctx context.Context) ([]*storage.HistoryEntry, error) {
+	var result []*storage.HistoryEntry
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.DeletedIssuesInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// IssueRelationsAsOf returns the labels, comments, and dependencies issueID
+// had as of ref.
+// Implements storage.DeletedIssueQuerier.
+func (s *EmbeddedDoltStore) IssueRelationsAsOf(ctx context.Context, issueID, ref string) (*storage.IssueRelations, error) {
+	var result *storage.IssueRelations
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.IssueRelationsAsOfInTx(ctx, tx, issueID, ref)
+		return err
+	})
+	return result, err
+}
+
 // PreviousExternalRef returns the external_ref value recorded for issueID
 // as of the most recent commit at or before asOf.
 // Implements storage.ExternalRefHistoryQuerier.
@@ -21,6 +21,11 @@ func Open(_ context.Context, _, _, _ string) (*EmbeddedDoltStore, error) {
 	return nil, errNoCGO
 }
 
+// OpenEphemeral returns an error when CGO is not enabled.
+func OpenEphemeral(_ context.Context, _ string) (*EmbeddedDoltStore, error) {
+	return nil, errNoCGO
+}
+
 // OpenReadOnly returns an error when CGO is not enabled.
 func OpenReadOnly(_ context.Context, _, _, _ string) (*EmbeddedDoltStore, error) {
 	return nil, errNoCGO
@@ -228,15 +228,40 @@ func (t *embeddedTransaction) GetLocalMetadata(ctx context.Context, key string)
 }
 
 func (t *embeddedTransaction) AddComment(ctx context.Context, issueID, actor, comment string) error {
-	return fmt.Errorf("embeddedTransaction: AddComment not implemented")
+	if err := issueops.AddCommentEventInTx(ctx, t.tx, issueID, actor, comment); err != nil {
+		return err
+	}
+	t.dirty.MarkDirty("events")
+	return nil
 }
 
 func (t *embeddedTransaction) ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
-	return nil, fmt.Errorf("embeddedTransaction: ImportIssueComment not implemented")
+	c, err := issueops.ImportIssueCommentInTx(ctx, t.tx, issueID, author, text, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	t.dirty.MarkDirty("comments")
+	return c, nil
 }
 
 func (t *embeddedTransaction) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
-	return nil, fmt.Errorf("embeddedTransaction: GetIssueComments not implemented")
+	return issueops.GetIssueCommentsInTx(ctx, t.tx, issueID)
+}
+
+func (t *embeddedTransaction) RedirectIssueAliases(ctx context.Context, fromID, toID, actor string) error {
+	if err := issueops.RedirectIssueAliasesInTx(ctx, t.tx, fromID, toID, actor); err != nil {
+		return err
+	}
+	t.dirty.MarkDirty("issue_aliases")
+	return nil
+}
+
+func (t *embeddedTransaction) SetIssueRecurrence(ctx context.Context, r *types.Recurrence) error {
+	if err := issueops.SetIssueRecurrenceInTx(ctx, t.tx, r); err != nil {
+		return err
+	}
+	t.dirty.MarkDirty("issue_recurrences")
+	return nil
 }
 
 func (t *embeddedTransaction) CreateIssueImport(ctx context.Context, issue *types.Issue, actor string, skipPrefixValidation bool) error {
@@ -243,6 +243,18 @@ func (s *EmbeddedDoltStore) Merge(ctx context.Context, branch string) ([]storage
 	return conflicts, err
 }
 
+// PreviewMerge reports the conflicts merging branch into the current branch
+// would produce without merging. Implements storage.MergePreviewer.
+func (s *EmbeddedDoltStore) PreviewMerge(ctx context.Context, branch string) ([]storage.Conflict, error) {
+	var conflicts []storage.Conflict
+	err := s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		var err error
+		conflicts, err = versioncontrolops.PreviewMerge(ctx, db, branch)
+		return err
+	})
+	return conflicts, err
+}
+
 // RecomputeBlockedAfterMerge recomputes the denormalized is_blocked column
 // for the rows changed since fromCommit and commits the result — the hook a
 // caller that resolved merge conflicts itself must run after committing the
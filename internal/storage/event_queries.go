@@ -15,6 +15,14 @@ type EventCursor struct {
 	ID        string
 }
 
+// EventImporter is implemented by storage backends that can restore audit
+// events verbatim, keeping their IDs and timestamps, e.g. when bd unarchive
+// brings back the history archived with an issue. Events whose ID already
+// exists are skipped.
+type EventImporter interface {
+	ImportEvents(ctx context.Context, events []*types.Event) error
+}
+
 // EventQueryStore provides keyset paging over the durable event log, beyond
 // the base Storage interface's time-only GetAllEventsSince. Callers that need
 // it type-assert to this interface.
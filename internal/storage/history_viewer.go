@@ -39,3 +39,32 @@ type ExternalRefHistoryQuerier interface {
 	// column was NULL.
 	PreviousExternalRef(ctx context.Context, issueID string, asOf time.Time) (ref string, found bool, err error)
 }
+
+// DeletedIssueQuerier is implemented by history-capable Dolt storage backends
+// that can list issues deleted from the working set but still present in
+// Dolt commit history. It backs 'bd trash': a deleted issue stays recoverable
+// from history until the history itself is flattened or garbage collected.
+type DeletedIssueQuerier interface {
+	HistoryViewer
+
+	// DeletedIssues returns the issues found in dolt_history_issues but not in
+	// the issues table, newest deletion first, each as of the last commit that
+	// contained it. An old ID that 'bd rename' kept as an alias is not deleted
+	// and is not listed. Wisps live in dolt_ignored tables, never reach
+	// history, and so are never listed.
+	DeletedIssues(ctx context.Context) ([]*HistoryEntry, error)
+
+	// IssueRelationsAsOf returns the labels, comments, and dependencies that
+	// issueID had as of ref, so a restore can bring them back with the issue.
+	IssueRelationsAsOf(ctx context.Context, issueID, ref string) (*IssueRelations, error)
+}
+
+// IssueRelations is what 'bd delete' removes along with an issue row.
+// Dependencies are the edges the issue owns; Dependents are other issues'
+// edges onto it.
+type IssueRelations struct {
+	Labels       []string
+	Dependencies []*types.Dependency
+	Dependents   []*types.Dependency
+	Comments     []*types.Comment
+}
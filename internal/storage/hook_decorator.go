@@ -448,8 +448,15 @@ func cloneIssueForHook(issue *types.Issue) *types.Issue {
 			clone.Comments[i] = &commentCopy
 		}
 	}
+	if issue.Attachments != nil {
+		clone.Attachments = make([]*types.Attachment, len(issue.Attachments))
+		for i, att := range issue.Attachments {
+			clone.Attachments[i] = clonePtr(att)
+		}
+	}
 	clone.BondedFrom = append([]types.BondRef(nil), issue.BondedFrom...)
 	clone.Waiters = append([]string(nil), issue.Waiters...)
+	clone.Assignees = append([]string(nil), issue.Assignees...)
 	return &clone
 }
 
@@ -287,8 +287,10 @@ func TestCloneIssueForHookCoversReferenceFields(t *testing.T) {
 		"Labels":            {},
 		"Dependencies":      {},
 		"Comments":          {},
+		"Attachments":       {},
 		"BondedFrom":        {},
 		"Waiters":           {},
+		"Assignees":         {},
 	}
 	issueType := reflect.TypeOf(types.Issue{})
 	for i := 0; i < issueType.NumField(); i++ {
@@ -0,0 +1,160 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// maxAliasLen matches the width of issue_aliases.alias.
+const maxAliasLen = 255
+
+// validateAlias rejects aliases that could never be typed as a single
+// command-line ID.
+func validateAlias(alias string) error {
+	if alias == "" {
+		return fmt.Errorf("alias is required")
+	}
+	if len(alias) > maxAliasLen {
+		return fmt.Errorf("alias %q is longer than %d characters", alias, maxAliasLen)
+	}
+	if strings.IndexFunc(alias, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("alias %q must not contain whitespace", alias)
+	}
+	return nil
+}
+
+// AddIssueAliasInTx records a.Alias as an alternate ID of a.IssueID.
+// Re-adding an alias to the issue it already names is a no-op; an alias held
+// by another issue, or one that is itself an issue ID, is an error. Wisps
+// cannot carry aliases: they are clone-local, while issue_aliases is synced.
+func AddIssueAliasInTx(ctx context.Context, tx DBTX, a *types.IssueAlias) error {
+	if err := validateAlias(a.Alias); err != nil {
+		return err
+	}
+	if IsActiveWispInTx(ctx, tx, a.IssueID) {
+		return fmt.Errorf("cannot alias ephemeral issue %s", a.IssueID)
+	}
+	var exists int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, a.IssueID).Scan(&exists); err != nil {
+		return fmt.Errorf("check issue %s: %w", a.IssueID, err)
+	}
+	if exists == 0 {
+		return fmt.Errorf("%w: issue %s", storage.ErrNotFound, a.IssueID)
+	}
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, a.Alias).Scan(&exists); err != nil {
+		return fmt.Errorf("check issue %s: %w", a.Alias, err)
+	}
+	if exists > 0 || IsActiveWispInTx(ctx, tx, a.Alias) {
+		return fmt.Errorf("alias %s is already an issue ID", a.Alias)
+	}
+
+	current, err := ResolveIssueAliasInTx(ctx, tx, a.Alias)
+	switch {
+	case err == nil && current == a.IssueID:
+		return nil
+	case err == nil:
+		return fmt.Errorf("alias %s already refers to %s", a.Alias, current)
+	case !errors.Is(err, storage.ErrNotFound):
+		return err
+	}
+
+	args := []any{a.Alias, a.IssueID, a.CreatedBy}
+	query := `INSERT INTO issue_aliases (alias, issue_id, created_by) VALUES (?, ?, ?)`
+	if !a.CreatedAt.IsZero() {
+		query = `INSERT INTO issue_aliases (alias, issue_id, created_by, created_at) VALUES (?, ?, ?, ?)`
+		args = append(args, a.CreatedAt.UTC())
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("add alias %s to %s: %w", a.Alias, a.IssueID, err)
+	}
+	return nil
+}
+
+// RemoveIssueAliasInTx deletes an alias.
+func RemoveIssueAliasInTx(ctx context.Context, tx DBTX, alias string) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM issue_aliases WHERE alias = ?`, alias)
+	if err != nil {
+		return fmt.Errorf("remove alias %s: %w", alias, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: alias %s", storage.ErrNotFound, alias)
+	}
+	return nil
+}
+
+// ResolveIssueAliasInTx returns the ID of the issue alias refers to, or an
+// error wrapping storage.ErrNotFound.
+func ResolveIssueAliasInTx(ctx context.Context, tx DBTX, alias string) (string, error) {
+	var issueID string
+	err := tx.QueryRowContext(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, alias).Scan(&issueID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: alias %s", storage.ErrNotFound, alias)
+	}
+	if err != nil {
+		return "", fmt.Errorf("resolve alias %s: %w", alias, err)
+	}
+	return issueID, nil
+}
+
+// GetIssueAliasesInTx returns the aliases of the given issues, oldest first
+// within each issue.
+func GetIssueAliasesInTx(ctx context.Context, tx DBTX, issueIDs []string) (map[string][]*types.IssueAlias, error) {
+	result := make(map[string][]*types.IssueAlias)
+	for start := 0; start < len(issueIDs); start += queryBatchSize {
+		batch := issueIDs[start:min(start+queryBatchSize, len(issueIDs))]
+		placeholders, args := buildSQLInClause(batch)
+		//nolint:gosec // G201: placeholders is a generated list of "?" markers
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT alias, issue_id, created_at, created_by
+			FROM issue_aliases
+			WHERE issue_id IN (%s)
+			ORDER BY issue_id, created_at, alias
+		`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("get issue aliases: %w", err)
+		}
+		for rows.Next() {
+			var a types.IssueAlias
+			if err := rows.Scan(&a.Alias, &a.IssueID, &a.CreatedAt, &a.CreatedBy); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan issue alias: %w", err)
+			}
+			result[a.IssueID] = append(result[a.IssueID], &a)
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// RedirectIssueAliasesInTx makes fromID and every alias of fromID resolve to
+// toID. Rename calls it after the foreign key has already carried the
+// aliases along; merging calls it before deleting fromID so the cascade does
+// not drop them. An alias spelled like toID is dropped because the real ID
+// shadows it.
+func RedirectIssueAliasesInTx(ctx context.Context, tx DBTX, fromID, toID, actor string) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE issue_aliases SET issue_id = ? WHERE issue_id = ?`, toID, fromID); err != nil {
+		return fmt.Errorf("move aliases of %s to %s: %w", fromID, toID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issue_aliases WHERE alias = ?`, toID); err != nil {
+		return fmt.Errorf("drop alias %s: %w", toID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO issue_aliases (alias, issue_id, created_by) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE issue_id = VALUES(issue_id)
+	`, fromID, toID, actor); err != nil {
+		return fmt.Errorf("record alias %s for %s: %w", fromID, toID, err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package issueops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// checkAssignableInTx rejects wisps and missing issues. Wisps are clone-local
+// while issue_assignees and issue_teams are synced.
+func checkAssignableInTx(ctx context.Context, tx DBTX, issueID string) error {
+	if IsActiveWispInTx(ctx, tx, issueID) {
+		return fmt.Errorf("cannot assign ephemeral issue %s to a team or several people", issueID)
+	}
+	var exists int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, issueID).Scan(&exists); err != nil {
+		return fmt.Errorf("check issue %s: %w", issueID, err)
+	}
+	if exists == 0 {
+		return fmt.Errorf("%w: issue %s", storage.ErrNotFound, issueID)
+	}
+	return nil
+}
+
+// AddIssueAssigneesInTx adds co-assignees to an issue. Names already present
+// are skipped.
+func AddIssueAssigneesInTx(ctx context.Context, tx DBTX, issueID string, assignees []string) error {
+	if err := checkAssignableInTx(ctx, tx, issueID); err != nil {
+		return err
+	}
+	for _, a := range assignees {
+		if strings.TrimSpace(a) == "" {
+			return fmt.Errorf("assignee name is required")
+		}
+		if err := types.CheckFieldLen("assignee", a); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO issue_assignees (issue_id, assignee) VALUES (?, ?)
+			ON DUPLICATE KEY UPDATE assignee = assignee
+		`, issueID, a); err != nil {
+			return fmt.Errorf("add assignee %s to %s: %w", a, issueID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveIssueAssigneesInTx removes co-assignees from an issue; nil removes
+// them all. Names that are not co-assignees are ignored.
+func RemoveIssueAssigneesInTx(ctx context.Context, tx DBTX, issueID string, assignees []string) error {
+	if assignees == nil {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM issue_assignees WHERE issue_id = ?`, issueID); err != nil {
+			return fmt.Errorf("remove assignees of %s: %w", issueID, err)
+		}
+		return nil
+	}
+	for _, a := range assignees {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM issue_assignees WHERE issue_id = ? AND assignee = ?`, issueID, a); err != nil {
+			return fmt.Errorf("remove assignee %s from %s: %w", a, issueID, err)
+		}
+	}
+	return nil
+}
+
+// SetIssueTeamInTx sets the team of an issue; "" clears it.
+func SetIssueTeamInTx(ctx context.Context, tx DBTX, issueID, team string) error {
+	if team == "" {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM issue_teams WHERE issue_id = ?`, issueID); err != nil {
+			return fmt.Errorf("clear team of %s: %w", issueID, err)
+		}
+		return nil
+	}
+	if err := checkAssignableInTx(ctx, tx, issueID); err != nil {
+		return err
+	}
+	if err := types.CheckFieldLen("team", team); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO issue_teams (issue_id, team) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE team = VALUES(team)
+	`, issueID, team); err != nil {
+		return fmt.Errorf("set team of %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// GetIssueAssignmentsInTx returns the co-assignees and team of the given
+// issues. Issues with neither are absent from the map.
+func GetIssueAssignmentsInTx(ctx context.Context, tx DBTX, issueIDs []string) (map[string]*types.IssueAssignment, error) {
+	result := make(map[string]*types.IssueAssignment)
+	get := func(id string) *types.IssueAssignment {
+		a := result[id]
+		if a == nil {
+			a = &types.IssueAssignment{}
+			result[id] = a
+		}
+		return a
+	}
+	for start := 0; start < len(issueIDs); start += queryBatchSize {
+		batch := issueIDs[start:min(start+queryBatchSize, len(issueIDs))]
+		placeholders, args := buildSQLInClause(batch)
+
+		//nolint:gosec // G201: placeholders is a generated list of "?" markers
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT issue_id, assignee FROM issue_assignees
+			WHERE issue_id IN (%s)
+			ORDER BY issue_id, created_at, assignee
+		`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("get issue assignees: %w", err)
+		}
+		for rows.Next() {
+			var issueID, assignee string
+			if err := rows.Scan(&issueID, &assignee); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan issue assignee: %w", err)
+			}
+			a := get(issueID)
+			a.Assignees = append(a.Assignees, assignee)
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		//nolint:gosec // G201: placeholders is a generated list of "?" markers
+		rows, err = tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT issue_id, team FROM issue_teams WHERE issue_id IN (%s)
+		`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("get issue teams: %w", err)
+		}
+		for rows.Next() {
+			var issueID, team string
+			if err := rows.Scan(&issueID, &team); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan issue team: %w", err)
+			}
+			get(issueID).Team = team
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// attachmentSHA256Pattern matches a lower-case hex SHA256 digest.
+var attachmentSHA256Pattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// AddAttachmentInTx records that issue a.IssueID references the blob a.SHA256
+// under a.Name. Re-attaching the same blob under the same name is a no-op.
+// Wisps cannot carry attachments: they are clone-local, while the attachments
+// table is synced.
+func AddAttachmentInTx(ctx context.Context, tx DBTX, a *types.Attachment) error {
+	if !attachmentSHA256Pattern.MatchString(a.SHA256) {
+		return fmt.Errorf("invalid attachment sha256 %q", a.SHA256)
+	}
+	if a.Name == "" {
+		return fmt.Errorf("attachment name is required")
+	}
+	if err := types.CheckFieldLen("name", a.Name); err != nil {
+		return err
+	}
+	if IsActiveWispInTx(ctx, tx, a.IssueID) {
+		return fmt.Errorf("cannot attach files to ephemeral issue %s", a.IssueID)
+	}
+	var exists int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, a.IssueID).Scan(&exists); err != nil {
+		return fmt.Errorf("check issue %s: %w", a.IssueID, err)
+	}
+	if exists == 0 {
+		return fmt.Errorf("%w: issue %s", storage.ErrNotFound, a.IssueID)
+	}
+	args := []any{a.IssueID, a.SHA256, a.Name, a.Size, a.MediaType, a.CreatedBy}
+	query := `INSERT IGNORE INTO attachments (issue_id, sha256, name, size, media_type, created_by) VALUES (?, ?, ?, ?, ?, ?)`
+	if !a.CreatedAt.IsZero() {
+		query = `INSERT IGNORE INTO attachments (issue_id, sha256, name, size, media_type, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+		args = append(args, a.CreatedAt.UTC())
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("add attachment to %s: %w", a.IssueID, err)
+	}
+	return nil
+}
+
+// RemoveAttachmentInTx removes the attachment of issueID named name. The blob
+// is left in place: other issues, or other clones, may still reference it.
+func RemoveAttachmentInTx(ctx context.Context, tx DBTX, issueID, name string) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ? AND name = ?`, issueID, name)
+	if err != nil {
+		return fmt.Errorf("remove attachment %s from %s: %w", name, issueID, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: attachment %q on %s", storage.ErrNotFound, name, issueID)
+	}
+	return nil
+}
+
+// GetAttachmentsForIssuesInTx returns the attachments of the given issues,
+// ordered by creation time then name within each issue.
+func GetAttachmentsForIssuesInTx(ctx context.Context, tx DBTX, issueIDs []string) (map[string][]*types.Attachment, error) {
+	result := make(map[string][]*types.Attachment)
+	for start := 0; start < len(issueIDs); start += queryBatchSize {
+		batch := issueIDs[start:min(start+queryBatchSize, len(issueIDs))]
+		placeholders, args := buildSQLInClause(batch)
+		//nolint:gosec // G201: placeholders is a generated list of "?" markers
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT issue_id, sha256, name, size, media_type, created_by, created_at
+			FROM attachments
+			WHERE issue_id IN (%s)
+			ORDER BY issue_id, created_at, name
+		`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("get attachments: %w", err)
+		}
+		for rows.Next() {
+			var a types.Attachment
+			var createdAt sql.NullTime
+			if err := rows.Scan(&a.IssueID, &a.SHA256, &a.Name, &a.Size, &a.MediaType, &a.CreatedBy, &createdAt); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan attachment: %w", err)
+			}
+			a.CreatedAt = createdAt.Time
+			result[a.IssueID] = append(result[a.IssueID], &a)
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -253,6 +253,10 @@ func updateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, iss
 		return fmt.Errorf("rename lease row: %w", err)
 	}
 
+	if err := RedirectIssueAliasesInTx(ctx, tx, oldID, newID, actor); err != nil {
+		return err
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (id, issue_id, event_type, actor, old_value, new_value)
 		VALUES (?, ?, 'renamed', ?, ?, ?)
@@ -30,6 +30,8 @@ func CloseIssueWithoutEventInTx(ctx context.Context, tx DBTX, id string, reason,
 
 // CloseIssueCheckedInTx closes an issue within a transaction, refusing with
 // storage.ErrCloseBlocked when it has a LIVE direct blocker unless force is set.
+// Without force it also refuses with storage.ErrTransitionNotAllowed when the
+// configured status workflow (CheckStatusTransitionInTx) does not allow the close.
 // The guard (IsBlockedInTx) and the close (CloseIssueInTx) share the SAME
 // transaction, so no blocker can clear between the check and the close.
 //
@@ -82,6 +84,15 @@ func CloseIssueCheckedInTx(ctx context.Context, tx DBTX, id, reason, actor, sess
 			if blocked && len(blockers) > 0 {
 				return nil, fmt.Errorf("%w: %s is blocked by %v", storage.ErrCloseBlocked, id, blockers)
 			}
+			issue, err := GetIssueInTx(ctx, tx, id)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return nil, err
+			}
+			if issue != nil {
+				if err := CheckStatusTransitionInTx(ctx, tx, issue, types.StatusClosed); err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 	return CloseIssueInTx(ctx, tx, id, reason, actor, session)
@@ -178,5 +189,58 @@ func closeIssueInTx(ctx context.Context, tx DBTX, id string, reason, actor, sess
 		return nil, fmt.Errorf("recompute is_blocked after close for %s: %w", id, err)
 	}
 
+	if err := closeOpenDuplicatesInTx(ctx, tx, id, actor, session, recordEvent); err != nil {
+		return nil, err
+	}
+
 	return &CloseResult{IsWisp: isWisp}, nil
 }
+
+// CloseOpenDuplicatesInTx closes the open issues marked as duplicates of
+// canonicalID (a duplicates dependency pointing at it), with the reason
+// "duplicate of <canonicalID>". Every path that closes an issue calls it in
+// the same transaction, so a duplicate never outlives the issue it duplicates
+// whichever command did the close. Duplicates of a duplicate close in turn.
+func CloseOpenDuplicatesInTx(ctx context.Context, tx DBTX, canonicalID, actor, session string) error {
+	return closeOpenDuplicatesInTx(ctx, tx, canonicalID, actor, session, true)
+}
+
+//nolint:gosec // G201: depTable is a hardcoded constant and DepTargetExpr a constant expression.
+func closeOpenDuplicatesInTx(ctx context.Context, tx DBTX, canonicalID, actor, session string, recordEvent bool) error {
+	var dupIDs []string
+	for _, depTable := range []string{"dependencies", "wisp_dependencies"} {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT issue_id FROM %s WHERE %s = ? AND type = ?
+		`, depTable, DepTargetExpr), canonicalID, types.DepDuplicates)
+		if err != nil {
+			if optionalBlockedTable(depTable) && isTableNotExistError(err) {
+				continue
+			}
+			return fmt.Errorf("find duplicates of %s in %s: %w", canonicalID, depTable, err)
+		}
+		for rows.Next() {
+			var dupID string
+			if err := rows.Scan(&dupID); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("scan duplicate of %s: %w", canonicalID, err)
+			}
+			dupIDs = append(dupIDs, dupID)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("find duplicates of %s in %s: %w", canonicalID, depTable, err)
+		}
+	}
+
+	// Already-closed duplicates are a no-op in closeIssueInTx, which also
+	// ends the recursion on a duplicates cycle.
+	for _, dupID := range dupIDs {
+		if _, err := closeIssueInTx(ctx, tx, dupID, "duplicate of "+canonicalID, actor, session, recordEvent); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue // dangling edge to a deleted issue
+			}
+			return fmt.Errorf("close duplicate %s of %s: %w", dupID, canonicalID, err)
+		}
+	}
+	return nil
+}
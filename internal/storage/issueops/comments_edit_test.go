@@ -0,0 +1,82 @@
+package issueops
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestEditIssueCommentInTx_ResolvesPrefix(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	const id = "0190f2a1-0000-7000-8000-000000000001"
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM wisps WHERE id = ? LIMIT 1")).
+		WithArgs("bd-1").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM comments WHERE issue_id = ? AND id LIKE ? LIMIT 2")).
+		WithArgs("bd-1", "0190f2a1%").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE comments SET text = ? WHERE id = ?")).
+		WithArgs("fixed", id).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, issue_id, author, text, created_at FROM comments WHERE id = ?")).
+		WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"id", "issue_id", "author", "text", "created_at"}).
+		AddRow(id, "bd-1", "alice", "fixed", time.Now()))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := EditIssueCommentInTx(context.Background(), tx, "bd-1", "0190F2A1", "fixed")
+	if err != nil {
+		t.Fatalf("EditIssueCommentInTx: %v", err)
+	}
+	if c.ID != id || c.Text != "fixed" || c.Author != "alice" {
+		t.Errorf("edited comment = %+v", c)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteIssueCommentInTx_Errors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(true)
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A LIKE wildcard never reaches the query.
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM wisps")).WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	if err := DeleteIssueCommentInTx(context.Background(), tx, "bd-1", "%"); err == nil {
+		t.Error("DeleteIssueCommentInTx accepted a wildcard id")
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM wisps")).WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM comments")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	if err := DeleteIssueCommentInTx(context.Background(), tx, "bd-1", "abc"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("missing comment: got %v, want ErrNotFound", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM wisps")).WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM comments")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("abc1").AddRow("abc2"))
+	if err := DeleteIssueCommentInTx(context.Background(), tx, "bd-1", "abc"); err == nil {
+		t.Error("ambiguous prefix accepted")
+	}
+}
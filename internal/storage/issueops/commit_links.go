@@ -0,0 +1,92 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// commitSHAPattern matches a full SHA-1 or SHA-256 git object name.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
+// AddCommitLinksInTx records commit links, skipping ones already present, and
+// returns how many were added. Every issue must exist and must not be a wisp:
+// wisps are clone-local, while the commit_links table is synced.
+func AddCommitLinksInTx(ctx context.Context, tx DBTX, links []*types.CommitLink) (int, error) {
+	added := 0
+	for _, link := range links {
+		if !commitSHAPattern.MatchString(link.CommitSHA) {
+			return added, fmt.Errorf("invalid commit sha %q", link.CommitSHA)
+		}
+		if IsActiveWispInTx(ctx, tx, link.IssueID) {
+			return added, fmt.Errorf("cannot link commits to ephemeral issue %s", link.IssueID)
+		}
+		var exists int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, link.IssueID).Scan(&exists); err != nil {
+			return added, fmt.Errorf("check issue %s: %w", link.IssueID, err)
+		}
+		if exists == 0 {
+			return added, fmt.Errorf("%w: issue %s", storage.ErrNotFound, link.IssueID)
+		}
+		subject := link.Subject
+		if runes := []rune(subject); len(runes) > 1024 {
+			subject = string(runes[:1024])
+		}
+		var committedAt any
+		if !link.CommittedAt.IsZero() {
+			committedAt = link.CommittedAt.UTC()
+		}
+		res, err := tx.ExecContext(ctx, `
+			INSERT IGNORE INTO commit_links (issue_id, commit_sha, subject, author, committed_at, closes)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, link.IssueID, link.CommitSHA, subject, link.Author, committedAt, link.Closes)
+		if err != nil {
+			return added, fmt.Errorf("link commit %s to %s: %w", link.CommitSHA, link.IssueID, err)
+		}
+		if n, err := res.RowsAffected(); err == nil && n > 0 {
+			added++
+		}
+	}
+	return added, nil
+}
+
+// GetCommitLinksForIssuesInTx returns the commits linked to the given issues,
+// oldest commit first within each issue.
+func GetCommitLinksForIssuesInTx(ctx context.Context, tx DBTX, issueIDs []string) (map[string][]*types.CommitLink, error) {
+	result := make(map[string][]*types.CommitLink)
+	for start := 0; start < len(issueIDs); start += queryBatchSize {
+		batch := issueIDs[start:min(start+queryBatchSize, len(issueIDs))]
+		placeholders, args := buildSQLInClause(batch)
+		//nolint:gosec // G201: placeholders is a generated list of "?" markers
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT issue_id, commit_sha, subject, author, committed_at, closes
+			FROM commit_links
+			WHERE issue_id IN (%s)
+			ORDER BY issue_id, committed_at, commit_sha
+		`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("get commit links: %w", err)
+		}
+		for rows.Next() {
+			var link types.CommitLink
+			var committedAt sql.NullTime
+			if err := rows.Scan(&link.IssueID, &link.CommitSHA, &link.Subject, &link.Author, &committedAt, &link.Closes); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan commit link: %w", err)
+			}
+			link.CommittedAt = committedAt.Time
+			result[link.IssueID] = append(result[link.IssueID], &link)
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -208,20 +208,10 @@ func CreateIssuesInTxWithResult(ctx context.Context, tx *sql.Tx, issues []*types
 		return CreateIssuesResult{}, err
 	}
 
-	result := CreateIssuesResult{}
-	accepted := issues[:0:0]
-	for _, issue := range issues {
-		issueResult, err := CreateIssueInTxWithResult(ctx, tx, bc, issue, actor)
-		if err != nil {
-			return CreateIssuesResult{}, err
-		}
-		result.merge(issueResult.ChangedTables)
-		if issueResult.StaleRejected {
-			continue // stale snapshot: keep its deps out of the batch too
-		}
-		accepted = append(accepted, issue)
+	result, issues, err := createIssueRowsInTx(ctx, tx, bc, issues, actor)
+	if err != nil {
+		return CreateIssuesResult{}, err
 	}
-	issues = accepted
 
 	depResult, err := PersistDependenciesWithOptionsResult(ctx, tx, issues, actor, opts)
 	if err != nil {
@@ -250,6 +240,73 @@ func CreateIssuesInTxWithResult(ctx context.Context, tx *sql.Tx, issues []*types
 	return result, nil
 }
 
+// createIssueRowsInTx writes the issue rows of a batch and their created
+// events, leases, labels, and comments — the per-issue work of
+// CreateIssueInTxWithResult, but with the row checks answered from one
+// prefetch and the rows and events written by multi-row INSERTs. It returns
+// the issues whose dependencies should still be persisted: every issue except
+// the ones the RejectStaleUpserts guard rejected.
+func createIssueRowsInTx(ctx context.Context, tx *sql.Tx, bc *BatchContext, issues []*types.Issue, actor string) (CreateIssuesResult, []*types.Issue, error) {
+	var result CreateIssuesResult
+	w, err := newBatchIssueWriter(ctx, tx, bc, issues)
+	if err != nil {
+		return result, nil, err
+	}
+	accepted := issues[:0:0]
+	var staged []batchStagedIssue
+	for _, issue := range issues {
+		s, written, staleRejected, err := w.stage(ctx, issue, actor)
+		if err != nil {
+			return result, nil, err
+		}
+		if staleRejected {
+			// The stored row is strictly newer than this snapshot; see
+			// CreateIssueInTxWithResult (bd-578h9.8).
+			if bc.Opts.OnStaleRejected != nil {
+				bc.Opts.OnStaleRejected(issue.ID)
+			}
+			continue // stale snapshot: keep its deps out of the batch too
+		}
+		accepted = append(accepted, issue)
+		if written {
+			staged = append(staged, s)
+			result.markChanged(s.issueTable)
+		}
+	}
+	if err := w.flush(ctx); err != nil {
+		return result, nil, err
+	}
+
+	// Leases are reconciled only once every row is written: the restore
+	// reads the stored row.
+	for _, s := range staged {
+		if s.issueTable == "issues" {
+			if err := RestoreLeaseOnImportInTx(ctx, tx, s.issue, s.isNew); err != nil {
+				return result, nil, err
+			}
+		}
+	}
+	if err := recordCreatedEvents(ctx, tx, staged, actor); err != nil {
+		return result, nil, err
+	}
+	for _, s := range staged {
+		if s.isNew {
+			result.markChanged(s.eventTable)
+		}
+		labelResult, err := PersistLabels(ctx, tx, s.issue, actor, s.eventTable)
+		if err != nil {
+			return result, nil, err
+		}
+		result.merge(labelResult.ChangedTables)
+		commentResult, err := PersistComments(ctx, tx, s.issue)
+		if err != nil {
+			return result, nil, err
+		}
+		result.merge(commentResult.ChangedTables)
+	}
+	return result, accepted, nil
+}
+
 // CreateIssueDirtyTables returns the regular Dolt tables CreateIssueInTx may
 // dirty for the given issue. Wisp tables are intentionally omitted because they
 // are Dolt-ignored and cannot be staged.
@@ -0,0 +1,273 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// batchInsertRows bounds one multi-row issue INSERT. Each row binds 47
+// parameters, so a full chunk stays far below the 65535-placeholder limit of
+// the MySQL protocol while still replacing hundreds of round-trips.
+const batchInsertRows = 200
+
+// batchLookupIDs bounds the IN list of the existence prefetch.
+const batchLookupIDs = 500
+
+// batchRows records what the batch knows about rows in the issues and wisps
+// tables: table -> id -> stored updated_at, where a nil value means the ID is
+// known to be absent. IDs missing from the map are unknown and are looked up
+// on demand.
+type batchRows map[string]map[string]*time.Time
+
+// batchIssueWriter stages issue rows for CreateIssuesInTxWithResult. The
+// existence, cross-table collision, orphan, and staleness checks that
+// CreateIssueInTxWithResult runs as one query each are answered from a
+// prefetch, and accepted rows are written with chunked multi-row UPSERTs, so
+// a batch costs a handful of statements instead of several per issue.
+type batchIssueWriter struct {
+	tx      *sql.Tx
+	bc      *BatchContext
+	rows    batchRows
+	pending map[string][]*types.Issue // table -> rows awaiting flush
+}
+
+// batchStagedIssue is an issue whose row was written (or deliberately left
+// alone) and whose created event, lease, labels, and comments are still due.
+type batchStagedIssue struct {
+	issue      *types.Issue
+	issueTable string
+	eventTable string
+	isNew      bool
+}
+
+func newBatchIssueWriter(ctx context.Context, tx *sql.Tx, bc *BatchContext, issues []*types.Issue) (*batchIssueWriter, error) {
+	w := &batchIssueWriter{
+		tx:      tx,
+		bc:      bc,
+		rows:    batchRows{"issues": {}, "wisps": {}},
+		pending: map[string][]*types.Issue{},
+	}
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, issue := range issues {
+		add(issue.ID)
+		if parentID, _, ok := ParseHierarchicalID(issue.ID); ok {
+			add(parentID)
+		}
+	}
+	for _, table := range []string{"issues", "wisps"} {
+		for _, id := range ids {
+			w.rows[table][id] = nil
+		}
+		if err := w.prefetch(ctx, table, ids); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// prefetch loads the stored updated_at of the given IDs that exist in table.
+//
+//nolint:gosec // G201: table is a hardcoded constant ("issues" or "wisps")
+func (w *batchIssueWriter) prefetch(ctx context.Context, table string, ids []string) error {
+	for start := 0; start < len(ids); start += batchLookupIDs {
+		chunk := ids[start:min(start+batchLookupIDs, len(ids))]
+		args := make([]any, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+		rows, err := w.tx.QueryContext(ctx, fmt.Sprintf(`SELECT id, updated_at FROM %s WHERE id IN (%s)`,
+			table, strings.TrimSuffix(strings.Repeat("?,", len(chunk)), ",")), args...)
+		if err != nil {
+			return fmt.Errorf("failed to check issue existence in %s: %w", table, err)
+		}
+		for rows.Next() {
+			var id string
+			var updatedAt sql.NullTime
+			if err := rows.Scan(&id, &updatedAt); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan existing issue in %s: %w", table, err)
+			}
+			t := updatedAt.Time
+			w.rows[table][id] = &t
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup reports whether id exists in table and its stored updated_at,
+// querying only for IDs the prefetch did not cover (generated IDs). Rows still
+// pending a flush are already recorded, so the answer includes them.
+//
+//nolint:gosec // G201: table is a hardcoded constant ("issues" or "wisps")
+func (w *batchIssueWriter) lookup(ctx context.Context, table, id string) (*time.Time, error) {
+	if updatedAt, known := w.rows[table][id]; known {
+		return updatedAt, nil
+	}
+	var updatedAt sql.NullTime
+	err := w.tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT updated_at FROM %s WHERE id = ?`, table), id).Scan(&updatedAt)
+	if err == sql.ErrNoRows {
+		w.rows[table][id] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check issue existence for %s: %w", id, err)
+	}
+	t := updatedAt.Time
+	w.rows[table][id] = &t
+	return &t, nil
+}
+
+// stage runs the per-issue create checks of CreateIssueInTxWithResult against
+// the batch state and queues the row. written is false when the issue is
+// skipped (ConflictSkip collision, OrphanSkip); staleRejected reports the
+// RejectStaleUpserts guard kept the stored row.
+func (w *batchIssueWriter) stage(ctx context.Context, issue *types.Issue, actor string) (staged batchStagedIssue, written, staleRejected bool, err error) {
+	opts := w.bc.Opts
+	if err := PrepareIssueForInsert(issue, w.bc.CustomStatuses, w.bc.CustomTypes); err != nil {
+		return staged, false, false, err
+	}
+	issueTable, eventTable := TableRouting(issue)
+	staged = batchStagedIssue{issue: issue, issueTable: issueTable, eventTable: eventTable}
+
+	if issue.ID == "" {
+		prefix := w.bc.ConfigPrefix
+		if issue.PrefixOverride != "" {
+			prefix = issue.PrefixOverride
+		} else if issue.IDPrefix != "" {
+			prefix = w.bc.ConfigPrefix + "-" + issue.IDPrefix
+		} else if IsWisp(issue) {
+			prefix = w.bc.ConfigPrefix + "-wisp"
+		}
+		// ID generation probes the table for collisions, so the rows staged
+		// so far must be visible to it.
+		if err := w.flush(ctx); err != nil {
+			return staged, false, false, err
+		}
+		issue.ID, err = GenerateIssueIDInTable(ctx, w.tx, issueTable, prefix, issue, actor)
+		if err != nil {
+			return staged, false, false, fmt.Errorf("failed to generate issue ID: %w", err)
+		}
+	} else if !opts.SkipPrefixValidation {
+		if err := ValidateIssueIDPrefix(issue.ID, w.bc.ConfigPrefix, w.bc.AllowedPrefixes); err != nil {
+			return staged, false, false, fmt.Errorf("prefix validation failed for %s: %w", issue.ID, err)
+		}
+	}
+
+	// Cross-table collision (GH#4455); see checkCrossTableIDCollision.
+	siblingTable := "wisps"
+	if issueTable == "wisps" {
+		siblingTable = "issues"
+	}
+	if sibling, err := w.lookup(ctx, siblingTable, issue.ID); err != nil {
+		return staged, false, false, err
+	} else if sibling != nil {
+		if opts.ConflictSkip {
+			return staged, false, false, nil
+		}
+		return staged, false, false, fmt.Errorf("cannot create %q: ID already exists in the %s table (issues and wisps share one ID space)", issue.ID, siblingTable)
+	}
+
+	// Orphan handling; see CheckOrphan.
+	if parentID, _, ok := ParseHierarchicalID(issue.ID); ok {
+		parent, err := w.lookup(ctx, issueTable, parentID)
+		if err != nil {
+			return staged, false, false, err
+		}
+		if parent == nil {
+			switch opts.OrphanHandling {
+			case storage.OrphanStrict:
+				return staged, false, false, fmt.Errorf("parent issue %s does not exist (strict mode)", parentID)
+			case storage.OrphanSkip:
+				return staged, false, false, nil
+			}
+		}
+	}
+
+	// Existence and staleness; see InsertIssueIfNew.
+	stored, err := w.lookup(ctx, issueTable, issue.ID)
+	if err != nil {
+		return staged, false, false, err
+	}
+	staged.isNew = stored == nil
+	if stored != nil && opts.ConflictSkip {
+		return staged, true, false, nil // already exists: keep the row, still merge aux data
+	}
+	if stored != nil && opts.RejectStaleUpserts && stored.After(issue.UpdatedAt) {
+		return staged, false, true, nil
+	}
+	w.pending[issueTable] = append(w.pending[issueTable], issue)
+	if stored == nil || !opts.RejectStaleUpserts || issue.UpdatedAt.After(*stored) {
+		updatedAt := issue.UpdatedAt
+		w.rows[issueTable][issue.ID] = &updatedAt
+	}
+	return staged, true, false, nil
+}
+
+// flush writes the pending rows with chunked multi-row UPSERTs.
+func (w *batchIssueWriter) flush(ctx context.Context) error {
+	for _, table := range []string{"issues", "wisps"} {
+		rows := w.pending[table]
+		for start := 0; start < len(rows); start += batchInsertRows {
+			chunk := rows[start:min(start+batchInsertRows, len(rows))]
+			if err := insertIssuesIntoTable(ctx, w.tx, table, chunk, w.bc.Opts.RejectStaleUpserts); err != nil {
+				if len(chunk) == 1 {
+					return fmt.Errorf("failed to insert issue %s: %w", chunk[0].ID, err)
+				}
+				return fmt.Errorf("failed to insert issues %s..%s: %w", chunk[0].ID, chunk[len(chunk)-1].ID, err)
+			}
+		}
+		delete(w.pending, table)
+	}
+	return nil
+}
+
+// recordCreatedEvents writes the 'created' events of the new issues with
+// chunked multi-row INSERTs, one statement per events table and chunk.
+//
+//nolint:gosec // G201: table is a hardcoded constant ("events" or "wisp_events")
+func recordCreatedEvents(ctx context.Context, tx *sql.Tx, staged []batchStagedIssue, actor string) error {
+	byTable := map[string][]string{}
+	for _, s := range staged {
+		if s.isNew {
+			byTable[s.eventTable] = append(byTable[s.eventTable], s.issue.ID)
+		}
+	}
+	for _, table := range []string{"events", "wisp_events"} {
+		ids := byTable[table]
+		for start := 0; start < len(ids); start += batchInsertRows {
+			chunk := ids[start:min(start+batchInsertRows, len(ids))]
+			rows := make([]string, len(chunk))
+			args := make([]any, 0, len(chunk)*6)
+			for i, id := range chunk {
+				rows[i] = "(?, ?, ?, ?, ?, ?)"
+				args = append(args, NewEventID(), id, types.EventCreated, actor, "", "")
+			}
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+				INSERT INTO %s (id, issue_id, event_type, actor, old_value, new_value)
+				VALUES %s
+			`, table, strings.Join(rows, ", ")), args...); err != nil {
+				return fmt.Errorf("failed to record created events in %s: %w", table, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package issueops
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func batchTestIssue(id string, updatedAt time.Time) *types.Issue {
+	return &types.Issue{
+		ID:        id,
+		Title:     "batch " + id,
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+		CreatedAt: updatedAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+func TestBatchIssueWriterStagesFromPrefetchAndInsertsOnce(t *testing.T) {
+	ctx := context.Background()
+	db, mock, tx := beginMockTx(t)
+	defer db.Close()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	fresh := batchTestIssue("test-a", now)
+	older := batchTestIssue("test-b", now)      // stored row is older: upserted
+	stale := batchTestIssue("test-c", now)      // stored row is newer: rejected
+	child := batchTestIssue("test-a.1", now)    // parent staged earlier in the batch
+	dup := batchTestIssue("test-a", now.Add(1)) // second row for the same ID
+
+	mock.ExpectQuery("SELECT id, updated_at FROM issues WHERE id IN").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "updated_at"}).
+			AddRow("test-b", now.Add(-time.Hour)).
+			AddRow("test-c", now.Add(time.Hour)))
+	mock.ExpectQuery("SELECT id, updated_at FROM wisps WHERE id IN").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "updated_at"}))
+	mock.ExpectExec("INSERT INTO issues").
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	bc := &BatchContext{ConfigPrefix: "test", Opts: storage.BatchCreateOptions{
+		RejectStaleUpserts: true,
+		OrphanHandling:     storage.OrphanStrict,
+	}}
+	issues := []*types.Issue{fresh, older, stale, child, dup}
+	w, err := newBatchIssueWriter(ctx, tx, bc, issues)
+	if err != nil {
+		t.Fatalf("newBatchIssueWriter: %v", err)
+	}
+
+	wantNew := map[*types.Issue]bool{fresh: true, older: false, child: true, dup: false}
+	for _, issue := range issues {
+		staged, written, staleRejected, err := w.stage(ctx, issue, "tester")
+		if err != nil {
+			t.Fatalf("stage %s: %v", issue.ID, err)
+		}
+		if issue == stale {
+			if !staleRejected || written {
+				t.Fatalf("stage %s: written=%v staleRejected=%v, want stale rejection", issue.ID, written, staleRejected)
+			}
+			continue
+		}
+		if !written || staleRejected {
+			t.Fatalf("stage %s: written=%v staleRejected=%v, want written", issue.ID, written, staleRejected)
+		}
+		if staged.isNew != wantNew[issue] {
+			t.Fatalf("stage %s: isNew=%v, want %v", issue.ID, staged.isNew, wantNew[issue])
+		}
+	}
+	if got := len(w.pending["issues"]); got != 4 {
+		t.Fatalf("pending issues = %d, want 4", got)
+	}
+	if err := w.flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestBatchIssueWriterRejectsCrossTableCollision(t *testing.T) {
+	ctx := context.Background()
+	db, mock, tx := beginMockTx(t)
+	defer db.Close()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery("SELECT id, updated_at FROM issues WHERE id IN").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "updated_at"}))
+	mock.ExpectQuery("SELECT id, updated_at FROM wisps WHERE id IN").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "updated_at"}).AddRow("test-w", now))
+
+	issue := batchTestIssue("test-w", now)
+	w, err := newBatchIssueWriter(ctx, tx, &BatchContext{ConfigPrefix: "test"}, []*types.Issue{issue})
+	if err != nil {
+		t.Fatalf("newBatchIssueWriter: %v", err)
+	}
+	if _, _, _, err := w.stage(ctx, issue, "tester"); err == nil {
+		t.Fatal("stage: want cross-table collision error")
+	}
+
+	w.bc.Opts.ConflictSkip = true
+	_, written, _, err := w.stage(ctx, issue, "tester")
+	if err != nil || written {
+		t.Fatalf("stage with ConflictSkip: written=%v err=%v, want skipped", written, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRecordCreatedEventsBatchesPerTable(t *testing.T) {
+	ctx := context.Background()
+	db, mock, tx := beginMockTx(t)
+	defer db.Close()
+
+	staged := []batchStagedIssue{
+		{issue: &types.Issue{ID: "test-a"}, eventTable: "events", isNew: true},
+		{issue: &types.Issue{ID: "test-b"}, eventTable: "events", isNew: false},
+		{issue: &types.Issue{ID: "test-c"}, eventTable: "events", isNew: true},
+		{issue: &types.Issue{ID: "test-w"}, eventTable: "wisp_events", isNew: true},
+	}
+	mock.ExpectExec("INSERT INTO events").
+		WithArgs(sqlmock.AnyArg(), "test-a", types.EventCreated, "tester", "", "",
+			sqlmock.AnyArg(), "test-c", types.EventCreated, "tester", "", "").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO wisp_events").
+		WithArgs(sqlmock.AnyArg(), "test-w", types.EventCreated, "tester", "", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := recordCreatedEvents(ctx, tx, staged, "tester"); err != nil {
+		t.Fatalf("recordCreatedEvents: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -51,6 +51,41 @@ func getAllDependencyRecordsIntoFromTable(ctx context.Context, tx DBTX, depTable
 	return nil
 }
 
+// GetExternalDependencyRecordsInTx returns the dependency records, from both
+// dependency tables, whose target is not stored here: external:<project>:<cap>
+// references and issues with another prefix.
+func GetExternalDependencyRecordsInTx(ctx context.Context, tx DBTX) (map[string][]*types.Dependency, error) {
+	result := make(map[string][]*types.Dependency)
+	for _, depTable := range []string{"dependencies", "wisp_dependencies"} {
+		//nolint:gosec // G201: depTable is a hardcoded table name.
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT issue_id, depends_on_external, type, created_at, created_by, metadata, thread_id
+			FROM %s
+			WHERE depends_on_external IS NOT NULL
+			ORDER BY issue_id
+		`, depTable))
+		if err != nil {
+			if optionalBlockedTable(depTable) && isTableNotExistError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("get external dependency records from %s: %w", depTable, err)
+		}
+		for rows.Next() {
+			dep, scanErr := scanDependencyRow(rows)
+			if scanErr != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("get external dependency records from %s: %w", depTable, scanErr)
+			}
+			result[dep.IssueID] = append(result[dep.IssueID], dep)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("get external dependency records from %s: %w", depTable, err)
+		}
+	}
+	return result, nil
+}
+
 // GetDependencyRecordsForIssuesInTx returns dependency records for specific issues,
 // routing each ID to dependencies or wisp_dependencies based on wisp status.
 // Uses a single batched wisp-partition query + batched IN clauses, so cost is
@@ -83,6 +83,33 @@ func TestGetAllDependencyRecordsInTxToleratesMissingWispDependencyTable(t *testi
 	}
 }
 
+func TestGetExternalDependencyRecordsInTx(t *testing.T) {
+	t.Parallel()
+
+	_, mock, tx := beginMockTx(t)
+	query := func(table string) string {
+		return `(?s)SELECT issue_id, depends_on_external, type, created_at, created_by, metadata, thread_id\s+FROM ` +
+			regexp.QuoteMeta(table) + `\s+WHERE depends_on_external IS NOT NULL`
+	}
+	mock.ExpectQuery(query("dependencies")).
+		WillReturnRows(dependencyRows().AddRow(
+			"bd-1", "hr-7", types.DepBlocks, time.Now(), "tester", "{}", "",
+		))
+	mock.ExpectQuery(query("wisp_dependencies")).
+		WillReturnError(errors.New("Error 1146: Table 'db.wisp_dependencies' doesn't exist"))
+
+	got, err := GetExternalDependencyRecordsInTx(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("GetExternalDependencyRecordsInTx: %v", err)
+	}
+	if dep := onlyDependency(t, got, "bd-1"); dep.DependsOnID != "hr-7" {
+		t.Fatalf("external dependency target = %q, want hr-7", dep.DependsOnID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet SQL expectations: %v", err)
+	}
+}
+
 func onlyDependency(t *testing.T, deps map[string][]*types.Dependency, issueID string) *types.Dependency {
 	t.Helper()
 
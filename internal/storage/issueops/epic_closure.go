@@ -3,6 +3,7 @@ package issueops
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -72,6 +73,7 @@ func GetEpicsEligibleForClosureInTx(ctx context.Context, tx DBTX) ([]*types.Epic
 		allChildIDs = append(allChildIDs, children...)
 	}
 	childStatusMap := make(map[string]string)
+	childBlocked := make(map[string]bool)
 	if len(allChildIDs) > 0 {
 		// Check both issues and wisps tables for child statuses (bd-w2w)
 		for _, table := range []string{"issues", "wisps"} {
@@ -83,7 +85,7 @@ func GetEpicsEligibleForClosureInTx(ctx context.Context, tx DBTX) ([]*types.Epic
 				batch := allChildIDs[start:end]
 				placeholders, args := buildSQLInClause(batch)
 
-				statusQuery := fmt.Sprintf("SELECT id, status FROM %s WHERE id IN (%s)", table, placeholders)
+				statusQuery := fmt.Sprintf("SELECT id, status, is_blocked FROM %s WHERE id IN (%s)", table, placeholders)
 				statusRows, err := tx.QueryContext(ctx, statusQuery, args...)
 				if err != nil {
 					if isTableNotExistError(err) {
@@ -93,11 +95,13 @@ func GetEpicsEligibleForClosureInTx(ctx context.Context, tx DBTX) ([]*types.Epic
 				}
 				for statusRows.Next() {
 					var id, status string
-					if err := statusRows.Scan(&id, &status); err != nil {
+					var blocked bool
+					if err := statusRows.Scan(&id, &status, &blocked); err != nil {
 						statusRows.Close()
 						return nil, fmt.Errorf("scan child status: %w", err)
 					}
 					childStatusMap[id] = status
+					childBlocked[id] = blocked
 				}
 				statusRows.Close()
 			}
@@ -121,6 +125,7 @@ func GetEpicsEligibleForClosureInTx(ctx context.Context, tx DBTX) ([]*types.Epic
 	}
 
 	// Step 5: Build results from cached data
+	now := time.Now().UTC()
 	var results []*types.EpicStatus
 	for _, epicID := range epicIDs {
 		children := epicChildMap[epicID]
@@ -133,21 +138,40 @@ func GetEpicsEligibleForClosureInTx(ctx context.Context, tx DBTX) ([]*types.Epic
 			continue
 		}
 
-		totalChildren := len(children)
-		closedChildren := 0
+		es := &types.EpicStatus{Epic: issue, TotalChildren: len(children)}
 		for _, childID := range children {
-			if status, ok := childStatusMap[childID]; ok && types.Status(status) == types.StatusClosed {
-				closedChildren++
+			switch types.Status(childStatusMap[childID]) {
+			case types.StatusClosed:
+				es.ClosedChildren++
+				continue
+			case types.StatusInProgress:
+				es.InProgressChildren++
+			case types.StatusBlocked:
+				es.BlockedChildren++
+				continue
+			}
+			if childBlocked[childID] {
+				es.BlockedChildren++
 			}
 		}
+		es.EligibleForClose = es.TotalChildren > 0 && es.TotalChildren == es.ClosedChildren
+		es.ProjectedFinish = ProjectEpicFinish(issue.CreatedAt, es.ClosedChildren, es.TotalChildren, now)
 
-		results = append(results, &types.EpicStatus{
-			Epic:             issue,
-			TotalChildren:    totalChildren,
-			ClosedChildren:   closedChildren,
-			EligibleForClose: totalChildren > 0 && totalChildren == closedChildren,
-		})
+		results = append(results, es)
 	}
 
 	return results, nil
 }
+
+// ProjectEpicFinish extrapolates when an epic's open children will be closed,
+// assuming children keep closing at the average rate since the epic was
+// created. Returns nil when there is nothing to project: no child closed yet,
+// all children closed, or no creation time.
+func ProjectEpicFinish(createdAt time.Time, closed, total int, now time.Time) *time.Time {
+	if closed == 0 || closed >= total || createdAt.IsZero() || !now.After(createdAt) {
+		return nil
+	}
+	perChild := now.Sub(createdAt) / time.Duration(closed)
+	finish := now.Add(perChild * time.Duration(total-closed))
+	return &finish
+}
@@ -0,0 +1,31 @@
+package issueops
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectEpicFinish(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(10 * 24 * time.Hour)
+
+	// 2 of 6 closed in 10 days: 5 days per child, 4 left.
+	got := ProjectEpicFinish(created, 2, 6, now)
+	if want := now.Add(20 * 24 * time.Hour); got == nil || !got.Equal(want) {
+		t.Fatalf("ProjectEpicFinish = %v, want %v", got, want)
+	}
+	for _, tc := range []struct {
+		name          string
+		closed, total int
+		createdAt     time.Time
+	}{
+		{"nothing closed", 0, 6, created},
+		{"all closed", 6, 6, created},
+		{"no creation time", 2, 6, time.Time{}},
+		{"created in the future", 2, 6, now.Add(time.Hour)},
+	} {
+		if got := ProjectEpicFinish(tc.createdAt, tc.closed, tc.total, now); got != nil {
+			t.Errorf("%s: ProjectEpicFinish = %v, want nil", tc.name, got)
+		}
+	}
+}
@@ -36,6 +36,24 @@ func GetEventsInTx(ctx context.Context, tx DBTX, issueID string, limit int) ([]*
 	return scanEvents(rows)
 }
 
+// ImportEventsInTx inserts events with their original IDs and timestamps,
+// each into the events table of the issue or wisp it belongs to. Events whose
+// ID already exists are skipped, so a repeated import is a no-op.
+//
+//nolint:gosec // G201: table is hardcoded via WispTableRouting
+func ImportEventsInTx(ctx context.Context, tx DBTX, events []*types.Event) error {
+	for _, e := range events {
+		_, _, eventTable, _ := WispTableRouting(IsActiveWispInTx(ctx, tx, e.IssueID))
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			INSERT IGNORE INTO %s (id, issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, eventTable), e.ID, e.IssueID, e.EventType, e.Actor, e.OldValue, e.NewValue, e.Comment, e.CreatedAt); err != nil {
+			return fmt.Errorf("import event %s for %s: %w", e.ID, e.IssueID, err)
+		}
+	}
+	return nil
+}
+
 // GetAllEventsSinceInTx returns all events created after the given time,
 // querying both events and wisp_events tables.
 func GetAllEventsSinceInTx(ctx context.Context, tx *sql.Tx, since time.Time) ([]*types.Event, error) {
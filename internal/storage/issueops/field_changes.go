@@ -0,0 +1,111 @@
+package issueops
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// FieldChange is one field's before/after value in an issue event.
+type FieldChange struct {
+	EventID   string          `json:"event_id"`
+	EventType types.EventType `json:"event_type"`
+	Actor     string          `json:"actor"`
+	At        time.Time       `json:"at"`
+	Field     string          `json:"field"`
+	Old       string          `json:"old"`
+	New       string          `json:"new"`
+	// OldKnown is false when the event did not record the prior value
+	// (close and reopen events store only the new state).
+	OldKnown bool `json:"old_known"`
+}
+
+// eventFieldAliases maps update columns to the Issue JSON key that holds the
+// old value, where the two differ.
+var eventFieldAliases = map[string]string{
+	"wisp": "ephemeral",
+}
+
+// EventFieldChanges derives the per-field changes recorded by event. Update,
+// claim, and unclaim events store the full pre-update issue as old_value and
+// the applied column values as new_value, so every changed column yields a
+// FieldChange; close, reopen, and reclaim events yield the status and owner
+// changes they imply. Fields whose value did not actually change are omitted,
+// and the result is sorted by field name. Other events yield nothing.
+func EventFieldChanges(event *types.Event) []FieldChange {
+	base := FieldChange{EventID: event.ID, EventType: event.EventType, Actor: event.Actor, At: event.CreatedAt}
+	change := func(field, oldVal, newVal string, oldKnown bool) FieldChange {
+		c := base
+		c.Field, c.Old, c.New, c.OldKnown = field, oldVal, newVal, oldKnown
+		return c
+	}
+	switch event.EventType {
+	case types.EventClosed:
+		changes := []FieldChange{change("status", "", string(types.StatusClosed), false)}
+		if reason := derefString(event.NewValue); reason != "" {
+			changes = append(changes, change("close_reason", "", reason, false))
+		}
+		return changes
+	case types.EventReopened:
+		return []FieldChange{change("status", "", string(types.StatusOpen), false)}
+	case types.EventLeaseReclaimed:
+		return []FieldChange{
+			change("assignee", derefString(event.OldValue), "", true),
+			change("status", string(types.StatusInProgress), string(types.StatusOpen), true),
+		}
+	}
+
+	if event.OldValue == nil || event.NewValue == nil {
+		return nil
+	}
+	var oldIssue, updates map[string]json.RawMessage
+	if json.Unmarshal([]byte(*event.OldValue), &oldIssue) != nil ||
+		json.Unmarshal([]byte(*event.NewValue), &updates) != nil {
+		return nil
+	}
+	var changes []FieldChange
+	for field, raw := range updates {
+		oldKey := field
+		if alias, ok := eventFieldAliases[field]; ok {
+			oldKey = alias
+		}
+		oldVal, newVal := eventValueString(oldIssue[oldKey]), eventValueString(raw)
+		// The Issue JSON omits false booleans, so an absent old value
+		// equals an explicit false.
+		if oldVal == newVal || (oldVal == "" && newVal == "false") {
+			continue
+		}
+		changes = append(changes, change(field, oldVal, newVal, true))
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// eventValueString renders a JSON value for display: strings unquoted, null
+// and absent (omitempty) values empty, anything else as compact JSON.
+func eventValueString(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if json.Compact(&buf, raw) == nil {
+		return strings.TrimSpace(buf.String())
+	}
+	return string(raw)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
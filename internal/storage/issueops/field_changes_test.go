@@ -0,0 +1,53 @@
+package issueops
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestEventFieldChanges(t *testing.T) {
+	fields := func(changes []FieldChange) [][3]string {
+		var out [][3]string
+		for _, c := range changes {
+			out = append(out, [3]string{c.Field, c.Old, c.New})
+		}
+		return out
+	}
+
+	updated := &types.Event{
+		ID:        "ev-1",
+		EventType: types.EventUpdated,
+		Actor:     "alice",
+		OldValue:  strPtr(`{"id":"bd-1","title":"Old","priority":2,"notes":"same"}`),
+		NewValue:  strPtr(`{"title":"New","priority":0,"notes":"same","description":"added","wisp":false}`),
+	}
+	got := EventFieldChanges(updated)
+	want := [][3]string{
+		{"description", "", "added"},
+		{"priority", "2", "0"},
+		{"title", "Old", "New"},
+	}
+	if !reflect.DeepEqual(fields(got), want) {
+		t.Errorf("update changes = %v, want %v", fields(got), want)
+	}
+	if got[0].Actor != "alice" || got[0].EventID != "ev-1" || !got[0].OldKnown {
+		t.Errorf("change metadata = %+v", got[0])
+	}
+
+	closed := &types.Event{EventType: types.EventClosed, NewValue: strPtr("done")}
+	got = EventFieldChanges(closed)
+	if want := [][3]string{{"status", "", "closed"}, {"close_reason", "", "done"}}; !reflect.DeepEqual(fields(got), want) {
+		t.Errorf("close changes = %v, want %v", fields(got), want)
+	}
+	if got[0].OldKnown {
+		t.Error("close event: old value should be unknown")
+	}
+
+	if got := EventFieldChanges(&types.Event{EventType: types.EventCommented, NewValue: strPtr("hi")}); got != nil {
+		t.Errorf("comment event changes = %v, want none", got)
+	}
+}
@@ -107,11 +107,16 @@ func InsertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *
 	return insertIssueIntoTable(ctx, tx, table, issue, false)
 }
 
-//nolint:gosec // G201: table is a hardcoded constant ("issues" or "wisps")
 func insertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *types.Issue, rejectStaleUpdate bool) error {
-	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
-		INSERT INTO %s (
-			id, content_hash, title, description, design, acceptance_criteria, notes,
+	if err := insertIssuesIntoTable(ctx, tx, table, []*types.Issue{issue}, rejectStaleUpdate); err != nil {
+		return fmt.Errorf("insert issue into %s: %w", table, err)
+	}
+	return nil
+}
+
+// issueInsertColumns lists the columns written by the issue UPSERT, in the
+// order issueInsertArgs returns their values.
+const issueInsertColumns = `id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, created_by, owner, updated_at, started_at, closed_at, external_ref, spec_id,
 			compaction_level, compacted_at, compacted_at_commit, original_size,
@@ -120,22 +125,10 @@ func insertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *
 			event_kind, actor, target, payload,
 			await_type, await_id, timeout_ns, waiters,
 			due_at, defer_until, metadata,
-			row_lock
-		) VALUES (
-			?, ?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?,
-			?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?,
-			?, ?, ?, ?,
-			?, ?, ?, ?,
-			?, ?, ?,
-			?
-		)
-		ON DUPLICATE KEY UPDATE
-			%s
-	`, table, issueUpsertAssignments(table, rejectStaleUpdate)),
+			row_lock`
+
+func issueInsertArgs(issue *types.Issue) []any {
+	return []any{
 		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
 		issue.Status, issue.Priority, issue.IssueType, NullString(issue.Assignee), NullInt(issue.EstimatedMinutes),
 		issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt, issue.StartedAt, issue.ClosedAt, NullStringPtr(issue.ExternalRef), issue.SpecID,
@@ -146,11 +139,34 @@ func insertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *
 		issue.AwaitType, issue.AwaitID, issue.Timeout.Nanoseconds(), FormatJSONStringArray(issue.Waiters),
 		issue.DueAt, issue.DeferUntil, JSONMetadata(issue.Metadata),
 		freshRowLock(),
-	)
-	if err != nil {
-		return fmt.Errorf("insert issue into %s: %w", table, err)
 	}
-	return nil
+}
+
+// insertIssuesIntoTable upserts issues with one multi-row INSERT … ON
+// DUPLICATE KEY UPDATE. Rows are applied in order, so a later row with the
+// same ID updates the row an earlier one inserted, exactly as separate
+// statements would.
+//
+//nolint:gosec // G201: table is a hardcoded constant ("issues" or "wisps")
+func insertIssuesIntoTable(ctx context.Context, tx *sql.Tx, table string, issues []*types.Issue, rejectStaleUpdate bool) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	var args []any
+	rows := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		rowArgs := issueInsertArgs(issue)
+		rows = append(rows, "("+strings.TrimSuffix(strings.Repeat("?, ", len(rowArgs)), ", ")+")")
+		args = append(args, rowArgs...)
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %s (
+			%s
+		) VALUES %s
+		ON DUPLICATE KEY UPDATE
+			%s
+	`, table, issueInsertColumns, strings.Join(rows, ",\n\t\t\t"), issueUpsertAssignments(table, rejectStaleUpdate)), args...)
+	return err
 }
 
 // RecordEventInTable records an event in the specified events table.
@@ -225,6 +241,16 @@ func IsCounterModeTx(ctx context.Context, tx *sql.Tx) (bool, error) {
 
 // NextCounterIDTx atomically increments and returns the next sequential issue ID.
 func NextCounterIDTx(ctx context.Context, tx *sql.Tx, prefix string) (string, error) {
+	if r, err := ConfiguredIDRange(); err != nil {
+		return "", err
+	} else if r != nil {
+		n, err := NextRangedCounterIDTx(ctx, tx, prefix, *r)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s-%d", prefix, n), nil
+	}
+
 	res, err := tx.ExecContext(ctx, "UPDATE issue_counter SET last_id = last_id + 1 WHERE prefix = ?", prefix)
 	if err != nil {
 		return "", fmt.Errorf("failed to increment issue counter for prefix %q: %w", prefix, err)
@@ -260,6 +286,9 @@ func NextCounterIDTx(ctx context.Context, tx *sql.Tx, prefix string) (string, er
 	if err != nil {
 		return "", fmt.Errorf("failed to read issue counter after increment for prefix %q: %w", prefix, err)
 	}
+	if nextID, err = skipReservedIDRangesTx(ctx, tx, prefix, nextID); err != nil {
+		return "", err
+	}
 	return fmt.Sprintf("%s-%d", prefix, nextID), nil
 }
 
@@ -429,21 +458,7 @@ func ValidateMetadataIfConfigured(metadata json.RawMessage) error {
 		return nil
 	}
 
-	rawFields := config.MetadataSchemaFields()
-	if rawFields == nil {
-		return nil
-	}
-
-	fields := make(map[string]storage.MetadataFieldSchema)
-	for name, raw := range rawFields {
-		fieldMap, ok := raw.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		schema := ParseFieldSchema(fieldMap)
-		fields[name] = schema
-	}
-
+	fields := ConfiguredMetadataFields()
 	if len(fields) == 0 {
 		return nil
 	}
@@ -468,6 +483,26 @@ func ValidateMetadataIfConfigured(metadata json.RawMessage) error {
 	return fmt.Errorf("metadata schema violation: %s", errs[0].Error())
 }
 
+// ConfiguredMetadataFields returns the custom fields declared under
+// validation.metadata.fields, keyed by name. Declarations apply whatever the
+// validation mode: they also type --set-metadata values and group the fields
+// in bd show.
+func ConfiguredMetadataFields() map[string]storage.MetadataFieldSchema {
+	rawFields := config.MetadataSchemaFields()
+	if rawFields == nil {
+		return nil
+	}
+	fields := make(map[string]storage.MetadataFieldSchema)
+	for name, raw := range rawFields {
+		fieldMap, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fields[name] = ParseFieldSchema(fieldMap)
+	}
+	return fields
+}
+
 // ParseFieldSchema converts a raw config map into a MetadataFieldSchema.
 func ParseFieldSchema(m map[string]interface{}) storage.MetadataFieldSchema {
 	schema := storage.MetadataFieldSchema{}
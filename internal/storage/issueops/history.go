@@ -34,7 +34,125 @@ func HistoryInTx(ctx context.Context, tx DBTX, issueID string) ([]*storage.Histo
 		return nil, fmt.Errorf("failed to get issue history: %w", err)
 	}
 	defer rows.Close()
+	return scanHistoryEntries(rows)
+}
+
+// DeletedIssuesInTx returns the issues present in dolt_history_issues but
+// absent from the issues table, newest first, each as of the last commit that
+// contained it. IDs kept as aliases by a rename are left out: the issue lives
+// on under its new ID.
+func DeletedIssuesInTx(ctx context.Context, tx DBTX) ([]*storage.HistoryEntry, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			h.id, h.title, h.description, h.design, h.acceptance_criteria, h.notes,
+			h.status, h.priority, h.issue_type, h.assignee, h.owner, h.created_by,
+			h.estimated_minutes, h.created_at, h.updated_at, h.closed_at, h.close_reason,
+			h.pinned, h.mol_type,
+			h.commit_hash, h.committer, h.commit_date
+		FROM dolt_history_issues h
+		JOIN (
+			SELECT id, MAX(commit_date) AS last_seen
+			FROM dolt_history_issues
+			WHERE id NOT IN (SELECT id FROM issues)
+			  AND id NOT IN (SELECT alias FROM issue_aliases)
+			GROUP BY id
+		) d ON h.id = d.id AND h.commit_date = d.last_seen
+		ORDER BY h.commit_date DESC, h.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted issues: %w", err)
+	}
+	defer rows.Close()
+	entries, err := scanHistoryEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+	// Two commits can share a commit_date; keep one entry per issue.
+	seen := make(map[string]bool, len(entries))
+	deduped := entries[:0]
+	for _, e := range entries {
+		if !seen[e.Issue.ID] {
+			seen[e.Issue.ID] = true
+			deduped = append(deduped, e)
+		}
+	}
+	return deduped, nil
+}
+
+// IssueRelationsAsOfInTx returns the labels, comments, and dependencies in
+// both directions that issueID had as of ref.
+//
+//nolint:gosec // G201: ref is validated by ValidateRef — AS OF requires a literal
+func IssueRelationsAsOfInTx(ctx context.Context, tx DBTX, issueID, ref string) (*storage.IssueRelations, error) {
+	if err := ValidateRef(ref); err != nil {
+		return nil, fmt.Errorf("invalid ref: %w", err)
+	}
+	rel := &storage.IssueRelations{}
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+		`SELECT label FROM labels AS OF '%s' WHERE issue_id = ? ORDER BY label`, ref), issueID)
+	if err != nil {
+		return nil, fmt.Errorf("get labels as of %s: %w", ref, err)
+	}
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("get labels as of %s: scan: %w", ref, err)
+		}
+		rel.Labels = append(rel.Labels, label)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get labels as of %s: %w", ref, err)
+	}
+
+	rows, err = tx.QueryContext(ctx, fmt.Sprintf(
+		`SELECT issue_id, %s AS depends_on_id, type, created_at, created_by, metadata, thread_id
+		 FROM dependencies AS OF '%s' WHERE issue_id = ? OR depends_on_issue_id = ? ORDER BY issue_id`,
+		DepTargetExpr, ref), issueID, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("get dependencies as of %s: %w", ref, err)
+	}
+	for rows.Next() {
+		dep, err := scanDependencyRow(rows)
+		if err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("get dependencies as of %s: %w", ref, err)
+		}
+		if dep.IssueID == issueID {
+			rel.Dependencies = append(rel.Dependencies, dep)
+		} else {
+			rel.Dependents = append(rel.Dependents, dep)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get dependencies as of %s: %w", ref, err)
+	}
+
+	rows, err = tx.QueryContext(ctx, fmt.Sprintf(
+		`SELECT id, issue_id, author, text, created_at FROM comments AS OF '%s'
+		 WHERE issue_id = ? ORDER BY created_at ASC, id ASC`, ref), issueID)
+	if err != nil {
+		return nil, fmt.Errorf("get comments as of %s: %w", ref, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c types.Comment
+		if err := rows.Scan(&c.ID, &c.IssueID, &c.Author, &c.Text, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("get comments as of %s: scan: %w", ref, err)
+		}
+		rel.Comments = append(rel.Comments, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get comments as of %s: %w", ref, err)
+	}
+	return rel, nil
+}
 
+// scanHistoryEntries scans rows selecting the history columns of HistoryInTx.
+func scanHistoryEntries(rows *sql.Rows) ([]*storage.HistoryEntry, error) {
 	var entries []*storage.HistoryEntry
 	for rows.Next() {
 		var issue types.Issue
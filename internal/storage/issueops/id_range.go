@@ -0,0 +1,171 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// IDRange is a block of counter-mode issue numbers reserved for one clone or
+// agent (yaml key id.counter-range, e.g. "5000-5999"). Writers that each
+// allocate from their own block never mint the same sequential ID, even when
+// their counters only meet at a Dolt merge.
+type IDRange struct {
+	Start, End int
+}
+
+// ConfiguredIDRange returns the reserved counter range from config, or nil
+// when none is set and the shared per-prefix counter applies.
+func ConfiguredIDRange() (*IDRange, error) {
+	raw := strings.TrimSpace(config.GetString("id.counter-range"))
+	if raw == "" {
+		return nil, nil
+	}
+	return ParseIDRange(raw)
+}
+
+// ParseIDRange parses "start-end" (inclusive, start >= 1).
+func ParseIDRange(raw string) (*IDRange, error) {
+	startStr, endStr, ok := strings.Cut(raw, "-")
+	start, errStart := strconv.Atoi(strings.TrimSpace(startStr))
+	end, errEnd := strconv.Atoi(strings.TrimSpace(endStr))
+	if !ok || errStart != nil || errEnd != nil || start < 1 || end < start {
+		return nil, fmt.Errorf("invalid id.counter-range %q: want start-end with 1 <= start <= end", raw)
+	}
+	return &IDRange{Start: start, End: end}, nil
+}
+
+// counterKey is the issue_counter row that tracks allocation inside r. Each
+// range has its own row, so clones allocating from different ranges update
+// different rows and their counters merge without conflict.
+func (r IDRange) counterKey(prefix string) string {
+	return fmt.Sprintf("%s@%d-%d", prefix, r.Start, r.End)
+}
+
+// NextRangedCounterIDTx allocates the next issue number for prefix inside r.
+// On first use the range's counter seeds from the highest existing numeric
+// ID that already falls inside it. It fails once the range is exhausted
+// rather than spilling into a block another writer owns.
+func NextRangedCounterIDTx(ctx context.Context, tx DBTX, prefix string, r IDRange) (int, error) {
+	key := r.counterKey(prefix)
+	var last int
+	err := tx.QueryRowContext(ctx, "SELECT last_id FROM issue_counter WHERE prefix = ?", key).Scan(&last)
+	if errors.Is(err, sql.ErrNoRows) {
+		seed, err := maxNumericIDInRange(ctx, tx, prefix, r)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO issue_counter (prefix, last_id) VALUES (?, ?)", key, seed); err != nil {
+			return 0, fmt.Errorf("failed to create issue counter for range %s: %w", key, err)
+		}
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to read issue counter for range %s: %w", key, err)
+	}
+
+	// Increment in the engine, as NextCounterIDTx does, so concurrent
+	// transactions cannot both claim the same number.
+	res, err := tx.ExecContext(ctx, "UPDATE issue_counter SET last_id = last_id + 1 WHERE prefix = ? AND last_id < ?", key, r.End)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment issue counter for range %s: %w", key, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("failed to check rows affected for issue counter range %s: %w", key, err)
+	} else if n == 0 {
+		return 0, fmt.Errorf("reserved ID range %d-%d for prefix %q is exhausted; configure a new id.counter-range", r.Start, r.End, prefix)
+	}
+	var next int
+	if err := tx.QueryRowContext(ctx, "SELECT last_id FROM issue_counter WHERE prefix = ?", key).Scan(&next); err != nil {
+		return 0, fmt.Errorf("failed to read issue counter for range %s: %w", key, err)
+	}
+	return next, nil
+}
+
+// reservedIDRangesTx returns the ranges reserved for prefix, as recorded by
+// their issue_counter rows (prefix@start-end). A range appears once a writer
+// has allocated from it and its counter row has reached this database.
+func reservedIDRangesTx(ctx context.Context, tx DBTX, prefix string) ([]IDRange, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT prefix FROM issue_counter WHERE prefix LIKE ? ESCAPE '|'", escapeLike(prefix)+"@%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reserved ID ranges for prefix %q: %w", prefix, err)
+	}
+	defer rows.Close()
+
+	var ranges []IDRange
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan reserved ID range: %w", err)
+		}
+		if r, err := ParseIDRange(strings.TrimPrefix(key, prefix+"@")); err == nil {
+			ranges = append(ranges, *r)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reserved ID ranges for prefix %q: %w", prefix, err)
+	}
+	return ranges, nil
+}
+
+// skipReservedIDRangesTx moves n, just taken from the shared counter for
+// prefix, past every reserved range that contains it, and advances the shared
+// counter to the number returned, so the shared counter never mints an ID
+// inside a block another writer allocates from.
+func skipReservedIDRangesTx(ctx context.Context, tx DBTX, prefix string, n int) (int, error) {
+	ranges, err := reservedIDRangesTx(ctx, tx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	next := n
+	for moved := true; moved; {
+		moved = false
+		for _, r := range ranges {
+			if next >= r.Start && next <= r.End {
+				next = r.End + 1
+				moved = true
+			}
+		}
+	}
+	if next == n {
+		return n, nil
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE issue_counter SET last_id = ? WHERE prefix = ?", next, prefix); err != nil {
+		return 0, fmt.Errorf("failed to move issue counter for prefix %q past reserved ranges: %w", prefix, err)
+	}
+	return next, nil
+}
+
+// escapeLike escapes the LIKE metacharacters in s for use with ESCAPE '|'.
+func escapeLike(s string) string {
+	return strings.NewReplacer("|", "||", "%", "|%", "_", "|_").Replace(s)
+}
+
+// maxNumericIDInRange returns the highest prefix-N issue number with N inside
+// r, or r.Start-1 when there is none.
+func maxNumericIDInRange(ctx context.Context, tx DBTX, prefix string, r IDRange) (int, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM issues WHERE id LIKE ? ESCAPE '|'", escapeLike(prefix)+"-%")
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan existing issues for prefix %q: %w", prefix, err)
+	}
+	defer rows.Close()
+
+	maxNum := r.Start - 1
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("failed to scan issue id: %w", err)
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(id, prefix+"-"))
+		if err == nil && n >= r.Start && n <= r.End && n > maxNum {
+			maxNum = n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to iterate existing issues for prefix %q: %w", prefix, err)
+	}
+	return maxNum, nil
+}
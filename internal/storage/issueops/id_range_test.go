@@ -0,0 +1,85 @@
+package issueops
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestParseIDRange(t *testing.T) {
+	r, err := ParseIDRange(" 5000 - 5999 ")
+	if err != nil || r.Start != 5000 || r.End != 5999 {
+		t.Fatalf("ParseIDRange = %+v, %v; want 5000-5999", r, err)
+	}
+	for _, bad := range []string{"5000", "0-10", "10-5", "a-b", "-5-10"} {
+		if _, err := ParseIDRange(bad); err == nil {
+			t.Errorf("ParseIDRange(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+// TestNextRangedCounterIDSeedsInsideRange pins first use of a reserved range:
+// the counter row is keyed by prefix and range, seeds from the highest
+// existing ID inside the range only, and increments in the engine.
+func TestNextRangedCounterIDSeedsInsideRange(t *testing.T) {
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT last_id FROM issue_counter WHERE prefix = ?")).
+		WithArgs("bd@100-199").WillReturnRows(sqlmock.NewRows([]string{"last_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM issues WHERE id LIKE ? ESCAPE '|'")).
+		WithArgs("bd-%").WillReturnRows(sqlmock.NewRows([]string{"id"}).
+		AddRow("bd-7").AddRow("bd-104").AddRow("bd-104.2").AddRow("bd-250"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO issue_counter (prefix, last_id) VALUES (?, ?)")).
+		WithArgs("bd@100-199", 104).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE issue_counter SET last_id = last_id + 1 WHERE prefix = ? AND last_id < ?")).
+		WithArgs("bd@100-199", 199).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT last_id FROM issue_counter WHERE prefix = ?")).
+		WithArgs("bd@100-199").WillReturnRows(sqlmock.NewRows([]string{"last_id"}).AddRow(105))
+
+	got, err := NextRangedCounterIDTx(context.Background(), tx, "bd", IDRange{Start: 100, End: 199})
+	if err != nil || got != 105 {
+		t.Fatalf("NextRangedCounterIDTx = %d, %v; want 105", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNextRangedCounterIDExhausted(t *testing.T) {
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT last_id FROM issue_counter WHERE prefix = ?")).
+		WithArgs("bd@1-2").WillReturnRows(sqlmock.NewRows([]string{"last_id"}).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE issue_counter SET last_id = last_id + 1 WHERE prefix = ? AND last_id < ?")).
+		WithArgs("bd@1-2", 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err := NextRangedCounterIDTx(context.Background(), tx, "bd", IDRange{Start: 1, End: 2})
+	if err == nil || !strings.Contains(err.Error(), "exhausted") {
+		t.Fatalf("err = %v, want exhausted range", err)
+	}
+}
+
+// TestNextCounterIDSkipsReservedRanges pins the shared counter stepping over
+// blocks reserved through id.counter-range, including adjacent ones, and the
+// LIKE escaping of a prefix with an underscore.
+func TestNextCounterIDSkipsReservedRanges(t *testing.T) {
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE issue_counter SET last_id = last_id + 1 WHERE prefix = ?")).
+		WithArgs("my_app").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT last_id FROM issue_counter WHERE prefix = ?")).
+		WithArgs("my_app").WillReturnRows(sqlmock.NewRows([]string{"last_id"}).AddRow(100))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT prefix FROM issue_counter WHERE prefix LIKE ? ESCAPE '|'")).
+		WithArgs("my|_app@%").WillReturnRows(sqlmock.NewRows([]string{"prefix"}).
+		AddRow("my_app@200-299").AddRow("my_app@100-199").AddRow("my_app@500-599"))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE issue_counter SET last_id = ? WHERE prefix = ?")).
+		WithArgs(300, "my_app").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	got, err := NextCounterIDTx(context.Background(), tx, "my_app")
+	if err != nil || got != "my_app-300" {
+		t.Fatalf("NextCounterIDTx = %q, %v; want my_app-300", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
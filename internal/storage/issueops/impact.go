@@ -0,0 +1,129 @@
+package issueops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// impactEdgeTypes are the dependency types along which closing or
+// deprioritizing an issue reaches other work: the blocking edges plus
+// parent-child, whose children lose their parent.
+const impactEdgeTypes = "'blocks', 'conditional-blocks', 'waits-for', 'parent-child'"
+
+// impactQuery builds the recursive CTE behind GetImpactInTx. Edges are
+// restricted to non-closed dependents, so the walk never passes through
+// finished work. UNION (not UNION ALL) collapses rows reached along several
+// paths at the same depth, and the depth bound ends the walk on cycles.
+//
+//nolint:gosec // G201: table names and DepTargetExpr are hardcoded constants
+func impactQuery(includeWisps bool) string {
+	edges := fmt.Sprintf(`
+		SELECT d.issue_id, %s AS depends_on_id, d.type
+		FROM dependencies d JOIN issues i ON i.id = d.issue_id
+		WHERE d.type IN (%s) AND i.status <> 'closed'`, depTargetExpr("d"), impactEdgeTypes)
+	if includeWisps {
+		edges += fmt.Sprintf(`
+		UNION ALL
+		SELECT d.issue_id, %s AS depends_on_id, d.type
+		FROM wisp_dependencies d JOIN wisps w ON w.id = d.issue_id
+		WHERE d.type IN (%s) AND w.status <> 'closed'`, depTargetExpr("d"), impactEdgeTypes)
+	}
+	return fmt.Sprintf(`
+		WITH RECURSIVE
+		edges(issue_id, depends_on_id, type) AS (%s
+		),
+		impact(id, depth, parent_id, edge_type) AS (
+			SELECT issue_id, 1, depends_on_id, type
+			FROM edges
+			WHERE depends_on_id = ?
+			UNION
+			SELECT e.issue_id, i.depth + 1, e.depends_on_id, e.type
+			FROM edges e
+			JOIN impact i ON e.depends_on_id = i.id
+			WHERE i.depth < ?
+		)
+		SELECT id, depth, parent_id, edge_type FROM impact
+		WHERE id <> ?
+		ORDER BY depth, parent_id, id
+	`, edges)
+}
+
+// GetImpactInTx returns the open work that transitively depends on rootID
+// through blocking or parent-child edges, as a tree: the root at depth 0,
+// then each dependent once, at the shallowest depth it is reached, with
+// ParentID and EdgeFromParent naming the edge it was first reached by.
+// Traversal stops after maxDepth levels.
+func GetImpactInTx(ctx context.Context, tx DBTX, rootID string, maxDepth int) ([]*types.TreeNode, error) {
+	root, err := GetIssueInTx(ctx, tx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	type impactRow struct {
+		id, parentID string
+		depth        int
+		edge         types.DependencyType
+	}
+	queryImpact := func(includeWisps bool) ([]impactRow, error) {
+		rows, err := tx.QueryContext(ctx, impactQuery(includeWisps), rootID, maxDepth, rootID)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = rows.Close() }()
+		var result []impactRow
+		for rows.Next() {
+			var r impactRow
+			if err := rows.Scan(&r.id, &r.depth, &r.parentID, &r.edge); err != nil {
+				return nil, fmt.Errorf("scan impact row: %w", err)
+			}
+			result = append(result, r)
+		}
+		return result, rows.Err()
+	}
+	impactRows, err := queryImpact(true)
+	if err != nil {
+		if !isTableNotExistError(err) {
+			return nil, fmt.Errorf("impact of %s: %w", rootID, err)
+		}
+		if impactRows, err = queryImpact(false); err != nil {
+			return nil, fmt.Errorf("impact of %s: %w", rootID, err)
+		}
+	}
+
+	// Rows arrive shallowest first; keep the first row per issue.
+	first := make(map[string]impactRow, len(impactRows))
+	var ids []string
+	for _, r := range impactRows {
+		if _, seen := first[r.id]; seen {
+			continue
+		}
+		first[r.id] = r
+		ids = append(ids, r.id)
+	}
+	issues, err := GetIssuesByIDsInTx(ctx, tx, ids, nil)
+	if err != nil {
+		return nil, fmt.Errorf("impact of %s: %w", rootID, err)
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	nodes := []*types.TreeNode{{Issue: *root}}
+	for _, id := range ids {
+		issue, ok := byID[id]
+		if !ok {
+			continue // dangling edge to a deleted issue
+		}
+		r := first[id]
+		nodes = append(nodes, &types.TreeNode{
+			Issue:          *issue,
+			Depth:          r.depth,
+			ParentID:       r.parentID,
+			EdgeFromParent: r.edge,
+		})
+	}
+	return nodes, nil
+}
@@ -0,0 +1,116 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetIssueContextInTx reads the full relational picture of one issue in a
+// single transaction: labels, dependencies and dependents with their
+// statuses, parent, comments, attachments, linked commits, aliases,
+// co-assignees and team, recurrence, the eventLimit most recent events (all
+// when eventLimit <= 0), and the computed blocked_by_count and days_open.
+// Count fields are set from the loaded slices. Returns storage.ErrNotFound
+// when the issue does not exist.
+func GetIssueContextInTx(ctx context.Context, tx *sql.Tx, id string, eventLimit int, now time.Time) (*types.IssueDetails, error) {
+	issue, err := GetIssueInTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	details := &types.IssueDetails{Issue: *issue, Labels: issue.Labels}
+
+	if details.Dependencies, err = GetDependenciesWithMetadataInTx(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	if details.Dependents, err = GetDependentsWithMetadataInTx(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	if details.Comments, err = GetIssueCommentsInTx(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	if details.Events, err = GetEventsInTx(ctx, tx, id, eventLimit); err != nil {
+		return nil, err
+	}
+
+	ids := []string{id}
+	attachments, err := GetAttachmentsForIssuesInTx(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	details.Attachments = attachments[id]
+	commits, err := GetCommitLinksForIssuesInTx(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	details.Commits = commits[id]
+	aliases, err := GetIssueAliasesInTx(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range aliases[id] {
+		details.Aliases = append(details.Aliases, a.Alias)
+	}
+	if !issue.Ephemeral {
+		assignments, err := GetIssueAssignmentsInTx(ctx, tx, ids)
+		if err != nil {
+			return nil, err
+		}
+		if a := assignments[id]; a != nil {
+			details.Assignees = a.Assignees
+			details.Team = a.Team
+		}
+	}
+	if details.Recurrence, err = GetIssueRecurrenceInTx(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	for _, dep := range details.Dependencies {
+		if dep.DependencyType == types.DepParentChild {
+			parent := dep.ID
+			details.Parent = &parent
+			break
+		}
+	}
+	dependencyCount := int64(len(details.Dependencies))
+	dependentCount := int64(len(details.Dependents))
+	commentCount := int64(len(details.Comments))
+	details.DependencyCount = &dependencyCount
+	details.DependentCount = &dependentCount
+	details.CommentCount = &commentCount
+
+	_, blockers, err := IsBlockedInTx(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get blockers of %s: %w", id, err)
+	}
+	blockedBy := len(blockers)
+	details.BlockedByCount = &blockedBy
+	end := now
+	if issue.ClosedAt != nil {
+		end = *issue.ClosedAt
+	}
+	daysOpen := max(0, int(end.Sub(issue.CreatedAt).Hours()/24))
+	details.DaysOpen = &daysOpen
+
+	if issue.IssueType == types.TypeEpic {
+		total, closed := 0, 0
+		for _, dep := range details.Dependents {
+			if dep.DependencyType == types.DepParentChild {
+				total++
+				if dep.Status == types.StatusClosed {
+					closed++
+				}
+			}
+		}
+		if total > 0 {
+			closeable := total == closed
+			details.EpicTotalChildren = &total
+			details.EpicClosedChildren = &closed
+			details.EpicCloseable = &closeable
+		}
+	}
+	return details, nil
+}
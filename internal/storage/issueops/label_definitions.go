@@ -0,0 +1,194 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// labelColorPattern matches a #rgb or #rrggbb hex color, with or without the
+// leading '#'.
+var labelColorPattern = regexp.MustCompile(`^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// NormalizeLabelColor validates a label color and returns it lower-cased with
+// a leading '#'. The empty string means "no color" and is returned unchanged.
+func NormalizeLabelColor(color string) (string, error) {
+	color = strings.TrimSpace(color)
+	if color == "" {
+		return "", nil
+	}
+	if !labelColorPattern.MatchString(color) {
+		return "", fmt.Errorf("invalid label color %q (want #rgb or #rrggbb)", color)
+	}
+	return "#" + strings.ToLower(strings.TrimPrefix(color, "#")), nil
+}
+
+// SetLabelDefinitionInTx creates or replaces the definition of def.Name.
+// created_at is preserved on replace; updated_at is always bumped.
+func SetLabelDefinitionInTx(ctx context.Context, tx DBTX, def *types.LabelDefinition) error {
+	name := strings.TrimSpace(def.Name)
+	if name == "" {
+		return fmt.Errorf("label name is required")
+	}
+	if err := types.CheckFieldLen("label", name); err != nil {
+		return err
+	}
+	color, err := NormalizeLabelColor(def.Color)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	createdAt, updatedAt := def.CreatedAt.UTC(), def.UpdatedAt.UTC()
+	if def.CreatedAt.IsZero() {
+		createdAt = now
+	}
+	if def.UpdatedAt.IsZero() {
+		updatedAt = now
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO label_definitions (name, color, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE color = VALUES(color), description = VALUES(description), updated_at = VALUES(updated_at)
+	`, name, color, def.Description, createdAt, updatedAt); err != nil {
+		return fmt.Errorf("set label definition %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetLabelDefinitionsInTx returns every label definition ordered by name.
+func GetLabelDefinitionsInTx(ctx context.Context, tx DBTX) ([]*types.LabelDefinition, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT name, color, description, created_at, updated_at FROM label_definitions ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("get label definitions: %w", err)
+	}
+	defer rows.Close()
+
+	var defs []*types.LabelDefinition
+	for rows.Next() {
+		var d types.LabelDefinition
+		var createdAt, updatedAt sql.NullTime
+		if err := rows.Scan(&d.Name, &d.Color, &d.Description, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("get label definitions: scan: %w", err)
+		}
+		d.CreatedAt, d.UpdatedAt = createdAt.Time, updatedAt.Time
+		defs = append(defs, &d)
+	}
+	return defs, rows.Err()
+}
+
+// RenameLabelInTx renames oldName to newName on every issue and wisp that
+// carries it, recording the usual label removed/added events on each, and
+// moves the definition along. If newName is already defined, oldName's
+// definition is dropped and newName's kept, so renaming onto an existing label
+// merges the two. Returns the number of issues and wisps relabeled.
+func RenameLabelInTx(ctx context.Context, tx DBTX, oldName, newName, actor string) (int, error) {
+	newName = strings.TrimSpace(newName)
+	if oldName == "" || newName == "" {
+		return 0, fmt.Errorf("rename label: old and new names are required")
+	}
+	if oldName == newName {
+		return 0, fmt.Errorf("rename label: %q is already named that", oldName)
+	}
+	if err := types.CheckFieldLen("label", newName); err != nil {
+		return 0, err
+	}
+
+	n, err := relabelInTx(ctx, tx, oldName, func(labelTable, eventTable, id string) error {
+		if err := RemoveLabelInTx(ctx, tx, labelTable, eventTable, id, oldName, actor); err != nil {
+			return err
+		}
+		return AddLabelInTx(ctx, tx, labelTable, eventTable, id, newName, actor)
+	})
+	if err != nil {
+		return n, fmt.Errorf("rename label %s: %w", oldName, err)
+	}
+
+	var defined int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM label_definitions WHERE name = ?`, newName).Scan(&defined); err != nil {
+		return n, fmt.Errorf("rename label %s: %w", oldName, err)
+	}
+	query := `UPDATE label_definitions SET name = ?, updated_at = ? WHERE name = ?`
+	args := []any{newName, time.Now().UTC(), oldName}
+	if defined > 0 {
+		query, args = `DELETE FROM label_definitions WHERE name = ?`, []any{oldName}
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return n, fmt.Errorf("rename label definition %s: %w", oldName, err)
+	}
+	if moved, _ := res.RowsAffected(); n == 0 && moved == 0 && defined == 0 {
+		return 0, fmt.Errorf("%w: label %q", storage.ErrNotFound, oldName)
+	}
+	return n, nil
+}
+
+// DeleteLabelInTx removes name from every issue and wisp that carries it,
+// recording a label removed event on each, and drops its definition. Returns
+// the number of issues and wisps touched.
+func DeleteLabelInTx(ctx context.Context, tx DBTX, name, actor string) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("delete label: name is required")
+	}
+	n, err := relabelInTx(ctx, tx, name, func(labelTable, eventTable, id string) error {
+		return RemoveLabelInTx(ctx, tx, labelTable, eventTable, id, name, actor)
+	})
+	if err != nil {
+		return n, fmt.Errorf("delete label %s: %w", name, err)
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM label_definitions WHERE name = ?`, name)
+	if err != nil {
+		return n, fmt.Errorf("delete label definition %s: %w", name, err)
+	}
+	if dropped, _ := res.RowsAffected(); n == 0 && dropped == 0 {
+		return 0, fmt.Errorf("%w: label %q", storage.ErrNotFound, name)
+	}
+	return n, nil
+}
+
+// relabelInTx calls fn for every issue in labels and every wisp in
+// wisp_labels that carries label, with the matching label and event tables.
+// The IDs are collected before fn runs so fn may rewrite the rows.
+func relabelInTx(ctx context.Context, tx DBTX, label string, fn func(labelTable, eventTable, id string) error) (int, error) {
+	total := 0
+	for _, isWisp := range []bool{false, true} {
+		_, labelTable, eventTable, _ := WispTableRouting(isWisp)
+		ids, err := issueIDsWithLabelInTx(ctx, tx, labelTable, label)
+		if err != nil {
+			return total, err
+		}
+		for _, id := range ids {
+			if err := fn(labelTable, eventTable, id); err != nil {
+				return total, fmt.Errorf("%s: %w", id, err)
+			}
+			total++
+		}
+	}
+	return total, nil
+}
+
+//nolint:gosec // G201: labelTable is from WispTableRouting ("labels" or "wisp_labels")
+func issueIDsWithLabelInTx(ctx context.Context, tx DBTX, labelTable, label string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT issue_id FROM %s WHERE label = ? ORDER BY issue_id`, labelTable), label)
+	if err != nil {
+		if labelTable == "wisp_labels" && isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find issues labeled %s: %w", label, err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("find issues labeled %s: scan: %w", label, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
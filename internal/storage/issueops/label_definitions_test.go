@@ -0,0 +1,28 @@
+package issueops
+
+import "testing"
+
+func TestNormalizeLabelColor(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "", want: ""},
+		{in: "#1D76DB", want: "#1d76db"},
+		{in: "1d76db", want: "#1d76db"},
+		{in: " #abc ", want: "#abc"},
+		{in: "blue", wantErr: true},
+		{in: "#12345", wantErr: true},
+		{in: "##123456", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeLabelColor(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeLabelColor(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeLabelColor(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
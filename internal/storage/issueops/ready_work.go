@@ -397,6 +397,9 @@ func filterReadyWispsInTx(ctx context.Context, tx DBTX, filter types.WorkFilter,
 			excluded[id] = struct{}{}
 		}
 	}
+	for _, id := range filter.ExcludeIDs {
+		excluded[id] = struct{}{}
+	}
 
 	for start := 0; start < len(wispIDs); start += queryBatchSize {
 		end := start + queryBatchSize
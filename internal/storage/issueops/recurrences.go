@@ -0,0 +1,103 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// recurrenceEveryRe matches the intervals bd recur accepts: a positive count
+// of hours, days, weeks, months or years.
+var recurrenceEveryRe = regexp.MustCompile(`^[1-9][0-9]*[hdwmy]$`)
+
+// SetIssueRecurrenceInTx creates or replaces the recurrence rule of
+// r.IssueID. Wisps cannot recur: they are clone-local, while
+// issue_recurrences is synced.
+func SetIssueRecurrenceInTx(ctx context.Context, tx DBTX, r *types.Recurrence) error {
+	if !recurrenceEveryRe.MatchString(r.Every) {
+		return fmt.Errorf("invalid interval %q (use a count and h, d, w, m or y, e.g. 2w)", r.Every)
+	}
+	if r.NextAt.IsZero() {
+		return fmt.Errorf("recurrence of %s has no next occurrence time", r.IssueID)
+	}
+	if IsActiveWispInTx(ctx, tx, r.IssueID) {
+		return fmt.Errorf("cannot schedule ephemeral issue %s to recur", r.IssueID)
+	}
+	var exists int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, r.IssueID).Scan(&exists); err != nil {
+		return fmt.Errorf("check issue %s: %w", r.IssueID, err)
+	}
+	if exists == 0 {
+		return fmt.Errorf("%w: issue %s", storage.ErrNotFound, r.IssueID)
+	}
+
+	args := []any{r.IssueID, r.Every, r.NextAt.UTC(), r.LastInstanceID, r.CreatedBy}
+	query := `INSERT INTO issue_recurrences (issue_id, every, next_at, last_instance_id, created_by) VALUES (?, ?, ?, ?, ?)`
+	if !r.CreatedAt.IsZero() {
+		query = `INSERT INTO issue_recurrences (issue_id, every, next_at, last_instance_id, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?)`
+		args = append(args, r.CreatedAt.UTC())
+	}
+	query += `
+		ON DUPLICATE KEY UPDATE every = VALUES(every), next_at = VALUES(next_at), last_instance_id = VALUES(last_instance_id)`
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("set recurrence of %s: %w", r.IssueID, err)
+	}
+	return nil
+}
+
+// RemoveIssueRecurrenceInTx deletes the recurrence rule of issueID.
+func RemoveIssueRecurrenceInTx(ctx context.Context, tx DBTX, issueID string) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM issue_recurrences WHERE issue_id = ?`, issueID)
+	if err != nil {
+		return fmt.Errorf("remove recurrence of %s: %w", issueID, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: recurrence of %s", storage.ErrNotFound, issueID)
+	}
+	return nil
+}
+
+// GetIssueRecurrencesInTx returns every recurrence rule, soonest due first.
+func GetIssueRecurrencesInTx(ctx context.Context, tx DBTX) ([]*types.Recurrence, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT issue_id, every, next_at, last_instance_id, created_at, created_by
+		FROM issue_recurrences
+		ORDER BY next_at, issue_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("get recurrences: %w", err)
+	}
+	defer rows.Close()
+	var result []*types.Recurrence
+	for rows.Next() {
+		var r types.Recurrence
+		if err := rows.Scan(&r.IssueID, &r.Every, &r.NextAt, &r.LastInstanceID, &r.CreatedAt, &r.CreatedBy); err != nil {
+			return nil, fmt.Errorf("scan recurrence: %w", err)
+		}
+		result = append(result, &r)
+	}
+	return result, rows.Err()
+}
+
+// GetIssueRecurrenceInTx returns the recurrence rule of issueID, or nil when
+// it has none.
+func GetIssueRecurrenceInTx(ctx context.Context, tx DBTX, issueID string) (*types.Recurrence, error) {
+	var r types.Recurrence
+	err := tx.QueryRowContext(ctx, `
+		SELECT issue_id, every, next_at, last_instance_id, created_at, created_by
+		FROM issue_recurrences
+		WHERE issue_id = ?
+	`, issueID).Scan(&r.IssueID, &r.Every, &r.NextAt, &r.LastInstanceID, &r.CreatedAt, &r.CreatedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get recurrence of %s: %w", issueID, err)
+	}
+	return &r, nil
+}
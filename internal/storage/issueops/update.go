@@ -211,6 +211,29 @@ func updateIssueInTx(ctx context.Context, tx DBTX, id string, updates map[string
 		return nil, err
 	}
 
+	// Enforce the configured status workflow (status.transitions) against the
+	// row read in this transaction, so guards see the same blockers and
+	// children the update commits against. An assignee set by this same
+	// update counts for the assigned guard.
+	if rawStatus, ok := updates["status"]; ok {
+		var to types.Status
+		switch v := rawStatus.(type) {
+		case string:
+			to = types.Status(v)
+		case types.Status:
+			to = v
+		}
+		if to != "" {
+			subject := *oldIssue
+			if assignee, ok := updates["assignee"].(string); ok {
+				subject.Assignee = assignee
+			}
+			if err := CheckStatusTransitionInTx(ctx, tx, &subject, to); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Validate issue_type against built-in + custom types (GH#3030).
 	// This mirrors the create path (PrepareIssueForInsert → ValidateWithCustom)
 	// and reads custom types from the same transaction, so it works reliably
@@ -355,6 +378,12 @@ func updateIssueInTx(ctx context.Context, tx DBTX, id string, updates map[string
 				return nil, fmt.Errorf("recompute is_blocked after status change for %s: %w", id, err)
 			}
 		}
+		if newStatus == string(types.StatusClosed) && oldIssue.Status != types.StatusClosed {
+			session, _ := updates["closed_by_session"].(string)
+			if err := closeOpenDuplicatesInTx(ctx, tx, id, actor, session, recordEvent); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return &UpdateResult{OldIssue: oldIssue, IsWisp: isWisp}, nil
@@ -466,7 +495,7 @@ func resolveMetadataMergeOps(oldIssue *types.Issue, updates, resolved map[string
 		if err != nil {
 			return err
 		}
-		merged, err := storage.ApplyMetadataEdits(current, set, unset)
+		merged, err := storage.ApplyTypedMetadataEdits(current, set, unset, ConfiguredMetadataFields())
 		if err != nil {
 			return fmt.Errorf("metadata edit failed: %w", err)
 		}
@@ -0,0 +1,134 @@
+package issueops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// StatusTransitionsConfigKey is the config key holding the workflow rules
+// parsed by types.ParseStatusTransitions.
+const StatusTransitionsConfigKey = "status.transitions"
+
+// CheckStatusTransitionInTx enforces the configured workflow on a status
+// change of issue to the given status. With no status.transitions rules every
+// change is allowed. Otherwise the change must match a rule whose guard holds;
+// a refusal wraps storage.ErrTransitionNotAllowed and names either the allowed
+// targets or the failed guards.
+func CheckStatusTransitionInTx(ctx context.Context, tx DBTX, issue *types.Issue, to types.Status) error {
+	if issue.Status == to {
+		return nil
+	}
+	value, err := GetConfigInTx(ctx, tx, StatusTransitionsConfigKey)
+	if err != nil {
+		return err
+	}
+	rules, err := types.ParseStatusTransitions(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s config: %w", StatusTransitionsConfigKey, err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	matched := false
+	var failures []string
+	for _, rule := range rules {
+		if !rule.Matches(issue.Status, to) {
+			continue
+		}
+		matched = true
+		failure, err := transitionGuardFailureInTx(ctx, tx, issue, rule.Guard)
+		if err != nil {
+			return err
+		}
+		if failure == "" {
+			return nil
+		}
+		failures = append(failures, failure)
+	}
+	if !matched {
+		return fmt.Errorf("%w: %s cannot move from %s to %s (allowed from %s: %s)",
+			storage.ErrTransitionNotAllowed, issue.ID, issue.Status, to, issue.Status, allowedTransitionTargets(rules, issue.Status))
+	}
+	return fmt.Errorf("%w: %s cannot move from %s to %s: %s",
+		storage.ErrTransitionNotAllowed, issue.ID, issue.Status, to, strings.Join(failures, "; "))
+}
+
+// transitionGuardFailureInTx returns why guard does not hold for issue, or ""
+// when it does (an empty guard always holds).
+func transitionGuardFailureInTx(ctx context.Context, tx DBTX, issue *types.Issue, guard string) (string, error) {
+	switch guard {
+	case "":
+		return "", nil
+	case types.TransitionGuardAssigned:
+		if issue.Assignee == "" {
+			return "it has no assignee", nil
+		}
+	case types.TransitionGuardNoBlockers:
+		blocked, blockers, err := IsBlockedInTx(ctx, tx, issue.ID)
+		if err != nil {
+			return "", err
+		}
+		if blocked && len(blockers) > 0 {
+			return fmt.Sprintf("it is blocked by %s", strings.Join(blockers, ", ")), nil
+		}
+	case types.TransitionGuardNoOpenChildren:
+		n, err := countOpenChildrenInTx(ctx, tx, issue.ID)
+		if err != nil {
+			return "", err
+		}
+		if n > 0 {
+			return fmt.Sprintf("it has %d open child issue(s)", n), nil
+		}
+	default:
+		return fmt.Sprintf("unknown guard %q", guard), nil
+	}
+	return "", nil
+}
+
+// allowedTransitionTargets lists the statuses the rules allow leaving from to.
+func allowedTransitionTargets(rules []types.StatusTransition, from types.Status) string {
+	var targets []string
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.From != types.StatusTransitionAny && rule.From != string(from) {
+			continue
+		}
+		target := rule.To
+		if target == types.StatusTransitionAny {
+			target = "any status"
+		}
+		if rule.Guard != "" {
+			target += " (" + rule.Guard + ")"
+		}
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 {
+		return "none"
+	}
+	return strings.Join(targets, ", ")
+}
+
+// countOpenChildrenInTx counts the parent-child children of id that are not
+// closed.
+//
+//nolint:gosec // G201: depTargetExpr renders a fixed column expression.
+func countOpenChildrenInTx(ctx context.Context, tx DBTX, id string) (int, error) {
+	query := fmt.Sprintf(`
+		SELECT COUNT(*) FROM dependencies d
+		JOIN issues i ON i.id = d.issue_id
+		WHERE d.type = 'parent-child' AND %s = ? AND i.status <> ?
+	`, depTargetExpr("d"))
+	var n int
+	if err := tx.QueryRowContext(ctx, query, id, types.StatusClosed).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count open children of %s: %w", id, err)
+	}
+	return n, nil
+}
@@ -2,10 +2,14 @@
 package storage
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // NormalizeMetadataValue converts metadata values to a validated JSON string.
@@ -44,6 +48,7 @@ const (
 	MetadataFieldFloat  MetadataFieldType = "float"
 	MetadataFieldBool   MetadataFieldType = "bool"
 	MetadataFieldEnum   MetadataFieldType = "enum"
+	MetadataFieldDate   MetadataFieldType = "date"
 )
 
 // MetadataFieldSchema defines validation rules for a single metadata field.
@@ -177,6 +182,20 @@ func ValidateMetadataSchema(metadata json.RawMessage, schema MetadataSchemaConfi
 				})
 			}
 
+		case MetadataFieldDate:
+			str, ok := val.(string)
+			if !ok {
+				errs = append(errs, MetadataValidationError{
+					Field:   fieldName,
+					Message: fmt.Sprintf("expected date string, got %T", val),
+				})
+			} else if _, ok := ParseMetadataDate(str); !ok {
+				errs = append(errs, MetadataValidationError{
+					Field:   fieldName,
+					Message: fmt.Sprintf("value %q is not a date (use YYYY-MM-DD or RFC3339)", str),
+				})
+			}
+
 		case MetadataFieldEnum:
 			str, ok := val.(string)
 			if !ok {
@@ -264,6 +283,13 @@ func MergeMetadataJSON(existing, incoming json.RawMessage) (json.RawMessage, err
 // to existing metadata and returns the merged JSON. Set values are typed via
 // MetadataEditValue; keys are validated with ValidateMetadataKey.
 func ApplyMetadataEdits(existing json.RawMessage, setFlags, unsetFlags []string) (json.RawMessage, error) {
+	return ApplyTypedMetadataEdits(existing, setFlags, unsetFlags, nil)
+}
+
+// ApplyTypedMetadataEdits is ApplyMetadataEdits for a configured schema: set
+// values of keys declared as int, float or bool are stored as JSON numbers or
+// booleans (see TypedMetadataEditValue). Undeclared keys stay strings.
+func ApplyTypedMetadataEdits(existing json.RawMessage, setFlags, unsetFlags []string, fields map[string]MetadataFieldSchema) (json.RawMessage, error) {
 	data := make(map[string]json.RawMessage)
 	if len(existing) > 0 {
 		trimmed := strings.TrimSpace(string(existing))
@@ -282,7 +308,11 @@ func ApplyMetadataEdits(existing json.RawMessage, setFlags, unsetFlags []string)
 		if err := ValidateMetadataKey(k); err != nil {
 			return nil, err
 		}
-		data[k] = MetadataEditValue(v)
+		if schema, ok := fields[k]; ok {
+			data[k] = TypedMetadataEditValue(v, schema)
+		} else {
+			data[k] = MetadataEditValue(v)
+		}
 	}
 
 	for _, k := range unsetFlags {
@@ -309,3 +339,76 @@ func MetadataEditValue(s string) json.RawMessage {
 	b, _ := json.Marshal(s)
 	return json.RawMessage(b)
 }
+
+// TypedMetadataEditValue converts a --set-metadata value for a key the schema
+// declares. int, float and bool fields are stored as their JSON type so the
+// value passes ValidateMetadataSchema and compares numerically; a value that
+// does not parse stays a string and is reported by validation instead.
+func TypedMetadataEditValue(s string, schema MetadataFieldSchema) json.RawMessage {
+	switch schema.Type {
+	case MetadataFieldInt:
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return json.RawMessage(strconv.FormatInt(n, 10))
+		}
+	case MetadataFieldFloat:
+		if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return json.RawMessage(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+	case MetadataFieldBool:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return json.RawMessage(strconv.FormatBool(b))
+		}
+	}
+	return MetadataEditValue(s)
+}
+
+// ParseMetadataDate parses a date field value: a calendar date (YYYY-MM-DD)
+// or an RFC3339 timestamp.
+func ParseMetadataDate(s string) (time.Time, bool) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// LookupMetadataValue returns the top-level value at key as display text:
+// strings unquoted, numbers and booleans in their JSON form. ok is false when
+// metadata is not an object, the key is missing, or its value is null.
+func LookupMetadataValue(metadata json.RawMessage, key string) (string, bool) {
+	if len(metadata) == 0 {
+		return "", false
+	}
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &data); err != nil {
+		return "", false
+	}
+	raw, ok := data[key]
+	if !ok || string(raw) == "null" {
+		return "", false
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, true
+	}
+	return string(raw), true
+}
+
+// CompareMetadataValues orders two metadata values as returned by
+// LookupMetadataValue: numerically when both are numbers, chronologically
+// when both are dates, and as strings otherwise.
+func CompareMetadataValues(a, b string) int {
+	af, aErr := strconv.ParseFloat(a, 64)
+	bf, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		return cmp.Compare(af, bf)
+	}
+	at, aOK := ParseMetadataDate(a)
+	bt, bOK := ParseMetadataDate(b)
+	if aOK && bOK {
+		return at.Compare(bt)
+	}
+	return strings.Compare(a, b)
+}
@@ -150,6 +150,26 @@ func TestValidateMetadataSchema_BoolValidation(t *testing.T) {
 	}
 }
 
+func TestValidateMetadataSchema_DateValidation(t *testing.T) {
+	schema := MetadataSchemaConfig{
+		Mode: "error",
+		Fields: map[string]MetadataFieldSchema{
+			"review": {Type: MetadataFieldDate},
+		},
+	}
+
+	for _, valid := range []string{`{"review":"2025-03-01"}`, `{"review":"2025-03-01T09:00:00Z"}`} {
+		if errs := ValidateMetadataSchema(json.RawMessage(valid), schema); len(errs) != 0 {
+			t.Errorf("%s: expected no errors, got %v", valid, errs)
+		}
+	}
+	for _, invalid := range []string{`{"review":"next week"}`, `{"review":20250301}`} {
+		if errs := ValidateMetadataSchema(json.RawMessage(invalid), schema); len(errs) != 1 {
+			t.Errorf("%s: expected 1 error, got %v", invalid, errs)
+		}
+	}
+}
+
 func TestValidateMetadataSchema_FloatValidation(t *testing.T) {
 	min := float64(0.0)
 	max := float64(1.0)
@@ -259,3 +279,67 @@ func TestMetadataValidationError_Error(t *testing.T) {
 		t.Errorf("got %q, want %q", e.Error(), want)
 	}
 }
+
+func TestApplyTypedMetadataEdits(t *testing.T) {
+	fields := map[string]MetadataFieldSchema{
+		"points":  {Type: MetadataFieldInt},
+		"ratio":   {Type: MetadataFieldFloat},
+		"urgent":  {Type: MetadataFieldBool},
+		"release": {Type: MetadataFieldString},
+	}
+	got, err := ApplyTypedMetadataEdits(nil,
+		[]string{"points=5", "ratio=0.5", "urgent=true", "release=1.0", "build=42", "points_note=x"},
+		nil, fields)
+	if err != nil {
+		t.Fatalf("ApplyTypedMetadataEdits: %v", err)
+	}
+	want := `{"build":"42","points":5,"points_note":"x","ratio":0.5,"release":"1.0","urgent":true}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// A value that does not parse stays a string for validation to reject.
+	got, err = ApplyTypedMetadataEdits(nil, []string{"points=many"}, nil, fields)
+	if err != nil {
+		t.Fatalf("ApplyTypedMetadataEdits: %v", err)
+	}
+	if string(got) != `{"points":"many"}` {
+		t.Errorf("got %s", got)
+	}
+	schema := MetadataSchemaConfig{Mode: "error", Fields: fields}
+	if errs := ValidateMetadataSchema(got, schema); len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+}
+
+func TestCompareMetadataValues(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"9", "10", -1},
+		{"2.5", "2.5", 0},
+		{"2025-03-01", "2025-02-28T23:00:00Z", 1},
+		{"beta", "alpha", 1},
+		{"10", "abc", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareMetadataValues(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareMetadataValues(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLookupMetadataValue(t *testing.T) {
+	meta := json.RawMessage(`{"team":"core","points":5,"done":false,"gone":null}`)
+	for key, want := range map[string]string{"team": "core", "points": "5", "done": "false"} {
+		if got, ok := LookupMetadataValue(meta, key); !ok || got != want {
+			t.Errorf("LookupMetadataValue(%q) = %q, %v; want %q", key, got, ok, want)
+		}
+	}
+	for _, key := range []string{"gone", "missing"} {
+		if _, ok := LookupMetadataValue(meta, key); ok {
+			t.Errorf("LookupMetadataValue(%q) should report missing", key)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+-- File attachments on issues (bd attach). The blob itself lives
+-- content-addressed under .beads/attachments/; this table records which issue
+-- references which blob under which name.
+--
+-- The primary key is the natural (issue_id, sha256, name) triple rather than a
+-- generated id, so clones that attach the same file converge on one row when
+-- they merge instead of duplicating it (the per-clone-random key hazard that
+-- 0037/0051 had to repair on the other aux tables).
+--
+-- Wisps carry no attachments, so there is no dolt-ignored wisp twin.
+CREATE TABLE IF NOT EXISTS attachments (
+    issue_id VARCHAR(255) NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    media_type VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, sha256, name),
+    INDEX idx_attachments_sha256 (sha256),
+    CONSTRAINT fk_attachments_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,16 @@
+-- Label metadata (bd label create). The labels and wisp_labels tables keep
+-- recording which issue carries which label as a bare string; this table adds
+-- an optional color and description per label name. A label may be used on
+-- issues without being defined here, and a definition may exist before any
+-- issue uses it.
+--
+-- Keyed by the label name itself so clones that define the same label merge
+-- onto one row. There is no foreign key to labels: renames and deletes are
+-- propagated explicitly by bd label rename/delete.
+CREATE TABLE IF NOT EXISTS label_definitions (
+    name VARCHAR(255) NOT NULL PRIMARY KEY,
+    color VARCHAR(32) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,20 @@
+-- Git commits whose messages mention an issue (bd backfill-commits and the
+-- post-commit hook). closes is set when the mention used a closing keyword
+-- such as "fixes bd-12".
+--
+-- Keyed by the natural (issue_id, commit_sha) pair so clones that scan the
+-- same history converge on one row when they merge.
+--
+-- Wisps are not linked, so there is no dolt-ignored wisp twin.
+CREATE TABLE IF NOT EXISTS commit_links (
+    issue_id VARCHAR(255) NOT NULL,
+    commit_sha VARCHAR(64) NOT NULL,
+    subject VARCHAR(1024) NOT NULL DEFAULT '',
+    author VARCHAR(255) NOT NULL DEFAULT '',
+    committed_at DATETIME NULL,
+    closes TINYINT(1) NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, commit_sha),
+    INDEX idx_commit_links_sha (commit_sha),
+    CONSTRAINT fk_commit_links_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,17 @@
+-- Alternate IDs of an issue: the IDs it had before bd rename or
+-- bd rename-prefix (recorded automatically), and keys in other trackers
+-- added with bd alias add. Every ID lookup falls back to this table.
+--
+-- An alias names exactly one issue, so it is the primary key. The foreign
+-- key cascades renames, keeping older aliases pointing at the current ID.
+--
+-- Wisps are not aliased, so there is no dolt-ignored wisp twin.
+CREATE TABLE IF NOT EXISTS issue_aliases (
+    alias VARCHAR(255) NOT NULL,
+    issue_id VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (alias),
+    INDEX idx_issue_aliases_issue (issue_id),
+    CONSTRAINT fk_issue_aliases_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS issue_teams;
+DROP TABLE IF EXISTS issue_assignees;
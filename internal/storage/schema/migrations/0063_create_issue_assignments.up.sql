@@ -0,0 +1,22 @@
+-- Structured assignment beyond the single assignee column: co-assignees
+-- working an issue alongside its assignee, and the team that owns it. Both
+-- are added with bd assign and counted by bd workload.
+--
+-- The foreign keys cascade deletes and renames. Wisps are not assigned this
+-- way, so there are no dolt-ignored wisp twins.
+CREATE TABLE IF NOT EXISTS issue_assignees (
+    issue_id VARCHAR(255) NOT NULL,
+    assignee VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, assignee),
+    INDEX idx_issue_assignees_assignee (assignee),
+    CONSTRAINT fk_issue_assignees_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS issue_teams (
+    issue_id VARCHAR(255) NOT NULL,
+    team VARCHAR(255) NOT NULL,
+    PRIMARY KEY (issue_id),
+    INDEX idx_issue_teams_team (team),
+    CONSTRAINT fk_issue_teams_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,18 @@
+-- Recurrence rules added with bd recur: the issue (often a template) that
+-- a series repeats, the interval, when the next occurrence is due, and the
+-- occurrence bd tick created last. bd tick materializes due occurrences.
+--
+-- last_instance_id has no foreign key: deleting an occurrence must not
+-- take the rule with it. Wisps do not recur, so there is no dolt-ignored
+-- wisp twin.
+CREATE TABLE IF NOT EXISTS issue_recurrences (
+    issue_id VARCHAR(255) NOT NULL,
+    every VARCHAR(32) NOT NULL,
+    next_at DATETIME NOT NULL,
+    last_instance_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (issue_id),
+    INDEX idx_issue_recurrences_next_at (next_at),
+    CONSTRAINT fk_issue_recurrences_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
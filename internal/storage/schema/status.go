@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MigrationStatus describes one embedded migration and whether the database
+// has applied it.
+type MigrationStatus struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	File    string `json:"file"`
+	// Ignored marks migrations of the clone-local (dolt-ignored) tables,
+	// tracked in ignored_schema_migrations rather than schema_migrations.
+	Ignored bool `json:"ignored,omitempty"`
+	Applied bool `json:"applied"`
+}
+
+// Status reports every embedded migration, main source first, with whether db
+// has applied it. It only reads: nothing is created or migrated, so it is safe
+// on read-only connections and on databases the open could not migrate.
+func Status(ctx context.Context, db DBConn) ([]MigrationStatus, error) {
+	var out []MigrationStatus
+	for _, src := range []migrationSource{mainSource, ignoredSource} {
+		current, err := src.currentVersion(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		for _, mf := range src.list() {
+			out = append(out, MigrationStatus{
+				Version: mf.version,
+				Name:    humanMigrationName(mf.name),
+				File:    mf.name,
+				Ignored: src.cursorTable == ignoredSource.cursorTable,
+				Applied: mf.version <= current,
+			})
+		}
+	}
+	return out, nil
+}
+
+// PendingSQL returns the SQL that MigrateUp would execute for the migrations
+// db has not applied, one commented block per migration file. It omits the
+// cursor-table bookkeeping and the pre-migration repairs and backfills
+// MigrateUp layers on, which depend on the data at apply time. An empty string
+// means nothing is pending.
+func PendingSQL(ctx context.Context, db DBConn) (string, error) {
+	statuses, err := Status(ctx, db)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, st := range statuses {
+		if st.Applied {
+			continue
+		}
+		src := mainSource
+		if st.Ignored {
+			src = ignoredSource
+		}
+		data, err := src.files.ReadFile(src.dir + "/" + st.File)
+		if err != nil {
+			return "", fmt.Errorf("reading migration %s: %w", st.File, err)
+		}
+		fmt.Fprintf(&b, "-- %s/%s\n%s\n\n", src.dir, st.File, strings.TrimRight(string(data), "\n"))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestStatusAndPendingSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	latest := LatestVersion()
+	for range 2 {
+		mock.ExpectQuery(`SELECT COALESCE\(MAX\(version\), 0\) FROM schema_migrations`).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(latest - 1))
+		mock.ExpectQuery(`SELECT COALESCE\(MAX\(version\), 0\) FROM ignored_schema_migrations`).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(LatestIgnoredVersion()))
+	}
+
+	statuses, err := Status(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	var pending []MigrationStatus
+	for _, st := range statuses {
+		if !st.Applied {
+			pending = append(pending, st)
+		}
+	}
+	if len(pending) != 1 || pending[0].Version != latest || pending[0].Ignored {
+		t.Fatalf("pending = %+v, want only main migration %d", pending, latest)
+	}
+	if len(statuses) != latest+LatestIgnoredVersion() {
+		t.Errorf("len(statuses) = %d, want %d", len(statuses), latest+LatestIgnoredVersion())
+	}
+
+	preview, err := PendingSQL(context.Background(), db)
+	if err != nil {
+		t.Fatalf("PendingSQL: %v", err)
+	}
+	if !strings.HasPrefix(preview, "-- migrations/"+pending[0].File+"\n") {
+		t.Errorf("preview does not start with the pending file header:\n%s", preview)
+	}
+	if strings.Count(preview, "\n-- migrations/") != 0 {
+		t.Errorf("preview includes applied migrations:\n%s", preview)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet sql expectations: %v", err)
+	}
+}
@@ -70,6 +70,14 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 		whereClauses = append(whereClauses, "external_ref = ?")
 		args = append(args, *filter.ExternalRef)
 	}
+	for _, term := range filter.TextTerms {
+		clause, termArgs, err := TextTermClause(term, tables)
+		if err != nil {
+			return nil, nil, err
+		}
+		whereClauses = append(whereClauses, clause)
+		args = append(args, termArgs...)
+	}
 
 	if filter.Status != nil {
 		whereClauses = append(whereClauses, "status = ?")
@@ -106,8 +114,23 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 	}
 
 	if filter.Assignee != nil {
-		whereClauses = append(whereClauses, "assignee = ?")
-		args = append(args, *filter.Assignee)
+		clause, assigneeArgs := AssigneeClause([]string{*filter.Assignee}, tables)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, assigneeArgs...)
+	}
+	if len(filter.Assignees) > 0 {
+		clause, assigneeArgs := AssigneeClause(filter.Assignees, tables)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, assigneeArgs...)
+	}
+	if filter.Team != nil {
+		if tables.Teams == "" {
+			whereClauses = append(whereClauses, "1 = 0")
+		} else {
+			//nolint:gosec // G201: tables.Teams is a hardcoded table name
+			whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM %s WHERE team = ?)", tables.Teams))
+			args = append(args, *filter.Team)
+		}
 	}
 
 	if filter.Priority != nil {
@@ -149,6 +172,17 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 		whereClauses = append(whereClauses, fmt.Sprintf("id NOT IN (SELECT issue_id FROM %s WHERE type = 'parent-child')", tables.Dependencies))
 	}
 
+	// The blocked issue's own edge rows record what blocks it, and it may be
+	// a wisp or a durable issue, so both dependency tables are consulted.
+	for _, blockedID := range filter.BlocksIDs {
+		whereClauses = append(whereClauses, fmt.Sprintf("(id IN (SELECT %s FROM %s WHERE issue_id = ? AND type = 'blocks') OR id IN (SELECT %s FROM %s WHERE issue_id = ? AND type = 'blocks'))", DepTargetExpr, IssuesFilterTables.Dependencies, DepTargetExpr, WispsFilterTables.Dependencies))
+		args = append(args, blockedID, blockedID)
+	}
+	for _, blockerID := range filter.BlockedByIDs {
+		whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM %s WHERE type = 'blocks' AND %s = ?)", tables.Dependencies, DepTargetExpr))
+		args = append(args, blockerID)
+	}
+
 	if filter.MolType != nil {
 		whereClauses = append(whereClauses, "mol_type = ?")
 		args = append(args, string(*filter.MolType))
@@ -341,6 +375,41 @@ func globToLikePattern(pattern string) string {
 	return b.String()
 }
 
+// TextTermClause renders the WHERE fragment matching one TextTerm and its
+// args. The term is matched literally: LIKE metacharacters in it are escaped.
+func TextTermClause(term types.TextTerm, tables FilterTables) (string, []any, error) {
+	like := "LIKE ? ESCAPE '|'"
+	comments := fmt.Sprintf("id IN (SELECT issue_id FROM %s WHERE LOWER(text) %s)", tables.Comments, like)
+	var clause string
+	switch term.Field {
+	case types.TextFieldAny:
+		clause = "(" + strings.Join([]string{
+			"LOWER(title) " + like, "LOWER(description) " + like, "LOWER(design) " + like,
+			"LOWER(notes) " + like, "LOWER(acceptance_criteria) " + like, "LOWER(id) " + like, comments,
+		}, " OR ") + ")"
+	case types.TextFieldTitle:
+		clause = "LOWER(title) " + like
+	case types.TextFieldDescription:
+		clause = "LOWER(description) " + like
+	case types.TextFieldDesign:
+		clause = "LOWER(design) " + like
+	case types.TextFieldNotes:
+		clause = "LOWER(notes) " + like
+	case types.TextFieldAcceptance:
+		clause = "LOWER(acceptance_criteria) " + like
+	case types.TextFieldComments:
+		clause = comments
+	default:
+		return "", nil, fmt.Errorf("unknown text search field %q", term.Field)
+	}
+	pattern := "%" + strings.NewReplacer("|", "||", "%", "|%", "_", "|_").Replace(strings.ToLower(term.Text)) + "%"
+	args := make([]any, strings.Count(clause, "?"))
+	for i := range args {
+		args[i] = pattern
+	}
+	return clause, args, nil
+}
+
 // LooksLikeIssueID returns true if the query string looks like a beads issue ID.
 func LooksLikeIssueID(query string) bool {
 	idx := strings.Index(query, "-")
@@ -357,3 +426,25 @@ func LooksLikeIssueID(query string) bool {
 	}
 	return true
 }
+
+// AssigneeClause matches issues assigned to any of names: through the
+// assignee column, or as a co-assignee where the table keeps them.
+func AssigneeClause(names []string, tables FilterTables) (string, []any) {
+	placeholders := make([]string, len(names))
+	args := make([]any, 0, 2*len(names))
+	for i, name := range names {
+		placeholders[i] = "?"
+		args = append(args, name)
+	}
+	list := strings.Join(placeholders, ", ")
+	column := "assignee = ?"
+	if len(names) > 1 {
+		column = fmt.Sprintf("assignee IN (%s)", list)
+	}
+	if tables.Assignees == "" {
+		return column, args
+	}
+	args = append(args, args...)
+	//nolint:gosec // G201: tables.Assignees is a hardcoded table name
+	return fmt.Sprintf("(%s OR id IN (SELECT issue_id FROM %s WHERE assignee IN (%s)))", column, tables.Assignees, list), args
+}
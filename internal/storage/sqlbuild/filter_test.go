@@ -1,6 +1,11 @@
 package sqlbuild
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
 
 // TestGlobToLikePattern exercises globToLikePattern directly against the
 // package that actually calls it from BuildIssueFilterClauses (be-ucslk4).
@@ -35,3 +40,91 @@ func TestGlobToLikePattern(t *testing.T) {
 		})
 	}
 }
+
+func TestTextTermClause(t *testing.T) {
+	t.Parallel()
+
+	clause, args, err := TextTermClause(types.TextTerm{Text: "50%_Off"}, WispsFilterTables)
+	if err != nil {
+		t.Fatalf("TextTermClause: %v", err)
+	}
+	if !strings.Contains(clause, "wisp_comments") || !strings.Contains(clause, "acceptance_criteria") {
+		t.Errorf("any-field clause = %q, want every text field and the wisp comments table", clause)
+	}
+	if len(args) != strings.Count(clause, "?") {
+		t.Fatalf("got %d args for %d placeholders", len(args), strings.Count(clause, "?"))
+	}
+	if args[0] != "%50|%|_off%" {
+		t.Errorf("pattern = %q, want lower-cased with LIKE metacharacters escaped", args[0])
+	}
+
+	clause, args, err = TextTermClause(types.TextTerm{Field: types.TextFieldTitle, Text: "crash"}, IssuesFilterTables)
+	if err != nil || clause != "LOWER(title) LIKE ? ESCAPE '|'" || len(args) != 1 {
+		t.Errorf("title clause = %q %v %v", clause, args, err)
+	}
+
+	if _, _, err := TextTermClause(types.TextTerm{Field: "bogus", Text: "x"}, IssuesFilterTables); err == nil {
+		t.Error("unknown field: want error")
+	}
+}
+
+func TestDependencyFilterClauses(t *testing.T) {
+	t.Parallel()
+
+	filter := types.IssueFilter{
+		Assignees:    []string{"alice", "bob"},
+		BlocksIDs:    []string{"bd-12"},
+		BlockedByIDs: []string{"bd-7"},
+	}
+	where, args, err := BuildIssueFilterClauses("", filter, WispsFilterTables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql := strings.Join(where, " AND ")
+	if len(args) != strings.Count(sql, "?") {
+		t.Fatalf("got %d args for %d placeholders in %q", len(args), strings.Count(sql, "?"), sql)
+	}
+	if !strings.Contains(sql, "assignee IN (?, ?)") {
+		t.Errorf("missing assignee list clause: %q", sql)
+	}
+	// blocks: reads the blocked issue's edges in both tables; blocked-by:
+	// reads the searched table's own edges.
+	if len(where) != 3 || !strings.Contains(where[1], "FROM dependencies") || !strings.Contains(where[1], "FROM wisp_dependencies") {
+		t.Errorf("blocks clause = %q", where[1])
+	}
+	if !strings.Contains(where[2], "SELECT issue_id FROM wisp_dependencies") {
+		t.Errorf("blocked-by clause = %q", where[2])
+	}
+}
+
+func TestAssigneeAndTeamClauses(t *testing.T) {
+	t.Parallel()
+
+	team := "infra"
+	alice := "alice"
+	filter := types.IssueFilter{Assignee: &alice, Team: &team}
+
+	where, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql := strings.Join(where, " AND ")
+	if len(args) != strings.Count(sql, "?") {
+		t.Fatalf("got %d args for %d placeholders in %q", len(args), strings.Count(sql, "?"), sql)
+	}
+	if !strings.Contains(where[0], "assignee = ? OR id IN (SELECT issue_id FROM issue_assignees WHERE assignee IN (?))") {
+		t.Errorf("assignee clause = %q", where[0])
+	}
+	if !strings.Contains(where[1], "SELECT issue_id FROM issue_teams WHERE team = ?") {
+		t.Errorf("team clause = %q", where[1])
+	}
+
+	// Wisps keep neither co-assignees nor teams.
+	where, _, err = BuildIssueFilterClauses("", filter, WispsFilterTables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if where[0] != "assignee = ?" || where[1] != "1 = 0" {
+		t.Errorf("wisp clauses = %q", where)
+	}
+}
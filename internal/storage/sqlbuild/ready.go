@@ -118,8 +118,9 @@ func BuildReadyWorkWhere(filter types.WorkFilter, tables FilterTables, in ReadyW
 	if filter.Unassigned {
 		whereClauses = append(whereClauses, "(assignee IS NULL OR assignee = '')")
 	} else if filter.Assignee != nil {
-		whereClauses = append(whereClauses, "assignee = ?")
-		args = append(args, *filter.Assignee)
+		clause, assigneeArgs := AssigneeClause([]string{*filter.Assignee}, tables)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, assigneeArgs...)
 	}
 
 	if !filter.IncludeDeferred {
@@ -135,6 +136,16 @@ func BuildReadyWorkWhere(filter types.WorkFilter, tables FilterTables, in ReadyW
 		}
 	}
 
+	for start := 0; start < len(filter.ExcludeIDs); start += QueryBatchSize {
+		end := start + QueryBatchSize
+		if end > len(filter.ExcludeIDs) {
+			end = len(filter.ExcludeIDs)
+		}
+		placeholders, batchArgs := InPlaceholders(filter.ExcludeIDs[start:end])
+		args = append(args, batchArgs...)
+		whereClauses = append(whereClauses, fmt.Sprintf("id NOT IN (%s)", placeholders))
+	}
+
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
 			whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM %s WHERE label = ?)", tables.Labels))
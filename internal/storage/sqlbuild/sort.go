@@ -41,7 +41,7 @@ const UnionSortColumnsSQL = `priority AS sort_priority,
 // IsGoSideSort reports sort keys that are applied in Go after the query
 // instead of in SQL.
 func IsGoSideSort(sortBy string) bool {
-	return sortBy == "id"
+	return sortBy == "id" || strings.HasPrefix(sortBy, "metadata.")
 }
 
 func flipDir(dir string) string {
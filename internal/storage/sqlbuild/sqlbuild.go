@@ -20,10 +20,12 @@ type FilterTables struct {
 	Labels       string // "labels" or "wisp_labels"
 	Dependencies string // "dependencies" or "wisp_dependencies"
 	Comments     string // "comments" or "wisp_comments"
+	Assignees    string // "issue_assignees", or "" where co-assignees are not kept (wisps)
+	Teams        string // "issue_teams", or "" where teams are not kept (wisps)
 }
 
 var (
-	IssuesFilterTables = FilterTables{Main: "issues", Labels: "labels", Dependencies: "dependencies", Comments: "comments"}
+	IssuesFilterTables = FilterTables{Main: "issues", Labels: "labels", Dependencies: "dependencies", Comments: "comments", Assignees: "issue_assignees", Teams: "issue_teams"}
 	WispsFilterTables  = FilterTables{Main: "wisps", Labels: "wisp_labels", Dependencies: "wisp_dependencies", Comments: "wisp_comments"}
 )
 
@@ -147,6 +147,27 @@ func TestBuildReadyWorkWhereBatchesIDSets(t *testing.T) {
 	}
 }
 
+func TestBuildReadyWorkWhereExcludeIDs(t *testing.T) {
+	t.Parallel()
+
+	ids := make([]string, QueryBatchSize+1)
+	for i := range ids {
+		ids[i] = "x-" + strings.Repeat("b", 3)
+	}
+	// ExcludeIDs applies even when deferred issues are included.
+	filter := types.WorkFilter{ExcludeIDs: ids, IncludeDeferred: true}
+	where, args, err := BuildReadyWorkWhere(filter, WispsFilterTables, ReadyWorkWhereInputs{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Count(where, "id NOT IN ("); got != 2 {
+		t.Errorf("expected 2 batched NOT IN clauses for %d IDs, got %d", len(ids), got)
+	}
+	if wantArgs := len(ids) + len(ReadyWorkExcludeTypes(nil)); len(args) != wantArgs {
+		t.Errorf("args = %d, want %d", len(args), wantArgs)
+	}
+}
+
 // wy-jpd3.2: --label-any was silently dropped on the ready/claim path (with or
 // without --parent). BuildReadyWorkWhere must now emit an OR-set membership
 // clause for LabelsAny that AND-combines with the AND-set Labels and the parent
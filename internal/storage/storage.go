@@ -63,6 +63,11 @@ var ErrPrefixMismatch = errors.New("prefix mismatch")
 // or an open blocking gate). Bypass with CloseIssueOptions.Force.
 var ErrCloseBlocked = errors.New("cannot close blocked issue")
 
+// ErrTransitionNotAllowed is returned when a status change breaks the
+// workflow configured in status.transitions: no rule allows it, or every
+// matching rule's guard fails.
+var ErrTransitionNotAllowed = errors.New("status transition not allowed")
+
 // ErrVersionMismatch is returned by a *Checked op given an ExpectedVersion that
 // no longer matches the row's current version (row_lock) — an optimistic
 // concurrency failure. Callers errors.Is it to distinguish a lost-update
@@ -117,7 +122,9 @@ type Storage interface {
 	// `bd close` guard. A bare is_blocked=1 with no live direct blocker (a purely
 	// transitive parent-child block, or a stale column) is not refused. The
 	// blocked-check and the close run in ONE transaction, so the guard is atomic
-	// (no TOCTOU). When opts.ExpectedVersion is non-nil it adds an orthogonal
+	// (no TOCTOU). Without opts.Force it also refuses with
+	// ErrTransitionNotAllowed when the status.transitions workflow does not
+	// allow closing. When opts.ExpectedVersion is non-nil it adds an orthogonal
 	// optimistic-concurrency precondition: the close proceeds only if the issue's
 	// current RowVersion still equals *opts.ExpectedVersion, else it refuses with
 	// ErrVersionMismatch atomically (Force does NOT bypass this check). Already-
@@ -427,6 +434,12 @@ type SchemaMigrator interface {
 	ApplySchemaMigrations(ctx context.Context) (applied int, err error)
 }
 
+// SchemaInspector runs fn in a read-only transaction so callers can inspect
+// the schema migration cursor (see schema.Status) without applying anything.
+type SchemaInspector interface {
+	InspectSchema(ctx context.Context, fn func(tx *sql.Tx) error) error
+}
+
 // Compactor squashes old Dolt commits while preserving recent ones.
 // Callers should type-assert to this interface for selective history compaction.
 type Compactor interface {
@@ -582,6 +595,16 @@ type Transaction interface {
 	RemoveLabel(ctx context.Context, issueID, label, actor string) error
 	GetLabels(ctx context.Context, issueID string) ([]string, error)
 
+	// Alias operations
+	// RedirectIssueAliases makes fromID and its aliases resolve to toID. Call
+	// it before deleting fromID when folding one issue into another.
+	RedirectIssueAliases(ctx context.Context, fromID, toID, actor string) error
+
+	// Recurrence operations
+	// SetIssueRecurrence creates or replaces an issue's recurrence rule, so
+	// bd tick can record an occurrence and advance its rule atomically.
+	SetIssueRecurrence(ctx context.Context, r *types.Recurrence) error
+
 	// Config operations (for atomic config + issue workflows)
 	SetConfig(ctx context.Context, key, value string) error
 	GetConfig(ctx context.Context, key string) (string, error)
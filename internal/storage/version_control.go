@@ -54,3 +54,10 @@ type VersionControl interface {
 	GetConflicts(ctx context.Context) ([]Conflict, error)
 	ResolveConflicts(ctx context.Context, table string, strategy string) error
 }
+
+// MergePreviewer is implemented by stores that can report the conflicts a
+// branch merge would produce, cell by cell, without performing the merge
+// (bd branch merge --report).
+type MergePreviewer interface {
+	PreviewMerge(ctx context.Context, branch string) ([]Conflict, error)
+}
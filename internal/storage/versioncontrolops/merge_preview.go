@@ -0,0 +1,166 @@
+package versioncontrolops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// previewMetaColumns are the dolt_preview_merge_conflicts columns that
+// describe the conflict rather than carry a table column's value.
+var previewMetaColumns = map[string]bool{
+	"from_root_ish":     true,
+	"our_diff_type":     true,
+	"their_diff_type":   true,
+	"base_cardinality":  true,
+	"our_cardinality":   true,
+	"their_cardinality": true,
+	"dolt_conflict_id":  true,
+}
+
+// PreviewMerge reports the conflicts merging branch into the current branch
+// would produce, without merging or touching the working set. Data conflicts
+// are reported per cell: one Conflict per column that both sides changed to
+// different values, with IssueID taken from the row's id / issue_id column.
+// Fields of tables other than issues are qualified as "table.column". A row
+// one side deleted and the other modified is reported with Field "(deleted)",
+// and schema conflicts as a table-level Conflict (Field is the table name),
+// matching GetConflicts.
+func PreviewMerge(ctx context.Context, db DBConn, branch string) ([]storage.Conflict, error) {
+	current, err := CurrentBranch(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	type tableSummary struct {
+		name          string
+		data, schemas int64
+	}
+	rows, err := db.QueryContext(ctx,
+		"SELECT `table`, num_data_conflicts, num_schema_conflicts FROM DOLT_PREVIEW_MERGE_CONFLICTS_SUMMARY(?, ?)",
+		current, branch)
+	if err != nil {
+		return nil, fmt.Errorf("preview merge of %s into %s: %w", branch, current, err)
+	}
+	var tables []tableSummary
+	for rows.Next() {
+		var name string
+		var dataCount, schemaCount sql.NullInt64
+		if err := rows.Scan(&name, &dataCount, &schemaCount); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan merge preview summary: %w", err)
+		}
+		tables = append(tables, tableSummary{name: name, data: dataCount.Int64, schemas: schemaCount.Int64})
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("read merge preview summary: %w", err)
+	}
+
+	var conflicts []storage.Conflict
+	for _, t := range tables {
+		if t.schemas > 0 {
+			conflicts = append(conflicts, storage.Conflict{Field: t.name})
+		}
+		if t.data == 0 {
+			continue
+		}
+		cells, err := previewTableConflicts(ctx, db, current, branch, t.name)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, cells...)
+	}
+	return conflicts, nil
+}
+
+// previewTableConflicts expands one table's previewed data conflicts into
+// per-cell Conflicts.
+func previewTableConflicts(ctx context.Context, db DBConn, current, branch, table string) ([]storage.Conflict, error) {
+	if err := validateTableName(table); err != nil {
+		return nil, fmt.Errorf("invalid table name: %w", err)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT * FROM DOLT_PREVIEW_MERGE_CONFLICTS(?, ?, ?)", current, branch, table)
+	if err != nil {
+		return nil, fmt.Errorf("preview merge conflicts in %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("preview merge conflicts in %s: %w", table, err)
+	}
+	// Columns are base_*, our_*, their_* for every table column, in the
+	// same order on each side.
+	var names []string
+	for _, c := range cols {
+		if name, ok := strings.CutPrefix(c, "base_"); ok && !previewMetaColumns[c] {
+			names = append(names, name)
+		}
+	}
+	index := make(map[string]int, len(cols))
+	for i, c := range cols {
+		index[c] = i
+	}
+
+	prefix := ""
+	if table != "issues" {
+		prefix = table + "."
+	}
+
+	var conflicts []storage.Conflict
+	for rows.Next() {
+		raw := make([]sql.NullString, len(cols))
+		dest := make([]any, len(cols))
+		for i := range raw {
+			dest[i] = &raw[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan merge preview conflict in %s: %w", table, err)
+		}
+		value := func(col string) any {
+			i, ok := index[col]
+			if !ok || !raw[i].Valid {
+				return nil
+			}
+			return raw[i].String
+		}
+
+		issueID := ""
+		for _, key := range []string{"id", "issue_id"} {
+			for _, side := range []string{"our_", "their_", "base_"} {
+				if v, ok := value(side + key).(string); ok && issueID == "" {
+					issueID = v
+				}
+			}
+		}
+
+		ourType, _ := value("our_diff_type").(string)
+		theirType, _ := value("their_diff_type").(string)
+		if ourType == "removed" || theirType == "removed" {
+			conflicts = append(conflicts, storage.Conflict{
+				IssueID:     issueID,
+				Field:       prefix + "(deleted)",
+				OursValue:   ourType,
+				TheirsValue: theirType,
+			})
+			continue
+		}
+
+		for _, name := range names {
+			base, ours, theirs := value("base_"+name), value("our_"+name), value("their_"+name)
+			if ours == base || theirs == base || ours == theirs {
+				continue
+			}
+			conflicts = append(conflicts, storage.Conflict{
+				IssueID:     issueID,
+				Field:       prefix + name,
+				OursValue:   ours,
+				TheirsValue: theirs,
+			})
+		}
+	}
+	return conflicts, rows.Err()
+}
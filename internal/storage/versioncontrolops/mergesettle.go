@@ -748,6 +748,12 @@ var fkCascadeRepairDeletes = map[string]string{
 		   OR (depends_on_issue_id IS NOT NULL AND depends_on_issue_id NOT IN (SELECT id FROM issues))`,
 	"labels":               `DELETE FROM labels WHERE issue_id NOT IN (SELECT id FROM issues)`,
 	"comments":             `DELETE FROM comments WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	"attachments":          `DELETE FROM attachments WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	"commit_links":         `DELETE FROM commit_links WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	"issue_aliases":        `DELETE FROM issue_aliases WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	"issue_assignees":      `DELETE FROM issue_assignees WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	"issue_teams":          `DELETE FROM issue_teams WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	"issue_recurrences":    `DELETE FROM issue_recurrences WHERE issue_id NOT IN (SELECT id FROM issues)`,
 	"events":               `DELETE FROM events WHERE issue_id NOT IN (SELECT id FROM issues)`,
 	"issue_snapshots":      `DELETE FROM issue_snapshots WHERE issue_id NOT IN (SELECT id FROM issues)`,
 	"compaction_snapshots": `DELETE FROM compaction_snapshots WHERE issue_id NOT IN (SELECT id FROM issues)`,
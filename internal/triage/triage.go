@@ -0,0 +1,301 @@
+// Package triage evaluates the triage policy rules configured under
+// triage.rules in config.yaml:
+//
+//	triage:
+//	  rules:
+//	    stale-wip:
+//	      status: in_progress
+//	      untouched: 14d
+//	      add_label: stale
+//	      notify: true
+//	    old-backlog:
+//	      status: open
+//	      priority: 3
+//	      age: 180d
+//	      propose_close: true
+//
+// A rule matches the open issues that meet all of its conditions and, when
+// applied (bd triage --apply), labels and comments on them and queues a
+// notification. Rules are idempotent through their labels: an issue that
+// already carries every label a rule adds is skipped, so running the rules
+// on a schedule acts on each issue once.
+package triage
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ProposeCloseLabel is the label propose_close adds.
+const ProposeCloseLabel = "close-proposed"
+
+// Rule is one configured triage policy.
+type Rule struct {
+	Name string `json:"name"`
+
+	// Conditions; an issue must meet all that are set.
+	Statuses   []string `json:"status,omitempty"`
+	Priorities []int    `json:"priority,omitempty"`
+	Types      []string `json:"type,omitempty"`
+	Labels     []string `json:"label,omitempty"`     // issue has every one
+	Untouched  string   `json:"untouched,omitempty"` // not updated for, e.g. 14d
+	Age        string   `json:"age,omitempty"`       // created more than, e.g. 180d ago
+
+	// Actions.
+	AddLabels    []string `json:"add_label,omitempty"`
+	Comment      string   `json:"comment,omitempty"`
+	ProposeClose bool     `json:"propose_close,omitempty"`
+	Notify       bool     `json:"notify,omitempty"`
+}
+
+// ParseRules decodes the triage.rules config map (name → settings), sorted
+// by name.
+func ParseRules(raw map[string]interface{}) ([]*Rule, error) {
+	var rules []*Rule
+	for name, v := range raw {
+		settings, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("triage.rules.%s: expected a map of settings", name)
+		}
+		r := &Rule{Name: name}
+		for key, val := range settings {
+			var err error
+			switch strings.ToLower(key) {
+			case "status":
+				r.Statuses, err = stringList(val)
+			case "priority":
+				r.Priorities, err = priorityList(val)
+			case "type":
+				r.Types, err = stringList(val)
+			case "label":
+				r.Labels, err = stringList(val)
+			case "untouched":
+				r.Untouched, err = duration(val)
+			case "age":
+				r.Age, err = duration(val)
+			case "add_label":
+				r.AddLabels, err = stringList(val)
+			case "comment":
+				r.Comment = strings.TrimSpace(fmt.Sprint(val))
+			case "propose_close":
+				r.ProposeClose, err = boolValue(val)
+			case "notify":
+				r.Notify, err = boolValue(val)
+			default:
+				return nil, fmt.Errorf("triage.rules.%s: unknown setting %q", name, key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("triage.rules.%s.%s: %w", name, key, err)
+			}
+		}
+		if len(r.AddLabels) == 0 && r.Comment == "" && !r.ProposeClose && !r.Notify {
+			return nil, fmt.Errorf("triage.rules.%s: no action (want add_label, comment, propose_close or notify)", name)
+		}
+		if len(r.Statuses) == 0 && len(r.Priorities) == 0 && len(r.Types) == 0 && len(r.Labels) == 0 && r.Untouched == "" && r.Age == "" {
+			return nil, fmt.Errorf("triage.rules.%s: no condition (want status, priority, type, label, untouched or age)", name)
+		}
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+// labelsToAdd returns the labels applying r adds: add_label plus
+// ProposeCloseLabel for propose_close.
+func (r *Rule) labelsToAdd() []string {
+	labels := slices.Clone(r.AddLabels)
+	if r.ProposeClose && !slices.Contains(labels, ProposeCloseLabel) {
+		labels = append(labels, ProposeCloseLabel)
+	}
+	return labels
+}
+
+// Match reports whether issue (with its labels) meets every condition of r
+// at now, and if so describes why in a short phrase for reports and
+// comments.
+func (r *Rule) Match(issue *types.Issue, labels []string, now time.Time) (string, bool) {
+	if issue.Status == types.StatusClosed {
+		return "", false
+	}
+	if len(r.Statuses) > 0 && !slices.Contains(r.Statuses, string(issue.Status)) {
+		return "", false
+	}
+	if len(r.Priorities) > 0 && !slices.Contains(r.Priorities, issue.Priority) {
+		return "", false
+	}
+	if len(r.Types) > 0 && !slices.Contains(r.Types, string(issue.IssueType)) {
+		return "", false
+	}
+	for _, label := range r.Labels {
+		if !slices.Contains(labels, label) {
+			return "", false
+		}
+	}
+	var reasons []string
+	if r.Untouched != "" {
+		if !issue.UpdatedAt.Before(cutoff(r.Untouched, now)) {
+			return "", false
+		}
+		reasons = append(reasons, "not updated for "+days(now.Sub(issue.UpdatedAt)))
+	}
+	if r.Age != "" {
+		if !issue.CreatedAt.Before(cutoff(r.Age, now)) {
+			return "", false
+		}
+		reasons = append(reasons, "created "+days(now.Sub(issue.CreatedAt))+" ago")
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, fmt.Sprintf("%s P%d %s", issue.Status, issue.Priority, issue.IssueType))
+	}
+	return strings.Join(reasons, ", "), true
+}
+
+// Action is what one rule does to one issue.
+type Action struct {
+	Rule      string   `json:"rule"`
+	IssueID   string   `json:"issue_id"`
+	Title     string   `json:"title"`
+	Reason    string   `json:"reason"`
+	AddLabels []string `json:"add_labels,omitempty"`
+	Comment   string   `json:"comment,omitempty"`
+	Notify    bool     `json:"notify,omitempty"`
+
+	// ProposeClose marks a close proposal; its label is in AddLabels.
+	ProposeClose bool `json:"propose_close,omitempty"`
+}
+
+// Plan evaluates rules against issues, with labels keyed by issue ID, and
+// returns the actions in rule order, then issue order. A rule skips an
+// issue that already has every label the rule adds, including labels added
+// by earlier rules of the same plan.
+func Plan(rules []*Rule, issues []*types.Issue, labels map[string][]string, now time.Time) []*Action {
+	current := make(map[string][]string, len(labels))
+	for id, l := range labels {
+		current[id] = slices.Clone(l)
+	}
+	var actions []*Action
+	for _, r := range rules {
+		add := r.labelsToAdd()
+		for _, issue := range issues {
+			reason, ok := r.Match(issue, current[issue.ID], now)
+			if !ok {
+				continue
+			}
+			var missing []string
+			for _, label := range add {
+				if !slices.Contains(current[issue.ID], label) {
+					missing = append(missing, label)
+				}
+			}
+			if len(add) > 0 && len(missing) == 0 {
+				continue
+			}
+			current[issue.ID] = append(current[issue.ID], missing...)
+			actions = append(actions, &Action{
+				Rule:         r.Name,
+				IssueID:      issue.ID,
+				Title:        issue.Title,
+				Reason:       reason,
+				AddLabels:    missing,
+				Comment:      r.comment(reason),
+				Notify:       r.Notify,
+				ProposeClose: r.ProposeClose,
+			})
+		}
+	}
+	return actions
+}
+
+// comment returns the comment applying r posts, or "" for none.
+func (r *Rule) comment(reason string) string {
+	switch {
+	case r.Comment != "":
+		return r.Comment
+	case r.ProposeClose:
+		return fmt.Sprintf("Proposed for closing by triage rule %s (%s). Close it if it is no longer needed, or remove the %s label to keep it.", r.Name, reason, ProposeCloseLabel)
+	}
+	return ""
+}
+
+// cutoff returns now minus a duration validated by ParseRules.
+func cutoff(d string, now time.Time) time.Time {
+	t, err := timeparsing.ParseCompactDuration("-"+d, now)
+	if err != nil {
+		return now
+	}
+	return t
+}
+
+func days(d time.Duration) string {
+	if d < 48*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+func duration(val interface{}) (string, error) {
+	s := strings.TrimSpace(fmt.Sprint(val))
+	zero := time.Time{}
+	if t, err := timeparsing.ParseCompactDuration(s, zero); err != nil || strings.HasPrefix(s, "+") || !t.After(zero) {
+		return "", fmt.Errorf("want a duration like 36h, 14d, 2w, 6m or 1y, got %q", s)
+	}
+	return s, nil
+}
+
+func priorityList(val interface{}) ([]int, error) {
+	if n, ok := val.(int); ok {
+		val = strconv.Itoa(n)
+	}
+	items, err := stringList(val)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int, 0, len(items))
+	for _, item := range items {
+		p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(item), "P"))
+		if err != nil || p < 0 || p > 4 {
+			return nil, fmt.Errorf("want priorities 0-4, got %q", item)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func boolValue(val interface{}) (bool, error) {
+	switch v := val.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, fmt.Errorf("want true or false, got %v", val)
+}
+
+func stringList(val interface{}) ([]string, error) {
+	switch v := val.(type) {
+	case string:
+		var out []string
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, s := range v {
+			out = append(out, strings.TrimSpace(fmt.Sprint(s)))
+		}
+		return out, nil
+	case []string:
+		return v, nil
+	}
+	return nil, fmt.Errorf("expected a list, got %T", val)
+}
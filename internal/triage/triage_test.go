@@ -0,0 +1,89 @@
+package triage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(map[string]interface{}{
+		"stale-wip": map[string]interface{}{
+			"status":    "in_progress",
+			"untouched": "14d",
+			"add_label": []interface{}{"stale"},
+			"notify":    true,
+		},
+		"old-backlog": map[string]interface{}{
+			"status":        "open",
+			"priority":      []interface{}{3, "P4"},
+			"age":           "6m",
+			"propose_close": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Name != "old-backlog" || rules[1].Name != "stale-wip" {
+		t.Fatalf("rules = %+v, want old-backlog then stale-wip", rules)
+	}
+	if !reflect.DeepEqual(rules[0].Priorities, []int{3, 4}) || !rules[0].ProposeClose || rules[0].Age != "6m" {
+		t.Errorf("old-backlog = %+v", rules[0])
+	}
+	if !reflect.DeepEqual(rules[1].AddLabels, []string{"stale"}) || !rules[1].Notify {
+		t.Errorf("stale-wip = %+v", rules[1])
+	}
+
+	for name, settings := range map[string]map[string]interface{}{
+		"unknown setting": {"status": "open", "add_label": "x", "colour": "red"},
+		"bad duration":    {"untouched": "soon", "add_label": "x"},
+		"negative":        {"untouched": "-3d", "add_label": "x"},
+		"bad priority":    {"priority": 7, "add_label": "x"},
+		"no action":       {"status": "open"},
+		"no condition":    {"add_label": "x"},
+	} {
+		if _, err := ParseRules(map[string]interface{}{"r": settings}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Abandoned", Status: types.StatusInProgress, Priority: 2, CreatedAt: now.Add(-40 * day), UpdatedAt: now.Add(-20 * day)},
+		{ID: "bd-2", Title: "Active", Status: types.StatusInProgress, Priority: 2, CreatedAt: now.Add(-40 * day), UpdatedAt: now.Add(-day)},
+		{ID: "bd-3", Title: "Ancient", Status: types.StatusOpen, Priority: 3, CreatedAt: now.Add(-200 * day), UpdatedAt: now.Add(-200 * day)},
+		{ID: "bd-4", Title: "Already stale", Status: types.StatusInProgress, Priority: 2, CreatedAt: now.Add(-40 * day), UpdatedAt: now.Add(-30 * day)},
+		{ID: "bd-5", Title: "Done", Status: types.StatusClosed, Priority: 3, CreatedAt: now.Add(-200 * day), UpdatedAt: now.Add(-200 * day)},
+	}
+	labels := map[string][]string{"bd-4": {"stale"}}
+	rules := []*Rule{
+		{Name: "old-backlog", Statuses: []string{"open"}, Priorities: []int{3}, Age: "180d", ProposeClose: true},
+		{Name: "stale-wip", Statuses: []string{"in_progress"}, Untouched: "14d", AddLabels: []string{"stale"}, Notify: true},
+	}
+
+	actions := Plan(rules, issues, labels, now)
+	if len(actions) != 2 {
+		t.Fatalf("got %d actions, want 2: %+v", len(actions), actions)
+	}
+	propose, stale := actions[0], actions[1]
+	if propose.IssueID != "bd-3" || !reflect.DeepEqual(propose.AddLabels, []string{ProposeCloseLabel}) ||
+		!strings.Contains(propose.Comment, "old-backlog") || propose.Reason != "created 200d ago" {
+		t.Errorf("propose action = %+v", propose)
+	}
+	if stale.IssueID != "bd-1" || !reflect.DeepEqual(stale.AddLabels, []string{"stale"}) || !stale.Notify || stale.Reason != "not updated for 20d" {
+		t.Errorf("stale action = %+v", stale)
+	}
+
+	// Once applied, the labels make the rules skip these issues.
+	labels["bd-1"] = []string{"stale"}
+	labels["bd-3"] = []string{ProposeCloseLabel}
+	if again := Plan(rules, issues, labels, now); len(again) != 0 {
+		t.Errorf("re-running applied rules planned %+v", again)
+	}
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Checklists live in an issue's description as markdown task items
+// ("- [ ] write tests", "- [x] write tests"), so they are stored, synced and
+// exported with the description itself. Items inside fenced code blocks are
+// not part of the checklist.
+
+var (
+	checklistItemPattern  = regexp.MustCompile(`^(\s*[-*+] \[)([ xX])(\]\s+)(.+?)\s*$`)
+	checklistFencePattern = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// ChecklistItem is one task item of an issue description.
+type ChecklistItem struct {
+	Index int    `json:"index"` // 1-based position in the checklist
+	Text  string `json:"text"`
+	Done  bool   `json:"done"`
+	Line  int    `json:"-"` // 0-based line in the description
+}
+
+// ChecklistProgress summarizes a checklist.
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// Percent returns the completed share of the checklist, rounded down.
+func (p ChecklistProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// ParseChecklist returns the task items of a description in document order.
+func ParseChecklist(description string) []ChecklistItem {
+	var items []ChecklistItem
+	inFence := false
+	for i, line := range strings.Split(description, "\n") {
+		if checklistFencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := checklistItemPattern.FindStringSubmatch(line); m != nil {
+			items = append(items, ChecklistItem{
+				Index: len(items) + 1,
+				Text:  m[4],
+				Done:  m[2] != " ",
+				Line:  i,
+			})
+		}
+	}
+	return items
+}
+
+// ChecklistProgressOf returns the progress of the description's checklist,
+// or nil when it has none.
+func ChecklistProgressOf(description string) *ChecklistProgress {
+	if !strings.Contains(description, "[") {
+		return nil
+	}
+	items := ParseChecklist(description)
+	if len(items) == 0 {
+		return nil
+	}
+	p := &ChecklistProgress{Total: len(items)}
+	for _, item := range items {
+		if item.Done {
+			p.Done++
+		}
+	}
+	return p
+}
+
+// SetChecklistItemDone returns description with the given item marked done
+// or not done. The rest of the line is left as written.
+func SetChecklistItemDone(description string, item ChecklistItem, done bool) string {
+	lines := strings.Split(description, "\n")
+	mark := " "
+	if done {
+		mark = "x"
+	}
+	lines[item.Line] = checklistItemPattern.ReplaceAllString(lines[item.Line], "${1}"+mark+"${3}${4}")
+	return strings.Join(lines, "\n")
+}
+
+// AddChecklistItems returns description with unchecked items for texts
+// appended after its last checklist item, in that item's style. A description
+// without a checklist gets a "## Checklist" section at the end.
+func AddChecklistItems(description string, texts ...string) string {
+	items := ParseChecklist(description)
+	if len(items) == 0 {
+		var sb strings.Builder
+		if desc := strings.TrimRight(description, "\n"); desc != "" {
+			sb.WriteString(desc + "\n\n")
+		}
+		sb.WriteString("## Checklist")
+		for _, text := range texts {
+			sb.WriteString("\n- [ ] " + text)
+		}
+		return sb.String()
+	}
+
+	lines := strings.Split(description, "\n")
+	last := items[len(items)-1].Line
+	m := checklistItemPattern.FindStringSubmatch(lines[last])
+	added := make([]string, len(texts))
+	for i, text := range texts {
+		added[i] = m[1] + " " + m[3] + text
+	}
+	out := append(append(lines[:last+1:last+1], added...), lines[last+1:]...)
+	return strings.Join(out, "\n")
+}
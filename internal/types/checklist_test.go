@@ -0,0 +1,58 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseChecklist(t *testing.T) {
+	desc := "Intro\n\n- [ ] write tests\n  * [x] review docs  \n```\n- [ ] not an item\n```\n+ [X] ship\n- [] malformed"
+	items := ParseChecklist(desc)
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3: %+v", len(items), items)
+	}
+	want := []ChecklistItem{
+		{Index: 1, Text: "write tests", Done: false, Line: 2},
+		{Index: 2, Text: "review docs", Done: true, Line: 3},
+		{Index: 3, Text: "ship", Done: true, Line: 7},
+	}
+	for i, w := range want {
+		if items[i] != w {
+			t.Errorf("item %d = %+v, want %+v", i, items[i], w)
+		}
+	}
+
+	p := ChecklistProgressOf(desc)
+	if p == nil || p.Done != 2 || p.Total != 3 || p.Percent() != 66 {
+		t.Errorf("progress = %+v", p)
+	}
+	if ChecklistProgressOf("no items here") != nil {
+		t.Error("description without items should have no progress")
+	}
+}
+
+func TestSetChecklistItemDone(t *testing.T) {
+	desc := "- [ ] a\n  - [x] b $1"
+	items := ParseChecklist(desc)
+	desc = SetChecklistItemDone(desc, items[0], true)
+	desc = SetChecklistItemDone(desc, items[1], false)
+	if want := "- [x] a\n  - [ ] b $1"; desc != want {
+		t.Errorf("got %q, want %q", desc, want)
+	}
+}
+
+func TestAddChecklistItems(t *testing.T) {
+	tests := []struct {
+		name, desc, want string
+	}{
+		{"empty", "", "## Checklist\n- [ ] one\n- [ ] two"},
+		{"no checklist", "Some text\n", "Some text\n\n## Checklist\n- [ ] one\n- [ ] two"},
+		{"after last item", "* [x] first\n\nNotes", "* [x] first\n* [ ] one\n* [ ] two\n\nNotes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddChecklistItems(tt.desc, "one", "two"); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
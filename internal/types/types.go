@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"hash"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -37,6 +38,10 @@ type Issue struct {
 	Assignee         string `json:"assignee,omitempty"`
 	Owner            string `json:"owner,omitempty"` // Human owner for CV attribution (git author email)
 	EstimatedMinutes *int   `json:"estimated_minutes,omitempty"`
+	// Co-assignees and the owning team live in the issue_assignees and
+	// issue_teams tables; they are populated where shown (bd show, bd workload).
+	Assignees []string `json:"assignees,omitempty"` // Working the issue alongside Assignee
+	Team      string   `json:"team,omitempty"`
 
 	// ===== Timestamps =====
 	CreatedAt       time.Time  `json:"created_at"`
@@ -106,6 +111,7 @@ type Issue struct {
 	Labels       []string      `json:"labels,omitempty"`
 	Dependencies []*Dependency `json:"dependencies,omitempty"`
 	Comments     []*Comment    `json:"comments,omitempty"`
+	Attachments  []*Attachment `json:"attachments,omitempty"` // References only; blobs live in .beads/attachments
 
 	// ===== Messaging Fields (inter-agent communication) =====
 	Sender    string   `json:"sender,omitempty"`     // Who sent this (for messages)
@@ -203,6 +209,41 @@ func (i *Issue) ComputeContentHash() string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
+// VersionToken returns an opaque token that changes whenever the issue as
+// read from the store changes: its content hash, labels, scheduling and
+// close fields, updated_at at full precision, and the row_lock RowVersion.
+// bd show --json reports it as "version" and bd update --expect-version
+// compares it, so a write within the same second as the read is still
+// caught. Compare it for equality only.
+func (i *Issue) VersionToken() string {
+	h := sha256.New()
+	w := hashFieldWriter{h}
+
+	w.str(i.ComputeContentHash())
+	labels := slices.Clone(i.Labels)
+	slices.Sort(labels)
+	for _, label := range labels {
+		w.str(label)
+	}
+	w.h.Write([]byte{0})
+	for _, t := range []*time.Time{&i.UpdatedAt, i.StartedAt, i.ClosedAt, i.DueAt, i.DeferUntil} {
+		if t != nil {
+			w.str(t.UTC().Format(time.RFC3339Nano))
+		} else {
+			w.str("")
+		}
+	}
+	w.str(i.CloseReason)
+	if i.EstimatedMinutes != nil {
+		w.int(*i.EstimatedMinutes)
+	} else {
+		w.str("")
+	}
+	w.str(fmt.Sprintf("%d", i.RowVersion))
+
+	return fmt.Sprintf("%x", h.Sum(nil)[:12])
+}
+
 // hashFieldWriter provides helper methods for writing fields to a hash.
 // Each method writes the value followed by a null separator for consistency.
 type hashFieldWriter struct {
@@ -568,6 +609,80 @@ func CustomStatusesByCategory(statuses []CustomStatus, category StatusCategory)
 	return result
 }
 
+// StatusTransitionAny matches every status on either side of a transition rule.
+const StatusTransitionAny = "*"
+
+// Transition guards a status.transitions rule can require.
+const (
+	// TransitionGuardNoBlockers requires no open blocking dependency.
+	TransitionGuardNoBlockers = "no-blockers"
+	// TransitionGuardAssigned requires an assignee.
+	TransitionGuardAssigned = "assigned"
+	// TransitionGuardNoOpenChildren requires every parent-child child to be closed.
+	TransitionGuardNoOpenChildren = "no-open-children"
+)
+
+var validTransitionGuards = map[string]bool{
+	TransitionGuardNoBlockers:     true,
+	TransitionGuardAssigned:       true,
+	TransitionGuardNoOpenChildren: true,
+}
+
+// StatusTransition is one allowed status change of a configured workflow.
+// From and To are status names or StatusTransitionAny; Guard, when set, must
+// hold for the change to be allowed.
+type StatusTransition struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Guard string `json:"guard,omitempty"`
+}
+
+// Matches reports whether the rule covers a change from one status to another.
+func (t StatusTransition) Matches(from, to Status) bool {
+	return (t.From == StatusTransitionAny || t.From == string(from)) &&
+		(t.To == StatusTransitionAny || t.To == string(to))
+}
+
+// String renders the rule in status.transitions syntax.
+func (t StatusTransition) String() string {
+	if t.Guard == "" {
+		return t.From + ">" + t.To
+	}
+	return t.From + ">" + t.To + ":" + t.Guard
+}
+
+// ParseStatusTransitions parses a status.transitions config value: a
+// comma-separated list of "from>to" rules with an optional ":guard" suffix,
+// e.g. "open>in_progress,in_progress>in_review,in_review>closed:no-blockers,*>deferred".
+// An empty value means no workflow: every status change is allowed.
+func ParseStatusTransitions(value string) ([]StatusTransition, error) {
+	var result []StatusTransition
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		rule, guard, hasGuard := strings.Cut(part, ":")
+		from, to, ok := strings.Cut(rule, ">")
+		if !ok {
+			return nil, fmt.Errorf("invalid transition %q: expected from>to", part)
+		}
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		for _, name := range []string{from, to} {
+			if name != StatusTransitionAny && !statusNameRegexp.MatchString(name) {
+				return nil, fmt.Errorf("invalid status %q in transition %q", name, part)
+			}
+		}
+		guard = strings.TrimSpace(guard)
+		if hasGuard && !validTransitionGuards[guard] {
+			return nil, fmt.Errorf("invalid guard %q in transition %q: must be one of %s, %s, %s",
+				guard, part, TransitionGuardNoBlockers, TransitionGuardAssigned, TransitionGuardNoOpenChildren)
+		}
+		result = append(result, StatusTransition{From: from, To: to, Guard: guard})
+	}
+	return result, nil
+}
+
 // BuiltInStatusCategory returns the category for a built-in status.
 func BuiltInStatusCategory(status Status) StatusCategory {
 	switch status {
@@ -828,11 +943,17 @@ type IssueWithCounts struct {
 // Used for JSON serialization in bd show and RPC responses.
 type IssueDetails struct {
 	Issue
+	Version      string                         `json:"version,omitempty"` // Issue.VersionToken, for bd update --expect-version
 	Labels       []string                       `json:"labels,omitempty"`
 	Dependencies []*IssueWithDependencyMetadata `json:"dependencies,omitempty"`
 	Dependents   []*IssueWithDependencyMetadata `json:"dependents,omitempty"`
 	Comments     []*Comment                     `json:"comments,omitempty"`
 	Parent       *string                        `json:"parent,omitempty"`
+	Commits      []*CommitLink                  `json:"commits,omitempty"`
+	Aliases      []string                       `json:"aliases,omitempty"`
+	Recurrence   *Recurrence                    `json:"recurrence,omitempty"`
+	Events       []*Event                       `json:"events,omitempty"`
+	Checklist    *ChecklistProgress             `json:"checklist,omitempty"` // from the description's task items
 
 	// Cardinality fields — emitted by default (count-only mode).
 	// Slice fields (Dependents, Comments) are nil when count-only is active.
@@ -841,6 +962,10 @@ type IssueDetails struct {
 	DependencyCount *int64 `json:"dependency_count,omitempty"`
 	CommentCount    *int64 `json:"comment_count,omitempty"`
 
+	// Computed fields — populated by bd show --full.
+	BlockedByCount *int `json:"blocked_by_count,omitempty"` // live direct blockers (the bd close guard)
+	DaysOpen       *int `json:"days_open,omitempty"`        // whole days from creation to close, or to now
+
 	// Epic progress fields (populated only for issue_type=epic with children)
 	EpicTotalChildren  *int  `json:"epic_total_children,omitempty"`
 	EpicClosedChildren *int  `json:"epic_closed_children,omitempty"`
@@ -1026,6 +1151,69 @@ type Comment struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// Attachment references a file attached to an issue. The content is stored
+// content-addressed by SHA256 under .beads/attachments; the same blob may be
+// attached to several issues or under several names.
+type Attachment struct {
+	IssueID   string    `json:"issue_id"`
+	SHA256    string    `json:"sha256"`
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	MediaType string    `json:"media_type,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CommitLink records that a git commit's message mentions an issue. Closes
+// is set when the mention used a closing keyword ("fixes bd-12").
+type CommitLink struct {
+	IssueID     string    `json:"issue_id"`
+	CommitSHA   string    `json:"commit"`
+	Subject     string    `json:"subject"`
+	Author      string    `json:"author,omitempty"`
+	CommittedAt time.Time `json:"committed_at"`
+	Closes      bool      `json:"closes,omitempty"`
+}
+
+// IssueAlias is an alternate ID that resolves to an issue: an ID the issue
+// had before a rename, or a key in another tracker (PROJ-123, gh-42).
+type IssueAlias struct {
+	Alias     string    `json:"alias"`
+	IssueID   string    `json:"issue_id"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+// Recurrence repeats an issue on a schedule (bd recur). Every is a compact
+// interval such as "2w" or "1m"; bd tick creates an occurrence once NextAt
+// has passed and the previous occurrence, LastInstanceID, is closed.
+type Recurrence struct {
+	IssueID        string    `json:"issue_id"`
+	Every          string    `json:"every"`
+	NextAt         time.Time `json:"next_at"`
+	LastInstanceID string    `json:"last_instance_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	CreatedBy      string    `json:"created_by,omitempty"`
+}
+
+// IssueAssignment is the structured assignment of an issue beyond its
+// assignee column: co-assignees, in the order they were added, and the team.
+type IssueAssignment struct {
+	Assignees []string `json:"assignees,omitempty"`
+	Team      string   `json:"team,omitempty"`
+}
+
+// LabelDefinition carries the optional metadata of a label name. Labels are
+// still attached to issues as bare strings; a definition adds a display color
+// and a description, and bd label rename/delete keep the two in step.
+type LabelDefinition struct {
+	Name        string    `json:"name"`
+	Color       string    `json:"color,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 // UnmarshalJSON handles backward compatibility for Comment.
 // Pre-v1.0 exported Comment.ID as int64; current schema uses string.
 func (c *Comment) UnmarshalJSON(data []byte) error {
@@ -1270,6 +1458,28 @@ type Statistics struct {
 	AverageLeadTime         float64 `json:"average_lead_time_hours"`
 }
 
+// TextField names the issue text a TextTerm is matched against.
+type TextField string
+
+// Text fields searchable by TextTerm. TextFieldAny matches any of them, or
+// the issue ID.
+const (
+	TextFieldAny         TextField = ""
+	TextFieldTitle       TextField = "title"
+	TextFieldDescription TextField = "description"
+	TextFieldDesign      TextField = "design"
+	TextFieldNotes       TextField = "notes"
+	TextFieldAcceptance  TextField = "acceptance"
+	TextFieldComments    TextField = "comments"
+)
+
+// TextTerm is one case-insensitive substring a search result must contain,
+// optionally scoped to a single field.
+type TextTerm struct {
+	Field TextField
+	Text  string
+}
+
 // IssueFilter is used to filter issue queries
 type IssueFilter struct {
 	Status        *Status
@@ -1277,6 +1487,8 @@ type IssueFilter struct {
 	Priority      *int
 	IssueType     *IssueType
 	Assignee      *string
+	Assignees     []string // OR semantics: assigned to any of these
+	Team          *string  // Owned by this team (issue_teams)
 	Labels        []string // AND semantics: issue must have ALL these labels
 	LabelsAny     []string // OR semantics: issue must have AT LEAST ONE of these labels
 	ExcludeLabels []string // Exclusion: issue must NOT have ANY of these labels
@@ -1293,7 +1505,8 @@ type IssueFilter struct {
 	DescriptionContains string
 	NotesContains       string
 	ExternalRefContains string
-	ExternalRef         *string // exact match on external_ref
+	ExternalRef         *string    // exact match on external_ref
+	TextTerms           []TextTerm // every term must match (bd search text queries)
 
 	// Date ranges
 	CreatedAfter  *time.Time
@@ -1346,6 +1559,11 @@ type IssueFilter struct {
 	// column alone is not a filter; this optional predicate makes it one.
 	IsBlocked *bool // nil = any, true = only is_blocked, false = only unblocked
 
+	// Dependency filtering: blocks edges to or from specific issues. Each
+	// list has AND semantics.
+	BlocksIDs    []string // issue blocks every one of these issues
+	BlockedByIDs []string // issue is blocked by every one of these issues
+
 	// Template filtering
 	IsTemplate *bool // Filter by template flag (nil = any, true = only templates, false = exclude templates)
 
@@ -1488,6 +1706,10 @@ type WorkFilter struct {
 	// When Type is set, ExcludeTypes is ignored (explicit type inclusion wins).
 	ExcludeTypes []IssueType
 
+	// ID exclusion: issues the caller knows are not ready for reasons the
+	// store cannot see, e.g. a blocker in another workspace (bd ready).
+	ExcludeIDs []string
+
 	// Metadata field filtering (GH#1406)
 	MetadataFields map[string]string // Top-level key=value equality; AND semantics (all must match)
 	HasMetadataKey string            // Existence check: issue has this top-level key set (non-null)
@@ -1539,12 +1761,18 @@ type WispFilter struct {
 	Limit int
 }
 
-// EpicStatus represents an epic with its completion status
+// EpicStatus represents an epic with its completion status.
+// BlockedChildren counts open children that have status blocked or an open
+// blocking dependency. ProjectedFinish extrapolates the close rate of the
+// children so far; it is nil until at least one child has closed.
 type EpicStatus struct {
-	Epic             *Issue `json:"epic"`
-	TotalChildren    int    `json:"total_children"`
-	ClosedChildren   int    `json:"closed_children"`
-	EligibleForClose bool   `json:"eligible_for_close"`
+	Epic               *Issue     `json:"epic"`
+	TotalChildren      int        `json:"total_children"`
+	ClosedChildren     int        `json:"closed_children"`
+	InProgressChildren int        `json:"in_progress_children"`
+	BlockedChildren    int        `json:"blocked_children"`
+	EligibleForClose   bool       `json:"eligible_for_close"`
+	ProjectedFinish    *time.Time `json:"projected_finish,omitempty"`
 }
 
 // BondRef tracks compound molecule lineage.
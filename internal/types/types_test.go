@@ -1093,6 +1093,32 @@ func TestComputeContentHash(t *testing.T) {
 	}
 }
 
+func TestVersionToken(t *testing.T) {
+	read := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	base := Issue{ID: "test-1", Title: "Test Issue", Labels: []string{"b", "a"}, UpdatedAt: read, RowVersion: 42}
+	version := base.VersionToken()
+
+	same := base
+	same.Labels = []string{"a", "b"}
+	if got := same.VersionToken(); got != version {
+		t.Errorf("label order changed the version: %s != %s", got, version)
+	}
+
+	for name, mutate := range map[string]func(*Issue){
+		"title":       func(i *Issue) { i.Title = "Other" },
+		"label":       func(i *Issue) { i.Labels = []string{"a"} },
+		"updated_at":  func(i *Issue) { i.UpdatedAt = read.Add(time.Millisecond) },
+		"row_version": func(i *Issue) { i.RowVersion = 43 },
+		"defer_until": func(i *Issue) { i.DeferUntil = &read },
+	} {
+		changed := base
+		mutate(&changed)
+		if changed.VersionToken() == version {
+			t.Errorf("changing %s kept the version", name)
+		}
+	}
+}
+
 func TestSortPolicyIsValid(t *testing.T) {
 	tests := []struct {
 		policy SortPolicy
@@ -1837,3 +1863,38 @@ func TestCheckFieldLen(t *testing.T) {
 		t.Errorf("CheckFieldLen(256 runes) = %v, want errors.Is(ErrFieldTooLong)", err)
 	}
 }
+
+func TestParseStatusTransitions(t *testing.T) {
+	rules, err := ParseStatusTransitions("open>in_progress, in_review>closed:no-blockers,*>deferred")
+	if err != nil {
+		t.Fatalf("ParseStatusTransitions: %v", err)
+	}
+	want := []StatusTransition{
+		{From: "open", To: "in_progress"},
+		{From: "in_review", To: "closed", Guard: TransitionGuardNoBlockers},
+		{From: "*", To: "deferred"},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("got %d rules, want %d: %+v", len(rules), len(want), rules)
+	}
+	for i := range want {
+		if rules[i] != want[i] {
+			t.Errorf("rule %d = %+v, want %+v", i, rules[i], want[i])
+		}
+	}
+	if rules[1].String() != "in_review>closed:no-blockers" {
+		t.Errorf("String() = %q", rules[1].String())
+	}
+	if !rules[2].Matches(StatusInProgress, StatusDeferred) || rules[2].Matches(StatusOpen, StatusClosed) {
+		t.Error("wildcard rule matched incorrectly")
+	}
+
+	if rules, err := ParseStatusTransitions(""); err != nil || rules != nil {
+		t.Errorf("empty value = %v, %v; want nil, nil", rules, err)
+	}
+	for _, bad := range []string{"open", "open>Closed", "open>closed:approved"} {
+		if _, err := ParseStatusTransitions(bad); err == nil {
+			t.Errorf("ParseStatusTransitions(%q) should fail", bad)
+		}
+	}
+}
@@ -11,6 +11,21 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
+// resolveAlias looks input up in the store's alias table, for stores that
+// have one.
+func resolveAlias(ctx context.Context, store storage.Storage, input string) (string, bool) {
+	var s any = store
+	if ds, ok := store.(storage.DoltStorage); ok {
+		s = storage.UnwrapStore(ds)
+	}
+	aliasStore, ok := s.(storage.AliasStore)
+	if !ok {
+		return "", false
+	}
+	id, err := aliasStore.ResolveIssueAlias(ctx, input)
+	return id, err == nil
+}
+
 // parseIssueID ensures an issue ID has the configured prefix.
 // If the input already has the prefix (e.g., "bd-a3f8e9"), returns it as-is.
 // If the input lacks the prefix (e.g., "a3f8e9"), adds the configured prefix.
@@ -33,6 +48,9 @@ func parseIssueID(input string, prefix string) string {
 // - Without hyphen: "bda3f8e9" or "wya3f8e9" → "bd-a3f8e9"
 // - Partial IDs: "a3f8" → "bd-a3f8e9" (if unique match)
 // - Hierarchical: "a3f8e9.1" → "bd-a3f8e9.1"
+// - Aliases: "PROJ-123" or a pre-rename ID → the issue carrying that alias
+//
+// An alias is only matched exactly, and takes precedence over partial matches.
 //
 // Returns an error if:
 // - No issue found matching the ID
@@ -51,6 +69,10 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 		return issues[0].ID, nil
 	}
 
+	if id, ok := resolveAlias(ctx, store, input); ok {
+		return id, nil
+	}
+
 	// Get the configured prefix
 	prefix, err := store.GetConfig(ctx, "issue_prefix")
 	if err != nil || prefix == "" {